@@ -4,10 +4,13 @@ package integration
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
@@ -92,7 +95,7 @@ func TestRepository(t *testing.T) {
 				input:   "testdata/fixtures/repo/gomod",
 				vex:     "repo",
 			},
-			golden: "testdata/gomod-vex.json.golden",
+			golden: "testdata/gomod-vex-repo.json.golden",
 		},
 		{
 			name: "npm",
@@ -551,3 +554,63 @@ func TestRepository(t *testing.T) {
 		})
 	}
 }
+
+// TestFilesystemReadOnly tests that `trivy fs --read-only` leaves the target directory unmodified
+func TestFilesystemReadOnly(t *testing.T) {
+	// Set up testing DB
+	cacheDir := initDB(t)
+
+	// Set a temp dir so that modules will not be loaded
+	t.Setenv("XDG_DATA_HOME", cacheDir)
+
+	target := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(target, "app"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(target, "app", "requirements.txt"), []byte("click==8.0.0\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(target, "deploy.sh"), []byte("export AWS_ACCESS_KEY_ID=AKIAABCDEFGHI1234567\n"), 0o644))
+	before := snapshotDir(t, target)
+
+	osArgs := []string{
+		"-q",
+		"--cache-dir",
+		cacheDir,
+		"fs",
+		"--skip-db-update",
+		"--skip-policy-update",
+		"--offline-scan",
+		"--scanners",
+		"vuln,secret",
+		"--format",
+		"json",
+		"--read-only",
+	}
+
+	// The report would be written into the target
+	err := execute(append(osArgs, "--output", filepath.Join(target, "report.json"), target))
+	require.ErrorContains(t, err, "is under the scan target")
+
+	outputFile := filepath.Join(t.TempDir(), "report.json")
+	err = execute(append(osArgs, "--output", outputFile, target))
+	require.NoError(t, err)
+
+	report := readReport(t, outputFile)
+	assert.NotEmpty(t, report.Results)
+	assert.Equal(t, before, snapshotDir(t, target))
+}
+
+// snapshotDir returns the mode, size and modification time of each file and directory under the root
+func snapshotDir(t *testing.T, root string) map[string]string {
+	snapshot := make(map[string]string)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		snapshot[path] = fmt.Sprintf("%s %d %s", fi.Mode(), fi.Size(), fi.ModTime())
+		return nil
+	})
+	require.NoError(t, err)
+	return snapshot
+}
@@ -0,0 +1,197 @@
+package baseline
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const (
+	// statement is the statement of the vulnerabilities hidden as known in the baseline
+	statement = "Known in the baseline"
+
+	// unchangedStatement is the statement of the misconfigurations hidden as failing in the baseline as well
+	unchangedStatement = "Failing in the baseline"
+)
+
+// key identifies a vulnerability across reports.
+// PkgID is empty in some results, such as old reports, and the name and the version are used instead.
+type key struct {
+	target          string
+	pkgID           string
+	vulnerabilityID string
+}
+
+func newKey(target string, vuln types.DetectedVulnerability) key {
+	pkgID := vuln.PkgID
+	if pkgID == "" {
+		pkgID = vuln.PkgName + "@" + vuln.InstalledVersion
+	}
+	return key{
+		target:          target,
+		pkgID:           pkgID,
+		vulnerabilityID: vuln.VulnerabilityID,
+	}
+}
+
+// misconfKey identifies a check on a resource across reports.
+// The line is not a part of the key so that edits elsewhere in the file don't make the failure new.
+type misconfKey struct {
+	target   string
+	id       string
+	resource string
+}
+
+func newMisconfKey(target string, misconf types.DetectedMisconfiguration) misconfKey {
+	id := misconf.AVDID
+	if id == "" {
+		id = misconf.ID
+	}
+	return misconfKey{
+		target:   target,
+		id:       id,
+		resource: misconf.CauseMetadata.Resource,
+	}
+}
+
+// Baseline holds the vulnerabilities and the failed misconfigurations of a prior report, e.g. the report of the last CI run,
+// so that new findings can be told from the known ones.
+type Baseline struct {
+	path     string
+	known    map[key]struct{}
+	failures map[string][]types.DetectedMisconfiguration // Failed misconfigurations by the target
+}
+
+// Load reads the baseline from the JSON report generated by "trivy --format json".
+func Load(path string) (*Baseline, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to open the baseline file: %w", err)
+	}
+	defer f.Close()
+
+	var report types.Report
+	if err = json.NewDecoder(f).Decode(&report); err != nil {
+		return nil, xerrors.Errorf("failed to decode the baseline file: %w", err)
+	}
+
+	b := &Baseline{
+		path:     path,
+		known:    make(map[key]struct{}),
+		failures: make(map[string][]types.DetectedMisconfiguration),
+	}
+	for _, result := range report.Results {
+		for _, vuln := range result.Vulnerabilities {
+			b.known[newKey(result.Target, vuln)] = struct{}{}
+		}
+		for _, misconf := range result.Misconfigurations {
+			if misconf.Status == types.MisconfStatusFailure {
+				b.failures[result.Target] = append(b.failures[result.Target], misconf)
+			}
+		}
+	}
+	return b, nil
+}
+
+// Apply marks the vulnerabilities in the results as new or known in the baseline.
+// With hideKnown, the known vulnerabilities are hidden as ignored findings instead,
+// so that only new vulnerabilities are reported and fail "--exit-code".
+func (b *Baseline) Apply(results types.Results, hideKnown bool) {
+	for i := range results {
+		result := &results[i]
+		for j := range result.Vulnerabilities {
+			v := &result.Vulnerabilities[j]
+			v.BaselineStatus = types.BaselineStatusNew
+			if _, ok := b.known[newKey(result.Target, *v)]; ok {
+				v.BaselineStatus = types.BaselineStatusKnown
+			}
+		}
+		if !hideKnown {
+			continue
+		}
+
+		result.Vulnerabilities = slices.DeleteFunc(result.Vulnerabilities, func(v types.DetectedVulnerability) bool {
+			if v.BaselineStatus != types.BaselineStatusKnown {
+				return false
+			}
+			result.ModifiedFindings = append(result.ModifiedFindings,
+				types.NewModifiedFinding(v, types.FindingStatusIgnored, statement, b.path))
+			return true
+		})
+	}
+}
+
+// DiffMisconfigurations leaves only the misconfigurations whose status changed since the baseline.
+// Checks newly failing are marked as new, and checks failing in the baseline but not anymore are marked as resolved.
+// Failures unchanged since the baseline are hidden as ignored findings so that they don't fail "--exit-code".
+// Failures in the baseline missing in the results, e.g. passed checks without "--include-non-failures", are added as passed.
+// The misconfiguration summary is recomputed so that it counts only the changed checks.
+func (b *Baseline) DiffMisconfigurations(results types.Results) {
+	for i := range results {
+		result := &results[i]
+		if result.Class != types.ClassConfig {
+			continue
+		}
+
+		failed := make(map[misconfKey]struct{})
+		for _, misconf := range b.failures[result.Target] {
+			failed[newMisconfKey(result.Target, misconf)] = struct{}{}
+		}
+
+		var misconfs []types.DetectedMisconfiguration
+		seen := make(map[misconfKey]struct{})
+		for _, misconf := range result.Misconfigurations {
+			k := newMisconfKey(result.Target, misconf)
+			seen[k] = struct{}{}
+			_, failedBefore := failed[k]
+			failing := misconf.Status == types.MisconfStatusFailure
+			switch {
+			case failing && !failedBefore:
+				misconf.BaselineStatus = types.BaselineStatusNew
+			case !failing && failedBefore:
+				misconf.BaselineStatus = types.BaselineStatusResolved
+			case failing:
+				result.ModifiedFindings = append(result.ModifiedFindings,
+					types.NewModifiedFinding(misconf, types.FindingStatusIgnored, unchangedStatement, b.path))
+				continue
+			default:
+				// Passed in both
+				continue
+			}
+			misconfs = append(misconfs, misconf)
+		}
+
+		for _, misconf := range b.failures[result.Target] {
+			k := newMisconfKey(result.Target, misconf)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			misconf.Status = types.MisconfStatusPassed
+			misconf.BaselineStatus = types.BaselineStatusResolved
+			misconfs = append(misconfs, misconf)
+		}
+		result.Misconfigurations = misconfs
+		result.MisconfSummary = summarize(misconfs)
+	}
+}
+
+// summarize counts the statuses of the misconfigurations
+func summarize(misconfs []types.DetectedMisconfiguration) *types.MisconfSummary {
+	summary := new(types.MisconfSummary)
+	for _, misconf := range misconfs {
+		switch misconf.Status {
+		case types.MisconfStatusFailure:
+			summary.Failures++
+		case types.MisconfStatusPassed:
+			summary.Successes++
+		case types.MisconfStatusException:
+			summary.Exceptions++
+		}
+	}
+	return summary
+}
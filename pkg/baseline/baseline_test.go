@@ -0,0 +1,263 @@
+package baseline_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/baseline"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{
+			name: "happy path",
+			path: "testdata/baseline.json",
+		},
+		{
+			name:    "invalid JSON",
+			path:    "testdata/invalid.json",
+			wantErr: "failed to decode the baseline file",
+		},
+		{
+			name:    "missing file",
+			path:    "testdata/missing.json",
+			wantErr: "failed to open the baseline file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := baseline.Load(tt.path)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestBaseline_Apply(t *testing.T) {
+	results := func() types.Results {
+		return types.Results{
+			{
+				Target: "alpine:3.20 (alpine 3.20.0)",
+				Class:  types.ClassOSPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2024-0001",
+						PkgID:            "musl@1.2.5-r0",
+						PkgName:          "musl",
+						InstalledVersion: "1.2.5-r0",
+					},
+					{
+						// New vulnerability
+						VulnerabilityID:  "CVE-2024-0002",
+						PkgID:            "busybox@1.36.1-r28",
+						PkgName:          "busybox",
+						InstalledVersion: "1.36.1-r28",
+					},
+				},
+			},
+			{
+				Target: "app/package-lock.json",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						// Matched by the name and the version as the baseline has no PkgID
+						VulnerabilityID:  "CVE-2024-0003",
+						PkgName:          "lodash",
+						InstalledVersion: "4.17.20",
+					},
+				},
+			},
+			{
+				// The same vulnerability in another target is new
+				Target: "web/package-lock.json",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2024-0003",
+						PkgName:          "lodash",
+						InstalledVersion: "4.17.20",
+					},
+				},
+			},
+		}
+	}
+
+	b, err := baseline.Load("testdata/baseline.json")
+	require.NoError(t, err)
+
+	t.Run("mark", func(t *testing.T) {
+		got := results()
+		b.Apply(got, false)
+
+		var statuses []types.BaselineStatus
+		for _, result := range got {
+			assert.Empty(t, result.ModifiedFindings)
+			for _, vuln := range result.Vulnerabilities {
+				statuses = append(statuses, vuln.BaselineStatus)
+			}
+		}
+		assert.Equal(t, []types.BaselineStatus{
+			types.BaselineStatusKnown,
+			types.BaselineStatusNew,
+			types.BaselineStatusKnown,
+			types.BaselineStatusNew,
+		}, statuses)
+	})
+
+	t.Run("hide known", func(t *testing.T) {
+		got := results()
+		b.Apply(got, true)
+
+		var vulnIDs, hidden []string
+		for _, result := range got {
+			for _, vuln := range result.Vulnerabilities {
+				assert.Equal(t, types.BaselineStatusNew, vuln.BaselineStatus)
+				vulnIDs = append(vulnIDs, result.Target+": "+vuln.VulnerabilityID)
+			}
+			for _, finding := range result.ModifiedFindings {
+				assert.Equal(t, types.FindingStatusIgnored, finding.Status)
+				assert.Equal(t, "testdata/baseline.json", finding.Source)
+				hidden = append(hidden, result.Target+": "+finding.Finding.(types.DetectedVulnerability).VulnerabilityID)
+			}
+		}
+		assert.Equal(t, []string{
+			"alpine:3.20 (alpine 3.20.0): CVE-2024-0002",
+			"web/package-lock.json: CVE-2024-0003",
+		}, vulnIDs)
+		assert.Equal(t, []string{
+			"alpine:3.20 (alpine 3.20.0): CVE-2024-0001",
+			"app/package-lock.json: CVE-2024-0003",
+		}, hidden)
+	})
+}
+
+func TestBaseline_DiffMisconfigurations(t *testing.T) {
+	results := types.Results{
+		{
+			Target: "Dockerfile",
+			Class:  types.ClassConfig,
+			MisconfSummary: &types.MisconfSummary{
+				Successes: 2,
+				Failures:  3,
+			},
+			Misconfigurations: []types.DetectedMisconfiguration{
+				{
+					// Failing in the baseline as well
+					AVDID:  "AVD-DS-0002",
+					Status: types.MisconfStatusFailure,
+					CauseMetadata: ftypes.CauseMetadata{
+						Resource:  "app",
+						StartLine: 10, // Moved
+					},
+				},
+				{
+					// Passed in the baseline, and fails now
+					AVDID:  "AVD-DS-0001",
+					Status: types.MisconfStatusFailure,
+					CauseMetadata: ftypes.CauseMetadata{
+						Resource: "app",
+					},
+				},
+				{
+					// Failed in the baseline, and passes now
+					AVDID:  "AVD-DS-0026",
+					Status: types.MisconfStatusPassed,
+					CauseMetadata: ftypes.CauseMetadata{
+						Resource: "app",
+					},
+				},
+				{
+					// Passed in both
+					AVDID:  "AVD-DS-0013",
+					Status: types.MisconfStatusPassed,
+					CauseMetadata: ftypes.CauseMetadata{
+						Resource: "app",
+					},
+				},
+				{
+					// The same check failing on another resource is new
+					AVDID:  "AVD-DS-0002",
+					Status: types.MisconfStatusFailure,
+					CauseMetadata: ftypes.CauseMetadata{
+						Resource: "builder",
+					},
+				},
+				// AVD-DS-0005 failed in the baseline, and is missing as passed checks are not included
+			},
+		},
+		{
+			// Not a misconfiguration result
+			Target: "Dockerfile",
+			Class:  types.ClassSecret,
+		},
+	}
+
+	b, err := baseline.Load("testdata/baseline.json")
+	require.NoError(t, err)
+	b.DiffMisconfigurations(results)
+
+	type status struct {
+		ID       string
+		Resource string
+		Status   types.MisconfStatus
+		Baseline types.BaselineStatus
+	}
+	var got []status
+	for _, misconf := range results[0].Misconfigurations {
+		got = append(got, status{
+			ID:       misconf.AVDID,
+			Resource: misconf.CauseMetadata.Resource,
+			Status:   misconf.Status,
+			Baseline: misconf.BaselineStatus,
+		})
+	}
+	assert.Equal(t, []status{
+		{
+			ID:       "AVD-DS-0001",
+			Resource: "app",
+			Status:   types.MisconfStatusFailure,
+			Baseline: types.BaselineStatusNew,
+		},
+		{
+			ID:       "AVD-DS-0026",
+			Resource: "app",
+			Status:   types.MisconfStatusPassed,
+			Baseline: types.BaselineStatusResolved,
+		},
+		{
+			ID:       "AVD-DS-0002",
+			Resource: "builder",
+			Status:   types.MisconfStatusFailure,
+			Baseline: types.BaselineStatusNew,
+		},
+		{
+			ID:       "AVD-DS-0005",
+			Resource: "app",
+			Status:   types.MisconfStatusPassed,
+			Baseline: types.BaselineStatusResolved,
+		},
+	}, got)
+	assert.Equal(t, &types.MisconfSummary{
+		Successes: 2,
+		Failures:  2,
+	}, results[0].MisconfSummary)
+
+	// The unchanged failure is hidden
+	require.Len(t, results[0].ModifiedFindings, 1)
+	hidden := results[0].ModifiedFindings[0]
+	assert.Equal(t, types.FindingStatusIgnored, hidden.Status)
+	assert.Equal(t, "AVD-DS-0002", hidden.Finding.(types.DetectedMisconfiguration).AVDID)
+
+	assert.Empty(t, results[1].Misconfigurations)
+}
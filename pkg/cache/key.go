@@ -32,6 +32,7 @@ func CalcKey(id string, analyzerVersions analyzer.Versions, hookVersions map[str
 		SkipDirs          []string
 		FilePatterns      []string                `json:",omitempty"`
 		DetectionPriority types.DetectionPriority `json:",omitempty"`
+		SecretEntropy     bool                    `json:",omitempty"`
 	}{
 		id,
 		analyzerVersions,
@@ -40,6 +41,7 @@ func CalcKey(id string, analyzerVersions analyzer.Versions, hookVersions map[str
 		artifactOpt.WalkerOption.SkipDirs,
 		artifactOpt.FilePatterns,
 		artifactOpt.DetectionPriority,
+		artifactOpt.SecretScannerOption.Entropy,
 	}
 
 	if err := json.NewEncoder(h).Encode(keyBase); err != nil {
@@ -24,6 +24,7 @@ func TestCalcKey(t *testing.T) {
 		policy            []string
 		data              []string
 		secretConfigPath  string
+		secretEntropy     bool
 		detectionPriority types.DetectionPriority
 	}
 	tests := []struct {
@@ -48,6 +49,24 @@ func TestCalcKey(t *testing.T) {
 			},
 			want: "sha256:c720b502991465ea11929cfefc71cf4b5aeaa9a8c0ae59fdaf597f957f5cdb18",
 		},
+		{
+			name: "secret entropy",
+			args: args{
+				key: "sha256:5c534be56eca62e756ef2ef51523feda0f19cd7c15bb0c015e3d6e3ae090bf6e",
+				analyzerVersions: analyzer.Versions{
+					Analyzers: map[string]int{
+						"alpine": 1,
+						"debian": 1,
+					},
+				},
+				hookVersions: map[string]int{
+					"python-pkg": 1,
+				},
+				secretConfigPath: "trivy-secret.yaml",
+				secretEntropy:    true,
+			},
+			want: "sha256:e390e59dd67ec7f141cd2037a428108564fddf279b1ed316210224d485e78e0a",
+		},
 		{
 			name: "with disabled analyzer",
 			args: args{
@@ -266,6 +285,7 @@ func TestCalcKey(t *testing.T) {
 
 				SecretScannerOption: analyzer.SecretScannerOption{
 					ConfigPath: tt.args.secretConfigPath,
+					Entropy:    tt.args.secretEntropy,
 				},
 
 				WalkerOption: walker.Option{
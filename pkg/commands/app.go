@@ -276,6 +276,7 @@ func NewImageCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	imageFlags.PackageFlagGroup.IncludeDevDeps = nil          // disable '--include-dev-deps'
 	imageFlags.MisconfFlagGroup.CloudformationParamVars = nil // disable '--cf-params'
 	imageFlags.MisconfFlagGroup.TerraformTFVars = nil         // disable '--tf-vars'
+	imageFlags.MisconfFlagGroup.ShowCode = nil                // disable '--show-code'
 
 	cmd := &cobra.Command{
 		Use:     "image [flags] IMAGE_NAME",
@@ -355,6 +356,12 @@ func NewFilesystemCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	fsFlags.CacheFlagGroup.CacheBackend.Default = string(cache.TypeMemory)                           // Use memory cache by default
 	fsFlags.ReportFlagGroup.ReportFormat.Usage = "specify a compliance report format for the output" // @TODO: support --report summary for non compliance reports
 	fsFlags.ReportFlagGroup.ExitOnEOL = nil                                                          // disable '--exit-on-eol'
+	fsFlags.SecretFlagGroup.ScanGitHistory = flag.ScanGitHistoryFlag.Clone()
+	fsFlags.SecretFlagGroup.GitHistoryDepth = flag.GitHistoryDepthFlag.Clone()
+	fsFlags.ScanFlagGroup.MaxTargets = flag.MaxTargetsFlag.Clone()
+	fsFlags.ScanFlagGroup.IncludeFileHashes = flag.IncludeFileHashesFlag.Clone()
+	fsFlags.ScanFlagGroup.ReadOnly = flag.ReadOnlyFlag.Clone()
+	fsFlags.ScanFlagGroup.Diff = flag.DiffFlag.Clone()
 
 	cmd := &cobra.Command{
 		Use:     "filesystem [flags] PATH",
@@ -365,7 +372,10 @@ func NewFilesystemCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
   $ trivy fs /path/to/your_project
 
   # Scan a single file
-  $ trivy fs ./trivy-ci-test/Pipfile.lock`,
+  $ trivy fs ./trivy-ci-test/Pipfile.lock
+
+  # Report the findings changed between two snapshots
+  $ trivy fs --diff /path/to/before /path/to/after`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if err := fsFlags.Bind(cmd); err != nil {
 				return xerrors.Errorf("flag bind error: %w", err)
@@ -519,6 +529,7 @@ func NewConvertCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 		ScanFlagGroup:   &flag.ScanFlagGroup{},
 		ReportFlagGroup: flag.NewReportFlagGroup(),
 	}
+	convertFlags.ReportFlagGroup.FailOnEmpty = nil // disable '--fail-on-empty' as JSON reports don't always include packages
 
 	cmd := &cobra.Command{
 		Use:     "convert [flags] RESULT_JSON",
@@ -977,6 +988,37 @@ func NewKubernetesCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	}
 	reportFlagGroup.Compliance = compliance // override usage as the accepted values differ for each subcommand.
 	reportFlagGroup.ExitOnEOL = nil         // disable '--exit-on-eol'
+	reportFlagGroup.ShowEPSS = nil          // disable '--show-epss'
+	reportFlagGroup.EPSSSource = nil        // disable '--epss-source'
+	reportFlagGroup.SortBy = nil            // disable '--sort-by'
+	reportFlagGroup.FailOnEmpty = nil       // disable '--fail-on-empty'
+	reportFlagGroup.LabelsFile = nil        // disable '--labels-file'
+	reportFlagGroup.FocusCVE = nil          // disable '--focus-cve'
+	reportFlagGroup.RemediationPlan = nil   // disable '--remediation-plan'
+	reportFlagGroup.ValidateOutput = nil    // disable '--validate-output'
+	reportFlagGroup.SLA = nil               // disable '--sla'
+	reportFlagGroup.FailOnSLABreach = nil   // disable '--fail-on-sla-breach'
+	reportFlagGroup.LicenseAllow = nil      // disable '--license-allow'
+	reportFlagGroup.LicenseDeny = nil       // disable '--license-deny'
+	reportFlagGroup.LicenseUnknown = nil    // disable '--license-unknown-verdict'
+	reportFlagGroup.FailOnLicense = nil     // disable '--fail-on-license-violation'
+	reportFlagGroup.OutputDir = nil         // disable '--output-dir'
+	reportFlagGroup.SPDXRelationships = nil // disable '--spdx-relationships-only'
+	reportFlagGroup.ShowCleanSummary = nil  // disable '--show-clean-summary'
+	reportFlagGroup.ShowAffectedRange = nil // disable '--show-affected-range'
+	reportFlagGroup.ShowKEV = nil           // disable '--show-kev'
+	reportFlagGroup.KEVOnly = nil           // disable '--kev-only'
+	reportFlagGroup.KEVSource = nil         // disable '--kev-source'
+	reportFlagGroup.ShowKEVDue = nil        // disable '--show-kev-due'
+	reportFlagGroup.Interactive = nil       // disable '--interactive'
+	reportFlagGroup.SeverityLabels = nil    // disable '--severity-labels'
+	reportFlagGroup.ShowFilteredCount = nil // disable '--show-filtered-count'
+	reportFlagGroup.Banner = nil            // disable '--banner'
+	reportFlagGroup.BaselineFile = nil      // disable '--baseline-file'
+	reportFlagGroup.HideKnown = nil         // disable '--hide-known'
+	reportFlagGroup.MisconfigDiff = nil     // disable '--misconfig-diff'
+	reportFlagGroup.TrendFile = nil         // disable '--trend-file'
+	reportFlagGroup.Scorecard = nil         // disable '--scorecard'
 
 	formatFlag := flag.FormatFlag.Clone()
 	formatFlag.Values = xstrings.ToStringSlice([]types.Format{
@@ -989,6 +1031,7 @@ func NewKubernetesCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	misconfFlagGroup := flag.NewMisconfFlagGroup()
 	misconfFlagGroup.CloudformationParamVars = nil // disable '--cf-params'
 	misconfFlagGroup.TerraformTFVars = nil         // disable '--tf-vars'
+	misconfFlagGroup.ShowCode = nil                // disable '--show-code'
 
 	k8sFlags := &flag.Flags{
 		GlobalFlagGroup:        globalFlags,
@@ -1076,6 +1119,7 @@ func NewVMCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	vmFlags.PackageFlagGroup.IncludeDevDeps = nil          // disable '--include-dev-deps'
 	vmFlags.MisconfFlagGroup.CloudformationParamVars = nil // disable '--cf-params'
 	vmFlags.MisconfFlagGroup.TerraformTFVars = nil         // disable '--tf-vars'
+	vmFlags.MisconfFlagGroup.ShowCode = nil                // disable '--show-code'
 
 	cmd := &cobra.Command{
 		Use:     "vm [flags] VM_IMAGE",
@@ -1433,7 +1477,7 @@ func validateArgs(cmd *cobra.Command, args []string) error {
 			return xerrors.New(`Require at least 1 argument or --input option`)
 		}
 		return xerrors.New(`Require at least 1 argument`)
-	} else if cmd.Name() != "kubernetes" && len(args) > 1 {
+	} else if cmd.Name() != "kubernetes" && len(args) > 1 && !diffArgs(cmd, args) {
 		if err := cmd.Help(); err != nil {
 			return err
 		}
@@ -1443,6 +1487,11 @@ func validateArgs(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// diffArgs returns whether the two paths are given to compare with '--diff'
+func diffArgs(cmd *cobra.Command, args []string) bool {
+	return cmd.Flags().Lookup(flag.DiffFlag.Name) != nil && viper.GetBool(flag.DiffFlag.ConfigName) && len(args) == 2
+}
+
 // show help on using the command when an invalid flag is encountered
 func flagErrorFunc(command *cobra.Command, err error) error {
 	if err := command.Help(); err != nil {
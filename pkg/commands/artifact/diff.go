@@ -0,0 +1,60 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/commands/operation"
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/log"
+	pkgReport "github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// runDiff scans the two paths given with "--diff" and writes the findings and the packages changed between them.
+// With "--exit-code", it exits with the code if any finding is added in the latter path.
+func runDiff(ctx context.Context, r Runner, opts flag.Options) error {
+	// Packages are required to detect the version changes
+	opts.ListAllPkgs = true
+
+	before, err := scanSnapshot(ctx, r, opts, opts.Target)
+	if err != nil {
+		return err
+	}
+	after, err := scanSnapshot(ctx, r, opts, opts.DiffTarget)
+	if err != nil {
+		return err
+	}
+
+	diff, err := pkgReport.DiffReports(before, after)
+	if err != nil {
+		return xerrors.Errorf("diff error: %w", err)
+	}
+	if err = pkgReport.WriteDiff(ctx, diff, opts); err != nil {
+		return xerrors.Errorf("unable to write the diff: %w", err)
+	}
+
+	if added := diff.Added(); opts.ExitCode != 0 && added > 0 {
+		log.InfoContext(ctx, "Findings were added", log.String("before", opts.Target),
+			log.String("after", opts.DiffTarget), log.Int("count", added))
+		operation.ExplainExit(opts, fmt.Sprintf("added=%d threshold=--exit-code", added))
+		return &types.ExitError{Code: opts.ExitCode}
+	}
+	return nil
+}
+
+// scanSnapshot scans and filters one of the paths compared with "--diff".
+func scanSnapshot(ctx context.Context, r Runner, opts flag.Options, target string) (types.Report, error) {
+	opts.Target = target
+	report, err := r.ScanFilesystem(ctx, opts)
+	if err != nil {
+		return types.Report{}, xerrors.Errorf("%s scan error: %w", target, err)
+	}
+	report, err = r.Filter(ctx, opts, report)
+	if err != nil {
+		return types.Report{}, xerrors.Errorf("filter error: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,139 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/flag"
+	pkgReport "github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// snapshotRunner returns the report of each snapshot instead of scanning the path.
+type snapshotRunner struct {
+	Runner
+	t         *testing.T
+	snapshots map[string]types.Report
+}
+
+func (r snapshotRunner) ScanFilesystem(_ context.Context, opts flag.Options) (types.Report, error) {
+	assert.True(r.t, opts.ListAllPkgs, "packages must be listed to detect version changes")
+	return r.snapshots[opts.Target], nil
+}
+
+func (r snapshotRunner) Filter(_ context.Context, _ flag.Options, report types.Report) (types.Report, error) {
+	return report, nil
+}
+
+func Test_runDiff(t *testing.T) {
+	snapshot := func(name, version string, vulnIDs ...string) types.Report {
+		result := types.Result{
+			Target: "app/package-lock.json",
+			Class:  types.ClassLangPkg,
+			Type:   ftypes.Npm,
+			Packages: []ftypes.Package{
+				{
+					ID:      name + "@" + version,
+					Name:    name,
+					Version: version,
+				},
+			},
+		}
+		for _, id := range vulnIDs {
+			result.Vulnerabilities = append(result.Vulnerabilities, types.DetectedVulnerability{
+				VulnerabilityID:  id,
+				PkgID:            name + "@" + version,
+				PkgName:          name,
+				InstalledVersion: version,
+				Vulnerability:    dbTypes.Vulnerability{Severity: "HIGH"},
+			})
+		}
+		return types.Report{
+			ArtifactName: "snapshots/" + version,
+			Results:      types.Results{result},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		after        types.Report
+		exitCode     int
+		wantFindings []pkgReport.FindingChange
+		wantExitCode int
+	}{
+		{
+			name:         "patch reduced vulnerabilities",
+			after:        snapshot("lodash", "4.17.21"),
+			exitCode:     1,
+			wantFindings: []pkgReport.FindingChange{pkgReport.FindingRemoved},
+		},
+		{
+			name:         "patch added a vulnerability",
+			after:        snapshot("lodash", "4.17.21", "CVE-2021-23337", "CVE-2024-0001"),
+			exitCode:     1,
+			wantFindings: []pkgReport.FindingChange{pkgReport.FindingAdded},
+			wantExitCode: 1,
+		},
+		{
+			name:         "added without --exit-code",
+			after:        snapshot("lodash", "4.17.21", "CVE-2021-23337", "CVE-2024-0001"),
+			wantFindings: []pkgReport.FindingChange{pkgReport.FindingAdded},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := snapshotRunner{
+				t: t,
+				snapshots: map[string]types.Report{
+					"before": snapshot("lodash", "4.17.20", "CVE-2021-23337"),
+					"after":  tt.after,
+				},
+			}
+
+			out := new(bytes.Buffer)
+			opts := flag.Options{
+				ScanOptions: flag.ScanOptions{
+					Target:     "before",
+					Diff:       true,
+					DiffTarget: "after",
+				},
+				ReportOptions: flag.ReportOptions{
+					Format:   types.FormatJSON,
+					ExitCode: tt.exitCode,
+				},
+			}
+			opts.SetOutputWriter(out)
+
+			err := runDiff(context.Background(), r, opts)
+			if tt.wantExitCode != 0 {
+				var exitErr *types.ExitError
+				require.ErrorAs(t, err, &exitErr)
+				assert.Equal(t, tt.wantExitCode, exitErr.Code)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var got pkgReport.Diff
+			require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+			assert.Equal(t, "snapshots/4.17.20", got.Before)
+			assert.Equal(t, "snapshots/4.17.21", got.After)
+
+			var changes []pkgReport.FindingChange
+			for _, f := range got.Findings {
+				changes = append(changes, f.Change)
+			}
+			assert.Equal(t, tt.wantFindings, changes)
+
+			// The upgrade is reported as a version change
+			require.Len(t, got.Packages, 1)
+			assert.Equal(t, pkgReport.PackageVersionChanged, got.Packages[0].Change)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package artifact
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/digest"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact/local"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// addFileHashes sets the SHA-256 digests of the scanned files to the results,
+// so that consumers of the report can verify that they are looking at the same files.
+// Results whose targets are not files, such as OS packages, are left as they are.
+func addFileHashes(ctx context.Context, target string, results types.Results) error {
+	if target == local.StdinPath {
+		log.WarnContext(ctx, `"--include-file-hashes" cannot be used with "-" as the files are not available after the scan`)
+		return nil
+	}
+
+	root := target
+	fi, err := os.Stat(target)
+	if err != nil {
+		return xerrors.Errorf("stat error: %w", err)
+	} else if !fi.IsDir() {
+		// The target of the result is the file name when a single file is scanned
+		root = filepath.Dir(target)
+	}
+
+	// The same file may be the target of multiple results, e.g. vulnerabilities and secrets
+	digests := make(map[string]digest.Digest)
+	for i, result := range results {
+		d, ok := digests[result.Target]
+		if !ok {
+			if d, err = fileDigest(filepath.Join(root, filepath.FromSlash(result.Target))); err != nil {
+				return xerrors.Errorf("failed to calculate the digest of %s: %w", result.Target, err)
+			}
+			digests[result.Target] = d
+		}
+		results[i].FileDigest = d
+	}
+	return nil
+}
+
+// fileDigest returns the SHA-256 digest of the file, or an empty digest if the path is not a regular file.
+// The file is hashed while it is read so that large files are not loaded into memory.
+func fileDigest(filePath string) (digest.Digest, error) {
+	fi, err := os.Stat(filePath)
+	if err != nil || !fi.Mode().IsRegular() {
+		return "", nil
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	return digest.CalcSHA256(f)
+}
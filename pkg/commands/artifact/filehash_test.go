@@ -0,0 +1,111 @@
+package artifact
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/digest"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func Test_addFileHashes(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "app"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app", "requirements.txt"), []byte("Flask==2.0.0\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("API_KEY=xxx\n"), 0o644))
+
+	const (
+		requirementsDigest = digest.Digest("sha256:6841932aaf90891d6c47630ab1d924abb358fabd4e09bab61603fc5d0fbf0e0f")
+		envDigest          = digest.Digest("sha256:b97f8f7301b0eb4041c0b9bf55e28c662f79e712b18e7553f30f632a479599c9")
+	)
+
+	tests := []struct {
+		name    string
+		target  string
+		results types.Results
+		want    []digest.Digest
+	}{
+		{
+			name:   "directory",
+			target: dir,
+			results: types.Results{
+				{
+					Target: "app/requirements.txt",
+					Class:  types.ClassLangPkg,
+					Type:   ftypes.Pip,
+				},
+				{
+					Target: "app/requirements.txt",
+					Class:  types.ClassSecret,
+				},
+				{
+					Target: ".env",
+					Class:  types.ClassSecret,
+				},
+				{
+					// Aggregated packages, not a file
+					Target: "Python",
+					Class:  types.ClassLangPkg,
+					Type:   ftypes.PythonPkg,
+				},
+				{
+					// Directory
+					Target: "app",
+					Class:  types.ClassConfig,
+				},
+			},
+			want: []digest.Digest{
+				requirementsDigest,
+				requirementsDigest,
+				envDigest,
+				"",
+				"",
+			},
+		},
+		{
+			name:   "single file",
+			target: filepath.Join(dir, "app", "requirements.txt"),
+			results: types.Results{
+				{
+					Target: "requirements.txt",
+					Class:  types.ClassLangPkg,
+					Type:   ftypes.Pip,
+				},
+			},
+			want: []digest.Digest{
+				requirementsDigest,
+			},
+		},
+		{
+			name:   "stdin",
+			target: "-",
+			results: types.Results{
+				{
+					Target: ".env",
+					Class:  types.ClassSecret,
+				},
+			},
+			want: []digest.Digest{
+				"",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := addFileHashes(context.Background(), tt.target, tt.results)
+			require.NoError(t, err)
+
+			var got []digest.Digest
+			for _, result := range tt.results {
+				got = append(got, result.FileDigest)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package artifact
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// ociLayoutImages returns the images in the OCI image layout as references that can be scanned as image archives,
+// e.g. "/path/to/layout@sha256:...", or false if the target is not an OCI image layout.
+// An image can be selected by a tag or a digest in the same way as "trivy image --input", e.g. "/path/to/layout:0.0.1",
+// and otherwise all the images listed in "index.json" are returned.
+func ociLayoutImages(target string) ([]string, bool, error) {
+	dir, ref := target, ""
+	if _, err := os.Stat(target); err != nil {
+		// e.g. /path/to/layout@sha256:... or /path/to/layout:0.0.1
+		var found bool
+		if dir, ref, found = strings.Cut(target, "@"); !found {
+			dir, ref, _ = strings.Cut(target, ":")
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "oci-layout")); err != nil {
+		return nil, false, nil
+	}
+	if ref != "" {
+		return []string{target}, true, nil
+	}
+
+	lp, err := layout.FromPath(dir)
+	if err != nil {
+		return nil, true, xerrors.Errorf("unable to open the OCI layout: %w", err)
+	}
+	index, err := lp.ImageIndex()
+	if err != nil {
+		return nil, true, xerrors.Errorf("unable to retrieve index.json: %w", err)
+	}
+	m, err := index.IndexManifest()
+	if err != nil {
+		return nil, true, xerrors.Errorf("invalid index.json: %w", err)
+	}
+	if len(m.Manifests) == 0 {
+		return nil, true, xerrors.New("no image in the OCI layout")
+	}
+
+	// A nested index, e.g. of a multi-platform image, is resolved to its first image when scanned
+	var images []string
+	for _, manifest := range m.Manifests {
+		images = append(images, dir+"@"+manifest.Digest.String())
+	}
+	return images, true, nil
+}
+
+// scanOCILayout scans the images in the OCI image layout instead of the files,
+// and merges the results of multiple images into one report.
+func (r *runner) scanOCILayout(ctx context.Context, opts flag.Options, images []string) (types.Report, error) {
+	var merged types.Report
+	var metadata []types.Metadata
+	for _, image := range images {
+		log.InfoContext(ctx, "Scanning the image in the OCI layout", log.String("image", image))
+		imageOpts := opts
+		imageOpts.Input = image
+		report, err := r.ScanImage(ctx, imageOpts)
+		if err != nil {
+			return types.Report{}, xerrors.Errorf("unable to scan %s: %w", image, err)
+		}
+		if len(images) == 1 {
+			return report, nil
+		}
+
+		metadata = append(metadata, report.Metadata)
+		if merged.ArtifactType == "" {
+			merged = report
+			merged.ArtifactName = opts.Target
+			continue
+		}
+		// The targets are distinguished by the digest, e.g. "/path/to/layout@sha256:... (alpine 3.20.0)"
+		merged.Results = append(merged.Results, report.Results...)
+	}
+	// Only the metadata shared by the images, such as the OS of a multi-platform image, is kept
+	merged.Metadata = sharedMetadata(metadata)
+	return merged, nil
+}
@@ -0,0 +1,70 @@
+package artifact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ociLayoutImages(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		want       []string
+		wantLayout bool
+		wantErr    string
+	}{
+		{
+			name:   "all images",
+			target: "testdata/multi-image.oci",
+			want: []string{
+				"testdata/multi-image.oci@sha256:afb744871f99e0ff8e6f253244836ed34c5d805fdb096d3a205ffaf5e9073cab",
+				"testdata/multi-image.oci@sha256:3d2e482b82608d153a374df3357c0291589a61cc194ec4a9ca2381073a17f58e",
+			},
+			wantLayout: true,
+		},
+		{
+			name:       "image selected by tag",
+			target:     "testdata/multi-image.oci:0.0.2",
+			want:       []string{"testdata/multi-image.oci:0.0.2"},
+			wantLayout: true,
+		},
+		{
+			name:       "image selected by digest",
+			target:     "testdata/multi-image.oci@sha256:afb744871f99e0ff8e6f253244836ed34c5d805fdb096d3a205ffaf5e9073cab",
+			want:       []string{"testdata/multi-image.oci@sha256:afb744871f99e0ff8e6f253244836ed34c5d805fdb096d3a205ffaf5e9073cab"},
+			wantLayout: true,
+		},
+		{
+			name:       "no image",
+			target:     "testdata/empty.oci",
+			wantLayout: true,
+			wantErr:    "no image in the OCI layout",
+		},
+		{
+			name:   "not an OCI layout",
+			target: "testdata/no-layout",
+		},
+		{
+			name:   "file",
+			target: "testdata/no-layout/requirements.txt",
+		},
+		{
+			name:   "stdin",
+			target: "-",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := ociLayoutImages(tt.target)
+			assert.Equal(t, tt.wantLayout, ok)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/remote"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// platformReport is the report of the image for the platform, e.g. linux/arm64
+type platformReport struct {
+	platform string
+	report   types.Report
+}
+
+// imagePlatforms returns the platforms to scan with "--all-platforms" or multiple platforms in "--platform",
+// or nil if the image is scanned for a single platform as usual.
+func imagePlatforms(ctx context.Context, opts flag.Options) ([]ftypes.Platform, error) {
+	if !opts.AllPlatforms && len(opts.Platforms) == 0 {
+		return nil, nil
+	}
+	if opts.Input != "" {
+		log.WarnContext(ctx, "Multiple platforms are not supported for image archives, and only one platform is scanned")
+		return nil, nil
+	}
+	if len(opts.Platforms) > 0 {
+		return opts.Platforms, nil
+	}
+
+	// The platforms are listed in the manifest list in the registry
+	ref, err := name.ParseReference(opts.Target)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to parse the image name: %w", err)
+	}
+	platforms, err := remote.Platforms(ctx, ref, opts.RegistryOpts())
+	if err != nil {
+		return nil, xerrors.Errorf("unable to list the platforms of the image: %w", err)
+	}
+	if len(platforms) < 2 {
+		log.DebugContext(ctx, "Ignore '--all-platforms' as the image is not multi-arch")
+		return nil, nil
+	}
+	return platforms, nil
+}
+
+// scanPlatforms scans the image for each platform and merges the reports into one.
+func (r *runner) scanPlatforms(ctx context.Context, opts flag.Options, platforms []ftypes.Platform) (types.Report, error) {
+	var reports []platformReport
+	for _, p := range platforms {
+		platform := p.String()
+		log.InfoContext(ctx, "Scanning the image for the platform", log.String("platform", platform))
+		platformOpts := opts
+		platformOpts.Platform = p
+		report, err := r.scanImage(ctx, platformOpts)
+		if err != nil {
+			return types.Report{}, xerrors.Errorf("unable to scan the image for %s: %w", platform, err)
+		}
+		reports = append(reports, platformReport{
+			platform: platform,
+			report:   report,
+		})
+	}
+	return mergePlatformReports(reports, opts.MergePlatforms), nil
+}
+
+// mergePlatformReports merges the reports of the platforms into one.
+// The results are labelled by the platform, e.g. "alpine:3.20 (alpine 3.20.0) [linux/arm64]",
+// or, with merge, the results of the same target are merged and identical vulnerabilities have the list of the platforms.
+func mergePlatformReports(reports []platformReport, merge bool) types.Report {
+	if len(reports) == 0 {
+		return types.Report{}
+	}
+	merged := reports[0].report
+	// The metadata, such as the image ID, differs per platform, while the OS is usually shared
+	merged.Metadata = sharedMetadata(lo.Map(reports, func(pr platformReport, _ int) types.Metadata {
+		return pr.report.Metadata
+	}))
+	merged.Results = nil
+
+	if !merge {
+		for _, pr := range reports {
+			for _, result := range pr.report.Results {
+				result.Target = fmt.Sprintf("%s [%s]", result.Target, pr.platform)
+				merged.Results = append(merged.Results, result)
+			}
+		}
+		return merged
+	}
+
+	// Only vulnerabilities and packages are merged, and the other findings are taken from the first platform with the target
+	// as they rarely differ per platform.
+	for _, pr := range reports {
+		for _, result := range pr.report.Results {
+			vulns := result.Vulnerabilities
+			i := slices.IndexFunc(merged.Results, func(r types.Result) bool {
+				return r.Target == result.Target && r.Class == result.Class && r.Type == result.Type
+			})
+			if i == -1 {
+				result.Vulnerabilities = nil
+				merged.Results = append(merged.Results, result)
+				i = len(merged.Results) - 1
+			} else {
+				for _, pkg := range result.Packages {
+					if !slices.ContainsFunc(merged.Results[i].Packages, func(p ftypes.Package) bool {
+						return p.ID == pkg.ID && p.Name == pkg.Name && p.Version == pkg.Version
+					}) {
+						merged.Results[i].Packages = append(merged.Results[i].Packages, pkg)
+					}
+				}
+			}
+			merged.Results[i].Vulnerabilities = mergePlatformVulnerabilities(merged.Results[i].Vulnerabilities, vulns, pr.platform)
+		}
+	}
+	return merged
+}
+
+// mergePlatformVulnerabilities adds the platform to the vulnerabilities that are already detected,
+// and appends the others with the platform.
+func mergePlatformVulnerabilities(merged, vulns []types.DetectedVulnerability, platform string) []types.DetectedVulnerability {
+	for _, vuln := range vulns {
+		i := slices.IndexFunc(merged, func(v types.DetectedVulnerability) bool {
+			return v.VulnerabilityID == vuln.VulnerabilityID && v.PkgName == vuln.PkgName &&
+				v.InstalledVersion == vuln.InstalledVersion && v.PkgPath == vuln.PkgPath
+		})
+		if i == -1 {
+			vuln.Platforms = []string{platform}
+			merged = append(merged, vuln)
+			continue
+		}
+		merged[i].Platforms = append(merged[i].Platforms, platform)
+	}
+	return merged
+}
+
+// sharedMetadata returns the metadata with the fields that are identical in all the reports, e.g. the OS of a multi-platform image.
+// The other fields, such as the image ID differing per platform, are left empty.
+func sharedMetadata(metadata []types.Metadata) types.Metadata {
+	if len(metadata) == 0 {
+		return types.Metadata{}
+	}
+	shared := metadata[0]
+	for _, m := range metadata[1:] {
+		if shared.Size != m.Size {
+			shared.Size = 0
+		}
+		if !reflect.DeepEqual(shared.OS, m.OS) {
+			shared.OS = nil
+		}
+		if shared.ImageID != m.ImageID {
+			shared.ImageID = ""
+		}
+		if !slices.Equal(shared.DiffIDs, m.DiffIDs) {
+			shared.DiffIDs = nil
+		}
+		if !slices.Equal(shared.RepoTags, m.RepoTags) {
+			shared.RepoTags = nil
+		}
+		if !slices.Equal(shared.RepoDigests, m.RepoDigests) {
+			shared.RepoDigests = nil
+		}
+		if !reflect.DeepEqual(shared.ImageConfig, m.ImageConfig) {
+			shared.ImageConfig = v1.ConfigFile{}
+		}
+	}
+	return shared
+}
@@ -0,0 +1,211 @@
+package artifact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func Test_mergePlatformReports(t *testing.T) {
+	vuln := func(id, pkgName string, platforms ...string) types.DetectedVulnerability {
+		return types.DetectedVulnerability{
+			VulnerabilityID:  id,
+			PkgName:          pkgName,
+			InstalledVersion: "1.0.0",
+			Platforms:        platforms,
+		}
+	}
+	reports := []platformReport{
+		{
+			platform: "linux/amd64",
+			report: types.Report{
+				ArtifactName: "alpine:3.20",
+				ArtifactType: artifact.TypeContainerImage,
+				Metadata: types.Metadata{
+					OS: &ftypes.OS{
+						Family: ftypes.Alpine,
+						Name:   "3.20.0",
+					},
+					ImageID:  "sha256:amd64",
+					RepoTags: []string{"alpine:3.20"},
+				},
+				Results: types.Results{
+					{
+						Target: "alpine:3.20 (alpine 3.20.0)",
+						Class:  types.ClassOSPkg,
+						Type:   ftypes.Alpine,
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln("CVE-2024-0001", "musl"),
+						},
+					},
+				},
+			},
+		},
+		{
+			platform: "linux/arm64/v8",
+			report: types.Report{
+				ArtifactName: "alpine:3.20",
+				ArtifactType: artifact.TypeContainerImage,
+				Metadata: types.Metadata{
+					OS: &ftypes.OS{
+						Family: ftypes.Alpine,
+						Name:   "3.20.0",
+					},
+					ImageID:  "sha256:arm64",
+					RepoTags: []string{"alpine:3.20"},
+				},
+				Results: types.Results{
+					{
+						Target: "alpine:3.20 (alpine 3.20.0)",
+						Class:  types.ClassOSPkg,
+						Type:   ftypes.Alpine,
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln("CVE-2024-0001", "musl"),
+							vuln("CVE-2024-0002", "busybox"),
+						},
+					},
+					{
+						Target: "usr/local/bin/app",
+						Class:  types.ClassLangPkg,
+						Type:   ftypes.GoBinary,
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln("CVE-2024-0003", "golang.org/x/net"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// The OS and the tags are shared by the platforms, while the image ID is not
+	metadata := types.Metadata{
+		OS: &ftypes.OS{
+			Family: ftypes.Alpine,
+			Name:   "3.20.0",
+		},
+		RepoTags: []string{"alpine:3.20"},
+	}
+
+	tests := []struct {
+		name  string
+		merge bool
+		want  types.Report
+	}{
+		{
+			name: "per platform",
+			want: types.Report{
+				ArtifactName: "alpine:3.20",
+				ArtifactType: artifact.TypeContainerImage,
+				Metadata:     metadata,
+				Results: types.Results{
+					{
+						Target: "alpine:3.20 (alpine 3.20.0) [linux/amd64]",
+						Class:  types.ClassOSPkg,
+						Type:   ftypes.Alpine,
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln("CVE-2024-0001", "musl"),
+						},
+					},
+					{
+						Target: "alpine:3.20 (alpine 3.20.0) [linux/arm64/v8]",
+						Class:  types.ClassOSPkg,
+						Type:   ftypes.Alpine,
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln("CVE-2024-0001", "musl"),
+							vuln("CVE-2024-0002", "busybox"),
+						},
+					},
+					{
+						Target: "usr/local/bin/app [linux/arm64/v8]",
+						Class:  types.ClassLangPkg,
+						Type:   ftypes.GoBinary,
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln("CVE-2024-0003", "golang.org/x/net"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "merged",
+			merge: true,
+			want: types.Report{
+				ArtifactName: "alpine:3.20",
+				ArtifactType: artifact.TypeContainerImage,
+				Metadata:     metadata,
+				Results: types.Results{
+					{
+						Target: "alpine:3.20 (alpine 3.20.0)",
+						Class:  types.ClassOSPkg,
+						Type:   ftypes.Alpine,
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln("CVE-2024-0001", "musl", "linux/amd64", "linux/arm64/v8"),
+							vuln("CVE-2024-0002", "busybox", "linux/arm64/v8"),
+						},
+					},
+					{
+						Target: "usr/local/bin/app",
+						Class:  types.ClassLangPkg,
+						Type:   ftypes.GoBinary,
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln("CVE-2024-0003", "golang.org/x/net", "linux/arm64/v8"),
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergePlatformReports(reports, tt.merge)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_sharedMetadata(t *testing.T) {
+	alpine := &ftypes.OS{
+		Family: ftypes.Alpine,
+		Name:   "3.20.0",
+	}
+	debian := &ftypes.OS{
+		Family: ftypes.Debian,
+		Name:   "12.5",
+	}
+
+	tests := []struct {
+		name     string
+		metadata []types.Metadata
+		want     types.Metadata
+	}{
+		{
+			name: "shared OS",
+			metadata: []types.Metadata{
+				{OS: alpine, ImageID: "sha256:amd64", DiffIDs: []string{"sha256:layer1"}},
+				{OS: alpine, ImageID: "sha256:arm64", DiffIDs: []string{"sha256:layer2"}},
+			},
+			want: types.Metadata{OS: alpine},
+		},
+		{
+			name: "different OSes",
+			metadata: []types.Metadata{
+				{OS: alpine, RepoTags: []string{"app:1.0"}},
+				{OS: debian, RepoTags: []string{"app:1.0"}},
+			},
+			want: types.Metadata{RepoTags: []string{"app:1.0"}},
+		},
+		{
+			name: "no metadata",
+			want: types.Metadata{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sharedMetadata(tt.metadata))
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package artifact
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact/local"
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// enforceReadOnly guarantees that the filesystem scan with "--read-only" doesn't write into the target.
+// It refuses the scan if the report, the cache or the trend file would be written under the target,
+// and routes temporary files, such as extracted archives, to a dedicated directory outside the target.
+// The returned function removes the temporary directory and must be called after the scan.
+func enforceReadOnly(ctx context.Context, opts flag.Options) (func(), error) {
+	if opts.Target == local.StdinPath {
+		return func() {}, nil
+	}
+
+	target := realPath(opts.Target)
+	writes := []struct {
+		flag string
+		path string
+	}{
+		{"--output", opts.OutputFile()},
+		{"--output-dir", opts.OutputDir},
+		{"--cache-dir", opts.CacheDir},
+		{"--trend-file", opts.TrendFile},
+	}
+	for _, w := range writes {
+		if w.path != "" && isUnder(realPath(w.path), target) {
+			return nil, xerrors.Errorf("'--read-only' is specified, but %s (%s) is under the scan target", w.flag, w.path)
+		}
+	}
+
+	// Analyzers create temporary files in the system temp dir
+	tmpDir := realPath(os.TempDir())
+	if isUnder(tmpDir, target) {
+		return nil, xerrors.Errorf("'--read-only' is specified, but the temp dir (%s) is under the scan target, set %s to another directory", tmpDir, tempDirEnv())
+	}
+	dir, err := os.MkdirTemp("", "trivy-read-only-*")
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	log.DebugContext(ctx, "Temporary files are written into a dedicated directory", log.String("dir", dir))
+
+	oldTmpDir, ok := os.LookupEnv(tempDirEnv())
+	if err = os.Setenv(tempDirEnv(), dir); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, xerrors.Errorf("failed to set %s: %w", tempDirEnv(), err)
+	}
+	return func() {
+		if ok {
+			_ = os.Setenv(tempDirEnv(), oldTmpDir)
+		} else {
+			_ = os.Unsetenv(tempDirEnv())
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			log.WarnContext(ctx, "Failed to remove the temp dir", log.String("dir", dir), log.Err(err))
+		}
+	}, nil
+}
+
+// tempDirEnv returns the environment variable that os.TempDir refers to
+func tempDirEnv() string {
+	if runtime.GOOS == "windows" {
+		return "TMP"
+	}
+	return "TMPDIR"
+}
+
+// realPath returns the absolute path with symlinks resolved, e.g. /tmp => /private/tmp on macOS.
+// Paths that don't exist yet, such as the output file, are resolved through the parent directory.
+func realPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved
+	}
+	if parent, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+		return filepath.Join(parent, filepath.Base(abs))
+	}
+	return abs
+}
+
+// isUnder returns true if the path is the root or under the root
+func isUnder(path, root string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
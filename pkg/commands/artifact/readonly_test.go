@@ -0,0 +1,127 @@
+package artifact
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/flag"
+)
+
+func Test_enforceReadOnly(t *testing.T) {
+	target := t.TempDir()
+	outside := t.TempDir()
+
+	tests := []struct {
+		name    string
+		target  string
+		opts    flag.Options
+		tmpDir  string
+		wantErr string
+	}{
+		{
+			name: "happy path",
+			opts: flag.Options{
+				GlobalOptions: flag.GlobalOptions{CacheDir: filepath.Join(outside, "cache")},
+				ReportOptions: flag.ReportOptions{Output: filepath.Join(outside, "report.json")},
+			},
+		},
+		{
+			name: "output to a plugin",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{Output: "plugin=count"},
+			},
+		},
+		{
+			name:   "output to Kafka",
+			target: ".",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{Output: "kafka://localhost:9092/trivy"},
+			},
+		},
+		{
+			name:   "output to Elasticsearch",
+			target: ".",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{Output: "es://localhost:9200/trivy"},
+			},
+		},
+		{
+			name: "output under the target",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{Output: filepath.Join(target, "report.json")},
+			},
+			wantErr: "--output",
+		},
+		{
+			name: "output dir under the target",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{OutputDir: filepath.Join(target, "reports")},
+			},
+			wantErr: "--output-dir",
+		},
+		{
+			name: "cache dir under the target",
+			opts: flag.Options{
+				GlobalOptions: flag.GlobalOptions{CacheDir: filepath.Join(target, ".cache")},
+			},
+			wantErr: "--cache-dir",
+		},
+		{
+			name: "trend file under the target",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{TrendFile: filepath.Join(target, ".trivy-trend.json")},
+			},
+			wantErr: "--trend-file",
+		},
+		{
+			name:    "temp dir under the target",
+			tmpDir:  filepath.Join(target, "tmp"),
+			wantErr: "the temp dir",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := outside
+			if tt.tmpDir != "" {
+				tmpDir = tt.tmpDir
+			}
+			t.Setenv(tempDirEnv(), tmpDir)
+
+			tt.opts.Target = target
+			if tt.target != "" {
+				tt.opts.Target = tt.target
+			}
+			cleanup, err := enforceReadOnly(context.Background(), tt.opts)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			// Temporary files are written into a dedicated directory outside the target
+			dir := os.TempDir()
+			assert.NotEqual(t, tmpDir, dir)
+			assert.True(t, isUnder(realPath(dir), realPath(outside)))
+			assert.DirExists(t, dir)
+
+			cleanup()
+			assert.Equal(t, tmpDir, os.TempDir())
+			assert.NoDirExists(t, dir)
+		})
+	}
+}
+
+func Test_isUnder(t *testing.T) {
+	root := filepath.Join("/", "app")
+	assert.True(t, isUnder(root, root))
+	assert.True(t, isUnder(filepath.Join(root, "report.json"), root))
+	assert.True(t, isUnder(filepath.Join(root, "..data", "x"), root))
+	assert.False(t, isUnder(filepath.Join("/", "application"), root))
+	assert.False(t, isUnder(filepath.Join("/", "tmp", "report.json"), root))
+	assert.False(t, isUnder(filepath.Dir(root), root))
+}
@@ -12,11 +12,16 @@ import (
 	"github.com/spf13/viper"
 	"golang.org/x/xerrors"
 
+	"github.com/aquasecurity/trivy-db/pkg/metadata"
 	"github.com/aquasecurity/trivy/pkg/cache"
 	"github.com/aquasecurity/trivy/pkg/commands/operation"
 	"github.com/aquasecurity/trivy/pkg/db"
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	secretanalyzer "github.com/aquasecurity/trivy/pkg/fanal/analyzer/secret"
 	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact/local"
+	"github.com/aquasecurity/trivy/pkg/fanal/secret"
+	"github.com/aquasecurity/trivy/pkg/fanal/secret/githistory"
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/fanal/walker"
 	"github.com/aquasecurity/trivy/pkg/flag"
@@ -154,6 +159,17 @@ func (r *runner) Close(ctx context.Context) error {
 }
 
 func (r *runner) ScanImage(ctx context.Context, opts flag.Options) (types.Report, error) {
+	// Scan the image per platform with "--all-platforms" or multiple platforms in "--platform"
+	platforms, err := imagePlatforms(ctx, opts)
+	if err != nil {
+		return types.Report{}, xerrors.Errorf("platform error: %w", err)
+	} else if len(platforms) > 0 {
+		return r.scanPlatforms(ctx, opts, platforms)
+	}
+	return r.scanImage(ctx, opts)
+}
+
+func (r *runner) scanImage(ctx context.Context, opts flag.Options) (types.Report, error) {
 	// Disable the lock file scanning
 	opts.DisabledAnalyzers = analyzer.TypeLockfiles
 
@@ -177,11 +193,84 @@ func (r *runner) ScanImage(ctx context.Context, opts flag.Options) (types.Report
 }
 
 func (r *runner) ScanFilesystem(ctx context.Context, opts flag.Options) (types.Report, error) {
+	// Scan the images described by an OCI image layout rather than its blobs
+	images, ok, err := ociLayoutImages(opts.Target)
+	if err != nil {
+		return types.Report{}, xerrors.Errorf("OCI layout error: %w", err)
+	} else if ok {
+		return r.scanOCILayout(ctx, opts, images)
+	}
+
 	// Disable scanning of individual package and SBOM files
 	opts.DisabledAnalyzers = append(opts.DisabledAnalyzers, analyzer.TypeIndividualPkgs...)
 	opts.DisabledAnalyzers = append(opts.DisabledAnalyzers, analyzer.TypeSBOM)
 
-	return r.scanFS(ctx, opts)
+	report, err := r.scanFS(ctx, opts)
+	if err != nil {
+		return types.Report{}, err
+	}
+	if opts.IncludeFileHashes {
+		if err = addFileHashes(ctx, opts.Target, report.Results); err != nil {
+			return types.Report{}, xerrors.Errorf("file hash error: %w", err)
+		}
+	}
+	if opts.ScanGitHistory {
+		if err = scanGitHistory(ctx, opts, &report); err != nil {
+			return types.Report{}, xerrors.Errorf("git history scan error: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// scanGitHistory adds the secrets found in the git history but removed from the working tree to the report.
+func scanGitHistory(ctx context.Context, opts flag.Options, report *types.Report) error {
+	if !opts.Scanners.Enabled(types.SecretScanner) {
+		log.WarnContext(ctx, `"--scan-git-history" requires the secret scanner, e.g. "--scanners secret"`)
+		return nil
+	}
+	if fi, err := os.Stat(opts.Target); err != nil || !fi.IsDir() {
+		log.WarnContext(ctx, `"--scan-git-history" can be used only with a directory`)
+		return nil
+	}
+
+	config, err := secret.ParseConfig(opts.SecretConfigPath)
+	if err != nil {
+		return xerrors.Errorf("secret config error: %w", err)
+	}
+	entropy := secret.WithEntropy(opts.ShowSecretConf || opts.MinSecretConf != "")
+	scanner := secret.NewScanner(config, entropy)
+
+	// Skip the same files as the secret scanning of the working tree
+	secretAnalyzer := secretanalyzer.NewSecretAnalyzer(scanner, opts.SecretConfigPath)
+	historyScanner := githistory.NewScanner(scanner, opts.GitHistoryDepth,
+		githistory.WithWalkerOption(walker.Option{
+			SkipFiles: opts.SkipFiles,
+			SkipDirs:  opts.SkipDirs,
+		}),
+		githistory.WithFilter(secretAnalyzer.Required),
+	)
+	secrets, err := historyScanner.Scan(ctx, opts.Target)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range secrets {
+		findings := lo.Map(s.Findings, func(f ftypes.SecretFinding, _ int) types.DetectedSecret {
+			return types.DetectedSecret(f)
+		})
+		i := slices.IndexFunc(report.Results, func(r types.Result) bool {
+			return r.Class == types.ClassSecret && r.Target == s.FilePath
+		})
+		if i < 0 {
+			report.Results = append(report.Results, types.Result{
+				Target: s.FilePath,
+				Class:  types.ClassSecret,
+			})
+			i = len(report.Results) - 1
+		}
+		report.Results[i].Secrets = append(report.Results[i].Secrets, findings...)
+	}
+	return nil
 }
 
 func (r *runner) ScanRootfs(ctx context.Context, opts flag.Options) (types.Report, error) {
@@ -192,6 +281,11 @@ func (r *runner) ScanRootfs(ctx context.Context, opts flag.Options) (types.Repor
 }
 
 func (r *runner) scanFS(ctx context.Context, opts flag.Options) (types.Report, error) {
+	// "-" reads a tar archive from stdin, so a terminal must not be attached
+	if opts.Target == local.StdinPath && isTerminal(os.Stdin) {
+		return types.Report{}, xerrors.New(`"-" requires a tar archive piped to stdin, e.g. "tar -c . | trivy fs -"`)
+	}
+
 	var s InitializeScanner
 	if opts.ServerAddr == "" {
 		// Scan filesystem in standalone mode
@@ -204,6 +298,14 @@ func (r *runner) scanFS(ctx context.Context, opts flag.Options) (types.Report, e
 	return r.scanArtifact(ctx, opts, s)
 }
 
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
 func (r *runner) ScanRepository(ctx context.Context, opts flag.Options) (types.Report, error) {
 	// Do not scan OS packages
 	opts.PkgTypes = []string{types.PkgTypeLibrary}
@@ -273,6 +375,15 @@ func (r *runner) Filter(ctx context.Context, opts flag.Options, report types.Rep
 }
 
 func (r *runner) Report(ctx context.Context, opts flag.Options, report types.Report) error {
+	// The vulnerability database is available only when it is opened locally.
+	if opts.DBStaleWarning > 0 && r.dbOpen {
+		meta, err := metadata.NewClient(db.Dir(opts.CacheDir)).Get()
+		if err != nil {
+			log.WarnContext(ctx, "Unable to get the DB metadata", log.Err(err))
+		}
+		report.DBUpdatedAt = meta.UpdatedAt
+	}
+
 	if err := pkgReport.Write(ctx, report, opts); err != nil {
 		return xerrors.Errorf("unable to write results: %w", err)
 	}
@@ -359,6 +470,14 @@ func Run(ctx context.Context, opts flag.Options, targetKind TargetKind) (err err
 		return viper.SafeWriteConfigAs("trivy-default.yaml")
 	}
 
+	if targetKind == TargetFilesystem && opts.ReadOnly {
+		cleanup, err := enforceReadOnly(ctx, opts)
+		if err != nil {
+			return xerrors.Errorf("read-only error: %w", err)
+		}
+		defer cleanup()
+	}
+
 	r, err := NewRunner(ctx, opts)
 	if err != nil {
 		if errors.Is(err, SkipScan) {
@@ -368,6 +487,10 @@ func Run(ctx context.Context, opts flag.Options, targetKind TargetKind) (err err
 	}
 	defer r.Close(ctx)
 
+	if targetKind == TargetFilesystem && opts.Diff {
+		return runDiff(ctx, r, opts)
+	}
+
 	scans := map[TargetKind]func(context.Context, flag.Options) (types.Report, error){
 		TargetContainerImage: r.ScanImage,
 		TargetFilesystem:     r.ScanFilesystem,
@@ -392,11 +515,26 @@ func Run(ctx context.Context, opts flag.Options, targetKind TargetKind) (err err
 		return xerrors.Errorf("filter error: %w", err)
 	}
 
+	// It must be checked before writing the report as packages are removed in some formats
+	empty := report.Empty()
+
 	if err = r.Report(ctx, opts, report); err != nil {
 		return xerrors.Errorf("report error: %w", err)
 	}
 
-	return operation.Exit(opts, report.Results.Failed(), report.Metadata)
+	if opts.FailOnEmpty && empty {
+		log.WarnContext(ctx, "Neither packages nor findings were detected. Make sure the target is correct and the files are not skipped",
+			log.String("target", opts.Target))
+		operation.ExplainExit(opts, "empty=true threshold=--fail-on-empty")
+		return &types.ExitError{Code: 1}
+	}
+	if err = operation.ExitOnSLABreach(ctx, opts, report); err != nil {
+		return err
+	}
+	if err = operation.ExitOnLicenseViolation(ctx, opts, report); err != nil {
+		return err
+	}
+	return operation.Exit(opts, report.Results, report.Metadata)
 }
 
 func disabledAnalyzers(opts flag.Options) []analyzer.Type {
@@ -453,6 +591,12 @@ func disabledAnalyzers(opts flag.Options) []analyzer.Type {
 		analyzers = append(analyzers, analyzer.TypeHistoryDockerfile)
 	}
 
+	// The fields of container image config, such as the user, the environment variables and the exposed ports,
+	// are checked as a part of misconfiguration scanning
+	if !opts.Scanners.Enabled(types.MisconfigScanner) && !opts.ImageConfigScanners.Enabled(types.MisconfigScanner) {
+		analyzers = append(analyzers, analyzer.TypeImageConfigMisconf)
+	}
+
 	// Skip executable file analysis if Rekor isn't a specified SBOM source.
 	if !slices.Contains(opts.SBOMSources, types.SBOMSourceRekor) {
 		analyzers = append(analyzers, analyzer.TypeExecutable)
@@ -570,6 +714,7 @@ func (r *runner) initScannerConfig(ctx context.Context, opts flag.Options) (Scan
 			AWSEndpoint:       opts.Endpoint,
 			FileChecksum:      fileChecksum,
 			DetectionPriority: opts.DetectionPriority,
+			MaxTargets:        opts.MaxTargets,
 
 			// For image scanning
 			ImageOption: ftypes.ImageOptions{
@@ -589,6 +734,7 @@ func (r *runner) initScannerConfig(ctx context.Context, opts flag.Options) (Scan
 			// For secret scanning
 			SecretScannerOption: analyzer.SecretScannerOption{
 				ConfigPath: opts.SecretConfigPath,
+				Entropy:    opts.ShowSecretConf || opts.MinSecretConf != "",
 			},
 
 			// For license scanning
@@ -648,6 +794,7 @@ func initMisconfScannerOption(ctx context.Context, opts flag.Options) (misconf.S
 
 	return misconf.ScannerOption{
 		Trace:                    opts.Trace,
+		PolicySource:             opts.ShowPolicySource,
 		Namespaces:               append(opts.CheckNamespaces, rego.BuiltinNamespaces()...),
 		PolicyPaths:              append(opts.CheckPaths, downloadedPolicyPaths...),
 		DataPaths:                opts.DataPaths,
@@ -1,6 +1,7 @@
 package convert
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"github.com/aquasecurity/trivy/pkg/flag"
 	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/report/trivybin"
 	"github.com/aquasecurity/trivy/pkg/result"
 	"github.com/aquasecurity/trivy/pkg/types"
 )
@@ -28,7 +30,12 @@ func Run(ctx context.Context, opts flag.Options) (err error) {
 	defer f.Close()
 
 	var r types.Report
-	if err = json.NewDecoder(f).Decode(&r); err != nil {
+	br := bufio.NewReader(f)
+	if trivybin.Detect(br) {
+		if r, err = trivybin.Read(br); err != nil {
+			return xerrors.Errorf("unable to read the report in the binary format: %w", err)
+		}
+	} else if err = json.NewDecoder(br).Decode(&r); err != nil {
 		return xerrors.Errorf("json decode error: %w", err)
 	}
 
@@ -47,7 +54,13 @@ func Run(ctx context.Context, opts flag.Options) (err error) {
 		return xerrors.Errorf("unable to write results: %w", err)
 	}
 
-	return operation.Exit(opts, r.Results.Failed(), r.Metadata)
+	if err = operation.ExitOnSLABreach(ctx, opts, r); err != nil {
+		return err
+	}
+	if err = operation.ExitOnLicenseViolation(ctx, opts, r); err != nil {
+		return err
+	}
+	return operation.Exit(opts, r.Results, r.Metadata)
 }
 
 // compat converts the JSON report to the latest format
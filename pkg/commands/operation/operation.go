@@ -2,12 +2,16 @@ package operation
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"sync"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/samber/lo"
 	"golang.org/x/xerrors"
 
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/clock"
 	"github.com/aquasecurity/trivy/pkg/db"
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/flag"
@@ -115,15 +119,95 @@ func InitBuiltinChecks(ctx context.Context, cacheDir string, quiet, skipUpdate b
 	return policyPaths, nil
 }
 
-func Exit(opts flag.Options, failedResults bool, m types.Metadata) error {
+// Exit returns an error with the exit code if the OS reaches end of life with "--exit-on-eol"
+// or any security issue is found with "--exit-code".
+func Exit(opts flag.Options, results types.Results, m types.Metadata) error {
 	if opts.ExitOnEOL != 0 && m.OS != nil && m.OS.Eosl {
 		log.Error("Detected EOL OS", log.String("family", string(m.OS.Family)),
 			log.String("version", m.OS.Name))
+		ExplainExit(opts, fmt.Sprintf("os=%s/%s eol=true threshold=--exit-on-eol", m.OS.Family, m.OS.Name))
 		return &types.ExitError{Code: opts.ExitOnEOL}
 	}
 
-	if opts.ExitCode != 0 && failedResults {
+	if opts.ExitCode != 0 && results.Failed() {
+		severity, count := highestSeverity(results, opts.SeverityOrder)
+		ExplainExit(opts, fmt.Sprintf("severity=%s count=%d threshold=--severity", severity, count))
 		return &types.ExitError{Code: opts.ExitCode}
 	}
 	return nil
 }
+
+// ExitOnSLABreach returns an error with exit code 1 if "--fail-on-sla-breach" is enabled
+// and any vulnerability in the report breaches the SLA.
+func ExitOnSLABreach(ctx context.Context, opts flag.Options, report types.Report) error {
+	if !opts.FailOnSLABreach || len(opts.SLA) == 0 {
+		return nil
+	}
+	now := report.CreatedAt
+	if now.IsZero() {
+		now = clock.Now(ctx)
+	}
+	if breached := opts.SLA.Breached(report.Results, now); breached > 0 {
+		log.ErrorContext(ctx, "Detected vulnerabilities breaching the SLA", log.Int("count", breached))
+		ExplainExit(opts, fmt.Sprintf("sla-breaches=%d threshold=--fail-on-sla-breach", breached))
+		return &types.ExitError{Code: 1}
+	}
+	return nil
+}
+
+// ExitOnLicenseViolation returns an error with exit code 1 if "--fail-on-license-violation" is enabled
+// and any license in the report fails the license policy.
+func ExitOnLicenseViolation(ctx context.Context, opts flag.Options, report types.Report) error {
+	if !opts.FailOnLicense || !opts.LicensePolicy.Enabled() {
+		return nil
+	}
+	if violations := opts.LicensePolicy.Violations(report.Results); violations > 0 {
+		log.ErrorContext(ctx, "Detected licenses failing the license policy", log.Int("count", violations))
+		ExplainExit(opts, fmt.Sprintf("license-violations=%d threshold=--fail-on-license-violation", violations))
+		return &types.ExitError{Code: 1}
+	}
+	return nil
+}
+
+// ExplainExit writes the reason for the non-zero exit code to stderr with "--explain-exit"
+// so that CI logs tell why the build failed, e.g. "exit-reason: severity=CRITICAL count=3 threshold=--severity".
+// The reason is a list of key=value pairs to be parsed by scripts.
+func ExplainExit(opts flag.Options, reason string) {
+	if !opts.ExplainExit {
+		return
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "exit-reason: %s\n", reason)
+}
+
+// highestSeverity returns the highest severity of the findings failing the scan and the number of findings with it.
+// Findings have already been filtered by "--severity".
+func highestSeverity(results types.Results, severityOrder []string) (string, int) {
+	counts := make(map[string]int)
+	for _, result := range results {
+		for _, vuln := range result.Vulnerabilities {
+			counts[vuln.Severity]++
+		}
+		for _, misconf := range result.Misconfigurations {
+			if misconf.Status == types.MisconfStatusFailure {
+				counts[misconf.Severity]++
+			}
+		}
+		for _, secret := range result.Secrets {
+			counts[secret.Severity]++
+		}
+		for _, license := range result.Licenses {
+			counts[license.Severity]++
+		}
+	}
+
+	order := severityOrder
+	if len(order) == 0 {
+		order = dbTypes.SeverityNames
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		if counts[order[i]] > 0 {
+			return order[i], counts[order[i]]
+		}
+	}
+	return dbTypes.SeverityUnknown.String(), counts[dbTypes.SeverityUnknown.String()]
+}
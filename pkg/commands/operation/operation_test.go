@@ -0,0 +1,166 @@
+package operation_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/commands/operation"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestExit(t *testing.T) {
+	results := types.Results{
+		{
+			Target: "package-lock.json",
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "CVE-2021-23337",
+					Vulnerability:   dbTypes.Vulnerability{Severity: "HIGH"},
+				},
+				{
+					VulnerabilityID: "CVE-2020-8203",
+					Vulnerability:   dbTypes.Vulnerability{Severity: "CRITICAL"},
+				},
+			},
+		},
+		{
+			Target: "Dockerfile",
+			Misconfigurations: []types.DetectedMisconfiguration{
+				{
+					ID:       "DS002",
+					Severity: "CRITICAL",
+					Status:   types.MisconfStatusFailure,
+				},
+				{
+					ID:       "DS001",
+					Severity: "CRITICAL",
+					Status:   types.MisconfStatusPassed,
+				},
+			},
+			Secrets: []types.DetectedSecret{
+				{
+					RuleID:   "aws-access-key-id",
+					Severity: "CRITICAL",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		opts       flag.Options
+		results    types.Results
+		metadata   types.Metadata
+		wantCode   int
+		wantStderr string
+	}{
+		{
+			name: "severity",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{
+					ExitCode:    5,
+					ExplainExit: true,
+				},
+			},
+			results:    results,
+			wantCode:   5,
+			wantStderr: "exit-reason: severity=CRITICAL count=3 threshold=--severity\n",
+		},
+		{
+			name: "severity order",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{
+					ExitCode:      1,
+					ExplainExit:   true,
+					SeverityOrder: []string{"CRITICAL", "HIGH"},
+				},
+			},
+			results:    results,
+			wantCode:   1,
+			wantStderr: "exit-reason: severity=HIGH count=1 threshold=--severity\n",
+		},
+		{
+			name: "EOL",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{
+					ExitCode:    1,
+					ExitOnEOL:   2,
+					ExplainExit: true,
+				},
+			},
+			results: results,
+			metadata: types.Metadata{
+				OS: &ftypes.OS{
+					Family: ftypes.Alpine,
+					Name:   "3.10",
+					Eosl:   true,
+				},
+			},
+			wantCode:   2,
+			wantStderr: "exit-reason: os=alpine/3.10 eol=true threshold=--exit-on-eol\n",
+		},
+		{
+			name: "not explained",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{
+					ExitCode: 1,
+				},
+			},
+			results:  results,
+			wantCode: 1,
+		},
+		{
+			name: "no findings",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{
+					ExitCode:    1,
+					ExplainExit: true,
+				},
+			},
+			results: types.Results{
+				{
+					Target: "go.mod",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stderr := setTempStderr(t)
+
+			err := operation.Exit(tt.opts, tt.results, tt.metadata)
+			if tt.wantCode == 0 {
+				require.NoError(t, err)
+			} else {
+				var exitErr *types.ExitError
+				require.ErrorAs(t, err, &exitErr)
+				assert.Equal(t, tt.wantCode, exitErr.Code)
+			}
+
+			got, err := os.ReadFile(stderr.Name())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStderr, string(got))
+		})
+	}
+}
+
+func setTempStderr(t *testing.T) *os.File {
+	f, err := os.Create(filepath.Join(t.TempDir(), "stderr.txt"))
+	require.NoError(t, err)
+
+	// Overwrite Stderr to get the reason
+	defaultStderr := os.Stderr
+	os.Stderr = f
+	t.Cleanup(func() {
+		os.Stderr = defaultStderr
+		f.Close()
+	})
+	return f
+}
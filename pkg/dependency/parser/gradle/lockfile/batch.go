@@ -0,0 +1,91 @@
+package lockfile
+
+import (
+	"context"
+	"io/fs"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/utils"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/parallel"
+	xio "github.com/aquasecurity/trivy/pkg/x/io"
+)
+
+type parsedFile struct {
+	index int
+	pkgs  []ftypes.Package
+}
+
+// ParseEach parses the lockfiles in fsys concurrently with the given number of workers.
+// A default number of workers is used if "workers" is 0.
+// The i-th element of the result holds the packages of paths[i],
+// so the result doesn't depend on the order in which the lockfiles are parsed.
+func (p *Parser) ParseEach(ctx context.Context, fsys fs.FS, paths []string, workers int) ([][]ftypes.Package, error) {
+	indices := make([]int, len(paths))
+	for i := range paths {
+		indices[i] = i
+	}
+
+	parsed := make([][]ftypes.Package, len(paths))
+	onItem := func(_ context.Context, i int) (parsedFile, error) {
+		pkgs, err := p.parseFile(fsys, paths[i])
+		if err != nil {
+			return parsedFile{}, xerrors.Errorf("failed to parse %s: %w", paths[i], err)
+		}
+		return parsedFile{
+			index: i,
+			pkgs:  pkgs,
+		}, nil
+	}
+	onResult := func(f parsedFile) error {
+		parsed[f.index] = f.pkgs
+		return nil
+	}
+
+	pipeline := parallel.NewPipeline(workers, false, indices, onItem, onResult)
+	if err := pipeline.Do(ctx); err != nil {
+		return nil, xerrors.Errorf("pipeline error: %w", err)
+	}
+	return parsed, nil
+}
+
+// ParseFiles parses the lockfiles in fsys concurrently like ParseEach and merges the packages.
+// Packages locked in several lockfiles are merged into one, and the merged packages are sorted,
+// so the result doesn't depend on the order in which the lockfiles are parsed.
+// Locations are dropped as line numbers are meaningless without the lockfile they belong to.
+func (p *Parser) ParseFiles(ctx context.Context, fsys fs.FS, paths []string, workers int) ([]ftypes.Package, error) {
+	parsed, err := p.ParseEach(ctx, fsys, paths, workers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Concatenate the packages in the order of the lockfiles so that the merged packages are deterministic
+	var pkgs []ftypes.Package
+	for _, ps := range parsed {
+		for _, pkg := range ps {
+			pkg.Locations = nil
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	return utils.UniquePackages(pkgs), nil
+}
+
+func (p *Parser) parseFile(fsys fs.FS, path string) ([]ftypes.Package, error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return nil, xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	r, err := xio.NewReadSeekerAt(f)
+	if err != nil {
+		return nil, xerrors.Errorf("reader error: %w", err)
+	}
+
+	pkgs, _, err := p.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return pkgs, nil
+}
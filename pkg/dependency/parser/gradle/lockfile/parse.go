@@ -4,24 +4,39 @@ import (
 	"bufio"
 	"strings"
 
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+	"golang.org/x/xerrors"
+
 	"github.com/aquasecurity/trivy/pkg/dependency"
 	"github.com/aquasecurity/trivy/pkg/dependency/parser/utils"
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
 	xio "github.com/aquasecurity/trivy/pkg/x/io"
 )
 
-type Parser struct{}
+type Parser struct {
+	logger *log.Logger
+}
 
 func NewParser() *Parser {
-	return &Parser{}
+	return &Parser{
+		logger: log.WithPrefix("gradle"),
+	}
 }
 
-func (Parser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependency, error) {
+func (p *Parser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependency, error) {
 	var pkgs []ftypes.Package
-	scanner := bufio.NewScanner(r)
+	// Lockfiles authored on Windows can start with a byte order mark (BOM).
+	// It must be stripped, otherwise the first dependency is read with a corrupted group name.
+	decodedReader := transform.NewReader(r, unicode.BOMOverride(encoding.Nop.NewDecoder()))
+	scanner := bufio.NewScanner(decodedReader)
 	var lineNum int
 	for scanner.Scan() {
 		lineNum++
+		// `bufio.ScanLines` drops only the trailing `\r` of CRLF line endings,
+		// so we trim the remaining whitespace as well.
 		line := strings.TrimSpace(scanner.Text())
 		if strings.HasPrefix(line, "#") { // skip comments
 			continue
@@ -49,5 +64,15 @@ func (Parser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependency,
 		})
 
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, xerrors.Errorf("scan error: %w", err)
+	}
+
+	// A project without locked dependencies has a lockfile with only comments and the list of empty configurations.
+	// It is parsed successfully, so zero packages don't mean a parse failure.
+	if len(pkgs) == 0 {
+		p.logger.Debug("The lockfile has no locked dependencies")
+		return nil, nil, nil
+	}
 	return utils.UniquePackages(pkgs), nil, nil
 }
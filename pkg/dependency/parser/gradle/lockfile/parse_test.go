@@ -1,8 +1,12 @@
 package lockfile
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -56,11 +60,44 @@ func TestParser_Parse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "CRLF line endings with BOM",
+			inputFile: "testdata/crlf-bom.lockfile",
+			want: []ftypes.Package{
+				{
+					ID:      "cglib:cglib-nodep:2.1.2",
+					Name:    "cglib:cglib-nodep",
+					Version: "2.1.2",
+					Locations: []ftypes.Location{
+						{
+							StartLine: 1,
+							EndLine:   1,
+						},
+					},
+				},
+				{
+					ID:      "org.springframework:spring-asm:3.1.3.RELEASE",
+					Name:    "org.springframework:spring-asm",
+					Version: "3.1.3.RELEASE",
+					Locations: []ftypes.Location{
+						{
+							StartLine: 2,
+							EndLine:   2,
+						},
+					},
+				},
+			},
+		},
 		{
 			name:      "empty",
 			inputFile: "testdata/empty.lockfile",
 			want:      nil,
 		},
+		{
+			name:      "no locked dependencies",
+			inputFile: "testdata/no-dependencies.lockfile",
+			want:      nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -69,9 +106,125 @@ func TestParser_Parse(t *testing.T) {
 			f, err := os.Open(tt.inputFile)
 			require.NoError(t, err)
 
-			pkgs, _, _ := parser.Parse(f)
+			pkgs, _, err := parser.Parse(f)
+			require.NoError(t, err)
 			sort.Sort(ftypes.Packages(pkgs))
 			assert.Equal(t, tt.want, pkgs)
 		})
 	}
 }
+
+func TestParser_ParseFiles(t *testing.T) {
+	paths := []string{
+		"happy.lockfile",
+		"no-dependencies.lockfile",
+		"multi-module.lockfile",
+		"crlf-bom.lockfile",
+	}
+	want := []ftypes.Package{
+		{
+			ID:      "cglib:cglib-nodep:2.1.2",
+			Name:    "cglib:cglib-nodep",
+			Version: "2.1.2",
+		},
+		{
+			ID:      "com.google.guava:guava:32.1.3-jre",
+			Name:    "com.google.guava:guava",
+			Version: "32.1.3-jre",
+		},
+		{
+			ID:      "org.springframework:spring-asm:3.1.3.RELEASE",
+			Name:    "org.springframework:spring-asm",
+			Version: "3.1.3.RELEASE",
+		},
+		{
+			ID:      "org.springframework:spring-beans:5.0.5.RELEASE",
+			Name:    "org.springframework:spring-beans",
+			Version: "5.0.5.RELEASE",
+		},
+	}
+
+	// The merged packages must not depend on the number of workers and the completion order
+	for _, workers := range []int{0, 1, 2, len(paths)} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			got, err := NewParser().ParseFiles(context.Background(), os.DirFS("testdata"), paths, workers)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+
+	t.Run("missing lockfile", func(t *testing.T) {
+		_, err := NewParser().ParseFiles(context.Background(), os.DirFS("testdata"), []string{
+			"happy.lockfile",
+			"missing.lockfile",
+		}, 2)
+		require.ErrorContains(t, err, "failed to parse missing.lockfile")
+	})
+}
+
+func TestParser_ParseEach(t *testing.T) {
+	paths := []string{
+		"multi-module.lockfile",
+		"no-dependencies.lockfile",
+		"crlf-bom.lockfile",
+	}
+
+	// The packages must be returned in the order of the lockfiles regardless of the completion order
+	for _, workers := range []int{1, len(paths)} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			got, err := NewParser().ParseEach(context.Background(), os.DirFS("testdata"), paths, workers)
+			require.NoError(t, err)
+			require.Len(t, got, len(paths))
+
+			assert.Equal(t, []string{
+				"com.google.guava:guava:32.1.3-jre",
+				"org.springframework:spring-asm:3.1.3.RELEASE",
+			}, packageIDs(got[0]))
+			assert.Empty(t, got[1])
+			assert.Equal(t, ftypes.Locations{
+				{
+					StartLine: 4,
+					EndLine:   4,
+				},
+			}, got[0][0].Locations)
+			assert.NotEmpty(t, got[2])
+		})
+	}
+}
+
+func packageIDs(pkgs []ftypes.Package) []string {
+	var ids []string
+	for _, pkg := range pkgs {
+		ids = append(ids, pkg.ID)
+	}
+	return ids
+}
+
+func BenchmarkParser_ParseFiles(b *testing.B) {
+	// A multi-module project with 100 modules locking 200 dependencies each, half of them shared
+	dir := b.TempDir()
+	var paths []string
+	for i := range 100 {
+		var lines []string
+		for j := range 200 {
+			group := "com.example.shared"
+			if j%2 == 1 {
+				group = fmt.Sprintf("com.example.module%d", i)
+			}
+			lines = append(lines, fmt.Sprintf("%s:lib%d:1.0.%d=compileClasspath,runtimeClasspath", group, j, j))
+		}
+		name := fmt.Sprintf("module%d.lockfile", i)
+		require.NoError(b, os.WriteFile(filepath.Join(dir, name), []byte(strings.Join(lines, "\n")), 0o644))
+		paths = append(paths, name)
+	}
+
+	for _, workers := range []int{1, 5, 10} {
+		b.Run(fmt.Sprintf("%d workers", workers), func(b *testing.B) {
+			parser := NewParser()
+			for range b.N {
+				_, err := parser.ParseFiles(context.Background(), os.DirFS(dir), paths, workers)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
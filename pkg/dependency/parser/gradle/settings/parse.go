@@ -0,0 +1,105 @@
+package settings
+
+import (
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// FileNames are the names of Gradle settings files for Groovy and Kotlin DSL
+var FileNames = []string{
+	"settings.gradle",
+	"settings.gradle.kts",
+}
+
+var (
+	// e.g.
+	//   include 'app', ':lib:core'
+	//   include(":app", ":lib:core")
+	//   include(
+	//       "app",
+	//       "lib"
+	//   )
+	//   include(listOf("app", "lib"))
+	includeRegexp = regexp.MustCompile(`\binclude\b\s*\(?\s*(?:\[|listOf\s*\()?((?:\s*["'][^"'\n]*["']\s*,?)+)`)
+	stringRegexp  = regexp.MustCompile(`["']([^"'\n]*)["']`)
+)
+
+// Subprojects returns the directories of the subprojects included by the settings file.
+// A project path like ":lib:core" is mapped to "lib/core" as Gradle does by default,
+// and its parent project ("lib") is also included.
+// The directories are resolved relative to the directory of the settings file (filePath).
+func Subprojects(filePath string, r io.Reader) ([]string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to read %s: %w", filePath, err)
+	}
+
+	content := stripComments(string(b))
+
+	rootDir := path.Dir(filePath)
+	seen := make(map[string]struct{})
+	var dirs []string
+	for _, include := range includeRegexp.FindAllStringSubmatch(content, -1) {
+		for _, s := range stringRegexp.FindAllStringSubmatch(include[1], -1) {
+			projectPath := strings.Trim(strings.TrimSpace(s[1]), ":")
+			if projectPath == "" {
+				continue
+			}
+
+			// Gradle includes the ancestors of nested projects as well
+			names := strings.Split(projectPath, ":")
+			for i := range names {
+				dir := path.Join(rootDir, path.Join(names[:i+1]...))
+				if _, ok := seen[dir]; ok {
+					continue
+				}
+				seen[dir] = struct{}{}
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs, nil
+}
+
+// stripComments removes line and block comments outside string literals,
+// so that "//" and "/*" in strings such as URLs are kept.
+func stripComments(s string) string {
+	var sb strings.Builder
+	var quote byte // The quote of the string literal being read, or 0 outside strings
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && i+1 < len(s) {
+				sb.WriteByte(c)
+				i++
+				c = s[i]
+			} else if c == quote || c == '\n' {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case strings.HasPrefix(s[i:], "//"):
+			// Keep the line break
+			n := strings.IndexByte(s[i:], '\n')
+			if n < 0 {
+				return sb.String()
+			}
+			i += n - 1
+			continue
+		case strings.HasPrefix(s[i:], "/*"):
+			n := strings.Index(s[i+2:], "*/")
+			if n < 0 {
+				return sb.String()
+			}
+			i += n + 3
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
@@ -0,0 +1,60 @@
+package settings
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubprojects(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputFile string
+		want      []string
+	}{
+		{
+			name:      "groovy",
+			inputFile: "testdata/settings.gradle",
+			want: []string{
+				"testdata/app",
+				"testdata/lib",
+				"testdata/lib/core",
+				"testdata/web",
+				"testdata/cli",
+				"testdata/tools",
+			},
+		},
+		{
+			name:      "kotlin",
+			inputFile: "testdata/settings.gradle.kts",
+			want: []string{
+				"testdata/app",
+				"testdata/lib",
+				"testdata/lib/core",
+				"testdata/web",
+				"testdata/cli",
+				"testdata/tools",
+				"testdata/docs",
+			},
+		},
+		{
+			name:      "no subprojects",
+			inputFile: "testdata/no-include.gradle",
+			want:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			got, err := Subprojects(tt.inputFile, f)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+rootProject.name = "multi-module"
+
+include("app", ":lib:core")
+include(
+    ":web",
+    ":cli", // command line interface
+)
+include(listOf(":tools"))
+val docsUrl = "https://example.com/docs"; include(":docs")
+includeBuild("build-logic")
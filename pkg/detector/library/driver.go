@@ -140,6 +140,35 @@ func (d *Driver) DetectVulnerabilities(pkgID, pkgName, pkgVer string) ([]types.D
 	return vulns, nil
 }
 
+// AffectedRange returns the vulnerable versions of the package in the advisory, e.g. ">=1.0.0, <1.4.2".
+// Multiple ranges are joined with " || ", and discrete versions with ", ".
+// It returns an empty string when the advisory has no vulnerable versions, e.g. only patched versions.
+func (d *Driver) AffectedRange(pkgName, vulnID string) (string, error) {
+	prefix := fmt.Sprintf("%s::", d.ecosystem)
+	advisories, err := d.dbc.GetAdvisories(prefix, vulnerability.NormalizePkgName(d.ecosystem, pkgName))
+	if err != nil {
+		return "", xerrors.Errorf("failed to get %s advisories: %w", d.ecosystem, err)
+	}
+
+	for _, adv := range advisories {
+		if adv.VulnerabilityID != vulnID {
+			continue
+		}
+		// e.g. ["=1.2.3", "=1.2.4"] => "1.2.3, 1.2.4"
+		discrete := lo.EveryBy(adv.VulnerableVersions, func(v string) bool {
+			return !strings.ContainsAny(strings.TrimPrefix(v, "="), "<>=!~^*|, ")
+		})
+		if discrete {
+			versions := lo.Map(adv.VulnerableVersions, func(v string, _ int) string {
+				return strings.TrimPrefix(v, "=")
+			})
+			return strings.Join(versions, ", "), nil
+		}
+		return strings.Join(adv.VulnerableVersions, " || "), nil
+	}
+	return "", nil
+}
+
 func createFixedVersions(advisory dbTypes.Advisory) string {
 	if len(advisory.PatchedVersions) != 0 {
 		return joinFixedVersions(advisory.PatchedVersions)
@@ -205,3 +205,56 @@ func TestDriver_Detect(t *testing.T) {
 		})
 	}
 }
+
+func TestDriver_AffectedRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		pkgName string
+		vulnID  string
+		want    string
+	}{
+		{
+			name:    "range",
+			pkgName: "lodash",
+			vulnID:  "CVE-2021-23337",
+			want:    ">=4.0.0, <4.17.21",
+		},
+		{
+			name:    "multiple ranges",
+			pkgName: "lodash",
+			vulnID:  "CVE-2020-8203",
+			want:    ">=3.7.0, <4.17.19 || >=1.0.0, <3.0.0",
+		},
+		{
+			name:    "discrete versions",
+			pkgName: "lodash",
+			vulnID:  "CVE-2019-1010266",
+			want:    "4.17.10, 4.17.11",
+		},
+		{
+			name:    "patched versions only",
+			pkgName: "lodash",
+			vulnID:  "CVE-2018-16487",
+			want:    "",
+		},
+		{
+			name:    "unknown vulnerability",
+			pkgName: "lodash",
+			vulnID:  "CVE-2000-0001",
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = dbtest.InitDB(t, []string{"testdata/fixtures/npm.yaml"})
+			defer db.Close()
+
+			driver, ok := library.NewDriver(ftypes.Npm)
+			require.True(t, ok)
+
+			got, err := driver.AffectedRange(tt.pkgName, tt.vulnID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
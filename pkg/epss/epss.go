@@ -0,0 +1,117 @@
+package epss
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/utils"
+	"github.com/aquasecurity/trivy/pkg/feed"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// DefaultSource is the daily EPSS dataset published by FIRST.
+// cf. https://www.first.org/epss/data_stats
+const DefaultSource = "https://epss.cyentia.com/epss_scores-current.csv.gz"
+
+// The EPSS dataset is cached in the cache dir and downloaded again after a day as it is updated daily
+var dataFeed = feed.Feed{
+	Name:           "EPSS dataset",
+	Dir:            "epss",
+	FileName:       "epss_scores.csv",
+	UpdateInterval: 24 * time.Hour,
+}
+
+// Scores holds the EPSS data keyed by CVE ID
+type Scores map[string]types.EPSS
+
+// Load reads the EPSS dataset from the source, which is either a URL or a path to a local file.
+// The dataset is the CSV file published by FIRST, optionally compressed with gzip.
+// A dataset on the Internet is cached in the cache dir, and only the cached copy is used in offline mode.
+func Load(ctx context.Context, source string, opts feed.Options) (Scores, error) {
+	if !strings.HasPrefix(source, "https://") && !strings.HasPrefix(source, "http://") {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to open the EPSS dataset: %w", err)
+		}
+		defer f.Close()
+		return Parse(f)
+	}
+
+	f, err := dataFeed.Open(ctx, source, opts)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to fetch the EPSS dataset: %w", err)
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse parses the EPSS dataset in CSV, optionally compressed with gzip.
+//
+//	#model_version:v2023.03.01,score_date:2024-10-01T00:00:00+0000
+//	cve,epss,percentile
+//	CVE-1999-0001,0.01141,0.84286
+func Parse(r io.Reader) (Scores, error) {
+	br := bufio.NewReader(r)
+	var rr io.Reader = br
+	if utils.IsGzip(br) {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to decompress the EPSS dataset: %w", err)
+		}
+		defer gr.Close()
+		rr = gr
+	}
+
+	cr := csv.NewReader(rr)
+	cr.Comment = '#' // The first line holds the model version and the score date
+	cr.FieldsPerRecord = 3
+
+	scores := make(Scores)
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, xerrors.Errorf("failed to parse the EPSS dataset: %w", err)
+		}
+		if record[0] == "cve" { // header
+			continue
+		}
+
+		score, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid EPSS score for %s: %w", record[0], err)
+		}
+		percentile, err := strconv.ParseFloat(record[2], 64)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid EPSS percentile for %s: %w", record[0], err)
+		}
+		scores[record[0]] = types.EPSS{
+			Score:      score,
+			Percentile: percentile,
+		}
+	}
+	return scores, nil
+}
+
+// Fill sets the EPSS data of the vulnerabilities that have an entry in the dataset
+func (s Scores) Fill(results types.Results) {
+	for i := range results {
+		for j := range results[i].Vulnerabilities {
+			vuln := &results[i].Vulnerabilities[j]
+			if score, ok := s[vuln.VulnerabilityID]; ok {
+				vuln.EPSS = &score
+			}
+		}
+	}
+}
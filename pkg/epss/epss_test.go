@@ -0,0 +1,108 @@
+package epss_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/feed"
+	"github.com/aquasecurity/trivy/pkg/epss"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestLoad(t *testing.T) {
+	want := epss.Scores{
+		"CVE-2021-44228": {
+			Score:      0.97565,
+			Percentile: 0.99996,
+		},
+		"CVE-2022-22965": {
+			Score:      0.97467,
+			Percentile: 0.99966,
+		},
+		"CVE-2019-0001": {
+			Score:      0.00428,
+			Percentile: 0.74589,
+		},
+	}
+
+	ts := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	t.Cleanup(ts.Close)
+
+	tests := []struct {
+		name    string
+		source  string
+		want    epss.Scores
+		wantErr string
+	}{
+		{
+			name:   "local file",
+			source: "testdata/epss_scores.csv",
+			want:   want,
+		},
+		{
+			name:   "gzipped local file",
+			source: "testdata/epss_scores.csv.gz",
+			want:   want,
+		},
+		{
+			name:   "fetch",
+			source: ts.URL + "/epss_scores.csv.gz",
+			want:   want,
+		},
+		{
+			name:    "not found",
+			source:  ts.URL + "/missing.csv.gz",
+			wantErr: "bad response code: 404",
+		},
+		{
+			name:    "missing file",
+			source:  "testdata/missing.csv",
+			wantErr: "failed to open the EPSS dataset",
+		},
+		{
+			name:    "invalid score",
+			source:  "testdata/invalid.csv",
+			wantErr: "invalid EPSS score for CVE-2021-44228",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := epss.Load(context.Background(), tt.source, feed.Options{CacheDir: t.TempDir()})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestScores_Fill(t *testing.T) {
+	scores := epss.Scores{
+		"CVE-2021-44228": {
+			Score:      0.97565,
+			Percentile: 0.99996,
+		},
+	}
+	results := types.Results{
+		{
+			Vulnerabilities: []types.DetectedVulnerability{
+				{VulnerabilityID: "CVE-2021-44228"},
+				{VulnerabilityID: "GHSA-jfh8-c2jp-5v3q"},
+			},
+		},
+	}
+
+	scores.Fill(results)
+	assert.Equal(t, &types.EPSS{
+		Score:      0.97565,
+		Percentile: 0.99996,
+	}, results[0].Vulnerabilities[0].EPSS)
+	assert.Nil(t, results[0].Vulnerabilities[1].EPSS)
+}
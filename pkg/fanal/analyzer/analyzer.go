@@ -52,6 +52,9 @@ type AnalyzerOptions struct {
 
 type SecretScannerOption struct {
 	ConfigPath string
+
+	// Calculate the entropy and the confidence of secrets
+	Entropy bool
 }
 
 type LicenseScannerOption struct {
@@ -454,6 +457,20 @@ func (ag AnalyzerGroup) AnalyzeFile(ctx context.Context, wg *sync.WaitGroup, lim
 	return nil
 }
 
+// Required returns true if any analyzer or post-analyzer requires the given file.
+func (ag AnalyzerGroup) Required(filePath string, info os.FileInfo) bool {
+	if info.IsDir() {
+		return false
+	}
+	cleanPath := strings.TrimLeft(filePath, "/")
+	for _, a := range ag.analyzers {
+		if ag.filePatternMatch(a.Type(), cleanPath) || a.Required(cleanPath, info) {
+			return true
+		}
+	}
+	return len(ag.RequiredPostAnalyzers(filePath, info)) > 0
+}
+
 // RequiredPostAnalyzers returns a list of analyzer types that require the given file.
 func (ag AnalyzerGroup) RequiredPostAnalyzers(filePath string, info os.FileInfo) []Type {
 	if info.IsDir() {
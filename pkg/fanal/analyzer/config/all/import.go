@@ -7,6 +7,7 @@ import (
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/helm"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/json"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/k8s"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/systemd"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/terraform"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/terraformplan/json"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/terraformplan/snapshot"
@@ -0,0 +1,37 @@
+package systemd
+
+import (
+	"os"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/config"
+	"github.com/aquasecurity/trivy/pkg/iac/detection"
+)
+
+const (
+	analyzerType = analyzer.TypeSystemd
+	version      = 1
+)
+
+func init() {
+	analyzer.RegisterPostAnalyzer(analyzerType, newSystemdConfigAnalyzer)
+}
+
+// systemdConfigAnalyzer is an analyzer for detecting misconfigurations in systemd unit files.
+// It embeds config.Analyzer so it can implement analyzer.PostAnalyzer.
+type systemdConfigAnalyzer struct {
+	*config.Analyzer
+}
+
+func newSystemdConfigAnalyzer(opts analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
+	a, err := config.NewAnalyzer(analyzerType, version, detection.FileTypeSystemd, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &systemdConfigAnalyzer{Analyzer: a}, nil
+}
+
+// Required overrides config.Analyzer.Required() and checks if the given file is a systemd unit file.
+func (*systemdConfigAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return detection.IsSystemdUnitFile(filePath)
+}
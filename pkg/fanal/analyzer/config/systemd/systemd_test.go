@@ -0,0 +1,43 @@
+package systemd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_systemdConfigAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "service",
+			filePath: "etc/systemd/system/nginx.service",
+			want:     true,
+		},
+		{
+			name:     "upper case",
+			filePath: "nginx.SERVICE",
+			want:     true,
+		},
+		{
+			name:     "socket",
+			filePath: "etc/systemd/system/nginx.socket",
+			want:     false,
+		},
+		{
+			name:     "drop-in",
+			filePath: "etc/systemd/system/nginx.service.d/override.conf",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := systemdConfigAnalyzer{}
+			got := a.Required(tt.filePath, nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
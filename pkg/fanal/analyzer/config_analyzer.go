@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"cmp"
 	"context"
 	"slices"
 
@@ -58,7 +59,14 @@ func (r *ConfigAnalysisResult) Merge(newResult *ConfigAnalysisResult) {
 		return
 	}
 	if newResult.Misconfiguration != nil {
-		r.Misconfiguration = newResult.Misconfiguration
+		if r.Misconfiguration == nil {
+			r.Misconfiguration = newResult.Misconfiguration
+		} else {
+			// The history and the fields of the image config are reported as misconfigurations of the same target
+			r.Misconfiguration.Successes = append(r.Misconfiguration.Successes, newResult.Misconfiguration.Successes...)
+			r.Misconfiguration.Warnings = append(r.Misconfiguration.Warnings, newResult.Misconfiguration.Warnings...)
+			r.Misconfiguration.Failures = append(r.Misconfiguration.Failures, newResult.Misconfiguration.Failures...)
+		}
 	}
 	if newResult.Secret != nil {
 		r.Secret = newResult.Secret
@@ -87,6 +95,11 @@ func NewConfigAnalyzerGroup(opts ConfigAnalyzerOptions) (ConfigAnalyzerGroup, er
 		g.configAnalyzers = append(g.configAnalyzers, a)
 	}
 
+	// Analyzers run in a fixed order so that the merged misconfigurations are stable
+	slices.SortFunc(g.configAnalyzers, func(a, b ConfigAnalyzer) int {
+		return cmp.Compare(a.Type(), b.Type())
+	})
+
 	return g, nil
 }
 
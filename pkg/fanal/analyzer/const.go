@@ -111,9 +111,10 @@ const (
 	// ============
 	// Image Config
 	// ============
-	TypeApkCommand        Type = "apk-command"
-	TypeHistoryDockerfile Type = "history-dockerfile"
-	TypeImageConfigSecret Type = "image-config-secret"
+	TypeApkCommand         Type = "apk-command"
+	TypeHistoryDockerfile  Type = "history-dockerfile"
+	TypeImageConfigSecret  Type = "image-config-secret"
+	TypeImageConfigMisconf Type = "image-config-misconf"
 
 	// =================
 	// Structured Config
@@ -126,6 +127,7 @@ const (
 	TypeTerraform             Type = Type(detection.FileTypeTerraform)
 	TypeTerraformPlanJSON     Type = Type(detection.FileTypeTerraformPlanJSON)
 	TypeTerraformPlanSnapshot Type = Type(detection.FileTypeTerraformPlanSnapshot)
+	TypeSystemd               Type = Type(detection.FileTypeSystemd)
 	TypeYAML                  Type = Type(detection.FileTypeYAML)
 	TypeJSON                  Type = Type(detection.FileTypeJSON)
 
@@ -249,6 +251,7 @@ var (
 		TypeTerraform,
 		TypeTerraformPlanJSON,
 		TypeTerraformPlanSnapshot,
+		TypeSystemd,
 		TypeYAML,
 		TypeJSON,
 	}
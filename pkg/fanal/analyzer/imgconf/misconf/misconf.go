@@ -0,0 +1,202 @@
+package misconf
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/samber/lo"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+const (
+	analyzerVersion = 3
+
+	// namespace is not a built-in namespace so that the links of the checks point to the references
+	namespace = "imageconfig"
+)
+
+func init() {
+	analyzer.RegisterConfigAnalyzer(analyzer.TypeImageConfigMisconf, newConfigAnalyzer)
+}
+
+// check evaluates a field of the container image config
+type check struct {
+	types.PolicyMetadata
+
+	// field is the field of the image config, e.g. "config.User"
+	field string
+
+	// evaluate returns the messages of the failures, or nothing if the config passes the check
+	evaluate func(config v1.Config) []string
+}
+
+// checks evaluate the final values of the config, so they work even if the history is missing or squashed.
+// The SSH port and the HEALTHCHECK are left to the Dockerfile checks DS004 and DS026.
+var checks = []check{
+	{
+		PolicyMetadata: types.PolicyMetadata{
+			ID:                 "IC001",
+			AVDID:              "AVD-IC-0001",
+			Type:               "Image config check",
+			Title:              "Image runs as root",
+			Description:        "Running containers as root gives an attacker who escapes the application full control of the container.",
+			Severity:           "HIGH",
+			RecommendedActions: "Add a USER instruction with a non-root user to the Dockerfile.",
+			References:         []string{"https://docs.docker.com/reference/dockerfile/#user"},
+		},
+		field:    "config.User",
+		evaluate: checkRootUser,
+	},
+	{
+		PolicyMetadata: types.PolicyMetadata{
+			ID:                 "IC002",
+			AVDID:              "AVD-IC-0002",
+			Type:               "Image config check",
+			Title:              "Sensitive environment variable",
+			Description:        "Environment variables are stored in the image config and can be read by anyone who can pull the image.",
+			Severity:           "HIGH",
+			RecommendedActions: "Pass secrets at runtime, e.g. with build secrets or mounted files, instead of ENV instructions.",
+			References:         []string{"https://docs.docker.com/build/building/secrets/"},
+		},
+		field:    "config.Env",
+		evaluate: checkSensitiveEnv,
+	},
+	{
+		PolicyMetadata: types.PolicyMetadata{
+			ID:                 "IC003",
+			AVDID:              "AVD-IC-0003",
+			Type:               "Image config check",
+			Title:              "Privileged port exposed",
+			Description:        "Binding a port below 1024 requires root or the NET_BIND_SERVICE capability.",
+			Severity:           "MEDIUM",
+			RecommendedActions: "Expose a port of 1024 or above and map it to the privileged port on the host if necessary.",
+			References:         []string{"https://docs.docker.com/reference/dockerfile/#expose"},
+		},
+		field:    "config.ExposedPorts",
+		evaluate: checkPrivilegedPorts,
+	},
+}
+
+// sensitiveEnvKeys are the parts of the names of environment variables which usually hold secrets
+var sensitiveEnvKeys = []string{
+	"PASSWORD",
+	"PASSWD",
+	"SECRET",
+	"TOKEN",
+	"API_KEY",
+	"APIKEY",
+	"PRIVATE_KEY",
+	"ACCESS_KEY",
+	"CREDENTIAL",
+}
+
+// sshPort is reported by the Dockerfile check DS004
+const sshPort = 22
+
+// configAnalyzer checks the fields of the container image config, such as the user and the exposed ports.
+// Unlike the history analyzer, it doesn't depend on the history, which may be missing or squashed.
+type configAnalyzer struct{}
+
+func newConfigAnalyzer(_ analyzer.ConfigAnalyzerOptions) (analyzer.ConfigAnalyzer, error) {
+	return &configAnalyzer{}, nil
+}
+
+func (a *configAnalyzer) Analyze(_ context.Context, input analyzer.ConfigAnalysisInput) (*analyzer.
+	ConfigAnalysisResult, error) {
+	if input.Config == nil {
+		return nil, nil
+	}
+
+	// The image config is reported in the same result as the history Dockerfile
+	misconf := &types.Misconfiguration{
+		FileType: types.Dockerfile,
+		FilePath: "config.json",
+	}
+	for _, c := range checks {
+		res := types.MisconfResult{
+			Namespace:      namespace,
+			PolicyMetadata: c.PolicyMetadata,
+			CauseMetadata: types.CauseMetadata{
+				Resource: c.field,
+			},
+		}
+		messages := c.evaluate(input.Config.Config)
+		if len(messages) == 0 {
+			misconf.Successes = append(misconf.Successes, res)
+			continue
+		}
+		for _, msg := range messages {
+			res.Message = msg
+			misconf.Failures = append(misconf.Failures, res)
+		}
+	}
+
+	return &analyzer.ConfigAnalysisResult{
+		Misconfiguration: misconf,
+	}, nil
+}
+
+func (a *configAnalyzer) Required(_ types.OS) bool {
+	return true
+}
+
+func (a *configAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeImageConfigMisconf
+}
+
+func (a *configAnalyzer) Version() int {
+	return analyzerVersion
+}
+
+// checkRootUser fails if the user is not set or the user is root, e.g. "root", "0" or "0:0"
+func checkRootUser(config v1.Config) []string {
+	user, _, _ := strings.Cut(config.User, ":")
+	switch user {
+	case "":
+		return []string{"config.User is not set, so the image runs as root"}
+	case "root", "0":
+		return []string{fmt.Sprintf("config.User is %q", config.User)}
+	}
+	return nil
+}
+
+// checkSensitiveEnv fails for each environment variable which is likely to hold a secret.
+// Only the names are reported so that the secrets don't leak into the report.
+func checkSensitiveEnv(config v1.Config) []string {
+	var messages []string
+	for _, env := range config.Env {
+		name, value, _ := strings.Cut(env, "=")
+		if value == "" {
+			continue
+		}
+		upper := strings.ToUpper(name)
+		// Paths to secret files, e.g. "POSTGRES_PASSWORD_FILE=/run/secrets/password", are fine
+		if strings.HasSuffix(upper, "_FILE") || strings.HasSuffix(upper, "_PATH") {
+			continue
+		}
+		if lo.ContainsBy(sensitiveEnvKeys, func(key string) bool { return strings.Contains(upper, key) }) {
+			messages = append(messages, fmt.Sprintf("config.Env has %q, which may contain a secret", name))
+		}
+	}
+	return messages
+}
+
+// checkPrivilegedPorts fails for each exposed port below 1024 except for the SSH port, e.g. "80/tcp"
+func checkPrivilegedPorts(config v1.Config) []string {
+	var messages []string
+	ports := lo.Keys(config.ExposedPorts)
+	slices.Sort(ports)
+	for _, port := range ports {
+		num, _, _ := strings.Cut(port, "/")
+		if n, err := strconv.Atoi(num); err == nil && n > 0 && n < 1024 && n != sshPort {
+			messages = append(messages, fmt.Sprintf("config.ExposedPorts has the privileged port %s", port))
+		}
+	}
+	return messages
+}
@@ -0,0 +1,178 @@
+package misconf
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func Test_configAnalyzer_Analyze(t *testing.T) {
+	type result struct {
+		ID       string
+		Resource string
+		Message  string
+	}
+
+	tests := []struct {
+		name          string
+		config        *v1.ConfigFile
+		configFile    string
+		wantFailures  []result
+		wantSuccesses []string
+	}{
+		{
+			name:       "root with secrets in ENV",
+			configFile: "testdata/root-with-secrets.json",
+			wantFailures: []result{
+				{
+					ID:       "IC001",
+					Resource: "config.User",
+					Message:  "config.User is not set, so the image runs as root",
+				},
+				{
+					ID:       "IC002",
+					Resource: "config.Env",
+					Message:  `config.Env has "DB_PASSWORD", which may contain a secret`,
+				},
+				{
+					ID:       "IC002",
+					Resource: "config.Env",
+					Message:  `config.Env has "AWS_SECRET_ACCESS_KEY", which may contain a secret`,
+				},
+			},
+			wantSuccesses: []string{
+				"IC003",
+			},
+		},
+		{
+			name:       "privileged ports",
+			configFile: "testdata/privileged-ports.json",
+			wantFailures: []result{
+				{
+					ID:       "IC003",
+					Resource: "config.ExposedPorts",
+					Message:  "config.ExposedPorts has the privileged port 443/tcp",
+				},
+				{
+					ID:       "IC003",
+					Resource: "config.ExposedPorts",
+					Message:  "config.ExposedPorts has the privileged port 80/tcp",
+				},
+			},
+			wantSuccesses: []string{
+				"IC001",
+				"IC002",
+			},
+		},
+		{
+			name: "explicit root user",
+			config: &v1.ConfigFile{
+				Config: v1.Config{
+					User: "0:0",
+					Env: []string{
+						"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
+					},
+				},
+			},
+			wantFailures: []result{
+				{
+					ID:       "IC001",
+					Resource: "config.User",
+					Message:  `config.User is "0:0"`,
+				},
+			},
+			wantSuccesses: []string{
+				"IC002",
+				"IC003",
+			},
+		},
+		{
+			name: "SSH port reported by DS004",
+			config: &v1.ConfigFile{
+				Config: v1.Config{
+					User: "nonroot",
+					ExposedPorts: map[string]struct{}{
+						"22/tcp": {},
+					},
+				},
+			},
+			wantSuccesses: []string{
+				"IC001",
+				"IC002",
+				"IC003",
+			},
+		},
+		{
+			name: "non-root user with unprivileged port",
+			config: &v1.ConfigFile{
+				Config: v1.Config{
+					User: "nonroot",
+					ExposedPorts: map[string]struct{}{
+						"8080/tcp": {},
+					},
+					Env: []string{
+						"POSTGRES_PASSWORD_FILE=/run/secrets/postgres-password",
+					},
+				},
+			},
+			wantSuccesses: []string{
+				"IC001",
+				"IC002",
+				"IC003",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			if tt.configFile != "" {
+				f, err := os.Open(tt.configFile)
+				require.NoError(t, err)
+				defer f.Close()
+				config, err = v1.ParseConfigFile(f)
+				require.NoError(t, err)
+			}
+
+			a, err := newConfigAnalyzer(analyzer.ConfigAnalyzerOptions{})
+			require.NoError(t, err)
+			got, err := a.Analyze(context.Background(), analyzer.ConfigAnalysisInput{
+				Config: config,
+			})
+			require.NoError(t, err)
+			require.NotNil(t, got.Misconfiguration)
+			assert.Equal(t, types.Dockerfile, got.Misconfiguration.FileType)
+
+			var failures []result
+			for _, f := range got.Misconfiguration.Failures {
+				assert.Equal(t, namespace, f.Namespace)
+				failures = append(failures, result{
+					ID:       f.ID,
+					Resource: f.Resource,
+					Message:  f.Message,
+				})
+			}
+			assert.Equal(t, tt.wantFailures, failures)
+
+			var successes []string
+			for _, s := range got.Misconfiguration.Successes {
+				successes = append(successes, s.ID)
+			}
+			assert.Equal(t, tt.wantSuccesses, successes)
+		})
+	}
+}
+
+func Test_configAnalyzer_Analyze_noConfig(t *testing.T) {
+	a, err := newConfigAnalyzer(analyzer.ConfigAnalyzerOptions{})
+	require.NoError(t, err)
+	got, err := a.Analyze(context.Background(), analyzer.ConfigAnalysisInput{})
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -29,7 +29,7 @@ func newSecretAnalyzer(opts analyzer.ConfigAnalyzerOptions) (analyzer.ConfigAnal
 	if err != nil {
 		return nil, xerrors.Errorf("secret config error: %w", err)
 	}
-	scanner := secret.NewScanner(c)
+	scanner := secret.NewScanner(c, secret.WithEntropy(opts.SecretScannerOption.Entropy))
 
 	return &secretAnalyzer{
 		scanner: scanner,
@@ -62,6 +62,11 @@ func Parse(fileType types.LangType, filePath string, r io.Reader, parser Parser)
 	return toApplication(fileType, filePath, "", nil, parsedPkgs, parsedDependencies), nil
 }
 
+// ToApplication returns an application of the packages already parsed from the lock file, e.g. concurrently.
+func ToApplication(fileType types.LangType, filePath string, pkgs []types.Package, deps []types.Dependency) *types.Application {
+	return toApplication(fileType, filePath, "", nil, pkgs, deps)
+}
+
 // ParsePackage returns a parsed result of the package file
 func ParsePackage(fileType types.LangType, filePath string, r xio.ReadSeekerAt, parser Parser, checksum bool) (*types.Application, error) {
 	parsedPkgs, parsedDependencies, err := parser.Parse(r)
@@ -6,6 +6,8 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"path"
+	"slices"
 	"sort"
 	"strings"
 
@@ -13,6 +15,7 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/aquasecurity/trivy/pkg/dependency/parser/gradle/lockfile"
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/gradle/settings"
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/language"
 	"github.com/aquasecurity/trivy/pkg/fanal/types"
@@ -25,24 +28,27 @@ func init() {
 }
 
 const (
-	version        = 2
+	version        = 3
 	fileNameSuffix = "gradle.lockfile"
 )
 
-// gradleLockAnalyzer analyzes '*gradle.lockfile'
+// gradleLockAnalyzer analyzes '*gradle.lockfile'.
+// It also reads 'settings.gradle(.kts)' to find subprojects that are not locked.
 type gradleLockAnalyzer struct {
-	logger *log.Logger
-	parser language.Parser
+	logger   *log.Logger
+	parser   *lockfile.Parser
+	parallel int
 }
 
-func newGradleLockAnalyzer(_ analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
+func newGradleLockAnalyzer(opt analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
 	return &gradleLockAnalyzer{
-		logger: log.WithPrefix("gradle"),
-		parser: lockfile.NewParser(),
+		logger:   log.WithPrefix("gradle"),
+		parser:   lockfile.NewParser(),
+		parallel: opt.Parallel,
 	}, nil
 }
 
-func (a gradleLockAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAnalysisInput) (*analyzer.AnalysisResult, error) {
+func (a gradleLockAnalyzer) PostAnalyze(ctx context.Context, input analyzer.PostAnalysisInput) (*analyzer.AnalysisResult, error) {
 	poms, err := a.parsePoms()
 	if err != nil {
 		a.logger.Warn("Unable to get licenses and dependencies", log.Err(err))
@@ -52,16 +58,38 @@ func (a gradleLockAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAn
 		return a.Required(path, nil)
 	}
 
-	var apps []types.Application
+	subprojects := make(map[string][]string) // settings file => subproject directories
+	lockfiles := make(map[string]struct{})
+	var paths []string
 	err = fsutils.WalkDir(input.FS, ".", required, func(filePath string, _ fs.DirEntry, r io.Reader) error {
-		var app *types.Application
-		app, err = language.Parse(types.Gradle, filePath, r, a.parser)
-		if err != nil {
-			return xerrors.Errorf("%s parse error: %w", filePath, err)
+		if isSettingsFile(filePath) {
+			dirs, err := settings.Subprojects(filePath, r)
+			if err != nil {
+				a.logger.Debug("Unable to parse the settings file", log.FilePath(filePath), log.Err(err))
+				return nil
+			}
+			subprojects[filePath] = dirs
+			return nil
 		}
+		lockfiles[filePath] = struct{}{}
+		paths = append(paths, filePath)
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("walk error: %w", err)
+	}
 
+	// Multi-module projects have a lockfile per module, so they are parsed concurrently
+	parsed, err := a.parser.ParseEach(ctx, input.FS, paths, a.parallel)
+	if err != nil {
+		return nil, xerrors.Errorf("gradle lockfile parse error: %w", err)
+	}
+
+	var apps []types.Application
+	for j, filePath := range paths {
+		app := language.ToApplication(types.Gradle, filePath, parsed[j], nil)
 		if app == nil {
-			return nil
+			continue
 		}
 
 		pkgs := lo.SliceToMap(app.Packages, func(lib types.Package) (string, struct{}) {
@@ -92,10 +120,11 @@ func (a gradleLockAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAn
 
 		sort.Sort(app.Packages)
 		apps = append(apps, *app)
-		return nil
-	})
-	if err != nil {
-		return nil, xerrors.Errorf("walk error: %w", err)
+	}
+
+	for _, dir := range unlockedSubprojects(subprojects, lockfiles) {
+		a.logger.Warn("Subproject has no lockfile, so its dependencies are not detected. "+
+			"Run 'gradle dependencies --write-locks' to generate the lockfile", log.FilePath(dir))
 	}
 
 	return &analyzer.AnalysisResult{
@@ -104,7 +133,7 @@ func (a gradleLockAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAn
 }
 
 func (a gradleLockAnalyzer) Required(filePath string, _ os.FileInfo) bool {
-	return strings.HasSuffix(filePath, fileNameSuffix)
+	return strings.HasSuffix(filePath, fileNameSuffix) || isSettingsFile(filePath)
 }
 
 func (a gradleLockAnalyzer) Type() analyzer.Type {
@@ -118,3 +147,37 @@ func (a gradleLockAnalyzer) Version() int {
 func packageID(groupId, artifactId, ver string) string {
 	return fmt.Sprintf("%s:%s:%s", groupId, artifactId, ver)
 }
+
+func isSettingsFile(filePath string) bool {
+	return slices.Contains(settings.FileNames, path.Base(filePath))
+}
+
+// unlockedSubprojects returns the directories of the subprojects without 'gradle.lockfile'.
+// Only builds having at least one lockfile are checked, as builds without any lockfile don't use dependency locking.
+// Parent projects (e.g. "lib" for ":lib:core") are skipped as they often have no dependencies.
+func unlockedSubprojects(subprojects map[string][]string, lockfiles map[string]struct{}) []string {
+	var unlocked []string
+	for settingsFile, dirs := range subprojects {
+		rootDir := path.Dir(settingsFile)
+		locked := lo.ContainsBy(lo.Keys(lockfiles), func(lockfile string) bool {
+			return rootDir == "." || strings.HasPrefix(lockfile, rootDir+"/")
+		})
+		if !locked {
+			continue
+		}
+
+		for _, dir := range dirs {
+			isParent := lo.ContainsBy(dirs, func(d string) bool {
+				return strings.HasPrefix(d, dir+"/")
+			})
+			if isParent {
+				continue
+			}
+			if _, ok := lockfiles[path.Join(dir, fileNameSuffix)]; !ok {
+				unlocked = append(unlocked, dir)
+			}
+		}
+	}
+	sort.Strings(unlocked)
+	return unlocked
+}
@@ -102,6 +102,32 @@ func Test_gradleLockAnalyzer_Analyze(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "multi-module project",
+			dir:  "testdata/lockfiles/multi-module",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Gradle,
+						FilePath: "app/gradle.lockfile",
+						Packages: types.Packages{
+							{
+								ID:           "junit:junit:4.13",
+								Name:         "junit:junit",
+								Version:      "4.13",
+								Relationship: types.RelationshipUnknown,
+								Locations: []types.Location{
+									{
+										StartLine: 4,
+										EndLine:   4,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "empty file",
 			dir:  "testdata/lockfiles/empty",
@@ -144,6 +170,16 @@ func Test_nugetLibraryAnalyzer_Required(t *testing.T) {
 			filePath: "test/settings-gradle.lockfile",
 			want:     true,
 		},
+		{
+			name:     "settings.gradle",
+			filePath: "test/settings.gradle",
+			want:     true,
+		},
+		{
+			name:     "settings.gradle.kts",
+			filePath: "test/settings.gradle.kts",
+			want:     true,
+		},
 		{
 			name:     "txt",
 			filePath: "test/test.txt",
@@ -158,3 +194,75 @@ func Test_nugetLibraryAnalyzer_Required(t *testing.T) {
 		})
 	}
 }
+
+func Test_unlockedSubprojects(t *testing.T) {
+	tests := []struct {
+		name        string
+		subprojects map[string][]string
+		lockfiles   []string
+		want        []string
+	}{
+		{
+			name: "subproject without lockfile",
+			subprojects: map[string][]string{
+				"settings.gradle": {
+					"app",
+					"lib",
+				},
+			},
+			lockfiles: []string{
+				"gradle.lockfile",
+				"app/gradle.lockfile",
+			},
+			want: []string{"lib"},
+		},
+		{
+			name: "parent project is skipped",
+			subprojects: map[string][]string{
+				"settings.gradle.kts": {
+					"lib",
+					"lib/core",
+				},
+			},
+			lockfiles: []string{
+				"lib/core/gradle.lockfile",
+			},
+			want: nil,
+		},
+		{
+			name: "nested build",
+			subprojects: map[string][]string{
+				"backend/settings.gradle": {
+					"backend/app",
+					"backend/lib",
+				},
+			},
+			lockfiles: []string{
+				"backend/app/gradle.lockfile",
+			},
+			want: []string{"backend/lib"},
+		},
+		{
+			name: "dependency locking is not used",
+			subprojects: map[string][]string{
+				"backend/settings.gradle": {
+					"backend/app",
+				},
+			},
+			lockfiles: []string{
+				"frontend/gradle.lockfile",
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lockfiles := make(map[string]struct{})
+			for _, lockfile := range tt.lockfiles {
+				lockfiles[lockfile] = struct{}{}
+			}
+			got := unlockedSubprojects(tt.subprojects, lockfiles)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -95,7 +95,7 @@ func (a *SecretAnalyzer) Init(opt analyzer.AnalyzerOptions) error {
 	if err != nil {
 		return xerrors.Errorf("secret config error: %w", err)
 	}
-	a.scanner = secret.NewScanner(c)
+	a.scanner = secret.NewScanner(c, secret.WithEntropy(opt.SecretScannerOption.Entropy))
 	a.configPath = configPath
 	return nil
 }
@@ -29,6 +29,7 @@ type Option struct {
 	AWSEndpoint       string
 	FileChecksum      bool // For SPDX
 	DetectionPriority types.DetectionPriority
+	MaxTargets        int // Maximum number of files to analyze in filesystem scanning (0 means unlimited)
 
 	// Git repositories
 	RepoBranch string
@@ -352,17 +352,17 @@ func TestArtifact_Inspect(t *testing.T) {
 			missingBlobsExpectation: cache.ArtifactCacheMissingBlobsExpectation{
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:c232b7d8ac8aa08aa767313d0b53084c4380d1c01a213a5971bdb039e6538313",
-					BlobIDs:    []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+					BlobIDs:    []string{"sha256:a332a63be2dba6858452a9ede2748f9018ddddbca68df9c3796592066d9a4751"},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					MissingArtifact: true,
-					MissingBlobIDs:  []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+					MissingBlobIDs:  []string{"sha256:a332a63be2dba6858452a9ede2748f9018ddddbca68df9c3796592066d9a4751"},
 				},
 			},
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4",
+						BlobID: "sha256:a332a63be2dba6858452a9ede2748f9018ddddbca68df9c3796592066d9a4751",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -429,7 +429,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				Name:    "../../test/testdata/alpine-311.tar.gz",
 				Type:    artifact.TypeContainerImage,
 				ID:      "sha256:c232b7d8ac8aa08aa767313d0b53084c4380d1c01a213a5971bdb039e6538313",
-				BlobIDs: []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+				BlobIDs: []string{"sha256:a332a63be2dba6858452a9ede2748f9018ddddbca68df9c3796592066d9a4751"},
 				ImageMetadata: artifact.ImageMetadata{
 					ID: "sha256:a187dde48cd289ac374ad8539930628314bc581a481cdb41409c9289419ddb72",
 					DiffIDs: []string{
@@ -488,25 +488,25 @@ func TestArtifact_Inspect(t *testing.T) {
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:33f9415ed2cd5a9cef5d5144333619745b9ec0f851f0684dd45fa79c6b26a650",
 					BlobIDs: []string{
-						"sha256:4a26915356c961f038d5a7b7f73f24cd1eec53dcf6fdeecd39b310ddc066faec",
-						"sha256:e23e1d428a2a4ca9607cde5c556f744c7e9f3a1d3bfe835707c0fea107caf453",
-						"sha256:b8ae022ed4f8b8bf827c04a825c2e6998217581d44c0b28b59a4e66ca65bbaa5",
-						"sha256:8c51dcc708602d983f3f0507f0d26de609819c4391db92497639417e54378d11",
+						"sha256:8e1d109c96dd570b2a08b5f851ffa33b10b525ecb4573963c77dc26ac85ae12b",
+						"sha256:9d251214ef8152c268bec2ea09b8b00e9ee76477ecc708734c00a9f55efe8faa",
+						"sha256:573560b751ecab2f68fc22d5f4b106cb1d32d962f4fd4ebf8de547f08c0e09e1",
+						"sha256:9df98e433e89f7c08b11f4c51c018e00174374142a66321716b7f93b000bf327",
 					},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					MissingBlobIDs: []string{
-						"sha256:4a26915356c961f038d5a7b7f73f24cd1eec53dcf6fdeecd39b310ddc066faec",
-						"sha256:e23e1d428a2a4ca9607cde5c556f744c7e9f3a1d3bfe835707c0fea107caf453",
-						"sha256:b8ae022ed4f8b8bf827c04a825c2e6998217581d44c0b28b59a4e66ca65bbaa5",
-						"sha256:8c51dcc708602d983f3f0507f0d26de609819c4391db92497639417e54378d11",
+						"sha256:8e1d109c96dd570b2a08b5f851ffa33b10b525ecb4573963c77dc26ac85ae12b",
+						"sha256:9d251214ef8152c268bec2ea09b8b00e9ee76477ecc708734c00a9f55efe8faa",
+						"sha256:573560b751ecab2f68fc22d5f4b106cb1d32d962f4fd4ebf8de547f08c0e09e1",
+						"sha256:9df98e433e89f7c08b11f4c51c018e00174374142a66321716b7f93b000bf327",
 					},
 				},
 			},
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:4a26915356c961f038d5a7b7f73f24cd1eec53dcf6fdeecd39b310ddc066faec",
+						BlobID: "sha256:8e1d109c96dd570b2a08b5f851ffa33b10b525ecb4573963c77dc26ac85ae12b",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -595,7 +595,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:e23e1d428a2a4ca9607cde5c556f744c7e9f3a1d3bfe835707c0fea107caf453",
+						BlobID: "sha256:9d251214ef8152c268bec2ea09b8b00e9ee76477ecc708734c00a9f55efe8faa",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -691,7 +691,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:b8ae022ed4f8b8bf827c04a825c2e6998217581d44c0b28b59a4e66ca65bbaa5",
+						BlobID: "sha256:573560b751ecab2f68fc22d5f4b106cb1d32d962f4fd4ebf8de547f08c0e09e1",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -899,7 +899,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:8c51dcc708602d983f3f0507f0d26de609819c4391db92497639417e54378d11",
+						BlobID: "sha256:9df98e433e89f7c08b11f4c51c018e00174374142a66321716b7f93b000bf327",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1762,10 +1762,10 @@ func TestArtifact_Inspect(t *testing.T) {
 				Type: artifact.TypeContainerImage,
 				ID:   "sha256:33f9415ed2cd5a9cef5d5144333619745b9ec0f851f0684dd45fa79c6b26a650",
 				BlobIDs: []string{
-					"sha256:4a26915356c961f038d5a7b7f73f24cd1eec53dcf6fdeecd39b310ddc066faec",
-					"sha256:e23e1d428a2a4ca9607cde5c556f744c7e9f3a1d3bfe835707c0fea107caf453",
-					"sha256:b8ae022ed4f8b8bf827c04a825c2e6998217581d44c0b28b59a4e66ca65bbaa5",
-					"sha256:8c51dcc708602d983f3f0507f0d26de609819c4391db92497639417e54378d11",
+					"sha256:8e1d109c96dd570b2a08b5f851ffa33b10b525ecb4573963c77dc26ac85ae12b",
+					"sha256:9d251214ef8152c268bec2ea09b8b00e9ee76477ecc708734c00a9f55efe8faa",
+					"sha256:573560b751ecab2f68fc22d5f4b106cb1d32d962f4fd4ebf8de547f08c0e09e1",
+					"sha256:9df98e433e89f7c08b11f4c51c018e00174374142a66321716b7f93b000bf327",
 				},
 				ImageMetadata: artifact.ImageMetadata{
 					ID: "sha256:58701fd185bda36cab0557bb6438661831267aa4a9e0b54211c4d5317a48aff4",
@@ -1859,25 +1859,25 @@ func TestArtifact_Inspect(t *testing.T) {
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:33f9415ed2cd5a9cef5d5144333619745b9ec0f851f0684dd45fa79c6b26a650",
 					BlobIDs: []string{
-						"sha256:139bc12e936e0c46090b9380c4a29456d3ad8d8abd50c7bdc6160018cd887462",
-						"sha256:c491838e70ff0fcfdd0605af1ba84e86d6958c0846b16c52a84e06bb344e8e8d",
-						"sha256:25e775ef81049a93eafd865447b0b79da9e9956ab74bc02b5916eaea21c87c7c",
-						"sha256:a8a4798a22b65739cda9ca99ddb2cd86125c1dd86df6fc3971f937a0ff5b9ec3",
+						"sha256:7af71cba482c8da8aa8f67e62d06b20f051bc15f74f290ddfaa1e48d24a8b73f",
+						"sha256:91313f64a65ce639cbe8da725d62dcf42bcc82de54e86794437170aee36ddab3",
+						"sha256:465e27497fda5c36193f87e7db3e587b746ccde3bda44ae71e4f24584037a9a1",
+						"sha256:bf2c2f6e6fceaa43e57b2953a99b09b8d5f7ff8507f4395eb327bdd869f176da",
 					},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					MissingBlobIDs: []string{
-						"sha256:139bc12e936e0c46090b9380c4a29456d3ad8d8abd50c7bdc6160018cd887462",
-						"sha256:c491838e70ff0fcfdd0605af1ba84e86d6958c0846b16c52a84e06bb344e8e8d",
-						"sha256:25e775ef81049a93eafd865447b0b79da9e9956ab74bc02b5916eaea21c87c7c",
-						"sha256:a8a4798a22b65739cda9ca99ddb2cd86125c1dd86df6fc3971f937a0ff5b9ec3",
+						"sha256:7af71cba482c8da8aa8f67e62d06b20f051bc15f74f290ddfaa1e48d24a8b73f",
+						"sha256:91313f64a65ce639cbe8da725d62dcf42bcc82de54e86794437170aee36ddab3",
+						"sha256:465e27497fda5c36193f87e7db3e587b746ccde3bda44ae71e4f24584037a9a1",
+						"sha256:bf2c2f6e6fceaa43e57b2953a99b09b8d5f7ff8507f4395eb327bdd869f176da",
 					},
 				},
 			},
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:139bc12e936e0c46090b9380c4a29456d3ad8d8abd50c7bdc6160018cd887462",
+						BlobID: "sha256:7af71cba482c8da8aa8f67e62d06b20f051bc15f74f290ddfaa1e48d24a8b73f",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1888,7 +1888,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:c491838e70ff0fcfdd0605af1ba84e86d6958c0846b16c52a84e06bb344e8e8d",
+						BlobID: "sha256:91313f64a65ce639cbe8da725d62dcf42bcc82de54e86794437170aee36ddab3",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1899,7 +1899,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:25e775ef81049a93eafd865447b0b79da9e9956ab74bc02b5916eaea21c87c7c",
+						BlobID: "sha256:465e27497fda5c36193f87e7db3e587b746ccde3bda44ae71e4f24584037a9a1",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1911,7 +1911,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:a8a4798a22b65739cda9ca99ddb2cd86125c1dd86df6fc3971f937a0ff5b9ec3",
+						BlobID: "sha256:bf2c2f6e6fceaa43e57b2953a99b09b8d5f7ff8507f4395eb327bdd869f176da",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1927,10 +1927,10 @@ func TestArtifact_Inspect(t *testing.T) {
 				Type: artifact.TypeContainerImage,
 				ID:   "sha256:33f9415ed2cd5a9cef5d5144333619745b9ec0f851f0684dd45fa79c6b26a650",
 				BlobIDs: []string{
-					"sha256:139bc12e936e0c46090b9380c4a29456d3ad8d8abd50c7bdc6160018cd887462",
-					"sha256:c491838e70ff0fcfdd0605af1ba84e86d6958c0846b16c52a84e06bb344e8e8d",
-					"sha256:25e775ef81049a93eafd865447b0b79da9e9956ab74bc02b5916eaea21c87c7c",
-					"sha256:a8a4798a22b65739cda9ca99ddb2cd86125c1dd86df6fc3971f937a0ff5b9ec3",
+					"sha256:7af71cba482c8da8aa8f67e62d06b20f051bc15f74f290ddfaa1e48d24a8b73f",
+					"sha256:91313f64a65ce639cbe8da725d62dcf42bcc82de54e86794437170aee36ddab3",
+					"sha256:465e27497fda5c36193f87e7db3e587b746ccde3bda44ae71e4f24584037a9a1",
+					"sha256:bf2c2f6e6fceaa43e57b2953a99b09b8d5f7ff8507f4395eb327bdd869f176da",
 				},
 				ImageMetadata: artifact.ImageMetadata{
 					ID: "sha256:58701fd185bda36cab0557bb6438661831267aa4a9e0b54211c4d5317a48aff4",
@@ -2013,7 +2013,7 @@ func TestArtifact_Inspect(t *testing.T) {
 			missingBlobsExpectation: cache.ArtifactCacheMissingBlobsExpectation{
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:c232b7d8ac8aa08aa767313d0b53084c4380d1c01a213a5971bdb039e6538313",
-					BlobIDs:    []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+					BlobIDs:    []string{"sha256:a332a63be2dba6858452a9ede2748f9018ddddbca68df9c3796592066d9a4751"},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					Err: xerrors.New("MissingBlobs failed"),
@@ -2027,16 +2027,16 @@ func TestArtifact_Inspect(t *testing.T) {
 			missingBlobsExpectation: cache.ArtifactCacheMissingBlobsExpectation{
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:c232b7d8ac8aa08aa767313d0b53084c4380d1c01a213a5971bdb039e6538313",
-					BlobIDs:    []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+					BlobIDs:    []string{"sha256:a332a63be2dba6858452a9ede2748f9018ddddbca68df9c3796592066d9a4751"},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
-					MissingBlobIDs: []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+					MissingBlobIDs: []string{"sha256:a332a63be2dba6858452a9ede2748f9018ddddbca68df9c3796592066d9a4751"},
 				},
 			},
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4",
+						BlobID: "sha256:a332a63be2dba6858452a9ede2748f9018ddddbca68df9c3796592066d9a4751",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -2096,18 +2096,18 @@ func TestArtifact_Inspect(t *testing.T) {
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:33f9415ed2cd5a9cef5d5144333619745b9ec0f851f0684dd45fa79c6b26a650",
 					BlobIDs: []string{
-						"sha256:4a26915356c961f038d5a7b7f73f24cd1eec53dcf6fdeecd39b310ddc066faec",
-						"sha256:e23e1d428a2a4ca9607cde5c556f744c7e9f3a1d3bfe835707c0fea107caf453",
-						"sha256:b8ae022ed4f8b8bf827c04a825c2e6998217581d44c0b28b59a4e66ca65bbaa5",
-						"sha256:8c51dcc708602d983f3f0507f0d26de609819c4391db92497639417e54378d11",
+						"sha256:8e1d109c96dd570b2a08b5f851ffa33b10b525ecb4573963c77dc26ac85ae12b",
+						"sha256:9d251214ef8152c268bec2ea09b8b00e9ee76477ecc708734c00a9f55efe8faa",
+						"sha256:573560b751ecab2f68fc22d5f4b106cb1d32d962f4fd4ebf8de547f08c0e09e1",
+						"sha256:9df98e433e89f7c08b11f4c51c018e00174374142a66321716b7f93b000bf327",
 					},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					MissingBlobIDs: []string{
-						"sha256:4a26915356c961f038d5a7b7f73f24cd1eec53dcf6fdeecd39b310ddc066faec",
-						"sha256:e23e1d428a2a4ca9607cde5c556f744c7e9f3a1d3bfe835707c0fea107caf453",
-						"sha256:b8ae022ed4f8b8bf827c04a825c2e6998217581d44c0b28b59a4e66ca65bbaa5",
-						"sha256:8c51dcc708602d983f3f0507f0d26de609819c4391db92497639417e54378d11",
+						"sha256:8e1d109c96dd570b2a08b5f851ffa33b10b525ecb4573963c77dc26ac85ae12b",
+						"sha256:9d251214ef8152c268bec2ea09b8b00e9ee76477ecc708734c00a9f55efe8faa",
+						"sha256:573560b751ecab2f68fc22d5f4b106cb1d32d962f4fd4ebf8de547f08c0e09e1",
+						"sha256:9df98e433e89f7c08b11f4c51c018e00174374142a66321716b7f93b000bf327",
 					},
 				},
 			},
@@ -2115,7 +2115,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				{
 
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID:           "sha256:4a26915356c961f038d5a7b7f73f24cd1eec53dcf6fdeecd39b310ddc066faec",
+						BlobID:           "sha256:8e1d109c96dd570b2a08b5f851ffa33b10b525ecb4573963c77dc26ac85ae12b",
 						BlobInfoAnything: true,
 					},
 
@@ -2126,7 +2126,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				{
 
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID:           "sha256:e23e1d428a2a4ca9607cde5c556f744c7e9f3a1d3bfe835707c0fea107caf453",
+						BlobID:           "sha256:9d251214ef8152c268bec2ea09b8b00e9ee76477ecc708734c00a9f55efe8faa",
 						BlobInfoAnything: true,
 					},
 
@@ -2137,7 +2137,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				{
 
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID:           "sha256:b8ae022ed4f8b8bf827c04a825c2e6998217581d44c0b28b59a4e66ca65bbaa5",
+						BlobID:           "sha256:573560b751ecab2f68fc22d5f4b106cb1d32d962f4fd4ebf8de547f08c0e09e1",
 						BlobInfoAnything: true,
 					},
 
@@ -2148,7 +2148,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				{
 
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID:           "sha256:8c51dcc708602d983f3f0507f0d26de609819c4391db92497639417e54378d11",
+						BlobID:           "sha256:9df98e433e89f7c08b11f4c51c018e00174374142a66321716b7f93b000bf327",
 						BlobInfoAnything: true,
 					},
 
@@ -2165,17 +2165,17 @@ func TestArtifact_Inspect(t *testing.T) {
 			missingBlobsExpectation: cache.ArtifactCacheMissingBlobsExpectation{
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:c232b7d8ac8aa08aa767313d0b53084c4380d1c01a213a5971bdb039e6538313",
-					BlobIDs:    []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+					BlobIDs:    []string{"sha256:a332a63be2dba6858452a9ede2748f9018ddddbca68df9c3796592066d9a4751"},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					MissingArtifact: true,
-					MissingBlobIDs:  []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+					MissingBlobIDs:  []string{"sha256:a332a63be2dba6858452a9ede2748f9018ddddbca68df9c3796592066d9a4751"},
 				},
 			},
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4",
+						BlobID: "sha256:a332a63be2dba6858452a9ede2748f9018ddddbca68df9c3796592066d9a4751",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
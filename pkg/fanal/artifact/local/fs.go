@@ -1,9 +1,12 @@
 package local
 
 import (
+	"bufio"
+	"compress/gzip"
 	"context"
 	"crypto/sha256"
 	"encoding/json"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -12,6 +15,7 @@ import (
 
 	"github.com/google/wire"
 	"github.com/opencontainers/go-digest"
+	xsemaphore "golang.org/x/sync/semaphore"
 	"golang.org/x/xerrors"
 
 	"github.com/aquasecurity/trivy/pkg/cache"
@@ -19,6 +23,7 @@ import (
 	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
 	"github.com/aquasecurity/trivy/pkg/fanal/handler"
 	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/fanal/utils"
 	"github.com/aquasecurity/trivy/pkg/fanal/walker"
 	"github.com/aquasecurity/trivy/pkg/semaphore"
 )
@@ -33,6 +38,9 @@ var (
 	_ Walker = (*walker.FS)(nil)
 )
 
+// StdinPath is the target path to read a tar archive from stdin instead of the local filesystem
+const StdinPath = "-"
+
 type Walker interface {
 	Walk(root string, opt walker.Option, fn walker.WalkFunc) error
 }
@@ -44,6 +52,9 @@ type Artifact struct {
 	analyzer       analyzer.AnalyzerGroup
 	handlerManager handler.Manager
 
+	// stdin is read when rootPath is StdinPath
+	stdin io.Reader
+
 	artifactOption artifact.Option
 }
 
@@ -64,6 +75,7 @@ func NewArtifact(rootPath string, c cache.ArtifactCache, w Walker, opt artifact.
 		walker:         w,
 		analyzer:       a,
 		handlerManager: handlerManager,
+		stdin:          os.Stdin,
 		artifactOption: opt,
 	}, nil
 }
@@ -83,32 +95,12 @@ func (a Artifact) Inspect(ctx context.Context) (artifact.Reference, error) {
 		return artifact.Reference{}, xerrors.Errorf("failed to prepare filesystem for post analysis: %w", err)
 	}
 
-	err = a.walker.Walk(a.rootPath, a.artifactOption.WalkerOption, func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
-		dir := a.rootPath
-
-		// When the directory is the same as the filePath, a file was given
-		// instead of a directory, rewrite the file path and directory in this case.
-		if filePath == "." {
-			dir, filePath = path.Split(a.rootPath)
-		}
-
-		if err := a.analyzer.AnalyzeFile(ctx, &wg, limit, result, dir, filePath, info, opener, nil, opts); err != nil {
-			return xerrors.Errorf("analyze file (%s): %w", filePath, err)
-		}
-
-		// Skip post analysis if the file is not required
-		analyzerTypes := a.analyzer.RequiredPostAnalyzers(filePath, info)
-		if len(analyzerTypes) == 0 {
-			return nil
-		}
-
-		// Build filesystem for post analysis
-		if err := composite.CreateLink(analyzerTypes, dir, filePath, filepath.Join(dir, filePath)); err != nil {
-			return xerrors.Errorf("failed to create link: %w", err)
-		}
-
-		return nil
-	})
+	var archiveHostName string
+	if a.rootPath == StdinPath {
+		archiveHostName, err = a.walkArchive(ctx, &wg, limit, result, composite, opts)
+	} else {
+		err = a.walkDir(ctx, &wg, limit, result, composite, opts)
+	}
 	if err != nil {
 		return artifact.Reference{}, xerrors.Errorf("walk filesystem: %w", err)
 	}
@@ -150,11 +142,14 @@ func (a Artifact) Inspect(ctx context.Context) (artifact.Reference, error) {
 	}
 
 	// get hostname
-	var hostName string
-	b, err := os.ReadFile(filepath.Join(a.rootPath, "etc", "hostname"))
-	if err == nil && len(b) != 0 {
-		hostName = strings.TrimSpace(string(b))
-	} else {
+	hostName := archiveHostName
+	if a.rootPath != StdinPath {
+		b, err := os.ReadFile(filepath.Join(a.rootPath, "etc", "hostname"))
+		if err == nil && len(b) != 0 {
+			hostName = strings.TrimSpace(string(b))
+		}
+	}
+	if hostName == "" {
 		// To slash for Windows
 		hostName = filepath.ToSlash(a.rootPath)
 	}
@@ -167,6 +162,129 @@ func (a Artifact) Inspect(ctx context.Context) (artifact.Reference, error) {
 	}, nil
 }
 
+func (a Artifact) walkDir(ctx context.Context, wg *sync.WaitGroup, limit *xsemaphore.Weighted, result *analyzer.AnalysisResult,
+	composite *analyzer.CompositeFS, opts analyzer.AnalysisOptions) error {
+	countTarget := a.targetCounter()
+	return a.walker.Walk(a.rootPath, a.artifactOption.WalkerOption, func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
+		if err := countTarget(filePath, info); err != nil {
+			return err
+		}
+
+		dir := a.rootPath
+
+		// When the directory is the same as the filePath, a file was given
+		// instead of a directory, rewrite the file path and directory in this case.
+		if filePath == "." {
+			dir, filePath = path.Split(a.rootPath)
+		}
+
+		if err := a.analyzer.AnalyzeFile(ctx, wg, limit, result, dir, filePath, info, opener, nil, opts); err != nil {
+			return xerrors.Errorf("analyze file (%s): %w", filePath, err)
+		}
+
+		// Skip post analysis if the file is not required
+		analyzerTypes := a.analyzer.RequiredPostAnalyzers(filePath, info)
+		if len(analyzerTypes) == 0 {
+			return nil
+		}
+
+		// Build filesystem for post analysis
+		if err := composite.CreateLink(analyzerTypes, dir, filePath, filepath.Join(dir, filePath)); err != nil {
+			return xerrors.Errorf("failed to create link: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// walkArchive walks a tar archive read from stdin, which may be compressed with gzip.
+// As the archive can be read only once, the files required for post analysis are copied to temporary files.
+// It returns the hostname stored in the archive, if any.
+func (a Artifact) walkArchive(ctx context.Context, wg *sync.WaitGroup, limit *xsemaphore.Weighted, result *analyzer.AnalysisResult,
+	composite *analyzer.CompositeFS, opts analyzer.AnalysisOptions) (string, error) {
+	var r io.Reader
+	br := bufio.NewReader(a.stdin)
+	r = br
+	if utils.IsGzip(br) {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return "", xerrors.Errorf("failed to open gzip: %w", err)
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	var hostName string
+	countTarget := a.targetCounter()
+	_, _, err := walker.NewLayerTar(a.artifactOption.WalkerOption).Walk(r, func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
+		if err := countTarget(filePath, info); err != nil {
+			return err
+		}
+		if filePath == "etc/hostname" {
+			hostName = readHostName(opener)
+		}
+
+		if err := a.analyzer.AnalyzeFile(ctx, wg, limit, result, "", filePath, info, opener, nil, opts); err != nil {
+			return xerrors.Errorf("analyze file (%s): %w", filePath, err)
+		}
+
+		// Skip post analysis if the file is not required
+		analyzerTypes := a.analyzer.RequiredPostAnalyzers(filePath, info)
+		if len(analyzerTypes) == 0 {
+			return nil
+		}
+
+		// Build filesystem for post analysis
+		tmpFilePath, err := composite.CopyFileToTemp(opener, info)
+		if err != nil {
+			return xerrors.Errorf("failed to copy file to temp: %w", err)
+		}
+		if err = composite.CreateLink(analyzerTypes, "", filePath, tmpFilePath); err != nil {
+			return xerrors.Errorf("failed to create link: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", xerrors.Errorf("tar walk error: %w", err)
+	}
+	return hostName, nil
+}
+
+// targetCounter returns a function counting the files to be analyzed during the walk.
+// It returns an error as soon as the number exceeds "MaxTargets" so that a scan of an unexpectedly huge tree is aborted
+// before analyzing all the files.
+func (a Artifact) targetCounter() func(filePath string, info os.FileInfo) error {
+	maxTargets := a.artifactOption.MaxTargets
+	if maxTargets <= 0 {
+		return func(string, os.FileInfo) error { return nil }
+	}
+	var count int
+	return func(filePath string, info os.FileInfo) error {
+		if !a.analyzer.Required(filePath, info) {
+			return nil
+		}
+		if count++; count > maxTargets {
+			return xerrors.Errorf("the scan would analyze more than %d files (%s), narrow down the scan target or raise the limit with '--max-targets'",
+				maxTargets, filePath)
+		}
+		return nil
+	}
+}
+
+func readHostName(opener analyzer.Opener) string {
+	rc, err := opener()
+	if err != nil {
+		return ""
+	}
+	defer rc.Close()
+
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
 func (a Artifact) Clean(reference artifact.Reference) error {
 	return a.cache.DeleteBlobs(reference.BlobIDs)
 }
@@ -1,8 +1,13 @@
 package local
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
@@ -47,7 +52,7 @@ func TestArtifact_Inspect(t *testing.T) {
 			},
 			putBlobExpectation: cache.ArtifactCachePutBlobExpectation{
 				Args: cache.ArtifactCachePutBlobArgs{
-					BlobID: "sha256:5ba63074e071e3f0247d03dd7e544b6a75f7224ee238618482c490b36f4792dc",
+					BlobID: "sha256:ff64e3346b00b5820ed068379239d095fbcd7b07e4c82d46fffe4c46e9ff786d",
 					BlobInfo: types.BlobInfo{
 						SchemaVersion: types.BlobJSONSchemaVersion,
 						OS: types.OS{
@@ -82,9 +87,9 @@ func TestArtifact_Inspect(t *testing.T) {
 			want: artifact.Reference{
 				Name: "host",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:5ba63074e071e3f0247d03dd7e544b6a75f7224ee238618482c490b36f4792dc",
+				ID:   "sha256:ff64e3346b00b5820ed068379239d095fbcd7b07e4c82d46fffe4c46e9ff786d",
 				BlobIDs: []string{
-					"sha256:5ba63074e071e3f0247d03dd7e544b6a75f7224ee238618482c490b36f4792dc",
+					"sha256:ff64e3346b00b5820ed068379239d095fbcd7b07e4c82d46fffe4c46e9ff786d",
 				},
 			},
 		},
@@ -102,7 +107,7 @@ func TestArtifact_Inspect(t *testing.T) {
 			},
 			putBlobExpectation: cache.ArtifactCachePutBlobExpectation{
 				Args: cache.ArtifactCachePutBlobArgs{
-					BlobID: "sha256:649ddb291d142363aafcf9e9cf8a6e32dc0a6ae5a95ab43d09b8201d86ed8f7a",
+					BlobID: "sha256:6631d9d3bd140ec8369d86a84be3547b2d87ddb29d4186d028602df7ca456550",
 					BlobInfo: types.BlobInfo{
 						SchemaVersion: types.BlobJSONSchemaVersion,
 					},
@@ -112,9 +117,9 @@ func TestArtifact_Inspect(t *testing.T) {
 			want: artifact.Reference{
 				Name: "host",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:649ddb291d142363aafcf9e9cf8a6e32dc0a6ae5a95ab43d09b8201d86ed8f7a",
+				ID:   "sha256:6631d9d3bd140ec8369d86a84be3547b2d87ddb29d4186d028602df7ca456550",
 				BlobIDs: []string{
-					"sha256:649ddb291d142363aafcf9e9cf8a6e32dc0a6ae5a95ab43d09b8201d86ed8f7a",
+					"sha256:6631d9d3bd140ec8369d86a84be3547b2d87ddb29d4186d028602df7ca456550",
 				},
 			},
 		},
@@ -125,7 +130,7 @@ func TestArtifact_Inspect(t *testing.T) {
 			},
 			putBlobExpectation: cache.ArtifactCachePutBlobExpectation{
 				Args: cache.ArtifactCachePutBlobArgs{
-					BlobID: "sha256:5ba63074e071e3f0247d03dd7e544b6a75f7224ee238618482c490b36f4792dc",
+					BlobID: "sha256:ff64e3346b00b5820ed068379239d095fbcd7b07e4c82d46fffe4c46e9ff786d",
 					BlobInfo: types.BlobInfo{
 						SchemaVersion: types.BlobJSONSchemaVersion,
 						OS: types.OS{
@@ -175,7 +180,7 @@ func TestArtifact_Inspect(t *testing.T) {
 			},
 			putBlobExpectation: cache.ArtifactCachePutBlobExpectation{
 				Args: cache.ArtifactCachePutBlobArgs{
-					BlobID: "sha256:00e49bf14e0a8c15b2d611d8e5c231276f1e10f22b3307177e513605fd18d807",
+					BlobID: "sha256:2e14327a271aae71496c7e135d085ed72676f60b16061897e76d1bdd55cb84ae",
 					BlobInfo: types.BlobInfo{
 						SchemaVersion: types.BlobJSONSchemaVersion,
 						Applications: []types.Application{
@@ -203,9 +208,9 @@ func TestArtifact_Inspect(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/requirements.txt",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:00e49bf14e0a8c15b2d611d8e5c231276f1e10f22b3307177e513605fd18d807",
+				ID:   "sha256:2e14327a271aae71496c7e135d085ed72676f60b16061897e76d1bdd55cb84ae",
 				BlobIDs: []string{
-					"sha256:00e49bf14e0a8c15b2d611d8e5c231276f1e10f22b3307177e513605fd18d807",
+					"sha256:2e14327a271aae71496c7e135d085ed72676f60b16061897e76d1bdd55cb84ae",
 				},
 			},
 		},
@@ -216,7 +221,7 @@ func TestArtifact_Inspect(t *testing.T) {
 			},
 			putBlobExpectation: cache.ArtifactCachePutBlobExpectation{
 				Args: cache.ArtifactCachePutBlobArgs{
-					BlobID: "sha256:00e49bf14e0a8c15b2d611d8e5c231276f1e10f22b3307177e513605fd18d807",
+					BlobID: "sha256:2e14327a271aae71496c7e135d085ed72676f60b16061897e76d1bdd55cb84ae",
 					BlobInfo: types.BlobInfo{
 						SchemaVersion: types.BlobJSONSchemaVersion,
 						Applications: []types.Application{
@@ -244,9 +249,9 @@ func TestArtifact_Inspect(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/requirements.txt",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:00e49bf14e0a8c15b2d611d8e5c231276f1e10f22b3307177e513605fd18d807",
+				ID:   "sha256:2e14327a271aae71496c7e135d085ed72676f60b16061897e76d1bdd55cb84ae",
 				BlobIDs: []string{
-					"sha256:00e49bf14e0a8c15b2d611d8e5c231276f1e10f22b3307177e513605fd18d807",
+					"sha256:2e14327a271aae71496c7e135d085ed72676f60b16061897e76d1bdd55cb84ae",
 				},
 			},
 		},
@@ -272,6 +277,194 @@ func TestArtifact_Inspect(t *testing.T) {
 	}
 }
 
+func TestArtifact_InspectStdin(t *testing.T) {
+	alpineBlob := cache.ArtifactCachePutBlobExpectation{
+		Args: cache.ArtifactCachePutBlobArgs{
+			BlobID: "sha256:ff64e3346b00b5820ed068379239d095fbcd7b07e4c82d46fffe4c46e9ff786d",
+			BlobInfo: types.BlobInfo{
+				SchemaVersion: types.BlobJSONSchemaVersion,
+				OS: types.OS{
+					Family: "alpine",
+					Name:   "3.11.6",
+				},
+				PackageInfos: []types.PackageInfo{
+					{
+						FilePath: "lib/apk/db/installed",
+						Packages: types.Packages{
+							{
+								ID:         "musl@1.1.24-r2",
+								Name:       "musl",
+								Version:    "1.1.24-r2",
+								SrcName:    "musl",
+								SrcVersion: "1.1.24-r2",
+								Licenses:   []string{"MIT"},
+								Arch:       "x86_64",
+								Digest:     "sha1:cb2316a189ebee5282c4a9bd98794cc2477a74c6",
+								InstalledFiles: []string{
+									"lib/libc.musl-x86_64.so.1",
+									"lib/ld-musl-x86_64.so.1",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Returns: cache.ArtifactCachePutBlobReturns{},
+	}
+	alpineRef := artifact.Reference{
+		Name: "host",
+		Type: artifact.TypeFilesystem,
+		ID:   "sha256:ff64e3346b00b5820ed068379239d095fbcd7b07e4c82d46fffe4c46e9ff786d",
+		BlobIDs: []string{
+			"sha256:ff64e3346b00b5820ed068379239d095fbcd7b07e4c82d46fffe4c46e9ff786d",
+		},
+	}
+
+	tests := []struct {
+		name               string
+		stdin              func(t *testing.T) []byte
+		putBlobExpectation cache.ArtifactCachePutBlobExpectation
+		want               artifact.Reference
+		wantErr            string
+	}{
+		{
+			name: "tar",
+			stdin: func(t *testing.T) []byte {
+				return tarDir(t, "testdata/alpine")
+			},
+			putBlobExpectation: alpineBlob,
+			want:               alpineRef,
+		},
+		{
+			name: "tar.gz",
+			stdin: func(t *testing.T) []byte {
+				var buf bytes.Buffer
+				gw := gzip.NewWriter(&buf)
+				_, err := gw.Write(tarDir(t, "testdata/alpine"))
+				require.NoError(t, err)
+				require.NoError(t, gw.Close())
+				return buf.Bytes()
+			},
+			putBlobExpectation: alpineBlob,
+			want:               alpineRef,
+		},
+		{
+			name: "sad path with invalid archive",
+			stdin: func(t *testing.T) []byte {
+				return []byte("this is not a tar archive")
+			},
+			wantErr: "failed to extract the archive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := new(cache.MockArtifactCache)
+			c.ApplyPutBlobExpectation(tt.putBlobExpectation)
+
+			a, err := NewArtifact(StdinPath, c, walker.NewFS(), artifact.Option{})
+			require.NoError(t, err)
+
+			art := a.(Artifact)
+			art.stdin = bytes.NewReader(tt.stdin(t))
+
+			got, err := art.Inspect(context.Background())
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// tarDir creates a tar archive of the given directory like "tar -C dir -c ."
+func tarDir(t *testing.T, dir string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	err := filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		hdr.Name = "./" + filepath.ToSlash(rel)
+		if err = tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		b, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(b)
+		return err
+	})
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func TestArtifact_InspectMaxTargets(t *testing.T) {
+	// 3 files to analyze and a file not required by any analyzer
+	dir := t.TempDir()
+	for i := range 3 {
+		filePath := filepath.Join(dir, fmt.Sprintf("app%d", i), "requirements.txt")
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+		require.NoError(t, os.WriteFile(filePath, []byte("Flask==2.0.0\n"), 0o644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# app\n"), 0o644))
+
+	tests := []struct {
+		name       string
+		maxTargets int
+		wantErr    string
+	}{
+		{
+			name:       "unlimited",
+			maxTargets: 0,
+		},
+		{
+			name:       "within the limit",
+			maxTargets: 3,
+		},
+		{
+			name:       "exceeding the limit",
+			maxTargets: 2,
+			wantErr:    "the scan would analyze more than 2 files",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewArtifact(dir, cache.NewMemoryCache(), walker.NewFS(), artifact.Option{
+				DisabledAnalyzers: []analyzer.Type{analyzer.TypeSecret},
+				MaxTargets:        tt.maxTargets,
+			})
+			require.NoError(t, err)
+
+			_, err = a.Inspect(context.Background())
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 var terraformPolicyMetadata = types.PolicyMetadata{
 	ID:                 "TEST001",
 	AVDID:              "AVD-TEST-0001",
@@ -332,9 +525,9 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/terraform/single-failure",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:4f2a334086f1d175c0ee57cd4220f20b187b456dc36bbe39a63c42b5637b2179",
+				ID:   "sha256:1f06ea5311cd967544dfe0b1ea9e5b2aa0525601347c434d37c16a36240e6cc3",
 				BlobIDs: []string{
-					"sha256:4f2a334086f1d175c0ee57cd4220f20b187b456dc36bbe39a63c42b5637b2179",
+					"sha256:1f06ea5311cd967544dfe0b1ea9e5b2aa0525601347c434d37c16a36240e6cc3",
 				},
 			},
 		},
@@ -408,9 +601,9 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/terraform/multiple-failures",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:ff7a84de97729e169c94107a89bc9da88f5ecf94873cdbd9bf0844e1af5f5b30",
+				ID:   "sha256:be8b38c2628c9ca9d871bdb0e5b4c87c124795278312f686311b6683cd7e6360",
 				BlobIDs: []string{
-					"sha256:ff7a84de97729e169c94107a89bc9da88f5ecf94873cdbd9bf0844e1af5f5b30",
+					"sha256:be8b38c2628c9ca9d871bdb0e5b4c87c124795278312f686311b6683cd7e6360",
 				},
 			},
 		},
@@ -431,9 +624,9 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/terraform/no-results",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:06406e9bb7ba09d8d24c73c0995ac3b94fc1d6ce059e5a45418d7c0ab2b6dca4",
+				ID:   "sha256:5a77348a8781d8729b33b7edf3615cdfab36c085b4c9d8658f5e8f803f916978",
 				BlobIDs: []string{
-					"sha256:06406e9bb7ba09d8d24c73c0995ac3b94fc1d6ce059e5a45418d7c0ab2b6dca4",
+					"sha256:5a77348a8781d8729b33b7edf3615cdfab36c085b4c9d8658f5e8f803f916978",
 				},
 			},
 		},
@@ -471,9 +664,9 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/terraform/passed",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:107251e6ee7312c8c27ff04e71dd943b92021777c575971809f57b60bf41bba4",
+				ID:   "sha256:d59705f8593b398c336beaad5ba168713503983f15499b7933a460abf9705b75",
 				BlobIDs: []string{
-					"sha256:107251e6ee7312c8c27ff04e71dd943b92021777c575971809f57b60bf41bba4",
+					"sha256:d59705f8593b398c336beaad5ba168713503983f15499b7933a460abf9705b75",
 				},
 			},
 		},
@@ -528,9 +721,9 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/terraform/busted-relative-paths/child/main.tf",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:f2f07f41dbd6816d41ce6f28b3922fcedab611b8602d95e328571afd5c53b31d",
+				ID:   "sha256:8783fe840e804b56aba9cc24ebfe122afb38db1c6eeb7a9cd88e6b61895a53d2",
 				BlobIDs: []string{
-					"sha256:f2f07f41dbd6816d41ce6f28b3922fcedab611b8602d95e328571afd5c53b31d",
+					"sha256:8783fe840e804b56aba9cc24ebfe122afb38db1c6eeb7a9cd88e6b61895a53d2",
 				},
 			},
 		},
@@ -573,9 +766,9 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/terraform/tfvar-outside/tf",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:107251e6ee7312c8c27ff04e71dd943b92021777c575971809f57b60bf41bba4",
+				ID:   "sha256:d59705f8593b398c336beaad5ba168713503983f15499b7933a460abf9705b75",
 				BlobIDs: []string{
-					"sha256:107251e6ee7312c8c27ff04e71dd943b92021777c575971809f57b60bf41bba4",
+					"sha256:d59705f8593b398c336beaad5ba168713503983f15499b7933a460abf9705b75",
 				},
 			},
 		},
@@ -655,9 +848,9 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/terraform/relative-paths/child",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:f04c37d8e5300ce9344c795c2d4e0bb1dbef251b15538a6e0c11d6d9a86664d1",
+				ID:   "sha256:c87b516719024223858c9d421870e78fc4042866a7800ce05996746d5af5a820",
 				BlobIDs: []string{
-					"sha256:f04c37d8e5300ce9344c795c2d4e0bb1dbef251b15538a6e0c11d6d9a86664d1",
+					"sha256:c87b516719024223858c9d421870e78fc4042866a7800ce05996746d5af5a820",
 				},
 			},
 		},
@@ -776,9 +969,9 @@ func TestTerraformPlanSnapshotMisconfScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/terraformplan/snapshots/single-failure",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:c21e15d7d0cfe7c1ef1e1933b443f781d1411b864500431302a1e45fe0950529",
+				ID:   "sha256:931983e3c67cc902d8251058531d039c565218a5e695295c38f0480b541748b6",
 				BlobIDs: []string{
-					"sha256:c21e15d7d0cfe7c1ef1e1933b443f781d1411b864500431302a1e45fe0950529",
+					"sha256:931983e3c67cc902d8251058531d039c565218a5e695295c38f0480b541748b6",
 				},
 			},
 		},
@@ -852,9 +1045,9 @@ func TestTerraformPlanSnapshotMisconfScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/terraformplan/snapshots/multiple-failures",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:800c9ce07be36c7f4d1a4876ecfaaa77c1d90b15f43c58eaf52ea27670afcc42",
+				ID:   "sha256:632d8a836d3e624c3625af582c7ad2d4bc63d345f752c7b3257803a592b9fdfb",
 				BlobIDs: []string{
-					"sha256:800c9ce07be36c7f4d1a4876ecfaaa77c1d90b15f43c58eaf52ea27670afcc42",
+					"sha256:632d8a836d3e624c3625af582c7ad2d4bc63d345f752c7b3257803a592b9fdfb",
 				},
 			},
 		},
@@ -892,9 +1085,9 @@ func TestTerraformPlanSnapshotMisconfScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/terraformplan/snapshots/passed",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:3d90bb96d2dc0af277ab0ce28972670eb81968d00775d1e92edce54ae2d165c0",
+				ID:   "sha256:5e4cc607f592a4d6109c7514f5316b56553ce3f935fa3f80e2170979ca99c9e2",
 				BlobIDs: []string{
-					"sha256:3d90bb96d2dc0af277ab0ce28972670eb81968d00775d1e92edce54ae2d165c0",
+					"sha256:5e4cc607f592a4d6109c7514f5316b56553ce3f935fa3f80e2170979ca99c9e2",
 				},
 			},
 		},
@@ -1004,9 +1197,9 @@ func TestCloudFormationMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/cloudformation/single-failure/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:bd481a673eb07ed7b51e1ff2a6e7aca08b433d11288eb9f5e9aa2d2f482a0c16",
+				ID:   "sha256:be3415654c6bf172d88077d6d0d2dd933acbf4a6deb4bcd72ef01f0b283cacac",
 				BlobIDs: []string{
-					"sha256:bd481a673eb07ed7b51e1ff2a6e7aca08b433d11288eb9f5e9aa2d2f482a0c16",
+					"sha256:be3415654c6bf172d88077d6d0d2dd933acbf4a6deb4bcd72ef01f0b283cacac",
 				},
 			},
 		},
@@ -1087,9 +1280,9 @@ func TestCloudFormationMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/cloudformation/multiple-failures/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:c25676d23114b9c912067d45285cd9e662cefae5e3cc82c40f67df5fee39f92a",
+				ID:   "sha256:68f202c53688e54d85a84576429a28d9af67b763a9bc5a6cccbfd213945c06f7",
 				BlobIDs: []string{
-					"sha256:c25676d23114b9c912067d45285cd9e662cefae5e3cc82c40f67df5fee39f92a",
+					"sha256:68f202c53688e54d85a84576429a28d9af67b763a9bc5a6cccbfd213945c06f7",
 				},
 			},
 		},
@@ -1118,9 +1311,9 @@ func TestCloudFormationMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/cloudformation/no-results/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:522b19ad182f50b7b04217831c914df52c2d2eb1bdddb02eb9cd2b4e14c9a32b",
+				ID:   "sha256:aadd3ab6648e30f689a86d64c903a4763837527bb0ceb323ee984ea108d9bea3",
 				BlobIDs: []string{
-					"sha256:522b19ad182f50b7b04217831c914df52c2d2eb1bdddb02eb9cd2b4e14c9a32b",
+					"sha256:aadd3ab6648e30f689a86d64c903a4763837527bb0ceb323ee984ea108d9bea3",
 				},
 			},
 		},
@@ -1175,9 +1368,9 @@ func TestCloudFormationMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/cloudformation/params/code/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:40d6550292de7518fd7229f7b14803c67cbffbad3376e773ad7e6dc003846e87",
+				ID:   "sha256:f3ddda1d4f424cd8dcc0065ca9b1dcbbac3cdecb75dd0afc616c4d110a70e47f",
 				BlobIDs: []string{
-					"sha256:40d6550292de7518fd7229f7b14803c67cbffbad3376e773ad7e6dc003846e87",
+					"sha256:f3ddda1d4f424cd8dcc0065ca9b1dcbbac3cdecb75dd0afc616c4d110a70e47f",
 				},
 			},
 		},
@@ -1232,9 +1425,9 @@ func TestCloudFormationMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/cloudformation/passed/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:e2269b8ea44e29aedeaeea83368f879b3fb0cb97bfe46bcca4383a637280cace",
+				ID:   "sha256:a7ccfe6f31c6ca64274b6ce89a7baa4cbaf3d8c56127bc9ef74687d840df3d08",
 				BlobIDs: []string{
-					"sha256:e2269b8ea44e29aedeaeea83368f879b3fb0cb97bfe46bcca4383a637280cace",
+					"sha256:a7ccfe6f31c6ca64274b6ce89a7baa4cbaf3d8c56127bc9ef74687d840df3d08",
 				},
 			},
 		},
@@ -1319,9 +1512,9 @@ func TestDockerfileMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/dockerfile/single-failure/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:3551bddb0f53fb9e0c32390e3ac33f841e3cc15a52ddbcbd9ea07f7e6d1d4437",
+				ID:   "sha256:88c67269fe518276f5743ae6e7fec2374e6c79489d97b3544d71d4c0880d3aab",
 				BlobIDs: []string{
-					"sha256:3551bddb0f53fb9e0c32390e3ac33f841e3cc15a52ddbcbd9ea07f7e6d1d4437",
+					"sha256:88c67269fe518276f5743ae6e7fec2374e6c79489d97b3544d71d4c0880d3aab",
 				},
 			},
 		},
@@ -1376,9 +1569,9 @@ func TestDockerfileMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/dockerfile/multiple-failures/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:3551bddb0f53fb9e0c32390e3ac33f841e3cc15a52ddbcbd9ea07f7e6d1d4437",
+				ID:   "sha256:88c67269fe518276f5743ae6e7fec2374e6c79489d97b3544d71d4c0880d3aab",
 				BlobIDs: []string{
-					"sha256:3551bddb0f53fb9e0c32390e3ac33f841e3cc15a52ddbcbd9ea07f7e6d1d4437",
+					"sha256:88c67269fe518276f5743ae6e7fec2374e6c79489d97b3544d71d4c0880d3aab",
 				},
 			},
 		},
@@ -1406,9 +1599,9 @@ func TestDockerfileMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/dockerfile/no-results/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:e57ad1b0be7370a131e1265a25ac8790bbfec2bb5867315916cf92799e5855d3",
+				ID:   "sha256:1146152b39e2cd4301325255e8c4764bfcd9419bf00d65c33a5d6254c6c84fbe",
 				BlobIDs: []string{
-					"sha256:e57ad1b0be7370a131e1265a25ac8790bbfec2bb5867315916cf92799e5855d3",
+					"sha256:1146152b39e2cd4301325255e8c4764bfcd9419bf00d65c33a5d6254c6c84fbe",
 				},
 			},
 		},
@@ -1465,9 +1658,9 @@ func TestDockerfileMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/dockerfile/passed/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:ff4a3a7aed57bd8190277cf2cc16213eef43b7a37f26f8458525f2efd9793e8f",
+				ID:   "sha256:97cb217cd01928788e695e1a5a702f7520055bc9c428c8c1baff94e8a1a16225",
 				BlobIDs: []string{
-					"sha256:ff4a3a7aed57bd8190277cf2cc16213eef43b7a37f26f8458525f2efd9793e8f",
+					"sha256:97cb217cd01928788e695e1a5a702f7520055bc9c428c8c1baff94e8a1a16225",
 				},
 			},
 		},
@@ -1557,9 +1750,9 @@ func TestKubernetesMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/kubernetes/single-failure/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:63ceedb6582e29ee4184b8b776ee27efe226d07a932461639c05bfbe47bf7efa",
+				ID:   "sha256:fc77e1d0c8b31a602e44f094f5a86d0498addc9efbb771a2f32fb4766e94bc0e",
 				BlobIDs: []string{
-					"sha256:63ceedb6582e29ee4184b8b776ee27efe226d07a932461639c05bfbe47bf7efa",
+					"sha256:fc77e1d0c8b31a602e44f094f5a86d0498addc9efbb771a2f32fb4766e94bc0e",
 				},
 			},
 		},
@@ -1642,9 +1835,9 @@ func TestKubernetesMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/kubernetes/multiple-failures/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:47fcc85b182385fc6cd7ca08270efff33281ba7717c7a97c7b28a47bef24fae3",
+				ID:   "sha256:66f6729677bcc72f2d93ac806089725331f3649faa4a7b1117d71585372b8c49",
 				BlobIDs: []string{
-					"sha256:47fcc85b182385fc6cd7ca08270efff33281ba7717c7a97c7b28a47bef24fae3",
+					"sha256:66f6729677bcc72f2d93ac806089725331f3649faa4a7b1117d71585372b8c49",
 				},
 			},
 		},
@@ -1672,9 +1865,9 @@ func TestKubernetesMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/kubernetes/no-results/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:4aad6cb079f406935fa383e126616cee6c82e326a92c163042d6043596f18e04",
+				ID:   "sha256:957bd22a74ca58b60d6bed74e8c677507040c57fbadfcd56117171cad70eedb8",
 				BlobIDs: []string{
-					"sha256:4aad6cb079f406935fa383e126616cee6c82e326a92c163042d6043596f18e04",
+					"sha256:957bd22a74ca58b60d6bed74e8c677507040c57fbadfcd56117171cad70eedb8",
 				},
 			},
 		},
@@ -1731,9 +1924,9 @@ func TestKubernetesMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/kubernetes/passed/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:b781859c685b32a25e96e54b331957d696cedfc98162146819ac64d3f157660e",
+				ID:   "sha256:a50d49e596829b8bf1ba21589e68d51dbf160af780efb403a0212dffe2734623",
 				BlobIDs: []string{
-					"sha256:b781859c685b32a25e96e54b331957d696cedfc98162146819ac64d3f157660e",
+					"sha256:a50d49e596829b8bf1ba21589e68d51dbf160af780efb403a0212dffe2734623",
 				},
 			},
 		},
@@ -1821,9 +2014,9 @@ func TestAzureARMMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/azurearm/single-failure/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:62a167d993f603f5552042e4b3c7ac3a65dbbe62bad28e72631c69c9a8f5e2b5",
+				ID:   "sha256:41925386fdc2d81ef66160e7170374464fa765205d2e42d827e71ffffe3f57fd",
 				BlobIDs: []string{
-					"sha256:62a167d993f603f5552042e4b3c7ac3a65dbbe62bad28e72631c69c9a8f5e2b5",
+					"sha256:41925386fdc2d81ef66160e7170374464fa765205d2e42d827e71ffffe3f57fd",
 				},
 			},
 		},
@@ -1903,9 +2096,9 @@ func TestAzureARMMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/azurearm/multiple-failures/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:3cc8c966f10a75dc902589329cf202168176243ef8fdec7219452bb54d02af8e",
+				ID:   "sha256:8e8e9324ae20997e3174540afbeea28876e1a0ed966c9438ab331aaec08bcd15",
 				BlobIDs: []string{
-					"sha256:3cc8c966f10a75dc902589329cf202168176243ef8fdec7219452bb54d02af8e",
+					"sha256:8e8e9324ae20997e3174540afbeea28876e1a0ed966c9438ab331aaec08bcd15",
 				},
 			},
 		},
@@ -1933,9 +2126,9 @@ func TestAzureARMMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/azurearm/no-results/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:522b19ad182f50b7b04217831c914df52c2d2eb1bdddb02eb9cd2b4e14c9a32b",
+				ID:   "sha256:aadd3ab6648e30f689a86d64c903a4763837527bb0ceb323ee984ea108d9bea3",
 				BlobIDs: []string{
-					"sha256:522b19ad182f50b7b04217831c914df52c2d2eb1bdddb02eb9cd2b4e14c9a32b",
+					"sha256:aadd3ab6648e30f689a86d64c903a4763837527bb0ceb323ee984ea108d9bea3",
 				},
 			},
 		},
@@ -1989,9 +2182,9 @@ func TestAzureARMMisconfigurationScan(t *testing.T) {
 			want: artifact.Reference{
 				Name: "testdata/misconfig/azurearm/passed/src",
 				Type: artifact.TypeFilesystem,
-				ID:   "sha256:d6a4722cb6865cac6f55c1789d64c57479539e9198722519918764a230586b4b",
+				ID:   "sha256:d9ec297be1cc30d1f6e0435ad1d8f7c417335b7f0c2c41d272cb7f9eb954310e",
 				BlobIDs: []string{
-					"sha256:d6a4722cb6865cac6f55c1789d64c57479539e9198722519918764a230586b4b",
+					"sha256:d9ec297be1cc30d1f6e0435ad1d8f7c417335b7f0c2c41d272cb7f9eb954310e",
 				},
 			},
 		},
@@ -197,9 +197,9 @@ func TestArtifact_Inspect(t *testing.T) {
 			want: artifact.Reference{
 				Name: ts.URL + "/test-repo.git",
 				Type: artifact.TypeRepository,
-				ID:   "sha256:88233504639eb201433a0505956309ba0c48156f45beb786f95ccd3e8a343e9d",
+				ID:   "sha256:81b4feaf13a47e0208109116d37d3d8af718e775ed6f737e11013ca48a0ff81a",
 				BlobIDs: []string{
-					"sha256:88233504639eb201433a0505956309ba0c48156f45beb786f95ccd3e8a343e9d",
+					"sha256:81b4feaf13a47e0208109116d37d3d8af718e775ed6f737e11013ca48a0ff81a",
 				},
 			},
 		},
@@ -0,0 +1,315 @@
+package githistory
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/secret"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/fanal/utils"
+	"github.com/aquasecurity/trivy/pkg/fanal/walker"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// Scanner scans the git history of a repository for secrets removed from HEAD.
+type Scanner struct {
+	logger     *log.Logger
+	scanner    secret.Scanner
+	depth      int // Maximum number of commits to scan from HEAD (0 means unlimited)
+	walkerOpt  walker.Option
+	filterFunc FilterFunc
+}
+
+// FilterFunc returns true if the file should be scanned, e.g. the Required method of the secret analyzer.
+type FilterFunc func(filePath string, info os.FileInfo) bool
+
+type Option func(*Scanner)
+
+// WithWalkerOption skips the files and directories in the same way as the filesystem walker.
+// The paths are resolved against the scanned directory.
+func WithWalkerOption(opt walker.Option) Option {
+	return func(s *Scanner) {
+		s.walkerOpt = opt
+	}
+}
+
+// WithFilter scans only the files for which the filter returns true.
+func WithFilter(fn FilterFunc) Option {
+	return func(s *Scanner) {
+		s.filterFunc = fn
+	}
+}
+
+func NewScanner(scanner secret.Scanner, depth int, opts ...Option) Scanner {
+	s := Scanner{
+		logger:  log.WithPrefix(log.PrefixSecret),
+		scanner: scanner,
+		depth:   depth,
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// finding is a secret found in a commit
+type finding struct {
+	filePath string
+	snippet  string // Lines of the secret, used to check whether the secret is still present at HEAD
+	types.SecretFinding
+}
+
+// Scan returns the secrets in the commits reachable from HEAD that are no longer present at HEAD.
+// Secrets present at HEAD are reported by the regular secret scanning.
+// Commits are scanned from the oldest so that an identical secret in several commits is reported once
+// with the commit introducing it.
+// File paths are relative to "dir", and files outside "dir" are not scanned.
+func (s Scanner) Scan(ctx context.Context, dir string) ([]types.Secret, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to open the git repository: %w", err)
+	}
+	prefix, err := pathPrefix(repo, dir)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get the path in the repository: %w", err)
+	}
+
+	// Convert the skip paths to the paths relative to "dir" as the filesystem walker does
+	fsWalker := walker.NewFS()
+	s.walkerOpt.SkipFiles = fsWalker.BuildSkipPaths(dir, s.walkerOpt.SkipFiles)
+	s.walkerOpt.SkipDirs = fsWalker.BuildSkipPaths(dir, s.walkerOpt.SkipDirs)
+
+	commits, err := s.commits(repo)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list the commits: %w", err)
+	}
+	s.logger.Debug("Scanning the git history for secrets", log.Int("commits", len(commits)))
+
+	seen := make(map[string]struct{})
+	var findings []finding
+	// Scan from the oldest commit
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		found, err := s.scanCommit(ctx, commit, prefix)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to scan the commit %s: %w", commit.Hash, err)
+		}
+		for _, f := range found {
+			key := f.RuleID + ":" + fmt.Sprintf("%x", sha256.Sum256([]byte(f.snippet)))
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			findings = append(findings, f)
+		}
+	}
+
+	head, err := commits[0].Tree()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get the tree of HEAD: %w", err)
+	}
+	return removedSecrets(head, prefix, findings), nil
+}
+
+// commits returns the commits reachable from HEAD, from the newest one.
+func (s Scanner) commits(repo *git.Repository) ([]*object.Commit, error) {
+	ref, err := repo.Head()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get HEAD: %w", err)
+	}
+	iter, err := repo.Log(&git.LogOptions{From: ref.Hash()})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get the log: %w", err)
+	}
+	defer iter.Close()
+
+	var commits []*object.Commit
+	err = iter.ForEach(func(c *object.Commit) error {
+		if s.depth > 0 && len(commits) >= s.depth {
+			return storer.ErrStop
+		}
+		commits = append(commits, c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
+
+// scanCommit scans the files added or modified in the commit compared to its first parent.
+func (s Scanner) scanCommit(ctx context.Context, commit *object.Commit, prefix string) ([]finding, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get the tree: %w", err)
+	}
+	// The root commit is compared with the empty tree
+	var parentTree *object.Tree
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to get the parent: %w", err)
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, xerrors.Errorf("failed to get the tree of the parent: %w", err)
+		}
+	}
+
+	changes, err := object.DiffTreeWithOptions(ctx, parentTree, tree, nil)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to diff the trees: %w", err)
+	}
+
+	var findings []finding
+	for _, change := range changes {
+		filePath, ok := strings.CutPrefix(change.To.Name, prefix)
+		if change.To.Name == "" || !ok {
+			// Deleted, or outside the scanned directory
+			continue
+		}
+		if s.skipPath(filePath) {
+			continue
+		}
+		_, file, err := change.Files()
+		if err != nil {
+			return nil, xerrors.Errorf("failed to get the file %s: %w", change.To.Name, err)
+		}
+		if s.filterFunc != nil && !s.filterFunc(filePath, newFileInfo(file)) {
+			continue
+		}
+		if binary, err := file.IsBinary(); err != nil || binary {
+			continue
+		}
+		content, err := file.Contents()
+		if err != nil {
+			return nil, xerrors.Errorf("failed to read the file %s: %w", change.To.Name, err)
+		}
+
+		result := s.scanner.Scan(secret.ScanArgs{
+			FilePath: filePath,
+			Content:  []byte(content),
+		})
+		if len(result.Findings) == 0 {
+			continue
+		}
+		lines := strings.Split(content, "\n")
+		for _, f := range result.Findings {
+			if f.StartLine < 1 || f.EndLine > len(lines) || f.StartLine > f.EndLine {
+				continue
+			}
+			f.Commit = &types.SecretCommit{
+				Hash:   commit.Hash.String(),
+				Author: fmt.Sprintf("%s <%s>", commit.Author.Name, commit.Author.Email),
+			}
+			findings = append(findings, finding{
+				filePath:      filePath,
+				snippet:       strings.Join(lines[f.StartLine-1:f.EndLine], "\n"),
+				SecretFinding: f,
+			})
+		}
+	}
+	return findings, nil
+}
+
+// skipPath returns true if the file or one of its parent directories is skipped by --skip-files or --skip-dirs.
+func (s Scanner) skipPath(filePath string) bool {
+	if utils.SkipPath(filePath, s.walkerOpt.SkipFiles) {
+		return true
+	}
+	for dir := path.Dir(filePath); dir != "."; dir = path.Dir(dir) {
+		if utils.SkipPath(dir, s.walkerOpt.SkipDirs) {
+			return true
+		}
+	}
+	return false
+}
+
+// removedSecrets returns the secrets that are not present at HEAD, grouped by file.
+func removedSecrets(head *object.Tree, prefix string, findings []finding) []types.Secret {
+	var secrets []types.Secret
+	for _, f := range findings {
+		if file, err := head.File(prefix + f.filePath); err == nil {
+			if content, err := file.Contents(); err == nil && strings.Contains(content, f.snippet) {
+				continue
+			}
+		}
+
+		i := slices.IndexFunc(secrets, func(s types.Secret) bool {
+			return s.FilePath == f.filePath
+		})
+		if i < 0 {
+			secrets = append(secrets, types.Secret{FilePath: f.filePath})
+			i = len(secrets) - 1
+		}
+		secrets[i].Findings = append(secrets[i].Findings, f.SecretFinding)
+	}
+	slices.SortFunc(secrets, func(a, b types.Secret) int {
+		return strings.Compare(a.FilePath, b.FilePath)
+	})
+	return secrets
+}
+
+// pathPrefix returns the path of "dir" in the repository with a trailing slash, or an empty string for the root.
+func pathPrefix(repo *git.Repository, dir string) (string, error) {
+	wt, err := repo.Worktree()
+	if err != nil {
+		return "", xerrors.Errorf("failed to get the worktree: %w", err)
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	// Resolve symlinks on both sides, e.g. /tmp on macOS
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	root := wt.Filesystem.Root()
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." {
+		return "", nil
+	} else if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", xerrors.New("the directory is outside the worktree")
+	}
+	return filepath.ToSlash(rel) + "/", nil
+}
+
+// fileInfo implements os.FileInfo for a file in a commit
+type fileInfo struct {
+	file *object.File
+}
+
+func newFileInfo(file *object.File) os.FileInfo {
+	return fileInfo{file: file}
+}
+
+func (fi fileInfo) Name() string { return path.Base(fi.file.Name) }
+func (fi fileInfo) Size() int64  { return fi.file.Size }
+func (fi fileInfo) Mode() fs.FileMode {
+	mode, err := fi.file.Mode.ToOSFileMode()
+	if err != nil {
+		return 0
+	}
+	return mode
+}
+func (fi fileInfo) ModTime() time.Time { return time.Time{} }
+func (fi fileInfo) IsDir() bool        { return false }
+func (fi fileInfo) Sys() any           { return nil }
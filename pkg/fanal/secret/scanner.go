@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"regexp"
 	"slices"
@@ -22,10 +23,20 @@ import (
 var lineSep = []byte{'\n'}
 
 type Scanner struct {
-	logger *log.Logger
+	logger  *log.Logger
+	entropy bool // Calculate the entropy and the confidence of secrets
 	*Global
 }
 
+type ScannerOption func(*Scanner)
+
+// WithEntropy enables calculating the entropy and the confidence of secrets
+func WithEntropy(enabled bool) ScannerOption {
+	return func(s *Scanner) {
+		s.entropy = enabled
+	}
+}
+
 type Config struct {
 	// Enable only specified built-in rules. If only one ID is specified, all other rules are disabled.
 	// All the built-in rules are enabled if this field is not specified. It doesn't affect custom rules.
@@ -317,18 +328,21 @@ func convertSeverity(logger *log.Logger, severity string) string {
 	}
 }
 
-func NewScanner(config *Config) Scanner {
-	logger := log.WithPrefix("secret")
+func NewScanner(config *Config, opts ...ScannerOption) Scanner {
+	s := Scanner{
+		logger: log.WithPrefix("secret"),
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
 
 	// Use the default rules
 	if config == nil {
-		return Scanner{
-			logger: logger,
-			Global: &Global{
-				Rules:      builtinRules,
-				AllowRules: builtinAllowRules,
-			},
+		s.Global = &Global{
+			Rules:      builtinRules,
+			AllowRules: builtinAllowRules,
 		}
+		return s
 	}
 
 	enabledRules := builtinRules
@@ -353,14 +367,12 @@ func NewScanner(config *Config) Scanner {
 		return !slices.Contains(config.DisableAllowRuleIDs, v.ID)
 	})
 
-	return Scanner{
-		logger: logger,
-		Global: &Global{
-			Rules:        rules,
-			AllowRules:   allowRules,
-			ExcludeBlock: config.ExcludeBlock,
-		},
+	s.Global = &Global{
+		Rules:        rules,
+		AllowRules:   allowRules,
+		ExcludeBlock: config.ExcludeBlock,
 	}
+	return s
 }
 
 type ScanArgs struct {
@@ -437,6 +449,11 @@ func (s *Scanner) Scan(args ScanArgs) types.Secret {
 	}
 	for _, match := range matched {
 		finding := toFinding(match.Rule, match.Location, censored)
+		if s.entropy {
+			// The entropy is calculated on the original content as the secret is already censored
+			finding.Entropy = entropy(args.Content[match.Location.Start:match.Location.End])
+			finding.Confidence = types.NewSecretConfidence(finding.Entropy)
+		}
 		// Rewrite unreadable fields for binary files
 		if args.Binary {
 			finding.Match = fmt.Sprintf("Binary file %q matches a rule %q", args.FilePath, match.Rule.Title)
@@ -462,6 +479,26 @@ func (s *Scanner) Scan(args ScanArgs) types.Secret {
 	}
 }
 
+// entropy returns the Shannon entropy of the secret in bits per character, rounded to two decimal places
+func entropy(secret []byte) float64 {
+	if len(secret) == 0 {
+		return 0
+	}
+	var counts [256]int
+	for _, b := range secret {
+		counts[b]++
+	}
+	var e float64
+	for _, c := range counts {
+		if c == 0 {
+			continue
+		}
+		p := float64(c) / float64(len(secret))
+		e -= p * math.Log2(p)
+	}
+	return math.Round(e*100) / 100
+}
+
 func censorLocation(loc Location, input []byte) []byte {
 	return append(
 		input[:loc.Start],
@@ -848,6 +848,7 @@ func TestSecretScanner(t *testing.T) {
 		name          string
 		configPath    string
 		inputFilePath string
+		entropy       bool
 		want          types.Secret
 	}{
 		{
@@ -862,6 +863,19 @@ func TestSecretScanner(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:          "find match with entropy",
+			configPath:    filepath.Join("testdata", "config.yaml"),
+			inputFilePath: filepath.Join("testdata", "secret.txt"),
+			entropy:       true,
+			want: types.Secret{
+				FilePath: filepath.Join("testdata", "secret.txt"),
+				Findings: []types.SecretFinding{
+					withEntropy(wantFinding1, 2.95, types.SecretConfidenceMedium),
+					withEntropy(wantFinding2, 3.12, types.SecretConfidenceMedium),
+				},
+			},
+		},
 		{
 			name:          "find aws secrets",
 			configPath:    filepath.Join("testdata", "config.yaml"),
@@ -1215,7 +1229,7 @@ func TestSecretScanner(t *testing.T) {
 			c, err := secret.ParseConfig(tt.configPath)
 			require.NoError(t, err)
 
-			s := secret.NewScanner(c)
+			s := secret.NewScanner(c, secret.WithEntropy(tt.entropy))
 			got := s.Scan(secret.ScanArgs{
 				FilePath: tt.inputFilePath,
 				Content:  content,
@@ -1225,3 +1239,9 @@ func TestSecretScanner(t *testing.T) {
 		})
 	}
 }
+
+func withEntropy(finding types.SecretFinding, entropy float64, confidence types.SecretConfidence) types.SecretFinding {
+	finding.Entropy = entropy
+	finding.Confidence = confidence
+	return finding
+}
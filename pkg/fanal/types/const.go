@@ -108,6 +108,7 @@ const (
 	Helm                  ConfigType = "helm"
 	Cloud                 ConfigType = "cloud"
 	AzureARM              ConfigType = "azure-arm"
+	Systemd               ConfigType = "systemd"
 )
 
 // Language-specific file names
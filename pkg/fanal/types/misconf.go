@@ -24,7 +24,8 @@ type MisconfResult struct {
 	CauseMetadata  `json:",omitempty"`
 
 	// For debugging
-	Traces []string `json:",omitempty"`
+	Traces       []string `json:",omitempty"`
+	PolicySource string   `json:",omitempty"`
 }
 
 type MisconfResults []MisconfResult
@@ -1,5 +1,10 @@
 package types
 
+import (
+	"cmp"
+	"slices"
+)
+
 type SecretRuleCategory string
 
 type Secret struct {
@@ -17,4 +22,55 @@ type SecretFinding struct {
 	Code      Code
 	Match     string
 	Layer     Layer `json:",omitempty"`
+
+	// Entropy holds the Shannon entropy of the secret in bits per character, e.g. around 6 for random base64 strings
+	Entropy float64 `json:",omitempty"`
+
+	// Confidence holds how likely the secret is real, based on the entropy
+	Confidence SecretConfidence `json:",omitempty"`
+
+	// Commit holds the git commit introducing the secret, only filled for secrets found in the git history
+	Commit *SecretCommit `json:",omitempty"`
+
+	// Labels holds the labels attached by "--labels-file" in the report
+	Labels map[string]string `json:",omitempty"`
+}
+
+// SecretConfidence represents how likely a detected secret is real rather than a placeholder, e.g. "changeme"
+type SecretConfidence string
+
+const (
+	SecretConfidenceLow    SecretConfidence = "low"
+	SecretConfidenceMedium SecretConfidence = "medium"
+	SecretConfidenceHigh   SecretConfidence = "high"
+)
+
+// SecretConfidences lists the confidences in ascending order
+var SecretConfidences = []SecretConfidence{
+	SecretConfidenceLow,
+	SecretConfidenceMedium,
+	SecretConfidenceHigh,
+}
+
+// NewSecretConfidence returns the confidence for the entropy of the secret.
+// Random strings such as tokens and keys have high entropy, while words and placeholders have low entropy.
+func NewSecretConfidence(entropy float64) SecretConfidence {
+	switch {
+	case entropy >= 3.5:
+		return SecretConfidenceHigh
+	case entropy >= 2.5:
+		return SecretConfidenceMedium
+	}
+	return SecretConfidenceLow
+}
+
+// Compare returns -1, 0 or +1 depending on whether c is lower than, equal to or higher than other
+func (c SecretConfidence) Compare(other SecretConfidence) int {
+	return cmp.Compare(slices.Index(SecretConfidences, c), slices.Index(SecretConfidences, other))
+}
+
+// SecretCommit represents the git commit introducing a secret
+type SecretCommit struct {
+	Hash   string
+	Author string
 }
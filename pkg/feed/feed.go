@@ -0,0 +1,149 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	getter "github.com/hashicorp/go-getter"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/clock"
+	"github.com/aquasecurity/trivy/pkg/downloader"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/utils/fsutils"
+)
+
+const metadataFile = "metadata.json"
+
+// Options holds the options to download feeds
+type Options struct {
+	CacheDir string
+	Insecure bool
+	Offline  bool // Use only the cached copy
+}
+
+// Feed is a data file published on the Internet, such as the EPSS dataset.
+// It is cached in the cache dir and downloaded again only after the update interval.
+type Feed struct {
+	Name           string // Name in messages, e.g. "EPSS dataset"
+	Dir            string // Directory in the cache dir, e.g. "epss"
+	FileName       string
+	UpdateInterval time.Duration
+}
+
+// Metadata is stored next to the cached feed
+type Metadata struct {
+	URL       string
+	ETag      string
+	UpdatedAt time.Time
+}
+
+// Open returns the cached copy of the feed at the URL, downloading it when it is missing or outdated.
+// The cached copy is used as is in offline mode or when the download fails.
+func (f Feed) Open(ctx context.Context, url string, opts Options) (*os.File, error) {
+	logger := log.WithPrefix(f.Dir)
+	dir := filepath.Join(opts.CacheDir, f.Dir)
+	filePath := filepath.Join(dir, f.FileName)
+
+	m, err := readMetadata(dir)
+	if err != nil {
+		logger.DebugContext(ctx, "Failed to read the cache metadata", log.Err(err))
+	}
+	cached := m.URL == url && fsutils.FileExists(filePath)
+
+	switch {
+	case opts.Offline:
+		if !cached {
+			return nil, xerrors.Errorf("the %s is not cached and cannot be downloaded in offline mode: %s", f.Name, url)
+		}
+		logger.DebugContext(ctx, "Skipping the update in offline mode", log.Time("updated_at", m.UpdatedAt))
+	case cached && clock.Now(ctx).Before(m.UpdatedAt.Add(f.UpdateInterval)):
+		logger.DebugContext(ctx, "No need to update the cache", log.Time("updated_at", m.UpdatedAt))
+	default:
+		if !cached {
+			m = Metadata{URL: url}
+		}
+		if err = f.download(ctx, dir, m, opts); err != nil {
+			if !cached {
+				return nil, err
+			}
+			logger.WarnContext(ctx, "Failed to update the cache, using the cached copy",
+				log.Time("updated_at", m.UpdatedAt), log.Err(err))
+		}
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to open the %s: %w", f.Name, err)
+	}
+	return file, nil
+}
+
+func (f Feed) download(ctx context.Context, dir string, m Metadata, opts Options) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return xerrors.Errorf("failed to mkdir: %w", err)
+	}
+
+	// The downloader removes the destination first,
+	// so the feed is downloaded to a temporary file not to lose the cached copy when it is not modified.
+	filePath := filepath.Join(dir, f.FileName)
+	tmpPath := filePath + ".tmp"
+	defer os.Remove(tmpPath)
+
+	log.DebugContext(ctx, "Downloading the "+f.Name+"...", log.String("url", m.URL), log.String("etag", m.ETag))
+	etag, err := downloader.Download(ctx, m.URL, tmpPath, dir, downloader.Options{
+		Insecure:   opts.Insecure,
+		ETag:       m.ETag,
+		ClientMode: getter.ClientModeFile,
+	})
+	switch {
+	case errors.Is(err, downloader.ErrSkipDownload):
+		log.DebugContext(ctx, "No updates in the "+f.Name)
+		etag = m.ETag
+	case err != nil:
+		return xerrors.Errorf("failed to download the %s: %w", f.Name, err)
+	default:
+		if err = os.Rename(tmpPath, filePath); err != nil {
+			return xerrors.Errorf("failed to rename the %s: %w", f.Name, err)
+		}
+	}
+
+	return writeMetadata(dir, Metadata{
+		URL:       m.URL,
+		ETag:      etag,
+		UpdatedAt: clock.Now(ctx),
+	})
+}
+
+func readMetadata(dir string) (Metadata, error) {
+	f, err := os.Open(filepath.Join(dir, metadataFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return Metadata{}, nil
+	} else if err != nil {
+		return Metadata{}, xerrors.Errorf("failed to open the file: %w", err)
+	}
+	defer f.Close()
+
+	var m Metadata
+	if err = json.NewDecoder(f).Decode(&m); err != nil {
+		return Metadata{}, xerrors.Errorf("failed to decode the metadata: %w", err)
+	}
+	return m, nil
+}
+
+func writeMetadata(dir string, m Metadata) error {
+	f, err := os.Create(filepath.Join(dir, metadataFile))
+	if err != nil {
+		return xerrors.Errorf("failed to create the file: %w", err)
+	}
+	defer f.Close()
+
+	if err = json.NewEncoder(f).Encode(m); err != nil {
+		return xerrors.Errorf("failed to encode the metadata: %w", err)
+	}
+	return nil
+}
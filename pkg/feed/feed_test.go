@@ -0,0 +1,197 @@
+package feed_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/clock"
+	"github.com/aquasecurity/trivy/pkg/feed"
+)
+
+var testFeed = feed.Feed{
+	Name:           "test feed",
+	Dir:            "test",
+	FileName:       "feed.json",
+	UpdateInterval: 24 * time.Hour,
+}
+
+func TestFeed_Open(t *testing.T) {
+	now := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	var requests int // Number of GET requests, not counting HEAD requests of the downloader
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			requests++
+		}
+		switch {
+		case r.URL.Path == "/error":
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.Header.Get("If-None-Match") == "current-etag":
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			w.Header().Set("ETag", "current-etag")
+			_, _ = w.Write([]byte(`{"version": "new"}`))
+		}
+	}))
+	t.Cleanup(ts.Close)
+	url := ts.URL + "/feed.json"
+
+	tests := []struct {
+		name         string
+		url          string
+		offline      bool
+		cache        *feed.Metadata // The cached feed is "old" if not nil
+		want         string
+		wantRequests int
+		wantMetadata *feed.Metadata
+		wantErr      string
+	}{
+		{
+			name:         "no cache",
+			url:          url,
+			want:         `{"version": "new"}`,
+			wantRequests: 1,
+			wantMetadata: &feed.Metadata{
+				URL:       url,
+				ETag:      "current-etag",
+				UpdatedAt: now,
+			},
+		},
+		{
+			name: "cache is up to date",
+			url:  url,
+			cache: &feed.Metadata{
+				URL:       url,
+				ETag:      "old-etag",
+				UpdatedAt: now.Add(-time.Hour),
+			},
+			want:         "old",
+			wantRequests: 0,
+		},
+		{
+			name: "outdated cache",
+			url:  url,
+			cache: &feed.Metadata{
+				URL:       url,
+				ETag:      "old-etag",
+				UpdatedAt: now.Add(-48 * time.Hour),
+			},
+			want:         `{"version": "new"}`,
+			wantRequests: 1,
+			wantMetadata: &feed.Metadata{
+				URL:       url,
+				ETag:      "current-etag",
+				UpdatedAt: now,
+			},
+		},
+		{
+			name: "not modified",
+			url:  url,
+			cache: &feed.Metadata{
+				URL:       url,
+				ETag:      "current-etag",
+				UpdatedAt: now.Add(-48 * time.Hour),
+			},
+			want:         "old",
+			wantRequests: 1,
+			wantMetadata: &feed.Metadata{
+				URL:       url,
+				ETag:      "current-etag",
+				UpdatedAt: now,
+			},
+		},
+		{
+			name: "cache of another URL",
+			url:  url,
+			cache: &feed.Metadata{
+				URL:       ts.URL + "/another.json",
+				ETag:      "current-etag",
+				UpdatedAt: now.Add(-time.Hour),
+			},
+			want:         `{"version": "new"}`,
+			wantRequests: 1,
+		},
+		{
+			name:    "offline with outdated cache",
+			url:     url,
+			offline: true,
+			cache: &feed.Metadata{
+				URL:       url,
+				UpdatedAt: now.Add(-48 * time.Hour),
+			},
+			want:         "old",
+			wantRequests: 0,
+		},
+		{
+			name:         "offline without cache",
+			url:          url,
+			offline:      true,
+			wantRequests: 0,
+			wantErr:      "the test feed is not cached and cannot be downloaded in offline mode",
+		},
+		{
+			name: "download failure with cache",
+			url:  ts.URL + "/error",
+			cache: &feed.Metadata{
+				URL:       ts.URL + "/error",
+				UpdatedAt: now.Add(-48 * time.Hour),
+			},
+			want:         "old",
+			wantRequests: 1,
+		},
+		{
+			name:         "download failure without cache",
+			url:          ts.URL + "/error",
+			wantRequests: 1,
+			wantErr:      "failed to download the test feed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = 0
+			cacheDir := t.TempDir()
+			dir := filepath.Join(cacheDir, testFeed.Dir)
+			if tt.cache != nil {
+				require.NoError(t, os.MkdirAll(dir, 0o700))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, testFeed.FileName), []byte("old"), 0o600))
+				b, err := json.Marshal(tt.cache)
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "metadata.json"), b, 0o600))
+			}
+
+			ctx := clock.With(context.Background(), now)
+			f, err := testFeed.Open(ctx, tt.url, feed.Options{
+				CacheDir: cacheDir,
+				Offline:  tt.offline,
+			})
+			assert.Equal(t, tt.wantRequests, requests)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			defer f.Close()
+
+			got, err := os.ReadFile(f.Name())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+
+			if tt.wantMetadata != nil {
+				b, err := os.ReadFile(filepath.Join(dir, "metadata.json"))
+				require.NoError(t, err)
+				var m feed.Metadata
+				require.NoError(t, json.Unmarshal(b, &m))
+				assert.Equal(t, *tt.wantMetadata, m)
+			}
+		})
+	}
+}
@@ -2,6 +2,9 @@ package flag
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"golang.org/x/xerrors"
@@ -62,6 +65,11 @@ var (
 		Default:    []string{javadb.DefaultGCRRepository, javadb.DefaultGHCRRepository},
 		Usage:      "OCI repository(ies) to retrieve trivy-java-db in order of priority",
 	}
+	DBStaleWarningFlag = Flag[string]{
+		Name:       "db-stale-warning",
+		ConfigName: "db.stale-warning",
+		Usage:      `show a warning when the vulnerability database is older than the given duration (e.g. "7d", "72h")`,
+	}
 	LightFlag = Flag[bool]{
 		Name:       "light",
 		ConfigName: "db.light",
@@ -80,6 +88,7 @@ type DBFlagGroup struct {
 	NoProgress         *Flag[bool]
 	DBRepositories     *Flag[[]string]
 	JavaDBRepositories *Flag[[]string]
+	DBStaleWarning     *Flag[string]
 	Light              *Flag[bool] // deprecated
 }
 
@@ -92,6 +101,7 @@ type DBOptions struct {
 	NoProgress         bool
 	DBRepositories     []name.Reference
 	JavaDBRepositories []name.Reference
+	DBStaleWarning     time.Duration
 }
 
 // NewDBFlagGroup returns a default DBFlagGroup
@@ -106,6 +116,7 @@ func NewDBFlagGroup() *DBFlagGroup {
 		NoProgress:         NoProgressFlag.Clone(),
 		DBRepositories:     DBRepositoryFlag.Clone(),
 		JavaDBRepositories: JavaDBRepositoryFlag.Clone(),
+		DBStaleWarning:     DBStaleWarningFlag.Clone(),
 	}
 }
 
@@ -123,6 +134,7 @@ func (f *DBFlagGroup) Flags() []Flagger {
 		f.NoProgress,
 		f.DBRepositories,
 		f.JavaDBRepositories,
+		f.DBStaleWarning,
 		f.Light,
 	}
 }
@@ -164,6 +176,11 @@ func (f *DBFlagGroup) ToOptions() (DBOptions, error) {
 		javaDBRepositories = append(javaDBRepositories, ref)
 	}
 
+	staleWarning, err := parseStaleWarning(f.DBStaleWarning.Value())
+	if err != nil {
+		return DBOptions{}, xerrors.Errorf("invalid '--db-stale-warning': %w", err)
+	}
+
 	return DBOptions{
 		Reset:              f.Reset.Value(),
 		DownloadDBOnly:     downloadDBOnly,
@@ -173,9 +190,37 @@ func (f *DBFlagGroup) ToOptions() (DBOptions, error) {
 		NoProgress:         f.NoProgress.Value(),
 		DBRepositories:     dbRepositories,
 		JavaDBRepositories: javaDBRepositories,
+		DBStaleWarning:     staleWarning,
 	}, nil
 }
 
+// parseStaleWarning parses the threshold of "--db-stale-warning".
+// In addition to Go durations such as "72h", days are accepted (e.g. "7d").
+func parseStaleWarning(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, xerrors.Errorf("invalid number of days: %s", s)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, xerrors.Errorf("invalid duration: %w", err)
+		}
+	}
+
+	if d <= 0 {
+		return 0, xerrors.Errorf("duration must be positive: %s", s)
+	}
+	return d, nil
+}
+
 func parseRepository(repo string, dbSchemaVersion int) (name.Reference, error) {
 	dbRepository, err := name.ParseReference(repo, name.WithDefaultTag(""))
 	if err != nil {
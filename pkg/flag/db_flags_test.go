@@ -2,6 +2,7 @@ package flag_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/spf13/viper"
@@ -19,6 +20,7 @@ func TestDBFlagGroup_ToOptions(t *testing.T) {
 		Light            bool
 		DBRepository     []string
 		JavaDBRepository []string
+		DBStaleWarning   string
 	}
 	tests := []struct {
 		name     string
@@ -84,6 +86,39 @@ func TestDBFlagGroup_ToOptions(t *testing.T) {
 				JavaDBRepositories: []name.Reference{name.Tag{}, name.Tag{}}, // All fields are unexported
 			},
 		},
+		{
+			name: "stale warning in days",
+			fields: fields{
+				DBRepository:     []string{"ghcr.io/aquasecurity/trivy-db:2"},
+				JavaDBRepository: []string{"ghcr.io/aquasecurity/trivy-java-db:1"},
+				DBStaleWarning:   "7d",
+			},
+			want: flag.DBOptions{
+				DBRepositories:     []name.Reference{name.Tag{}}, // All fields are unexported
+				JavaDBRepositories: []name.Reference{name.Tag{}}, // All fields are unexported
+				DBStaleWarning:     7 * 24 * time.Hour,
+			},
+		},
+		{
+			name: "stale warning in hours",
+			fields: fields{
+				DBRepository:     []string{"ghcr.io/aquasecurity/trivy-db:2"},
+				JavaDBRepository: []string{"ghcr.io/aquasecurity/trivy-java-db:1"},
+				DBStaleWarning:   "36h",
+			},
+			want: flag.DBOptions{
+				DBRepositories:     []name.Reference{name.Tag{}}, // All fields are unexported
+				JavaDBRepositories: []name.Reference{name.Tag{}}, // All fields are unexported
+				DBStaleWarning:     36 * time.Hour,
+			},
+		},
+		{
+			name: "invalid stale warning",
+			fields: fields{
+				DBStaleWarning: "a week",
+			},
+			wantErr: "invalid '--db-stale-warning'",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -93,6 +128,7 @@ func TestDBFlagGroup_ToOptions(t *testing.T) {
 			viper.Set(flag.DownloadDBOnlyFlag.ConfigName, tt.fields.DownloadDBOnly)
 			viper.Set(flag.DBRepositoryFlag.ConfigName, tt.fields.DBRepository)
 			viper.Set(flag.JavaDBRepositoryFlag.ConfigName, tt.fields.JavaDBRepository)
+			viper.Set(flag.DBStaleWarningFlag.ConfigName, tt.fields.DBStaleWarning)
 
 			// Assert options
 			f := &flag.DBFlagGroup{
@@ -100,6 +136,7 @@ func TestDBFlagGroup_ToOptions(t *testing.T) {
 				SkipDBUpdate:       flag.SkipDBUpdateFlag.Clone(),
 				DBRepositories:     flag.DBRepositoryFlag.Clone(),
 				JavaDBRepositories: flag.JavaDBRepositoryFlag.Clone(),
+				DBStaleWarning:     flag.DBStaleWarningFlag.Clone(),
 			}
 			got, err := f.ToOptions()
 			if tt.wantErr != "" {
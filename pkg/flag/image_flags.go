@@ -1,10 +1,13 @@
 package flag
 
 import (
+	"strings"
+
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"golang.org/x/xerrors"
 
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/types"
 	xstrings "github.com/aquasecurity/trivy/pkg/x/strings"
 )
@@ -37,7 +40,17 @@ var (
 	PlatformFlag = Flag[string]{
 		Name:       "platform",
 		ConfigName: "image.platform",
-		Usage:      "set platform in the form os/arch if image is multi-platform capable",
+		Usage:      "set platform in the form os/arch if image is multi-platform capable. Multiple platforms can be specified with commas, e.g. linux/amd64,linux/arm64",
+	}
+	AllPlatformsFlag = Flag[bool]{
+		Name:       "all-platforms",
+		ConfigName: "image.all-platforms",
+		Usage:      "scan all the platforms of a multi-platform image and report the results per platform",
+	}
+	MergePlatformsFlag = Flag[bool]{
+		Name:       "merge-platforms",
+		ConfigName: "image.merge-platforms",
+		Usage:      "merge identical vulnerabilities across platforms with the list of the platforms, instead of reporting them per platform",
 	}
 	DockerHostFlag = Flag[string]{
 		Name:       "docker-host",
@@ -65,6 +78,8 @@ type ImageFlagGroup struct {
 	ImageConfigScanners *Flag[[]string]
 	ScanRemovedPkgs     *Flag[bool]
 	Platform            *Flag[string]
+	AllPlatforms        *Flag[bool]
+	MergePlatforms      *Flag[bool]
 	DockerHost          *Flag[string]
 	PodmanHost          *Flag[string]
 	ImageSources        *Flag[[]string]
@@ -75,6 +90,9 @@ type ImageOptions struct {
 	ImageConfigScanners types.Scanners
 	ScanRemovedPkgs     bool
 	Platform            ftypes.Platform
+	Platforms           []ftypes.Platform // Multiple platforms given by "--platform"
+	AllPlatforms        bool
+	MergePlatforms      bool
 	DockerHost          string
 	PodmanHost          string
 	ImageSources        ftypes.ImageSources
@@ -86,6 +104,8 @@ func NewImageFlagGroup() *ImageFlagGroup {
 		ImageConfigScanners: ImageConfigScannersFlag.Clone(),
 		ScanRemovedPkgs:     ScanRemovedPkgsFlag.Clone(),
 		Platform:            PlatformFlag.Clone(),
+		AllPlatforms:        AllPlatformsFlag.Clone(),
+		MergePlatforms:      MergePlatformsFlag.Clone(),
 		DockerHost:          DockerHostFlag.Clone(),
 		PodmanHost:          PodmanHostFlag.Clone(),
 		ImageSources:        SourceFlag.Clone(),
@@ -102,6 +122,8 @@ func (f *ImageFlagGroup) Flags() []Flagger {
 		f.ImageConfigScanners,
 		f.ScanRemovedPkgs,
 		f.Platform,
+		f.AllPlatforms,
+		f.MergePlatforms,
 		f.DockerHost,
 		f.PodmanHost,
 		f.ImageSources,
@@ -113,16 +135,31 @@ func (f *ImageFlagGroup) ToOptions() (ImageOptions, error) {
 		return ImageOptions{}, err
 	}
 
-	var platform ftypes.Platform
+	var platforms []ftypes.Platform
 	if p := f.Platform.Value(); p != "" {
-		pl, err := v1.ParsePlatform(p)
-		if err != nil {
-			return ImageOptions{}, xerrors.Errorf("unable to parse platform: %w", err)
-		}
-		if pl.OS == "*" {
-			pl.OS = "" // Empty OS means any OS
+		// e.g. linux/amd64,linux/arm64
+		for _, s := range strings.Split(p, ",") {
+			pl, err := v1.ParsePlatform(strings.TrimSpace(s))
+			if err != nil {
+				return ImageOptions{}, xerrors.Errorf("unable to parse platform: %w", err)
+			}
+			if pl.OS == "*" {
+				pl.OS = "" // Empty OS means any OS
+			}
+			platforms = append(platforms, ftypes.Platform{Platform: pl})
 		}
-		platform = ftypes.Platform{Platform: pl}
+	}
+	if f.AllPlatforms.Value() && len(platforms) > 0 {
+		return ImageOptions{}, xerrors.New(`"--all-platforms" and "--platform" cannot be used together`)
+	}
+
+	// A single platform is scanned as usual, and multiple platforms are scanned one by one
+	var platform ftypes.Platform
+	if len(platforms) == 1 {
+		platform, platforms = platforms[0], nil
+	}
+	if f.MergePlatforms.Value() && !f.AllPlatforms.Value() && len(platforms) == 0 {
+		log.Warn(`"--merge-platforms" can be used only with "--all-platforms" or multiple platforms in "--platform".`)
 	}
 
 	return ImageOptions{
@@ -130,6 +167,9 @@ func (f *ImageFlagGroup) ToOptions() (ImageOptions, error) {
 		ImageConfigScanners: xstrings.ToTSlice[types.Scanner](f.ImageConfigScanners.Value()),
 		ScanRemovedPkgs:     f.ScanRemovedPkgs.Value(),
 		Platform:            platform,
+		Platforms:           platforms,
+		AllPlatforms:        f.AllPlatforms.Value(),
+		MergePlatforms:      f.MergePlatforms.Value(),
 		DockerHost:          f.DockerHost.Value(),
 		PodmanHost:          f.PodmanHost.Value(),
 		ImageSources:        xstrings.ToTSlice[ftypes.ImageSource](f.ImageSources.Value()),
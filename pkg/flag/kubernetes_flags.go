@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -71,7 +72,7 @@ var (
 	ExcludeNamespaces = Flag[[]string]{
 		Name:       "exclude-namespaces",
 		ConfigName: "kubernetes.excludeNamespaces",
-		Usage:      "indicate the namespaces excluded from scanning (example: kube-system)",
+		Usage:      "indicate the namespaces excluded from scanning, glob patterns are supported (example: kube-system,kube-*)",
 	}
 	IncludeNamespaces = Flag[[]string]{
 		Name:       "include-namespaces",
@@ -90,6 +91,11 @@ var (
 		Default:    10,
 		Usage:      "specify the maximum burst for throttle",
 	}
+	AdmissionReview = Flag[bool]{
+		Name:       "admission-review",
+		ConfigName: "kubernetes.admission-review",
+		Usage:      "read an AdmissionReview request from stdin, scan its resource and write the AdmissionReview response",
+	}
 )
 
 type K8sFlagGroup struct {
@@ -108,6 +114,7 @@ type K8sFlagGroup struct {
 	IncludeNamespaces      *Flag[[]string]
 	QPS                    *Flag[float64]
 	Burst                  *Flag[int]
+	AdmissionReview        *Flag[bool]
 }
 
 type K8sOptions struct {
@@ -126,6 +133,7 @@ type K8sOptions struct {
 	QPS                    float32
 	SkipImages             bool
 	Burst                  int
+	AdmissionReview        bool
 }
 
 func NewK8sFlagGroup() *K8sFlagGroup {
@@ -145,6 +153,7 @@ func NewK8sFlagGroup() *K8sFlagGroup {
 		QPS:                    QPS.Clone(),
 		SkipImages:             SkipImages.Clone(),
 		Burst:                  Burst.Clone(),
+		AdmissionReview:        AdmissionReview.Clone(),
 	}
 }
 
@@ -169,6 +178,7 @@ func (f *K8sFlagGroup) Flags() []Flagger {
 		f.QPS,
 		f.SkipImages,
 		f.Burst,
+		f.AdmissionReview,
 	}
 }
 
@@ -194,6 +204,11 @@ func (f *K8sFlagGroup) ToOptions() (K8sOptions, error) {
 	if len(f.ExcludeNamespaces.Value()) > 0 && len(f.IncludeNamespaces.Value()) > 0 {
 		return K8sOptions{}, fmt.Errorf("include-namespaces and exclude-namespaces flags cannot be used together")
 	}
+	for _, pattern := range f.ExcludeNamespaces.Value() {
+		if !doublestar.ValidatePattern(pattern) {
+			return K8sOptions{}, fmt.Errorf("invalid namespace pattern: %s", pattern)
+		}
+	}
 	if len(f.ExcludeKinds.Value()) > 0 && len(f.IncludeKinds.Value()) > 0 {
 		return K8sOptions{}, fmt.Errorf("include-kinds and exclude-kinds flags cannot be used together")
 	}
@@ -214,6 +229,7 @@ func (f *K8sFlagGroup) ToOptions() (K8sOptions, error) {
 		ExcludeNamespaces:      f.ExcludeNamespaces.Value(),
 		IncludeNamespaces:      f.IncludeNamespaces.Value(),
 		Burst:                  f.Burst.Value(),
+		AdmissionReview:        f.AdmissionReview.Value(),
 	}, nil
 }
 
@@ -36,6 +36,11 @@ var (
 		ConfigName: "misconfiguration.include-non-failures",
 		Usage:      "include successes, available with '--scanners misconfig'",
 	}
+	ShowCodeFlag = Flag[bool]{
+		Name:       "show-code",
+		ConfigName: "misconfiguration.show-code",
+		Usage:      "show the lines of the source file around each misconfiguration in the table format, available for local files",
+	}
 	HelmValuesFileFlag = Flag[[]string]{
 		Name:       "helm-values",
 		ConfigName: "misconfiguration.helm.values",
@@ -113,6 +118,7 @@ var (
 // MisconfFlagGroup composes common printer flag structs used for commands providing misconfiguration scanning.
 type MisconfFlagGroup struct {
 	IncludeNonFailures     *Flag[bool]
+	ShowCode               *Flag[bool]
 	ResetChecksBundle      *Flag[bool]
 	ChecksBundleRepository *Flag[string]
 
@@ -132,6 +138,7 @@ type MisconfFlagGroup struct {
 
 type MisconfOptions struct {
 	IncludeNonFailures     bool
+	ShowCode               bool
 	ResetChecksBundle      bool
 	ChecksBundleRepository string
 
@@ -152,6 +159,7 @@ type MisconfOptions struct {
 func NewMisconfFlagGroup() *MisconfFlagGroup {
 	return &MisconfFlagGroup{
 		IncludeNonFailures:     IncludeNonFailuresFlag.Clone(),
+		ShowCode:               ShowCodeFlag.Clone(),
 		ResetChecksBundle:      ResetChecksBundleFlag.Clone(),
 		ChecksBundleRepository: ChecksBundleRepositoryFlag.Clone(),
 
@@ -176,6 +184,7 @@ func (f *MisconfFlagGroup) Name() string {
 func (f *MisconfFlagGroup) Flags() []Flagger {
 	return []Flagger{
 		f.IncludeNonFailures,
+		f.ShowCode,
 		f.ResetChecksBundle,
 		f.ChecksBundleRepository,
 		f.HelmValues,
@@ -199,6 +208,7 @@ func (f *MisconfFlagGroup) ToOptions() (MisconfOptions, error) {
 
 	return MisconfOptions{
 		IncludeNonFailures:      f.IncludeNonFailures.Value(),
+		ShowCode:                f.ShowCode.Value(),
 		ResetChecksBundle:       f.ResetChecksBundle.Value(),
 		ChecksBundleRepository:  f.ChecksBundleRepository.Value(),
 		HelmValues:              f.HelmValues.Value(),
@@ -1,13 +1,19 @@
 package flag
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"slices"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/samber/lo"
@@ -20,8 +26,10 @@ import (
 	"github.com/aquasecurity/trivy/pkg/cache"
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/feed"
 	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/plugin"
+	"github.com/aquasecurity/trivy/pkg/report/jsonarray"
 	"github.com/aquasecurity/trivy/pkg/result"
 	"github.com/aquasecurity/trivy/pkg/rpc/client"
 	"github.com/aquasecurity/trivy/pkg/types"
@@ -439,11 +447,18 @@ func (o *Options) enableSBOM() {
 	}
 
 	if o.Format == types.FormatCycloneDX || o.Format == types.FormatSPDX || o.Format == types.FormatSPDXJSON {
+		includeVulns := o.IncludeVulns && o.Format == types.FormatCycloneDX
 		// Vulnerability scanning is disabled by default for CycloneDX.
 		if !viper.IsSet(ScannersFlag.ConfigName) {
-			log.Info(fmt.Sprintf(`"--format %[1]s" disables security scanning. Specify "--scanners vuln" explicitly if you want to include vulnerabilities in the "%[1]s" report.`, o.Format))
+			if !includeVulns {
+				log.Info(fmt.Sprintf(`"--format %[1]s" disables security scanning. Specify "--scanners vuln" explicitly if you want to include vulnerabilities in the "%[1]s" report.`, o.Format))
+			}
 			o.Scanners = nil
 		}
+		// Vulnerabilities are embedded in the CycloneDX report and linked to components via "bom-ref".
+		if includeVulns {
+			o.Scanners.Enable(types.VulnerabilityScanner)
+		}
 		o.Scanners.Enable(types.SBOMScanner)
 	}
 }
@@ -461,8 +476,16 @@ func (o *Options) RegistryOpts() ftypes.RegistryOptions {
 
 // FilterOpts returns options for filtering
 func (o *Options) FilterOpts() result.FilterOptions {
+	// The KEV catalog is loaded only when it is shown or filtered on
+	var kevSource string
+	if o.ShowKEV || o.ShowKEVDue || o.KEVOnly {
+		kevSource = o.KEVSource
+	}
 	return result.FilterOptions{
 		Severities:         o.Severities,
+		VulnSeverities:     o.VulnSeverities,
+		MisconfSeverities:  o.MisconfSeverities,
+		SecretSeverities:   o.SecretSeverities,
 		IgnoreStatuses:     o.IgnoreStatuses,
 		IncludeNonFailures: o.IncludeNonFailures,
 		IgnoreFile:         o.IgnoreFile,
@@ -470,6 +493,27 @@ func (o *Options) FilterOpts() result.FilterOptions {
 		IgnoreLicenses:     o.IgnoredLicenses,
 		CacheDir:           o.CacheDir,
 		VEXSources:         o.VEXSources,
+		VEXReachability:    o.ShowReachability,
+		PkgFilters:         o.PkgFilters,
+		InternalPackages:   o.InternalPackages,
+		CountFiltered:      o.ShowFilteredCount,
+		MinSecretConf:      o.MinSecretConf,
+		KEVSource:          kevSource,
+		KEVOnly:            o.KEVOnly,
+		HideWontFix:        o.HideWontFix,
+		FeedOpts:           o.FeedOpts(),
+		BaselineFile:       o.BaselineFile,
+		HideKnown:          o.HideKnown,
+		MisconfDiff:        o.MisconfigDiff,
+	}
+}
+
+// FeedOpts returns options to download the data feeds such as the EPSS dataset and the KEV catalog
+func (o *Options) FeedOpts() feed.Options {
+	return feed.Options{
+		CacheDir: o.CacheDir,
+		Insecure: o.Insecure,
+		Offline:  o.OfflineScan,
 	}
 }
 
@@ -498,10 +542,11 @@ func (o *Options) RemoteCacheOpts() cache.RemoteOptions {
 
 func (o *Options) ClientScannerOpts() client.ScannerOption {
 	return client.ScannerOption{
-		RemoteURL:     o.ServerAddr,
-		CustomHeaders: o.CustomHeaders,
-		Insecure:      o.Insecure,
-		PathPrefix:    o.PathPrefix,
+		RemoteURL:       o.ServerAddr,
+		CustomHeaders:   o.CustomHeaders,
+		Insecure:        o.Insecure,
+		PathPrefix:      o.PathPrefix,
+		MaxResponseSize: o.MaxResponseSize,
 	}
 }
 
@@ -517,17 +562,69 @@ func (o *Options) OutputWriter(ctx context.Context) (io.Writer, func() error, er
 	switch {
 	case o.outputWriter != nil:
 		return o.outputWriter, cleanup, nil
+	case len(o.OutputCommand) > 0:
+		return o.outputCommandWriter(ctx)
 	case o.Output == "":
-		return os.Stdout, cleanup, nil
+		return o.compressWriter(os.Stdout, cleanup)
+	case o.Format == types.FormatSQLite:
+		// The database file is opened by the writer so that scans are appended to it
+		return io.Discard, cleanup, nil
 	case strings.HasPrefix(o.Output, "plugin="):
 		return o.outputPluginWriter(ctx)
+	case strings.HasPrefix(o.Output, "kafka://"):
+		// The report is published to Kafka by the writer
+		return io.Discard, cleanup, nil
+	case strings.HasPrefix(o.Output, "es://"), strings.HasPrefix(o.Output, "es+http://"):
+		// The findings are indexed into Elasticsearch by the writer
+		return io.Discard, cleanup, nil
+	case o.AppendOutput:
+		return o.appendWriter()
 	}
 
 	f, err := os.Create(o.Output)
 	if err != nil {
 		return nil, nil, xerrors.Errorf("failed to create output file: %w", err)
 	}
-	return f, f.Close, nil
+	return o.compressWriter(f, f.Close)
+}
+
+// OutputFile returns the path of the file the report is written to.
+// It returns an empty string if the report is written to stdout, a command, a plugin or a remote service such as Kafka.
+func (o *Options) OutputFile() string {
+	switch {
+	case len(o.OutputCommand) > 0, strings.HasPrefix(o.Output, "plugin="), strings.HasPrefix(o.Output, "kafka://"),
+		strings.HasPrefix(o.Output, "es://"), strings.HasPrefix(o.Output, "es+http://"):
+		return ""
+	}
+	return o.Output
+}
+
+// compressWriter wraps the writer with gzip when "--compress gzip" is specified or the output file name ends with ".gz".
+func (o *Options) compressWriter(w io.Writer, cleanup func() error) (io.Writer, func() error, error) {
+	if o.Compress != CompressGzip && filepath.Ext(o.Output) != ".gz" {
+		return w, cleanup, nil
+	}
+
+	gw := gzip.NewWriter(w)
+	return gw, func() error {
+		// The gzip footer must be flushed before closing the file
+		if err := gw.Close(); err != nil {
+			_ = cleanup()
+			return xerrors.Errorf("failed to close gzip writer: %w", err)
+		}
+		return cleanup()
+	}, nil
+}
+
+// appendWriter buffers the report and appends it to the JSON array in the output file on cleanup.
+func (o *Options) appendWriter() (io.Writer, func() error, error) {
+	buf := new(bytes.Buffer)
+	return buf, func() error {
+		if err := jsonarray.Append(o.Output, buf.Bytes()); err != nil {
+			return xerrors.Errorf("failed to append the report to %s: %w", o.Output, err)
+		}
+		return nil
+	}, nil
 }
 
 func (o *Options) outputPluginWriter(ctx context.Context) (io.Writer, func() error, error) {
@@ -554,6 +651,57 @@ func (o *Options) outputPluginWriter(ctx context.Context) (io.Writer, func() err
 	return pw, cleanup, nil
 }
 
+// outputCommandWriter pipes the report into the standard input of the command given by "--output-command".
+// The command inherits stdout and stderr, and its failure is returned on cleanup.
+func (o *Options) outputCommandWriter(ctx context.Context) (io.Writer, func() error, error) {
+	cmd := exec.CommandContext(ctx, o.OutputCommand[0], o.OutputCommand[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to open the stdin of the output command: %w", err)
+	}
+	if err = cmd.Start(); err != nil {
+		return nil, nil, xerrors.Errorf("failed to start the output command: %w", err)
+	}
+
+	w := &commandWriter{w: stdin}
+	return o.compressWriter(w, func() error {
+		if err := stdin.Close(); err != nil && !isBrokenPipe(err) {
+			return xerrors.Errorf("failed to close the stdin of the output command: %w", err)
+		}
+		if err := cmd.Wait(); err != nil {
+			return xerrors.Errorf("output command failed: %w", err)
+		}
+		return nil
+	})
+}
+
+// commandWriter writes to the stdin of a command.
+// If the command exits without reading the whole input, the rest is discarded
+// so that the exit status of the command is reported instead of a broken pipe.
+type commandWriter struct {
+	w      io.Writer
+	broken bool
+}
+
+func (cw *commandWriter) Write(p []byte) (int, error) {
+	if cw.broken {
+		return len(p), nil
+	}
+	n, err := cw.w.Write(p)
+	if err != nil && isBrokenPipe(err) {
+		log.Debug("The output command exited without reading the whole report")
+		cw.broken = true
+		return len(p), nil
+	}
+	return n, err
+}
+
+func isBrokenPipe(err error) bool {
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, os.ErrClosed)
+}
+
 // groups returns all the flag groups other than global flags
 func (f *Flags) groups() []FlagGroup {
 	var groups []FlagGroup
@@ -2,13 +2,19 @@ package flag_test
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/aquasecurity/trivy/pkg/flag"
@@ -153,3 +159,197 @@ func newLogger(level slog.Level) Output {
 	log.SetDefault(logger)
 	return Output{b: out}
 }
+
+func TestOptions_Align(t *testing.T) {
+	tests := []struct {
+		name         string
+		format       types.Format
+		includeVulns bool
+		scanners     types.Scanners
+		want         types.Scanners
+	}{
+		{
+			name:     "cyclonedx",
+			format:   types.FormatCycloneDX,
+			scanners: types.Scanners{types.VulnerabilityScanner, types.SecretScanner},
+			want:     types.Scanners{types.SBOMScanner},
+		},
+		{
+			name:         "cyclonedx with vulnerabilities",
+			format:       types.FormatCycloneDX,
+			includeVulns: true,
+			scanners:     types.Scanners{types.VulnerabilityScanner, types.SecretScanner},
+			want:         types.Scanners{types.VulnerabilityScanner, types.SBOMScanner},
+		},
+		{
+			name:         "spdx ignores --include-vulns",
+			format:       types.FormatSPDXJSON,
+			includeVulns: true,
+			scanners:     types.Scanners{types.VulnerabilityScanner, types.SecretScanner},
+			want:         types.Scanners{types.SBOMScanner},
+		},
+		{
+			name:     "table",
+			format:   types.FormatTable,
+			scanners: types.Scanners{types.VulnerabilityScanner, types.SecretScanner},
+			want:     types.Scanners{types.VulnerabilityScanner, types.SecretScanner, types.SBOMScanner},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+
+			opts := flag.Options{
+				ReportOptions: flag.ReportOptions{
+					Format:       tt.format,
+					IncludeVulns: tt.includeVulns,
+				},
+				ScanOptions: flag.ScanOptions{
+					Scanners: tt.scanners,
+				},
+			}
+			err := opts.Align(&flag.Flags{
+				ScanFlagGroup: flag.NewScanFlagGroup(),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, opts.Scanners)
+		})
+	}
+}
+
+func TestOptions_OutputWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		compress string
+		wantGzip bool
+	}{
+		{
+			name:   "plain",
+			output: "report.json",
+		},
+		{
+			name:     "gz extension",
+			output:   "report.json.gz",
+			wantGzip: true,
+		},
+		{
+			name:     "explicit compression",
+			output:   "report.json",
+			compress: flag.CompressGzip,
+			wantGzip: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputPath := filepath.Join(t.TempDir(), tt.output)
+			opts := flag.Options{
+				ReportOptions: flag.ReportOptions{
+					Output:   outputPath,
+					Compress: tt.compress,
+				},
+			}
+
+			w, cleanup, err := opts.OutputWriter(context.Background())
+			require.NoError(t, err)
+			_, err = w.Write([]byte(`{"SchemaVersion":2}`))
+			require.NoError(t, err)
+			require.NoError(t, cleanup())
+
+			f, err := os.Open(outputPath)
+			require.NoError(t, err)
+			defer f.Close()
+
+			var r io.Reader = f
+			if tt.wantGzip {
+				r, err = gzip.NewReader(f)
+				require.NoError(t, err)
+			}
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"SchemaVersion":2}`, string(got))
+		})
+	}
+}
+
+func TestOptions_OutputWriter_SQLite(t *testing.T) {
+	// The existing database must not be truncated since scans are appended to it by the writer
+	outputPath := filepath.Join(t.TempDir(), "trivy.db")
+	require.NoError(t, os.WriteFile(outputPath, []byte("existing"), 0o600))
+
+	opts := flag.Options{
+		ReportOptions: flag.ReportOptions{
+			Format: types.FormatSQLite,
+			Output: outputPath,
+		},
+	}
+	w, cleanup, err := opts.OutputWriter(context.Background())
+	require.NoError(t, err)
+	_, err = w.Write([]byte("ignored"))
+	require.NoError(t, err)
+	require.NoError(t, cleanup())
+
+	got, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, "existing", string(got))
+}
+
+func TestOptions_OutputWriter_Command(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "report.json")
+	tests := []struct {
+		name    string
+		command []string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "happy path",
+			command: []string{"sh", "-c", `tr a-z A-Z > "$0"`, outputPath},
+			input:   "report",
+			want:    "REPORT",
+		},
+		{
+			name:    "non-zero exit",
+			command: []string{"sh", "-c", "cat > /dev/null; exit 3"},
+			input:   "report",
+			wantErr: "output command failed: exit status 3",
+		},
+		{
+			name:    "command not reading the input",
+			command: []string{"true"},
+			input:   strings.Repeat("report", 100000),
+		},
+		{
+			name:    "command not found",
+			command: []string{"trivy-no-such-command"},
+			wantErr: "failed to start the output command",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := flag.Options{
+				ReportOptions: flag.ReportOptions{
+					OutputCommand: tt.command,
+				},
+			}
+			w, cleanup, err := opts.OutputWriter(context.Background())
+			if err == nil {
+				_, err = io.WriteString(w, tt.input)
+				require.NoError(t, err)
+				err = cleanup()
+			}
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.want != "" {
+				got, err := os.ReadFile(outputPath)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(got))
+			}
+		})
+	}
+}
@@ -1,5 +1,9 @@
 package flag
 
+import (
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
 // e.g. config yaml:
 //
 //	rego:
@@ -29,6 +33,11 @@ var (
 		ConfigName: "rego.trace",
 		Usage:      "enable more verbose trace output for custom queries",
 	}
+	ShowPolicySourceFlag = Flag[bool]{
+		Name:       "show-policy-source",
+		ConfigName: "rego.show-policy-source",
+		Usage:      "show the source of the Rego rule alongside the trace output",
+	}
 	ConfigCheckFlag = Flag[[]string]{
 		Name:       "config-check",
 		ConfigName: "rego.check",
@@ -62,6 +71,7 @@ type RegoFlagGroup struct {
 	IncludeDeprecatedChecks *Flag[bool]
 	SkipCheckUpdate         *Flag[bool]
 	Trace                   *Flag[bool]
+	ShowPolicySource        *Flag[bool]
 	CheckPaths              *Flag[[]string]
 	DataPaths               *Flag[[]string]
 	CheckNamespaces         *Flag[[]string]
@@ -71,6 +81,7 @@ type RegoOptions struct {
 	IncludeDeprecatedChecks bool
 	SkipCheckUpdate         bool
 	Trace                   bool
+	ShowPolicySource        bool
 	CheckPaths              []string
 	DataPaths               []string
 	CheckNamespaces         []string
@@ -81,6 +92,7 @@ func NewRegoFlagGroup() *RegoFlagGroup {
 		IncludeDeprecatedChecks: IncludeDeprecatedChecksFlag.Clone(),
 		SkipCheckUpdate:         SkipCheckUpdateFlag.Clone(),
 		Trace:                   TraceFlag.Clone(),
+		ShowPolicySource:        ShowPolicySourceFlag.Clone(),
 		CheckPaths:              ConfigCheckFlag.Clone(),
 		DataPaths:               ConfigDataFlag.Clone(),
 		CheckNamespaces:         CheckNamespaceFlag.Clone(),
@@ -96,6 +108,7 @@ func (f *RegoFlagGroup) Flags() []Flagger {
 		f.IncludeDeprecatedChecks,
 		f.SkipCheckUpdate,
 		f.Trace,
+		f.ShowPolicySource,
 		f.CheckPaths,
 		f.DataPaths,
 		f.CheckNamespaces,
@@ -107,10 +120,15 @@ func (f *RegoFlagGroup) ToOptions() (RegoOptions, error) {
 		return RegoOptions{}, err
 	}
 
+	if f.ShowPolicySource.Value() && !f.Trace.Value() {
+		log.Warn(`"--show-policy-source" can be used only with "--trace".`)
+	}
+
 	return RegoOptions{
 		IncludeDeprecatedChecks: f.IncludeDeprecatedChecks.Value(),
 		SkipCheckUpdate:         f.SkipCheckUpdate.Value(),
 		Trace:                   f.Trace.Value(),
+		ShowPolicySource:        f.ShowPolicySource.Value(),
 		CheckPaths:              f.CheckPaths.Value(),
 		DataPaths:               f.DataPaths.Value(),
 		CheckNamespaces:         f.CheckNamespaces.Value(),
@@ -4,7 +4,10 @@ import (
 	"net/http"
 	"strings"
 
+	"golang.org/x/xerrors"
+
 	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/rpc/client"
 )
 
 const (
@@ -33,6 +36,12 @@ var (
 		ConfigName: "server.custom-headers",
 		Usage:      "custom headers in client mode",
 	}
+	ServerMaxResponseSizeFlag = Flag[int]{
+		Name:       "max-response-size",
+		ConfigName: "server.max-response-size",
+		Default:    int(client.DefaultMaxResponseSize >> 20),
+		Usage:      "maximum size of responses from the server in MiB in client mode",
+	}
 	ServerListenFlag = Flag[string]{
 		Name:       "listen",
 		ConfigName: "server.listen",
@@ -56,8 +65,9 @@ type RemoteFlagGroup struct {
 	PathPrefix  *Flag[string]
 
 	// for client
-	ServerAddr    *Flag[string]
-	CustomHeaders *Flag[[]string]
+	ServerAddr      *Flag[string]
+	CustomHeaders   *Flag[[]string]
+	MaxResponseSize *Flag[int]
 
 	// for server
 	Listen *Flag[string]
@@ -71,6 +81,9 @@ type RemoteOptions struct {
 	Listen        string
 	CustomHeaders http.Header
 
+	// Maximum size of responses from the server in bytes
+	MaxResponseSize int64
+
 	// Server endpoint: <baseURL>[<prefix>]/<package>.<Service>/<Method> (default prefix: /twirp)
 	// e.g., http://localhost:4954/twirp/trivy.scanner.v1.Scanner/Scan
 	PathPrefix string
@@ -78,11 +91,12 @@ type RemoteOptions struct {
 
 func NewClientFlags() *RemoteFlagGroup {
 	return &RemoteFlagGroup{
-		Token:         ServerTokenFlag.Clone(),
-		TokenHeader:   ServerTokenHeaderFlag.Clone(),
-		PathPrefix:    ServerPathPrefixFlag.Clone(),
-		ServerAddr:    ServerAddrFlag.Clone(),
-		CustomHeaders: ServerCustomHeadersFlag.Clone(),
+		Token:           ServerTokenFlag.Clone(),
+		TokenHeader:     ServerTokenHeaderFlag.Clone(),
+		PathPrefix:      ServerPathPrefixFlag.Clone(),
+		ServerAddr:      ServerAddrFlag.Clone(),
+		CustomHeaders:   ServerCustomHeadersFlag.Clone(),
+		MaxResponseSize: ServerMaxResponseSizeFlag.Clone(),
 	}
 }
 
@@ -106,6 +120,7 @@ func (f *RemoteFlagGroup) Flags() []Flagger {
 		f.PathPrefix,
 		f.ServerAddr,
 		f.CustomHeaders,
+		f.MaxResponseSize,
 		f.Listen,
 	}
 }
@@ -132,6 +147,12 @@ func (f *RemoteFlagGroup) ToOptions() (RemoteOptions, error) {
 		}
 	}
 
+	// The flag is absent in server mode
+	maxResponseSize := f.MaxResponseSize.Value()
+	if f.MaxResponseSize != nil && maxResponseSize <= 0 {
+		return RemoteOptions{}, xerrors.Errorf("'--max-response-size' must be positive: %d", maxResponseSize)
+	}
+
 	if token == "" && tokenHeader != DefaultTokenHeader {
 		log.Warn(`"--token-header" should be used with "--token"`)
 	}
@@ -141,12 +162,13 @@ func (f *RemoteFlagGroup) ToOptions() (RemoteOptions, error) {
 	}
 
 	return RemoteOptions{
-		Token:         token,
-		TokenHeader:   tokenHeader,
-		PathPrefix:    f.PathPrefix.Value(),
-		ServerAddr:    serverAddr,
-		CustomHeaders: customHeaders,
-		Listen:        listen,
+		Token:           token,
+		TokenHeader:     tokenHeader,
+		PathPrefix:      f.PathPrefix.Value(),
+		ServerAddr:      serverAddr,
+		CustomHeaders:   customHeaders,
+		Listen:          listen,
+		MaxResponseSize: int64(maxResponseSize) << 20,
 	}, nil
 }
 
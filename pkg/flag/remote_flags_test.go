@@ -119,3 +119,45 @@ func TestRemoteFlagGroup_ToOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoteFlagGroup_ToOptions_MaxResponseSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		want    int64
+		wantErr string
+	}{
+		{
+			name: "happy",
+			size: 256,
+			want: 256 << 20,
+		},
+		{
+			name:    "zero",
+			size:    0,
+			wantErr: "'--max-response-size' must be positive: 0",
+		},
+		{
+			name:    "negative",
+			size:    -1,
+			wantErr: "'--max-response-size' must be positive: -1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			viper.Set(flag.ServerMaxResponseSizeFlag.ConfigName, tt.size)
+
+			f := &flag.RemoteFlagGroup{
+				MaxResponseSize: flag.ServerMaxResponseSizeFlag.Clone(),
+			}
+			got, err := f.ToOptions()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.MaxResponseSize)
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package flag
 
 import (
+	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/mattn/go-shellwords"
 	"github.com/samber/lo"
 	"github.com/spf13/viper"
@@ -12,6 +15,9 @@ import (
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	"github.com/aquasecurity/trivy/pkg/cache"
 	"github.com/aquasecurity/trivy/pkg/compliance/spec"
+	"github.com/aquasecurity/trivy/pkg/epss"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/kev"
 	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/result"
 	"github.com/aquasecurity/trivy/pkg/types"
@@ -19,6 +25,12 @@ import (
 	xstrings "github.com/aquasecurity/trivy/pkg/x/strings"
 )
 
+const (
+	CompressGzip = "gzip"
+	SortByEPSS   = "epss"
+	SortByKEVDue = "kev-due"
+)
+
 // e.g. config yaml:
 //
 //	format: table
@@ -54,6 +66,26 @@ var (
 		ConfigName: "dependency-tree",
 		Usage:      "[EXPERIMENTAL] show dependency origin tree of vulnerable packages",
 	}
+	TreeDirectionFlag = Flag[string]{
+		Name:       "tree-direction",
+		ConfigName: "tree-direction",
+		Default:    "up",
+		Values: []string{
+			"up",
+			"down",
+		},
+		Usage: "direction of the dependency tree. 'up' shows the dependents of vulnerable packages, 'down' shows the dependencies from direct dependencies to vulnerable packages",
+	}
+	TreeShortestPathFlag = Flag[bool]{
+		Name:       "tree-shortest-path",
+		ConfigName: "tree-shortest-path",
+		Usage:      "show only the shortest path from a direct dependency to each vulnerable package in the dependency tree",
+	}
+	TreeMinPackagesFlag = Flag[int]{
+		Name:       "tree-min-packages",
+		ConfigName: "tree-min-packages",
+		Usage:      "render the dependency tree only when a result has at least this number of vulnerable packages (0 means always)",
+	}
 	ListAllPkgsFlag = Flag[bool]{
 		Name:       "list-all-pkgs",
 		ConfigName: "list-all-pkgs",
@@ -80,24 +112,114 @@ var (
 		ConfigName: "exit-on-eol",
 		Usage:      "exit with the specified code when the OS reaches end of service/life",
 	}
+	ExplainExitFlag = Flag[bool]{
+		Name:       "explain-exit",
+		ConfigName: "explain-exit",
+		Usage:      "write the reason for a non-zero exit code to stderr, e.g. \"exit-reason: severity=CRITICAL count=3 threshold=--severity\"",
+	}
+	FailOnEmptyFlag = Flag[bool]{
+		Name:       "fail-on-empty",
+		ConfigName: "fail-on-empty",
+		Usage:      "exit with a non-zero code when neither packages nor findings are detected, e.g. due to a wrong target or skipped files",
+	}
 	OutputFlag = Flag[string]{
 		Name:       "output",
 		ConfigName: "output",
 		Shorthand:  "o",
 		Usage:      "output file name",
 	}
+	CompressFlag = Flag[string]{
+		Name:       "compress",
+		ConfigName: "compress",
+		Values:     []string{CompressGzip},
+		Usage:      "compress the output, inferred from the \".gz\" extension of the output file",
+	}
+	AppendOutputFlag = Flag[bool]{
+		Name:       "append-output",
+		ConfigName: "append-output",
+		Usage:      "append the JSON report to the array in the output file instead of overwriting it",
+	}
+	OutputDirFlag = Flag[string]{
+		Name:       "output-dir",
+		ConfigName: "output-dir",
+		Usage:      "write the report of each target into a separate file in the directory",
+	}
+	CountByFlag = Flag[string]{
+		Name:       "count-by",
+		ConfigName: "count-by",
+		Values:     []string{"severity"},
+		Usage:      "print the number of findings per group with \"--format count\"",
+	}
+	SyslogAddrFlag = Flag[string]{
+		Name:       "syslog-addr",
+		ConfigName: "syslog-addr",
+		Usage:      "syslog server address with \"--format syslog\" (e.g. udp://localhost:514, tcp://localhost:514)",
+	}
+	ESUsernameFlag = Flag[string]{
+		Name:       "es-username",
+		ConfigName: "es-username",
+		Usage:      "username for the basic authentication with \"--output es://\"",
+	}
+	ESPasswordFlag = Flag[string]{
+		Name:       "es-password",
+		ConfigName: "es-password",
+		Usage:      "password for the basic authentication with \"--output es://\". TRIVY_ES_PASSWORD should be used for security reasons.",
+	}
+	ESAPIKeyFlag = Flag[string]{
+		Name:       "es-api-key",
+		ConfigName: "es-api-key",
+		Usage:      "API key with \"--output es://\", used instead of the basic authentication. TRIVY_ES_API_KEY should be used for security reasons.",
+	}
 	OutputPluginArgFlag = Flag[string]{
 		Name:       "output-plugin-arg",
 		ConfigName: "output-plugin-arg",
 		Usage:      "[EXPERIMENTAL] output plugin arguments",
 	}
+	OutputCommandFlag = Flag[string]{
+		Name:       "output-command",
+		ConfigName: "output-command",
+		Usage:      "pipe the report into the standard input of the command, e.g. \"gpg --encrypt -r alice\"",
+	}
 	SeverityFlag = Flag[[]string]{
-		Name:       "severity",
-		ConfigName: "severity",
-		Shorthand:  "s",
+		Name:           "severity",
+		ConfigName:     "severity",
+		Shorthand:      "s",
+		Default:        dbTypes.SeverityNames,
+		Values:         dbTypes.SeverityNames,
+		ValueNormalize: expandSeverities,
+		Usage:          "severities of security issues to be displayed, also accepting ranges (e.g. HIGH-CRITICAL) and negations (e.g. !LOW)",
+	}
+	VulnSeverityFlag = Flag[[]string]{
+		Name:           "vuln-severity",
+		ConfigName:     "vuln-severity",
+		Values:         dbTypes.SeverityNames,
+		ValueNormalize: expandSeverities,
+		Usage:          `severities of vulnerabilities to be displayed, overriding "--severity"`,
+	}
+	MisconfigSeverityFlag = Flag[[]string]{
+		Name:           "misconfig-severity",
+		ConfigName:     "misconfig-severity",
+		Values:         dbTypes.SeverityNames,
+		ValueNormalize: expandSeverities,
+		Usage:          `severities of misconfigurations to be displayed, overriding "--severity"`,
+	}
+	SecretSeverityFlag = Flag[[]string]{
+		Name:           "secret-severity",
+		ConfigName:     "secret-severity",
+		Values:         dbTypes.SeverityNames,
+		ValueNormalize: expandSeverities,
+		Usage:          `severities of secrets to be displayed, overriding "--severity"`,
+	}
+	SeverityOrderFlag = Flag[[]string]{
+		Name:       "severity-order",
+		ConfigName: "severity-order",
 		Default:    dbTypes.SeverityNames,
-		Values:     dbTypes.SeverityNames,
-		Usage:      "severities of security issues to be displayed",
+		Usage:      "order of severities from the lowest to the highest, used for summaries, sorting and grouping in reports",
+	}
+	SeverityLabelsFlag = Flag[[]string]{
+		Name:       "severity-labels",
+		ConfigName: "severity-labels",
+		Usage:      "labels of severities rendered in the severity column and the summary in the table format (e.g. CRITICAL=C,HIGH=H)",
 	}
 	ComplianceFlag = Flag[string]{
 		Name:       "compliance",
@@ -109,60 +231,623 @@ var (
 		ConfigName: "scan.show-suppressed",
 		Usage:      "[EXPERIMENTAL] show suppressed vulnerabilities",
 	}
+	JSONCompactFlag = Flag[bool]{
+		Name:       "json-compact",
+		ConfigName: "json-compact",
+		Usage:      "omit empty and zero-valued fields and minify the JSON report",
+	}
+	ValidateOutputFlag = Flag[bool]{
+		Name:       "validate-output",
+		ConfigName: "validate-output",
+		Usage:      "validate the JSON report against the JSON schema before writing it",
+	}
+	PkgFilterFlag = Flag[[]string]{
+		Name:       "pkg-filter",
+		ConfigName: "pkg-filter",
+		Default:    []string{},
+		Usage:      "glob patterns of package names to be reported (e.g. 'org.springframework:*')",
+	}
+	InternalPackagesFlag = Flag[[]string]{
+		Name:       "internal-packages",
+		ConfigName: "internal-packages",
+		Default:    []string{},
+		Usage:      "glob patterns of first-party package names to be excluded from vulnerability matching (e.g. '@my-org/*')",
+	}
+	ShowReachabilityFlag = Flag[bool]{
+		Name:       "show-reachability",
+		ConfigName: "show-reachability",
+		Usage:      "show whether the vulnerable code is reachable, as stated by VEX documents, in the table format",
+	}
+	NoCellMergeFlag = Flag[bool]{
+		Name:       "no-cell-merge",
+		ConfigName: "no-cell-merge",
+		Usage:      "disable merging identical adjacent cells in the table format",
+	}
+	ColumnsLayoutFlag = Flag[int]{
+		Name:       "columns-layout",
+		ConfigName: "columns-layout",
+		Default:    1,
+		Usage:      "number of results rendered side by side in the table format when the terminal is wide enough",
+	}
+	InteractiveFlag = Flag[bool]{
+		Name:       "interactive",
+		ConfigName: "interactive",
+		Usage:      "browse the results with collapsible sections in the terminal in the table format",
+	}
+	ShowLayerFlag = Flag[bool]{
+		Name:       "show-layer",
+		ConfigName: "show-layer",
+		Usage:      "show the image layer that introduced each vulnerable package in the table format",
+	}
+	ShowPURLFlag = Flag[bool]{
+		Name:       "show-purl",
+		ConfigName: "show-purl",
+		Usage:      "show the package URL (PURL) of each vulnerable package in the table format",
+	}
+	ShowBlastRadiusFlag = Flag[bool]{
+		Name:       "show-blast-radius",
+		ConfigName: "show-blast-radius",
+		Usage:      "show the number of packages depending on each vulnerable package directly or transitively in the table format",
+	}
+	ShowFixCommandFlag = Flag[bool]{
+		Name:       "show-fix-command",
+		ConfigName: "show-fix-command",
+		Usage:      "show the package manager command upgrading each vulnerable package to the fixed version in the table format",
+	}
+	FixableFirstFlag = Flag[bool]{
+		Name:       "fixable-first",
+		ConfigName: "fixable-first",
+		Usage:      "sort vulnerabilities with fixed versions first, and then by severity, in the table format",
+	}
+	PrereleaseFixesFlag = Flag[bool]{
+		Name:       "flag-prerelease-fixes",
+		ConfigName: "flag-prerelease-fixes",
+		Usage:      "mark fixed versions that are pre-releases (e.g. 2.0.0-rc1) in the table format, for ecosystems using Semantic Versioning",
+	}
+	SeverityConflictsFlag = Flag[bool]{
+		Name:       "flag-severity-conflicts",
+		ConfigName: "flag-severity-conflicts",
+		Usage:      "mark vulnerabilities whose severity differs from the rating of another source by two or more levels, showing the divergent ratings in the table format",
+	}
+	SummaryBarFlag = Flag[bool]{
+		Name:       "summary-bar",
+		ConfigName: "summary-bar",
+		Usage:      "render the number of vulnerabilities per severity as a colored bar under the summary in the table format (terminal only)",
+	}
+	DirectOnlyFlag = Flag[bool]{
+		Name:       "direct-only",
+		ConfigName: "direct-only",
+		Usage:      "show only vulnerabilities in direct dependencies in the table format, counting the others separately",
+	}
+	FocusCVEFlag = Flag[[]string]{
+		Name:       "focus-cve",
+		ConfigName: "focus-cve",
+		Usage:      "list only the targets and packages affected by the vulnerability IDs in the table format",
+	}
+	RemediationPlanFlag = Flag[bool]{
+		Name:       "remediation-plan",
+		ConfigName: "remediation-plan",
+		Usage:      "list the package upgrades and the vulnerabilities fixed by each of them instead of the vulnerabilities in the table format",
+	}
+	ShowEPSSFlag = Flag[bool]{
+		Name:       "show-epss",
+		ConfigName: "show-epss",
+		Usage:      "show the EPSS score and percentile of each vulnerability",
+	}
+	ShowAffectedRangeFlag = Flag[bool]{
+		Name:       "show-affected-range",
+		ConfigName: "show-affected-range",
+		Usage:      "show the vulnerable versions in the advisory of each vulnerability in language-specific packages",
+	}
+	EPSSSourceFlag = Flag[string]{
+		Name:       "epss-source",
+		ConfigName: "epss-source",
+		Default:    epss.DefaultSource,
+		Usage:      "URL or local path of the EPSS dataset (CSV, optionally gzipped) used with \"--show-epss\"",
+	}
+	ShowKEVFlag = Flag[bool]{
+		Name:       "show-kev",
+		ConfigName: "show-kev",
+		Usage:      "show whether each vulnerability is in the CISA Known Exploited Vulnerabilities catalog",
+	}
+	ShowKEVDueFlag = Flag[bool]{
+		Name:       "show-kev-due",
+		ConfigName: "show-kev-due",
+		Usage:      "show the remediation due date of each vulnerability in the CISA Known Exploited Vulnerabilities catalog",
+	}
+	KEVOnlyFlag = Flag[bool]{
+		Name:       "kev-only",
+		ConfigName: "kev-only",
+		Usage:      "show only vulnerabilities in the CISA Known Exploited Vulnerabilities catalog",
+	}
+	KEVSourceFlag = Flag[string]{
+		Name:       "kev-source",
+		ConfigName: "kev-source",
+		Default:    kev.DefaultSource,
+		Usage:      "URL or local path of the CISA Known Exploited Vulnerabilities catalog (JSON) used with \"--show-kev\", \"--show-kev-due\" and \"--kev-only\"",
+	}
+	ShowFilteredCountFlag = Flag[bool]{
+		Name:       "show-filtered-count",
+		ConfigName: "show-filtered-count",
+		Usage:      "show the number of findings filtered out by each filter in the summary of the table format",
+	}
+	ShowVendorStatusFlag = Flag[bool]{
+		Name:       "show-vendor-status",
+		ConfigName: "show-vendor-status",
+		Usage:      "show the status in the vendor advisory of each vulnerability with the vendor and its severity in the table format (e.g. won't fix (Red Hat, LOW))",
+	}
+	HideWontFixFlag = Flag[bool]{
+		Name:       "hide-wont-fix",
+		ConfigName: "hide-wont-fix",
+		Usage:      "hide vulnerabilities that the vendor won't fix according to its advisory",
+	}
+	LabelsFileFlag = Flag[string]{
+		Name:       "labels-file",
+		ConfigName: "labels-file",
+		Usage:      "path to a YAML file with rules attaching labels to matching findings",
+	}
+	SLAFlag = Flag[[]string]{
+		Name:       "sla",
+		ConfigName: "sla",
+		Usage:      "time allowed to fix vulnerabilities for each severity since the published date, marking vulnerabilities exceeding it as breached (e.g. critical=7d,high=30d)",
+	}
+	FailOnSLABreachFlag = Flag[bool]{
+		Name:       "fail-on-sla-breach",
+		ConfigName: "fail-on-sla-breach",
+		Usage:      "exit with code 1 when any vulnerability breaches the SLA given by \"--sla\"",
+	}
+	LicenseAllowFlag = Flag[[]string]{
+		Name:       "license-allow",
+		ConfigName: "license-allow",
+		Usage:      "licenses allowed by the license policy, rendering the verdict of each detected license (e.g. MIT,Apache-2.0)",
+	}
+	LicenseDenyFlag = Flag[[]string]{
+		Name:       "license-deny",
+		ConfigName: "license-deny",
+		Usage:      "licenses denied by the license policy, rendering the verdict of each detected license (e.g. GPL-3.0)",
+	}
+	LicenseUnknownVerdictFlag = Flag[string]{
+		Name:       "license-unknown-verdict",
+		ConfigName: "license-unknown-verdict",
+		Values:     []string{"pass", "fail"},
+		Usage:      "verdict for licenses neither allowed nor denied, fail with \"--license-allow\" and pass otherwise by default",
+	}
+	FailOnLicenseViolationFlag = Flag[bool]{
+		Name:       "fail-on-license-violation",
+		ConfigName: "fail-on-license-violation",
+		Usage:      "exit with code 1 when any license fails the license policy given by \"--license-allow\" and \"--license-deny\"",
+	}
+	BaselineFileFlag = Flag[string]{
+		Name:       "baseline-file",
+		ConfigName: "baseline-file",
+		Usage:      "path to the JSON report of a prior scan, marking vulnerabilities in it as known and the others as new",
+	}
+	HideKnownFlag = Flag[bool]{
+		Name:       "hide-known",
+		ConfigName: "hide-known",
+		Usage:      "hide vulnerabilities known in the baseline given by \"--baseline-file\" and report only new ones",
+	}
+	MisconfigDiffFlag = Flag[bool]{
+		Name:       "misconfig-diff",
+		ConfigName: "misconfig-diff",
+		Usage:      "show only misconfigurations newly failing or newly passing since the baseline given by \"--baseline-file\"",
+	}
+	TrendFileFlag = Flag[string]{
+		Name:       "trend-file",
+		ConfigName: "trend-file",
+		Usage:      "path to the file storing the summary of the previous run, showing the change in the number of findings per severity",
+	}
+	SortByFlag = Flag[string]{
+		Name:       "sort-by",
+		ConfigName: "sort-by",
+		Values: []string{
+			SortByEPSS,
+			SortByKEVDue,
+		},
+		Usage: "sort vulnerabilities in the report (epss: highest EPSS score first, kev-due: earliest KEV due date first)",
+	}
+	ShowClassFlag = Flag[[]string]{
+		Name:       "show-class",
+		ConfigName: "show-class",
+		Values: xstrings.ToStringSlice([]types.ResultClass{
+			types.ClassOSPkg,
+			types.ClassLangPkg,
+			types.ClassConfig,
+			types.ClassSecret,
+			types.ClassLicense,
+			types.ClassLicenseFile,
+		}),
+		Usage: "result classes to be displayed in the table format (all classes by default)",
+	}
+	TimezoneFlag = Flag[string]{
+		Name:       "timezone",
+		ConfigName: "timezone",
+		Usage:      "IANA time zone name (e.g. 'Asia/Tokyo' or 'Local') used to display timestamps in the table format (UTC by default)",
+	}
+	TimeFormatFlag = Flag[string]{
+		Name:       "time-format",
+		ConfigName: "time-format",
+		Usage:      "Go layout (e.g. '2006-01-02 15:04 MST') used to display timestamps in the table format (RFC 3339 by default)",
+	}
+	QRCodeFlag = Flag[bool]{
+		Name:       "qr-code",
+		ConfigName: "qr-code",
+		Usage:      "print a QR code linking to the advisory of the most critical finding in the table format (terminal only)",
+	}
+	ShowVEXSuppressedFlag = Flag[bool]{
+		Name:       "show-vex-suppressed",
+		ConfigName: "show-vex-suppressed",
+		Usage:      "show vulnerabilities suppressed by VEX with their VEX status in the table format",
+	}
+	IncludeVulnsFlag = Flag[bool]{
+		Name:       "include-vulns",
+		ConfigName: "include-vulns",
+		Usage:      "include vulnerabilities in the CycloneDX report, which is the same as specifying \"--scanners vuln\"",
+	}
+	SPDXRelationshipsFlag = Flag[bool]{
+		Name:       "spdx-relationships-only",
+		ConfigName: "spdx-relationships-only",
+		Usage:      "write only the DEPENDS_ON relationships of the dependency graph, with only the names and versions of the packages, in the SPDX formats",
+	}
+	RelativePathsFlag = Flag[bool]{
+		Name:       "relative-paths",
+		ConfigName: "relative-paths",
+		Usage:      "render absolute paths in the report relative to the scanned directory or \"--relative-paths-base\"",
+	}
+	RelativePathsBaseFlag = Flag[string]{
+		Name:       "relative-paths-base",
+		ConfigName: "relative-paths-base",
+		Usage:      "base directory used by \"--relative-paths\" (defaults to the scanned directory)",
+	}
+	GroupBySeverityFlag = Flag[bool]{
+		Name:       "group-by-severity",
+		ConfigName: "group-by-severity",
+		Usage:      "group vulnerabilities by severity in the table format",
+	}
+	ShowCleanSummaryFlag = Flag[bool]{
+		Name:       "show-clean-summary",
+		ConfigName: "show-clean-summary",
+		Usage:      "show what was scanned, such as the number of packages, the OS and the scanners, when no findings are found in the table format",
+	}
+	GroupByInstructionFlag = Flag[bool]{
+		Name:       "group-by-instruction",
+		ConfigName: "group-by-instruction",
+		Usage:      "group vulnerabilities by the Dockerfile instruction that introduced the vulnerable package in the table format",
+	}
+	AgeHistogramFlag = Flag[bool]{
+		Name:       "age-histogram",
+		ConfigName: "age-histogram",
+		Usage:      "show the number of vulnerabilities per age based on their published dates",
+	}
+	ScorecardFlag = Flag[bool]{
+		Name:       "scorecard",
+		ConfigName: "scorecard",
+		Usage:      "show the percentage of misconfiguration checks passing with a letter grade",
+	}
+	BannerFlag = Flag[string]{
+		Name:       "banner",
+		ConfigName: "banner",
+		Usage:      "classification banner printed at the top and bottom of the table output and included in the JSON report (e.g. CONFIDENTIAL)",
+	}
+	MaxRowsFlag = Flag[int]{
+		Name:       "max-rows",
+		ConfigName: "max-rows",
+		Usage:      "maximum number of findings rendered per result in the table format (0 means unlimited)",
+	}
+	SecretMatchWidthFlag = Flag[int]{
+		Name:       "secret-match-width",
+		ConfigName: "secret-match-width",
+		Default:    60,
+		Usage:      "maximum number of characters of each secret line rendered in the table format (0 means unlimited)",
+	}
+	ShowSecretConfFlag = Flag[bool]{
+		Name:       "show-secret-confidence",
+		ConfigName: "show-secret-confidence",
+		Usage:      "show the confidence and the entropy of secrets in the table format",
+	}
+	SecretsByFileFlag = Flag[bool]{
+		Name:       "secrets-by-file",
+		ConfigName: "secrets-by-file",
+		Usage:      "group secrets by file in the table format, ordering files by the number of secrets and secrets by line number",
+	}
+	MinSecretConfFlag = Flag[string]{
+		Name:       "min-secret-confidence",
+		ConfigName: "min-secret-confidence",
+		Values:     xstrings.ToStringSlice(ftypes.SecretConfidences),
+		Usage:      "minimum confidence of secrets to be reported, based on the entropy of the secret",
+	}
 )
 
 // ReportFlagGroup composes common printer flag structs
 // used for commands requiring reporting logic.
 type ReportFlagGroup struct {
-	Format          *Flag[string]
-	ReportFormat    *Flag[string]
-	Template        *Flag[string]
-	DependencyTree  *Flag[bool]
-	ListAllPkgs     *Flag[bool]
-	IgnoreFile      *Flag[string]
-	IgnorePolicy    *Flag[string]
-	ExitCode        *Flag[int]
-	ExitOnEOL       *Flag[int]
-	Output          *Flag[string]
-	OutputPluginArg *Flag[string]
-	Severity        *Flag[[]string]
-	Compliance      *Flag[string]
-	ShowSuppressed  *Flag[bool]
+	Format            *Flag[string]
+	ReportFormat      *Flag[string]
+	Template          *Flag[string]
+	DependencyTree    *Flag[bool]
+	TreeDirection     *Flag[string]
+	TreeShortestPath  *Flag[bool]
+	TreeMinPackages   *Flag[int]
+	ListAllPkgs       *Flag[bool]
+	IgnoreFile        *Flag[string]
+	IgnorePolicy      *Flag[string]
+	ExitCode          *Flag[int]
+	ExitOnEOL         *Flag[int]
+	ExplainExit       *Flag[bool]
+	FailOnEmpty       *Flag[bool]
+	Output            *Flag[string]
+	OutputPluginArg   *Flag[string]
+	OutputCommand     *Flag[string]
+	Compress          *Flag[string]
+	AppendOutput      *Flag[bool]
+	OutputDir         *Flag[string]
+	SyslogAddr        *Flag[string]
+	ESUsername        *Flag[string]
+	ESPassword        *Flag[string]
+	ESAPIKey          *Flag[string]
+	Severity          *Flag[[]string]
+	VulnSeverity      *Flag[[]string]
+	MisconfigSeverity *Flag[[]string]
+	SecretSeverity    *Flag[[]string]
+	SeverityOrder     *Flag[[]string]
+	SeverityLabels    *Flag[[]string]
+	Compliance        *Flag[string]
+	ShowSuppressed    *Flag[bool]
+	MaxRows           *Flag[int]
+	SecretMatchWidth  *Flag[int]
+	ShowSecretConf    *Flag[bool]
+	SecretsByFile     *Flag[bool]
+	MinSecretConf     *Flag[string]
+	JSONCompact       *Flag[bool]
+	ValidateOutput    *Flag[bool]
+	PkgFilter         *Flag[[]string]
+	InternalPackages  *Flag[[]string]
+	ShowReachability  *Flag[bool]
+	AgeHistogram      *Flag[bool]
+	Scorecard         *Flag[bool]
+	Banner            *Flag[string]
+	GroupBySeverity   *Flag[bool]
+	GroupByInstr      *Flag[bool]
+	ShowCleanSummary  *Flag[bool]
+	ShowLayer         *Flag[bool]
+	ShowPURL          *Flag[bool]
+	ShowBlastRadius   *Flag[bool]
+	ShowFixCommand    *Flag[bool]
+	DirectOnly        *Flag[bool]
+	FixableFirst      *Flag[bool]
+	PrereleaseFixes   *Flag[bool]
+	SeverityConflicts *Flag[bool]
+	SummaryBar        *Flag[bool]
+	FocusCVE          *Flag[[]string]
+	RemediationPlan   *Flag[bool]
+	ShowEPSS          *Flag[bool]
+	EPSSSource        *Flag[string]
+	ShowAffectedRange *Flag[bool]
+	ShowKEV           *Flag[bool]
+	ShowKEVDue        *Flag[bool]
+	KEVOnly           *Flag[bool]
+	KEVSource         *Flag[string]
+	ShowVendorStatus  *Flag[bool]
+	HideWontFix       *Flag[bool]
+	ShowFilteredCount *Flag[bool]
+	LabelsFile        *Flag[string]
+	SLA               *Flag[[]string]
+	FailOnSLABreach   *Flag[bool]
+	LicenseAllow      *Flag[[]string]
+	LicenseDeny       *Flag[[]string]
+	LicenseUnknown    *Flag[string]
+	FailOnLicense     *Flag[bool]
+	BaselineFile      *Flag[string]
+	HideKnown         *Flag[bool]
+	MisconfigDiff     *Flag[bool]
+	TrendFile         *Flag[string]
+	SortBy            *Flag[string]
+	ShowClass         *Flag[[]string]
+	Timezone          *Flag[string]
+	TimeFormat        *Flag[string]
+	QRCode            *Flag[bool]
+	ShowVEXSuppressed *Flag[bool]
+	IncludeVulns      *Flag[bool]
+	SPDXRelationships *Flag[bool]
+	RelativePaths     *Flag[bool]
+	RelativePathsBase *Flag[string]
+	NoCellMerge       *Flag[bool]
+	ColumnsLayout     *Flag[int]
+	Interactive       *Flag[bool]
+	CountBy           *Flag[string]
 }
 
 type ReportOptions struct {
-	Format           types.Format
-	ReportFormat     string
-	Template         string
-	DependencyTree   bool
-	ListAllPkgs      bool
-	IgnoreFile       string
-	ExitCode         int
-	ExitOnEOL        int
-	IgnorePolicy     string
-	Output           string
-	OutputPluginArgs []string
-	Severities       []dbTypes.Severity
-	Compliance       spec.ComplianceSpec
-	ShowSuppressed   bool
+	Format            types.Format
+	ReportFormat      string
+	Template          string
+	DependencyTree    bool
+	TreeDirection     string
+	TreeShortestPath  bool
+	TreeMinPackages   int
+	ListAllPkgs       bool
+	IgnoreFile        string
+	ExitCode          int
+	ExitOnEOL         int
+	ExplainExit       bool
+	FailOnEmpty       bool
+	IgnorePolicy      string
+	Output            string
+	OutputPluginArgs  []string
+	OutputCommand     []string
+	Compress          string
+	AppendOutput      bool
+	OutputDir         string
+	SyslogAddr        string
+	ESUsername        string
+	ESPassword        string
+	ESAPIKey          string
+	Severities        []dbTypes.Severity
+	SeverityOrder     []string
+	SeverityLabels    map[string]string
+	VulnSeverities    []dbTypes.Severity
+	MisconfSeverities []dbTypes.Severity
+	SecretSeverities  []dbTypes.Severity
+	Compliance        spec.ComplianceSpec
+	ShowSuppressed    bool
+	MaxRows           int
+	SecretMatchWidth  int
+	ShowSecretConf    bool
+	SecretsByFile     bool
+	MinSecretConf     ftypes.SecretConfidence
+	JSONCompact       bool
+	ValidateOutput    bool
+	PkgFilters        []string
+	InternalPackages  []string
+	ShowReachability  bool
+	AgeHistogram      bool
+	Scorecard         bool
+	Banner            string
+	GroupBySeverity   bool
+	GroupByInstr      bool
+	ShowCleanSummary  bool
+	ShowLayer         bool
+	ShowPURL          bool
+	ShowBlastRadius   bool
+	ShowFixCommand    bool
+	DirectOnly        bool
+	FixableFirst      bool
+	PrereleaseFixes   bool
+	SeverityConflicts bool
+	SummaryBar        bool
+	FocusCVEs         []string
+	RemediationPlan   bool
+	ShowEPSS          bool
+	EPSSSource        string
+	ShowAffectedRange bool
+	ShowKEV           bool
+	ShowKEVDue        bool
+	KEVOnly           bool
+	KEVSource         string
+	ShowVendorStatus  bool
+	HideWontFix       bool
+	ShowFilteredCount bool
+	LabelsFile        string
+	SLA               types.SLA
+	FailOnSLABreach   bool
+	LicensePolicy     types.LicensePolicy
+	FailOnLicense     bool
+	BaselineFile      string
+	HideKnown         bool
+	MisconfigDiff     bool
+	TrendFile         string
+	SortBy            string
+	ShowClasses       []types.ResultClass
+	Timezone          *time.Location
+	TimeFormat        string
+	QRCode            bool
+	ShowVEXSuppressed bool
+	IncludeVulns      bool
+	SPDXRelationships bool
+	RelativePaths     bool
+	RelativePathsBase string
+	NoCellMerge       bool
+	ColumnsLayout     int
+	Interactive       bool
+	CountBy           string
 }
 
 func NewReportFlagGroup() *ReportFlagGroup {
 	return &ReportFlagGroup{
-		Format:          FormatFlag.Clone(),
-		ReportFormat:    ReportFormatFlag.Clone(),
-		Template:        TemplateFlag.Clone(),
-		DependencyTree:  DependencyTreeFlag.Clone(),
-		ListAllPkgs:     ListAllPkgsFlag.Clone(),
-		IgnoreFile:      IgnoreFileFlag.Clone(),
-		IgnorePolicy:    IgnorePolicyFlag.Clone(),
-		ExitCode:        ExitCodeFlag.Clone(),
-		ExitOnEOL:       ExitOnEOLFlag.Clone(),
-		Output:          OutputFlag.Clone(),
-		OutputPluginArg: OutputPluginArgFlag.Clone(),
-		Severity:        SeverityFlag.Clone(),
-		Compliance:      ComplianceFlag.Clone(),
-		ShowSuppressed:  ShowSuppressedFlag.Clone(),
+		Format:            FormatFlag.Clone(),
+		ReportFormat:      ReportFormatFlag.Clone(),
+		Template:          TemplateFlag.Clone(),
+		DependencyTree:    DependencyTreeFlag.Clone(),
+		TreeDirection:     TreeDirectionFlag.Clone(),
+		TreeShortestPath:  TreeShortestPathFlag.Clone(),
+		TreeMinPackages:   TreeMinPackagesFlag.Clone(),
+		ListAllPkgs:       ListAllPkgsFlag.Clone(),
+		IgnoreFile:        IgnoreFileFlag.Clone(),
+		IgnorePolicy:      IgnorePolicyFlag.Clone(),
+		ExitCode:          ExitCodeFlag.Clone(),
+		ExitOnEOL:         ExitOnEOLFlag.Clone(),
+		ExplainExit:       ExplainExitFlag.Clone(),
+		FailOnEmpty:       FailOnEmptyFlag.Clone(),
+		Output:            OutputFlag.Clone(),
+		OutputPluginArg:   OutputPluginArgFlag.Clone(),
+		OutputCommand:     OutputCommandFlag.Clone(),
+		Compress:          CompressFlag.Clone(),
+		AppendOutput:      AppendOutputFlag.Clone(),
+		OutputDir:         OutputDirFlag.Clone(),
+		SyslogAddr:        SyslogAddrFlag.Clone(),
+		ESUsername:        ESUsernameFlag.Clone(),
+		ESPassword:        ESPasswordFlag.Clone(),
+		ESAPIKey:          ESAPIKeyFlag.Clone(),
+		Severity:          SeverityFlag.Clone(),
+		VulnSeverity:      VulnSeverityFlag.Clone(),
+		MisconfigSeverity: MisconfigSeverityFlag.Clone(),
+		SecretSeverity:    SecretSeverityFlag.Clone(),
+		SeverityOrder:     SeverityOrderFlag.Clone(),
+		SeverityLabels:    SeverityLabelsFlag.Clone(),
+		Compliance:        ComplianceFlag.Clone(),
+		ShowSuppressed:    ShowSuppressedFlag.Clone(),
+		MaxRows:           MaxRowsFlag.Clone(),
+		SecretMatchWidth:  SecretMatchWidthFlag.Clone(),
+		ShowSecretConf:    ShowSecretConfFlag.Clone(),
+		SecretsByFile:     SecretsByFileFlag.Clone(),
+		MinSecretConf:     MinSecretConfFlag.Clone(),
+		JSONCompact:       JSONCompactFlag.Clone(),
+		ValidateOutput:    ValidateOutputFlag.Clone(),
+		PkgFilter:         PkgFilterFlag.Clone(),
+		InternalPackages:  InternalPackagesFlag.Clone(),
+		ShowReachability:  ShowReachabilityFlag.Clone(),
+		AgeHistogram:      AgeHistogramFlag.Clone(),
+		Scorecard:         ScorecardFlag.Clone(),
+		Banner:            BannerFlag.Clone(),
+		GroupBySeverity:   GroupBySeverityFlag.Clone(),
+		GroupByInstr:      GroupByInstructionFlag.Clone(),
+		ShowCleanSummary:  ShowCleanSummaryFlag.Clone(),
+		ShowLayer:         ShowLayerFlag.Clone(),
+		ShowPURL:          ShowPURLFlag.Clone(),
+		ShowBlastRadius:   ShowBlastRadiusFlag.Clone(),
+		ShowFixCommand:    ShowFixCommandFlag.Clone(),
+		DirectOnly:        DirectOnlyFlag.Clone(),
+		FixableFirst:      FixableFirstFlag.Clone(),
+		PrereleaseFixes:   PrereleaseFixesFlag.Clone(),
+		SeverityConflicts: SeverityConflictsFlag.Clone(),
+		SummaryBar:        SummaryBarFlag.Clone(),
+		FocusCVE:          FocusCVEFlag.Clone(),
+		RemediationPlan:   RemediationPlanFlag.Clone(),
+		ShowEPSS:          ShowEPSSFlag.Clone(),
+		EPSSSource:        EPSSSourceFlag.Clone(),
+		ShowAffectedRange: ShowAffectedRangeFlag.Clone(),
+		ShowKEV:           ShowKEVFlag.Clone(),
+		ShowKEVDue:        ShowKEVDueFlag.Clone(),
+		KEVOnly:           KEVOnlyFlag.Clone(),
+		KEVSource:         KEVSourceFlag.Clone(),
+		ShowVendorStatus:  ShowVendorStatusFlag.Clone(),
+		HideWontFix:       HideWontFixFlag.Clone(),
+		ShowFilteredCount: ShowFilteredCountFlag.Clone(),
+		LabelsFile:        LabelsFileFlag.Clone(),
+		SLA:               SLAFlag.Clone(),
+		FailOnSLABreach:   FailOnSLABreachFlag.Clone(),
+		LicenseAllow:      LicenseAllowFlag.Clone(),
+		LicenseDeny:       LicenseDenyFlag.Clone(),
+		LicenseUnknown:    LicenseUnknownVerdictFlag.Clone(),
+		FailOnLicense:     FailOnLicenseViolationFlag.Clone(),
+		BaselineFile:      BaselineFileFlag.Clone(),
+		HideKnown:         HideKnownFlag.Clone(),
+		MisconfigDiff:     MisconfigDiffFlag.Clone(),
+		TrendFile:         TrendFileFlag.Clone(),
+		SortBy:            SortByFlag.Clone(),
+		ShowClass:         ShowClassFlag.Clone(),
+		Timezone:          TimezoneFlag.Clone(),
+		TimeFormat:        TimeFormatFlag.Clone(),
+		QRCode:            QRCodeFlag.Clone(),
+		ShowVEXSuppressed: ShowVEXSuppressedFlag.Clone(),
+		IncludeVulns:      IncludeVulnsFlag.Clone(),
+		SPDXRelationships: SPDXRelationshipsFlag.Clone(),
+		RelativePaths:     RelativePathsFlag.Clone(),
+		RelativePathsBase: RelativePathsBaseFlag.Clone(),
+		NoCellMerge:       NoCellMergeFlag.Clone(),
+		ColumnsLayout:     ColumnsLayoutFlag.Clone(),
+		Interactive:       InteractiveFlag.Clone(),
+		CountBy:           CountByFlag.Clone(),
 	}
 }
 
@@ -176,16 +861,96 @@ func (f *ReportFlagGroup) Flags() []Flagger {
 		f.ReportFormat,
 		f.Template,
 		f.DependencyTree,
+		f.TreeDirection,
+		f.TreeShortestPath,
+		f.TreeMinPackages,
 		f.ListAllPkgs,
 		f.IgnoreFile,
 		f.IgnorePolicy,
 		f.ExitCode,
 		f.ExitOnEOL,
+		f.ExplainExit,
+		f.FailOnEmpty,
 		f.Output,
 		f.OutputPluginArg,
+		f.OutputCommand,
+		f.Compress,
+		f.AppendOutput,
+		f.OutputDir,
+		f.SyslogAddr,
+		f.ESUsername,
+		f.ESPassword,
+		f.ESAPIKey,
 		f.Severity,
+		f.VulnSeverity,
+		f.MisconfigSeverity,
+		f.SecretSeverity,
+		f.SeverityOrder,
+		f.SeverityLabels,
 		f.Compliance,
 		f.ShowSuppressed,
+		f.MaxRows,
+		f.SecretMatchWidth,
+		f.ShowSecretConf,
+		f.SecretsByFile,
+		f.MinSecretConf,
+		f.JSONCompact,
+		f.ValidateOutput,
+		f.PkgFilter,
+		f.InternalPackages,
+		f.ShowReachability,
+		f.AgeHistogram,
+		f.Scorecard,
+		f.Banner,
+		f.GroupBySeverity,
+		f.GroupByInstr,
+		f.ShowCleanSummary,
+		f.ShowLayer,
+		f.ShowPURL,
+		f.ShowBlastRadius,
+		f.ShowFixCommand,
+		f.DirectOnly,
+		f.FixableFirst,
+		f.PrereleaseFixes,
+		f.SeverityConflicts,
+		f.SummaryBar,
+		f.FocusCVE,
+		f.RemediationPlan,
+		f.ShowEPSS,
+		f.EPSSSource,
+		f.ShowAffectedRange,
+		f.ShowKEV,
+		f.ShowKEVDue,
+		f.KEVOnly,
+		f.KEVSource,
+		f.ShowVendorStatus,
+		f.HideWontFix,
+		f.ShowFilteredCount,
+		f.LabelsFile,
+		f.SLA,
+		f.FailOnSLABreach,
+		f.LicenseAllow,
+		f.LicenseDeny,
+		f.LicenseUnknown,
+		f.FailOnLicense,
+		f.BaselineFile,
+		f.HideKnown,
+		f.MisconfigDiff,
+		f.TrendFile,
+		f.SortBy,
+		f.ShowClass,
+		f.Timezone,
+		f.TimeFormat,
+		f.QRCode,
+		f.ShowVEXSuppressed,
+		f.IncludeVulns,
+		f.SPDXRelationships,
+		f.RelativePaths,
+		f.RelativePathsBase,
+		f.NoCellMerge,
+		f.ColumnsLayout,
+		f.Interactive,
+		f.CountBy,
 	}
 }
 
@@ -197,6 +962,9 @@ func (f *ReportFlagGroup) ToOptions() (ReportOptions, error) {
 	format := types.Format(f.Format.Value())
 	template := f.Template.Value()
 	dependencyTree := f.DependencyTree.Value()
+	treeDirection := f.TreeDirection.Value()
+	treeShortestPath := f.TreeShortestPath.Value()
+	treeMinPackages := f.TreeMinPackages.Value()
 	listAllPkgs := f.ListAllPkgs.Value()
 
 	if template != "" {
@@ -219,12 +987,361 @@ func (f *ReportFlagGroup) ToOptions() (ReportOptions, error) {
 
 	// "--dependency-tree" option is available only with "--format table".
 	if dependencyTree {
-		log.Info(`"--dependency-tree" only shows the dependents of vulnerable packages. ` +
-			`Note that it is the reverse of the usual dependency tree, which shows the packages that depend on the vulnerable package. ` +
-			`It supports limited package managers. Please see the document for the detail.`)
+		if treeDirection != "down" {
+			log.Info(`"--dependency-tree" only shows the dependents of vulnerable packages. ` +
+				`Note that it is the reverse of the usual dependency tree, which shows the packages that depend on the vulnerable package. ` +
+				`It supports limited package managers. Please see the document for the detail.`)
+		}
 		if format != types.FormatTable {
 			log.Warn(`"--dependency-tree" can be used only with "--format table".`)
 		}
+	} else if treeDirection == "down" {
+		log.Warn(`"--tree-direction" can be used only with "--dependency-tree".`)
+	}
+	if treeShortestPath && !dependencyTree {
+		log.Warn(`"--tree-shortest-path" can be used only with "--dependency-tree".`)
+	}
+	if treeMinPackages < 0 {
+		return ReportOptions{}, xerrors.Errorf("'--tree-min-packages' must not be negative: %d", treeMinPackages)
+	} else if treeMinPackages > 0 && !dependencyTree {
+		log.Warn(`"--tree-min-packages" can be used only with "--dependency-tree".`)
+	}
+
+	maxRows := f.MaxRows.Value()
+	if maxRows < 0 {
+		return ReportOptions{}, xerrors.Errorf("'--max-rows' must not be negative: %d", maxRows)
+	} else if maxRows > 0 && format != types.FormatTable {
+		log.Warn(`"--max-rows" can be used only with "--format table".`)
+	}
+
+	secretMatchWidth := f.SecretMatchWidth.Value()
+	if secretMatchWidth < 0 {
+		return ReportOptions{}, xerrors.Errorf("'--secret-match-width' must not be negative: %d", secretMatchWidth)
+	}
+
+	showSecretConf := f.ShowSecretConf.Value()
+	if showSecretConf && format != types.FormatTable {
+		log.Warn(`"--show-secret-confidence" can be used only with "--format table".`)
+	}
+
+	secretsByFile := f.SecretsByFile.Value()
+	if secretsByFile && format != types.FormatTable {
+		log.Warn(`"--secrets-by-file" can be used only with "--format table".`)
+	}
+
+	jsonCompact := f.JSONCompact.Value()
+	if jsonCompact && format != types.FormatJSON {
+		log.Warn(`"--json-compact" can be used only with "--format json".`)
+	}
+
+	validateOutput := f.ValidateOutput.Value()
+	if validateOutput && format != types.FormatJSON {
+		log.Warn(`"--validate-output" can be used only with "--format json".`)
+	}
+
+	showReachability := f.ShowReachability.Value()
+	if showReachability && format != types.FormatTable {
+		log.Warn(`"--show-reachability" can be used only with "--format table".`)
+	}
+
+	countBy := f.CountBy.Value()
+	if countBy != "" && format != types.FormatCount {
+		log.Warn(`"--count-by" can be used only with "--format count".`)
+	}
+
+	noCellMerge := f.NoCellMerge.Value()
+	if noCellMerge && format != types.FormatTable {
+		log.Warn(`"--no-cell-merge" can be used only with "--format table".`)
+	}
+
+	columnsLayout := f.ColumnsLayout.Value()
+	if columnsLayout < 0 {
+		return ReportOptions{}, xerrors.Errorf("'--columns-layout' must not be negative: %d", columnsLayout)
+	} else if columnsLayout > 1 && format != types.FormatTable {
+		log.Warn(`"--columns-layout" can be used only with "--format table".`)
+	}
+
+	interactive := f.Interactive.Value()
+	if interactive && format != types.FormatTable {
+		log.Warn(`"--interactive" can be used only with "--format table".`)
+	}
+
+	showVendorStatus := f.ShowVendorStatus.Value()
+	if showVendorStatus && format != types.FormatTable {
+		log.Warn(`"--show-vendor-status" can be used only with "--format table".`)
+	}
+
+	showFilteredCount := f.ShowFilteredCount.Value()
+	if showFilteredCount && format != types.FormatTable {
+		log.Warn(`"--show-filtered-count" can be used only with "--format table".`)
+	}
+
+	trendFile := f.TrendFile.Value()
+	if trendFile != "" && format != types.FormatTable {
+		log.Warn(`"--trend-file" can be used only with "--format table".`)
+	}
+
+	showLayer := f.ShowLayer.Value()
+	if showLayer && format != types.FormatTable {
+		log.Warn(`"--show-layer" can be used only with "--format table".`)
+	}
+
+	showPURL := f.ShowPURL.Value()
+	if showPURL && format != types.FormatTable {
+		log.Warn(`"--show-purl" can be used only with "--format table".`)
+	}
+
+	showBlastRadius := f.ShowBlastRadius.Value()
+	if showBlastRadius && format != types.FormatTable {
+		log.Warn(`"--show-blast-radius" can be used only with "--format table".`)
+	}
+
+	showFixCommand := f.ShowFixCommand.Value()
+	if showFixCommand && format != types.FormatTable {
+		log.Warn(`"--show-fix-command" can be used only with "--format table".`)
+	}
+
+	directOnly := f.DirectOnly.Value()
+	if directOnly && format != types.FormatTable {
+		log.Warn(`"--direct-only" can be used only with "--format table".`)
+	}
+
+	fixableFirst := f.FixableFirst.Value()
+	if fixableFirst && format != types.FormatTable {
+		log.Warn(`"--fixable-first" can be used only with "--format table".`)
+	}
+
+	prereleaseFixes := f.PrereleaseFixes.Value()
+	if prereleaseFixes && format != types.FormatTable {
+		log.Warn(`"--flag-prerelease-fixes" can be used only with "--format table".`)
+	}
+
+	severityConflicts := f.SeverityConflicts.Value()
+	if severityConflicts && format != types.FormatTable {
+		log.Warn(`"--flag-severity-conflicts" can be used only with "--format table".`)
+	}
+
+	summaryBar := f.SummaryBar.Value()
+	if summaryBar && format != types.FormatTable {
+		log.Warn(`"--summary-bar" can be used only with "--format table".`)
+	}
+
+	focusCVEs := xstrings.ToTSlice[string](f.FocusCVE.Value())
+	for i, id := range focusCVEs {
+		focusCVEs[i] = strings.ToUpper(id)
+	}
+	if len(focusCVEs) > 0 && format != types.FormatTable {
+		log.Warn(`"--focus-cve" can be used only with "--format table".`)
+	}
+
+	remediationPlan := f.RemediationPlan.Value()
+	if remediationPlan && format != types.FormatTable {
+		log.Warn(`"--remediation-plan" can be used only with "--format table".`)
+	} else if remediationPlan && len(focusCVEs) > 0 {
+		return ReportOptions{}, xerrors.New(`"--remediation-plan" cannot be used with "--focus-cve"`)
+	}
+
+	showEPSS := f.ShowEPSS.Value()
+	sortBy := f.SortBy.Value()
+	if sortBy == SortByEPSS && !showEPSS {
+		log.Warn(`"--sort-by epss" can be used only with "--show-epss".`)
+	}
+	showKEVDue := f.ShowKEVDue.Value()
+	if sortBy == SortByKEVDue && !showKEVDue {
+		log.Warn(`"--sort-by kev-due" can be used only with "--show-kev-due".`)
+	}
+
+	sla, err := types.ParseSLA(f.SLA.Value())
+	if err != nil {
+		return ReportOptions{}, xerrors.Errorf("invalid SLA: %w", err)
+	}
+	failOnSLABreach := f.FailOnSLABreach.Value()
+	if failOnSLABreach && len(sla) == 0 {
+		log.Warn(`"--fail-on-sla-breach" can be used only with "--sla".`)
+	}
+
+	licensePolicy, err := types.NewLicensePolicy(f.LicenseAllow.Value(), f.LicenseDeny.Value(), f.LicenseUnknown.Value())
+	if err != nil {
+		return ReportOptions{}, xerrors.Errorf("invalid license policy: %w", err)
+	}
+	failOnLicense := f.FailOnLicense.Value()
+	if failOnLicense && !licensePolicy.Enabled() {
+		log.Warn(`"--fail-on-license-violation" can be used only with "--license-allow" or "--license-deny".`)
+	}
+
+	baselineFile := f.BaselineFile.Value()
+	hideKnown := f.HideKnown.Value()
+	if hideKnown && baselineFile == "" {
+		log.Warn(`"--hide-known" can be used only with "--baseline-file".`)
+	}
+	misconfigDiff := f.MisconfigDiff.Value()
+	if misconfigDiff && baselineFile == "" {
+		log.Warn(`"--misconfig-diff" can be used only with "--baseline-file".`)
+	}
+
+	showClasses := xstrings.ToTSlice[types.ResultClass](f.ShowClass.Value())
+	if len(showClasses) > 0 && format != types.FormatTable {
+		log.Warn(`"--show-class" can be used only with "--format table".`)
+	}
+
+	var timezone *time.Location
+	if tz := f.Timezone.Value(); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return ReportOptions{}, xerrors.Errorf("invalid timezone %q, use an IANA time zone name such as 'Asia/Tokyo', 'UTC' or 'Local': %w", tz, err)
+		}
+		timezone = loc
+	}
+	timeFormat := f.TimeFormat.Value()
+	if (timezone != nil || timeFormat != "") && format != types.FormatTable {
+		log.Warn(`"--timezone" and "--time-format" can be used only with "--format table".`)
+	}
+
+	qrCode := f.QRCode.Value()
+	if qrCode && format != types.FormatTable {
+		log.Warn(`"--qr-code" can be used only with "--format table".`)
+	}
+
+	showVEXSuppressed := f.ShowVEXSuppressed.Value()
+	if showVEXSuppressed && format != types.FormatTable {
+		log.Warn(`"--show-vex-suppressed" can be used only with "--format table".`)
+	}
+
+	includeVulns := f.IncludeVulns.Value()
+	if includeVulns && format != types.FormatCycloneDX {
+		log.Warn(`"--include-vulns" can be used only with "--format cyclonedx".`)
+	}
+
+	spdxRelationships := f.SPDXRelationships.Value()
+	if spdxRelationships && format != types.FormatSPDX && format != types.FormatSPDXJSON {
+		log.Warn(`"--spdx-relationships-only" can be used only with "--format spdx" or "--format spdx-json".`)
+	}
+
+	relativePaths := f.RelativePaths.Value()
+	relativePathsBase := f.RelativePathsBase.Value()
+	if relativePathsBase != "" && !relativePaths {
+		log.Warn(`"--relative-paths-base" can be used only with "--relative-paths".`)
+	}
+
+	groupBySeverity := f.GroupBySeverity.Value()
+	if groupBySeverity && format != types.FormatTable {
+		log.Warn(`"--group-by-severity" can be used only with "--format table".`)
+	}
+
+	groupByInstr := f.GroupByInstr.Value()
+	if groupByInstr && format != types.FormatTable {
+		log.Warn(`"--group-by-instruction" can be used only with "--format table".`)
+	}
+	if groupByInstr && groupBySeverity {
+		log.Warn(`"--group-by-severity" is ignored with "--group-by-instruction".`)
+	}
+
+	showCleanSummary := f.ShowCleanSummary.Value()
+	if showCleanSummary && format != types.FormatTable {
+		log.Warn(`"--show-clean-summary" can be used only with "--format table".`)
+	}
+
+	ageHistogram := f.AgeHistogram.Value()
+	if ageHistogram && format != types.FormatTable && format != types.FormatJSON {
+		log.Warn(`"--age-histogram" can be used only with "--format table" or "--format json".`)
+	}
+
+	scorecard := f.Scorecard.Value()
+	if scorecard && format != types.FormatTable && format != types.FormatJSON {
+		log.Warn(`"--scorecard" can be used only with "--format table" or "--format json".`)
+	}
+
+	banner := strings.TrimSpace(f.Banner.Value())
+	if banner != "" && format != types.FormatTable && format != types.FormatJSON {
+		log.Warn(`"--banner" can be used only with "--format table" or "--format json".`)
+	}
+
+	syslogAddr := f.SyslogAddr.Value()
+	if format == types.FormatSyslog && syslogAddr == "" {
+		return ReportOptions{}, xerrors.New(`"--format syslog" requires "--syslog-addr"`)
+	} else if format != types.FormatSyslog && syslogAddr != "" {
+		log.Warn(`"--syslog-addr" can be used only with "--format syslog".`)
+	}
+
+	appendOutput := f.AppendOutput.Value()
+	if appendOutput {
+		output := f.Output.Value()
+		switch {
+		case format != types.FormatJSON:
+			return ReportOptions{}, xerrors.New(`"--append-output" can be used only with "--format json"`)
+		case output == "" || strings.HasPrefix(output, "plugin="):
+			return ReportOptions{}, xerrors.New(`"--append-output" requires an output file specified with "--output"`)
+		case f.Compress.Value() != "" || filepath.Ext(output) == ".gz":
+			return ReportOptions{}, xerrors.New(`"--append-output" cannot be used with compressed output`)
+		}
+	}
+
+	if output := f.Output.Value(); strings.HasPrefix(output, "kafka://") {
+		switch {
+		case format != types.FormatJSON:
+			return ReportOptions{}, xerrors.New(`"--output kafka://" can be used only with "--format json"`)
+		case appendOutput:
+			return ReportOptions{}, xerrors.New(`"--append-output" cannot be used with "--output kafka://"`)
+		case f.Compress.Value() != "":
+			return ReportOptions{}, xerrors.New(`"--compress" cannot be used with "--output kafka://"`)
+		}
+	}
+
+	esOutput := strings.HasPrefix(f.Output.Value(), "es://") || strings.HasPrefix(f.Output.Value(), "es+http://")
+	if esOutput {
+		switch {
+		case format != types.FormatJSON:
+			return ReportOptions{}, xerrors.New(`"--output es://" can be used only with "--format json"`)
+		case appendOutput:
+			return ReportOptions{}, xerrors.New(`"--append-output" cannot be used with "--output es://"`)
+		case f.Compress.Value() != "":
+			return ReportOptions{}, xerrors.New(`"--compress" cannot be used with "--output es://"`)
+		}
+	} else if f.ESUsername.Value() != "" || f.ESPassword.Value() != "" || f.ESAPIKey.Value() != "" {
+		log.Warn(`"--es-username", "--es-password" and "--es-api-key" can be used only with "--output es://".`)
+	}
+
+	if format == types.FormatSQLite {
+		output := f.Output.Value()
+		switch {
+		case output == "" || strings.HasPrefix(output, "plugin="):
+			return ReportOptions{}, xerrors.New(`"--format sqlite" requires a database file specified with "--output"`)
+		case f.Compress.Value() != "" || filepath.Ext(output) == ".gz":
+			return ReportOptions{}, xerrors.New(`"--format sqlite" cannot be used with compressed output`)
+		}
+	}
+
+	outputDir := f.OutputDir.Value()
+	if outputDir != "" {
+		switch {
+		case f.Output.Value() != "":
+			return ReportOptions{}, xerrors.New(`"--output" and "--output-dir" cannot be used together`)
+		case format == types.FormatSyslog || format == types.FormatSQLite:
+			return ReportOptions{}, xerrors.Errorf(`"--output-dir" cannot be used with "--format %s"`, format)
+		case f.Compliance.Value() != "":
+			return ReportOptions{}, xerrors.New(`"--output-dir" cannot be used with "--compliance"`)
+		}
+	}
+
+	pkgFilters := f.PkgFilter.Value()
+	for _, pattern := range pkgFilters {
+		if !doublestar.ValidatePattern(pattern) {
+			return ReportOptions{}, xerrors.Errorf("invalid package filter pattern: %s", pattern)
+		}
+	}
+
+	severityLabels, err := toSeverityLabels(f.SeverityLabels.Value())
+	if err != nil {
+		return ReportOptions{}, xerrors.Errorf("invalid severity labels: %w", err)
+	} else if len(severityLabels) > 0 && format != types.FormatTable {
+		log.Warn(`"--severity-labels" can be used only with "--format table".`)
+	}
+
+	internalPackages := f.InternalPackages.Value()
+	for _, pattern := range internalPackages {
+		if !doublestar.ValidatePattern(pattern) {
+			return ReportOptions{}, xerrors.Errorf("invalid internal package pattern: %s", pattern)
+		}
 	}
 
 	cs, err := loadComplianceTypes(f.Compliance.Value())
@@ -240,25 +1357,118 @@ func (f *ReportFlagGroup) ToOptions() (ReportOptions, error) {
 		}
 	}
 
+	var outputCommand []string
+	if command := f.OutputCommand.Value(); command != "" {
+		outputCommand, err = shellwords.Parse(command)
+		switch {
+		case err != nil:
+			return ReportOptions{}, xerrors.Errorf("unable to parse the output command: %w", err)
+		case len(outputCommand) == 0:
+			return ReportOptions{}, xerrors.Errorf("empty output command: %q", command)
+		case f.Output.Value() != "" || outputDir != "":
+			return ReportOptions{}, xerrors.New(`"--output-command" cannot be used with "--output" or "--output-dir"`)
+		case format == types.FormatSyslog || format == types.FormatSQLite:
+			return ReportOptions{}, xerrors.Errorf(`"--output-command" cannot be used with "--format %s"`, format)
+		}
+	}
+
 	if viper.IsSet(f.IgnoreFile.ConfigName) && !fsutils.FileExists(f.IgnoreFile.Value()) {
 		return ReportOptions{}, xerrors.Errorf("ignore file not found: %s", f.IgnoreFile.Value())
 	}
 
 	return ReportOptions{
-		Format:           format,
-		ReportFormat:     f.ReportFormat.Value(),
-		Template:         template,
-		DependencyTree:   dependencyTree,
-		ListAllPkgs:      listAllPkgs,
-		IgnoreFile:       f.IgnoreFile.Value(),
-		ExitCode:         f.ExitCode.Value(),
-		ExitOnEOL:        f.ExitOnEOL.Value(),
-		IgnorePolicy:     f.IgnorePolicy.Value(),
-		Output:           f.Output.Value(),
-		OutputPluginArgs: outputPluginArgs,
-		Severities:       toSeverity(f.Severity.Value()),
-		Compliance:       cs,
-		ShowSuppressed:   f.ShowSuppressed.Value(),
+		Format:            format,
+		ReportFormat:      f.ReportFormat.Value(),
+		Template:          template,
+		DependencyTree:    dependencyTree,
+		TreeDirection:     treeDirection,
+		TreeShortestPath:  treeShortestPath,
+		TreeMinPackages:   treeMinPackages,
+		ListAllPkgs:       listAllPkgs,
+		IgnoreFile:        f.IgnoreFile.Value(),
+		ExitCode:          f.ExitCode.Value(),
+		ExitOnEOL:         f.ExitOnEOL.Value(),
+		ExplainExit:       f.ExplainExit.Value(),
+		FailOnEmpty:       f.FailOnEmpty.Value(),
+		IgnorePolicy:      f.IgnorePolicy.Value(),
+		Output:            f.Output.Value(),
+		OutputPluginArgs:  outputPluginArgs,
+		OutputCommand:     outputCommand,
+		Compress:          f.Compress.Value(),
+		AppendOutput:      appendOutput,
+		OutputDir:         outputDir,
+		SyslogAddr:        syslogAddr,
+		ESUsername:        f.ESUsername.Value(),
+		ESPassword:        f.ESPassword.Value(),
+		ESAPIKey:          f.ESAPIKey.Value(),
+		Severities:        toSeverity(f.Severity.Value()),
+		SeverityOrder:     toSeverityOrder(f.SeverityOrder.Value()),
+		SeverityLabels:    severityLabels,
+		VulnSeverities:    toSeverity(f.VulnSeverity.Value()),
+		MisconfSeverities: toSeverity(f.MisconfigSeverity.Value()),
+		SecretSeverities:  toSeverity(f.SecretSeverity.Value()),
+		Compliance:        cs,
+		ShowSuppressed:    f.ShowSuppressed.Value(),
+		MaxRows:           maxRows,
+		SecretMatchWidth:  secretMatchWidth,
+		ShowSecretConf:    showSecretConf,
+		SecretsByFile:     secretsByFile,
+		MinSecretConf:     ftypes.SecretConfidence(f.MinSecretConf.Value()),
+		JSONCompact:       jsonCompact,
+		ValidateOutput:    validateOutput,
+		PkgFilters:        pkgFilters,
+		InternalPackages:  internalPackages,
+		ShowReachability:  showReachability,
+		AgeHistogram:      ageHistogram,
+		Scorecard:         scorecard,
+		Banner:            banner,
+		GroupBySeverity:   groupBySeverity,
+		GroupByInstr:      groupByInstr,
+		ShowCleanSummary:  showCleanSummary,
+		ShowLayer:         showLayer,
+		ShowPURL:          showPURL,
+		ShowBlastRadius:   showBlastRadius,
+		ShowFixCommand:    showFixCommand,
+		DirectOnly:        directOnly,
+		FixableFirst:      fixableFirst,
+		PrereleaseFixes:   prereleaseFixes,
+		SeverityConflicts: severityConflicts,
+		SummaryBar:        summaryBar,
+		FocusCVEs:         focusCVEs,
+		RemediationPlan:   remediationPlan,
+		ShowEPSS:          showEPSS,
+		EPSSSource:        f.EPSSSource.Value(),
+		ShowAffectedRange: f.ShowAffectedRange.Value(),
+		ShowKEV:           f.ShowKEV.Value(),
+		ShowKEVDue:        showKEVDue,
+		KEVOnly:           f.KEVOnly.Value(),
+		KEVSource:         f.KEVSource.Value(),
+		ShowVendorStatus:  showVendorStatus,
+		HideWontFix:       f.HideWontFix.Value(),
+		ShowFilteredCount: showFilteredCount,
+		LabelsFile:        f.LabelsFile.Value(),
+		SLA:               sla,
+		FailOnSLABreach:   failOnSLABreach,
+		LicensePolicy:     licensePolicy,
+		FailOnLicense:     failOnLicense,
+		BaselineFile:      baselineFile,
+		HideKnown:         hideKnown,
+		MisconfigDiff:     misconfigDiff,
+		TrendFile:         trendFile,
+		SortBy:            sortBy,
+		ShowClasses:       showClasses,
+		Timezone:          timezone,
+		TimeFormat:        timeFormat,
+		QRCode:            qrCode,
+		ShowVEXSuppressed: showVEXSuppressed,
+		IncludeVulns:      includeVulns,
+		SPDXRelationships: spdxRelationships,
+		RelativePaths:     relativePaths,
+		RelativePathsBase: relativePathsBase,
+		NoCellMerge:       noCellMerge,
+		ColumnsLayout:     columnsLayout,
+		Interactive:       interactive,
+		CountBy:           countBy,
 	}, nil
 }
 
@@ -288,3 +1498,87 @@ func toSeverity(severity []string) []dbTypes.Severity {
 	log.Debug("Parsed severities", log.Any("severities", severities))
 	return severities
 }
+
+// expandSeverities expands ranges such as "HIGH-CRITICAL" and negations such as "!LOW" into severity names.
+// Ranges follow the order of dbTypes.SeverityNames.
+// Negations exclude severities from the others, or from all the severities if only negations are given.
+// The value is returned as is if it contains invalid tokens so that they are reported with the valid names.
+func expandSeverities(severities []string) []string {
+	var included, excluded []string
+	for _, s := range severities {
+		names, ok := severityRange(strings.TrimPrefix(s, "!"))
+		switch {
+		case !ok:
+			return severities
+		case strings.HasPrefix(s, "!"):
+			excluded = append(excluded, names...)
+		default:
+			included = append(included, names...)
+		}
+	}
+	if len(included) == 0 && len(excluded) > 0 {
+		included = dbTypes.SeverityNames
+	}
+	return lo.Uniq(lo.Without(included, excluded...))
+}
+
+// severityRange returns the severity names from "FROM" to "TO" for "FROM-TO", or the name itself for a single name.
+func severityRange(s string) ([]string, bool) {
+	from, to, found := strings.Cut(s, "-")
+	if !found {
+		to = from
+	}
+	i, j := slices.Index(dbTypes.SeverityNames, from), slices.Index(dbTypes.SeverityNames, to)
+	if i < 0 || j < 0 || i > j {
+		return nil, false
+	}
+	return dbTypes.SeverityNames[i : j+1], true
+}
+
+// toSeverityOrder validates the order of severities from the lowest to the highest.
+// Unknown severities are ignored, and severities not in the order are regarded as lower than the others.
+func toSeverityOrder(order []string) []string {
+	if len(order) == 0 {
+		return nil
+	}
+
+	var known []string
+	for _, s := range order {
+		s = strings.ToUpper(s)
+		if !slices.Contains(dbTypes.SeverityNames, s) {
+			log.Warnf("Unknown severity %q in '--severity-order' is ignored", s)
+			continue
+		} else if slices.Contains(known, s) {
+			continue
+		}
+		known = append(known, s)
+	}
+
+	missing := lo.Without(dbTypes.SeverityNames, known...)
+	if order = append(missing, known...); slices.Equal(order, dbTypes.SeverityNames) {
+		return nil // The default order
+	}
+	return order
+}
+
+// toSeverityLabels parses the labels of severities in the form of "<severity>=<label>", e.g. "CRITICAL=C".
+// Severities are case-insensitive, and severities without labels are rendered with their names.
+func toSeverityLabels(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	labels := make(map[string]string)
+	for _, value := range values {
+		s, label, ok := strings.Cut(value, "=")
+		if !ok || strings.TrimSpace(label) == "" {
+			return nil, xerrors.Errorf("%q must be in the form of '<severity>=<label>', e.g. 'CRITICAL=C'", value)
+		}
+		severity, err := dbTypes.NewSeverity(strings.ToUpper(strings.TrimSpace(s)))
+		if err != nil {
+			return nil, xerrors.Errorf("%q: %w", value, err)
+		}
+		labels[severity.String()] = strings.TrimSpace(label)
+	}
+	return labels, nil
+}
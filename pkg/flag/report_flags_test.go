@@ -29,6 +29,8 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 		output           string
 		outputPluginArgs string
 		severities       string
+		severityOrder    string
+		severityLabels   string
 		compliance       string
 		debug            bool
 		pkgTypes         string
@@ -55,6 +57,83 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 				Format:     types.FormatCycloneDX,
 			},
 		},
+		{
+			name: "severity range",
+			fields: fields{
+				severities: "HIGH-CRITICAL",
+			},
+			want: flag.ReportOptions{
+				Severities: []dbTypes.Severity{
+					dbTypes.SeverityHigh,
+					dbTypes.SeverityCritical,
+				},
+			},
+		},
+		{
+			name: "severity negations",
+			fields: fields{
+				severities: "!UNKNOWN,!LOW",
+			},
+			want: flag.ReportOptions{
+				Severities: []dbTypes.Severity{
+					dbTypes.SeverityMedium,
+					dbTypes.SeverityHigh,
+					dbTypes.SeverityCritical,
+				},
+			},
+		},
+		{
+			name: "severity range with negation",
+			fields: fields{
+				severities: "LOW-CRITICAL,!MEDIUM",
+			},
+			want: flag.ReportOptions{
+				Severities: []dbTypes.Severity{
+					dbTypes.SeverityLow,
+					dbTypes.SeverityHigh,
+					dbTypes.SeverityCritical,
+				},
+			},
+		},
+		{
+			name: "custom severity order",
+			fields: fields{
+				severities:    "CRITICAL",
+				severityOrder: "low,critical,high,foo,medium",
+			},
+			wantLogs: []string{
+				`Unknown severity "FOO" in '--severity-order' is ignored`,
+			},
+			want: flag.ReportOptions{
+				Severities: []dbTypes.Severity{dbTypes.SeverityCritical},
+				SeverityOrder: []string{
+					"UNKNOWN",
+					"LOW",
+					"CRITICAL",
+					"HIGH",
+					"MEDIUM",
+				},
+			},
+		},
+		{
+			name: "custom severity labels",
+			fields: fields{
+				format:         "table",
+				severities:     "HIGH,CRITICAL",
+				severityLabels: "critical=C,high=H",
+			},
+			want: flag.ReportOptions{
+				Format: types.FormatTable,
+				Severities: []dbTypes.Severity{
+					dbTypes.SeverityHigh,
+					dbTypes.SeverityCritical,
+				},
+				SeverityLabels: map[string]string{
+					"CRITICAL": "C",
+					"HIGH":     "H",
+				},
+			},
+		},
 		{
 			name: "invalid option combination: --template enabled without --format",
 			fields: fields{
@@ -183,6 +262,8 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 			setValue(flag.OutputFlag.ConfigName, tt.fields.output)
 			setValue(flag.OutputPluginArgFlag.ConfigName, tt.fields.outputPluginArgs)
 			setValue(flag.SeverityFlag.ConfigName, tt.fields.severities)
+			setValue(flag.SeverityOrderFlag.ConfigName, tt.fields.severityOrder)
+			setValue(flag.SeverityLabelsFlag.ConfigName, tt.fields.severityLabels)
 			setValue(flag.ComplianceFlag.ConfigName, tt.fields.compliance)
 
 			// Assert options
@@ -198,6 +279,8 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 				Output:          flag.OutputFlag.Clone(),
 				OutputPluginArg: flag.OutputPluginArgFlag.Clone(),
 				Severity:        flag.SeverityFlag.Clone(),
+				SeverityOrder:   flag.SeverityOrderFlag.Clone(),
+				SeverityLabels:  flag.SeverityLabelsFlag.Clone(),
 				Compliance:      flag.ComplianceFlag.Clone(),
 			}
 
@@ -210,6 +293,26 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 		})
 	}
 
+	t.Run("Error on invalid severities", func(t *testing.T) {
+		for _, severities := range []string{
+			"HIGH-FOO",
+			"CRITICAL-HIGH",
+			"!low",
+		} {
+			t.Run(severities, func(t *testing.T) {
+				t.Cleanup(viper.Reset)
+
+				setValue(flag.SeverityFlag.ConfigName, severities)
+				f := &flag.ReportFlagGroup{
+					Severity: flag.SeverityFlag.Clone(),
+				}
+
+				_, err := f.ToOptions()
+				assert.ErrorContains(t, err, `for "--severity" flag: must be one of ["UNKNOWN" "LOW" "MEDIUM" "HIGH" "CRITICAL"]`)
+			})
+		}
+	})
+
 	t.Run("Error on non existing ignore file", func(t *testing.T) {
 		t.Cleanup(viper.Reset)
 
@@ -221,4 +324,293 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 		_, err := f.ToOptions()
 		assert.ErrorContains(t, err, "ignore file not found: doesntexist")
 	})
+	t.Run("Error on --format syslog without --syslog-addr", func(t *testing.T) {
+		t.Cleanup(viper.Reset)
+
+		setValue(flag.FormatFlag.ConfigName, string(types.FormatSyslog))
+		f := &flag.ReportFlagGroup{
+			Format:     flag.FormatFlag.Clone(),
+			SyslogAddr: flag.SyslogAddrFlag.Clone(),
+		}
+
+		_, err := f.ToOptions()
+		assert.ErrorContains(t, err, `"--format syslog" requires "--syslog-addr"`)
+	})
+	t.Run("Error on invalid --timezone", func(t *testing.T) {
+		t.Cleanup(viper.Reset)
+
+		setValue(flag.TimezoneFlag.ConfigName, "Mars/Olympus")
+		f := &flag.ReportFlagGroup{
+			Timezone: flag.TimezoneFlag.Clone(),
+		}
+
+		_, err := f.ToOptions()
+		assert.ErrorContains(t, err, `invalid timezone "Mars/Olympus", use an IANA time zone name`)
+	})
+
+	t.Run("Error on --append-output", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			format  types.Format
+			output  string
+			wantErr string
+		}{
+			{
+				name:    "without --format json",
+				format:  types.FormatTable,
+				output:  "report.json",
+				wantErr: `"--append-output" can be used only with "--format json"`,
+			},
+			{
+				name:    "without --output",
+				format:  types.FormatJSON,
+				wantErr: `"--append-output" requires an output file specified with "--output"`,
+			},
+			{
+				name:    "with compressed output",
+				format:  types.FormatJSON,
+				output:  "report.json.gz",
+				wantErr: `"--append-output" cannot be used with compressed output`,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Cleanup(viper.Reset)
+
+				setValue(flag.FormatFlag.ConfigName, string(tt.format))
+				setValue(flag.OutputFlag.ConfigName, tt.output)
+				setValue(flag.AppendOutputFlag.ConfigName, true)
+				f := &flag.ReportFlagGroup{
+					Format:       flag.FormatFlag.Clone(),
+					Output:       flag.OutputFlag.Clone(),
+					AppendOutput: flag.AppendOutputFlag.Clone(),
+				}
+
+				_, err := f.ToOptions()
+				assert.ErrorContains(t, err, tt.wantErr)
+			})
+		}
+	})
+
+	t.Run("Error on --format sqlite", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			output  string
+			wantErr string
+		}{
+			{
+				name:    "without --output",
+				wantErr: `"--format sqlite" requires a database file specified with "--output"`,
+			},
+			{
+				name:    "with output plugin",
+				output:  "plugin=count",
+				wantErr: `"--format sqlite" requires a database file specified with "--output"`,
+			},
+			{
+				name:    "with compressed output",
+				output:  "trivy.db.gz",
+				wantErr: `"--format sqlite" cannot be used with compressed output`,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Cleanup(viper.Reset)
+
+				setValue(flag.FormatFlag.ConfigName, string(types.FormatSQLite))
+				setValue(flag.OutputFlag.ConfigName, tt.output)
+				f := &flag.ReportFlagGroup{
+					Format: flag.FormatFlag.Clone(),
+					Output: flag.OutputFlag.Clone(),
+				}
+
+				_, err := f.ToOptions()
+				assert.ErrorContains(t, err, tt.wantErr)
+			})
+		}
+	})
+
+	t.Run("Error on --output kafka://", func(t *testing.T) {
+		tests := []struct {
+			name         string
+			format       types.Format
+			appendOutput bool
+			wantErr      string
+		}{
+			{
+				name:    "without --format json",
+				format:  types.FormatTable,
+				wantErr: `"--output kafka://" can be used only with "--format json"`,
+			},
+			{
+				name:         "with --append-output",
+				format:       types.FormatJSON,
+				appendOutput: true,
+				wantErr:      `"--append-output" cannot be used with "--output kafka://"`,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Cleanup(viper.Reset)
+
+				setValue(flag.FormatFlag.ConfigName, string(tt.format))
+				setValue(flag.OutputFlag.ConfigName, "kafka://localhost:9092/trivy")
+				setValue(flag.AppendOutputFlag.ConfigName, tt.appendOutput)
+				f := &flag.ReportFlagGroup{
+					Format:       flag.FormatFlag.Clone(),
+					Output:       flag.OutputFlag.Clone(),
+					AppendOutput: flag.AppendOutputFlag.Clone(),
+				}
+
+				_, err := f.ToOptions()
+				assert.ErrorContains(t, err, tt.wantErr)
+			})
+		}
+	})
+
+	t.Run("Error on --output es://", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			format  types.Format
+			output  string
+			wantErr string
+		}{
+			{
+				name:    "without --format json",
+				format:  types.FormatTable,
+				output:  "es://localhost:9200/trivy",
+				wantErr: `"--output es://" can be used only with "--format json"`,
+			},
+			{
+				name:    "plain HTTP without --format json",
+				format:  types.FormatSarif,
+				output:  "es+http://localhost:9200/trivy",
+				wantErr: `"--output es://" can be used only with "--format json"`,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Cleanup(viper.Reset)
+
+				setValue(flag.FormatFlag.ConfigName, string(tt.format))
+				setValue(flag.OutputFlag.ConfigName, tt.output)
+				f := &flag.ReportFlagGroup{
+					Format: flag.FormatFlag.Clone(),
+					Output: flag.OutputFlag.Clone(),
+				}
+
+				_, err := f.ToOptions()
+				assert.ErrorContains(t, err, tt.wantErr)
+			})
+		}
+	})
+
+	t.Run("Error on --output-command", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			command string
+			output  string
+			wantErr string
+		}{
+			{
+				name:    "unterminated quote",
+				command: `gpg --encrypt -r "alice`,
+				wantErr: "unable to parse the output command",
+			},
+			{
+				name:    "blank command",
+				command: " ",
+				wantErr: "empty output command",
+			},
+			{
+				name:    "with --output",
+				command: "gpg --encrypt -r alice",
+				output:  "report.json",
+				wantErr: `"--output-command" cannot be used with "--output" or "--output-dir"`,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Cleanup(viper.Reset)
+
+				setValue(flag.OutputFlag.ConfigName, tt.output)
+				setValue(flag.OutputCommandFlag.ConfigName, tt.command)
+				f := &flag.ReportFlagGroup{
+					Output:        flag.OutputFlag.Clone(),
+					OutputCommand: flag.OutputCommandFlag.Clone(),
+				}
+
+				_, err := f.ToOptions()
+				assert.ErrorContains(t, err, tt.wantErr)
+			})
+		}
+	})
+
+	t.Run("Error on --output-dir", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			format  types.Format
+			output  string
+			wantErr string
+		}{
+			{
+				name:    "with --output",
+				format:  types.FormatJSON,
+				output:  "report.json",
+				wantErr: `"--output" and "--output-dir" cannot be used together`,
+			},
+			{
+				name:    "with --format syslog",
+				format:  types.FormatSyslog,
+				wantErr: `"--output-dir" cannot be used with "--format syslog"`,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Cleanup(viper.Reset)
+
+				setValue(flag.FormatFlag.ConfigName, string(tt.format))
+				setValue(flag.OutputFlag.ConfigName, tt.output)
+				setValue(flag.OutputDirFlag.ConfigName, "reports")
+				setValue(flag.SyslogAddrFlag.ConfigName, "udp://localhost:514")
+				f := &flag.ReportFlagGroup{
+					Format:     flag.FormatFlag.Clone(),
+					Output:     flag.OutputFlag.Clone(),
+					OutputDir:  flag.OutputDirFlag.Clone(),
+					SyslogAddr: flag.SyslogAddrFlag.Clone(),
+				}
+
+				_, err := f.ToOptions()
+				assert.ErrorContains(t, err, tt.wantErr)
+			})
+		}
+	})
+
+	t.Run("Error on --sla", func(t *testing.T) {
+		t.Cleanup(viper.Reset)
+
+		setSliceValue(flag.SLAFlag.ConfigName, []string{"critical=7d", "high=soon"})
+		f := &flag.ReportFlagGroup{
+			Format: flag.FormatFlag.Clone(),
+			SLA:    flag.SLAFlag.Clone(),
+		}
+
+		_, err := f.ToOptions()
+		assert.ErrorContains(t, err, `invalid SLA "high=soon"`)
+	})
+
+	t.Run("Error on --license-allow and --license-deny", func(t *testing.T) {
+		t.Cleanup(viper.Reset)
+
+		setSliceValue(flag.LicenseAllowFlag.ConfigName, []string{"MIT", "GPL-3.0"})
+		setSliceValue(flag.LicenseDenyFlag.ConfigName, []string{"GPL-3.0"})
+		f := &flag.ReportFlagGroup{
+			Format:       flag.FormatFlag.Clone(),
+			LicenseAllow: flag.LicenseAllowFlag.Clone(),
+			LicenseDeny:  flag.LicenseDenyFlag.Clone(),
+		}
+
+		_, err := f.ToOptions()
+		assert.ErrorContains(t, err, `license "GPL-3.0" is both allowed and denied`)
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"runtime"
 
 	"github.com/samber/lo"
+	"golang.org/x/xerrors"
 
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/log"
@@ -110,6 +111,26 @@ var (
   - "comprehensive": Aims to detect more security findings at the cost of potential false positives.
 `,
 	}
+	MaxTargetsFlag = Flag[int]{
+		Name:       "max-targets",
+		ConfigName: "scan.max-targets",
+		Usage:      "abort the scan if more than the given number of files need to be analyzed (0 means unlimited)",
+	}
+	IncludeFileHashesFlag = Flag[bool]{
+		Name:       "include-file-hashes",
+		ConfigName: "scan.include-file-hashes",
+		Usage:      "include the SHA-256 digest of the scanned file in each result",
+	}
+	ReadOnlyFlag = Flag[bool]{
+		Name:       "read-only",
+		ConfigName: "scan.read-only",
+		Usage:      "refuse to scan if anything would be written into the target, and write temporary files outside the target",
+	}
+	DiffFlag = Flag[bool]{
+		Name:       "diff",
+		ConfigName: "scan.diff",
+		Usage:      "scan the two given paths, e.g. snapshots before and after a change, and report the findings and the packages changed between them",
+	}
 )
 
 type ScanFlagGroup struct {
@@ -123,6 +144,10 @@ type ScanFlagGroup struct {
 	SBOMSources       *Flag[[]string]
 	RekorURL          *Flag[string]
 	DetectionPriority *Flag[string]
+	MaxTargets        *Flag[int]  // only for the filesystem command
+	IncludeFileHashes *Flag[bool] // only for the filesystem command
+	ReadOnly          *Flag[bool] // only for the filesystem command
+	Diff              *Flag[bool] // only for the filesystem command
 }
 
 type ScanOptions struct {
@@ -136,6 +161,11 @@ type ScanOptions struct {
 	SBOMSources       []string
 	RekorURL          string
 	DetectionPriority ftypes.DetectionPriority
+	MaxTargets        int
+	IncludeFileHashes bool
+	ReadOnly          bool
+	Diff              bool
+	DiffTarget        string // The path compared with Target by "--diff"
 }
 
 func NewScanFlagGroup() *ScanFlagGroup {
@@ -169,6 +199,10 @@ func (f *ScanFlagGroup) Flags() []Flagger {
 		f.SBOMSources,
 		f.RekorURL,
 		f.DetectionPriority,
+		f.MaxTargets,
+		f.IncludeFileHashes,
+		f.ReadOnly,
+		f.Diff,
 	}
 }
 
@@ -177,8 +211,14 @@ func (f *ScanFlagGroup) ToOptions(args []string) (ScanOptions, error) {
 		return ScanOptions{}, err
 	}
 
-	var target string
-	if len(args) == 1 {
+	var target, diffTarget string
+	diff := f.Diff.Value()
+	switch {
+	case diff && len(args) != 2:
+		return ScanOptions{}, xerrors.Errorf("'--diff' requires two paths to compare, but %d given", len(args))
+	case diff:
+		target, diffTarget = args[0], args[1]
+	case len(args) == 1:
 		target = args[0]
 	}
 
@@ -188,6 +228,11 @@ func (f *ScanFlagGroup) ToOptions(args []string) (ScanOptions, error) {
 		parallel = runtime.NumCPU()
 	}
 
+	maxTargets := f.MaxTargets.Value()
+	if maxTargets < 0 {
+		return ScanOptions{}, xerrors.Errorf("'--max-targets' must not be negative: %d", maxTargets)
+	}
+
 	return ScanOptions{
 		Target:            target,
 		SkipDirs:          f.SkipDirs.Value(),
@@ -199,5 +244,10 @@ func (f *ScanFlagGroup) ToOptions(args []string) (ScanOptions, error) {
 		SBOMSources:       f.SBOMSources.Value(),
 		RekorURL:          f.RekorURL.Value(),
 		DetectionPriority: ftypes.DetectionPriority(f.DetectionPriority.Value()),
+		MaxTargets:        maxTargets,
+		IncludeFileHashes: f.IncludeFileHashes.Value(),
+		ReadOnly:          f.ReadOnly.Value(),
+		Diff:              diff,
+		DiffTarget:        diffTarget,
 	}, nil
 }
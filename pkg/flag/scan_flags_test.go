@@ -17,6 +17,7 @@ func TestScanFlagGroup_ToOptions(t *testing.T) {
 		skipFiles   []string
 		offlineScan bool
 		scanners    string
+		diff        bool
 	}
 	tests := []struct {
 		name      string
@@ -63,6 +64,31 @@ func TestScanFlagGroup_ToOptions(t *testing.T) {
 			want:      flag.ScanOptions{},
 			assertion: require.NoError,
 		},
+		{
+			name: "diff two paths",
+			args: []string{
+				"before",
+				"after",
+			},
+			fields: fields{
+				diff: true,
+			},
+			want: flag.ScanOptions{
+				Target:     "before",
+				Diff:       true,
+				DiffTarget: "after",
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "diff with one path",
+			args: []string{"before"},
+			fields: fields{
+				diff: true,
+			},
+			want:      flag.ScanOptions{},
+			assertion: require.Error,
+		},
 		{
 			name: "skip two files",
 			fields: fields{
@@ -114,6 +140,7 @@ func TestScanFlagGroup_ToOptions(t *testing.T) {
 			setSliceValue(flag.SkipFilesFlag.ConfigName, tt.fields.skipFiles)
 			setValue(flag.OfflineScanFlag.ConfigName, tt.fields.offlineScan)
 			setValue(flag.ScannersFlag.ConfigName, tt.fields.scanners)
+			setValue(flag.DiffFlag.ConfigName, tt.fields.diff)
 
 			// Assert options
 			f := &flag.ScanFlagGroup{
@@ -121,6 +148,7 @@ func TestScanFlagGroup_ToOptions(t *testing.T) {
 				SkipFiles:   flag.SkipFilesFlag.Clone(),
 				OfflineScan: flag.OfflineScanFlag.Clone(),
 				Scanners:    flag.ScannersFlag.Clone(),
+				Diff:        flag.DiffFlag.Clone(),
 			}
 
 			got, err := f.ToOptions(tt.args)
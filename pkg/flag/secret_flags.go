@@ -1,5 +1,7 @@
 package flag
 
+import "golang.org/x/xerrors"
+
 var (
 	SecretConfigFlag = Flag[string]{
 		Name:       "secret-config",
@@ -7,14 +9,28 @@ var (
 		Default:    "trivy-secret.yaml",
 		Usage:      "specify a path to config file for secret scanning",
 	}
+	ScanGitHistoryFlag = Flag[bool]{
+		Name:       "scan-git-history",
+		ConfigName: "secret.scan-git-history",
+		Usage:      "scan the git history for secrets removed from the working tree",
+	}
+	GitHistoryDepthFlag = Flag[int]{
+		Name:       "git-history-depth",
+		ConfigName: "secret.git-history-depth",
+		Usage:      "maximum number of commits to scan from HEAD with '--scan-git-history' (0 means unlimited)",
+	}
 )
 
 type SecretFlagGroup struct {
-	SecretConfig *Flag[string]
+	SecretConfig    *Flag[string]
+	ScanGitHistory  *Flag[bool] // only for the filesystem command
+	GitHistoryDepth *Flag[int]  // only for the filesystem command
 }
 
 type SecretOptions struct {
 	SecretConfigPath string
+	ScanGitHistory   bool
+	GitHistoryDepth  int
 }
 
 func NewSecretFlagGroup() *SecretFlagGroup {
@@ -28,7 +44,11 @@ func (f *SecretFlagGroup) Name() string {
 }
 
 func (f *SecretFlagGroup) Flags() []Flagger {
-	return []Flagger{f.SecretConfig}
+	return []Flagger{
+		f.SecretConfig,
+		f.ScanGitHistory,
+		f.GitHistoryDepth,
+	}
 }
 
 func (f *SecretFlagGroup) ToOptions() (SecretOptions, error) {
@@ -36,7 +56,14 @@ func (f *SecretFlagGroup) ToOptions() (SecretOptions, error) {
 		return SecretOptions{}, err
 	}
 
+	depth := f.GitHistoryDepth.Value()
+	if depth < 0 {
+		return SecretOptions{}, xerrors.Errorf("'--git-history-depth' must not be negative: %d", depth)
+	}
+
 	return SecretOptions{
 		SecretConfigPath: f.SecretConfig.Value(),
+		ScanGitHistory:   f.ScanGitHistory.Value(),
+		GitHistoryDepth:  depth,
 	}, nil
 }
@@ -31,6 +31,7 @@ const (
 	FileTypeJSON                  FileType = "json"
 	FileTypeHelm                  FileType = "helm"
 	FileTypeAzureARM              FileType = "azure-arm"
+	FileTypeSystemd               FileType = "systemd"
 )
 
 var matchers = make(map[FileType]func(name string, r io.ReadSeeker) bool)
@@ -173,6 +174,10 @@ func init() {
 		return false
 	}
 
+	matchers[FileTypeSystemd] = func(name string, _ io.ReadSeeker) bool {
+		return IsSystemdUnitFile(name)
+	}
+
 	matchers[FileTypeHelm] = func(name string, r io.ReadSeeker) bool {
 		helmFiles := []string{"Chart.yaml", ".helmignore", "values.schema.json", "NOTES.txt"}
 		for _, expected := range helmFiles {
@@ -267,6 +272,12 @@ func IsTerraformFile(path string) bool {
 	return false
 }
 
+// IsSystemdUnitFile returns true if the file is a systemd service unit, e.g. nginx.service.
+// Other units, such as sockets and timers, don't run processes by themselves.
+func IsSystemdUnitFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".service")
+}
+
 func IsType(name string, r io.ReadSeeker, t FileType) bool {
 	r = ensureSeeker(r)
 	f, ok := matchers[t]
@@ -188,6 +188,14 @@ Resources:
 				FileTypeDockerfile,
 			},
 		},
+		{
+			name: "systemd service",
+			path: "nginx.service",
+			r:    strings.NewReader("[Service]\nUser=root\n"),
+			expected: []FileType{
+				FileTypeSystemd,
+			},
+		},
 		{
 			name: "kubernetes, no reader",
 			path: "k8s.yml",
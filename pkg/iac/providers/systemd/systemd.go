@@ -0,0 +1,58 @@
+package systemd
+
+import (
+	"reflect"
+
+	"github.com/aquasecurity/trivy/pkg/iac/rego/convert"
+)
+
+// NOTE: the mixed case json is aligned with Dockerfile
+
+// Unit represents a parsed systemd unit file, e.g. nginx.service
+type Unit struct {
+	Sections []Section
+}
+
+// Section represents a section of the unit file, e.g. [Service]
+type Section struct {
+	Name       string
+	Directives []Directive
+	Path       string
+	StartLine  int
+	EndLine    int
+}
+
+// Directive represents an assignment in the section, e.g. User=root
+type Directive struct {
+	Key       string
+	Value     string
+	Path      string
+	StartLine int
+	EndLine   int
+}
+
+func (u Unit) ToRego() any {
+	return map[string]any{
+		"Sections": convert.SliceToRego(reflect.ValueOf(u.Sections)),
+	}
+}
+
+func (s Section) ToRego() any {
+	return map[string]any{
+		"Name":       s.Name,
+		"Directives": convert.SliceToRego(reflect.ValueOf(s.Directives)),
+		"Path":       s.Path,
+		"StartLine":  s.StartLine,
+		"EndLine":    s.EndLine,
+	}
+}
+
+func (d Directive) ToRego() any {
+	return map[string]any{
+		"Key":       d.Key,
+		"Value":     d.Value,
+		"Path":      d.Path,
+		"StartLine": d.StartLine,
+		"EndLine":   d.EndLine,
+	}
+}
@@ -69,6 +69,14 @@ func (s *Scanner) loadEmbedded() error {
 	if err != nil {
 		return fmt.Errorf("failed to load embedded rego checks: %w", err)
 	}
+	if s.embeddedChecksFS != nil {
+		builtin, err := LoadPoliciesFromDirs(s.embeddedChecksFS, ".")
+		if err != nil {
+			return fmt.Errorf("failed to load built-in rego checks: %w", err)
+		}
+		loaded = lo.Assign(loaded, builtin)
+		s.scannerChecks = builtin
+	}
 	s.embeddedChecks = loaded
 	s.logger.Debug("Embedded checks are loaded", log.Int("count", len(loaded)))
 
@@ -92,6 +100,10 @@ func (s *Scanner) LoadPolicies(srcFS fs.FS) error {
 
 	if s.includeEmbeddedPolicies {
 		s.policies = lo.Assign(s.policies, s.embeddedChecks)
+	} else {
+		// The checks built into the scanner are not in the checks bundle,
+		// so they are loaded even if the embedded checks are replaced by the bundle.
+		s.policies = lo.Assign(s.policies, s.scannerChecks)
 	}
 
 	if s.includeEmbeddedLibraries {
@@ -31,6 +31,17 @@ func WithEmbeddedLibraries(include bool) options.ScannerOption {
 	}
 }
 
+// WithEmbeddedChecksFS adds the checks in the filesystem to the embedded checks,
+// e.g. the checks built into a scanner for a config type that trivy-checks doesn't cover.
+// Unlike the other embedded checks, they are loaded even if the embedded checks are disabled.
+func WithEmbeddedChecksFS(fsys fs.FS) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if ss, ok := s.(*Scanner); ok {
+			ss.embeddedChecksFS = fsys
+		}
+	}
+}
+
 // WithTrace specifies an io.Writer for trace logs (mainly rego tracing) - if not set, they are discarded
 func WithTrace(w io.Writer) options.ScannerOption {
 	return func(s options.ConfigurableScanner) {
@@ -48,6 +59,15 @@ func WithPerResultTracing(enabled bool) options.ScannerOption {
 	}
 }
 
+// WithPolicySource attaches the source of the Rego rule to each result
+func WithPolicySource(enabled bool) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if ss, ok := s.(*Scanner); ok {
+			ss.policySource = enabled
+		}
+	}
+}
+
 func WithPolicyDirs(paths ...string) options.ScannerOption {
 	return func(s options.ConfigurableScanner) {
 		if ss, ok := s.(*Scanner); ok {
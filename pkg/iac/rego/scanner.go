@@ -53,6 +53,7 @@ type Scanner struct {
 	logger                   *log.Logger
 	traceWriter              io.Writer
 	tracePerResult           bool
+	policySource             bool
 	retriever                *MetadataRetriever
 	policyFS                 fs.FS
 	policyDirs               []string
@@ -65,9 +66,11 @@ type Scanner struct {
 	includeDeprecatedChecks  bool
 	includeEmbeddedPolicies  bool
 	includeEmbeddedLibraries bool
+	embeddedChecksFS         fs.FS
 
 	embeddedLibs   map[string]*ast.Module
 	embeddedChecks map[string]*ast.Module
+	scannerChecks  map[string]*ast.Module // Checks from embeddedChecksFS, a subset of embeddedChecks
 	customSchemas  map[string][]byte
 
 	disabledCheckIDs map[string]struct{}
@@ -257,6 +260,9 @@ func (s *Scanner) ScanInput(ctx context.Context, inputs ...Input) (scan.Results,
 					)
 					continue
 				}
+				if s.policySource {
+					ruleResults.SetRegoSource(ruleSource(module, ruleName))
+				}
 				results = append(results, s.embellishResultsWithRuleMetadata(ruleResults, *staticMeta)...)
 			}
 		}
@@ -384,6 +390,19 @@ func (s *Scanner) applyRuleCombined(ctx context.Context, namespace, rule string,
 	return s.convertResults(set, inputs[0], namespace, rule, traces), nil
 }
 
+// ruleSource returns the source of all the definitions of the rule in the module.
+// It returns an empty string if the source is unavailable, e.g. the module was not parsed from text.
+func ruleSource(module *ast.Module, name string) string {
+	var defs []string
+	for _, rule := range module.Rules {
+		if rule.Head.Name.String() != name || rule.Location == nil || len(rule.Location.Text) == 0 {
+			continue
+		}
+		defs = append(defs, string(rule.Location.Text))
+	}
+	return strings.Join(defs, "\n\n")
+}
+
 // severity is now set with metadata, so deny/warn/violation now behave the same way
 func isEnforcedRule(name string) bool {
 	switch {
@@ -552,6 +552,45 @@ deny {
 	assert.NotEmpty(t, results.GetFailed()[0].Traces())
 }
 
+func Test_RegoScanning_PolicySource(t *testing.T) {
+
+	srcFS := CreateFS(t, map[string]string{
+		"policies/test.rego": `
+package defsec.test
+
+deny {
+    input.evil
+}
+
+deny {
+    is_bad
+}
+
+is_bad {
+    input.bad
+}
+`,
+	})
+
+	scanner := rego.NewScanner(
+		types.SourceJSON,
+		rego.WithPolicySource(true),
+		rego.WithPolicyDirs("policies"),
+	)
+	require.NoError(t, scanner.LoadPolicies(srcFS))
+
+	results, err := scanner.ScanInput(context.TODO(), rego.Input{
+		Path: "/evil.lol",
+		Contents: map[string]any{
+			"evil": true,
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, results.GetFailed(), 1)
+	assert.Equal(t, "deny {\n    input.evil\n}\n\ndeny {\n    is_bad\n}", results.GetFailed()[0].RegoSource())
+}
+
 func Test_dynamicMetadata(t *testing.T) {
 
 	srcFS := CreateFS(t, map[string]string{
@@ -31,6 +31,7 @@ type Result struct {
 	regoRule         string
 	warning          bool
 	traces           []string
+	regoSource       string
 	fsPath           string
 }
 
@@ -105,6 +106,11 @@ func (r Result) Traces() []string {
 	return r.traces
 }
 
+// RegoSource returns the source of the Rego rule that produced the result, if available
+func (r Result) RegoSource() string {
+	return r.regoSource
+}
+
 func (r *Result) AbsolutePath(fsRoot string, metadata iacTypes.Metadata) string {
 	if strings.HasSuffix(fsRoot, ":") {
 		fsRoot += "/"
@@ -284,6 +290,12 @@ func (r *Results) SetRule(rule Rule) {
 	}
 }
 
+func (r *Results) SetRegoSource(source string) {
+	for i := range *r {
+		(*r)[i].regoSource = source
+	}
+}
+
 func (r *Results) SetSourceAndFilesystem(source string, f fs.FS, logicalSource bool) {
 	for i := range *r {
 		m := (*r)[i].Metadata()
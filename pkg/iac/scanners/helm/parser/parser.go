@@ -185,7 +185,7 @@ func (p *Parser) extractChartName(chartPath string) error {
 func (p *Parser) RenderedChartFiles() ([]ChartFile, error) {
 	workingChart, err := p.loadChart()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to load the chart: %w", err)
 	}
 
 	workingRelease, err := p.getRelease(workingChart)
@@ -214,11 +214,12 @@ func (p *Parser) getRelease(chrt *chart.Chart) (*release.Release, error) {
 
 	vals, err := opts.MergeValues()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to merge the values: %w", err)
 	}
 	r, err := p.helmClient.RunWithContext(context.Background(), chrt, vals)
 	if err != nil {
-		return nil, err
+		// The error has the template file and the line, e.g. a value required by the template is not set
+		return nil, fmt.Errorf("failed to render the templates: %w", err)
 	}
 
 	if r == nil {
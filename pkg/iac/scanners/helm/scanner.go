@@ -120,7 +120,7 @@ func (s *Scanner) getScanResults(path string, ctx context.Context, target fs.FS)
 	chartFiles, err := helmParser.RenderedChartFiles()
 	if err != nil { // not valid helm, maybe some other yaml etc., abort
 		s.logger.Error(
-			"Failed to render Chart files",
+			"Failed to render Chart files, and the chart is not scanned. Values required by the templates can be set with '--helm-set' or '--helm-values'",
 			log.FilePath(path), log.Err(err),
 		)
 		return nil, nil
@@ -193,3 +193,15 @@ func Test_helm_tarball_parser(t *testing.T) {
 		}
 	}
 }
+
+func Test_helm_parser_render_error(t *testing.T) {
+	helmParser, err := parser.New("with-required-values")
+	require.NoError(t, err)
+	require.NoError(t, helmParser.ParseFS(context.TODO(), os.DirFS("testdata"), "with-required-values"))
+
+	_, err = helmParser.RenderedChartFiles()
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "failed to render the templates")
+	assert.ErrorContains(t, err, "templates/deployment.yaml")
+	assert.ErrorContains(t, err, "image.repository is required")
+}
@@ -360,3 +360,85 @@ deny[res] {
 
 	assert.Empty(t, results.GetFailed())
 }
+
+func TestScanWithValues(t *testing.T) {
+	check := `# METADATA
+# title: "Test rego"
+# description: "Test rego"
+# scope: package
+# schemas:
+# - input: schema["kubernetes"]
+# custom:
+#   id: ID001
+#   avd_id: AVD-USR-ID001
+#   severity: LOW
+#   input:
+#     selector:
+#     - type: kubernetes
+package user.kubernetes.ID001
+
+import data.lib.kubernetes
+
+deny[res] {
+	container := kubernetes.containers[_]
+	container.securityContext.readOnlyRootFilesystem == false
+	res := result.new("set 'securityContext.readOnlyRootFilesystem' to true", container)
+}
+`
+
+	tests := []struct {
+		name       string
+		values     []string
+		wantResult bool
+		wantFailed bool
+	}{
+		{
+			name:   "required value is missing",
+			values: nil,
+		},
+		{
+			name:       "default values",
+			values:     []string{"image.repository=nginx"},
+			wantResult: true,
+		},
+		{
+			name:       "misconfiguration driven by values",
+			values:     []string{"image.repository=nginx", "securityContext.readOnlyRootFilesystem=false"},
+			wantResult: true,
+			wantFailed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := helm.New(
+				rego.WithEmbeddedPolicies(false),
+				rego.WithEmbeddedLibraries(true),
+				rego.WithPolicyNamespaces("user"),
+				rego.WithPolicyReader(strings.NewReader(check)),
+				helm.ScannerWithValues(tt.values...),
+			)
+
+			// The chart failing to render is not scanned, without failing the whole scan
+			results, err := scanner.ScanFS(context.TODO(), os.DirFS("testdata/with-required-values"), ".")
+			require.NoError(t, err)
+			if !tt.wantResult {
+				assert.Empty(t, results)
+				return
+			}
+			require.Len(t, results, 1)
+
+			failed := results.GetFailed()
+			if !tt.wantFailed {
+				assert.Empty(t, failed)
+				return
+			}
+			require.Len(t, failed, 1)
+
+			// The finding refers to the template rendered with the values
+			rng := failed[0].Range()
+			assert.Equal(t, "templates/deployment.yaml", rng.GetFilename())
+			assert.Equal(t, 17, rng.GetStartLine())
+		})
+	}
+}
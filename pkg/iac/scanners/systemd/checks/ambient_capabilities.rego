@@ -0,0 +1,51 @@
+# METADATA
+# title: "Service should not be granted privileged capabilities"
+# description: "Capabilities granted by 'AmbientCapabilities=' are passed to the processes of the service even if it runs as an unprivileged user. Some capabilities, such as CAP_SYS_ADMIN, are equivalent to root."
+# scope: package
+# related_resources:
+# - https://www.freedesktop.org/software/systemd/man/latest/systemd.exec.html#AmbientCapabilities=
+# - https://man7.org/linux/man-pages/man7/capabilities.7.html
+# custom:
+#   id: SD006
+#   avd_id: AVD-SD-0006
+#   severity: HIGH
+#   short_code: no-privileged-capabilities
+#   recommended_action: "Remove the privileged capabilities from 'AmbientCapabilities='"
+#   input:
+#     selector:
+#     - type: systemd
+package builtin.systemd.SD006
+
+import rego.v1
+
+import data.lib.systemd
+
+privileged_capabilities := {
+	"CAP_SYS_ADMIN",
+	"CAP_SYS_MODULE",
+	"CAP_SYS_PTRACE",
+	"CAP_SYS_RAWIO",
+	"CAP_DAC_OVERRIDE",
+	"CAP_DAC_READ_SEARCH",
+	"CAP_SETUID",
+	"CAP_SETGID",
+	"CAP_NET_ADMIN",
+	"CAP_BPF",
+}
+
+deny contains res if {
+	some section in systemd.services
+	some d in systemd.directives(section, "AmbientCapabilities")
+	not startswith(d.Value, "~")
+	some capability in split(d.Value, " ")
+	upper(capability) in privileged_capabilities
+	res := result.new(sprintf("'AmbientCapabilities' grants '%s'", [upper(capability)]), d)
+}
+
+# "~" grants all the capabilities except the listed ones
+deny contains res if {
+	some section in systemd.services
+	some d in systemd.directives(section, "AmbientCapabilities")
+	startswith(d.Value, "~")
+	res := result.new(sprintf("'AmbientCapabilities' grants all the capabilities except '%s'", [trim_prefix(d.Value, "~")]), d)
+}
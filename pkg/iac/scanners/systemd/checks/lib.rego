@@ -0,0 +1,35 @@
+# METADATA
+# custom:
+#   library: true
+#   input:
+#     selector:
+#     - type: systemd
+package lib.systemd
+
+import rego.v1
+
+# services returns the [Service] sections of the unit
+services contains section if {
+	some section in input.Sections
+	section.Name == "Service"
+}
+
+# directive returns the last assignment of the key in the section, which takes effect
+directive(section, key) := last if {
+	assignments := [d | some d in section.Directives; d.Key == key]
+	count(assignments) > 0
+	last := assignments[count(assignments) - 1]
+}
+
+# directives returns all the assignments of the key in the section, e.g. for list values
+directives(section, key) := [d | some d in section.Directives; d.Key == key]
+
+# is_true is true if the value is a boolean true in systemd
+is_true(value) if lower(value) in {"yes", "true", "on", "1"}
+
+# enabled is true if the boolean directive is set to true in the section
+enabled(section, key) if is_true(directive(section, key).Value)
+
+# dynamic_user is true if the service runs as a dynamic user,
+# which implies ProtectSystem=strict, ProtectHome=read-only and PrivateTmp=yes
+dynamic_user(section) if enabled(section, "DynamicUser")
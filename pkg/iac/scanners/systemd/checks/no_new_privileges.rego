@@ -0,0 +1,33 @@
+# METADATA
+# title: "Service should not gain new privileges"
+# description: "Without 'NoNewPrivileges=yes', the processes of the service can gain privileges through setuid binaries or file capabilities."
+# scope: package
+# related_resources:
+# - https://www.freedesktop.org/software/systemd/man/latest/systemd.exec.html#NoNewPrivileges=
+# custom:
+#   id: SD002
+#   avd_id: AVD-SD-0002
+#   severity: MEDIUM
+#   short_code: no-new-privileges
+#   recommended_action: "Set 'NoNewPrivileges=yes'"
+#   input:
+#     selector:
+#     - type: systemd
+package builtin.systemd.SD002
+
+import rego.v1
+
+import data.lib.systemd
+
+deny contains res if {
+	some section in systemd.services
+	d := systemd.directive(section, "NoNewPrivileges")
+	not systemd.is_true(d.Value)
+	res := result.new(sprintf("'NoNewPrivileges' is set to '%s'", [d.Value]), d)
+}
+
+deny contains res if {
+	some section in systemd.services
+	not systemd.directive(section, "NoNewPrivileges")
+	res := result.new("'NoNewPrivileges=yes' is not set", section)
+}
@@ -0,0 +1,39 @@
+# METADATA
+# title: "Service should use a private /tmp"
+# description: "Without 'PrivateTmp=yes', the service shares /tmp and /var/tmp with other processes, which exposes it to symlink attacks and leaks of temporary files."
+# scope: package
+# related_resources:
+# - https://www.freedesktop.org/software/systemd/man/latest/systemd.exec.html#PrivateTmp=
+# custom:
+#   id: SD005
+#   avd_id: AVD-SD-0005
+#   severity: LOW
+#   short_code: private-tmp
+#   recommended_action: "Set 'PrivateTmp=yes'"
+#   input:
+#     selector:
+#     - type: systemd
+package builtin.systemd.SD005
+
+import rego.v1
+
+import data.lib.systemd
+
+protected(value) if systemd.is_true(value)
+
+protected(value) if lower(value) == "disconnected"
+
+deny contains res if {
+	some section in systemd.services
+	not systemd.dynamic_user(section)
+	d := systemd.directive(section, "PrivateTmp")
+	not protected(d.Value)
+	res := result.new(sprintf("'PrivateTmp' is set to '%s'", [d.Value]), d)
+}
+
+deny contains res if {
+	some section in systemd.services
+	not systemd.dynamic_user(section)
+	not systemd.directive(section, "PrivateTmp")
+	res := result.new("'PrivateTmp=yes' is not set", section)
+}
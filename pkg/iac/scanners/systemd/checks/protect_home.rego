@@ -0,0 +1,39 @@
+# METADATA
+# title: "Service should not be able to access home directories"
+# description: "Without 'ProtectHome=', the service can access /home, /root and /run/user, which may contain credentials and other sensitive data of users."
+# scope: package
+# related_resources:
+# - https://www.freedesktop.org/software/systemd/man/latest/systemd.exec.html#ProtectHome=
+# custom:
+#   id: SD004
+#   avd_id: AVD-SD-0004
+#   severity: LOW
+#   short_code: protect-home
+#   recommended_action: "Set 'ProtectHome=yes', 'ProtectHome=read-only' or 'ProtectHome=tmpfs'"
+#   input:
+#     selector:
+#     - type: systemd
+package builtin.systemd.SD004
+
+import rego.v1
+
+import data.lib.systemd
+
+protected(value) if systemd.is_true(value)
+
+protected(value) if lower(value) in {"read-only", "tmpfs"}
+
+deny contains res if {
+	some section in systemd.services
+	not systemd.dynamic_user(section)
+	d := systemd.directive(section, "ProtectHome")
+	not protected(d.Value)
+	res := result.new(sprintf("'ProtectHome' is set to '%s'", [d.Value]), d)
+}
+
+deny contains res if {
+	some section in systemd.services
+	not systemd.dynamic_user(section)
+	not systemd.directive(section, "ProtectHome")
+	res := result.new("'ProtectHome' is not set", section)
+}
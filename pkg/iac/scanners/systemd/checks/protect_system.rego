@@ -0,0 +1,39 @@
+# METADATA
+# title: "Service should not be able to modify the operating system"
+# description: "Without 'ProtectSystem=', the service can write to /usr, /boot and /etc, and a compromised service can tamper with the operating system."
+# scope: package
+# related_resources:
+# - https://www.freedesktop.org/software/systemd/man/latest/systemd.exec.html#ProtectSystem=
+# custom:
+#   id: SD003
+#   avd_id: AVD-SD-0003
+#   severity: MEDIUM
+#   short_code: protect-system
+#   recommended_action: "Set 'ProtectSystem=strict' or 'ProtectSystem=full'"
+#   input:
+#     selector:
+#     - type: systemd
+package builtin.systemd.SD003
+
+import rego.v1
+
+import data.lib.systemd
+
+protected(value) if systemd.is_true(value)
+
+protected(value) if lower(value) in {"full", "strict"}
+
+deny contains res if {
+	some section in systemd.services
+	not systemd.dynamic_user(section)
+	d := systemd.directive(section, "ProtectSystem")
+	not protected(d.Value)
+	res := result.new(sprintf("'ProtectSystem' is set to '%s'", [d.Value]), d)
+}
+
+deny contains res if {
+	some section in systemd.services
+	not systemd.dynamic_user(section)
+	not systemd.directive(section, "ProtectSystem")
+	res := result.new("'ProtectSystem' is not set", section)
+}
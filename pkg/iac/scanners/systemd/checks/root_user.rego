@@ -0,0 +1,34 @@
+# METADATA
+# title: "Service should not run as root"
+# description: "Services run as root unless 'User=' is set, and a compromised service then has full control of the host. Running the service as an unprivileged or dynamic user limits the impact."
+# scope: package
+# related_resources:
+# - https://www.freedesktop.org/software/systemd/man/latest/systemd.exec.html#User=
+# custom:
+#   id: SD001
+#   avd_id: AVD-SD-0001
+#   severity: HIGH
+#   short_code: no-root-user
+#   recommended_action: "Set 'User=' to a non-root user or set 'DynamicUser=yes'"
+#   input:
+#     selector:
+#     - type: systemd
+package builtin.systemd.SD001
+
+import rego.v1
+
+import data.lib.systemd
+
+deny contains res if {
+	some section in systemd.services
+	user := systemd.directive(section, "User")
+	user.Value in {"root", "0"}
+	res := result.new(sprintf("Service runs as '%s'", [user.Value]), user)
+}
+
+deny contains res if {
+	some section in systemd.services
+	not systemd.directive(section, "User")
+	not systemd.dynamic_user(section)
+	res := result.new("Service runs as root since neither 'User=' nor 'DynamicUser=yes' is set", section)
+}
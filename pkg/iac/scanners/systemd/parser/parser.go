@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/iac/providers/systemd"
+)
+
+// Parse parses a systemd unit file into sections and directives.
+// cf. https://www.freedesktop.org/software/systemd/man/latest/systemd.syntax.html
+func Parse(_ context.Context, r io.Reader, path string) (any, error) {
+	var (
+		unit    systemd.Unit
+		section *systemd.Section
+		lineNum int
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNum++
+		startLine := lineNum
+		line := strings.TrimSpace(scanner.Text())
+
+		// A line ending with a backslash is continued on the next line, where comments are skipped
+		for strings.HasSuffix(line, `\`) && !isComment(line) {
+			line = strings.TrimSuffix(line, `\`) + " "
+			for scanner.Scan() {
+				lineNum++
+				next := strings.TrimSpace(scanner.Text())
+				if !isComment(next) {
+					line += next
+					break
+				}
+			}
+		}
+
+		switch {
+		case line == "" || isComment(line):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			if section != nil {
+				unit.Sections = append(unit.Sections, *section)
+			}
+			section = &systemd.Section{
+				Name:      strings.TrimSpace(line[1 : len(line)-1]),
+				Path:      path,
+				StartLine: startLine,
+				EndLine:   lineNum,
+			}
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("systemd unit parse error: line %d: missing '='", startLine)
+		} else if section == nil {
+			return nil, fmt.Errorf("systemd unit parse error: line %d: assignment outside of a section", startLine)
+		}
+		section.Directives = append(section.Directives, systemd.Directive{
+			Key:       strings.TrimSpace(key),
+			Value:     strings.TrimSpace(value),
+			Path:      path,
+			StartLine: startLine,
+			EndLine:   lineNum,
+		})
+		section.EndLine = lineNum
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("systemd unit read error: %w", err)
+	}
+	if section != nil {
+		unit.Sections = append(unit.Sections, *section)
+	}
+
+	return &unit, nil
+}
+
+func isComment(line string) bool {
+	return strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";")
+}
@@ -0,0 +1,107 @@
+package parser_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/iac/providers/systemd"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/systemd/parser"
+)
+
+func Test_Parser(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *systemd.Unit
+		wantErr string
+	}{
+		{
+			name: "happy path",
+			input: `# comment
+[Unit]
+Description=Example
+
+[Service]
+; comment
+ExecStart=/usr/bin/example \
+    # comment in a continued line
+    --verbose
+User = root
+Environment=
+`,
+			want: &systemd.Unit{
+				Sections: []systemd.Section{
+					{
+						Name:      "Unit",
+						Path:      "example.service",
+						StartLine: 2,
+						EndLine:   3,
+						Directives: []systemd.Directive{
+							{
+								Key:       "Description",
+								Value:     "Example",
+								Path:      "example.service",
+								StartLine: 3,
+								EndLine:   3,
+							},
+						},
+					},
+					{
+						Name:      "Service",
+						Path:      "example.service",
+						StartLine: 5,
+						EndLine:   11,
+						Directives: []systemd.Directive{
+							{
+								Key:       "ExecStart",
+								Value:     "/usr/bin/example  --verbose",
+								Path:      "example.service",
+								StartLine: 7,
+								EndLine:   9,
+							},
+							{
+								Key:       "User",
+								Value:     "root",
+								Path:      "example.service",
+								StartLine: 10,
+								EndLine:   10,
+							},
+							{
+								Key:       "Environment",
+								Value:     "",
+								Path:      "example.service",
+								StartLine: 11,
+								EndLine:   11,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "missing '='",
+			input:   "[Service]\nUser\n",
+			wantErr: "line 2: missing '='",
+		},
+		{
+			name:    "assignment outside of a section",
+			input:   "User=root\n",
+			wantErr: "line 1: assignment outside of a section",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parser.Parse(context.TODO(), strings.NewReader(tt.input), "example.service")
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
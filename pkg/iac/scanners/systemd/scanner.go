@@ -0,0 +1,21 @@
+package systemd
+
+import (
+	"embed"
+
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/generic"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/systemd/parser"
+	"github.com/aquasecurity/trivy/pkg/iac/types"
+)
+
+// checksFS has the built-in checks for systemd unit files, which are not part of trivy-checks
+//
+//go:embed checks/*.rego
+var checksFS embed.FS
+
+func NewScanner(opts ...options.ScannerOption) *generic.GenericScanner {
+	opts = append([]options.ScannerOption{rego.WithEmbeddedChecksFS(checksFS)}, opts...)
+	return generic.NewScanner("Systemd", types.SourceSystemd, generic.ParseFunc(parser.Parse), opts...)
+}
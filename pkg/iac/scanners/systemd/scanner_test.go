@@ -0,0 +1,85 @@
+package systemd_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+	"github.com/aquasecurity/trivy/pkg/iac/scan"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/systemd"
+)
+
+func Test_BuiltinChecks(t *testing.T) {
+	type failure struct {
+		AVDID     string
+		Filepath  string
+		StartLine int
+		EndLine   int
+	}
+
+	tests := []struct {
+		name string
+		dir  string
+		want []failure
+	}{
+		{
+			name: "root user",
+			dir:  "root.service",
+			want: []failure{
+				{AVDID: "AVD-SD-0001", Filepath: "root.service", StartLine: 7, EndLine: 7},
+				{AVDID: "AVD-SD-0002", Filepath: "root.service", StartLine: 9, EndLine: 9},
+				{AVDID: "AVD-SD-0003", Filepath: "root.service", StartLine: 4, EndLine: 9},
+				{AVDID: "AVD-SD-0004", Filepath: "root.service", StartLine: 4, EndLine: 9},
+				{AVDID: "AVD-SD-0005", Filepath: "root.service", StartLine: 4, EndLine: 9},
+				{AVDID: "AVD-SD-0006", Filepath: "root.service", StartLine: 8, EndLine: 8},
+			},
+		},
+		{
+			name: "hardened",
+			dir:  "hardened.service",
+		},
+		{
+			name: "dynamic user",
+			dir:  "dynamic-user.service",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := systemd.NewScanner(rego.WithEmbeddedPolicies(true), rego.WithEmbeddedLibraries(true))
+			results, err := s.ScanFS(context.TODO(), os.DirFS("testdata"), tt.dir)
+			require.NoError(t, err)
+
+			got := lo.Map(results.GetFailed(), func(r scan.Result, _ int) failure {
+				rng := r.Range()
+				return failure{
+					AVDID:     r.Rule().AVDID,
+					Filepath:  rng.GetFilename(),
+					StartLine: rng.GetStartLine(),
+					EndLine:   rng.GetEndLine(),
+				}
+			})
+			assert.ElementsMatch(t, tt.want, got)
+			if len(tt.want) == 0 {
+				assert.NotEmpty(t, results.GetPassed())
+			}
+		})
+	}
+}
+
+func Test_BuiltinChecks_DisableEmbeddedPolicies(t *testing.T) {
+	// The embedded checks and libraries are disabled when the checks bundle is downloaded,
+	// and the bundle doesn't contain the checks for systemd unit files.
+	s := systemd.NewScanner(rego.WithEmbeddedPolicies(false), rego.WithEmbeddedLibraries(false))
+	results, err := s.ScanFS(context.TODO(), os.DirFS("testdata"), "root.service")
+	require.NoError(t, err)
+
+	ids := lo.Map(results.GetFailed(), func(r scan.Result, _ int) string {
+		return r.Rule().AVDID
+	})
+	assert.Contains(t, ids, "AVD-SD-0001")
+}
@@ -11,4 +11,5 @@ const (
 	SourceYAML       Source = "yaml"
 	SourceJSON       Source = "json"
 	SourceTOML       Source = "toml"
+	SourceSystemd    Source = "systemd"
 )
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"golang.org/x/xerrors"
+
+	cmd "github.com/aquasecurity/trivy/pkg/commands/artifact"
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/k8s/scanner"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// admissionRun scans the resource of the AdmissionReview request read from r and writes the AdmissionReview response,
+// so that Trivy can be used as the backend of validating admission webhooks.
+// The cluster is not accessed as the resource is included in the request.
+func admissionRun(ctx context.Context, opts flag.Options, r io.Reader) error {
+	runner, err := cmd.NewRunner(ctx, opts)
+	if err != nil {
+		if errors.Is(err, cmd.SkipScan) {
+			return nil
+		}
+		return xerrors.Errorf("init error: %w", err)
+	}
+	defer func() {
+		if err := runner.Close(ctx); err != nil {
+			log.ErrorContext(ctx, "failed to close runner: %s", err)
+		}
+	}()
+
+	s := scanner.NewScanner("", runner, opts)
+	_, review, err := s.ScanAdmissionReview(ctx, r)
+	if err != nil {
+		return xerrors.Errorf("admission review error: %w", err)
+	}
+
+	output, cleanup, err := opts.OutputWriter(ctx)
+	if err != nil {
+		return xerrors.Errorf("failed to create output file: %w", err)
+	}
+	defer func() {
+		if err := cleanup(); err != nil {
+			log.ErrorContext(ctx, "Failed to write the output", log.Err(err))
+		}
+	}()
+
+	if err = json.NewEncoder(output).Encode(review); err != nil {
+		return xerrors.Errorf("failed to write the AdmissionReview response: %w", err)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/spf13/viper"
 	"golang.org/x/xerrors"
@@ -24,6 +25,12 @@ import (
 
 // Run runs a k8s scan
 func Run(ctx context.Context, args []string, opts flag.Options) error {
+	if opts.K8sOptions.AdmissionReview {
+		ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+		return admissionRun(ctx, opts, os.Stdin)
+	}
+
 	clusterOptions := []k8s.ClusterOption{
 		k8s.WithKubeConfig(opts.K8sOptions.KubeConfig),
 		k8s.WithBurst(opts.K8sOptions.Burst),
@@ -90,12 +97,18 @@ func (r *runner) run(ctx context.Context, artifacts []*k8sArtifacts.Artifact) er
 	if err != nil {
 		return xerrors.Errorf("k8s scan error: %w", err)
 	}
+	// Namespaces are also excluded in the report so that glob patterns are supported
+	rpt = rpt.ExcludeNamespaces(r.flagOpts.ExcludeNamespaces)
 
 	output, cleanup, err := r.flagOpts.OutputWriter(ctx)
 	if err != nil {
 		return xerrors.Errorf("failed to create output file: %w", err)
 	}
-	defer cleanup()
+	defer func() {
+		if err := cleanup(); err != nil {
+			log.ErrorContext(ctx, "Failed to write the output", log.Err(err))
+		}
+	}()
 
 	if r.flagOpts.Compliance.Spec.ID != "" {
 		var scanResults []types.Results
@@ -120,11 +133,12 @@ func (r *runner) run(ctx context.Context, artifacts []*k8sArtifacts.Artifact) er
 		Severities: r.flagOpts.Severities,
 		Scanners:   r.flagOpts.ScanOptions.Scanners,
 		APIVersion: r.flagOpts.AppVersion,
+		MaxRows:    r.flagOpts.MaxRows,
 	}); err != nil {
 		return xerrors.Errorf("unable to write results: %w", err)
 	}
 
-	return operation.Exit(r.flagOpts, rpt.Failed(), types.Metadata{})
+	return operation.Exit(r.flagOpts, rpt.Results(), types.Metadata{})
 }
 
 // Full-cluster scanning with '--format table' without explicit '--report all' is not allowed so that it won't mess up user's terminal.
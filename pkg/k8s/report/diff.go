@@ -0,0 +1,195 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// DiffStatus represents how a resource changed between two reports
+type DiffStatus string
+
+const (
+	DiffStatusAdded   DiffStatus = "added"
+	DiffStatusRemoved DiffStatus = "removed"
+	DiffStatusChanged DiffStatus = "changed"
+)
+
+// Diff compares two reports of the same cluster and returns a report of the resources whose findings changed.
+// Resources are matched by namespace, kind and name.
+//   - Resources only in "after" are marked as added with all their findings.
+//   - Resources only in "before" are marked as removed with the findings they had.
+//   - Resources in both are marked as changed if findings were introduced or resolved.
+//     The new findings are stored in Results and the resolved ones in Resolved.
+//     A finding whose severity changed is reported as both resolved and new.
+//
+// Resources without any change are omitted.
+func Diff(before, after Report) Report {
+	beforeIndex := indexResources(before.Resources)
+	afterIndex := indexResources(after.Resources)
+
+	diff := Report{
+		SchemaVersion: after.SchemaVersion,
+		ClusterName:   lo.CoalesceOrEmpty(after.ClusterName, before.ClusterName),
+	}
+	for key, res := range afterIndex {
+		old, ok := beforeIndex[key]
+		if !ok {
+			diff.Resources = append(diff.Resources, diffResource(res, DiffStatusAdded, failedFindings(res.Results), nil))
+			continue
+		}
+		added := subtractFindings(res.Results, old.Results)
+		resolved := subtractFindings(old.Results, res.Results)
+		if len(added) == 0 && len(resolved) == 0 {
+			continue
+		}
+		diff.Resources = append(diff.Resources, diffResource(res, DiffStatusChanged, added, resolved))
+	}
+	for key, res := range beforeIndex {
+		if _, ok := afterIndex[key]; ok {
+			continue
+		}
+		diff.Resources = append(diff.Resources, diffResource(res, DiffStatusRemoved, failedFindings(res.Results), nil))
+	}
+
+	sort.Slice(diff.Resources, func(i, j int) bool {
+		return diff.Resources[i].fullname() < diff.Resources[j].fullname()
+	})
+	return diff
+}
+
+func (r Report) isDiff() bool {
+	return lo.ContainsBy(r.Resources, func(res Resource) bool {
+		return res.DiffStatus != ""
+	})
+}
+
+// indexResources merges the resources scanned more than once, e.g. for vulnerabilities and misconfigurations, by their full name
+func indexResources(resources []Resource) map[string]Resource {
+	index := make(map[string]Resource)
+	for _, res := range resources {
+		key := res.fullname()
+		if r, ok := index[key]; ok {
+			r.Metadata = append(r.Metadata, res.Metadata...)
+			r.Results = append(r.Results, res.Results...)
+			r.Error = lo.CoalesceOrEmpty(r.Error, res.Error)
+			index[key] = r
+			continue
+		}
+		res.Results = append(types.Results{}, res.Results...)
+		index[key] = res
+	}
+	return index
+}
+
+func diffResource(res Resource, status DiffStatus, results, resolved types.Results) Resource {
+	return Resource{
+		Namespace:  res.Namespace,
+		Kind:       res.Kind,
+		Name:       res.Name,
+		Metadata:   res.Metadata,
+		Results:    results,
+		Resolved:   resolved,
+		Error:      res.Error,
+		DiffStatus: status,
+		Report: types.Report{
+			ArtifactName: res.Name,
+			Results:      results,
+		},
+	}
+}
+
+// statusLabel returns the status shown in the summary table, e.g. "changed (+2, -1)"
+func (r Resource) statusLabel() string {
+	if r.DiffStatus != DiffStatusChanged {
+		return string(r.DiffStatus)
+	}
+	return fmt.Sprintf("%s (+%d, -%d)", r.DiffStatus, countFindings(r.Results), countFindings(r.Resolved))
+}
+
+// failedFindings returns the results without passed misconfigurations
+func failedFindings(results types.Results) types.Results {
+	return subtractFindings(results, nil)
+}
+
+// subtractFindings returns the findings of "results" that are not in "other".
+// Results without any remaining findings are dropped.
+func subtractFindings(results, other types.Results) types.Results {
+	keys := make(map[string]struct{})
+	for _, result := range other {
+		for _, key := range findingKeys(result) {
+			keys[key] = struct{}{}
+		}
+	}
+
+	var diff types.Results
+	for _, result := range results {
+		result.Vulnerabilities = filterFindings(result.Vulnerabilities, func(v types.DetectedVulnerability) bool {
+			return !lo.HasKey(keys, vulnerabilityKey(result.Target, v))
+		})
+		result.Misconfigurations = filterFindings(result.Misconfigurations, func(m types.DetectedMisconfiguration) bool {
+			return m.Status == types.MisconfStatusFailure && !lo.HasKey(keys, misconfigurationKey(result.Target, m))
+		})
+		result.Secrets = filterFindings(result.Secrets, func(s types.DetectedSecret) bool {
+			return !lo.HasKey(keys, secretKey(result.Target, s))
+		})
+		if len(result.Vulnerabilities) == 0 && len(result.Misconfigurations) == 0 && len(result.Secrets) == 0 {
+			continue
+		}
+		result.MisconfSummary = nil
+		diff = append(diff, result)
+	}
+	return diff
+}
+
+// filterFindings is like lo.Filter, but returns nil instead of an empty slice so that empty fields are omitted
+func filterFindings[T any](findings []T, keep func(T) bool) []T {
+	var filtered []T
+	for _, f := range findings {
+		if keep(f) {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+func findingKeys(result types.Result) []string {
+	var keys []string
+	for _, v := range result.Vulnerabilities {
+		keys = append(keys, vulnerabilityKey(result.Target, v))
+	}
+	for _, m := range result.Misconfigurations {
+		if m.Status == types.MisconfStatusFailure {
+			keys = append(keys, misconfigurationKey(result.Target, m))
+		}
+	}
+	for _, s := range result.Secrets {
+		keys = append(keys, secretKey(result.Target, s))
+	}
+	return keys
+}
+
+func vulnerabilityKey(target string, v types.DetectedVulnerability) string {
+	return strings.Join([]string{"vuln", target, v.VulnerabilityID, v.PkgName, v.InstalledVersion, v.PkgPath, v.Severity}, "|")
+}
+
+func misconfigurationKey(target string, m types.DetectedMisconfiguration) string {
+	return strings.Join([]string{"misconf", target, m.AVDID, m.ID, m.Message, m.Severity}, "|")
+}
+
+func secretKey(target string, s types.DetectedSecret) string {
+	return strings.Join([]string{"secret", target, s.RuleID, strconv.Itoa(s.StartLine), s.Severity}, "|")
+}
+
+func countFindings(results types.Results) int {
+	var count int
+	for _, result := range results {
+		count += len(result.Vulnerabilities) + len(result.Misconfigurations) + len(result.Secrets)
+	}
+	return count
+}
@@ -0,0 +1,241 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestDiff(t *testing.T) {
+	vuln := func(id, severity string) types.DetectedVulnerability {
+		return types.DetectedVulnerability{
+			VulnerabilityID:  id,
+			PkgName:          "openssl",
+			InstalledVersion: "3.0.0",
+			Vulnerability:    dbTypes.Vulnerability{Severity: severity},
+		}
+	}
+	misconf := func(id string, status types.MisconfStatus) types.DetectedMisconfiguration {
+		return types.DetectedMisconfiguration{
+			ID:       id,
+			AVDID:    "AVD-" + id,
+			Severity: "HIGH",
+			Status:   status,
+		}
+	}
+	vulnResource := func(name string, vulns ...types.DetectedVulnerability) Resource {
+		return Resource{
+			Namespace: "default",
+			Kind:      "Deployment",
+			Name:      name,
+			Results: types.Results{
+				{
+					Target:          "alpine:3.14 (alpine 3.14.2)",
+					Vulnerabilities: vulns,
+				},
+			},
+		}
+	}
+	misconfResource := func(name string, misconfs ...types.DetectedMisconfiguration) Resource {
+		return Resource{
+			Namespace: "default",
+			Kind:      "Deployment",
+			Name:      name,
+			Results: types.Results{
+				{
+					Target:            "Deployment/" + name,
+					Misconfigurations: misconfs,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		before Report
+		after  Report
+		want   []Resource
+	}{
+		{
+			name: "resource gains a vulnerability",
+			before: Report{
+				Resources: []Resource{
+					vulnResource("orion", vuln("CVE-2022-1111", "LOW")),
+				},
+			},
+			after: Report{
+				Resources: []Resource{
+					vulnResource("orion", vuln("CVE-2022-1111", "LOW"), vuln("CVE-2022-2222", "HIGH")),
+				},
+			},
+			want: []Resource{
+				{
+					Namespace:  "default",
+					Kind:       "Deployment",
+					Name:       "orion",
+					DiffStatus: DiffStatusChanged,
+					Results: types.Results{
+						{
+							Target:          "alpine:3.14 (alpine 3.14.2)",
+							Vulnerabilities: []types.DetectedVulnerability{vuln("CVE-2022-2222", "HIGH")},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "resource loses a misconfiguration",
+			before: Report{
+				Resources: []Resource{
+					misconfResource("orion", misconf("KSV001", types.MisconfStatusFailure), misconf("KSV002", types.MisconfStatusFailure)),
+				},
+			},
+			after: Report{
+				Resources: []Resource{
+					misconfResource("orion", misconf("KSV001", types.MisconfStatusFailure), misconf("KSV002", types.MisconfStatusPassed)),
+				},
+			},
+			want: []Resource{
+				{
+					Namespace:  "default",
+					Kind:       "Deployment",
+					Name:       "orion",
+					DiffStatus: DiffStatusChanged,
+					Resolved: types.Results{
+						{
+							Target:            "Deployment/orion",
+							Misconfigurations: []types.DetectedMisconfiguration{misconf("KSV002", types.MisconfStatusFailure)},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "severity changed",
+			before: Report{
+				Resources: []Resource{
+					vulnResource("orion", vuln("CVE-2022-1111", "LOW")),
+				},
+			},
+			after: Report{
+				Resources: []Resource{
+					vulnResource("orion", vuln("CVE-2022-1111", "CRITICAL")),
+				},
+			},
+			want: []Resource{
+				{
+					Namespace:  "default",
+					Kind:       "Deployment",
+					Name:       "orion",
+					DiffStatus: DiffStatusChanged,
+					Results: types.Results{
+						{
+							Target:          "alpine:3.14 (alpine 3.14.2)",
+							Vulnerabilities: []types.DetectedVulnerability{vuln("CVE-2022-1111", "CRITICAL")},
+						},
+					},
+					Resolved: types.Results{
+						{
+							Target:          "alpine:3.14 (alpine 3.14.2)",
+							Vulnerabilities: []types.DetectedVulnerability{vuln("CVE-2022-1111", "LOW")},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "resources added and removed",
+			before: Report{
+				Resources: []Resource{
+					vulnResource("orion", vuln("CVE-2022-1111", "LOW")),
+					vulnResource("unchanged", vuln("CVE-2022-1111", "LOW")),
+				},
+			},
+			after: Report{
+				Resources: []Resource{
+					vulnResource("unchanged", vuln("CVE-2022-1111", "LOW")),
+					misconfResource("vega", misconf("KSV001", types.MisconfStatusFailure), misconf("KSV002", types.MisconfStatusPassed)),
+				},
+			},
+			want: []Resource{
+				{
+					Namespace:  "default",
+					Kind:       "Deployment",
+					Name:       "orion",
+					DiffStatus: DiffStatusRemoved,
+					Results: types.Results{
+						{
+							Target:          "alpine:3.14 (alpine 3.14.2)",
+							Vulnerabilities: []types.DetectedVulnerability{vuln("CVE-2022-1111", "LOW")},
+						},
+					},
+				},
+				{
+					Namespace:  "default",
+					Kind:       "Deployment",
+					Name:       "vega",
+					DiffStatus: DiffStatusAdded,
+					Results: types.Results{
+						{
+							Target:            "Deployment/vega",
+							Misconfigurations: []types.DetectedMisconfiguration{misconf("KSV001", types.MisconfStatusFailure)},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "findings of the same resource are merged",
+			before: Report{
+				Resources: []Resource{
+					vulnResource("orion", vuln("CVE-2022-1111", "LOW")),
+					misconfResource("orion", misconf("KSV001", types.MisconfStatusFailure)),
+				},
+			},
+			after: Report{
+				Resources: []Resource{
+					misconfResource("orion", misconf("KSV001", types.MisconfStatusFailure)),
+					vulnResource("orion", vuln("CVE-2022-1111", "LOW")),
+				},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diff(tt.before, tt.after)
+			for i := range got.Resources {
+				got.Resources[i].Report = types.Report{}
+			}
+			assert.Equal(t, tt.want, got.Resources)
+		})
+	}
+}
+
+func TestResource_statusLabel(t *testing.T) {
+	res := Resource{
+		DiffStatus: DiffStatusChanged,
+		Results: types.Results{
+			{
+				Vulnerabilities: []types.DetectedVulnerability{
+					{VulnerabilityID: "CVE-2022-1111"},
+					{VulnerabilityID: "CVE-2022-2222"},
+				},
+			},
+		},
+		Resolved: types.Results{
+			{
+				Secrets: []types.DetectedSecret{
+					{RuleID: "aws-access-key-id"},
+				},
+			},
+		},
+	}
+	assert.Equal(t, "changed (+2, -1)", res.statusLabel())
+
+	res.DiffStatus = DiffStatusAdded
+	assert.Equal(t, "added", res.statusLabel())
+}
@@ -7,6 +7,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/samber/lo"
 
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
@@ -24,6 +25,9 @@ const (
 	workloadComponent = "workload"
 	infraComponent    = "infra"
 	infraNamespace    = "kube-system"
+
+	// resourceErrorType is the type of custom resources holding errors that occurred while scanning resources
+	resourceErrorType = "k8s-resource-error"
 )
 
 type Option struct {
@@ -34,6 +38,7 @@ type Option struct {
 	ColumnHeading []string
 	Scanners      types.Scanners
 	APIVersion    string
+	MaxRows       int
 }
 
 // Report represents a kubernetes scan report
@@ -61,6 +66,10 @@ type Resource struct {
 	Results   types.Results    `json:",omitempty"`
 	Error     string           `json:",omitempty"`
 
+	// Set only in reports returned by Diff
+	DiffStatus DiffStatus    `json:",omitempty"`
+	Resolved   types.Results `json:",omitempty"`
+
 	// original report
 	Report types.Report `json:"-"`
 }
@@ -79,6 +88,15 @@ func (r Report) Failed() bool {
 	return false
 }
 
+// Results returns the results of all the resources
+func (r Report) Results() types.Results {
+	var results types.Results
+	for _, v := range r.Resources {
+		results = append(results, v.Results...)
+	}
+	return results
+}
+
 func (r Report) consolidate() ConsolidatedReport {
 	consolidated := ConsolidatedReport{
 		SchemaVersion: r.SchemaVersion,
@@ -128,6 +146,68 @@ func (r Report) consolidate() ConsolidatedReport {
 	return consolidated
 }
 
+// ExcludeNamespaces returns a copy of the report without resources in the namespaces matching the given glob patterns.
+// Cluster-scoped resources are kept unless an empty namespace is explicitly excluded,
+// since they would otherwise be dropped by wildcard patterns like "*".
+func (r Report) ExcludeNamespaces(patterns []string) Report {
+	if len(patterns) == 0 {
+		return r
+	}
+	r.Resources = lo.Reject(r.Resources, func(res Resource, _ int) bool {
+		return lo.ContainsBy(patterns, func(pattern string) bool {
+			if res.Namespace == "" {
+				return pattern == ""
+			}
+			matched, _ := doublestar.Match(pattern, res.Namespace)
+			return matched
+		})
+	})
+	return r
+}
+
+// ToCoreReport flattens the resources into the core report types so that the core writers can be used for k8s scans.
+// The target of each result is prefixed with the resource name like "namespace/kind/name",
+// or "kind/name" for cluster-scoped resources.
+// Errors that occurred while scanning resources are stored as custom resources of dedicated results.
+func (r Report) ToCoreReport() types.Report {
+	report := types.Report{
+		SchemaVersion: r.SchemaVersion,
+		ArtifactName:  r.ClusterName,
+		BOM:           r.BOM,
+	}
+
+	for _, res := range r.Resources {
+		name := res.Kind + "/" + res.Name
+		if res.Namespace != "" {
+			name = res.Namespace + "/" + name
+		}
+		for _, result := range res.Results {
+			target := name
+			// The target of kubernetes files is already the resource name, e.g. "Deployment/orion".
+			if result.Target != "" && result.Type != ftypes.Kubernetes {
+				target = fmt.Sprintf("%s (%s)", name, result.Target)
+			}
+			result.Target = target
+			report.Results = append(report.Results, result)
+		}
+
+		if res.Error != "" {
+			report.Results = append(report.Results, types.Result{
+				Target: name,
+				Class:  types.ClassCustom,
+				CustomResources: []ftypes.CustomResource{
+					{
+						Type:     resourceErrorType,
+						FilePath: name,
+						Data:     res.Error,
+					},
+				},
+			})
+		}
+	}
+	return report
+}
+
 // Writer defines the result write operation
 type Writer interface {
 	Write(Report) error
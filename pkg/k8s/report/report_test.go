@@ -1,11 +1,17 @@
 package report
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	coreReport "github.com/aquasecurity/trivy/pkg/report"
 	"github.com/aquasecurity/trivy/pkg/types"
 )
 
@@ -535,6 +541,215 @@ func TestReport_consolidate(t *testing.T) {
 	}
 }
 
+func TestReport_ExcludeNamespaces(t *testing.T) {
+	clusterRole := Resource{
+		Kind: "ClusterRole",
+		Name: "system:controller:expand-controller",
+	}
+	kubePublicPod := Resource{
+		Namespace: "kube-public",
+		Kind:      "Pod",
+		Name:      "cluster-info",
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []Resource
+	}{
+		{
+			name:     "wildcard pattern",
+			patterns: []string{"kube-*"},
+			want: []Resource{
+				deployOrionWithVulns,
+				clusterRole,
+			},
+		},
+		{
+			name:     "exact name",
+			patterns: []string{"kube-system"},
+			want: []Resource{
+				deployOrionWithVulns,
+				kubePublicPod,
+				clusterRole,
+			},
+		},
+		{
+			name:     "cluster-scoped resources are kept",
+			patterns: []string{"*"},
+			want: []Resource{
+				clusterRole,
+			},
+		},
+		{
+			name: "no patterns",
+			want: []Resource{
+				deployOrionWithVulns,
+				apiseverPodWithMisconfigAndInfra,
+				kubePublicPod,
+				clusterRole,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Report{
+				ClusterName: "test",
+				Resources: []Resource{
+					deployOrionWithVulns,
+					apiseverPodWithMisconfigAndInfra,
+					kubePublicPod,
+					clusterRole,
+				},
+			}
+			got := r.ExcludeNamespaces(tt.patterns)
+			assert.Equal(t, "test", got.ClusterName)
+			assert.Equal(t, tt.want, got.Resources)
+		})
+	}
+}
+
+func TestReport_ToCoreReport(t *testing.T) {
+	k8sReport := Report{
+		SchemaVersion: 2,
+		ClusterName:   "test-cluster",
+		Resources: []Resource{
+			{
+				Namespace: "default",
+				Kind:      "Deploy",
+				Name:      "orion",
+				Results: types.Results{
+					{
+						Target: "alpine:3.14 (alpine 3.14.2)",
+						Class:  types.ClassOSPkg,
+						Type:   ftypes.Alpine,
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID: "CVE-2022-1111",
+								PkgName:         "musl",
+								Vulnerability:   dbTypes.Vulnerability{Severity: "LOW"},
+							},
+						},
+					},
+					{
+						Target: "Deploy/orion",
+						Class:  types.ClassConfig,
+						Type:   ftypes.Kubernetes,
+						Misconfigurations: []types.DetectedMisconfiguration{
+							{
+								ID:       "ID100",
+								Status:   types.MisconfStatusFailure,
+								Severity: "HIGH",
+							},
+						},
+					},
+				},
+			},
+			{
+				Namespace: "default",
+				Kind:      "Pod",
+				Name:      "broken",
+				Error:     "image not found",
+			},
+			{
+				Kind: "ClusterRole",
+				Name: "admin",
+				Results: types.Results{
+					{
+						Target: "ClusterRole/admin",
+						Class:  types.ClassConfig,
+						Type:   ftypes.Kubernetes,
+						Misconfigurations: []types.DetectedMisconfiguration{
+							{
+								ID:       "ID200",
+								Status:   types.MisconfStatusFailure,
+								Severity: "MEDIUM",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	want := types.Report{
+		SchemaVersion: 2,
+		ArtifactName:  "test-cluster",
+		Results: types.Results{
+			{
+				Target: "default/Deploy/orion (alpine:3.14 (alpine 3.14.2))",
+				Class:  types.ClassOSPkg,
+				Type:   ftypes.Alpine,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID: "CVE-2022-1111",
+						PkgName:         "musl",
+						Vulnerability:   dbTypes.Vulnerability{Severity: "LOW"},
+					},
+				},
+			},
+			{
+				Target: "default/Deploy/orion",
+				Class:  types.ClassConfig,
+				Type:   ftypes.Kubernetes,
+				Misconfigurations: []types.DetectedMisconfiguration{
+					{
+						ID:       "ID100",
+						Status:   types.MisconfStatusFailure,
+						Severity: "HIGH",
+					},
+				},
+			},
+			{
+				Target: "default/Pod/broken",
+				Class:  types.ClassCustom,
+				CustomResources: []ftypes.CustomResource{
+					{
+						Type:     "k8s-resource-error",
+						FilePath: "default/Pod/broken",
+						Data:     "image not found",
+					},
+				},
+			},
+			{
+				Target: "ClusterRole/admin",
+				Class:  types.ClassConfig,
+				Type:   ftypes.Kubernetes,
+				Misconfigurations: []types.DetectedMisconfiguration{
+					{
+						ID:       "ID200",
+						Status:   types.MisconfStatusFailure,
+						Severity: "MEDIUM",
+					},
+				},
+			},
+		},
+	}
+
+	got := k8sReport.ToCoreReport()
+	assert.Equal(t, want, got)
+
+	t.Run("JSON", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		err := coreReport.JSONWriter{Output: buf}.Write(context.Background(), got)
+		require.NoError(t, err)
+
+		var roundTripped types.Report
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &roundTripped))
+		assert.Equal(t, want, roundTripped)
+	})
+
+	t.Run("SARIF", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		sw := &coreReport.SarifWriter{Output: buf}
+		err := sw.Write(context.Background(), got)
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "CVE-2022-1111")
+		assert.Contains(t, buf.String(), "ID100")
+	})
+}
+
 func TestResource_fullname(t *testing.T) {
 	tests := []struct {
 		expected string
@@ -68,7 +68,15 @@ func (s SummaryWriter) Write(report Report) error {
 	if len(s.ColumnsHeading) == 2 {
 		return nil
 	}
-	consolidated := report.consolidate()
+	// Resources of a diff are already merged and may have no failures left, e.g. when all findings are resolved.
+	diff := report.isDiff()
+	columnHeading := s.ColumnsHeading
+	findings := slices.Clone(report.Resources)
+	if diff {
+		columnHeading = slices.Insert(slices.Clone(columnHeading), 2, StatusColumn)
+	} else {
+		findings = report.consolidate().Findings
+	}
 
 	if _, err := fmt.Fprintln(s.Output); err != nil {
 		return xerrors.Errorf("failed to write summary report: %w", err)
@@ -80,14 +88,14 @@ func (s SummaryWriter) Write(report Report) error {
 
 	t := table.New(s.Output)
 	t.SetRowLines(false)
-	configureHeader(s, t, s.ColumnsHeading)
+	configureHeader(s, t, columnHeading)
 
-	sort.Slice(consolidated.Findings, func(i, j int) bool {
-		return consolidated.Findings[i].Namespace > consolidated.Findings[j].Namespace
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Namespace > findings[j].Namespace
 	})
 
-	for _, finding := range consolidated.Findings {
-		if !finding.Results.Failed() {
+	for _, finding := range findings {
+		if !diff && !finding.Results.Failed() {
 			continue
 		}
 		vCount, mCount, sCount := accumulateSeverityCounts(finding)
@@ -96,6 +104,9 @@ func (s SummaryWriter) Write(report Report) error {
 			finding.Namespace,
 			name,
 		}
+		if diff {
+			rowParts = append(rowParts, finding.statusLabel())
+		}
 
 		if slices.Contains(s.ColumnsHeading, VulnerabilitiesColumn) {
 			rowParts = append(rowParts, s.generateSummary(vCount)...)
@@ -182,19 +193,19 @@ func accumulateSeverityCounts(finding Resource) (map[string]int, map[string]int,
 func configureHeader(s SummaryWriter, t *table.Table, columnHeading []string) {
 	sevCount := len(s.Severities)
 	if len(columnHeading) > 2 {
-		headerRow := []string{
-			columnHeading[0],
-			columnHeading[1],
+		// namespace, resource and the status of diff reports
+		fixed := 2
+		if slices.Contains(columnHeading, StatusColumn) {
+			fixed = 3
 		}
+		headerRow := slices.Clone(columnHeading[:fixed])
 		//  vulnerabilities headings
 		count := len(columnHeading) - len(headerRow)
-		colSpan := []int{
-			1,
-			1,
-		}
-		headerAlignment := []table.Alignment{
-			table.AlignLeft,
-			table.AlignLeft,
+		var colSpan []int
+		var headerAlignment []table.Alignment
+		for range fixed {
+			colSpan = append(colSpan, 1)
+			headerAlignment = append(headerAlignment, table.AlignLeft)
 		}
 		for i := 0; i < count; i++ {
 			headerRow = append(headerRow, s.SeverityHeadings...)
@@ -1,10 +1,15 @@
 package report
 
 import (
+	"bytes"
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 
 	"github.com/aquasecurity/trivy/pkg/types"
 )
@@ -98,3 +103,59 @@ func TestReport_ColumnHeading(t *testing.T) {
 		})
 	}
 }
+
+func TestSummaryWriter_Write_Diff(t *testing.T) {
+	before := Report{
+		Resources: []Resource{
+			deployOrionWithVulns,
+			{
+				Namespace: "default",
+				Kind:      "Pod",
+				Name:      "removed",
+				Results: types.Results{
+					{
+						Secrets: []types.DetectedSecret{
+							{
+								RuleID:   "aws-access-key-id",
+								Severity: "CRITICAL",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	after := Report{
+		Resources: []Resource{
+			{
+				Namespace: "default",
+				Kind:      "Deploy",
+				Name:      "orion",
+				Results: types.Results{
+					{
+						Vulnerabilities: slices.Concat(deployOrionWithVulns.Results[0].Vulnerabilities[1:],
+							[]types.DetectedVulnerability{
+								{
+									VulnerabilityID: "CVE-2023-0001",
+									Vulnerability:   dbTypes.Vulnerability{Severity: "HIGH"},
+								},
+							},
+						),
+					},
+				},
+			},
+		},
+	}
+
+	output := bytes.Buffer{}
+	writer := NewSummaryWriter(&output, []dbTypes.Severity{dbTypes.SeverityCritical, dbTypes.SeverityHigh},
+		[]string{NamespaceColumn, ResourceColumn, VulnerabilitiesColumn, SecretsColumn})
+	require.NoError(t, writer.Write(Diff(before, after)))
+
+	got := output.String()
+	assert.Contains(t, got, StatusColumn)
+	assert.Contains(t, got, "Deploy/orion")
+	assert.Contains(t, got, "changed (+1, -1)")
+	assert.Contains(t, got, "Pod/removed")
+	assert.NotContains(t, got, "added")
+}
@@ -17,6 +17,7 @@ type TableWriter struct {
 	Output        io.Writer
 	Severities    []dbTypes.Severity
 	ColumnHeading []string
+	MaxRows       int
 }
 
 const (
@@ -26,6 +27,7 @@ const (
 	MisconfigurationsColumn = "Misconfigurations"
 	SecretsColumn           = "Secrets"
 	RbacAssessmentColumn    = "RBAC Assessment"
+	StatusColumn            = "Status"
 )
 
 func WorkloadColumns() []string {
@@ -54,6 +56,7 @@ func (tw TableWriter) Write(ctx context.Context, report Report) error {
 		t := pkgReport.Writer{
 			Output:     tw.Output,
 			Severities: tw.Severities,
+			MaxRows:    tw.MaxRows,
 		}
 		for i, r := range report.Resources {
 			if r.Report.Results.Failed() {
@@ -80,6 +83,9 @@ func updateTargetContext(r *Resource) {
 	if r.Kind == "NodeComponents" || r.Kind == "NodeInfo" {
 		targetName = fmt.Sprintf("node: %s", r.Name)
 	}
+	if r.DiffStatus != "" {
+		targetName = fmt.Sprintf("%s (%s)", targetName, r.statusLabel())
+	}
 	for i := range r.Report.Results {
 		r.Report.Results[i].Target = targetName
 	}
@@ -0,0 +1,91 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestTableWriter_Write(t *testing.T) {
+	report := Report{
+		ClusterName: "test",
+		Resources: []Resource{
+			{
+				Namespace: "default",
+				Kind:      "Deployment",
+				Name:      "app",
+				Report: types.Report{
+					Results: types.Results{
+						{
+							Target: "app:1.0",
+							Class:  types.ClassOSPkg,
+							Type:   "alpine",
+							Vulnerabilities: []types.DetectedVulnerability{
+								{
+									VulnerabilityID:  "CVE-2024-0001",
+									PkgName:          "musl",
+									InstalledVersion: "1.2.3",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityCritical.String(),
+									},
+								},
+								{
+									VulnerabilityID:  "CVE-2024-0002",
+									PkgName:          "musl",
+									InstalledVersion: "1.2.3",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityHigh.String(),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		maxRows     int
+		wantOmitted bool
+	}{
+		{
+			name:        "unlimited",
+			wantOmitted: false,
+		},
+		{
+			name:        "max rows are applied to each resource",
+			maxRows:     1,
+			wantOmitted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := bytes.NewBuffer(nil)
+			tw := TableWriter{
+				Report:     AllReport,
+				Output:     output,
+				Severities: []dbTypes.Severity{dbTypes.SeverityCritical, dbTypes.SeverityHigh},
+				MaxRows:    tt.maxRows,
+			}
+			require.NoError(t, tw.Write(context.Background(), report))
+
+			got := output.String()
+			assert.Contains(t, got, "namespace: default, deployment: app")
+			assert.Contains(t, got, "CVE-2024-0001")
+			if tt.wantOmitted {
+				assert.NotContains(t, got, "CVE-2024-0002")
+				assert.Contains(t, got, "... 1 more rows omitted")
+			} else {
+				assert.Contains(t, got, "CVE-2024-0002")
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy-kubernetes/pkg/artifacts"
+	"github.com/aquasecurity/trivy/pkg/k8s/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// maxDeniedFindings is the maximum number of finding IDs listed in the message of denied requests
+const maxDeniedFindings = 10
+
+// podSpecPaths are the paths of pod specs in workload resources
+var podSpecPaths = [][]string{
+	// Pod
+	{"spec"},
+	// Deployment, ReplicaSet, StatefulSet, DaemonSet, Job and ReplicationController
+	{"spec", "template", "spec"},
+	// CronJob
+	{"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// ScanAdmissionReview scans the resource of the AdmissionReview request read from r,
+// and returns the report and the AdmissionReview response.
+// The request is denied if findings of the severities specified with "--severity" are detected.
+func (s *Scanner) ScanAdmissionReview(ctx context.Context, r io.Reader) (report.Report, *admissionv1.AdmissionReview, error) {
+	var review admissionv1.AdmissionReview
+	if err := json.NewDecoder(r).Decode(&review); err != nil {
+		return report.Report{}, nil, xerrors.Errorf("failed to decode the AdmissionReview: %w", err)
+	}
+	if review.Request == nil {
+		return report.Report{}, nil, xerrors.New("the AdmissionReview has no request")
+	}
+
+	artifact, err := admissionArtifact(review.Request)
+	if err != nil {
+		return report.Report{}, nil, xerrors.Errorf("invalid AdmissionReview request: %w", err)
+	}
+
+	// e.g. DELETE requests have no object to be scanned
+	var rpt report.Report
+	if artifact != nil {
+		rpt, err = s.Scan(ctx, []*artifacts.Artifact{artifact})
+		if err != nil {
+			return report.Report{}, nil, xerrors.Errorf("k8s scan error: %w", err)
+		}
+	}
+	return rpt, admissionResponse(review, rpt, s.opts.Severities), nil
+}
+
+// admissionArtifact converts the object of the request to an artifact, or returns nil if there is no object.
+func admissionArtifact(req *admissionv1.AdmissionRequest) (*artifacts.Artifact, error) {
+	if len(req.Object.Raw) == 0 {
+		return nil, nil
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return nil, xerrors.Errorf("failed to decode the object: %w", err)
+	}
+
+	u := unstructured.Unstructured{Object: obj}
+	// The name is not set yet for objects created with "generateName"
+	name := lo.CoalesceOrEmpty(req.Name, u.GetName(), u.GetGenerateName())
+	return &artifacts.Artifact{
+		Namespace:   lo.CoalesceOrEmpty(req.Namespace, u.GetNamespace()),
+		Kind:        lo.CoalesceOrEmpty(req.Kind.Kind, u.GetKind()),
+		Name:        name,
+		Images:      containerImages(obj),
+		RawResource: obj,
+	}, nil
+}
+
+// containerImages returns the images of the containers in the workload resource
+func containerImages(obj map[string]any) []string {
+	var images []string
+	for _, path := range podSpecPaths {
+		spec, found, err := unstructured.NestedMap(obj, path...)
+		if err != nil || !found {
+			continue
+		}
+		for _, field := range []string{"initContainers", "containers", "ephemeralContainers"} {
+			containers, _, _ := unstructured.NestedSlice(spec, field)
+			for _, c := range containers {
+				container, ok := c.(map[string]any)
+				if !ok {
+					continue
+				}
+				if image, _, _ := unstructured.NestedString(container, "image"); image != "" {
+					images = append(images, image)
+				}
+			}
+		}
+	}
+	return lo.Uniq(images)
+}
+
+// admissionResponse returns the AdmissionReview response for the request based on the report.
+// The report is already filtered by severities, so any remaining finding denies the request.
+func admissionResponse(review admissionv1.AdmissionReview, rpt report.Report, severities []dbTypes.Severity) *admissionv1.AdmissionReview {
+	resp := &admissionv1.AdmissionResponse{
+		UID:     review.Request.UID,
+		Allowed: true,
+	}
+
+	var ids []string
+	for _, res := range rpt.Resources {
+		// Scan errors, e.g. images that cannot be pulled, don't block the request
+		if res.Error != "" {
+			resp.Warnings = append(resp.Warnings, fmt.Sprintf("Trivy failed to scan %s/%s: %s", res.Kind, res.Name, res.Error))
+		}
+		ids = append(ids, findingIDs(res.Results)...)
+	}
+	ids = lo.Uniq(ids)
+
+	if len(ids) > 0 {
+		listed := ids[:min(len(ids), maxDeniedFindings)]
+		message := fmt.Sprintf("Trivy detected %d security issue(s) of the severities %s: %s", len(ids),
+			strings.Join(lo.Map(severities, func(s dbTypes.Severity, _ int) string { return s.String() }), ","),
+			strings.Join(listed, ", "))
+		if len(ids) > len(listed) {
+			message += fmt.Sprintf(" and %d more", len(ids)-len(listed))
+		}
+
+		resp.Allowed = false
+		resp.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: message,
+			Reason:  metav1.StatusReasonForbidden,
+			Code:    http.StatusForbidden,
+		}
+	}
+
+	typeMeta := review.TypeMeta
+	if typeMeta.APIVersion == "" {
+		typeMeta = metav1.TypeMeta{
+			APIVersion: admissionv1.SchemeGroupVersion.String(),
+			Kind:       "AdmissionReview",
+		}
+	}
+	return &admissionv1.AdmissionReview{
+		TypeMeta: typeMeta,
+		Response: resp,
+	}
+}
+
+func findingIDs(results types.Results) []string {
+	var ids []string
+	for _, result := range results {
+		for _, vuln := range result.Vulnerabilities {
+			ids = append(ids, vuln.VulnerabilityID)
+		}
+		for _, misconf := range result.Misconfigurations {
+			if misconf.Status == types.MisconfStatusFailure {
+				ids = append(ids, misconf.ID)
+			}
+		}
+		for _, secret := range result.Secrets {
+			ids = append(ids, secret.RuleID)
+		}
+		for _, license := range result.Licenses {
+			ids = append(ids, license.Name)
+		}
+	}
+	return ids
+}
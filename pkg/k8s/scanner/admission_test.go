@@ -0,0 +1,280 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy-kubernetes/pkg/artifacts"
+	"github.com/aquasecurity/trivy/pkg/k8s/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func Test_admissionArtifact(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     admissionv1.AdmissionRequest
+		want    *artifacts.Artifact
+		wantErr string
+	}{
+		{
+			name: "deployment",
+			req: admissionv1.AdmissionRequest{
+				Kind:      metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+				Name:      "app",
+				Namespace: "default",
+				Object: runtime.RawExtension{
+					Raw: []byte(`{"kind":"Deployment","metadata":{"name":"app"},"spec":{"template":{"spec":{"initContainers":[{"image":"busybox:1.36"}],"containers":[{"image":"nginx:1.27"},{"image":"nginx:1.27"}]}}}}`),
+				},
+			},
+			want: &artifacts.Artifact{
+				Namespace: "default",
+				Kind:      "Deployment",
+				Name:      "app",
+				Images: []string{
+					"busybox:1.36",
+					"nginx:1.27",
+				},
+				RawResource: map[string]any{
+					"kind": "Deployment",
+					"metadata": map[string]any{
+						"name": "app",
+					},
+					"spec": map[string]any{
+						"template": map[string]any{
+							"spec": map[string]any{
+								"initContainers": []any{
+									map[string]any{"image": "busybox:1.36"},
+								},
+								"containers": []any{
+									map[string]any{"image": "nginx:1.27"},
+									map[string]any{"image": "nginx:1.27"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "pod with generateName",
+			req: admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+				Object: runtime.RawExtension{
+					Raw: []byte(`{"kind":"Pod","metadata":{"generateName":"app-","namespace":"test"},"spec":{"containers":[{"image":"alpine:3.20"}]}}`),
+				},
+			},
+			want: &artifacts.Artifact{
+				Namespace: "test",
+				Kind:      "Pod",
+				Name:      "app-",
+				Images:    []string{"alpine:3.20"},
+				RawResource: map[string]any{
+					"kind": "Pod",
+					"metadata": map[string]any{
+						"generateName": "app-",
+						"namespace":    "test",
+					},
+					"spec": map[string]any{
+						"containers": []any{
+							map[string]any{"image": "alpine:3.20"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "no object",
+			req: admissionv1.AdmissionRequest{
+				Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+				Name:      "app",
+				Operation: admissionv1.Delete,
+			},
+			want: nil,
+		},
+		{
+			name: "invalid object",
+			req: admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{
+					Raw: []byte(`[]`),
+				},
+			},
+			wantErr: "failed to decode the object",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := admissionArtifact(&tt.req)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_containerImages(t *testing.T) {
+	cronJob := map[string]any{
+		"kind": "CronJob",
+		"spec": map[string]any{
+			"jobTemplate": map[string]any{
+				"spec": map[string]any{
+					"template": map[string]any{
+						"spec": map[string]any{
+							"containers": []any{
+								map[string]any{"image": "alpine:3.20"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, []string{"alpine:3.20"}, containerImages(cronJob))
+	assert.Empty(t, containerImages(map[string]any{"kind": "ConfigMap"}))
+}
+
+func Test_admissionResponse(t *testing.T) {
+	review := admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "admission.k8s.io/v1",
+			Kind:       "AdmissionReview",
+		},
+		Request: &admissionv1.AdmissionRequest{
+			UID: "705ab4f5-6393-11e8-b7cc-42010a800002",
+		},
+	}
+	severities := []dbTypes.Severity{
+		dbTypes.SeverityHigh,
+		dbTypes.SeverityCritical,
+	}
+
+	tests := []struct {
+		name   string
+		review admissionv1.AdmissionReview
+		report report.Report
+		want   *admissionv1.AdmissionResponse
+	}{
+		{
+			name:   "allowed",
+			review: review,
+			report: report.Report{
+				Resources: []report.Resource{
+					{
+						Kind: "Deployment",
+						Name: "app",
+					},
+				},
+			},
+			want: &admissionv1.AdmissionResponse{
+				UID:     "705ab4f5-6393-11e8-b7cc-42010a800002",
+				Allowed: true,
+			},
+		},
+		{
+			name:   "denied",
+			review: review,
+			report: report.Report{
+				Resources: []report.Resource{
+					{
+						Kind: "Deployment",
+						Name: "app",
+						Results: types.Results{
+							{
+								Target: "nginx:1.27 (debian 12.7)",
+								Vulnerabilities: []types.DetectedVulnerability{
+									{VulnerabilityID: "CVE-2024-0001"},
+									{VulnerabilityID: "CVE-2024-0002"},
+									{VulnerabilityID: "CVE-2024-0001"},
+								},
+							},
+							{
+								Target: "Deployment/app",
+								Misconfigurations: []types.DetectedMisconfiguration{
+									{
+										ID:     "KSV017",
+										Status: types.MisconfStatusFailure,
+									},
+									{
+										ID:     "KSV001",
+										Status: types.MisconfStatusPassed,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &admissionv1.AdmissionResponse{
+				UID:     "705ab4f5-6393-11e8-b7cc-42010a800002",
+				Allowed: false,
+				Result: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: "Trivy detected 3 security issue(s) of the severities HIGH,CRITICAL: CVE-2024-0001, CVE-2024-0002, KSV017",
+					Reason:  metav1.StatusReasonForbidden,
+					Code:    403,
+				},
+			},
+		},
+		{
+			name:   "scan error",
+			review: review,
+			report: report.Report{
+				Resources: []report.Resource{
+					{
+						Kind:  "Pod",
+						Name:  "app",
+						Error: "unable to pull the image",
+					},
+				},
+			},
+			want: &admissionv1.AdmissionResponse{
+				UID:     "705ab4f5-6393-11e8-b7cc-42010a800002",
+				Allowed: true,
+				Warnings: []string{
+					"Trivy failed to scan Pod/app: unable to pull the image",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := admissionResponse(tt.review, tt.report, severities)
+			assert.Equal(t, tt.review.TypeMeta, got.TypeMeta)
+			assert.Nil(t, got.Request)
+			assert.Equal(t, tt.want, got.Response)
+		})
+	}
+
+	t.Run("too many findings", func(t *testing.T) {
+		var vulns []types.DetectedVulnerability
+		for i := range 12 {
+			vulns = append(vulns, types.DetectedVulnerability{VulnerabilityID: "CVE-2024-" + string(rune('A'+i))})
+		}
+		rpt := report.Report{
+			Resources: []report.Resource{
+				{Results: types.Results{{Vulnerabilities: vulns}}},
+			},
+		}
+		got := admissionResponse(review, rpt, severities)
+		require.NotNil(t, got.Response.Result)
+		assert.Equal(t, "Trivy detected 12 security issue(s) of the severities HIGH,CRITICAL: "+
+			"CVE-2024-A, CVE-2024-B, CVE-2024-C, CVE-2024-D, CVE-2024-E, CVE-2024-F, CVE-2024-G, CVE-2024-H, CVE-2024-I, CVE-2024-J and 2 more",
+			got.Response.Result.Message)
+	})
+
+	t.Run("default type meta", func(t *testing.T) {
+		got := admissionResponse(admissionv1.AdmissionReview{Request: review.Request}, report.Report{}, severities)
+		assert.Equal(t, metav1.TypeMeta{
+			APIVersion: "admission.k8s.io/v1",
+			Kind:       "AdmissionReview",
+		}, got.TypeMeta)
+	})
+}
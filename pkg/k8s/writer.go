@@ -36,6 +36,7 @@ func Write(ctx context.Context, k8sreport report.Report, option report.Option) e
 				Report:        option.Report,
 				Severities:    option.Severities,
 				ColumnHeading: report.ColumnHeading(option.Scanners, r.Columns),
+				MaxRows:       option.MaxRows,
 			}
 
 			if err := writer.Write(ctx, r.Report); err != nil {
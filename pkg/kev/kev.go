@@ -0,0 +1,100 @@
+package kev
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/feed"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// DefaultSource is the Known Exploited Vulnerabilities catalog published by CISA.
+// cf. https://www.cisa.gov/known-exploited-vulnerabilities-catalog
+const DefaultSource = "https://www.cisa.gov/sites/default/files/feeds/known_exploited_vulnerabilities.json"
+
+// The KEV catalog is cached in the cache dir and checked for updates once a day
+var dataFeed = feed.Feed{
+	Name:           "KEV catalog",
+	Dir:            "kev",
+	FileName:       "known_exploited_vulnerabilities.json",
+	UpdateInterval: 24 * time.Hour,
+}
+
+// Catalog holds the KEV entries keyed by CVE ID
+type Catalog map[string]types.KEV
+
+// catalogFile represents the JSON file of the catalog
+type catalogFile struct {
+	Vulnerabilities []struct {
+		CVEID                      string `json:"cveID"`
+		DateAdded                  string `json:"dateAdded"`
+		DueDate                    string `json:"dueDate"`
+		KnownRansomwareCampaignUse string `json:"knownRansomwareCampaignUse"`
+	} `json:"vulnerabilities"`
+}
+
+// Load reads the KEV catalog from the source, which is either a URL or a path to a local file.
+// The catalog is the JSON file published by CISA.
+// A dataset on the Internet is cached in the cache dir, and only the cached copy is used in offline mode.
+func Load(ctx context.Context, source string, opts feed.Options) (Catalog, error) {
+	if !strings.HasPrefix(source, "https://") && !strings.HasPrefix(source, "http://") {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to open the KEV catalog: %w", err)
+		}
+		defer f.Close()
+		return Parse(f)
+	}
+
+	f, err := dataFeed.Open(ctx, source, opts)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to fetch the KEV catalog: %w", err)
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse parses the KEV catalog in JSON.
+//
+//	{
+//	  "vulnerabilities": [
+//	    {"cveID": "CVE-2021-44228", "dateAdded": "2021-12-10", "dueDate": "2021-12-24", "knownRansomwareCampaignUse": "Known", ...}
+//	  ]
+//	}
+func Parse(r io.Reader) (Catalog, error) {
+	var file catalogFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, xerrors.Errorf("failed to parse the KEV catalog: %w", err)
+	}
+
+	catalog := make(Catalog)
+	for _, v := range file.Vulnerabilities {
+		if v.CVEID == "" {
+			return nil, xerrors.New("invalid KEV catalog: empty CVE ID")
+		}
+		catalog[v.CVEID] = types.KEV{
+			DateAdded:  v.DateAdded,
+			DueDate:    v.DueDate,
+			Ransomware: v.KnownRansomwareCampaignUse == "Known",
+		}
+	}
+	return catalog, nil
+}
+
+// Fill sets the KEV entry of the vulnerabilities that are in the catalog
+func (c Catalog) Fill(results types.Results) {
+	for i := range results {
+		for j := range results[i].Vulnerabilities {
+			vuln := &results[i].Vulnerabilities[j]
+			if entry, ok := c[vuln.VulnerabilityID]; ok {
+				vuln.KEV = &entry
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package kev_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/feed"
+	"github.com/aquasecurity/trivy/pkg/kev"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestLoad(t *testing.T) {
+	want := kev.Catalog{
+		"CVE-2021-44228": {
+			DateAdded:  "2021-12-10",
+			DueDate:    "2021-12-24",
+			Ransomware: true,
+		},
+		"CVE-2022-22965": {
+			DateAdded: "2022-04-04",
+			DueDate:   "2022-04-25",
+		},
+	}
+
+	ts := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	t.Cleanup(ts.Close)
+
+	tests := []struct {
+		name    string
+		source  string
+		want    kev.Catalog
+		wantErr string
+	}{
+		{
+			name:   "local file",
+			source: "testdata/known_exploited_vulnerabilities.json",
+			want:   want,
+		},
+		{
+			name:   "fetch",
+			source: ts.URL + "/known_exploited_vulnerabilities.json",
+			want:   want,
+		},
+		{
+			name:    "not found",
+			source:  ts.URL + "/missing.json",
+			wantErr: "bad response code: 404",
+		},
+		{
+			name:    "missing file",
+			source:  "testdata/missing.json",
+			wantErr: "failed to open the KEV catalog",
+		},
+		{
+			name:    "invalid catalog",
+			source:  "testdata/invalid.json",
+			wantErr: "empty CVE ID",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := kev.Load(context.Background(), tt.source, feed.Options{CacheDir: t.TempDir()})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCatalog_Fill(t *testing.T) {
+	catalog := kev.Catalog{
+		"CVE-2021-44228": {
+			DateAdded:  "2021-12-10",
+			DueDate:    "2021-12-24",
+			Ransomware: true,
+		},
+	}
+	results := types.Results{
+		{
+			Vulnerabilities: []types.DetectedVulnerability{
+				{VulnerabilityID: "CVE-2021-44228"},
+				{VulnerabilityID: "CVE-2019-0001"},
+			},
+		},
+	}
+
+	catalog.Fill(results)
+	assert.Equal(t, &types.KEV{
+		DateAdded:  "2021-12-10",
+		DueDate:    "2021-12-24",
+		Ransomware: true,
+	}, results[0].Vulnerabilities[0].KEV)
+	assert.Nil(t, results[0].Vulnerabilities[1].KEV)
+}
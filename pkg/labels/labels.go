@@ -0,0 +1,146 @@
+package labels
+
+import (
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// Rules holds the rules attaching labels to findings.
+//
+//	rules:
+//	  - targets: ["services/payment/**"]
+//	    severities: [CRITICAL, HIGH]
+//	    labels:
+//	      team: payment
+//	      sla: 7d
+type Rules []Rule
+
+// Rule attaches the labels to findings matching all the criteria.
+// A criterion matches if any of its values matches, and an empty criterion matches any finding.
+type Rule struct {
+	// Targets is the list of glob patterns matching the target, e.g. "**/package-lock.json"
+	Targets []string `yaml:"targets"`
+
+	// Packages is the list of glob patterns matching the package name, e.g. "libssl*".
+	// Findings without a package, such as misconfigurations and secrets, never match it.
+	Packages []string `yaml:"packages"`
+
+	// Severities is the list of severities, e.g. "CRITICAL"
+	Severities []string `yaml:"severities"`
+
+	// Labels are attached to the matching findings
+	Labels map[string]string `yaml:"labels"`
+}
+
+type rulesFile struct {
+	Rules Rules `yaml:"rules"`
+}
+
+// Load reads the rules from the YAML file.
+func Load(path string) (Rules, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to open the labels file: %w", err)
+	}
+	defer f.Close()
+
+	var file rulesFile
+	if err = yaml.NewDecoder(f).Decode(&file); err != nil {
+		return nil, xerrors.Errorf("failed to decode the labels file: %w", err)
+	}
+
+	for i, rule := range file.Rules {
+		if err = rule.validate(); err != nil {
+			return nil, xerrors.Errorf("invalid rule #%d in %s: %w", i+1, path, err)
+		}
+	}
+	return file.Rules, nil
+}
+
+func (r Rule) validate() error {
+	if len(r.Labels) == 0 {
+		return xerrors.New("no labels")
+	}
+	for _, pattern := range slices.Concat(r.Targets, r.Packages) {
+		if !doublestar.ValidatePattern(pattern) {
+			return xerrors.Errorf("invalid pattern: %s", pattern)
+		}
+	}
+	for _, severity := range r.Severities {
+		if _, err := dbTypes.NewSeverity(severity); err != nil {
+			return xerrors.Errorf("invalid severity: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r Rule) match(target, pkgName, severity string) bool {
+	if len(r.Targets) > 0 && !matchAny(r.Targets, target) {
+		return false
+	}
+	if len(r.Packages) > 0 && (pkgName == "" || !matchAny(r.Packages, pkgName)) {
+		return false
+	}
+	if len(r.Severities) > 0 && !slices.Contains(r.Severities, severity) {
+		return false
+	}
+	return true
+}
+
+func matchAny(patterns []string, s string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		// Patterns are validated on load
+		ok, _ := doublestar.Match(pattern, s)
+		return ok
+	})
+}
+
+// labels returns the labels of all the matching rules.
+// When several rules set the same key, the later rule wins.
+func (rs Rules) labels(target, pkgName, severity string) map[string]string {
+	var labels map[string]string
+	for _, rule := range rs {
+		if !rule.match(target, pkgName, severity) {
+			continue
+		}
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		maps.Copy(labels, rule.Labels)
+	}
+	return labels
+}
+
+// Apply attaches the labels to the findings in the results.
+func (rs Rules) Apply(results types.Results) {
+	if len(rs) == 0 {
+		return
+	}
+	for i := range results {
+		result := &results[i]
+		for j := range result.Vulnerabilities {
+			v := &result.Vulnerabilities[j]
+			v.Labels = rs.labels(result.Target, v.PkgName, v.Severity)
+		}
+		for j := range result.Misconfigurations {
+			m := &result.Misconfigurations[j]
+			m.Labels = rs.labels(result.Target, "", m.Severity)
+		}
+		for j := range result.Secrets {
+			s := &result.Secrets[j]
+			s.Labels = rs.labels(result.Target, "", s.Severity)
+		}
+		for j := range result.Licenses {
+			l := &result.Licenses[j]
+			l.Labels = rs.labels(result.Target, l.PkgName, l.Severity)
+		}
+	}
+}
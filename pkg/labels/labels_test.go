@@ -0,0 +1,191 @@
+package labels_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/labels"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    labels.Rules
+		wantErr string
+	}{
+		{
+			name: "happy path",
+			path: "testdata/labels.yaml",
+			want: labels.Rules{
+				{
+					Targets: []string{"services/payment/**"},
+					Labels: map[string]string{
+						"team": "payment",
+						"sla":  "30d",
+					},
+				},
+				{
+					Severities: []string{
+						"CRITICAL",
+						"HIGH",
+					},
+					Labels: map[string]string{
+						"sla": "7d",
+					},
+				},
+				{
+					Targets: []string{"services/**"},
+					Packages: []string{
+						"libssl*",
+						"openssl",
+					},
+					Severities: []string{"CRITICAL"},
+					Labels: map[string]string{
+						"owner": "security",
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid severity",
+			path:    "testdata/invalid-severity.yaml",
+			wantErr: "invalid rule #1 in testdata/invalid-severity.yaml: invalid severity: unknown severity: critical",
+		},
+		{
+			name:    "invalid pattern",
+			path:    "testdata/invalid-pattern.yaml",
+			wantErr: "invalid rule #1 in testdata/invalid-pattern.yaml: invalid pattern: libssl[",
+		},
+		{
+			name:    "no labels",
+			path:    "testdata/no-labels.yaml",
+			wantErr: "invalid rule #1 in testdata/no-labels.yaml: no labels",
+		},
+		{
+			name:    "no such file",
+			path:    "testdata/missing.yaml",
+			wantErr: "failed to open the labels file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := labels.Load(tt.path)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRules_Apply(t *testing.T) {
+	rules, err := labels.Load("testdata/labels.yaml")
+	require.NoError(t, err)
+
+	results := types.Results{
+		{
+			Target: "services/payment/package-lock.json",
+			Class:  types.ClassLangPkg,
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "CVE-2024-0001",
+					PkgName:         "express",
+					Vulnerability: dbTypes.Vulnerability{
+						Severity: "HIGH",
+					},
+				},
+				{
+					VulnerabilityID: "CVE-2024-0002",
+					PkgName:         "qs",
+					Vulnerability: dbTypes.Vulnerability{
+						Severity: "LOW",
+					},
+				},
+			},
+			Licenses: []types.DetectedLicense{
+				{
+					Name:     "GPL-3.0-only",
+					PkgName:  "express",
+					Severity: "HIGH",
+				},
+			},
+		},
+		{
+			Target: "services/api/rootfs",
+			Class:  types.ClassOSPkg,
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "CVE-2024-0003",
+					PkgName:         "libssl3",
+					Vulnerability: dbTypes.Vulnerability{
+						Severity: "CRITICAL",
+					},
+				},
+				{
+					VulnerabilityID: "CVE-2024-0004",
+					PkgName:         "zlib",
+					Vulnerability: dbTypes.Vulnerability{
+						Severity: "MEDIUM",
+					},
+				},
+			},
+		},
+		{
+			Target: "services/api/Dockerfile",
+			Class:  types.ClassConfig,
+			Misconfigurations: []types.DetectedMisconfiguration{
+				{
+					ID:       "DS002",
+					Severity: "CRITICAL",
+				},
+			},
+		},
+		{
+			Target: "config/.env",
+			Class:  types.ClassSecret,
+			Secrets: []types.DetectedSecret{
+				{
+					RuleID:   "aws-access-key-id",
+					Severity: "CRITICAL",
+				},
+			},
+		},
+	}
+	rules.Apply(results)
+
+	// Labels of all the matching rules are accumulated, and the later rule wins for the same key
+	assert.Equal(t, map[string]string{
+		"team": "payment",
+		"sla":  "7d",
+	}, results[0].Vulnerabilities[0].Labels)
+	assert.Equal(t, map[string]string{
+		"team": "payment",
+		"sla":  "30d",
+	}, results[0].Vulnerabilities[1].Labels)
+	assert.Equal(t, map[string]string{
+		"team": "payment",
+		"sla":  "7d",
+	}, results[0].Licenses[0].Labels)
+
+	// All the criteria of a rule must match
+	assert.Equal(t, map[string]string{
+		"sla":   "7d",
+		"owner": "security",
+	}, results[1].Vulnerabilities[0].Labels)
+	assert.Nil(t, results[1].Vulnerabilities[1].Labels)
+
+	// Findings without a package never match rules with packages
+	assert.Equal(t, map[string]string{
+		"sla": "7d",
+	}, results[2].Misconfigurations[0].Labels)
+	assert.Equal(t, map[string]string{
+		"sla": "7d",
+	}, results[3].Secrets[0].Labels)
+}
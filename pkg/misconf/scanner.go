@@ -28,6 +28,7 @@ import (
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/helm"
 	k8sscanner "github.com/aquasecurity/trivy/pkg/iac/scanners/kubernetes"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
+	systemdscanner "github.com/aquasecurity/trivy/pkg/iac/scanners/systemd"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/terraform"
 	tfprawscanner "github.com/aquasecurity/trivy/pkg/iac/scanners/terraformplan/snapshot"
 	tfpjsonscanner "github.com/aquasecurity/trivy/pkg/iac/scanners/terraformplan/tfjson"
@@ -46,6 +47,7 @@ var enablediacTypes = map[detection.FileType]types.ConfigType{
 	detection.FileTypeHelm:                  types.Helm,
 	detection.FileTypeTerraformPlanJSON:     types.TerraformPlanJSON,
 	detection.FileTypeTerraformPlanSnapshot: types.TerraformPlanSnapshot,
+	detection.FileTypeSystemd:               types.Systemd,
 	detection.FileTypeJSON:                  types.JSON,
 	detection.FileTypeYAML:                  types.YAML,
 }
@@ -58,6 +60,7 @@ type DisabledCheck struct {
 
 type ScannerOption struct {
 	Trace                    bool
+	PolicySource             bool
 	RegoOnly                 bool
 	Namespaces               []string
 	PolicyPaths              []string
@@ -122,6 +125,8 @@ func NewScanner(t detection.FileType, opt ScannerOption) (*Scanner, error) {
 		scanner = tfpjsonscanner.New(opts...)
 	case detection.FileTypeTerraformPlanSnapshot:
 		scanner = tfprawscanner.New(opts...)
+	case detection.FileTypeSystemd:
+		scanner = systemdscanner.NewScanner(opts...)
 	case detection.FileTypeYAML:
 		scanner = generic.NewYamlScanner(opts...)
 	case detection.FileTypeJSON:
@@ -258,6 +263,10 @@ func scannerOptions(t detection.FileType, opt ScannerOption) ([]options.ScannerO
 		opts = append(opts, rego.WithPerResultTracing(true))
 	}
 
+	if opt.PolicySource {
+		opts = append(opts, rego.WithPolicySource(true))
+	}
+
 	if opt.RegoOnly {
 		opts = append(opts, options.ScannerWithRegoOnly(true))
 	}
@@ -471,6 +480,7 @@ func ResultsToMisconf(configType types.ConfigType, scannerName string, results s
 			},
 			CauseMetadata: cause,
 			Traces:        result.Traces(),
+			PolicySource:  result.RegoSource(),
 		}
 
 		filePath := flattened.Location.Filename
@@ -132,6 +132,19 @@ func TestScanner_Scan(t *testing.T) {
 			wantFileType:     types.Dockerfile,
 			misconfsExpected: 1,
 		},
+		{
+			name:     "happy path. systemd unit file",
+			fileType: detection.FileTypeSystemd,
+			files: []file{
+				{
+					path:    "example.service",
+					content: []byte("[Service]\nExecStart=/usr/bin/example\nUser=root\n"),
+				},
+			},
+			wantFilePath:     "example.service",
+			wantFileType:     types.Systemd,
+			misconfsExpected: 1,
+		},
 		{
 			name:     "happy path. terraform plan file",
 			fileType: detection.FileTypeTerraformPlanJSON,
@@ -125,6 +125,39 @@ func Referrers(ctx context.Context, d name.Digest, option types.RegistryOptions)
 	return nil, errs
 }
 
+// Platforms returns the platforms of the images in the manifest list, e.g. linux/amd64 and linux/arm64,
+// or nil if the image is not multi-arch.
+// Entries without a platform, such as attestation manifests with "unknown/unknown", are skipped.
+func Platforms(ctx context.Context, ref name.Reference, option types.RegistryOptions) ([]types.Platform, error) {
+	// Get the manifest list itself rather than the image for the platform
+	option.Platform = types.Platform{}
+	desc, err := Get(ctx, ref, option)
+	if err != nil {
+		return nil, xerrors.Errorf("image get error: %w", err)
+	}
+	if !desc.MediaType.IsIndex() {
+		return nil, nil
+	}
+
+	index, err := desc.ImageIndex()
+	if err != nil {
+		return nil, xerrors.Errorf("image index error: %w", err)
+	}
+	m, err := index.IndexManifest()
+	if err != nil {
+		return nil, xerrors.Errorf("remote index manifest error: %w", err)
+	}
+
+	var platforms []types.Platform
+	for _, manifest := range m.Manifests {
+		if manifest.Platform == nil || manifest.Platform.OS == "unknown" || !manifest.MediaType.IsImage() {
+			continue
+		}
+		platforms = append(platforms, types.Platform{Platform: manifest.Platform})
+	}
+	return platforms, nil
+}
+
 func httpTransport(option types.RegistryOptions) (http.RoundTripper, error) {
 	d := &net.Dialer{
 		Timeout: 10 * time.Minute,
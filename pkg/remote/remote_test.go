@@ -12,7 +12,12 @@ import (
 	"testing"
 
 	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -212,6 +217,101 @@ func TestGet(t *testing.T) {
 	}
 }
 
+// setupMultiPlatformRegistry pushes a manifest list of two platforms with an attestation manifest,
+// and a single-arch image.
+func setupMultiPlatformRegistry(t *testing.T) *httptest.Server {
+	tr := httptest.NewServer(ggcrregistry.New())
+	t.Cleanup(tr.Close)
+	serverAddr := tr.Listener.Addr().String()
+
+	var adds []mutate.IndexAddendum
+	for _, platform := range []v1.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64", Variant: "v8"},
+		{OS: "unknown", Architecture: "unknown"}, // attestation
+	} {
+		img, err := random.Image(100, 1)
+		require.NoError(t, err)
+		adds = append(adds, mutate.IndexAddendum{
+			Add: img,
+			Descriptor: v1.Descriptor{
+				Platform: &platform,
+			},
+		})
+	}
+	index := mutate.AppendManifests(empty.Index, adds...)
+
+	ref, err := name.ParseReference(fmt.Sprintf("%s/library/multi:latest", serverAddr))
+	require.NoError(t, err)
+	require.NoError(t, remote.WriteIndex(ref, index))
+
+	img, err := random.Image(100, 1)
+	require.NoError(t, err)
+	ref, err = name.ParseReference(fmt.Sprintf("%s/library/single:latest", serverAddr))
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+
+	return tr
+}
+
+func TestPlatforms(t *testing.T) {
+	tr := setupMultiPlatformRegistry(t)
+	serverAddr := tr.Listener.Addr().String()
+
+	tests := []struct {
+		name      string
+		imageName string
+		want      []string
+		wantErr   string
+	}{
+		{
+			name:      "manifest list",
+			imageName: fmt.Sprintf("%s/library/multi:latest", serverAddr),
+			want: []string{
+				"linux/amd64",
+				"linux/arm64/v8",
+			},
+		},
+		{
+			name:      "single-arch image",
+			imageName: fmt.Sprintf("%s/library/single:latest", serverAddr),
+		},
+		{
+			name:      "not found",
+			imageName: fmt.Sprintf("%s/library/missing:latest", serverAddr),
+			wantErr:   "image get error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := name.ParseReference(tt.imageName)
+			require.NoError(t, err)
+
+			got, err := Platforms(context.Background(), n, types.RegistryOptions{
+				Insecure: true,
+				// The platform is ignored to get the manifest list
+				Platform: types.Platform{
+					Platform: &v1.Platform{
+						OS:           "linux",
+						Architecture: "amd64",
+					},
+				},
+			})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var platforms []string
+			for _, p := range got {
+				platforms = append(platforms, p.String())
+			}
+			assert.Equal(t, tt.want, platforms)
+		})
+	}
+}
+
 type userAgentsTrackingHandler struct {
 	hr http.Handler
 
@@ -0,0 +1,42 @@
+package report
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy-db/pkg/db"
+	"github.com/aquasecurity/trivy/pkg/detector/library"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// fillAffectedRanges sets the vulnerable versions in the advisories to the vulnerabilities of language-specific packages.
+// The advisories are looked up in the vulnerability DB, which is not available in client mode or "trivy convert".
+// OS packages are left blank as their advisories have only fixed versions.
+func fillAffectedRanges(ctx context.Context, results types.Results) error {
+	if (db.Config{}).Connection() == nil {
+		log.WarnContext(ctx, `"--show-affected-range" requires the local vulnerability DB and is ignored`)
+		return nil
+	}
+
+	for i := range results {
+		result := &results[i]
+		if result.Class != types.ClassLangPkg {
+			continue
+		}
+		driver, ok := library.NewDriver(result.Type)
+		if !ok {
+			continue
+		}
+		for j := range result.Vulnerabilities {
+			vuln := &result.Vulnerabilities[j]
+			r, err := driver.AffectedRange(vuln.PkgName, vuln.VulnerabilityID)
+			if err != nil {
+				return xerrors.Errorf("failed to get the affected range of %s: %w", vuln.VulnerabilityID, err)
+			}
+			vuln.AffectedRange = r
+		}
+	}
+	return nil
+}
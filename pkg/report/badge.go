@@ -0,0 +1,147 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const badgeLabel = "trivy"
+
+// badgeColors maps severities to the named colors of shields.io.
+// The badge is green when nothing is found.
+var badgeColors = map[string]string{
+	dbTypes.SeverityCritical.String(): "red",
+	dbTypes.SeverityHigh.String():     "orange",
+	dbTypes.SeverityMedium.String():   "yellow",
+	dbTypes.SeverityLow.String():      "yellowgreen",
+	dbTypes.SeverityUnknown.String():  "lightgrey",
+	"":                                "brightgreen",
+}
+
+// badgeHexColors is the same palette as shields.io so that static badges look like the hosted ones.
+var badgeHexColors = map[string]string{
+	"red":         "#e05d44",
+	"orange":      "#fe7d37",
+	"yellow":      "#dfb317",
+	"yellowgreen": "#a4a61d",
+	"lightgrey":   "#9f9f9f",
+	"brightgreen": "#4c1",
+}
+
+// badge represents the response of the shields.io endpoint badge
+// cf. https://shields.io/badges/endpoint-badge
+type badge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// BadgeWriter writes a badge summarizing the findings, colored by the most severe one.
+type BadgeWriter struct {
+	Output io.Writer
+
+	// SVG writes a self-contained SVG image instead of the JSON response of the shields.io endpoint
+	SVG bool
+
+	// Order of severities from the lowest to the highest (dbTypes.SeverityNames by default)
+	Order []string
+}
+
+func (bw BadgeWriter) Write(_ context.Context, report types.Report) error {
+	b := newBadge(report.Results, bw.Order)
+	if bw.SVG {
+		if _, err := io.WriteString(bw.Output, b.svg()); err != nil {
+			return xerrors.Errorf("failed to write the badge: %w", err)
+		}
+		return nil
+	}
+
+	output, err := json.Marshal(b)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal the badge: %w", err)
+	}
+	if _, err = fmt.Fprintln(bw.Output, string(output)); err != nil {
+		return xerrors.Errorf("failed to write the badge: %w", err)
+	}
+	return nil
+}
+
+// newBadge returns the badge with the number of findings per severity from the most severe, e.g. "1 critical, 2 high".
+func newBadge(results types.Results, order []string) badge {
+	counts := make(map[string]int)
+	for _, result := range results {
+		for _, severity := range findingSeverities(result) {
+			counts[severity]++
+		}
+	}
+
+	if len(order) == 0 {
+		order = dbTypes.SeverityNames
+	}
+	var worst string
+	var messages []string
+	for i := len(order) - 1; i >= 0; i-- {
+		severity := order[i]
+		if counts[severity] == 0 {
+			continue
+		}
+		if worst == "" {
+			worst = severity
+		}
+		messages = append(messages, fmt.Sprintf("%d %s", counts[severity], strings.ToLower(severity)))
+	}
+
+	message := "no findings"
+	if len(messages) > 0 {
+		message = strings.Join(messages, ", ")
+	}
+	return badge{
+		SchemaVersion: 1,
+		Label:         badgeLabel,
+		Message:       message,
+		Color:         badgeColors[worst],
+	}
+}
+
+// svg renders the badge in the flat style of shields.io.
+// Text widths are estimated as fonts are not available here.
+func (b badge) svg() string {
+	textWidth := func(s string) int {
+		return utf8.RuneCountInString(s)*7 + 10
+	}
+	labelWidth, messageWidth := textWidth(b.Label), textWidth(b.Message)
+	width := labelWidth + messageWidth
+	label, message := html.EscapeString(b.Label), html.EscapeString(b.Message)
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[4]s: %[5]s">
+  <title>%[4]s: %[5]s</title>
+  <linearGradient id="s" x2="0" y2="100%%">
+    <stop offset="0" stop-color="#bbb" stop-opacity=".1"/>
+    <stop offset="1" stop-opacity=".1"/>
+  </linearGradient>
+  <clipPath id="r">
+    <rect width="%[1]d" height="20" rx="3" fill="#fff"/>
+  </clipPath>
+  <g clip-path="url(#r)">
+    <rect width="%[2]d" height="20" fill="#555"/>
+    <rect x="%[2]d" width="%[3]d" height="20" fill="%[6]s"/>
+    <rect width="%[1]d" height="20" fill="url(#s)"/>
+  </g>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+    <text x="%[7]d" y="14">%[4]s</text>
+    <text x="%[8]d" y="14">%[5]s</text>
+  </g>
+</svg>
+`, width, labelWidth, messageWidth, label, message, badgeHexColors[b.Color], labelWidth/2, labelWidth+messageWidth/2)
+}
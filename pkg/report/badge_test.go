@@ -0,0 +1,165 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+type badge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+func TestBadgeWriter_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		severities []string
+		order      []string
+		want       badge
+	}{
+		{
+			name:       "critical",
+			severities: []string{"LOW", "CRITICAL", "HIGH", "CRITICAL"},
+			want: badge{
+				SchemaVersion: 1,
+				Label:         "trivy",
+				Message:       "2 critical, 1 high, 1 low",
+				Color:         "red",
+			},
+		},
+		{
+			name:       "high",
+			severities: []string{"HIGH", "MEDIUM"},
+			want: badge{
+				SchemaVersion: 1,
+				Label:         "trivy",
+				Message:       "1 high, 1 medium",
+				Color:         "orange",
+			},
+		},
+		{
+			name:       "medium",
+			severities: []string{"MEDIUM"},
+			want: badge{
+				SchemaVersion: 1,
+				Label:         "trivy",
+				Message:       "1 medium",
+				Color:         "yellow",
+			},
+		},
+		{
+			name:       "low",
+			severities: []string{"LOW"},
+			want: badge{
+				SchemaVersion: 1,
+				Label:         "trivy",
+				Message:       "1 low",
+				Color:         "yellowgreen",
+			},
+		},
+		{
+			name:       "unknown",
+			severities: []string{"UNKNOWN"},
+			want: badge{
+				SchemaVersion: 1,
+				Label:         "trivy",
+				Message:       "1 unknown",
+				Color:         "lightgrey",
+			},
+		},
+		{
+			name: "no findings",
+			want: badge{
+				SchemaVersion: 1,
+				Label:         "trivy",
+				Message:       "no findings",
+				Color:         "brightgreen",
+			},
+		},
+		{
+			name:       "custom order",
+			severities: []string{"UNKNOWN", "HIGH"},
+			order:      []string{"LOW", "MEDIUM", "HIGH", "UNKNOWN", "CRITICAL"},
+			want: badge{
+				SchemaVersion: 1,
+				Label:         "trivy",
+				Message:       "1 unknown, 1 high",
+				Color:         "lightgrey",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var vulns []types.DetectedVulnerability
+			for _, severity := range tt.severities {
+				vulns = append(vulns, types.DetectedVulnerability{
+					VulnerabilityID: "CVE-2024-0001",
+					Vulnerability:   dbTypes.Vulnerability{Severity: severity},
+				})
+			}
+			r := types.Report{
+				Results: types.Results{
+					{
+						Target:          "alpine:3.20 (alpine 3.20.0)",
+						Class:           types.ClassOSPkg,
+						Vulnerabilities: vulns,
+					},
+				},
+			}
+
+			buf := new(bytes.Buffer)
+			w := report.BadgeWriter{
+				Output: buf,
+				Order:  tt.order,
+			}
+			require.NoError(t, w.Write(context.Background(), r))
+
+			var got badge
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBadgeWriter_Write_SVG(t *testing.T) {
+	r := types.Report{
+		Results: types.Results{
+			{
+				Target: "Dockerfile",
+				Class:  types.ClassConfig,
+				Misconfigurations: []types.DetectedMisconfiguration{
+					{
+						ID:       "DS002",
+						Severity: "HIGH",
+						Status:   types.MisconfStatusFailure,
+					},
+				},
+			},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	w := report.BadgeWriter{
+		Output: buf,
+		SVG:    true,
+	}
+	require.NoError(t, w.Write(context.Background(), r))
+
+	got := buf.String()
+	assert.Contains(t, got, `<svg xmlns="http://www.w3.org/2000/svg"`)
+	assert.Contains(t, got, `aria-label="trivy: 1 high"`)
+	assert.Contains(t, got, `fill="#fe7d37"`)
+	assert.Contains(t, got, `<text x="22" y="14">trivy</text>`)
+	assert.NotContains(t, got, "href", "the SVG must be self-contained")
+}
@@ -0,0 +1,92 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const CountBySeverity = "severity"
+
+// CountWriter writes the number of findings so that it can be used in shell scripts
+type CountWriter struct {
+	Output     io.Writer
+	Severities []dbTypes.Severity
+
+	// By prints the counts per the given group, one per line (e.g. "severity")
+	By string
+
+	// Order of severities from the lowest to the highest (dbTypes.SeverityNames by default)
+	Order []string
+}
+
+// Write writes the total number of findings, or the number of findings per severity
+func (cw CountWriter) Write(_ context.Context, report types.Report) error {
+	counts := make(map[string]int)
+	var total int
+	for _, result := range report.Results {
+		for _, severity := range findingSeverities(result) {
+			counts[severity]++
+			total++
+		}
+	}
+
+	if cw.By != CountBySeverity {
+		if _, err := fmt.Fprintln(cw.Output, total); err != nil {
+			return xerrors.Errorf("failed to write the count: %w", err)
+		}
+		return nil
+	}
+
+	severities := slices.Clone(cw.Severities)
+	if len(severities) == 0 {
+		severities = []dbTypes.Severity{
+			dbTypes.SeverityUnknown,
+			dbTypes.SeverityLow,
+			dbTypes.SeverityMedium,
+			dbTypes.SeverityHigh,
+			dbTypes.SeverityCritical,
+		}
+	}
+	order := cw.Order
+	if len(order) == 0 {
+		order = dbTypes.SeverityNames
+	}
+	// Print from the most severe
+	slices.SortFunc(severities, func(a, b dbTypes.Severity) int {
+		return slices.Index(order, b.String()) - slices.Index(order, a.String())
+	})
+	for _, severity := range severities {
+		if _, err := fmt.Fprintf(cw.Output, "%s: %d\n", severity, counts[severity.String()]); err != nil {
+			return xerrors.Errorf("failed to write the count: %w", err)
+		}
+	}
+	return nil
+}
+
+// findingSeverities returns the severity of each finding in the result.
+// The result is already filtered, so only passed misconfigurations need to be excluded.
+func findingSeverities(result types.Result) []string {
+	var severities []string
+	for _, vuln := range result.Vulnerabilities {
+		severities = append(severities, vuln.Severity)
+	}
+	for _, misconf := range result.Misconfigurations {
+		if misconf.Status == types.MisconfStatusFailure {
+			severities = append(severities, misconf.Severity)
+		}
+	}
+	for _, secret := range result.Secrets {
+		severities = append(severities, secret.Severity)
+	}
+	for _, license := range result.Licenses {
+		severities = append(severities, license.Severity)
+	}
+	return severities
+}
@@ -0,0 +1,131 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestCountWriter_Write(t *testing.T) {
+	results := types.Results{
+		{
+			Target: "alpine:3.20 (alpine 3.20.0)",
+			Class:  types.ClassOSPkg,
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "CVE-2024-0001",
+					Vulnerability:   dbTypes.Vulnerability{Severity: "CRITICAL"},
+				},
+				{
+					VulnerabilityID: "CVE-2024-0002",
+					Vulnerability:   dbTypes.Vulnerability{Severity: "HIGH"},
+				},
+			},
+		},
+		{
+			Target: "Dockerfile",
+			Class:  types.ClassConfig,
+			Misconfigurations: []types.DetectedMisconfiguration{
+				{
+					ID:       "DS002",
+					Severity: "HIGH",
+					Status:   types.MisconfStatusFailure,
+				},
+				{
+					ID:       "DS001",
+					Severity: "MEDIUM",
+					Status:   types.MisconfStatusPassed,
+				},
+			},
+		},
+		{
+			Target: "config.env",
+			Class:  types.ClassSecret,
+			Secrets: []types.DetectedSecret{
+				{
+					RuleID:   "aws-access-key-id",
+					Severity: "CRITICAL",
+				},
+			},
+		},
+		{
+			Target: "OS Packages",
+			Class:  types.ClassLicense,
+			Licenses: []types.DetectedLicense{
+				{
+					Name:     "GPL-2.0",
+					Severity: "HIGH",
+					Category: ftypes.CategoryRestricted,
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		severities []dbTypes.Severity
+		by         string
+		order      []string
+		want       string
+	}{
+		{
+			name: "total",
+			want: "5\n",
+		},
+		{
+			name: "by severity",
+			severities: []dbTypes.Severity{
+				dbTypes.SeverityCritical,
+				dbTypes.SeverityMedium,
+				dbTypes.SeverityHigh,
+			},
+			by: report.CountBySeverity,
+			want: `CRITICAL: 2
+HIGH: 3
+MEDIUM: 0
+`,
+		},
+		{
+			name: "by severity with custom order",
+			severities: []dbTypes.Severity{
+				dbTypes.SeverityCritical,
+				dbTypes.SeverityMedium,
+				dbTypes.SeverityHigh,
+			},
+			by: report.CountBySeverity,
+			order: []string{
+				"UNKNOWN",
+				"LOW",
+				"HIGH",
+				"MEDIUM",
+				"CRITICAL",
+			},
+			want: `CRITICAL: 2
+MEDIUM: 0
+HIGH: 3
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := bytes.NewBuffer(nil)
+			w := report.CountWriter{
+				Output:     out,
+				Severities: tt.severities,
+				By:         tt.by,
+				Order:      tt.order,
+			}
+			err := w.Write(context.Background(), types.Report{Results: results})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package defectdojo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// CWE-798: Use of Hard-coded Credentials
+const secretCWE = 798
+
+// Findings represents the "Generic Findings Import" format of DefectDojo
+// cf. https://documentation.defectdojo.com/integrations/parsers/file/generic/
+type Findings struct {
+	Findings []Finding `json:"findings"`
+}
+
+type Finding struct {
+	Title            string            `json:"title"`
+	Description      string            `json:"description"`
+	Severity         string            `json:"severity"`
+	Mitigation       string            `json:"mitigation,omitempty"`
+	References       string            `json:"references,omitempty"`
+	CWE              int               `json:"cwe,omitempty"`
+	VulnerabilityIDs []VulnerabilityID `json:"vulnerability_ids,omitempty"`
+	VulnIDFromTool   string            `json:"vuln_id_from_tool,omitempty"`
+	ComponentName    string            `json:"component_name,omitempty"`
+	ComponentVersion string            `json:"component_version,omitempty"`
+	FilePath         string            `json:"file_path,omitempty"`
+	Line             int               `json:"line,omitempty"`
+	StaticFinding    bool              `json:"static_finding"`
+	DynamicFinding   bool              `json:"dynamic_finding"`
+}
+
+type VulnerabilityID struct {
+	VulnerabilityID string `json:"vulnerability_id"`
+}
+
+// Writer generates JSON for the generic findings import of DefectDojo
+type Writer struct {
+	Output io.Writer
+}
+
+func (w Writer) Write(_ context.Context, report types.Report) error {
+	findings := Findings{
+		Findings: []Finding{}, // DefectDojo requires the "findings" array even if it is empty
+	}
+	for _, result := range report.Results {
+		for _, vuln := range result.Vulnerabilities {
+			findings.Findings = append(findings.Findings, vulnerabilityFinding(result, vuln))
+		}
+		for _, misconf := range result.Misconfigurations {
+			if misconf.Status != types.MisconfStatusFailure {
+				continue
+			}
+			findings.Findings = append(findings.Findings, misconfigurationFinding(result, misconf))
+		}
+		for _, secret := range result.Secrets {
+			findings.Findings = append(findings.Findings, secretFinding(result, secret))
+		}
+	}
+
+	output, err := json.MarshalIndent(findings, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("failed to marshal defectdojo findings: %w", err)
+	}
+
+	if _, err = fmt.Fprintln(w.Output, string(output)); err != nil {
+		return xerrors.Errorf("failed to write defectdojo findings: %w", err)
+	}
+	return nil
+}
+
+func vulnerabilityFinding(result types.Result, vuln types.DetectedVulnerability) Finding {
+	var mitigation string
+	if vuln.FixedVersion != "" {
+		mitigation = fmt.Sprintf("Upgrade %s to version %s", vuln.PkgName, vuln.FixedVersion)
+	}
+
+	filePath := result.Target
+	if vuln.PkgPath != "" {
+		filePath = vuln.PkgPath
+	}
+
+	return Finding{
+		Title:       fmt.Sprintf("%s %s %s", vuln.VulnerabilityID, vuln.PkgName, vuln.InstalledVersion),
+		Description: lo.CoalesceOrEmpty(vuln.Description, vuln.Title, vuln.VulnerabilityID),
+		Severity:    toSeverity(vuln.Severity),
+		Mitigation:  mitigation,
+		References:  toReferences(vuln.PrimaryURL, vuln.References),
+		CWE:         toCWE(vuln.CweIDs),
+		VulnerabilityIDs: []VulnerabilityID{
+			{VulnerabilityID: vuln.VulnerabilityID},
+		},
+		VulnIDFromTool:   vuln.VulnerabilityID,
+		ComponentName:    vuln.PkgName,
+		ComponentVersion: vuln.InstalledVersion,
+		FilePath:         filePath,
+		Line:             packageLine(result.Packages, vuln),
+		StaticFinding:    true,
+	}
+}
+
+func misconfigurationFinding(result types.Result, misconf types.DetectedMisconfiguration) Finding {
+	description := misconf.Description
+	if misconf.Message != "" {
+		description = strings.TrimSpace(description + "\n\n" + misconf.Message)
+	}
+	return Finding{
+		Title:          fmt.Sprintf("%s: %s", misconf.ID, misconf.Title),
+		Description:    lo.CoalesceOrEmpty(description, misconf.Title),
+		Severity:       toSeverity(misconf.Severity),
+		Mitigation:     misconf.Resolution,
+		References:     toReferences(misconf.PrimaryURL, misconf.References),
+		VulnIDFromTool: lo.CoalesceOrEmpty(misconf.AVDID, misconf.ID),
+		FilePath:       result.Target,
+		Line:           misconf.CauseMetadata.StartLine,
+		StaticFinding:  true,
+	}
+}
+
+func secretFinding(result types.Result, secret types.DetectedSecret) Finding {
+	return Finding{
+		Title:          secret.Title,
+		Description:    fmt.Sprintf("Secret of %s detected by the rule %q: %s", secret.Category, secret.RuleID, secret.Match),
+		Severity:       toSeverity(secret.Severity),
+		CWE:            secretCWE,
+		VulnIDFromTool: secret.RuleID,
+		FilePath:       result.Target,
+		Line:           secret.StartLine,
+		StaticFinding:  true,
+	}
+}
+
+// toSeverity converts the severity to the scale of DefectDojo
+func toSeverity(severity string) string {
+	switch severity {
+	case dbTypes.SeverityCritical.String():
+		return "Critical"
+	case dbTypes.SeverityHigh.String():
+		return "High"
+	case dbTypes.SeverityMedium.String():
+		return "Medium"
+	case dbTypes.SeverityLow.String():
+		return "Low"
+	default:
+		return "Info"
+	}
+}
+
+// toCWE returns the first CWE number, as DefectDojo accepts only one CWE per finding.
+func toCWE(cweIDs []string) int {
+	for _, id := range cweIDs {
+		if n, err := strconv.Atoi(strings.TrimPrefix(id, "CWE-")); err == nil {
+			return n
+		}
+	}
+	return 0
+}
+
+func toReferences(primaryURL string, references []string) string {
+	refs := lo.Uniq(lo.Compact(append([]string{primaryURL}, references...)))
+	return strings.Join(refs, "\n")
+}
+
+// packageLine returns the first line where the vulnerable package is declared
+func packageLine(pkgs []ftypes.Package, vuln types.DetectedVulnerability) int {
+	pkg, found := lo.Find(pkgs, func(pkg ftypes.Package) bool {
+		if vuln.PkgID != "" && pkg.ID != "" {
+			return pkg.ID == vuln.PkgID
+		}
+		return pkg.Name == vuln.PkgName && pkg.Version == vuln.InstalledVersion
+	})
+	if !found || len(pkg.Locations) == 0 {
+		return 0
+	}
+	return pkg.Locations[0].StartLine
+}
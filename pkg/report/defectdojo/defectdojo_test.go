@@ -0,0 +1,168 @@
+package defectdojo_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report/defectdojo"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestWriter_Write(t *testing.T) {
+	tests := []struct {
+		name   string
+		report types.Report
+		want   string
+	}{
+		{
+			name: "vulnerabilities, misconfigurations and secrets",
+			report: types.Report{
+				Results: types.Results{
+					{
+						Target: "package-lock.json",
+						Class:  types.ClassLangPkg,
+						Type:   ftypes.Npm,
+						Packages: []ftypes.Package{
+							{
+								ID:      "foo@1.2.3",
+								Name:    "foo",
+								Version: "1.2.3",
+								Locations: []ftypes.Location{
+									{
+										StartLine: 5,
+										EndLine:   10,
+									},
+								},
+							},
+						},
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2020-0001",
+								PkgID:            "foo@1.2.3",
+								PkgName:          "foo",
+								InstalledVersion: "1.2.3",
+								FixedVersion:     "1.2.4",
+								PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2020-0001",
+								Vulnerability: dbTypes.Vulnerability{
+									Title:       "foo title",
+									Description: "This is a description",
+									Severity:    "HIGH",
+									CweIDs:      []string{"CWE-79"},
+									References: []string{
+										"https://avd.aquasec.com/nvd/cve-2020-0001",
+										"https://example.com/cve-2020-0001",
+									},
+								},
+							},
+							{
+								VulnerabilityID:  "CVE-2020-0002",
+								PkgID:            "bar@2.0.0",
+								PkgName:          "bar",
+								InstalledVersion: "2.0.0",
+								Vulnerability: dbTypes.Vulnerability{
+									Title:    "bar title",
+									Severity: "UNKNOWN",
+								},
+							},
+						},
+					},
+					{
+						Target: "Dockerfile",
+						Class:  types.ClassConfig,
+						Type:   ftypes.Dockerfile,
+						Misconfigurations: []types.DetectedMisconfiguration{
+							{
+								ID:          "DS002",
+								AVDID:       "AVD-DS-0002",
+								Title:       "Image user should not be 'root'",
+								Description: "Running containers with 'root' user can lead to a container escape situation.",
+								Message:     "Specify at least 1 USER command in Dockerfile with non-root user as argument",
+								Resolution:  "Add 'USER <non root user name>' line to the Dockerfile",
+								Severity:    "HIGH",
+								PrimaryURL:  "https://avd.aquasec.com/misconfig/ds002",
+								Status:      types.MisconfStatusFailure,
+								CauseMetadata: ftypes.CauseMetadata{
+									StartLine: 3,
+									EndLine:   3,
+								},
+							},
+							{
+								ID:       "DS001",
+								AVDID:    "AVD-DS-0001",
+								Title:    "':latest' tag used",
+								Severity: "MEDIUM",
+								Status:   types.MisconfStatusPassed,
+							},
+						},
+					},
+					{
+						Target: "config.env",
+						Class:  types.ClassSecret,
+						Secrets: []types.DetectedSecret{
+							{
+								RuleID:    "aws-access-key-id",
+								Category:  "AWS",
+								Severity:  "CRITICAL",
+								Title:     "AWS Access Key ID",
+								StartLine: 2,
+								EndLine:   2,
+								Match:     "AWS_ACCESS_KEY_ID=********************",
+							},
+						},
+					},
+				},
+			},
+			want: "testdata/findings.json.golden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := bytes.NewBuffer(nil)
+			w := defectdojo.Writer{Output: out}
+			err := w.Write(context.Background(), tt.report)
+			require.NoError(t, err)
+
+			want, err := os.ReadFile(tt.want)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(want), out.String())
+
+			validateSchema(t, out.Bytes())
+		})
+	}
+}
+
+func TestWriter_Write_Empty(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	w := defectdojo.Writer{Output: out}
+	err := w.Write(context.Background(), types.Report{})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"findings": []}`, out.String())
+	validateSchema(t, out.Bytes())
+}
+
+func validateSchema(t *testing.T, doc []byte) {
+	schema, err := os.ReadFile("testdata/generic-findings.schema.json")
+	require.NoError(t, err)
+
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewBytesLoader(doc))
+	require.NoError(t, err)
+
+	if valid := result.Valid(); !valid {
+		errs := lo.Map(result.Errors(), func(err gojsonschema.ResultError, _ int) string {
+			return err.String()
+		})
+		assert.True(t, valid, strings.Join(errs, "\n"))
+	}
+}
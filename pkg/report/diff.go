@@ -0,0 +1,355 @@
+package report
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/scanner/utils"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// PackageChange represents how a package changed between two reports
+type PackageChange string
+
+const (
+	PackageAdded          PackageChange = "added"
+	PackageRemoved        PackageChange = "removed"
+	PackageVersionChanged PackageChange = "version-changed"
+)
+
+// PackageDiff represents a package added, removed or changed between two reports
+type PackageDiff struct {
+	Target        string
+	Change        PackageChange
+	Name          string
+	BeforeVersion string // Empty for added packages
+	AfterVersion  string // Empty for removed packages
+
+	// Resolved holds the vulnerabilities of the package that no longer exist in the later report.
+	// They are resolved by removal for removed packages, and by the upgrade for packages whose version changed.
+	Resolved []types.DetectedVulnerability
+}
+
+// ResolvedBy returns the cause of the resolved vulnerabilities, e.g. "resolved by removal"
+func (d PackageDiff) ResolvedBy() string {
+	switch d.Change {
+	case PackageRemoved:
+		return "resolved by removal"
+	case PackageVersionChanged:
+		return "resolved by version change"
+	}
+	return ""
+}
+
+// FindingChange represents whether a finding was added or removed between two reports
+type FindingChange string
+
+const (
+	FindingAdded   FindingChange = "added"
+	FindingRemoved FindingChange = "removed"
+)
+
+// FindingDiff represents a finding added or removed between two reports
+type FindingDiff struct {
+	Target   string
+	Class    types.ResultClass
+	Change   FindingChange
+	ID       string // e.g. CVE-2024-0001, AVD-DS-0002, aws-access-key-id or MIT
+	PkgName  string `json:",omitempty"` // Only for vulnerabilities and package licenses
+	Version  string `json:",omitempty"` // Only for vulnerabilities
+	Severity string
+	Title    string `json:",omitempty"`
+}
+
+// Diff holds the findings and the packages changed between two reports, e.g. filesystem snapshots before and after a change.
+type Diff struct {
+	Before   string // The artifact name of the report before the change
+	After    string // The artifact name of the report after the change
+	Findings []FindingDiff
+	Packages []PackageDiff
+}
+
+// Empty returns whether nothing changed between the reports
+func (d Diff) Empty() bool {
+	return len(d.Findings) == 0 && len(d.Packages) == 0
+}
+
+// Added returns the number of findings added after the change
+func (d Diff) Added() int {
+	return lo.CountBy(d.Findings, func(f FindingDiff) bool {
+		return f.Change == FindingAdded
+	})
+}
+
+// DiffReports compares two reports of the same artifact and returns the findings added and removed,
+// and the packages changed as DiffPackages does.
+// Vulnerabilities are matched by the package name and the vulnerability ID so that a vulnerability remaining after
+// a package upgrade is neither added nor removed. Only failed misconfigurations are compared.
+func DiffReports(before, after types.Report) (Diff, error) {
+	packages, err := DiffPackages(before, after)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	beforeResults := lo.SliceToMap(before.Results, func(result types.Result) (string, types.Result) {
+		return resultKey(result), result
+	})
+	afterResults := lo.SliceToMap(after.Results, func(result types.Result) (string, types.Result) {
+		return resultKey(result), result
+	})
+
+	var findings []FindingDiff
+	for key, old := range beforeResults {
+		findings = append(findings, diffResultFindings(old, afterResults[key])...)
+	}
+	for key, res := range afterResults {
+		if _, ok := beforeResults[key]; !ok {
+			findings = append(findings, diffResultFindings(types.Result{}, res)...)
+		}
+	}
+
+	slices.SortFunc(findings, func(a, b FindingDiff) int {
+		return cmp.Or(
+			cmp.Compare(a.Target, b.Target),
+			cmp.Compare(a.Class, b.Class),
+			cmp.Compare(a.Change, b.Change),
+			cmp.Compare(a.ID, b.ID),
+			cmp.Compare(a.PkgName, b.PkgName),
+		)
+	})
+	return Diff{
+		Before:   before.ArtifactName,
+		After:    after.ArtifactName,
+		Findings: findings,
+		Packages: packages,
+	}, nil
+}
+
+// diffResultFindings compares the findings of the same result in two reports.
+// "before" or "after" is empty if the result exists only in one of the reports.
+func diffResultFindings(before, after types.Result) []FindingDiff {
+	target := lo.CoalesceOrEmpty(after.Target, before.Target)
+	class := lo.CoalesceOrEmpty(after.Class, before.Class)
+	beforeFindings := resultFindings(before)
+	afterFindings := resultFindings(after)
+
+	var diffs []FindingDiff
+	for key, f := range beforeFindings {
+		if _, ok := afterFindings[key]; !ok {
+			f.Target, f.Class, f.Change = target, class, FindingRemoved
+			diffs = append(diffs, f)
+		}
+	}
+	for key, f := range afterFindings {
+		if _, ok := beforeFindings[key]; !ok {
+			f.Target, f.Class, f.Change = target, class, FindingAdded
+			diffs = append(diffs, f)
+		}
+	}
+	return diffs
+}
+
+// resultFindings returns the findings of the result keyed so that the same finding matches across reports.
+func resultFindings(result types.Result) map[string]FindingDiff {
+	findings := make(map[string]FindingDiff)
+	for _, v := range result.Vulnerabilities {
+		findings["vuln|"+v.PkgName+"|"+v.VulnerabilityID] = FindingDiff{
+			ID:       v.VulnerabilityID,
+			PkgName:  v.PkgName,
+			Version:  v.InstalledVersion,
+			Severity: v.Severity,
+			Title:    v.Title,
+		}
+	}
+	for _, m := range result.Misconfigurations {
+		if m.Status != types.MisconfStatusFailure {
+			continue
+		}
+		id := lo.CoalesceOrEmpty(m.AVDID, m.ID)
+		findings["misconf|"+id+"|"+m.CauseMetadata.Resource] = FindingDiff{
+			ID:       id,
+			Severity: m.Severity,
+			Title:    m.Title,
+		}
+	}
+	for _, s := range result.Secrets {
+		findings["secret|"+s.RuleID+"|"+s.Match] = FindingDiff{
+			ID:       s.RuleID,
+			Severity: s.Severity,
+			Title:    s.Title,
+		}
+	}
+	for _, l := range result.Licenses {
+		findings["license|"+l.PkgName+"|"+l.Name] = FindingDiff{
+			ID:       l.Name,
+			PkgName:  l.PkgName,
+			Severity: l.Severity,
+		}
+	}
+	return findings
+}
+
+// DiffPackages compares the packages of two reports of the same artifact, e.g. images before and after a base image update,
+// and returns the packages added, removed or changed with the vulnerabilities resolved by each change.
+// Packages are matched by PkgID, and a package replaced with another version of the same name is reported as version-changed.
+// Results are matched by target, except for OS packages, whose target contains the OS version.
+// Both reports must contain the packages, i.e. be generated with "--list-all-pkgs".
+func DiffPackages(before, after types.Report) ([]PackageDiff, error) {
+	beforeResults, err := indexResults(before.Results)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid report before the change: %w", err)
+	}
+	afterResults, err := indexResults(after.Results)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid report after the change: %w", err)
+	}
+
+	var diffs []PackageDiff
+	for key, old := range beforeResults {
+		diffs = append(diffs, diffResultPackages(old, afterResults[key])...)
+	}
+	for key, res := range afterResults {
+		if _, ok := beforeResults[key]; !ok {
+			diffs = append(diffs, diffResultPackages(types.Result{}, res)...)
+		}
+	}
+
+	slices.SortFunc(diffs, func(a, b PackageDiff) int {
+		return cmp.Or(
+			cmp.Compare(a.Target, b.Target),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.BeforeVersion, b.BeforeVersion),
+			cmp.Compare(a.AfterVersion, b.AfterVersion),
+		)
+	})
+	return diffs, nil
+}
+
+// indexResults returns the package results keyed by their class, type and target.
+func indexResults(results types.Results) (map[string]types.Result, error) {
+	index := make(map[string]types.Result)
+	for _, result := range results {
+		if result.Class != types.ClassOSPkg && result.Class != types.ClassLangPkg {
+			continue
+		}
+		if len(result.Packages) == 0 && len(result.Vulnerabilities) > 0 {
+			return nil, xerrors.Errorf("%s has vulnerabilities but no packages, scan with '--list-all-pkgs'", result.Target)
+		}
+		index[resultKey(result)] = result
+	}
+	return index, nil
+}
+
+// resultKey returns the key matching the same result in two reports.
+// The target is not a part of the key for OS packages as it contains the OS version.
+func resultKey(result types.Result) string {
+	key := string(result.Class) + "|" + string(result.Type)
+	if result.Class != types.ClassOSPkg {
+		key += "|" + result.Target
+	}
+	return key
+}
+
+// diffResultPackages compares the packages of the same result in two reports.
+// "before" or "after" is empty if the result exists only in one of the reports.
+func diffResultPackages(before, after types.Result) []PackageDiff {
+	target := lo.CoalesceOrEmpty(after.Target, before.Target)
+	beforePkgs := lo.SliceToMap(before.Packages, func(pkg ftypes.Package) (string, ftypes.Package) {
+		return packageID(pkg), pkg
+	})
+	afterPkgs := lo.SliceToMap(after.Packages, func(pkg ftypes.Package) (string, ftypes.Package) {
+		return packageID(pkg), pkg
+	})
+
+	// Group the packages only in either report by name so that version changes can be detected
+	removed := make(map[string][]ftypes.Package)
+	for id, pkg := range beforePkgs {
+		if _, ok := afterPkgs[id]; !ok {
+			removed[packageName(pkg)] = append(removed[packageName(pkg)], pkg)
+		}
+	}
+	added := make(map[string][]ftypes.Package)
+	for id, pkg := range afterPkgs {
+		if _, ok := beforePkgs[id]; !ok {
+			added[packageName(pkg)] = append(added[packageName(pkg)], pkg)
+		}
+	}
+
+	var diffs []PackageDiff
+	for name, oldPkgs := range removed {
+		newPkgs := added[name]
+		// Only a one-to-one replacement is regarded as a version change.
+		// e.g. two versions of a npm package replaced with another version are reported as removed and added.
+		if len(oldPkgs) == 1 && len(newPkgs) == 1 {
+			diffs = append(diffs, PackageDiff{
+				Target:        target,
+				Change:        PackageVersionChanged,
+				Name:          oldPkgs[0].Name,
+				BeforeVersion: utils.FormatVersion(oldPkgs[0]),
+				AfterVersion:  utils.FormatVersion(newPkgs[0]),
+				Resolved:      resolvedVulnerabilities(before, oldPkgs[0], after, newPkgs[0]),
+			})
+			delete(added, name)
+			continue
+		}
+		for _, pkg := range oldPkgs {
+			diffs = append(diffs, PackageDiff{
+				Target:        target,
+				Change:        PackageRemoved,
+				Name:          pkg.Name,
+				BeforeVersion: utils.FormatVersion(pkg),
+				Resolved:      packageVulnerabilities(before, pkg),
+			})
+		}
+	}
+	for _, newPkgs := range added {
+		for _, pkg := range newPkgs {
+			diffs = append(diffs, PackageDiff{
+				Target:       target,
+				Change:       PackageAdded,
+				Name:         pkg.Name,
+				AfterVersion: utils.FormatVersion(pkg),
+			})
+		}
+	}
+	return diffs
+}
+
+// resolvedVulnerabilities returns the vulnerabilities of the old package which the new package doesn't have.
+func resolvedVulnerabilities(before types.Result, oldPkg ftypes.Package, after types.Result, newPkg ftypes.Package) []types.DetectedVulnerability {
+	remaining := lo.SliceToMap(packageVulnerabilities(after, newPkg), func(v types.DetectedVulnerability) (string, struct{}) {
+		return v.VulnerabilityID, struct{}{}
+	})
+	return lo.Filter(packageVulnerabilities(before, oldPkg), func(v types.DetectedVulnerability, _ int) bool {
+		return !lo.HasKey(remaining, v.VulnerabilityID)
+	})
+}
+
+func packageVulnerabilities(result types.Result, pkg ftypes.Package) []types.DetectedVulnerability {
+	id := packageID(pkg)
+	var vulns []types.DetectedVulnerability
+	for _, v := range result.Vulnerabilities {
+		if lo.CoalesceOrEmpty(v.PkgID, v.PkgName+"@"+v.InstalledVersion) == id {
+			vulns = append(vulns, v)
+		}
+	}
+	return vulns
+}
+
+// packageID returns the ID of the package, or "name@version" for packages without an ID
+func packageID(pkg ftypes.Package) string {
+	return lo.CoalesceOrEmpty(pkg.ID, pkg.Name+"@"+utils.FormatVersion(pkg))
+}
+
+// packageName returns the name identifying the package regardless of the version.
+// The architecture is taken into account as OS packages may be installed for several architectures.
+func packageName(pkg ftypes.Package) string {
+	if pkg.Arch != "" {
+		return pkg.Name + "/" + pkg.Arch
+	}
+	return pkg.Name
+}
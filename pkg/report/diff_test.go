@@ -0,0 +1,380 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestDiffPackages(t *testing.T) {
+	pkg := func(name, ver string) ftypes.Package {
+		return ftypes.Package{
+			ID:      name + "@" + ver,
+			Name:    name,
+			Version: ver,
+		}
+	}
+	vuln := func(id, name, ver string) types.DetectedVulnerability {
+		return types.DetectedVulnerability{
+			VulnerabilityID:  id,
+			PkgID:            name + "@" + ver,
+			PkgName:          name,
+			InstalledVersion: ver,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		before  types.Report
+		after   types.Report
+		want    []report.PackageDiff
+		wantErr string
+	}{
+		{
+			name: "package removed",
+			before: types.Report{
+				Results: types.Results{
+					{
+						Target: "alpine:3.19 (alpine 3.19.1)",
+						Class:  types.ClassOSPkg,
+						Type:   ftypes.Alpine,
+						Packages: []ftypes.Package{
+							pkg("busybox", "1.36.1-r15"),
+							pkg("curl", "8.5.0-r0"),
+							pkg("musl", "1.2.4_git20230717-r4"),
+						},
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln("CVE-2023-42363", "busybox", "1.36.1-r15"),
+							vuln("CVE-2024-0853", "curl", "8.5.0-r0"),
+							vuln("CVE-2024-2004", "curl", "8.5.0-r0"),
+						},
+					},
+				},
+			},
+			after: types.Report{
+				Results: types.Results{
+					{
+						Target: "alpine:3.20 (alpine 3.20.0)",
+						Class:  types.ClassOSPkg,
+						Type:   ftypes.Alpine,
+						Packages: []ftypes.Package{
+							pkg("busybox", "1.36.1-r15"),
+							pkg("musl", "1.2.4_git20230717-r4"),
+						},
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln("CVE-2023-42363", "busybox", "1.36.1-r15"),
+						},
+					},
+				},
+			},
+			want: []report.PackageDiff{
+				{
+					Target:        "alpine:3.20 (alpine 3.20.0)",
+					Change:        report.PackageRemoved,
+					Name:          "curl",
+					BeforeVersion: "8.5.0-r0",
+					Resolved: []types.DetectedVulnerability{
+						vuln("CVE-2024-0853", "curl", "8.5.0-r0"),
+						vuln("CVE-2024-2004", "curl", "8.5.0-r0"),
+					},
+				},
+			},
+		},
+		{
+			name: "added, removed and version-changed",
+			before: types.Report{
+				Results: types.Results{
+					{
+						Target: "package-lock.json",
+						Class:  types.ClassLangPkg,
+						Type:   ftypes.Npm,
+						Packages: []ftypes.Package{
+							pkg("express", "4.17.1"),
+							pkg("lodash", "4.17.20"),
+						},
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln("CVE-2022-24999", "express", "4.17.1"),
+							vuln("CVE-2024-29041", "express", "4.17.1"),
+							vuln("CVE-2021-23337", "lodash", "4.17.20"),
+						},
+					},
+				},
+			},
+			after: types.Report{
+				Results: types.Results{
+					{
+						Target: "package-lock.json",
+						Class:  types.ClassLangPkg,
+						Type:   ftypes.Npm,
+						Packages: []ftypes.Package{
+							pkg("express", "4.18.2"),
+							pkg("ms", "2.1.3"),
+						},
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln("CVE-2024-29041", "express", "4.18.2"),
+						},
+					},
+				},
+			},
+			want: []report.PackageDiff{
+				{
+					Target:        "package-lock.json",
+					Change:        report.PackageVersionChanged,
+					Name:          "express",
+					BeforeVersion: "4.17.1",
+					AfterVersion:  "4.18.2",
+					Resolved: []types.DetectedVulnerability{
+						vuln("CVE-2022-24999", "express", "4.17.1"),
+					},
+				},
+				{
+					Target:        "package-lock.json",
+					Change:        report.PackageRemoved,
+					Name:          "lodash",
+					BeforeVersion: "4.17.20",
+					Resolved: []types.DetectedVulnerability{
+						vuln("CVE-2021-23337", "lodash", "4.17.20"),
+					},
+				},
+				{
+					Target:       "package-lock.json",
+					Change:       report.PackageAdded,
+					Name:         "ms",
+					AfterVersion: "2.1.3",
+				},
+			},
+		},
+		{
+			name: "lockfile removed",
+			before: types.Report{
+				Results: types.Results{
+					{
+						Target: "app/go.mod",
+						Class:  types.ClassLangPkg,
+						Type:   ftypes.GoModule,
+						Packages: []ftypes.Package{
+							pkg("golang.org/x/net", "0.20.0"),
+						},
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln("CVE-2023-45288", "golang.org/x/net", "0.20.0"),
+						},
+					},
+				},
+			},
+			after: types.Report{},
+			want: []report.PackageDiff{
+				{
+					Target:        "app/go.mod",
+					Change:        report.PackageRemoved,
+					Name:          "golang.org/x/net",
+					BeforeVersion: "0.20.0",
+					Resolved: []types.DetectedVulnerability{
+						vuln("CVE-2023-45288", "golang.org/x/net", "0.20.0"),
+					},
+				},
+			},
+		},
+		{
+			name: "no packages",
+			before: types.Report{
+				Results: types.Results{
+					{
+						Target: "package-lock.json",
+						Class:  types.ClassLangPkg,
+						Type:   ftypes.Npm,
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln("CVE-2021-23337", "lodash", "4.17.20"),
+						},
+					},
+				},
+			},
+			wantErr: "scan with '--list-all-pkgs'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := report.DiffPackages(tt.before, tt.after)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiffReports(t *testing.T) {
+	vuln := func(id, severity, name, ver string) types.DetectedVulnerability {
+		return types.DetectedVulnerability{
+			VulnerabilityID:  id,
+			PkgID:            name + "@" + ver,
+			PkgName:          name,
+			InstalledVersion: ver,
+			Vulnerability: dbTypes.Vulnerability{
+				Title:    id + " in " + name,
+				Severity: severity,
+			},
+		}
+	}
+	misconf := func(id, title string) types.DetectedMisconfiguration {
+		return types.DetectedMisconfiguration{
+			AVDID:    id,
+			Title:    title,
+			Severity: "HIGH",
+			Status:   types.MisconfStatusFailure,
+		}
+	}
+
+	// Snapshots of the same tree before and after a change
+	before := types.Report{
+		ArtifactName: "before",
+		Results: types.Results{
+			{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.Npm,
+				Packages: []ftypes.Package{
+					{ID: "express@4.17.1", Name: "express", Version: "4.17.1"},
+				},
+				Vulnerabilities: []types.DetectedVulnerability{
+					vuln("CVE-2022-24999", "HIGH", "express", "4.17.1"),
+					vuln("CVE-2024-29041", "MEDIUM", "express", "4.17.1"),
+				},
+			},
+			{
+				Target: "Dockerfile",
+				Class:  types.ClassConfig,
+				Type:   ftypes.Dockerfile,
+				Misconfigurations: []types.DetectedMisconfiguration{
+					misconf("AVD-DS-0002", "Image user should not be 'root'"),
+				},
+			},
+			{
+				Target: "config.yaml",
+				Class:  types.ClassSecret,
+				Secrets: []types.DetectedSecret{
+					{
+						RuleID:   "aws-access-key-id",
+						Title:    "AWS Access Key ID",
+						Severity: "CRITICAL",
+						Match:    "key: ********************",
+					},
+				},
+			},
+		},
+	}
+	after := types.Report{
+		ArtifactName: "after",
+		Results: types.Results{
+			{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.Npm,
+				Packages: []ftypes.Package{
+					{ID: "express@4.18.2", Name: "express", Version: "4.18.2"},
+				},
+				Vulnerabilities: []types.DetectedVulnerability{
+					// Remaining after the upgrade
+					vuln("CVE-2024-29041", "MEDIUM", "express", "4.18.2"),
+				},
+			},
+			{
+				Target: "Dockerfile",
+				Class:  types.ClassConfig,
+				Type:   ftypes.Dockerfile,
+				Misconfigurations: []types.DetectedMisconfiguration{
+					misconf("AVD-DS-0002", "Image user should not be 'root'"),
+					misconf("AVD-DS-0026", "No HEALTHCHECK defined"),
+					{
+						// Passed checks are not compared
+						AVDID:  "AVD-DS-0001",
+						Status: types.MisconfStatusPassed,
+					},
+				},
+			},
+			{
+				Target: ".env",
+				Class:  types.ClassSecret,
+				Secrets: []types.DetectedSecret{
+					{
+						RuleID:   "github-pat",
+						Title:    "GitHub Personal Access Token",
+						Severity: "CRITICAL",
+						Match:    "GITHUB_TOKEN=****************************************",
+					},
+				},
+			},
+		},
+	}
+
+	got, err := report.DiffReports(before, after)
+	require.NoError(t, err)
+
+	want := report.Diff{
+		Before: "before",
+		After:  "after",
+		Findings: []report.FindingDiff{
+			{
+				Target:   ".env",
+				Class:    types.ClassSecret,
+				Change:   report.FindingAdded,
+				ID:       "github-pat",
+				Severity: "CRITICAL",
+				Title:    "GitHub Personal Access Token",
+			},
+			{
+				Target:   "Dockerfile",
+				Class:    types.ClassConfig,
+				Change:   report.FindingAdded,
+				ID:       "AVD-DS-0026",
+				Severity: "HIGH",
+				Title:    "No HEALTHCHECK defined",
+			},
+			{
+				Target:   "config.yaml",
+				Class:    types.ClassSecret,
+				Change:   report.FindingRemoved,
+				ID:       "aws-access-key-id",
+				Severity: "CRITICAL",
+				Title:    "AWS Access Key ID",
+			},
+			{
+				Target:   "package-lock.json",
+				Class:    types.ClassLangPkg,
+				Change:   report.FindingRemoved,
+				ID:       "CVE-2022-24999",
+				PkgName:  "express",
+				Version:  "4.17.1",
+				Severity: "HIGH",
+				Title:    "CVE-2022-24999 in express",
+			},
+		},
+		Packages: []report.PackageDiff{
+			{
+				Target:        "package-lock.json",
+				Change:        report.PackageVersionChanged,
+				Name:          "express",
+				BeforeVersion: "4.17.1",
+				AfterVersion:  "4.18.2",
+				Resolved: []types.DetectedVulnerability{
+					vuln("CVE-2022-24999", "HIGH", "express", "4.17.1"),
+				},
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+	assert.Equal(t, 2, got.Added())
+	assert.False(t, got.Empty())
+
+	// Nothing changes between the same snapshots
+	got, err = report.DiffReports(after, after)
+	require.NoError(t, err)
+	assert.True(t, got.Empty())
+}
@@ -0,0 +1,123 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/fatih/color"
+	"github.com/hashicorp/go-multierror"
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	asciitable "github.com/aquasecurity/table"
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/report/table"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// WriteDiff writes the findings and the packages changed between two reports to the output in the table or JSON format.
+func WriteDiff(ctx context.Context, diff Diff, option flag.Options) (err error) {
+	output, cleanup, err := option.OutputWriter(ctx)
+	if err != nil {
+		return xerrors.Errorf("failed to create a file: %w", err)
+	}
+	defer func() {
+		if cerr := cleanup(); cerr != nil {
+			err = multierror.Append(err, cerr)
+		}
+	}()
+
+	switch option.Format {
+	case types.FormatJSON:
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(diff); err != nil {
+			return xerrors.Errorf("failed to write the diff: %w", err)
+		}
+		return nil
+	case types.FormatTable:
+		renderDiff(output, diff, table.IsOutputToTerminal(output))
+		return nil
+	default:
+		return xerrors.Errorf(`unknown format %q with "--diff". Use "json" or "table"`, option.Format)
+	}
+}
+
+// renderDiff renders the findings added and removed, and the packages changed with the version changes highlighted.
+func renderDiff(w io.Writer, diff Diff, isTerminal bool) {
+	added := diff.Added()
+	table.RenderTarget(w, fmt.Sprintf("Findings (%s -> %s)", diff.Before, diff.After), isTerminal)
+	_, _ = fmt.Fprintf(w, "Total: %d (added: %d, removed: %d)\n\n", len(diff.Findings), added, len(diff.Findings)-added)
+	if len(diff.Findings) > 0 {
+		t := newDiffTable(w, isTerminal)
+		t.SetHeaders("Target", "Change", "ID", "Package", "Severity", "Title")
+		for _, f := range diff.Findings {
+			pkg := f.PkgName
+			if f.Version != "" {
+				pkg += " (" + f.Version + ")"
+			}
+			severity := f.Severity
+			if isTerminal {
+				severity = table.ColorizeSeverity(severity, severity)
+			}
+			t.AddRow(f.Target, findingChangeLabel(f.Change, isTerminal), f.ID, pkg, severity, f.Title)
+		}
+		t.Render()
+	}
+
+	changed := lo.CountBy(diff.Packages, func(d PackageDiff) bool {
+		return d.Change == PackageVersionChanged
+	})
+	pkgAdded := lo.CountBy(diff.Packages, func(d PackageDiff) bool {
+		return d.Change == PackageAdded
+	})
+	table.RenderTarget(w, "Packages", isTerminal)
+	_, _ = fmt.Fprintf(w, "Total: %d (added: %d, removed: %d, version-changed: %d)\n\n",
+		len(diff.Packages), pkgAdded, len(diff.Packages)-pkgAdded-changed, changed)
+	if len(diff.Packages) > 0 {
+		t := newDiffTable(w, isTerminal)
+		t.SetHeaders("Target", "Change", "Package", "Version", "Resolved")
+		for _, d := range diff.Packages {
+			version := lo.CoalesceOrEmpty(d.AfterVersion, d.BeforeVersion)
+			change := string(d.Change)
+			if d.Change == PackageVersionChanged {
+				version = d.BeforeVersion + " -> " + d.AfterVersion
+				if isTerminal {
+					change = color.New(color.FgYellow, color.Bold).Sprint(change)
+					version = color.New(color.Bold).Sprint(version)
+				}
+			}
+			t.AddRow(d.Target, change, d.Name, version, strconv.Itoa(len(d.Resolved)))
+		}
+		t.Render()
+	}
+}
+
+func newDiffTable(w io.Writer, isTerminal bool) *asciitable.Table {
+	t := asciitable.New(w)
+	if isTerminal {
+		t.SetHeaderStyle(asciitable.StyleBold)
+		t.SetLineStyle(asciitable.StyleDim)
+	}
+	t.SetBorders(true)
+	t.SetAutoMerge(true)
+	t.SetRowLines(true)
+	return t
+}
+
+// findingChangeLabel returns the value of the "Change" column, colored in the terminal.
+func findingChangeLabel(change FindingChange, isTerminal bool) string {
+	if !isTerminal {
+		return string(change)
+	}
+	switch change {
+	case FindingAdded:
+		return color.New(color.FgRed).Sprint(change)
+	case FindingRemoved:
+		return color.New(color.FgGreen).Sprint(change)
+	}
+	return string(change)
+}
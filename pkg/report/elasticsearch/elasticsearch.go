@@ -0,0 +1,355 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/clock"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const (
+	// Scheme is the prefix of the output to index the findings over HTTPS, e.g. "es://localhost:9200/trivy"
+	Scheme = "es://"
+
+	// SchemeHTTP is the prefix of the output to index the findings over plain HTTP, e.g. "es+http://localhost:9200/trivy"
+	SchemeHTTP = "es+http://"
+
+	defaultPort = "9200"
+
+	// Number of documents sent in a bulk request
+	batchSize = 500
+
+	// Number of attempts to send a bulk request
+	maxAttempts = 3
+
+	// Number of failed documents logged individually
+	maxLoggedFailures = 10
+)
+
+// Writer bulk-indexes each finding into an OpenSearch or Elasticsearch index as a JSON document.
+// The document ID is derived from the finding so that the findings of the next scan overwrite the same documents.
+type Writer struct {
+	// URL has the host and the index, e.g. "es://localhost:9200/trivy".
+	// The port is 9200 when omitted.
+	URL string
+
+	// Username and Password are used for the basic authentication
+	Username string
+	Password string
+
+	// APIKey is used instead of the basic authentication if set
+	APIKey string
+
+	// Client sends the bulk requests (http.DefaultClient by default)
+	Client *http.Client
+
+	// RetryInterval is the interval between attempts to send a bulk request (1 second by default)
+	RetryInterval time.Duration
+}
+
+// document is the source of a document.
+// It is self-contained with the artifact and the target so that dashboards can aggregate the findings across scans.
+type document struct {
+	Timestamp    time.Time `json:"@timestamp"`
+	ArtifactName string
+	ArtifactType artifact.Type `json:",omitempty"`
+	Target       string
+	Class        types.ResultClass `json:",omitempty"`
+	Type         ftypes.TargetType `json:",omitempty"`
+	FindingType  types.FindingType
+	Finding      any
+}
+
+type bulkDocument struct {
+	id     string
+	source []byte
+}
+
+// bulkResponse is the response of the bulk API.
+// Only the fields required to report the failed documents are decoded.
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID     string `json:"_id"`
+		Status int    `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+func (w Writer) Write(ctx context.Context, report types.Report) error {
+	endpoint, index, err := parseURL(w.URL)
+	if err != nil {
+		return err
+	}
+
+	timestamp := report.CreatedAt
+	if timestamp.IsZero() {
+		timestamp = clock.Now(ctx)
+	}
+	docs, err := documents(report, timestamp)
+	if err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		log.DebugContext(ctx, "No findings to index into Elasticsearch")
+		return nil
+	}
+
+	var failed int
+	for _, batch := range lo.Chunk(docs, batchSize) {
+		n, err := w.bulk(ctx, endpoint, index, batch)
+		if err != nil {
+			return err
+		}
+		failed += n
+	}
+	if failed > 0 {
+		return xerrors.Errorf("failed to index %d of %d documents into %q", failed, len(docs), index)
+	}
+	log.DebugContext(ctx, "Indexed findings into Elasticsearch", log.String("index", index), log.Int("documents", len(docs)))
+	return nil
+}
+
+// bulk indexes the documents with a bulk request and returns the number of documents failed to be indexed.
+// The failed documents are logged with the reasons as the bulk API succeeds even when some documents fail.
+func (w Writer) bulk(ctx context.Context, endpoint, index string, docs []bulkDocument) (int, error) {
+	var body bytes.Buffer
+	for _, doc := range docs {
+		action, err := json.Marshal(map[string]any{
+			"index": map[string]string{
+				"_index": index,
+				"_id":    doc.id,
+			},
+		})
+		if err != nil {
+			return 0, xerrors.Errorf("failed to marshal the bulk action: %w", err)
+		}
+		body.Write(action)
+		body.WriteByte('\n')
+		body.Write(doc.source)
+		body.WriteByte('\n')
+	}
+
+	resp, err := w.send(ctx, endpoint, body.Bytes())
+	if err != nil {
+		return 0, err
+	}
+	if !resp.Errors {
+		return 0, nil
+	}
+
+	var failed int
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Error == nil {
+				continue
+			}
+			failed++
+			if failed <= maxLoggedFailures {
+				log.ErrorContext(ctx, "Failed to index the document", log.String("id", result.ID),
+					log.Int("status", result.Status), log.String("type", result.Error.Type), log.String("reason", result.Error.Reason))
+			}
+		}
+	}
+	if failed > maxLoggedFailures {
+		log.ErrorContext(ctx, "More documents failed to be indexed", log.Int("count", failed-maxLoggedFailures))
+	}
+	return failed, nil
+}
+
+// send sends the bulk request, retrying on connection errors, throttling and server errors.
+func (w Writer) send(ctx context.Context, endpoint string, body []byte) (bulkResponse, error) {
+	interval := w.RetryInterval
+	if interval == 0 {
+		interval = time.Second
+	}
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			log.DebugContext(ctx, "Retrying the bulk request", log.Int("attempt", attempt), log.Err(lastErr))
+			select {
+			case <-ctx.Done():
+				return bulkResponse{}, ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return bulkResponse{}, xerrors.Errorf("failed to create the bulk request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		switch {
+		case w.APIKey != "":
+			req.Header.Set("Authorization", "ApiKey "+w.APIKey)
+		case w.Username != "" || w.Password != "":
+			req.SetBasicAuth(w.Username, w.Password)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = xerrors.Errorf("bulk request error: %w", err)
+			continue
+		}
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		switch {
+		case err != nil:
+			lastErr = xerrors.Errorf("failed to read the bulk response: %w", err)
+			continue
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError:
+			lastErr = xerrors.Errorf("bulk request failed with %s: %s", resp.Status, b)
+			continue
+		case resp.StatusCode != http.StatusOK:
+			return bulkResponse{}, xerrors.Errorf("bulk request failed with %s: %s", resp.Status, b)
+		}
+
+		var bulkResp bulkResponse
+		if err = json.Unmarshal(b, &bulkResp); err != nil {
+			return bulkResponse{}, xerrors.Errorf("failed to decode the bulk response: %w", err)
+		}
+		return bulkResp, nil
+	}
+	return bulkResponse{}, xerrors.Errorf("failed to send the bulk request after %d attempts: %w", maxAttempts, lastErr)
+}
+
+// parseURL returns the endpoint of the bulk API and the index in the URL
+func parseURL(s string) (string, string, error) {
+	scheme := "https"
+	rest, ok := strings.CutPrefix(s, Scheme)
+	if !ok {
+		if rest, ok = strings.CutPrefix(s, SchemeHTTP); !ok {
+			return "", "", xerrors.Errorf("invalid Elasticsearch URL %q, must start with %q or %q", s, Scheme, SchemeHTTP)
+		}
+		scheme = "http"
+	}
+
+	host, index, _ := strings.Cut(rest, "/")
+	switch {
+	case host == "":
+		return "", "", xerrors.Errorf("Elasticsearch host is missing in %q, e.g. es://localhost:9200/trivy", s)
+	case index == "":
+		return "", "", xerrors.Errorf("Elasticsearch index is missing in %q, e.g. es://localhost:9200/trivy", s)
+	case !validIndex(index):
+		return "", "", xerrors.Errorf("invalid Elasticsearch index %q, must be lowercase without special characters", index)
+	}
+
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, defaultPort)
+	}
+	endpoint := url.URL{
+		Scheme: scheme,
+		Host:   host,
+		Path:   "/_bulk",
+	}
+	return endpoint.String(), index, nil
+}
+
+// validIndex returns whether the index name is valid in Elasticsearch
+func validIndex(index string) bool {
+	if index != strings.ToLower(index) || strings.HasPrefix(index, "_") || strings.HasPrefix(index, "-") ||
+		strings.HasPrefix(index, "+") || index == "." || index == ".." {
+		return false
+	}
+	return !strings.ContainsAny(index, `\/*?"<>| ,#:`)
+}
+
+// documents returns the documents of the findings in the report.
+// Misconfigurations are indexed only when they fail, in the same way as other findings.
+func documents(report types.Report, timestamp time.Time) ([]bulkDocument, error) {
+	var docs []bulkDocument
+	for _, result := range report.Results {
+		newDocument := func(findingType types.FindingType, finding any, key ...string) error {
+			source, err := json.Marshal(document{
+				Timestamp:    timestamp,
+				ArtifactName: report.ArtifactName,
+				ArtifactType: report.ArtifactType,
+				Target:       result.Target,
+				Class:        result.Class,
+				Type:         result.Type,
+				FindingType:  findingType,
+				Finding:      finding,
+			})
+			if err != nil {
+				return xerrors.Errorf("failed to marshal the finding: %w", err)
+			}
+			docs = append(docs, bulkDocument{
+				id:     documentID(findingType, report, result.Target, key...),
+				source: source,
+			})
+			return nil
+		}
+
+		for _, vuln := range result.Vulnerabilities {
+			pkgID := vuln.PkgID
+			if pkgID == "" {
+				pkgID = vuln.PkgName + "@" + vuln.InstalledVersion
+			}
+			if err := newDocument(types.FindingTypeVulnerability, vuln, pkgID, vuln.VulnerabilityID); err != nil {
+				return nil, err
+			}
+		}
+		for _, misconf := range result.Misconfigurations {
+			if misconf.Status != types.MisconfStatusFailure {
+				continue
+			}
+			err := newDocument(types.FindingTypeMisconfiguration, misconf, misconf.AVDID, misconf.CauseMetadata.Resource,
+				fmt.Sprint(misconf.CauseMetadata.StartLine))
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, secret := range result.Secrets {
+			if err := newDocument(types.FindingTypeSecret, secret, secret.RuleID, fmt.Sprint(secret.StartLine)); err != nil {
+				return nil, err
+			}
+		}
+		for _, license := range result.Licenses {
+			if err := newDocument(types.FindingTypeLicense, license, license.PkgName, license.FilePath, license.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return docs, nil
+}
+
+// documentID returns the stable ID of the document, e.g. the hash of the artifact, the target, the package ID
+// and the vulnerability ID.
+// The finding type is a part of the ID so that findings of different types never collide.
+// The artifact is a part of the ID so that artifacts sharing target names, e.g. "package-lock.json" in two repositories,
+// don't overwrite the documents of each other. The image ID is used as well so that different images with the same name,
+// e.g. "alpine:latest" before and after an update, are kept separately.
+func documentID(findingType types.FindingType, report types.Report, target string, key ...string) string {
+	h := sha256.New()
+	artifact := []string{string(findingType), string(report.ArtifactType), report.ArtifactName, report.Metadata.ImageID, target}
+	for _, s := range append(artifact, key...) {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,408 @@
+package elasticsearch_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/report/elasticsearch"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const index = "trivy"
+
+var report = types.Report{
+	CreatedAt:    time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	ArtifactName: "alpine:3.20",
+	ArtifactType: "container_image",
+	Results: types.Results{
+		{
+			Target: "alpine:3.20 (alpine 3.20.0)",
+			Class:  types.ClassOSPkg,
+			Type:   "alpine",
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:  "CVE-2024-5535",
+					PkgID:            "libssl3@3.3.0-r2",
+					PkgName:          "libssl3",
+					InstalledVersion: "3.3.0-r2",
+					FixedVersion:     "3.3.1-r1",
+					Vulnerability: dbTypes.Vulnerability{
+						Severity: "CRITICAL",
+					},
+				},
+				{
+					VulnerabilityID:  "CVE-2024-4741",
+					PkgID:            "libssl3@3.3.0-r2",
+					PkgName:          "libssl3",
+					InstalledVersion: "3.3.0-r2",
+					FixedVersion:     "3.3.1-r0",
+					Vulnerability: dbTypes.Vulnerability{
+						Severity: "HIGH",
+					},
+				},
+			},
+		},
+		{
+			Target: "Dockerfile",
+			Class:  types.ClassConfig,
+			Type:   "dockerfile",
+			Misconfigurations: []types.DetectedMisconfiguration{
+				{
+					AVDID:    "AVD-DS-0002",
+					Severity: "HIGH",
+					Status:   types.MisconfStatusFailure,
+				},
+				{
+					AVDID:    "AVD-DS-0001",
+					Severity: "MEDIUM",
+					Status:   types.MisconfStatusPassed,
+				},
+			},
+		},
+	},
+}
+
+// document is a document received by the bulk endpoint
+type document struct {
+	Index  string
+	ID     string
+	Source map[string]any
+}
+
+// bulkServer is a mock of the bulk API.
+// It responds with the given status codes in order, and fails to index the documents of the given vulnerabilities.
+type bulkServer struct {
+	*httptest.Server
+	t          *testing.T
+	statuses   []int
+	failedIDs  []string
+	requests   int
+	authHeader string
+	documents  []document
+	mu         sync.Mutex
+}
+
+func newBulkServer(t *testing.T, statuses []int, failedIDs ...string) *bulkServer {
+	s := &bulkServer{
+		t:         t,
+		statuses:  statuses,
+		failedIDs: failedIDs,
+	}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *bulkServer) url() string {
+	return elasticsearch.Scheme + strings.TrimPrefix(s.URL, "https://") + "/" + index
+}
+
+func (s *bulkServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	assert.Equal(s.t, http.MethodPost, r.Method)
+	assert.Equal(s.t, "/_bulk", r.URL.Path)
+	assert.Equal(s.t, "application/x-ndjson", r.Header.Get("Content-Type"))
+	s.authHeader = r.Header.Get("Authorization")
+
+	s.requests++
+	if len(s.statuses) >= s.requests {
+		if status := s.statuses[s.requests-1]; status != http.StatusOK {
+			http.Error(w, `{"error":"unavailable"}`, status)
+			return
+		}
+	}
+
+	var items []map[string]any
+	var errors bool
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		var action struct {
+			Index struct {
+				Index string `json:"_index"`
+				ID    string `json:"_id"`
+			} `json:"index"`
+		}
+		require.NoError(s.t, json.Unmarshal(scanner.Bytes(), &action))
+		require.True(s.t, scanner.Scan(), "source is missing")
+		var source map[string]any
+		require.NoError(s.t, json.Unmarshal(scanner.Bytes(), &source))
+
+		item := map[string]any{
+			"_index": action.Index.Index,
+			"_id":    action.Index.ID,
+			"status": http.StatusCreated,
+		}
+		finding := source["Finding"].(map[string]any)
+		if id, _ := finding["VulnerabilityID"].(string); slices.Contains(s.failedIDs, id) {
+			errors = true
+			item["status"] = http.StatusBadRequest
+			item["error"] = map[string]any{
+				"type":   "mapper_parsing_exception",
+				"reason": "failed to parse field [Finding.FixedVersion]",
+			}
+		} else {
+			s.documents = append(s.documents, document{
+				Index:  action.Index.Index,
+				ID:     action.Index.ID,
+				Source: source,
+			})
+		}
+		items = append(items, map[string]any{"index": item})
+	}
+	require.NoError(s.t, json.NewEncoder(w).Encode(map[string]any{
+		"took":   1,
+		"errors": errors,
+		"items":  items,
+	}))
+}
+
+func (s *bulkServer) received() (int, string, []document) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests, s.authHeader, s.documents
+}
+
+func TestWriter_Write(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		failedIDs    []string
+		username     string
+		password     string
+		apiKey       string
+		wantRequests int
+		wantAuth     string
+		wantIndexed  int
+		wantErr      string
+	}{
+		{
+			name:         "happy path",
+			wantRequests: 1,
+			wantIndexed:  3,
+		},
+		{
+			name:         "basic auth",
+			username:     "elastic",
+			password:     "changeme",
+			wantRequests: 1,
+			wantAuth:     "Basic ZWxhc3RpYzpjaGFuZ2VtZQ==",
+			wantIndexed:  3,
+		},
+		{
+			name:         "API key",
+			apiKey:       "VnVhQ2ZHY0JDZGJrUW0tZTVhT3g6dWkybHAyYXhUTm1zeWFrdzl0dk5udw==",
+			wantRequests: 1,
+			wantAuth:     "ApiKey VnVhQ2ZHY0JDZGJrUW0tZTVhT3g6dWkybHAyYXhUTm1zeWFrdzl0dk5udw==",
+			wantIndexed:  3,
+		},
+		{
+			name:         "retry after throttling",
+			statuses:     []int{http.StatusTooManyRequests},
+			wantRequests: 2,
+			wantIndexed:  3,
+		},
+		{
+			name:         "unavailable",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			wantRequests: 3,
+			wantErr:      "after 3 attempts: bulk request failed with 503 Service Unavailable",
+		},
+		{
+			name:         "unauthorized",
+			statuses:     []int{http.StatusUnauthorized},
+			wantRequests: 1,
+			wantErr:      "bulk request failed with 401 Unauthorized",
+		},
+		{
+			name:         "partial failure",
+			failedIDs:    []string{"CVE-2024-5535"},
+			wantRequests: 1,
+			wantIndexed:  2,
+			wantErr:      `failed to index 1 of 3 documents into "trivy"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newBulkServer(t, tt.statuses, tt.failedIDs...)
+			w := elasticsearch.Writer{
+				URL:           s.url(),
+				Username:      tt.username,
+				Password:      tt.password,
+				APIKey:        tt.apiKey,
+				Client:        s.Client(),
+				RetryInterval: time.Millisecond,
+			}
+			err := w.Write(context.Background(), report)
+			requests, auth, docs := s.received()
+			assert.Equal(t, tt.wantRequests, requests)
+			assert.Equal(t, tt.wantAuth, auth)
+			assert.Len(t, docs, tt.wantIndexed)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var ids []string
+			for _, doc := range docs {
+				assert.Equal(t, index, doc.Index)
+				assert.Len(t, doc.ID, 64)
+				assert.Equal(t, "2024-06-01T00:00:00Z", doc.Source["@timestamp"])
+				assert.Equal(t, "alpine:3.20", doc.Source["ArtifactName"])
+				assert.Equal(t, "container_image", doc.Source["ArtifactType"])
+
+				finding := doc.Source["Finding"].(map[string]any)
+				switch doc.Source["FindingType"] {
+				case "vulnerability":
+					assert.Equal(t, "alpine:3.20 (alpine 3.20.0)", doc.Source["Target"])
+					assert.Equal(t, "os-pkgs", doc.Source["Class"])
+					assert.Equal(t, "libssl3", finding["PkgName"])
+					ids = append(ids, finding["VulnerabilityID"].(string))
+				case "misconfiguration":
+					assert.Equal(t, "Dockerfile", doc.Source["Target"])
+					ids = append(ids, finding["AVDID"].(string))
+				}
+			}
+			assert.ElementsMatch(t, []string{
+				"CVE-2024-5535",
+				"CVE-2024-4741",
+				"AVD-DS-0002",
+			}, ids)
+		})
+	}
+}
+
+func TestWriter_Write_stableID(t *testing.T) {
+	s := newBulkServer(t, nil)
+	w := elasticsearch.Writer{
+		URL:    s.url(),
+		Client: s.Client(),
+	}
+
+	// The documents of the next scan have the same IDs so that they are upserted
+	require.NoError(t, w.Write(context.Background(), report))
+	require.NoError(t, w.Write(context.Background(), report))
+	_, _, docs := s.received()
+	require.Len(t, docs, 6)
+	for i := range 3 {
+		assert.Equal(t, docs[i].ID, docs[i+3].ID)
+	}
+
+	// The IDs differ per finding
+	ids := make(map[string]struct{})
+	for _, doc := range docs[:3] {
+		ids[doc.ID] = struct{}{}
+	}
+	assert.Len(t, ids, 3)
+}
+
+func TestWriter_Write_artifactID(t *testing.T) {
+	s := newBulkServer(t, nil)
+	w := elasticsearch.Writer{
+		URL:    s.url(),
+		Client: s.Client(),
+	}
+
+	// Artifacts sharing the target and the findings
+	result := types.Result{
+		Target: "package-lock.json",
+		Class:  types.ClassLangPkg,
+		Type:   "npm",
+		Vulnerabilities: []types.DetectedVulnerability{
+			{
+				VulnerabilityID:  "CVE-2022-24999",
+				PkgID:            "qs@6.7.0",
+				PkgName:          "qs",
+				InstalledVersion: "6.7.0",
+			},
+		},
+	}
+	reports := []types.Report{
+		{
+			ArtifactName: "github.com/org/app1",
+			ArtifactType: "repository",
+			Results:      types.Results{result},
+		},
+		{
+			ArtifactName: "github.com/org/app2",
+			ArtifactType: "repository",
+			Results:      types.Results{result},
+		},
+		{
+			ArtifactName: "app:latest",
+			ArtifactType: "container_image",
+			Metadata: types.Metadata{
+				ImageID: "sha256:0a5a5b6f5b0e8b2e0b4d5e0f3f9d5a3b1a1e4d3c2b1a0f9e8d7c6b5a4f3e2d1c",
+			},
+			Results: types.Results{result},
+		},
+		{
+			ArtifactName: "app:latest",
+			ArtifactType: "container_image",
+			Metadata: types.Metadata{
+				ImageID: "sha256:1b6b6c7a6c1f9c3f1c5e6f1a4a0e6b4c2b2f5e4d3c2b1a0f9e8d7c6b5a4f3e2d",
+			},
+			Results: types.Results{result},
+		},
+	}
+	for _, r := range reports {
+		require.NoError(t, w.Write(context.Background(), r))
+	}
+
+	_, _, docs := s.received()
+	require.Len(t, docs, len(reports))
+	ids := make(map[string]struct{})
+	for _, doc := range docs {
+		ids[doc.ID] = struct{}{}
+	}
+	assert.Len(t, ids, len(reports), "documents of different artifacts must not collide")
+}
+
+func TestWriter_Write_invalidURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr string
+	}{
+		{
+			name:    "missing index",
+			url:     "es://localhost:9200",
+			wantErr: "Elasticsearch index is missing",
+		},
+		{
+			name:    "missing host",
+			url:     "es:///trivy",
+			wantErr: "Elasticsearch host is missing",
+		},
+		{
+			name:    "invalid index",
+			url:     "es://localhost/Trivy",
+			wantErr: `invalid Elasticsearch index "Trivy"`,
+		},
+		{
+			name:    "invalid scheme",
+			url:     "http://localhost:9200/trivy",
+			wantErr: "invalid Elasticsearch URL",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := elasticsearch.Writer{URL: tt.url}.Write(context.Background(), report)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
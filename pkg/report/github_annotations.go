@@ -0,0 +1,153 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// GitHubAnnotationsWriter writes findings as GitHub Actions workflow commands,
+// e.g. "::error file=package-lock.json,line=12,endLine=12,title=CVE-2021-23337 (HIGH)::lodash 4.17.20: ...",
+// so that they are shown as annotations of pull requests.
+// Findings with a file and line are annotated inline, and the others are annotated on the repository.
+// See https://docs.github.com/en/actions/writing-workflows/choosing-what-your-workflow-does/workflow-commands-for-github-actions
+type GitHubAnnotationsWriter struct {
+	Output io.Writer
+}
+
+// annotation represents a workflow command creating an annotation
+type annotation struct {
+	severity  string
+	file      string
+	startLine int
+	endLine   int
+	title     string
+	message   string
+}
+
+func (w GitHubAnnotationsWriter) Write(_ context.Context, report types.Report) error {
+	for _, result := range report.Results {
+		for _, a := range resultAnnotations(result) {
+			if _, err := fmt.Fprintln(w.Output, a.String()); err != nil {
+				return xerrors.Errorf("failed to write the annotation: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func resultAnnotations(result types.Result) []annotation {
+	var annotations []annotation
+	for _, vuln := range result.Vulnerabilities {
+		file := result.Target
+		if vuln.PkgPath != "" {
+			file = vuln.PkgPath
+		}
+		message := fmt.Sprintf("%s %s: %s", vuln.PkgName, vuln.InstalledVersion, vuln.Title)
+		if vuln.FixedVersion != "" {
+			message += fmt.Sprintf(" (fixed version: %s)", vuln.FixedVersion)
+		}
+		if vuln.PrimaryURL != "" {
+			message += "\n" + vuln.PrimaryURL
+		}
+
+		locs := packageLocations(vuln, result.Packages)
+		if len(locs) == 0 {
+			annotations = append(annotations, newAnnotation(vuln.Severity, file, 0, 0, vuln.VulnerabilityID, message))
+		}
+		for _, loc := range locs {
+			annotations = append(annotations, newAnnotation(vuln.Severity, file, loc.StartLine, loc.EndLine, vuln.VulnerabilityID, message))
+		}
+	}
+	for _, misconf := range result.Misconfigurations {
+		if misconf.Status != types.MisconfStatusFailure {
+			continue
+		}
+		message := fmt.Sprintf("%s: %s", misconf.Title, misconf.Message)
+		if misconf.PrimaryURL != "" {
+			message += "\n" + misconf.PrimaryURL
+		}
+		annotations = append(annotations, newAnnotation(misconf.Severity, result.Target,
+			misconf.CauseMetadata.StartLine, misconf.CauseMetadata.EndLine, misconf.ID, message))
+	}
+	for _, secret := range result.Secrets {
+		annotations = append(annotations, newAnnotation(secret.Severity, result.Target,
+			secret.StartLine, secret.EndLine, secret.RuleID, secret.Title))
+	}
+	for _, license := range result.Licenses {
+		file := result.Target
+		if license.FilePath != "" {
+			file = license.FilePath
+		}
+		message := fmt.Sprintf("%s is licensed under %s (%s)", license.PkgName, license.Name, license.Category)
+		if license.PkgName == "" {
+			message = fmt.Sprintf("Licensed under %s (%s)", license.Name, license.Category)
+		}
+		annotations = append(annotations, newAnnotation(license.Severity, file, 0, 0, license.Name, message))
+	}
+	return annotations
+}
+
+// newAnnotation returns the annotation of the finding.
+// Findings without a line, such as OS packages or licenses, are annotated on the repository with the file in the message.
+func newAnnotation(severity, file string, startLine, endLine int, id, message string) annotation {
+	a := annotation{
+		severity: "warning",
+		title:    fmt.Sprintf("%s (%s)", id, severity),
+		message:  message,
+	}
+	if severity == dbTypes.SeverityCritical.String() || severity == dbTypes.SeverityHigh.String() {
+		a.severity = "error"
+	}
+
+	if startLine <= 0 {
+		a.message = fmt.Sprintf("%s: %s", file, message)
+		return a
+	}
+	a.file = file
+	a.startLine = startLine
+	a.endLine = max(endLine, startLine)
+	return a
+}
+
+// String returns the workflow command, e.g. "::error file=app.js,line=1,endLine=1,title=...::..."
+func (a annotation) String() string {
+	var params []string
+	if a.file != "" {
+		params = append(params,
+			"file="+escapeProperty(a.file),
+			fmt.Sprintf("line=%d", a.startLine),
+			fmt.Sprintf("endLine=%d", a.endLine),
+		)
+	}
+	params = append(params, "title="+escapeProperty(a.title))
+	return fmt.Sprintf("::%s %s::%s", a.severity, strings.Join(params, ","), escapeData(a.message))
+}
+
+// packageLocations returns the locations of the vulnerable package in the lock file
+func packageLocations(vuln types.DetectedVulnerability, pkgs []ftypes.Package) []ftypes.Location {
+	for _, pkg := range pkgs {
+		if vuln.PkgID != "" && pkg.ID == vuln.PkgID ||
+			vuln.PkgID == "" && pkg.Name == vuln.PkgName && pkg.Version == vuln.InstalledVersion {
+			return pkg.Locations
+		}
+	}
+	return nil
+}
+
+// escapeData escapes the message of workflow commands
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes the properties of workflow commands, where ':' and ',' are delimiters
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
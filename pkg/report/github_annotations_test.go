@@ -0,0 +1,187 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestGitHubAnnotationsWriter_Write(t *testing.T) {
+	tests := []struct {
+		name    string
+		results types.Results
+		want    string
+	}{
+		{
+			name: "vulnerability with locations",
+			results: types.Results{
+				{
+					Target: "package-lock.json",
+					Class:  types.ClassLangPkg,
+					Type:   ftypes.Npm,
+					Packages: []ftypes.Package{
+						{
+							ID:      "lodash@4.17.20",
+							Name:    "lodash",
+							Version: "4.17.20",
+							Locations: []ftypes.Location{
+								{
+									StartLine: 12,
+									EndLine:   17,
+								},
+							},
+						},
+					},
+					Vulnerabilities: []types.DetectedVulnerability{
+						{
+							VulnerabilityID:  "CVE-2021-23337",
+							PkgID:            "lodash@4.17.20",
+							PkgName:          "lodash",
+							InstalledVersion: "4.17.20",
+							FixedVersion:     "4.17.21",
+							PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2021-23337",
+							Vulnerability: dbTypes.Vulnerability{
+								Title:    "nodejs-lodash: command injection via template",
+								Severity: "HIGH",
+							},
+						},
+					},
+				},
+			},
+			want: "::error file=package-lock.json,line=12,endLine=17,title=CVE-2021-23337 (HIGH)::lodash 4.17.20: nodejs-lodash: command injection via template (fixed version: 4.17.21)%0Ahttps://avd.aquasec.com/nvd/cve-2021-23337\n",
+		},
+		{
+			name: "vulnerability without locations",
+			results: types.Results{
+				{
+					Target: "alpine:3.20 (alpine 3.20.0)",
+					Class:  types.ClassOSPkg,
+					Type:   ftypes.Alpine,
+					Vulnerabilities: []types.DetectedVulnerability{
+						{
+							VulnerabilityID:  "CVE-2024-5535",
+							PkgName:          "libssl3",
+							InstalledVersion: "3.3.0-r2",
+							Vulnerability: dbTypes.Vulnerability{
+								Title:    "openssl: SSL_select_next_proto buffer overread",
+								Severity: "MEDIUM",
+							},
+						},
+					},
+				},
+			},
+			want: "::warning title=CVE-2024-5535 (MEDIUM)::alpine:3.20 (alpine 3.20.0): libssl3 3.3.0-r2: openssl: SSL_select_next_proto buffer overread\n",
+		},
+		{
+			name: "misconfigurations",
+			results: types.Results{
+				{
+					Target: "deploy/Dockerfile",
+					Class:  types.ClassConfig,
+					Type:   ftypes.Dockerfile,
+					Misconfigurations: []types.DetectedMisconfiguration{
+						{
+							ID:       "DS002",
+							Title:    "Image user should not be 'root'",
+							Message:  "Specify at least 1 USER command in Dockerfile with non-root user as argument",
+							Severity: "CRITICAL",
+							Status:   types.MisconfStatusFailure,
+							CauseMetadata: ftypes.CauseMetadata{
+								StartLine: 3,
+								EndLine:   3,
+							},
+						},
+						{
+							ID:       "DS001",
+							Title:    "':latest' tag used",
+							Message:  "Specify a tag in the 'FROM' statement",
+							Severity: "MEDIUM",
+							Status:   types.MisconfStatusPassed,
+						},
+					},
+				},
+			},
+			want: "::error file=deploy/Dockerfile,line=3,endLine=3,title=DS002 (CRITICAL)::Image user should not be 'root': Specify at least 1 USER command in Dockerfile with non-root user as argument\n",
+		},
+		{
+			name: "secret and license",
+			results: types.Results{
+				{
+					Target: "config/.env",
+					Class:  types.ClassSecret,
+					Secrets: []types.DetectedSecret{
+						{
+							RuleID:    "aws-access-key-id",
+							Title:     "AWS Access Key ID",
+							Severity:  "CRITICAL",
+							StartLine: 2,
+							EndLine:   2,
+						},
+					},
+				},
+				{
+					Target: "Node.js",
+					Class:  types.ClassLicense,
+					Licenses: []types.DetectedLicense{
+						{
+							Severity: "LOW",
+							Category: "notice",
+							PkgName:  "lodash",
+							FilePath: "node_modules/lodash/package.json",
+							Name:     "MIT",
+						},
+					},
+				},
+			},
+			want: "::error file=config/.env,line=2,endLine=2,title=aws-access-key-id (CRITICAL)::AWS Access Key ID\n" +
+				"::warning title=MIT (LOW)::node_modules/lodash/package.json: lodash is licensed under MIT (notice)\n",
+		},
+		{
+			name: "escape",
+			results: types.Results{
+				{
+					Target: "app,v1:prod/secrets%.txt",
+					Class:  types.ClassSecret,
+					Secrets: []types.DetectedSecret{
+						{
+							RuleID:    "private-key",
+							Title:     "Asymmetric Private Key\r\n100%",
+							Severity:  "HIGH",
+							StartLine: 1,
+							EndLine:   1,
+						},
+					},
+				},
+			},
+			want: "::error file=app%2Cv1%3Aprod/secrets%25.txt,line=1,endLine=1,title=private-key (HIGH)::Asymmetric Private Key%0D%0A100%25\n",
+		},
+		{
+			name: "no findings",
+			results: types.Results{
+				{
+					Target: "go.mod",
+					Class:  types.ClassLangPkg,
+					Type:   ftypes.GoModule,
+				},
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := report.GitHubAnnotationsWriter{Output: &buf}
+			err := w.Write(context.Background(), types.Report{Results: tt.results})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package report
+
+import (
+	"cmp"
+	"context"
+	_ "embed"
+	"html/template"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+//go:embed templates/report.html
+var htmlPage string
+
+var htmlTemplate = template.Must(template.New("report").Parse(htmlPage))
+
+// HTMLWriter writes the report as a self-contained HTML page.
+// Findings are embedded as JSON and rendered by the bundled script,
+// which supports searching and filtering by severity in the browser without any external assets.
+type HTMLWriter struct {
+	Output  io.Writer
+	Version string
+}
+
+type htmlReport struct {
+	ArtifactName string
+	CreatedAt    string
+	Version      string
+	Severities   []string
+	Findings     []htmlFinding
+}
+
+type htmlFinding struct {
+	Target           string `json:"target"`
+	Kind             string `json:"kind"`
+	ID               string `json:"id"`
+	Severity         string `json:"severity"`
+	Package          string `json:"package,omitempty"`
+	InstalledVersion string `json:"installed,omitempty"`
+	FixedVersion     string `json:"fixed,omitempty"`
+	Title            string `json:"title,omitempty"`
+	URL              string `json:"url,omitempty"`
+}
+
+// Write writes the HTML page.
+// All the values are escaped by html/template, and the script never renders them as HTML.
+func (hw HTMLWriter) Write(_ context.Context, report types.Report) error {
+	// From the highest severity
+	severities := slices.Clone(dbTypes.SeverityNames)
+	slices.Reverse(severities)
+
+	page := htmlReport{
+		ArtifactName: report.ArtifactName,
+		Version:      hw.Version,
+		Severities:   severities,
+		Findings:     htmlFindings(report.Results),
+	}
+	if !report.CreatedAt.IsZero() {
+		page.CreatedAt = report.CreatedAt.UTC().Format(time.RFC3339)
+	}
+
+	if err := htmlTemplate.Execute(hw.Output, page); err != nil {
+		return xerrors.Errorf("failed to write the HTML report: %w", err)
+	}
+	return nil
+}
+
+func htmlFindings(results types.Results) []htmlFinding {
+	// Not nil so that the script always receives an array
+	findings := make([]htmlFinding, 0)
+	for _, result := range results {
+		for _, vuln := range result.Vulnerabilities {
+			findings = append(findings, htmlFinding{
+				Target:           result.Target,
+				Kind:             "Vulnerability",
+				ID:               vuln.VulnerabilityID,
+				Severity:         vuln.Severity,
+				Package:          vuln.PkgName,
+				InstalledVersion: vuln.InstalledVersion,
+				FixedVersion:     vuln.FixedVersion,
+				Title:            vuln.Title,
+				URL:              safeURL(vuln.PrimaryURL),
+			})
+		}
+		for _, misconf := range result.Misconfigurations {
+			if misconf.Status != types.MisconfStatusFailure {
+				continue
+			}
+			findings = append(findings, htmlFinding{
+				Target:   result.Target,
+				Kind:     "Misconfiguration",
+				ID:       misconf.ID,
+				Severity: misconf.Severity,
+				Title:    misconf.Title,
+				URL:      safeURL(misconf.PrimaryURL),
+			})
+		}
+		for _, secret := range result.Secrets {
+			findings = append(findings, htmlFinding{
+				Target:   result.Target + ":" + strconv.Itoa(secret.StartLine),
+				Kind:     "Secret",
+				ID:       secret.RuleID,
+				Severity: secret.Severity,
+				Title:    secret.Title,
+			})
+		}
+		for _, license := range result.Licenses {
+			findings = append(findings, htmlFinding{
+				Target:   result.Target,
+				Kind:     "License",
+				ID:       license.Name,
+				Severity: license.Severity,
+				Package:  cmp.Or(license.PkgName, license.FilePath),
+				Title:    string(license.Category),
+				URL:      safeURL(license.Link),
+			})
+		}
+	}
+	return findings
+}
+
+// safeURL returns the URL only if it is an HTTP(S) URL so that links such as "javascript:" are never rendered.
+func safeURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return u.String()
+}
@@ -0,0 +1,181 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestHTMLWriter_Write(t *testing.T) {
+	tests := []struct {
+		name        string
+		report      types.Report
+		contains    []string
+		notContains []string
+	}{
+		{
+			name: "happy path",
+			report: types.Report{
+				ArtifactName: "alpine:3.20",
+				CreatedAt:    time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+				Results: types.Results{
+					{
+						Target: "alpine:3.20 (alpine 3.20.0)",
+						Class:  types.ClassOSPkg,
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2024-0001",
+								PkgName:          "openssl",
+								InstalledVersion: "3.3.0-r0",
+								FixedVersion:     "3.3.0-r1",
+								PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2024-0001",
+								Vulnerability: dbTypes.Vulnerability{
+									Title:    "openssl: a vulnerability",
+									Severity: "HIGH",
+								},
+							},
+						},
+					},
+					{
+						Target: "Dockerfile",
+						Class:  types.ClassConfig,
+						Misconfigurations: []types.DetectedMisconfiguration{
+							{
+								ID:       "DS002",
+								Title:    "Image user should not be 'root'",
+								Severity: "HIGH",
+								Status:   types.MisconfStatusFailure,
+							},
+							{
+								ID:       "DS001",
+								Title:    "':latest' tag used",
+								Severity: "MEDIUM",
+								Status:   types.MisconfStatusPassed,
+							},
+						},
+					},
+					{
+						Target: "/app/.env",
+						Class:  types.ClassSecret,
+						Secrets: []types.DetectedSecret{
+							{
+								RuleID:    "aws-access-key-id",
+								Title:     "AWS Access Key ID",
+								Severity:  "CRITICAL",
+								StartLine: 3,
+							},
+						},
+					},
+				},
+			},
+			contains: []string{
+				"<title>alpine:3.20 - Trivy Report</title>",
+				"Created at 2024-10-01T12:00:00Z · Trivy 0.57.0",
+				`<input type="checkbox" class="severity-filter" value="CRITICAL" checked> CRITICAL</label>`,
+				`"id":"CVE-2024-0001","severity":"HIGH","package":"openssl","installed":"3.3.0-r0","fixed":"3.3.0-r1"`,
+				`"url":"https://avd.aquasec.com/nvd/cve-2024-0001"`,
+				`"kind":"Misconfiguration","id":"DS002"`,
+				`"target":"/app/.env:3","kind":"Secret","id":"aws-access-key-id"`,
+			},
+			notContains: []string{
+				"DS001",
+				"<script src=",
+				"<link",
+			},
+		},
+		{
+			name: "escape user-controlled content",
+			report: types.Report{
+				ArtifactName: `<img src=x onerror="alert(1)">`,
+				Results: types.Results{
+					{
+						Target: "</script><script>alert(1)</script>",
+						Class:  types.ClassLangPkg,
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID: "CVE-2024-0002",
+								PkgName:         "<b>pkg</b>",
+								PrimaryURL:      "javascript:alert(1)",
+								Vulnerability: dbTypes.Vulnerability{
+									Title:    `"><svg onload=alert(1)>`,
+									Severity: "LOW",
+								},
+							},
+						},
+					},
+				},
+			},
+			contains: []string{
+				"<title>&lt;img src=x onerror=&#34;alert(1)&#34;&gt; - Trivy Report</title>",
+				`"target":"\u003c/script\u003e\u003cscript\u003ealert(1)\u003c/script\u003e"`,
+				`"package":"\u003cb\u003epkg\u003c/b\u003e"`,
+			},
+			notContains: []string{
+				"<img",
+				"</script><script>alert(1)",
+				"<svg",
+				"javascript:",
+			},
+		},
+		{
+			name:   "no findings",
+			report: types.Report{},
+			contains: []string{
+				"<title>Trivy Report</title>",
+				`<script id="trivy-findings" type="application/json">[]</script>`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			w := report.HTMLWriter{
+				Output:  buf,
+				Version: "0.57.0",
+			}
+			err := w.Write(context.Background(), tt.report)
+			require.NoError(t, err)
+
+			got := buf.String()
+			for _, s := range tt.contains {
+				assert.Contains(t, got, s)
+			}
+			for _, s := range tt.notContains {
+				assert.NotContains(t, got, s)
+			}
+		})
+	}
+}
+
+func TestHTMLWriter_Write_License(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := report.HTMLWriter{Output: buf}
+	err := w.Write(context.Background(), types.Report{
+		Results: types.Results{
+			{
+				Target: "OS Packages",
+				Class:  types.ClassLicense,
+				Licenses: []types.DetectedLicense{
+					{
+						Severity: "HIGH",
+						Category: ftypes.CategoryRestricted,
+						PkgName:  "musl",
+						Name:     "GPL-2.0-only",
+						Link:     "https://spdx.org/licenses/GPL-2.0-only.html",
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"kind":"License","id":"GPL-2.0-only","severity":"HIGH","package":"musl","title":"restricted","url":"https://spdx.org/licenses/GPL-2.0-only.html"`)
+}
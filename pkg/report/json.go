@@ -2,21 +2,42 @@ package report
 
 import (
 	"context"
+	_ "embed"
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/samber/lo"
+	"github.com/xeipuuv/gojsonschema"
 	"golang.org/x/xerrors"
 
 	"github.com/aquasecurity/trivy/pkg/types"
 )
 
+//go:embed schema/report.schema.json
+var reportSchema []byte
+
+var loadReportSchema = sync.OnceValues(func() (*gojsonschema.Schema, error) {
+	return gojsonschema.NewSchema(gojsonschema.NewBytesLoader(reportSchema))
+})
+
 // JSONWriter implements result Writer
 type JSONWriter struct {
 	Output         io.Writer
 	ListAllPkgs    bool
 	ShowSuppressed bool
+
+	// Compact omits empty and zero-valued fields and writes minified JSON
+	Compact bool
+
+	// Validate validates the JSON report against the embedded JSON schema before writing
+	Validate bool
 }
 
 // Write writes the results in JSON format
@@ -28,22 +49,237 @@ func (jw JSONWriter) Write(_ context.Context, report types.Report) error {
 		}
 	}
 	if !jw.ShowSuppressed {
-		// Delete suppressed findings
+		// Delete suppressed findings, but keep VEX decisions so that the reason of the suppression is always available
 		for i := range report.Results {
-			report.Results[i].ModifiedFindings = nil
+			report.Results[i].ModifiedFindings = lo.Filter(report.Results[i].ModifiedFindings, func(m types.ModifiedFinding, _ int) bool {
+				return m.IsVEX()
+			})
 		}
 	}
 	report.Results = lo.Filter(report.Results, func(r types.Result, _ int) bool {
 		return r.Target != "" || !r.IsEmpty()
 	})
 
-	output, err := json.MarshalIndent(report, "", "  ")
+	var output []byte
+	var err error
+	if jw.Compact {
+		v, _ := compactValue(reflect.ValueOf(report))
+		output, err = json.Marshal(v)
+	} else {
+		output, err = json.MarshalIndent(report, "", "  ")
+	}
 	if err != nil {
 		return xerrors.Errorf("failed to marshal json: %w", err)
 	}
+	if jw.Validate {
+		if err = validateJSON(output); err != nil {
+			return xerrors.Errorf("json validation error: %w", err)
+		}
+	}
 
 	if _, err = fmt.Fprintln(jw.Output, string(output)); err != nil {
 		return xerrors.Errorf("failed to write json: %w", err)
 	}
 	return nil
 }
+
+// validateJSON validates the JSON report against the embedded JSON schema.
+func validateJSON(output []byte) error {
+	schema, err := loadReportSchema()
+	if err != nil {
+		return xerrors.Errorf("failed to load the JSON schema: %w", err)
+	}
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(output))
+	if err != nil {
+		return xerrors.Errorf("failed to validate the JSON report: %w", err)
+	} else if !result.Valid() {
+		errs := lo.Map(result.Errors(), func(err gojsonschema.ResultError, _ int) string {
+			return err.String()
+		})
+		return xerrors.Errorf("the report does not match the JSON schema:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// compactValue converts v into a value that is marshaled without empty or zero-valued fields.
+// It follows the rules of "encoding/json" so that the output can be unmarshaled into the original types.
+// Map entries are always kept, as their keys carry information even when the values are zero (e.g. VendorSeverity).
+// The second return value reports whether v is empty.
+func compactValue(v reflect.Value) (any, bool) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil, true
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, true
+		}
+	}
+
+	// Types with custom marshalers are marshaled as they are
+	if v.Kind() != reflect.Pointer && v.CanAddr() && implementsMarshaler(reflect.PointerTo(v.Type())) {
+		v = v.Addr()
+	}
+	if implementsMarshaler(v.Type()) {
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			// Leave the error to the final marshaling
+			return v.Interface(), false
+		}
+		switch string(b) {
+		case "null", `""`, "[]", "{}":
+			return json.RawMessage(b), true
+		}
+		// e.g. time.Time{}
+		return json.RawMessage(b), reflect.Indirect(v).IsZero()
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return compactValue(v.Elem())
+	case reflect.Struct:
+		fields := compactStruct(v)
+		return fields, len(fields) == 0
+	case reflect.Map:
+		if v.Len() == 0 {
+			return nil, true
+		}
+		m := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := mapKey(iter.Key())
+			if err != nil {
+				return v.Interface(), false
+			}
+			m[key], _ = compactValue(iter.Value())
+		}
+		return m, false
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return nil, true
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// Keep []byte encoded as base64
+			return v.Interface(), false
+		}
+		elems := make([]any, v.Len())
+		for i := range v.Len() {
+			elems[i], _ = compactValue(v.Index(i))
+		}
+		return elems, false
+	default:
+		return v.Interface(), v.IsZero()
+	}
+}
+
+// compactStruct returns non-empty fields of the struct v keyed by their JSON names.
+// Fields of embedded structs are promoted unless they conflict with the fields of the outer struct.
+func compactStruct(v reflect.Value) map[string]any {
+	fields := make(map[string]any)
+	names := make(map[string]struct{})
+	var embedded []reflect.Value
+
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		fv := v.Field(i)
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && !implementsMarshaler(f.Type) {
+				if fv.Kind() == reflect.Pointer {
+					if fv.IsNil() {
+						continue
+					}
+					fv = fv.Elem()
+				}
+				embedded = append(embedded, fv)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = struct{}{}
+		val, empty := compactValue(fv)
+		if empty {
+			continue
+		}
+		if quotedField(f.Type, opts) {
+			if _, ok := val.(json.RawMessage); !ok {
+				// The ",string" option encodes the value as a JSON string, e.g. "1" instead of 1
+				b, err := json.Marshal(val)
+				if err == nil {
+					val = string(b)
+				}
+			}
+		}
+		fields[name] = val
+	}
+
+	for _, ev := range embedded {
+		for name, val := range compactStruct(ev) {
+			if _, ok := names[name]; ok {
+				continue
+			}
+			fields[name] = val
+		}
+	}
+	return fields
+}
+
+// quotedField reports whether the field of the type t is encoded as a JSON string with the ",string" option.
+// As in "encoding/json", the option applies only to strings, booleans and numbers, and pointers to them.
+func quotedField(t reflect.Type, opts string) bool {
+	if !slices.Contains(strings.Split(opts, ","), "string") {
+		return false
+	}
+	if t.Name() == "" && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func implementsMarshaler(t reflect.Type) bool {
+	return t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType)
+}
+
+// mapKey returns the JSON object key for the map key k in the same way as "encoding/json".
+func mapKey(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+	if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	}
+	return "", xerrors.Errorf("unsupported map key type: %s", k.Type())
+}
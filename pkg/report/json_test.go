@@ -5,7 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -17,9 +19,11 @@ import (
 
 func TestReportWriter_JSON(t *testing.T) {
 	testCases := []struct {
-		name          string
-		detectedVulns []types.DetectedVulnerability
-		want          types.Report
+		name             string
+		detectedVulns    []types.DetectedVulnerability
+		modifiedFindings []types.ModifiedFinding
+		compact          bool
+		want             types.Report
 	}{
 		{
 			name: "happy path",
@@ -67,13 +71,106 @@ func TestReportWriter_JSON(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "compact",
+			detectedVulns: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:  "CVE-2020-0001",
+					PkgName:          "foo",
+					InstalledVersion: "1.2.3",
+					Status:           dbTypes.StatusAffected,
+					Vulnerability: dbTypes.Vulnerability{
+						Title:    "foobar",
+						Severity: "HIGH",
+						VendorSeverity: map[dbTypes.SourceID]dbTypes.Severity{
+							vulnerability.NVD: dbTypes.SeverityUnknown,
+						},
+					},
+				},
+			},
+			compact: true,
+			want: types.Report{
+				SchemaVersion: 2,
+				ArtifactName:  "alpine:3.14",
+				Results: types.Results{
+					types.Result{
+						Target: "foojson",
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2020-0001",
+								PkgName:          "foo",
+								InstalledVersion: "1.2.3",
+								Status:           dbTypes.StatusAffected,
+								Vulnerability: dbTypes.Vulnerability{
+									Title:    "foobar",
+									Severity: "HIGH",
+									VendorSeverity: map[dbTypes.SourceID]dbTypes.Severity{
+										vulnerability.NVD: dbTypes.SeverityUnknown,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "VEX decisions are kept",
+			modifiedFindings: []types.ModifiedFinding{
+				{
+					Type:      types.FindingTypeVulnerability,
+					Status:    types.FindingStatusNotAffected,
+					Statement: "vulnerable_code_not_in_execute_path",
+					Source:    "openvex.json",
+					Finding: types.DetectedVulnerability{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+					},
+				},
+				{
+					Type:      types.FindingTypeVulnerability,
+					Status:    types.FindingStatusIgnored,
+					Statement: "Not exploitable",
+					Source:    ".trivyignore.yaml",
+					Finding: types.DetectedVulnerability{
+						VulnerabilityID:  "CVE-2020-0002",
+						PkgName:          "bar",
+						InstalledVersion: "4.5.6",
+					},
+				},
+			},
+			want: types.Report{
+				SchemaVersion: 2,
+				ArtifactName:  "alpine:3.14",
+				Results: types.Results{
+					types.Result{
+						Target: "foojson",
+						ModifiedFindings: []types.ModifiedFinding{
+							{
+								Type:      types.FindingTypeVulnerability,
+								Status:    types.FindingStatusNotAffected,
+								Statement: "vulnerable_code_not_in_execute_path",
+								Source:    "openvex.json",
+								Finding: types.DetectedVulnerability{
+									VulnerabilityID:  "CVE-2020-0001",
+									PkgName:          "foo",
+									InstalledVersion: "1.2.3",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			jsonWritten := bytes.NewBuffer(nil)
 			jw := report.JSONWriter{
-				Output: jsonWritten,
+				Output:  jsonWritten,
+				Compact: tc.compact,
 			}
 
 			inputResults := types.Report{
@@ -81,8 +178,9 @@ func TestReportWriter_JSON(t *testing.T) {
 				ArtifactName:  "alpine:3.14",
 				Results: types.Results{
 					{
-						Target:          "foojson",
-						Vulnerabilities: tc.detectedVulns,
+						Target:           "foojson",
+						Vulnerabilities:  tc.detectedVulns,
+						ModifiedFindings: tc.modifiedFindings,
 					},
 				},
 			}
@@ -94,7 +192,155 @@ func TestReportWriter_JSON(t *testing.T) {
 			err = json.Unmarshal(jsonWritten.Bytes(), &got)
 			require.NoError(t, err, "invalid json written")
 
+			if tc.compact {
+				assert.NotContains(t, jsonWritten.String(), `""`)
+				assert.NotContains(t, jsonWritten.String(), "\n  ")
+			}
+
 			assert.Equal(t, tc.want, got, tc.name)
 		})
 	}
 }
+
+func TestReportWriter_JSON_compactStringOption(t *testing.T) {
+	type custom struct {
+		Count   int      `json:",string"`
+		Name    string   `json:"name,omitempty,string"`
+		Fixed   *bool    `json:",string"`
+		Zero    int      `json:",omitempty,string"`
+		Aliases []string `json:",string"`
+	}
+	v := custom{
+		Count:   3,
+		Name:    "foo",
+		Fixed:   lo.ToPtr(true),
+		Aliases: []string{"GHSA-xxxx"},
+	}
+
+	var buf bytes.Buffer
+	jw := report.JSONWriter{
+		Output:  &buf,
+		Compact: true,
+	}
+	err := jw.Write(context.Background(), types.Report{
+		Results: types.Results{
+			{
+				Target: "foo",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID: "CVE-2020-0001",
+						Custom:          v,
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	var got struct {
+		Results []struct {
+			Vulnerabilities []struct {
+				Custom json.RawMessage
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+
+	// The option is applied in the same way as "encoding/json"
+	want, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(want), string(got.Results[0].Vulnerabilities[0].Custom))
+}
+
+func TestReportWriter_JSON_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(r *types.Report)
+		compact bool
+		wantErr string
+	}{
+		{
+			name: "valid report",
+		},
+		{
+			name:    "valid compact report",
+			compact: true,
+		},
+		{
+			name: "valid report with banner",
+			corrupt: func(r *types.Report) {
+				r.Banner = "CONFIDENTIAL"
+			},
+		},
+		{
+			name: "unknown severity",
+			corrupt: func(r *types.Report) {
+				r.Results[0].Vulnerabilities[0].Severity = "SUPER"
+			},
+			wantErr: "Results.0.Vulnerabilities.0.Severity must be one of the following",
+		},
+		{
+			name: "missing vulnerability ID",
+			corrupt: func(r *types.Report) {
+				r.Results[0].Vulnerabilities[0].VulnerabilityID = ""
+			},
+			wantErr: "Results.0.Vulnerabilities.0: VulnerabilityID is required",
+		},
+		{
+			name: "old schema version",
+			corrupt: func(r *types.Report) {
+				r.SchemaVersion = 1
+			},
+			wantErr: "SchemaVersion does not match: 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := types.Report{
+				SchemaVersion: 2,
+				CreatedAt:     time.Date(2024, 10, 31, 12, 0, 0, 0, time.UTC),
+				ArtifactName:  "alpine:3.14",
+				Results: types.Results{
+					{
+						Target: "foojson",
+						Class:  types.ClassLangPkg,
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2020-0001",
+								PkgName:          "foo",
+								InstalledVersion: "1.2.3",
+								FixedVersion:     "3.4.5",
+								Status:           dbTypes.StatusFixed,
+								Vulnerability: dbTypes.Vulnerability{
+									Severity: "HIGH",
+									VendorSeverity: map[dbTypes.SourceID]dbTypes.Severity{
+										vulnerability.NVD: dbTypes.SeverityHigh,
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			if tt.corrupt != nil {
+				tt.corrupt(&r)
+			}
+
+			output := bytes.NewBuffer(nil)
+			jw := report.JSONWriter{
+				Output:   output,
+				Compact:  tt.compact,
+				Validate: true,
+			}
+			err := jw.Write(context.Background(), r)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				assert.Empty(t, output.String(), "an invalid report must not be written")
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, output.String())
+		})
+	}
+}
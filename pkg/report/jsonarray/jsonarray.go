@@ -0,0 +1,67 @@
+package jsonarray
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"unicode"
+
+	"golang.org/x/xerrors"
+)
+
+// Append appends the JSON value as the last element of the top-level JSON array in the file.
+// The file is created if it doesn't exist.
+// The file is locked while being updated so that concurrent processes can append to the same file.
+// The existing content is left untouched if the file doesn't contain a JSON array.
+func Append(path string, element []byte) error {
+	element = bytes.TrimSpace(element)
+	if !json.Valid(element) {
+		return xerrors.New("the element to append is not valid JSON")
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return xerrors.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if err = lock(f); err != nil {
+		return xerrors.Errorf("failed to lock %s: %w", path, err)
+	}
+	defer func() { _ = unlock(f) }()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return xerrors.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var offset int64
+	var buf bytes.Buffer
+	if trimmed := bytes.TrimSpace(content); len(trimmed) == 0 {
+		buf.WriteString("[\n")
+	} else {
+		var elements []json.RawMessage
+		if trimmed[0] != '[' {
+			return xerrors.Errorf("%s is not a JSON array, refusing to append to it", path)
+		} else if err = json.Unmarshal(trimmed, &elements); err != nil {
+			return xerrors.Errorf("%s is not a valid JSON array, refusing to append to it: %w", path, err)
+		}
+		// Overwrite the closing bracket of the array
+		offset = int64(len(bytes.TrimRightFunc(content[:bytes.LastIndexByte(content, ']')], unicode.IsSpace)))
+		if len(elements) > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	buf.Write(element)
+	buf.WriteString("\n]\n")
+
+	if _, err = f.WriteAt(buf.Bytes(), offset); err != nil {
+		return xerrors.Errorf("failed to write %s: %w", path, err)
+	}
+	if err = f.Truncate(offset + int64(buf.Len())); err != nil {
+		return xerrors.Errorf("failed to truncate %s: %w", path, err)
+	}
+	return nil
+}
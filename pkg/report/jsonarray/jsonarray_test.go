@@ -0,0 +1,130 @@
+package jsonarray_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/report/jsonarray"
+)
+
+func TestAppend(t *testing.T) {
+	tests := []struct {
+		name    string
+		content *string
+		element string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "new file",
+			element: `{"ArtifactName": "a"}`,
+			want: `[
+{"ArtifactName": "a"}
+]
+`,
+		},
+		{
+			name:    "empty file",
+			content: lo.ToPtr("\n"),
+			element: `{"ArtifactName": "a"}`,
+			want: `[
+{"ArtifactName": "a"}
+]
+`,
+		},
+		{
+			name:    "empty array",
+			content: lo.ToPtr("[]\n"),
+			element: `{"ArtifactName": "a"}` + "\n",
+			want: `[
+{"ArtifactName": "a"}
+]
+`,
+		},
+		{
+			name: "existing elements",
+			content: lo.ToPtr(`[
+{"ArtifactName": "a"}
+]
+`),
+			element: `{"ArtifactName": "b"}`,
+			want: `[
+{"ArtifactName": "a"},
+{"ArtifactName": "b"}
+]
+`,
+		},
+		{
+			name:    "not an array",
+			content: lo.ToPtr(`{"ArtifactName": "a"}`),
+			element: `{"ArtifactName": "b"}`,
+			wantErr: "is not a JSON array",
+		},
+		{
+			name:    "truncated array",
+			content: lo.ToPtr(`[{"ArtifactName": "a"}`),
+			element: `{"ArtifactName": "b"}`,
+			wantErr: "is not a valid JSON array",
+		},
+		{
+			name:    "invalid element",
+			element: `{"ArtifactName": `,
+			wantErr: "not valid JSON",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "report.json")
+			if tt.content != nil {
+				require.NoError(t, os.WriteFile(path, []byte(*tt.content), 0o600))
+			}
+
+			err := jsonarray.Append(path, []byte(tt.element))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				if tt.content != nil {
+					// The existing content must be kept as is
+					got, err := os.ReadFile(path)
+					require.NoError(t, err)
+					assert.Equal(t, *tt.content, string(got))
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+			assert.True(t, json.Valid(got))
+		})
+	}
+}
+
+func TestAppend_Concurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, jsonarray.Append(path, []byte(fmt.Sprintf(`{"ArtifactName": "%d"}`, i))))
+		}()
+	}
+	wg.Wait()
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var elements []map[string]string
+	require.NoError(t, json.Unmarshal(got, &elements))
+	assert.Len(t, elements, 20)
+}
@@ -0,0 +1,179 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/clock"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const (
+	// Scheme is the prefix of the output to publish the report to Kafka, e.g. "kafka://localhost:9092/trivy"
+	Scheme = "kafka://"
+
+	defaultPort = "9092"
+
+	clientID = "trivy"
+
+	// Number of attempts to connect to the brokers and to publish messages
+	maxAttempts = 3
+)
+
+// Legal characters of topic names in Kafka
+var topicRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+
+// Writer publishes each finding to a Kafka topic as a JSON message.
+// The target is used as the message key so that the findings of the same target go to the same partition.
+type Writer struct {
+	// URL has the bootstrap brokers and the topic, e.g. "kafka://broker1:9092,broker2:9092/trivy".
+	// The port is 9092 when omitted.
+	URL string
+
+	// RetryInterval is the interval between attempts to connect and to publish messages (1 second by default)
+	RetryInterval time.Duration
+}
+
+// message is the value of a Kafka message.
+// It is self-contained with the artifact and the target so that consumers can process each message alone.
+type message struct {
+	ArtifactName string
+	ArtifactType artifact.Type `json:",omitempty"`
+	Target       string
+	Class        types.ResultClass `json:",omitempty"`
+	Type         ftypes.TargetType `json:",omitempty"`
+	FindingType  types.FindingType
+	Finding      any
+}
+
+func (w Writer) Write(ctx context.Context, report types.Report) error {
+	brokers, topic, err := parseURL(w.URL)
+	if err != nil {
+		return err
+	}
+
+	records, err := messages(report)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		log.DebugContext(ctx, "No findings to publish to Kafka")
+		return nil
+	}
+
+	interval := w.RetryInterval
+	if interval == 0 {
+		interval = time.Second
+	}
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(brokers...),
+		kgo.ClientID(clientID),
+		kgo.DefaultProduceTopic(topic),
+		kgo.RequestRetries(maxAttempts),
+		kgo.RecordRetries(maxAttempts),
+		kgo.UnknownTopicRetries(maxAttempts),
+		kgo.RetryBackoffFn(func(int) time.Duration { return interval }),
+		kgo.MetadataMinAge(interval),
+	)
+	if err != nil {
+		return xerrors.Errorf("failed to create a Kafka client: %w", err)
+	}
+	defer client.Close()
+
+	for _, r := range records {
+		r.Timestamp = clock.Now(ctx)
+	}
+	if err = client.ProduceSync(ctx, records...).FirstErr(); err != nil {
+		return xerrors.Errorf("failed to publish findings to Kafka topic %q: %w", topic, err)
+	}
+	log.DebugContext(ctx, "Published findings to Kafka", log.String("topic", topic), log.Int("messages", len(records)))
+	return nil
+}
+
+// parseURL returns the brokers and the topic in the URL
+func parseURL(s string) ([]string, string, error) {
+	rest, ok := strings.CutPrefix(s, Scheme)
+	if !ok {
+		return nil, "", xerrors.Errorf("invalid Kafka URL %q, must start with %q", s, Scheme)
+	}
+	hosts, topic, _ := strings.Cut(rest, "/")
+	switch {
+	case hosts == "":
+		return nil, "", xerrors.Errorf("Kafka broker is missing in %q, e.g. kafka://localhost:9092/trivy", s)
+	case topic == "":
+		return nil, "", xerrors.Errorf("Kafka topic is missing in %q, e.g. kafka://localhost:9092/trivy", s)
+	case !topicRegexp.MatchString(topic):
+		return nil, "", xerrors.Errorf("invalid Kafka topic %q", topic)
+	}
+
+	var brokers []string
+	for _, host := range strings.Split(hosts, ",") {
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			host = net.JoinHostPort(host, defaultPort)
+		}
+		brokers = append(brokers, host)
+	}
+	return brokers, topic, nil
+}
+
+// messages returns the records of the findings in the report.
+// Misconfigurations are published only when they fail, in the same way as other findings.
+func messages(report types.Report) ([]*kgo.Record, error) {
+	var records []*kgo.Record
+	for _, result := range report.Results {
+		newRecord := func(findingType types.FindingType, finding any) error {
+			value, err := json.Marshal(message{
+				ArtifactName: report.ArtifactName,
+				ArtifactType: report.ArtifactType,
+				Target:       result.Target,
+				Class:        result.Class,
+				Type:         result.Type,
+				FindingType:  findingType,
+				Finding:      finding,
+			})
+			if err != nil {
+				return xerrors.Errorf("failed to marshal the finding: %w", err)
+			}
+			records = append(records, &kgo.Record{
+				Key:   []byte(result.Target),
+				Value: value,
+			})
+			return nil
+		}
+
+		for _, vuln := range result.Vulnerabilities {
+			if err := newRecord(types.FindingTypeVulnerability, vuln); err != nil {
+				return nil, err
+			}
+		}
+		for _, misconf := range result.Misconfigurations {
+			if misconf.Status != types.MisconfStatusFailure {
+				continue
+			}
+			if err := newRecord(types.FindingTypeMisconfiguration, misconf); err != nil {
+				return nil, err
+			}
+		}
+		for _, secret := range result.Secrets {
+			if err := newRecord(types.FindingTypeSecret, secret); err != nil {
+				return nil, err
+			}
+		}
+		for _, license := range result.Licenses {
+			if err := newRecord(types.FindingTypeLicense, license); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,432 @@
+package kafka_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/clock"
+	"github.com/aquasecurity/trivy/pkg/report/kafka"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const topic = "trivy"
+
+var report = types.Report{
+	ArtifactName: "alpine:3.20",
+	ArtifactType: "container_image",
+	Results: types.Results{
+		{
+			Target: "alpine:3.20 (alpine 3.20.0)",
+			Class:  types.ClassOSPkg,
+			Type:   "alpine",
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:  "CVE-2024-5535",
+					PkgName:          "libssl3",
+					InstalledVersion: "3.3.0-r2",
+					FixedVersion:     "3.3.1-r1",
+					Vulnerability: dbTypes.Vulnerability{
+						Severity: "CRITICAL",
+					},
+				},
+				{
+					VulnerabilityID:  "CVE-2024-4741",
+					PkgName:          "libssl3",
+					InstalledVersion: "3.3.0-r2",
+					FixedVersion:     "3.3.1-r0",
+					Vulnerability: dbTypes.Vulnerability{
+						Severity: "HIGH",
+					},
+				},
+			},
+		},
+		{
+			Target: "Dockerfile",
+			Class:  types.ClassConfig,
+			Type:   "dockerfile",
+			Misconfigurations: []types.DetectedMisconfiguration{
+				{
+					AVDID:    "AVD-DS-0002",
+					Severity: "HIGH",
+					Status:   types.MisconfStatusFailure,
+				},
+				{
+					AVDID:    "AVD-DS-0001",
+					Severity: "MEDIUM",
+					Status:   types.MisconfStatusPassed,
+				},
+			},
+		},
+	},
+}
+
+// message is a message received by the broker
+type message struct {
+	Partition int32
+	Key       string
+	Value     map[string]any
+}
+
+// broker is an in-process Kafka broker with a single node serving one topic
+type broker struct {
+	t          *testing.T
+	ln         net.Listener
+	partitions int32
+
+	mu            sync.Mutex
+	produceErrors []int16 // Error codes returned to the produce requests in order
+	produced      int     // Number of the produce requests
+	messages      []message
+}
+
+func newBroker(t *testing.T, partitions int32, produceErrors ...int16) *broker {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	b := &broker{
+		t:             t,
+		ln:            ln,
+		partitions:    partitions,
+		produceErrors: produceErrors,
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(conn)
+		}
+	}()
+	return b
+}
+
+// received returns the number of the produce requests and the messages
+func (b *broker) received() (int, []message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.produced, b.messages
+}
+
+func (b *broker) url(topic string) string {
+	return kafka.Scheme + b.ln.Addr().String() + "/" + topic
+}
+
+func (b *broker) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		var size int32
+		if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+			return
+		}
+		buf := make([]byte, size)
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			return
+		}
+
+		// Request header: API key, API version, correlation ID and client ID
+		key := int16(binary.BigEndian.Uint16(buf))
+		version := int16(binary.BigEndian.Uint16(buf[2:]))
+		correlationID := binary.BigEndian.Uint32(buf[4:])
+		n := int(binary.BigEndian.Uint16(buf[8:]))
+		assert.Equal(b.t, "trivy", string(buf[10:10+n]))
+		body := buf[10+n:]
+
+		req := kmsg.RequestForKey(key)
+		require.NotNil(b.t, req, "API key: %d", key)
+		req.SetVersion(version)
+		if req.IsFlexible() {
+			body = body[1:] // No tagged fields in the header
+		}
+		require.NoError(b.t, req.ReadFrom(body))
+
+		resp := b.handle(req)
+		resp.SetVersion(version)
+		out := binary.BigEndian.AppendUint32(nil, correlationID)
+		if resp.IsFlexible() && key != kmsg.ApiVersions.Int16() {
+			out = append(out, 0) // No tagged fields in the header
+		}
+		out = resp.AppendTo(out)
+		if _, err := conn.Write(append(binary.BigEndian.AppendUint32(nil, uint32(len(out))), out...)); err != nil {
+			return
+		}
+	}
+}
+
+func (b *broker) handle(req kmsg.Request) kmsg.Response {
+	switch req := req.(type) {
+	case *kmsg.ApiVersionsRequest:
+		resp := req.ResponseKind().(*kmsg.ApiVersionsResponse)
+		for _, r := range []kmsg.Request{
+			kmsg.NewPtrApiVersionsRequest(),
+			kmsg.NewPtrMetadataRequest(),
+			kmsg.NewPtrInitProducerIDRequest(),
+			kmsg.NewPtrProduceRequest(),
+		} {
+			k := kmsg.NewApiVersionsResponseApiKey()
+			k.ApiKey = r.Key()
+			k.MaxVersion = r.MaxVersion()
+			resp.ApiKeys = append(resp.ApiKeys, k)
+		}
+		return resp
+	case *kmsg.MetadataRequest:
+		return b.metadata(req)
+	case *kmsg.InitProducerIDRequest:
+		resp := req.ResponseKind().(*kmsg.InitProducerIDResponse)
+		resp.ProducerID = 1
+		return resp
+	case *kmsg.ProduceRequest:
+		return b.produce(req)
+	default:
+		require.Failf(b.t, "unexpected request", "API key: %d", req.Key())
+		return nil
+	}
+}
+
+func (b *broker) metadata(req *kmsg.MetadataRequest) kmsg.Response {
+	host, port, err := net.SplitHostPort(b.ln.Addr().String())
+	require.NoError(b.t, err)
+	portNum, err := strconv.Atoi(port)
+	require.NoError(b.t, err)
+
+	resp := req.ResponseKind().(*kmsg.MetadataResponse)
+	node := kmsg.NewMetadataResponseBroker()
+	node.Host = host
+	node.Port = int32(portNum)
+	resp.Brokers = append(resp.Brokers, node)
+
+	for _, rt := range req.Topics {
+		t := kmsg.NewMetadataResponseTopic()
+		t.Topic = rt.Topic
+		if lo.FromPtr(rt.Topic) != topic {
+			t.ErrorCode = kerr.UnknownTopicOrPartition.Code
+			resp.Topics = append(resp.Topics, t)
+			continue
+		}
+		for i := range b.partitions {
+			p := kmsg.NewMetadataResponseTopicPartition()
+			p.Partition = i
+			p.Replicas = []int32{0}
+			p.ISR = []int32{0}
+			t.Partitions = append(t.Partitions, p)
+		}
+		resp.Topics = append(resp.Topics, t)
+	}
+	return resp
+}
+
+func (b *broker) produce(req *kmsg.ProduceRequest) kmsg.Response {
+	assert.EqualValues(b.t, -1, req.Acks)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var code int16
+	if b.produced < len(b.produceErrors) {
+		code = b.produceErrors[b.produced]
+	}
+	b.produced++
+
+	resp := req.ResponseKind().(*kmsg.ProduceResponse)
+	for _, rt := range req.Topics {
+		t := kmsg.NewProduceResponseTopic()
+		t.Topic = rt.Topic
+		for _, rp := range rt.Partitions {
+			if code == 0 {
+				b.readBatch(rp.Partition, rp.Records)
+			}
+			p := kmsg.NewProduceResponseTopicPartition()
+			p.Partition = rp.Partition
+			p.ErrorCode = code
+			t.Partitions = append(t.Partitions, p)
+		}
+		resp.Topics = append(resp.Topics, t)
+	}
+	return resp
+}
+
+func (b *broker) readBatch(partition int32, raw []byte) {
+	var batch kmsg.RecordBatch
+	require.NoError(b.t, batch.ReadFrom(raw))
+	require.EqualValues(b.t, 2, batch.Magic)
+	assert.Equal(b.t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC).UnixMilli(), batch.FirstTimestamp)
+
+	// The CRC covers the batch from the attributes to the end
+	assert.Equal(b.t, crc32.Checksum(raw[21:], crc32.MakeTable(crc32.Castagnoli)), uint32(batch.CRC))
+
+	records := batch.Records
+	switch codec := batch.Attributes & 0x07; codec {
+	case 0: // none
+	case 2: // snappy
+		var err error
+		records, err = s2.Decode(nil, records)
+		require.NoError(b.t, err)
+	default:
+		require.Failf(b.t, "unexpected compression", "codec: %d", codec)
+	}
+
+	for i := range batch.NumRecords {
+		length, n := binary.Varint(records)
+		require.Positive(b.t, n)
+		var rec kmsg.Record
+		require.NoError(b.t, rec.ReadFrom(records[:n+int(length)]))
+		records = records[n+int(length):]
+		assert.EqualValues(b.t, i, rec.OffsetDelta)
+
+		var v map[string]any
+		require.NoError(b.t, json.Unmarshal(rec.Value, &v))
+		b.messages = append(b.messages, message{
+			Partition: partition,
+			Key:       string(rec.Key),
+			Value:     v,
+		})
+	}
+}
+
+func TestWriter_Write(t *testing.T) {
+	tests := []struct {
+		name          string
+		topic         string
+		produceErrors []int16
+		wantProduced  int
+		wantErr       string
+	}{
+		{
+			name:         "happy path",
+			topic:        topic,
+			wantProduced: 1,
+		},
+		{
+			name:          "retry after leader election",
+			topic:         topic,
+			produceErrors: []int16{6}, // NOT_LEADER_OR_FOLLOWER
+			wantProduced:  2,
+		},
+		{
+			name:          "delivery failure",
+			topic:         topic,
+			produceErrors: []int16{7, 7, 7}, // REQUEST_TIMED_OUT
+			wantProduced:  3,
+			wantErr:       "REQUEST_TIMED_OUT",
+		},
+		{
+			name:          "non-retriable error",
+			topic:         topic,
+			produceErrors: []int16{29}, // TOPIC_AUTHORIZATION_FAILED
+			wantProduced:  1,
+			wantErr:       "TOPIC_AUTHORIZATION_FAILED",
+		},
+		{
+			name:    "unknown topic",
+			topic:   "unknown",
+			wantErr: `Kafka topic "unknown": no partitions available after attempting to refresh metadata 3 times, last err: UNKNOWN_TOPIC_OR_PARTITION`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBroker(t, 3, tt.produceErrors...)
+			ctx := clock.With(context.Background(), time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+			w := kafka.Writer{
+				URL:           b.url(tt.topic),
+				RetryInterval: 10 * time.Millisecond,
+			}
+			err := w.Write(ctx, report)
+			produced, messages := b.received()
+			assert.Equal(t, tt.wantProduced, produced)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Empty(t, messages)
+				return
+			}
+			require.NoError(t, err)
+
+			// The messages of the same target are in the same partition
+			require.Len(t, messages, 3)
+			var ids []string
+			partitions := make(map[string]int32)
+			for _, msg := range messages {
+				assert.Equal(t, msg.Key, msg.Value["Target"])
+				assert.Equal(t, "alpine:3.20", msg.Value["ArtifactName"])
+				if p, ok := partitions[msg.Key]; ok {
+					assert.Equal(t, p, msg.Partition)
+				}
+				partitions[msg.Key] = msg.Partition
+
+				finding := msg.Value["Finding"].(map[string]any)
+				switch msg.Value["FindingType"] {
+				case "vulnerability":
+					ids = append(ids, finding["VulnerabilityID"].(string))
+				case "misconfiguration":
+					ids = append(ids, finding["AVDID"].(string))
+				}
+			}
+			assert.ElementsMatch(t, []string{
+				"CVE-2024-5535",
+				"CVE-2024-4741",
+				"AVD-DS-0002",
+			}, ids)
+		})
+	}
+}
+
+func TestWriter_Write_connectionError(t *testing.T) {
+	// Reserve a port with no broker
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	w := kafka.Writer{
+		URL:           kafka.Scheme + addr + "/" + topic,
+		RetryInterval: 10 * time.Millisecond,
+	}
+	err = w.Write(context.Background(), report)
+	assert.ErrorContains(t, err, "refresh metadata 3 times")
+	assert.ErrorContains(t, err, "dial tcp "+addr)
+}
+
+func TestWriter_Write_invalidURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr string
+	}{
+		{
+			name:    "missing topic",
+			url:     "kafka://localhost:9092",
+			wantErr: "Kafka topic is missing",
+		},
+		{
+			name:    "missing broker",
+			url:     "kafka:///trivy",
+			wantErr: "Kafka broker is missing",
+		},
+		{
+			name:    "invalid topic",
+			url:     "kafka://localhost/trivy/findings",
+			wantErr: `invalid Kafka topic "trivy/findings"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := kafka.Writer{URL: tt.url}.Write(context.Background(), report)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,458 @@
+// Package qrcode implements a minimal QR code encoder to render short texts such as URLs in terminals.
+// Only the byte mode and the error correction level L are supported, up to version 10 (271 bytes).
+package qrcode
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// version represents the parameters of a QR code version with the error correction level L
+type version struct {
+	// Number of error correction codewords per block
+	ecCodewords int
+	// Number of data codewords of each block
+	blocks []int
+	// Center positions of alignment patterns
+	alignments []int
+}
+
+// cf. ISO/IEC 18004:2015 Table 9 and Annex E
+var versions = []version{
+	{ecCodewords: 7, blocks: []int{19}},
+	{ecCodewords: 10, blocks: []int{34}, alignments: []int{6, 18}},
+	{ecCodewords: 15, blocks: []int{55}, alignments: []int{6, 22}},
+	{ecCodewords: 20, blocks: []int{80}, alignments: []int{6, 26}},
+	{ecCodewords: 26, blocks: []int{108}, alignments: []int{6, 30}},
+	{ecCodewords: 18, blocks: []int{68, 68}, alignments: []int{6, 34}},
+	{ecCodewords: 20, blocks: []int{78, 78}, alignments: []int{6, 22, 38}},
+	{ecCodewords: 24, blocks: []int{97, 97}, alignments: []int{6, 24, 42}},
+	{ecCodewords: 30, blocks: []int{116, 116}, alignments: []int{6, 26, 46}},
+	{ecCodewords: 18, blocks: []int{68, 68, 69, 69}, alignments: []int{6, 28, 50}},
+}
+
+// Format bits of the error correction level L
+const ecLevelL = 1
+
+// Number of light modules around the symbol
+const quietZone = 2
+
+// Code represents an encoded QR code
+type Code struct {
+	size     int
+	modules  [][]bool // true for dark modules
+	reserved [][]bool // function patterns which must not be masked
+}
+
+// Encode encodes the text into a QR code of the smallest version that fits.
+func Encode(text string) (*Code, error) {
+	for i, v := range versions {
+		data, ok := v.encodeData([]byte(text), i+1)
+		if !ok {
+			continue
+		}
+		c := newCode(i + 1)
+		c.drawFunctionPatterns(i+1, v)
+		c.drawCodewords(v.interleave(data))
+		c.applyBestMask()
+		return c, nil
+	}
+	return nil, xerrors.Errorf("text too long for a QR code: %d bytes", len(text))
+}
+
+func newCode(ver int) *Code {
+	size := ver*4 + 17
+	c := &Code{
+		size:     size,
+		modules:  make([][]bool, size),
+		reserved: make([][]bool, size),
+	}
+	for i := range size {
+		c.modules[i] = make([]bool, size)
+		c.reserved[i] = make([]bool, size)
+	}
+	return c
+}
+
+// Render writes the QR code with half block characters so that each line holds two rows of modules.
+// Colors are set explicitly as scanners expect dark modules on a light background regardless of the terminal theme.
+func (c *Code) Render(w io.Writer) error {
+	dark := func(row, col int) bool {
+		row, col = row-quietZone, col-quietZone
+		if row < 0 || col < 0 || row >= c.size || col >= c.size {
+			return false
+		}
+		return c.modules[row][col]
+	}
+
+	var sb strings.Builder
+	total := c.size + quietZone*2
+	for row := 0; row < total; row += 2 {
+		sb.WriteString("\x1b[30;107m")
+		for col := range total {
+			switch top, bottom := dark(row, col), dark(row+1, col); {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\x1b[0m\n")
+	}
+	if _, err := fmt.Fprint(w, sb.String()); err != nil {
+		return xerrors.Errorf("failed to write the QR code: %w", err)
+	}
+	return nil
+}
+
+// encodeData returns the data codewords in the byte mode, or false if the data doesn't fit in the version.
+func (v version) encodeData(data []byte, ver int) ([]byte, bool) {
+	capacity := 0
+	for _, n := range v.blocks {
+		capacity += n
+	}
+
+	countBits := 8
+	if ver >= 10 {
+		countBits = 16
+	}
+	if 4+countBits+len(data)*8 > capacity*8 {
+		return nil, false
+	}
+
+	var bb bitBuffer
+	bb.append(0b0100, 4) // byte mode
+	bb.append(len(data), countBits)
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+	// Terminator and padding to a byte boundary
+	bb.append(0, min(4, capacity*8-len(bb)))
+	bb.append(0, (8-len(bb)%8)%8)
+
+	codewords := bb.bytes()
+	for i := 0; len(codewords) < capacity; i++ {
+		codewords = append(codewords, []byte{0xEC, 0x11}[i%2])
+	}
+	return codewords, true
+}
+
+// interleave splits the data into blocks, appends error correction codewords and interleaves them.
+func (v version) interleave(data []byte) []byte {
+	divisor := reedSolomonDivisor(v.ecCodewords)
+
+	var dataBlocks, ecBlocks [][]byte
+	var maxLen int
+	for _, n := range v.blocks {
+		block := data[:n]
+		data = data[n:]
+		dataBlocks = append(dataBlocks, block)
+		ecBlocks = append(ecBlocks, reedSolomonRemainder(block, divisor))
+		maxLen = max(maxLen, n)
+	}
+
+	var result []byte
+	for i := range maxLen {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := range v.ecCodewords {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+func (c *Code) set(row, col int, dark bool) {
+	c.modules[row][col] = dark
+	c.reserved[row][col] = true
+}
+
+func (c *Code) drawFunctionPatterns(ver int, v version) {
+	// Timing patterns
+	for i := range c.size {
+		c.set(6, i, i%2 == 0)
+		c.set(i, 6, i%2 == 0)
+	}
+
+	// Finder patterns with separators
+	c.drawFinder(3, 3)
+	c.drawFinder(3, c.size-4)
+	c.drawFinder(c.size-4, 3)
+
+	// Alignment patterns, except those overlapping the finder patterns
+	last := len(v.alignments) - 1
+	for i, row := range v.alignments {
+		for j, col := range v.alignments {
+			if i == 0 && j == 0 || i == 0 && j == last || i == last && j == 0 {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					c.set(row+dy, col+dx, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// Reserve the format information area with the dummy mask, which is overwritten after masking
+	c.drawFormat(0)
+
+	if ver >= 7 {
+		c.drawVersion(ver)
+	}
+}
+
+func (c *Code) drawFinder(centerRow, centerCol int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			row, col := centerRow+dy, centerCol+dx
+			if row < 0 || col < 0 || row >= c.size || col >= c.size {
+				continue
+			}
+			d := max(abs(dx), abs(dy))
+			c.set(row, col, d != 2 && d != 4)
+		}
+	}
+}
+
+// formatBits returns the 15-bit format information with the BCH code
+func formatBits(mask int) int {
+	data := ecLevelL<<3 | mask
+	rem := data
+	for range 10 {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// versionBits returns the 18-bit version information with the BCH code
+func versionBits(ver int) int {
+	rem := ver
+	for range 12 {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	return ver<<12 | rem
+}
+
+func (c *Code) drawFormat(mask int) {
+	bits := formatBits(mask)
+
+	// Around the top-left finder pattern
+	for i := 0; i <= 5; i++ {
+		c.set(i, 8, bit(bits, i))
+	}
+	c.set(7, 8, bit(bits, 6))
+	c.set(8, 8, bit(bits, 7))
+	c.set(8, 7, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.set(8, 14-i, bit(bits, i))
+	}
+
+	// Next to the other finder patterns
+	for i := range 8 {
+		c.set(8, c.size-1-i, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.set(c.size-15+i, 8, bit(bits, i))
+	}
+	c.set(c.size-8, 8, true) // dark module
+}
+
+func (c *Code) drawVersion(ver int) {
+	bits := versionBits(ver)
+	for i := range 18 {
+		a, b := c.size-11+i%3, i/3
+		c.set(b, a, bit(bits, i))
+		c.set(a, b, bit(bits, i))
+	}
+}
+
+// drawCodewords places the codewords in the zigzag order from the bottom-right corner
+func (c *Code) drawCodewords(codewords []byte) {
+	var i int
+	for right := c.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		upward := (right+1)&2 == 0
+		for vert := range c.size {
+			row := vert
+			if upward {
+				row = c.size - 1 - vert
+			}
+			for j := range 2 {
+				col := right - j
+				if c.reserved[row][col] || i >= len(codewords)*8 {
+					continue
+				}
+				c.modules[row][col] = codewords[i/8]>>(7-i%8)&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+var masks = []func(row, col int) bool{
+	func(row, col int) bool { return (row+col)%2 == 0 },
+	func(row, _ int) bool { return row%2 == 0 },
+	func(_, col int) bool { return col%3 == 0 },
+	func(row, col int) bool { return (row+col)%3 == 0 },
+	func(row, col int) bool { return (row/2+col/3)%2 == 0 },
+	func(row, col int) bool { return row*col%2+row*col%3 == 0 },
+	func(row, col int) bool { return (row*col%2+row*col%3)%2 == 0 },
+	func(row, col int) bool { return ((row+col)%2+row*col%3)%2 == 0 },
+}
+
+func (c *Code) applyMask(mask int) {
+	for row := range c.size {
+		for col := range c.size {
+			if !c.reserved[row][col] && masks[mask](row, col) {
+				c.modules[row][col] = !c.modules[row][col]
+			}
+		}
+	}
+}
+
+// applyBestMask applies the mask with the lowest penalty score.
+func (c *Code) applyBestMask() {
+	best, bestPenalty := 0, -1
+	for mask := range masks {
+		c.applyMask(mask)
+		c.drawFormat(mask)
+		if p := c.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		c.applyMask(mask) // XOR again to revert
+	}
+	c.applyMask(best)
+	c.drawFormat(best)
+}
+
+// penalty evaluates the symbol according to ISO/IEC 18004:2015 7.8.3
+func (c *Code) penalty() int {
+	var score, darkCount int
+	finderLike := []string{"10111010000", "00001011101"}
+	for i := range c.size {
+		row, col := make([]byte, c.size), make([]byte, c.size)
+		for j := range c.size {
+			row[j], col[j] = '0', '0'
+			if c.modules[i][j] {
+				row[j] = '1'
+				darkCount++
+			}
+			if c.modules[j][i] {
+				col[j] = '1'
+			}
+			// Blocks of the same color
+			if i+1 < c.size && j+1 < c.size {
+				m := c.modules[i][j]
+				if c.modules[i][j+1] == m && c.modules[i+1][j] == m && c.modules[i+1][j+1] == m {
+					score += 3
+				}
+			}
+		}
+		for _, line := range []string{string(row), string(col)} {
+			// Adjacent modules of the same color
+			run := 1
+			for j := 1; j <= len(line); j++ {
+				if j < len(line) && line[j] == line[j-1] {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += run - 2
+				}
+				run = 1
+			}
+			// Patterns similar to the finder patterns
+			for _, p := range finderLike {
+				score += strings.Count(line, p) * 40
+			}
+		}
+	}
+	// Proportion of dark modules
+	percent := darkCount * 100 / (c.size * c.size)
+	score += abs(percent-50) / 5 * 10
+	return score
+}
+
+type bitBuffer []bool
+
+func (bb *bitBuffer) append(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*bb = append(*bb, value>>i&1 == 1)
+	}
+}
+
+func (bb bitBuffer) bytes() []byte {
+	result := make([]byte, len(bb)/8)
+	for i, b := range bb {
+		if b {
+			result[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return result
+}
+
+// reedSolomonDivisor returns the generator polynomial of the given degree without the leading term
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for range degree {
+		for j := range degree {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < degree {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coef := range divisor {
+			result[i] ^= gfMultiply(coef, factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies two elements of GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1
+func gfMultiply(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11D
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
+
+func bit(x, i int) bool {
+	return x>>i&1 == 1
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,197 @@
+package qrcode
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		wantVersion int
+		wantErr     string
+	}{
+		{
+			name:        "short url",
+			text:        "https://avd.aquasec.com/nvd/cve-2021-44228",
+			wantVersion: 3,
+		},
+		{
+			name:        "multiple blocks",
+			text:        "https://example.com/" + strings.Repeat("a", 110),
+			wantVersion: 6,
+		},
+		{
+			name:        "version information",
+			text:        "https://example.com/" + strings.Repeat("b", 200),
+			wantVersion: 9,
+		},
+		{
+			name:        "16-bit character count",
+			text:        strings.Repeat("c", 271),
+			wantVersion: 10,
+		},
+		{
+			name:    "too long",
+			text:    strings.Repeat("d", 272),
+			wantErr: "text too long for a QR code: 272 bytes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Encode(tt.text)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVersion*4+17, c.size)
+			assert.Equal(t, tt.text, decode(t, c))
+		})
+	}
+}
+
+func TestCode_Render(t *testing.T) {
+	c, err := Encode("https://avd.aquasec.com/nvd/cve-2021-44228")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, c.Render(&buf))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, (c.size+quietZone*2+1)/2)
+	// The top edge of the finder pattern in the top-left corner
+	assert.True(t, strings.HasPrefix(lines[1], "\x1b[30;107m  █▀▀▀▀▀█ "), lines[1])
+	assert.True(t, strings.HasSuffix(lines[1], "\x1b[0m"))
+}
+
+func Test_reedSolomonRemainder(t *testing.T) {
+	// "HELLO WORLD" in the version 1-M
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	assert.Equal(t, want, reedSolomonRemainder(data, reedSolomonDivisor(10)))
+}
+
+func Test_formatBits(t *testing.T) {
+	assert.Equal(t, 0b111011111000100, formatBits(0))
+	assert.Equal(t, 0b110011000101111, formatBits(4))
+}
+
+func Test_versionBits(t *testing.T) {
+	assert.Equal(t, 0b000111110010010100, versionBits(7))
+	assert.Equal(t, 0b001010010011010011, versionBits(10))
+}
+
+// decode reads the data back from the symbol, checking the format information and error correction codewords.
+func decode(t *testing.T, c *Code) string {
+	ver := (c.size - 17) / 4
+	v := versions[ver-1]
+
+	// Format information around the top-left finder pattern
+	var format int
+	for i := 0; i <= 5; i++ {
+		format |= b2i(c.modules[i][8]) << i
+	}
+	format |= b2i(c.modules[7][8])<<6 | b2i(c.modules[8][8])<<7 | b2i(c.modules[8][7])<<8
+	for i := 9; i < 15; i++ {
+		format |= b2i(c.modules[8][14-i]) << i
+	}
+	mask := -1
+	for m := range masks {
+		if formatBits(m) == format {
+			mask = m
+		}
+	}
+	require.NotEqual(t, -1, mask, "invalid format information")
+
+	// The layout of function patterns
+	layout := newCode(ver)
+	layout.drawFunctionPatterns(ver, v)
+
+	var bits []bool
+	for right := c.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := range c.size {
+			row := vert
+			if upward {
+				row = c.size - 1 - vert
+			}
+			for j := range 2 {
+				col := right - j
+				if layout.reserved[row][col] {
+					continue
+				}
+				bits = append(bits, c.modules[row][col] != masks[mask](row, col))
+			}
+		}
+	}
+	codewords := bitBuffer(bits).bytes()
+
+	// De-interleave
+	var total int
+	for _, n := range v.blocks {
+		total += n
+	}
+	dataBlocks := make([][]byte, len(v.blocks))
+	ecBlocks := make([][]byte, len(v.blocks))
+	var pos int
+	for i := 0; pos < total; i++ {
+		for b, n := range v.blocks {
+			if i < n {
+				dataBlocks[b] = append(dataBlocks[b], codewords[pos])
+				pos++
+			}
+		}
+	}
+	for range v.ecCodewords {
+		for b := range v.blocks {
+			ecBlocks[b] = append(ecBlocks[b], codewords[pos])
+			pos++
+		}
+	}
+
+	var data []byte
+	for b := range v.blocks {
+		require.Equal(t, ecBlocks[b], reedSolomonRemainder(dataBlocks[b], reedSolomonDivisor(v.ecCodewords)))
+		data = append(data, dataBlocks[b]...)
+	}
+
+	// Byte mode segment
+	var stream bitBuffer
+	for _, d := range data {
+		stream.append(int(d), 8)
+	}
+	read := func(n int) int {
+		var x int
+		for _, b := range stream[:n] {
+			x = x<<1 | b2i(b)
+		}
+		stream = stream[n:]
+		return x
+	}
+	require.Equal(t, 0b0100, read(4))
+	countBits := 8
+	if ver >= 10 {
+		countBits = 16
+	}
+	text := make([]byte, read(countBits))
+	for i := range text {
+		text[i] = byte(read(8))
+	}
+	return string(text)
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,104 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report/table"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// RemediationReport is the output of "--format remediation-json".
+// The patches are grouped by file so that a remediation bot can edit each file at once.
+type RemediationReport struct {
+	ArtifactName string        `json:",omitempty"`
+	ArtifactType artifact.Type `json:",omitempty"`
+	Files        []RemediationFile
+}
+
+// RemediationFile represents a file to edit and the patches to apply to it
+type RemediationFile struct {
+	Path      string
+	Ecosystem ftypes.TargetType
+	Patches   []RemediationPatch
+}
+
+// RemediationPatch represents an upgrade of a package fixing a vulnerability
+type RemediationPatch struct {
+	VulnerabilityID string
+	Severity        string
+	PkgID           string `json:",omitempty"`
+	PkgName         string
+	CurrentVersion  string
+	TargetVersion   string
+	Locations       []ftypes.Location // Locations of the package in the file
+}
+
+// RemediationJSONWriter writes the patches upgrading vulnerable packages for remediation bots.
+// Only vulnerabilities with a fixed version in a package with locations, e.g. in lock files, are written.
+type RemediationJSONWriter struct {
+	Output io.Writer
+}
+
+func (w RemediationJSONWriter) Write(_ context.Context, report types.Report) error {
+	output, err := json.MarshalIndent(remediationReport(report), "", "  ")
+	if err != nil {
+		return xerrors.Errorf("failed to marshal the remediation report: %w", err)
+	}
+	if _, err = w.Output.Write(append(output, '\n')); err != nil {
+		return xerrors.Errorf("failed to write the remediation report: %w", err)
+	}
+	return nil
+}
+
+func remediationReport(report types.Report) RemediationReport {
+	files := make(map[string]*RemediationFile)
+	var paths []string
+	for _, result := range report.Results {
+		for _, vuln := range result.Vulnerabilities {
+			locs := packageLocations(vuln, result.Packages)
+			target := table.TargetVersion(vuln.InstalledVersion, vuln.FixedVersion)
+			if len(locs) == 0 || target == "" {
+				continue
+			}
+
+			path := result.Target
+			if vuln.PkgPath != "" {
+				path = vuln.PkgPath
+			}
+			file, ok := files[path]
+			if !ok {
+				file = &RemediationFile{
+					Path:      path,
+					Ecosystem: result.Type,
+				}
+				files[path] = file
+				paths = append(paths, path)
+			}
+			file.Patches = append(file.Patches, RemediationPatch{
+				VulnerabilityID: vuln.VulnerabilityID,
+				Severity:        vuln.Severity,
+				PkgID:           vuln.PkgID,
+				PkgName:         vuln.PkgName,
+				CurrentVersion:  vuln.InstalledVersion,
+				TargetVersion:   target,
+				Locations:       locs,
+			})
+		}
+	}
+
+	r := RemediationReport{
+		ArtifactName: report.ArtifactName,
+		ArtifactType: report.ArtifactType,
+		Files:        []RemediationFile{}, // Bots can iterate the files without checking null
+	}
+	for _, path := range paths {
+		r.Files = append(r.Files, *files[path])
+	}
+	return r
+}
@@ -0,0 +1,162 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestRemediationJSONWriter_Write(t *testing.T) {
+	vuln := func(id, pkgID, pkgName, installedVersion, fixedVersion string) types.DetectedVulnerability {
+		return types.DetectedVulnerability{
+			VulnerabilityID:  id,
+			PkgID:            pkgID,
+			PkgName:          pkgName,
+			InstalledVersion: installedVersion,
+			FixedVersion:     fixedVersion,
+			Vulnerability: dbTypes.Vulnerability{
+				Severity: "HIGH",
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		results types.Results
+		want    []report.RemediationFile
+	}{
+		{
+			name: "lock file",
+			results: types.Results{
+				{
+					Target: "app/package-lock.json",
+					Class:  types.ClassLangPkg,
+					Type:   ftypes.Npm,
+					Packages: []ftypes.Package{
+						{
+							ID:      "lodash@4.17.20",
+							Name:    "lodash",
+							Version: "4.17.20",
+							Locations: []ftypes.Location{
+								{
+									StartLine: 12,
+									EndLine:   17,
+								},
+							},
+						},
+						{
+							ID:      "minimist@1.2.5",
+							Name:    "minimist",
+							Version: "1.2.5",
+							Locations: []ftypes.Location{
+								{
+									StartLine: 20,
+									EndLine:   25,
+								},
+							},
+						},
+						{
+							ID:      "ms@2.0.0",
+							Name:    "ms",
+							Version: "2.0.0",
+						},
+					},
+					Vulnerabilities: []types.DetectedVulnerability{
+						// The minimal fixed version newer than the installed version
+						vuln("CVE-2021-23337", "lodash@4.17.20", "lodash", "4.17.20", "3.10.2, 4.17.21, 5.0.0"),
+						vuln("CVE-2021-44906", "minimist@1.2.5", "minimist", "1.2.5", "1.2.6"),
+						// Without a fixed version
+						vuln("CVE-2024-0001", "minimist@1.2.5", "minimist", "1.2.5", ""),
+						// Without locations
+						vuln("CVE-2017-20162", "ms@2.0.0", "ms", "2.0.0", "2.0.1"),
+					},
+				},
+			},
+			want: []report.RemediationFile{
+				{
+					Path:      "app/package-lock.json",
+					Ecosystem: ftypes.Npm,
+					Patches: []report.RemediationPatch{
+						{
+							VulnerabilityID: "CVE-2021-23337",
+							Severity:        "HIGH",
+							PkgID:           "lodash@4.17.20",
+							PkgName:         "lodash",
+							CurrentVersion:  "4.17.20",
+							TargetVersion:   "4.17.21",
+							Locations: []ftypes.Location{
+								{
+									StartLine: 12,
+									EndLine:   17,
+								},
+							},
+						},
+						{
+							VulnerabilityID: "CVE-2021-44906",
+							Severity:        "HIGH",
+							PkgID:           "minimist@1.2.5",
+							PkgName:         "minimist",
+							CurrentVersion:  "1.2.5",
+							TargetVersion:   "1.2.6",
+							Locations: []ftypes.Location{
+								{
+									StartLine: 20,
+									EndLine:   25,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "OS packages",
+			results: types.Results{
+				{
+					Target: "alpine:3.20 (alpine 3.20.0)",
+					Class:  types.ClassOSPkg,
+					Type:   ftypes.Alpine,
+					Packages: []ftypes.Package{
+						{
+							ID:      "libssl3@3.3.0-r2",
+							Name:    "libssl3",
+							Version: "3.3.0-r2",
+						},
+					},
+					Vulnerabilities: []types.DetectedVulnerability{
+						vuln("CVE-2024-5535", "libssl3@3.3.0-r2", "libssl3", "3.3.0-r2", "3.3.1-r1"),
+					},
+				},
+			},
+			want: []report.RemediationFile{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			w := report.RemediationJSONWriter{Output: out}
+			err := w.Write(context.Background(), types.Report{
+				ArtifactName: "app",
+				ArtifactType: artifact.TypeFilesystem,
+				Results:      tt.results,
+			})
+			require.NoError(t, err)
+
+			var got report.RemediationReport
+			require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+			assert.Equal(t, "app", got.ArtifactName)
+			assert.Equal(t, artifact.TypeFilesystem, got.ArtifactType)
+			assert.Equal(t, tt.want, got.Files)
+		})
+	}
+}
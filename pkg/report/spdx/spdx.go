@@ -14,23 +14,29 @@ import (
 )
 
 type Writer struct {
-	output    io.Writer
-	version   string
-	format    types.Format
-	marshaler *spdx.Marshaler
+	output            io.Writer
+	version           string
+	format            types.Format
+	relationshipsOnly bool // Write only the DEPENDS_ON relationships of the dependency graph
+	marshaler         *spdx.Marshaler
 }
 
-func NewWriter(output io.Writer, version string, spdxFormat types.Format) Writer {
+func NewWriter(output io.Writer, version string, spdxFormat types.Format, relationshipsOnly bool) Writer {
 	return Writer{
-		output:    output,
-		version:   version,
-		format:    spdxFormat,
-		marshaler: spdx.NewMarshaler(version),
+		output:            output,
+		version:           version,
+		format:            spdxFormat,
+		relationshipsOnly: relationshipsOnly,
+		marshaler:         spdx.NewMarshaler(version),
 	}
 }
 
 func (w Writer) Write(ctx context.Context, report types.Report) error {
-	spdxDoc, err := w.marshaler.MarshalReport(ctx, report)
+	marshal := w.marshaler.MarshalReport
+	if w.relationshipsOnly {
+		marshal = w.marshaler.MarshalRelationships
+	}
+	spdxDoc, err := marshal(ctx, report)
 	if err != nil {
 		return xerrors.Errorf("failed to marshal spdx: %w", err)
 	}
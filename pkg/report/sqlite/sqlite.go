@@ -0,0 +1,243 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"golang.org/x/xerrors"
+	_ "modernc.org/sqlite" // sqlite driver
+
+	"github.com/aquasecurity/trivy/pkg/clock"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const (
+	driverName = "sqlite"
+
+	kindVulnerability    = "vulnerability"
+	kindMisconfiguration = "misconfiguration"
+	kindSecret           = "secret"
+	kindLicense          = "license"
+)
+
+// migrations are applied in order to bring the schema up to date.
+// The schema version is stored as "PRAGMA user_version", which is the number of applied migrations.
+// Never modify released migrations; append a new one instead.
+var migrations = []string{
+	// Version 1
+	`CREATE TABLE scans (
+		id            INTEGER PRIMARY KEY AUTOINCREMENT,
+		created_at    TEXT NOT NULL,
+		artifact_name TEXT NOT NULL,
+		artifact_type TEXT NOT NULL,
+		trivy_version TEXT NOT NULL
+	);
+	CREATE TABLE targets (
+		id      INTEGER PRIMARY KEY AUTOINCREMENT,
+		scan_id INTEGER NOT NULL REFERENCES scans (id) ON DELETE CASCADE,
+		target  TEXT NOT NULL,
+		class   TEXT NOT NULL,
+		type    TEXT NOT NULL
+	);
+	CREATE TABLE findings (
+		id                INTEGER PRIMARY KEY AUTOINCREMENT,
+		target_id         INTEGER NOT NULL REFERENCES targets (id) ON DELETE CASCADE,
+		kind              TEXT NOT NULL,
+		finding_id        TEXT NOT NULL,
+		severity          TEXT NOT NULL,
+		pkg_name          TEXT NOT NULL,
+		installed_version TEXT NOT NULL,
+		fixed_version     TEXT NOT NULL,
+		status            TEXT NOT NULL,
+		title             TEXT NOT NULL,
+		primary_url       TEXT NOT NULL
+	);
+	CREATE INDEX targets_scan_id ON targets (scan_id);
+	CREATE INDEX findings_target_id ON findings (target_id);
+	CREATE INDEX findings_finding_id ON findings (finding_id);`,
+}
+
+// schemaVersion is the version of the database schema created by the writer.
+var schemaVersion = len(migrations)
+
+// Writer appends the report to a SQLite database so that findings of multiple scans can be queried over time.
+// Each report is stored as a row of the "scans" table, with its results in "targets" and findings in "findings".
+type Writer struct {
+	// Path is the path to the database file, which is created if it doesn't exist.
+	Path    string
+	Version string
+}
+
+type finding struct {
+	kind             string
+	id               string
+	severity         string
+	pkgName          string
+	installedVersion string
+	fixedVersion     string
+	status           string
+	title            string
+	primaryURL       string
+}
+
+func (w Writer) Write(ctx context.Context, report types.Report) error {
+	db, err := open(ctx, w.Path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return xerrors.Errorf("failed to begin a transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	createdAt := report.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = clock.Now(ctx)
+	}
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO scans (created_at, artifact_name, artifact_type, trivy_version) VALUES (?, ?, ?, ?)`,
+		createdAt.UTC().Format(time.RFC3339Nano), report.ArtifactName, string(report.ArtifactType), w.Version)
+	if err != nil {
+		return xerrors.Errorf("failed to insert the scan: %w", err)
+	}
+	scanID, err := res.LastInsertId()
+	if err != nil {
+		return xerrors.Errorf("failed to get the scan ID: %w", err)
+	}
+
+	insertFinding, err := tx.PrepareContext(ctx, `INSERT INTO findings (target_id, kind, finding_id, severity, pkg_name, installed_version, fixed_version, status, title, primary_url) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return xerrors.Errorf("failed to prepare the statement: %w", err)
+	}
+	defer func() { _ = insertFinding.Close() }()
+
+	for _, result := range report.Results {
+		res, err = tx.ExecContext(ctx, `INSERT INTO targets (scan_id, target, class, type) VALUES (?, ?, ?, ?)`,
+			scanID, result.Target, string(result.Class), string(result.Type))
+		if err != nil {
+			return xerrors.Errorf("failed to insert the target %q: %w", result.Target, err)
+		}
+		targetID, err := res.LastInsertId()
+		if err != nil {
+			return xerrors.Errorf("failed to get the target ID: %w", err)
+		}
+
+		for _, f := range findings(result) {
+			if _, err = insertFinding.ExecContext(ctx, targetID, f.kind, f.id, f.severity, f.pkgName,
+				f.installedVersion, f.fixedVersion, f.status, f.title, f.primaryURL); err != nil {
+				return xerrors.Errorf("failed to insert the finding %q: %w", f.id, err)
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return xerrors.Errorf("failed to commit the transaction: %w", err)
+	}
+	return nil
+}
+
+// open opens the database, creating the schema if absent and migrating it if it is older than schemaVersion.
+func open(ctx context.Context, path string) (*sql.DB, error) {
+	if path == "" {
+		return nil, xerrors.New("the database path is empty")
+	}
+
+	// SQLite disables foreign keys on every connection by default, which "ON DELETE CASCADE" relies on
+	db, err := sql.Open(driverName, path+"?_pragma=foreign_keys(1)")
+	if err != nil {
+		return nil, xerrors.Errorf("failed to open %s: %w", path, err)
+	}
+	if err = migrate(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, xerrors.Errorf("failed to migrate %s: %w", path, err)
+	}
+	return db, nil
+}
+
+func migrate(ctx context.Context, db *sql.DB) error {
+	// The schema is updated in a transaction so that a failed migration doesn't leave a partial schema
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return xerrors.Errorf("failed to begin a transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var version int
+	if err = tx.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return xerrors.Errorf("failed to get the schema version: %w", err)
+	}
+
+	switch {
+	case version == schemaVersion:
+		return nil
+	case version > schemaVersion:
+		return xerrors.Errorf("the schema version %d is newer than the supported version %d, please update Trivy", version, schemaVersion)
+	}
+
+	for _, m := range migrations[version:] {
+		if _, err = tx.ExecContext(ctx, m); err != nil {
+			return xerrors.Errorf("failed to apply the migration to version %d: %w", version+1, err)
+		}
+		version++
+	}
+	// PRAGMA doesn't support placeholders
+	if _, err = tx.ExecContext(ctx, "PRAGMA user_version = "+strconv.Itoa(version)); err != nil {
+		return xerrors.Errorf("failed to set the schema version: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return xerrors.Errorf("failed to commit the migration: %w", err)
+	}
+	return nil
+}
+
+func findings(result types.Result) []finding {
+	var fs []finding
+	for _, vuln := range result.Vulnerabilities {
+		fs = append(fs, finding{
+			kind:             kindVulnerability,
+			id:               vuln.VulnerabilityID,
+			severity:         vuln.Severity,
+			pkgName:          vuln.PkgName,
+			installedVersion: vuln.InstalledVersion,
+			fixedVersion:     vuln.FixedVersion,
+			status:           vuln.Status.String(),
+			title:            vuln.Title,
+			primaryURL:       vuln.PrimaryURL,
+		})
+	}
+	for _, misconf := range result.Misconfigurations {
+		fs = append(fs, finding{
+			kind:       kindMisconfiguration,
+			id:         misconf.ID,
+			severity:   misconf.Severity,
+			status:     string(misconf.Status),
+			title:      misconf.Title,
+			primaryURL: misconf.PrimaryURL,
+		})
+	}
+	for _, secret := range result.Secrets {
+		fs = append(fs, finding{
+			kind:     kindSecret,
+			id:       secret.RuleID,
+			severity: secret.Severity,
+			title:    secret.Title,
+		})
+	}
+	for _, license := range result.Licenses {
+		fs = append(fs, finding{
+			kind:       kindLicense,
+			id:         license.Name,
+			severity:   license.Severity,
+			pkgName:    license.PkgName,
+			title:      string(license.Category),
+			primaryURL: license.Link,
+		})
+	}
+	return fs
+}
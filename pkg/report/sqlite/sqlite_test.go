@@ -0,0 +1,217 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/clock"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report/sqlite"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+var report = types.Report{
+	ArtifactName: "alpine:3.20",
+	ArtifactType: "container_image",
+	CreatedAt:    time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+	Results: types.Results{
+		{
+			Target: "alpine:3.20 (alpine 3.20.0)",
+			Class:  types.ClassOSPkg,
+			Type:   ftypes.Alpine,
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:  "CVE-2024-5535",
+					PkgName:          "libssl3",
+					InstalledVersion: "3.3.0-r2",
+					FixedVersion:     "3.3.1-r1",
+					Status:           dbTypes.StatusFixed,
+					PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2024-5535",
+					Vulnerability: dbTypes.Vulnerability{
+						Title:    `openssl: SSL_select_next_proto "buffer overread"`,
+						Severity: "CRITICAL",
+					},
+				},
+			},
+		},
+		{
+			Target: "Dockerfile",
+			Class:  types.ClassConfig,
+			Type:   ftypes.Dockerfile,
+			Misconfigurations: []types.DetectedMisconfiguration{
+				{
+					ID:       "DS002",
+					Title:    "Image user should not be 'root'",
+					Severity: "HIGH",
+					Status:   types.MisconfStatusFailure,
+				},
+			},
+		},
+		{
+			Target: "/app/.env",
+			Class:  types.ClassSecret,
+			Secrets: []types.DetectedSecret{
+				{
+					RuleID:   "aws-access-key-id",
+					Title:    "AWS Access Key ID",
+					Severity: "CRITICAL",
+				},
+			},
+		},
+	},
+}
+
+type row struct {
+	CreatedAt        string
+	ArtifactName     string
+	Target           string
+	Kind             string
+	FindingID        string
+	Severity         string
+	PkgName          string
+	InstalledVersion string
+	FixedVersion     string
+	Status           string
+	Title            string
+}
+
+func TestWriter_Write(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trivy.db")
+	w := sqlite.Writer{
+		Path:    path,
+		Version: "0.57.0",
+	}
+
+	// The first scan creates the schema, and the second one is appended
+	require.NoError(t, w.Write(context.Background(), report))
+	ctx := clock.With(context.Background(), time.Date(2024, 10, 2, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, w.Write(ctx, types.Report{
+		ArtifactName: "alpine:3.20",
+		ArtifactType: "container_image",
+		Results:      report.Results[:1],
+	}))
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	rows, err := db.Query(`
+		SELECT s.created_at, s.artifact_name, t.target, f.kind, f.finding_id, f.severity,
+		       f.pkg_name, f.installed_version, f.fixed_version, f.status, f.title
+		FROM findings f
+		JOIN targets t ON f.target_id = t.id
+		JOIN scans s ON t.scan_id = s.id
+		ORDER BY f.id`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var got []row
+	for rows.Next() {
+		var r row
+		require.NoError(t, rows.Scan(&r.CreatedAt, &r.ArtifactName, &r.Target, &r.Kind, &r.FindingID, &r.Severity,
+			&r.PkgName, &r.InstalledVersion, &r.FixedVersion, &r.Status, &r.Title))
+		got = append(got, r)
+	}
+	require.NoError(t, rows.Err())
+
+	vuln := row{
+		CreatedAt:        "2024-10-01T12:00:00Z",
+		ArtifactName:     "alpine:3.20",
+		Target:           "alpine:3.20 (alpine 3.20.0)",
+		Kind:             "vulnerability",
+		FindingID:        "CVE-2024-5535",
+		Severity:         "CRITICAL",
+		PkgName:          "libssl3",
+		InstalledVersion: "3.3.0-r2",
+		FixedVersion:     "3.3.1-r1",
+		Status:           "fixed",
+		Title:            `openssl: SSL_select_next_proto "buffer overread"`,
+	}
+	vuln2 := vuln
+	vuln2.CreatedAt = "2024-10-02T12:00:00Z"
+
+	assert.Equal(t, []row{
+		vuln,
+		{
+			CreatedAt:    "2024-10-01T12:00:00Z",
+			ArtifactName: "alpine:3.20",
+			Target:       "Dockerfile",
+			Kind:         "misconfiguration",
+			FindingID:    "DS002",
+			Severity:     "HIGH",
+			Status:       "FAIL",
+			Title:        "Image user should not be 'root'",
+		},
+		{
+			CreatedAt:    "2024-10-01T12:00:00Z",
+			ArtifactName: "alpine:3.20",
+			Target:       "/app/.env",
+			Kind:         "secret",
+			FindingID:    "aws-access-key-id",
+			Severity:     "CRITICAL",
+			Title:        "AWS Access Key ID",
+		},
+		vuln2,
+	}, got)
+
+	// Trends can be queried over scans
+	var scans, criticals int
+	require.NoError(t, db.QueryRow(`
+		SELECT COUNT(DISTINCT s.id), COUNT(*)
+		FROM findings f
+		JOIN targets t ON f.target_id = t.id
+		JOIN scans s ON t.scan_id = s.id
+		WHERE f.severity = 'CRITICAL' AND f.kind = 'vulnerability'`).Scan(&scans, &criticals))
+	assert.Equal(t, 2, scans)
+	assert.Equal(t, 2, criticals)
+
+	var version int
+	require.NoError(t, db.QueryRow("PRAGMA user_version").Scan(&version))
+	assert.Equal(t, 1, version)
+}
+
+func TestWriter_Write_DeleteScan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trivy.db")
+	w := sqlite.Writer{Path: path}
+	require.NoError(t, w.Write(context.Background(), report))
+
+	db, err := sql.Open("sqlite", path+"?_pragma=foreign_keys(1)")
+	require.NoError(t, err)
+	defer db.Close()
+
+	// The targets and findings of the scan are deleted in cascade
+	_, err = db.Exec("DELETE FROM scans")
+	require.NoError(t, err)
+	for _, table := range []string{"targets", "findings"} {
+		var count int
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM "+table).Scan(&count))
+		assert.Zero(t, count, table)
+	}
+}
+
+func TestWriter_Write_NewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trivy.db")
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	_, err = db.Exec("PRAGMA user_version = 100")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	w := sqlite.Writer{Path: path}
+	err = w.Write(context.Background(), report)
+	assert.ErrorContains(t, err, "the schema version 100 is newer than the supported version 1")
+}
+
+func TestWriter_Write_EmptyPath(t *testing.T) {
+	w := sqlite.Writer{}
+	err := w.Write(context.Background(), report)
+	assert.ErrorContains(t, err, "the database path is empty")
+}
@@ -0,0 +1,254 @@
+package syslog
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/clock"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const (
+	appName     = "trivy"
+	defaultPort = "514"
+
+	// The facility of the messages (user-level messages)
+	facilityUser = 1
+
+	// SD-ID of the structured data, using the enterprise number reserved for documentation in RFC 5612
+	sdID = "trivy@32473"
+
+	// Number of attempts to connect to the server
+	maxAttempts = 3
+)
+
+// Writer sends each finding to a syslog server as an RFC 5424 message.
+// The details of the finding are sent as the structured data of the message.
+type Writer struct {
+	// Addr is the address of the syslog server, e.g. "udp://localhost:514" or "tcp://localhost:514".
+	// UDP is used when the scheme is omitted.
+	Addr string
+
+	// Hostname is sent in the HOSTNAME field (the local host name by default)
+	Hostname string
+
+	// RetryInterval is the interval between attempts to connect to the server (1 second by default)
+	RetryInterval time.Duration
+}
+
+type message struct {
+	severity int
+	msgID    string
+	params   [][2]string
+	text     string
+}
+
+func (w Writer) Write(ctx context.Context, report types.Report) error {
+	network, addr, err := parseAddr(w.Addr)
+	if err != nil {
+		return err
+	}
+
+	hostname := w.Hostname
+	if hostname == "" {
+		if hostname, err = os.Hostname(); err != nil {
+			hostname = "-"
+		}
+	}
+	timestamp := clock.Now(ctx).Format("2006-01-02T15:04:05.000000Z07:00")
+
+	conn, err := w.dial(ctx, network, addr)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	for _, msg := range messages(report) {
+		b := []byte(msg.format(timestamp, hostname))
+		if network == "tcp" {
+			// Octet counting framing (RFC 6587)
+			b = append([]byte(strconv.Itoa(len(b))+" "), b...)
+		}
+		if _, err = conn.Write(b); err == nil {
+			continue
+		}
+
+		// Reconnect once, e.g. when the server closed the connection
+		log.DebugContext(ctx, "Failed to send a syslog message, reconnecting", log.Err(err))
+		_ = conn.Close()
+		if conn, err = w.dial(ctx, network, addr); err != nil {
+			return err
+		}
+		if _, err = conn.Write(b); err != nil {
+			return xerrors.Errorf("failed to send a syslog message to %s: %w", addr, err)
+		}
+	}
+	return nil
+}
+
+// parseAddr returns the network and the address of the syslog server
+func parseAddr(s string) (string, string, error) {
+	network := "udp"
+	if scheme, addr, ok := strings.Cut(s, "://"); ok {
+		network, s = scheme, addr
+	}
+	if network != "udp" && network != "tcp" {
+		return "", "", xerrors.Errorf("unsupported syslog transport %q, must be udp or tcp", network)
+	}
+	if s == "" {
+		return "", "", xerrors.New("syslog server address is empty")
+	}
+	if _, _, err := net.SplitHostPort(s); err != nil {
+		s = net.JoinHostPort(s, defaultPort)
+	}
+	return network, s, nil
+}
+
+// dial connects to the server, retrying a few times for temporary failures
+func (w Writer) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	interval := w.RetryInterval
+	if interval == 0 {
+		interval = time.Second
+	}
+
+	var dialer net.Dialer
+	var err error
+	for i := range maxAttempts {
+		if i > 0 {
+			log.DebugContext(ctx, "Retrying to connect to the syslog server", log.String("addr", addr), log.Err(err))
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+		var conn net.Conn
+		if conn, err = dialer.DialContext(ctx, network, addr); err == nil {
+			return conn, nil
+		}
+	}
+	return nil, xerrors.Errorf("failed to connect to the syslog server %s after %d attempts: %w", addr, maxAttempts, err)
+}
+
+func messages(report types.Report) []message {
+	var msgs []message
+	for _, result := range report.Results {
+		common := [][2]string{
+			{"artifact", report.ArtifactName},
+			{"target", result.Target},
+			{"class", string(result.Class)},
+		}
+		for _, vuln := range result.Vulnerabilities {
+			msgs = append(msgs, message{
+				severity: toSeverity(vuln.Severity),
+				msgID:    "vulnerability",
+				params: slices.Concat(common, [][2]string{
+					{"id", vuln.VulnerabilityID},
+					{"severity", vuln.Severity},
+					{"pkg", vuln.PkgName},
+					{"installed", vuln.InstalledVersion},
+					{"fixed", vuln.FixedVersion},
+					{"status", vuln.Status.String()},
+				}),
+				text: fmt.Sprintf("%s %s %s: %s", vuln.VulnerabilityID, vuln.PkgName, vuln.InstalledVersion, vuln.Title),
+			})
+		}
+		for _, misconf := range result.Misconfigurations {
+			if misconf.Status != types.MisconfStatusFailure {
+				continue
+			}
+			msgs = append(msgs, message{
+				severity: toSeverity(misconf.Severity),
+				msgID:    "misconfiguration",
+				params: slices.Concat(common, [][2]string{
+					{"id", misconf.AVDID},
+					{"severity", misconf.Severity},
+					{"line", line(misconf.CauseMetadata.StartLine)},
+				}),
+				text: fmt.Sprintf("%s: %s", misconf.AVDID, misconf.Message),
+			})
+		}
+		for _, secret := range result.Secrets {
+			msgs = append(msgs, message{
+				severity: toSeverity(secret.Severity),
+				msgID:    "secret",
+				params: slices.Concat(common, [][2]string{
+					{"id", secret.RuleID},
+					{"severity", secret.Severity},
+					{"category", string(secret.Category)},
+					{"line", line(secret.StartLine)},
+				}),
+				text: fmt.Sprintf("%s: %s", secret.RuleID, secret.Title),
+			})
+		}
+		for _, license := range result.Licenses {
+			msgs = append(msgs, message{
+				severity: toSeverity(license.Severity),
+				msgID:    "license",
+				params: slices.Concat(common, [][2]string{
+					{"id", license.Name},
+					{"severity", license.Severity},
+					{"pkg", license.PkgName},
+					{"category", string(license.Category)},
+				}),
+				text: fmt.Sprintf("%s: %s", license.Name, lo.CoalesceOrEmpty(license.PkgName, license.FilePath)),
+			})
+		}
+	}
+	return msgs
+}
+
+// format returns the message in the syslog format.
+//
+//	<PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [SD-ID PARAM="VALUE"...] MSG
+func (m message) format(timestamp, hostname string) string {
+	var sd strings.Builder
+	sd.WriteString("[" + sdID)
+	for _, param := range m.params {
+		if param[1] == "" {
+			continue
+		}
+		fmt.Fprintf(&sd, ` %s="%s"`, param[0], sdEscaper.Replace(param[1]))
+	}
+	sd.WriteString("]")
+
+	pri := facilityUser*8 + m.severity
+	return fmt.Sprintf("<%d>1 %s %s %s - %s %s %s", pri, timestamp, hostname, appName, m.msgID, sd.String(), m.text)
+}
+
+// Characters that must be escaped in PARAM-VALUE
+var sdEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// toSeverity maps the severity of the finding to the syslog severity
+func toSeverity(severity string) int {
+	switch severity {
+	case dbTypes.SeverityCritical.String():
+		return 2 // Critical
+	case dbTypes.SeverityHigh.String():
+		return 3 // Error
+	case dbTypes.SeverityMedium.String():
+		return 4 // Warning
+	case dbTypes.SeverityLow.String():
+		return 5 // Notice
+	default:
+		return 6 // Informational
+	}
+}
+
+func line(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
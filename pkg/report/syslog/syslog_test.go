@@ -0,0 +1,174 @@
+package syslog_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/clock"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report/syslog"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+var (
+	report = types.Report{
+		ArtifactName: "alpine:3.20",
+		Results: types.Results{
+			{
+				Target: "alpine:3.20 (alpine 3.20.0)",
+				Class:  types.ClassOSPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2024-5535",
+						PkgName:          "libssl3",
+						InstalledVersion: "3.3.0-r2",
+						FixedVersion:     "3.3.1-r1",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    `openssl: SSL_select_next_proto "buffer overread"`,
+							Severity: "CRITICAL",
+						},
+					},
+				},
+			},
+			{
+				Target: "Dockerfile",
+				Class:  types.ClassConfig,
+				Misconfigurations: []types.DetectedMisconfiguration{
+					{
+						AVDID:    "AVD-DS-0002",
+						Message:  "Last USER command in Dockerfile should not be 'root'",
+						Severity: "HIGH",
+						Status:   types.MisconfStatusFailure,
+						CauseMetadata: ftypes.CauseMetadata{
+							StartLine: 3,
+						},
+					},
+					{
+						AVDID:    "AVD-DS-0001",
+						Severity: "MEDIUM",
+						Status:   types.MisconfStatusPassed,
+					},
+				},
+			},
+			{
+				Target: "/app/config.env",
+				Class:  types.ClassSecret,
+				Secrets: []types.DetectedSecret{
+					{
+						RuleID:    "aws-access-key-id",
+						Category:  "AWS",
+						Severity:  "LOW",
+						Title:     "AWS Access Key ID",
+						StartLine: 1,
+					},
+				},
+			},
+		},
+	}
+
+	want = []string{
+		`<10>1 2021-08-25T12:20:30.000000Z test-host trivy - vulnerability [trivy@32473 artifact="alpine:3.20" target="alpine:3.20 (alpine 3.20.0)" class="os-pkgs" id="CVE-2024-5535" severity="CRITICAL" pkg="libssl3" installed="3.3.0-r2" fixed="3.3.1-r1" status="fixed"] CVE-2024-5535 libssl3 3.3.0-r2: openssl: SSL_select_next_proto "buffer overread"`,
+		`<11>1 2021-08-25T12:20:30.000000Z test-host trivy - misconfiguration [trivy@32473 artifact="alpine:3.20" target="Dockerfile" class="config" id="AVD-DS-0002" severity="HIGH" line="3"] AVD-DS-0002: Last USER command in Dockerfile should not be 'root'`,
+		`<13>1 2021-08-25T12:20:30.000000Z test-host trivy - secret [trivy@32473 artifact="alpine:3.20" target="/app/config.env" class="secret" id="aws-access-key-id" severity="LOW" category="AWS" line="1"] aws-access-key-id: AWS Access Key ID`,
+	}
+)
+
+func TestWriter_Write(t *testing.T) {
+	ctx := clock.With(context.Background(), time.Date(2021, 8, 25, 12, 20, 30, 0, time.UTC))
+
+	t.Run("udp", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+
+		w := syslog.Writer{
+			Addr:     "udp://" + conn.LocalAddr().String(),
+			Hostname: "test-host",
+		}
+		require.NoError(t, w.Write(ctx, report))
+
+		var got []string
+		buf := make([]byte, 4096)
+		for range want {
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+			n, _, err := conn.ReadFrom(buf)
+			require.NoError(t, err)
+			got = append(got, string(buf[:n]))
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("tcp", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = l.Close() })
+
+		received := make(chan []string)
+		go func() {
+			conn, err := l.Accept()
+			if err != nil {
+				close(received)
+				return
+			}
+			defer conn.Close()
+
+			// Octet counting framing
+			var msgs []string
+			r := bufio.NewReader(conn)
+			for {
+				length, err := r.ReadString(' ')
+				if err != nil {
+					break
+				}
+				n, _ := strconv.Atoi(strings.TrimSpace(length))
+				msg := make([]byte, n)
+				if _, err = io.ReadFull(r, msg); err != nil {
+					break
+				}
+				msgs = append(msgs, string(msg))
+			}
+			received <- msgs
+		}()
+
+		w := syslog.Writer{
+			Addr:     "tcp://" + l.Addr().String(),
+			Hostname: "test-host",
+		}
+		require.NoError(t, w.Write(ctx, report))
+		assert.Equal(t, want, <-received)
+	})
+
+	t.Run("connection refused", func(t *testing.T) {
+		// Reserve a port and release it so that nobody listens on it
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := l.Addr().String()
+		require.NoError(t, l.Close())
+
+		w := syslog.Writer{
+			Addr:          "tcp://" + addr,
+			RetryInterval: time.Millisecond,
+		}
+		err = w.Write(ctx, report)
+		require.ErrorContains(t, err, "failed to connect to the syslog server "+addr+" after 3 attempts")
+	})
+
+	t.Run("unsupported transport", func(t *testing.T) {
+		w := syslog.Writer{
+			Addr: "tls://localhost:6514",
+		}
+		err := w.Write(ctx, report)
+		require.ErrorContains(t, err, `unsupported syslog transport "tls"`)
+	})
+}
@@ -0,0 +1,22 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
+)
+
+// renderBanner renders the classification banner, e.g. "CONFIDENTIAL", in bold white on red on terminals,
+// and delimited by lines otherwise so that it stands out in files and pipes.
+func renderBanner(w io.Writer, banner string, isTerminal bool) {
+	text := "  " + banner + "  "
+	if isTerminal {
+		_, _ = fmt.Fprintf(w, "\n%s\n", color.New(color.Bold, color.FgHiWhite, color.BgRed).Sprint(text))
+		return
+	}
+	line := strings.Repeat("#", runewidth.StringWidth(text)+2)
+	_, _ = fmt.Fprintf(w, "\n%s\n#%s#\n%s\n", line, text, line)
+}
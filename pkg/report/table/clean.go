@@ -0,0 +1,67 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// isClean returns true if no findings are found in the results.
+// Misconfigurations are regarded as findings only if they failed, as passed checks are included with "--include-non-failures".
+func isClean(results types.Results) bool {
+	for _, result := range results {
+		if len(result.Vulnerabilities) > 0 || len(result.Secrets) > 0 || len(result.Licenses) > 0 {
+			return false
+		}
+		for _, m := range result.Misconfigurations {
+			if m.Status == types.MisconfStatusFailure {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// renderCleanSummary renders what was scanned when no findings are found,
+// so that a clean scan can be distinguished from a scan in which nothing was detected to scan.
+func renderCleanSummary(w io.Writer, report types.Report, scanners types.Scanners, isTerminal bool) {
+	var pkgs, pkgTargets, checks int
+	for _, result := range report.Results {
+		if len(result.Packages) > 0 {
+			pkgs += len(result.Packages)
+			pkgTargets++
+		}
+		if result.MisconfSummary != nil {
+			checks += result.MisconfSummary.Successes
+		}
+	}
+	distro := report.Metadata.OS
+
+	RenderTarget(w, "Clean Scan Summary", isTerminal)
+	if distro == nil && pkgs == 0 && checks == 0 {
+		_, _ = fmt.Fprintln(w, "No findings, but nothing was detected to scan: no OS, packages or configuration files were found.")
+	} else {
+		_, _ = fmt.Fprintln(w, "No findings.")
+	}
+
+	// The SBOM scanner is enabled internally to collect packages.
+	// Scanners are unknown when converting a report.
+	if scanners = lo.Without(scanners, types.SBOMScanner); len(scanners) > 0 {
+		_, _ = fmt.Fprintf(w, "Scanners: %s\n", strings.Join(lo.Map(scanners, func(s types.Scanner, _ int) string {
+			return string(s)
+		}), ", "))
+	}
+	if distro != nil {
+		_, _ = fmt.Fprintf(w, "OS: %s %s\n", distro.Family, distro.Name)
+	}
+	if pkgs > 0 {
+		_, _ = fmt.Fprintf(w, "Packages: %d (in %d %s)\n", pkgs, pkgTargets, lo.Ternary(pkgTargets == 1, "target", "targets"))
+	}
+	if checks > 0 {
+		_, _ = fmt.Fprintf(w, "Passed misconfiguration checks: %d\n", checks)
+	}
+}
@@ -0,0 +1,94 @@
+package table
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+// columnGap separates the results rendered side by side
+const columnGap = "    "
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// terminalWidth returns the width of the terminal, or 0 if it is unknown
+func terminalWidth() int {
+	width, _, err := term.GetSize(0)
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// layoutColumns packs the rendered results into rows of the given number of columns.
+// A row falls back to the results rendered one after another if they don't fit in the width side by side,
+// e.g. for wide vulnerability tables, so that no line is wrapped by the terminal.
+func layoutColumns(outputs []string, columns, width int) []string {
+	if columns <= 1 {
+		return outputs
+	}
+
+	var laidOut []string
+	for i := 0; i < len(outputs); i += columns {
+		row := outputs[i:min(i+columns, len(outputs))]
+		if joined, ok := joinColumns(row, width); ok {
+			laidOut = append(laidOut, joined)
+		} else {
+			laidOut = append(laidOut, row...)
+		}
+	}
+	return laidOut
+}
+
+// joinColumns renders the outputs side by side.
+// It returns false if there is nothing to join or the joined lines are wider than the width.
+func joinColumns(outputs []string, width int) (string, bool) {
+	if len(outputs) < 2 {
+		return "", false
+	}
+
+	lines := make([][]string, len(outputs))
+	widths := make([]int, len(outputs))
+	var height int
+	total := len(columnGap) * (len(outputs) - 1)
+	for i, output := range outputs {
+		output = strings.TrimSuffix(strings.ReplaceAll(output, "\r\n", "\n"), "\n")
+		lines[i] = strings.Split(output, "\n")
+		for _, line := range lines[i] {
+			widths[i] = max(widths[i], displayWidth(line))
+		}
+		height = max(height, len(lines[i]))
+		total += widths[i]
+	}
+	if total > width {
+		return "", false
+	}
+
+	var sb strings.Builder
+	for row := range height {
+		var line strings.Builder
+		for i := range outputs {
+			var cell string
+			if row < len(lines[i]) {
+				cell = lines[i][row]
+			}
+			if i > 0 {
+				line.WriteString(columnGap)
+			}
+			line.WriteString(cell)
+			if i < len(outputs)-1 {
+				line.WriteString(strings.Repeat(" ", widths[i]-displayWidth(cell)))
+			}
+		}
+		sb.WriteString(strings.TrimRight(line.String(), " "))
+		sb.WriteString("\n")
+	}
+	return sb.String(), true
+}
+
+// displayWidth returns the number of cells the line occupies in the terminal, ignoring colors
+func displayWidth(line string) int {
+	return runewidth.StringWidth(ansiEscape.ReplaceAllString(line, ""))
+}
@@ -0,0 +1,55 @@
+package table
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// severityConflictLevels is the minimum number of levels between the severity of a vulnerability
+// and the rating of another source for them to conflict, e.g. MEDIUM and CRITICAL.
+const severityConflictLevels = 2
+
+// severityConflicts returns the ratings of the sources differing from the severity of the vulnerability
+// by severityConflictLevels or more levels, e.g. "redhat: LOW", sorted by source.
+// UNKNOWN is not regarded as a rating.
+func severityConflicts(v types.DetectedVulnerability) []string {
+	severity, err := dbTypes.NewSeverity(v.Severity)
+	if err != nil || severity == dbTypes.SeverityUnknown {
+		return nil
+	}
+
+	sources := lo.Keys(v.VendorSeverity)
+	slices.Sort(sources)
+
+	var conflicts []string
+	for _, source := range sources {
+		rating := v.VendorSeverity[source]
+		if rating == dbTypes.SeverityUnknown || abs(int(rating)-int(severity)) < severityConflictLevels {
+			continue
+		}
+		conflicts = append(conflicts, fmt.Sprintf("%s: %s", source, rating))
+	}
+	return conflicts
+}
+
+// severityConflictLabel appends the conflict indicator and the conflicting ratings to the severity cell
+func severityConflictLabel(severity string, v types.DetectedVulnerability) string {
+	conflicts := severityConflicts(v)
+	if len(conflicts) == 0 {
+		return severity
+	}
+	return severity + " ⚠\n" + strings.Join(conflicts, "\n")
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
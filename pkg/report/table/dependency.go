@@ -0,0 +1,158 @@
+package table
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"slices"
+	"sync"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+// dependencyGraph is the reversed dependency graph of the packages in a result.
+type dependencyGraph struct {
+	// Parents of each package.
+	// Only the ID and relationship of parents may be used as the graph can be shared by results with the same graph.
+	parents map[string]ftypes.Packages
+
+	// Direct dependencies that each package originates from, computed on first use
+	ancestors func() map[string][]string
+}
+
+func newDependencyGraph(pkgs []ftypes.Package) *dependencyGraph {
+	parents := ftypes.Packages(pkgs).ParentDeps()
+	return &dependencyGraph{
+		parents: parents,
+		ancestors: sync.OnceValue(func() map[string][]string {
+			return traverseAncestors(pkgs, parents)
+		}),
+	}
+}
+
+// dependents returns the number of packages that depend on the package directly or transitively.
+// Root packages, i.e. the scanned project itself, are not counted.
+func (g *dependencyGraph) dependents(pkgID string) int {
+	var n int
+	seen := map[string]struct{}{pkgID: {}}
+	queue := []string{pkgID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, parent := range g.parents[id] {
+			// Cyclic dependencies are visited only once
+			if _, ok := seen[parent.ID]; ok {
+				continue
+			}
+			seen[parent.ID] = struct{}{}
+			queue = append(queue, parent.ID)
+			if parent.Relationship != ftypes.RelationshipRoot {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// shortestPath returns the shortest chain of package IDs from the package up to a direct dependency,
+// e.g. ["qs@6.7.0", "body-parser@1.19.0", "express@4.17.1"].
+// Parents are visited in the order of their IDs so that ties are broken deterministically.
+// A dependency with no parents is regarded as a direct dependency as in the top-down tree.
+func (g *dependencyGraph) shortestPath(pkg ftypes.Package) []string {
+	isTop := func(id string, relationship ftypes.Relationship) bool {
+		return relationship == ftypes.RelationshipDirect ||
+			(relationship != ftypes.RelationshipRoot && len(g.parents[id]) == 0)
+	}
+	if isTop(pkg.ID, pkg.Relationship) {
+		return []string{pkg.ID}
+	}
+
+	// The child of each visited package on the way to the vulnerable package
+	next := map[string]string{pkg.ID: ""}
+	queue := []string{pkg.ID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		parents := slices.Clone(g.parents[id])
+		slices.SortFunc(parents, func(a, b ftypes.Package) int {
+			return cmp.Compare(a.ID, b.ID)
+		})
+		for _, parent := range parents {
+			if _, ok := next[parent.ID]; ok {
+				continue // to avoid infinite loops
+			}
+			next[parent.ID] = id
+			if !isTop(parent.ID, parent.Relationship) {
+				queue = append(queue, parent.ID)
+				continue
+			}
+
+			path := []string{parent.ID}
+			for child := id; child != ""; child = next[child] {
+				path = append(path, child)
+			}
+			slices.Reverse(path)
+			return path
+		}
+	}
+	// No direct dependency, e.g. the package is only used by the root package
+	return []string{pkg.ID}
+}
+
+// dependencyGraphCache memoizes dependency graphs across the results of a report,
+// e.g. when the same lock file is found in many images or a monorepo has many projects sharing dependencies.
+// It is safe for concurrent use.
+type dependencyGraphCache struct {
+	mu     sync.Mutex
+	graphs map[string]func() *dependencyGraph
+}
+
+func newDependencyGraphCache() *dependencyGraphCache {
+	return &dependencyGraphCache{
+		graphs: make(map[string]func() *dependencyGraph),
+	}
+}
+
+// graph returns the dependency graph of the packages.
+// The graph is computed only once for packages with the same IDs, relationships and dependencies.
+// A nil cache computes the graph every time.
+func (c *dependencyGraphCache) graph(pkgs []ftypes.Package) *dependencyGraph {
+	if c == nil {
+		return newDependencyGraph(pkgs)
+	}
+
+	key := dependencyGraphKey(pkgs)
+	c.mu.Lock()
+	g, ok := c.graphs[key]
+	if !ok {
+		// The graph is computed outside the lock so that other graphs can be computed concurrently
+		g = sync.OnceValue(func() *dependencyGraph {
+			return newDependencyGraph(pkgs)
+		})
+		c.graphs[key] = g
+	}
+	c.mu.Unlock()
+	return g()
+}
+
+// dependencyGraphKey returns the hash of what the dependency graph depends on.
+// The order of packages is taken into account as it determines the order of parents.
+func dependencyGraphKey(pkgs []ftypes.Package) string {
+	h := sha256.New()
+	writeString := func(s string) {
+		// Length-prefixed to avoid collisions between e.g. ["ab", "c"] and ["a", "bc"]
+		_ = binary.Write(h, binary.LittleEndian, uint64(len(s)))
+		_, _ = h.Write([]byte(s))
+	}
+	for _, pkg := range pkgs {
+		writeString(pkg.ID)
+		_ = binary.Write(h, binary.LittleEndian, int64(pkg.Relationship))
+		_ = binary.Write(h, binary.LittleEndian, uint64(len(pkg.DependsOn)))
+		for _, dep := range pkg.DependsOn {
+			writeString(dep)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
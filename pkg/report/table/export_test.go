@@ -0,0 +1,39 @@
+package table
+
+import "sync"
+
+// The side-by-side layout, the interactive view and the QR code are rendered only to a terminal,
+// which Writer never detects in tests, so they are exported here for the tests in table_test.
+var (
+	LayoutColumns = layoutColumns
+	RenderQRCode  = renderQRCode
+	ReadKey       = readKey
+)
+
+const (
+	KeyUp       = keyUp
+	KeyDown     = keyDown
+	KeyPageUp   = keyPageUp
+	KeyPageDown = keyPageDown
+	KeyToggle   = keyToggle
+	KeyExpand   = keyExpand
+	KeyCollapse = keyCollapse
+	KeyTop      = keyTop
+	KeyBottom   = keyBottom
+	KeyQuit     = keyQuit
+)
+
+// FoldView returns the interactive view of the outputs after the keys are pressed, and whether the last key quits.
+func FoldView(outputs []string, width, height int, keys ...string) (string, bool) {
+	m := newFoldModel(outputs, width, height)
+	var quit bool
+	for _, key := range keys {
+		quit = m.update(key)
+	}
+	return m.view(), quit
+}
+
+// ResetVEXNotice lets the VEX notice be shown again.
+func ResetVEXNotice() {
+	showVEXNoticeOnce = &sync.Once{}
+}
@@ -0,0 +1,104 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/go-version/pkg/semver"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+// fixCommandFormats maps package ecosystems to the format of the upgrade command,
+// taking the package name and the fixed version.
+// Ecosystems without a command upgrading a single package, such as Gradle and binaries, are not listed.
+var fixCommandFormats = map[ftypes.TargetType]string{
+	ftypes.Npm:           "npm install %s@%s",
+	ftypes.Yarn:          "yarn add %s@%s",
+	ftypes.Pnpm:          "pnpm add %s@%s",
+	ftypes.GoModule:      "go get %s@v%s",
+	ftypes.Pip:           "pip install %s==%s",
+	ftypes.Pipenv:        "pipenv install %s==%s",
+	ftypes.Poetry:        "poetry add %s@%s",
+	ftypes.Cargo:         "cargo update -p %s --precise %s",
+	ftypes.Composer:      "composer require %s:%s",
+	ftypes.NuGet:         "dotnet add package %s --version %s",
+	ftypes.DotNetCore:    "dotnet add package %s --version %s",
+	ftypes.PackagesProps: "dotnet add package %s --version %s",
+	ftypes.Pom:           "mvn versions:use-dep-version -Dincludes=%s -DdepVersion=%s -DforceVersion=true",
+}
+
+// goStdlib is the package name of the Go standard library detected in Go binaries and go.mod
+const goStdlib = "stdlib"
+
+// fixCommand returns the command upgrading the package to the fixed version,
+// or an empty string if the package is not fixed or the ecosystem has no such command.
+// When several fixed versions are listed, e.g. "4.17.21, 5.0.1", the minimal one newer than the installed version is used
+// so that the command doesn't downgrade the package to a fixed version of another release branch.
+func fixCommand(targetType ftypes.TargetType, pkgName, installedVersion, fixedVersion string) string {
+	format, ok := fixCommandFormats[targetType]
+	if !ok || pkgName == "" {
+		return ""
+	}
+	version := TargetVersion(installedVersion, fixedVersion)
+	if version == "" {
+		return ""
+	}
+	if targetType == ftypes.GoModule {
+		// Go modules require the "v" prefix, which fixed versions don't always have
+		version = strings.TrimPrefix(version, "v")
+		// The standard library is not a module, and it is upgraded with the Go toolchain building the module
+		if pkgName == goStdlib {
+			return fmt.Sprintf("go get toolchain@go%s", version)
+		}
+	}
+	return fmt.Sprintf(format, pkgName, version)
+}
+
+// semverEcosystems lists package ecosystems following Semantic Versioning,
+// where a pre-release is denoted by a hyphen after the patch version, e.g. "2.0.0-rc1".
+var semverEcosystems = map[ftypes.TargetType]struct{}{
+	ftypes.Npm:            {},
+	ftypes.Yarn:           {},
+	ftypes.Pnpm:           {},
+	ftypes.NodePkg:        {},
+	ftypes.JavaScript:     {},
+	ftypes.GoModule:       {},
+	ftypes.GoBinary:       {},
+	ftypes.Cargo:          {},
+	ftypes.RustBinary:     {},
+	ftypes.Composer:       {},
+	ftypes.ComposerVendor: {},
+	ftypes.NuGet:          {},
+	ftypes.DotNetCore:     {},
+	ftypes.PackagesProps:  {},
+	ftypes.Pub:            {},
+	ftypes.Hex:            {},
+	ftypes.Swift:          {},
+	ftypes.Cocoapods:      {},
+}
+
+// annotatePrerelease appends "(pre-release)" to each pre-release version in the fixed versions,
+// e.g. "1.9.3, 2.0.0-rc1 (pre-release)".
+// Fixed versions of ecosystems not following Semantic Versioning are returned as they are.
+func annotatePrerelease(targetType ftypes.TargetType, fixedVersion string) string {
+	if _, ok := semverEcosystems[targetType]; !ok || fixedVersion == "" {
+		return fixedVersion
+	}
+
+	var annotated bool
+	versions := strings.Split(fixedVersion, ",")
+	for i, ver := range versions {
+		ver = strings.TrimSpace(ver)
+		// Go modules may have the "v" prefix, which is not a part of Semantic Versioning
+		v, err := semver.Parse(strings.TrimPrefix(ver, "v"))
+		if err != nil || !v.IsPreRelease() {
+			continue
+		}
+		versions[i] = strings.Replace(versions[i], ver, ver+" (pre-release)", 1)
+		annotated = true
+	}
+	if !annotated {
+		return fixedVersion
+	}
+	return strings.Join(versions, ",")
+}
@@ -0,0 +1,70 @@
+package table
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// renderFocusedVulnerabilities lists the targets and packages affected by each of the vulnerability IDs
+// across all the results, instead of rendering a table per result.
+func renderFocusedVulnerabilities(w io.Writer, results types.Results, vulnIDs []string, isTerminal bool) {
+	for _, vulnID := range vulnIDs {
+		renderFocusedVulnerability(w, results, vulnID, isTerminal)
+	}
+}
+
+func renderFocusedVulnerability(w io.Writer, results types.Results, vulnID string, isTerminal bool) {
+	var found *types.DetectedVulnerability
+	var rows [][]string
+	var scanned, affected int
+	for _, result := range results {
+		if result.Class != types.ClassOSPkg && result.Class != types.ClassLangPkg {
+			continue
+		}
+		scanned++
+
+		var matched bool
+		for _, v := range result.Vulnerabilities {
+			if v.VulnerabilityID != vulnID {
+				continue
+			}
+			if found == nil {
+				found = &v
+			}
+			matched = true
+			rows = append(rows, []string{
+				result.Target,
+				v.PkgName,
+				v.InstalledVersion,
+				v.FixedVersion,
+				v.Status.String(),
+			})
+		}
+		if matched {
+			affected++
+		}
+	}
+
+	RenderTarget(w, vulnID, isTerminal)
+	if found == nil {
+		_, _ = fmt.Fprintf(w, "Not affected: %s was not found in any of the %d targets\n", vulnID, scanned)
+		return
+	}
+
+	severity := found.Severity
+	if isTerminal {
+		severity = ColorizeSeverity(severity, severity)
+	}
+	_, _ = fmt.Fprintf(w, "Severity: %s\n", severity)
+	if found.Title != "" {
+		_, _ = fmt.Fprintf(w, "Title: %s\n", found.Title)
+	}
+	_, _ = fmt.Fprintf(w, "Affected: %d packages in %d of %d targets\n\n", len(rows), affected, scanned)
+
+	tableWriter := newTableWriter(w, isTerminal, true)
+	tableWriter.SetHeaders("Target", "Library", "Installed Version", "Fixed Version", "Status")
+	tableWriter.AddRows(rows...)
+	tableWriter.Render()
+}
@@ -0,0 +1,300 @@
+package table
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+	"golang.org/x/xerrors"
+)
+
+// foldHelp is shown at the bottom of the interactive view
+const foldHelp = "↑/k ↓/j: move  enter/space: expand/collapse  e/c: expand/collapse all  PgUp/PgDn: scroll  g/G: top/bottom  q: quit"
+
+// Keys read from the terminal in raw mode
+const (
+	keyUp       = "up"
+	keyDown     = "down"
+	keyPageUp   = "pgup"
+	keyPageDown = "pgdown"
+	keyToggle   = "toggle"
+	keyExpand   = "expand"
+	keyCollapse = "collapse"
+	keyTop      = "top"
+	keyBottom   = "bottom"
+	keyQuit     = "quit"
+)
+
+// foldSection is a result that is collapsed to its summary and can be expanded to the full rendering
+type foldSection struct {
+	title    string   // The target, e.g. "alpine:3.20 (alpine 3.20.0)"
+	summary  string   // e.g. "Total: 3 (HIGH: 2, CRITICAL: 1)"
+	body     []string // The rendered lines of the result
+	expanded bool
+}
+
+// foldModel holds the state of the interactive view in the Elm architecture,
+// where update changes the state by a key and view renders the state.
+type foldModel struct {
+	sections []foldSection
+	cursor   int // Index of the selected section
+	offset   int // Index of the first line shown in the screen
+	width    int
+	height   int
+}
+
+func newFoldModel(outputs []string, width, height int) *foldModel {
+	m := &foldModel{
+		width:  width,
+		height: height,
+	}
+	for _, output := range outputs {
+		m.sections = append(m.sections, newFoldSection(output))
+	}
+	return m
+}
+
+// newFoldSection splits the rendered result into the title, the summary and the body.
+// The title is the first non-empty line, i.e. the target, and the summary is the line starting with "Total:" if any.
+func newFoldSection(output string) foldSection {
+	var section foldSection
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(output, "\r\n", "\n"), "\n"), "\n")
+	for i, line := range lines {
+		plain := strings.TrimSpace(ansiEscape.ReplaceAllString(line, ""))
+		switch {
+		case section.title == "" && plain != "":
+			section.title = plain
+			section.body = lines[i+1:]
+		case section.title != "" && strings.HasPrefix(plain, "Total:"):
+			section.summary = plain
+		}
+		if section.summary != "" {
+			break
+		}
+	}
+
+	// Skip the underline of the title and blank lines
+	for len(section.body) > 0 && strings.Trim(ansiEscape.ReplaceAllString(section.body[0], ""), "= ") == "" {
+		section.body = section.body[1:]
+	}
+	return section
+}
+
+// update changes the state by the key and returns true to quit
+func (m *foldModel) update(key string) bool {
+	switch key {
+	case keyUp:
+		m.cursor = max(m.cursor-1, 0)
+	case keyDown:
+		m.cursor = min(m.cursor+1, len(m.sections)-1)
+	case keyToggle:
+		if len(m.sections) > 0 {
+			m.sections[m.cursor].expanded = !m.sections[m.cursor].expanded
+		}
+	case keyExpand, keyCollapse:
+		for i := range m.sections {
+			m.sections[i].expanded = key == keyExpand
+		}
+	case keyPageUp:
+		m.offset = max(m.offset-m.pageSize(), 0)
+		return false // Scroll without following the cursor
+	case keyPageDown:
+		m.offset = max(min(m.offset+m.pageSize(), len(m.lines())-m.pageSize()), 0)
+		return false
+	case keyTop:
+		m.cursor = 0
+	case keyBottom:
+		m.cursor = max(len(m.sections)-1, 0)
+	case keyQuit:
+		return true
+	}
+	m.scrollToCursor()
+	return false
+}
+
+// scrollToCursor scrolls the screen so that the selected section is visible
+func (m *foldModel) scrollToCursor() {
+	var line int
+	for i := 0; i < m.cursor; i++ {
+		line += m.sectionHeight(i)
+	}
+	if line < m.offset {
+		m.offset = line
+	} else if line >= m.offset+m.pageSize() {
+		m.offset = line - m.pageSize() + 1
+	}
+}
+
+// pageSize returns the number of lines for the sections, i.e. the screen without the help line
+func (m *foldModel) pageSize() int {
+	return max(m.height-1, 1)
+}
+
+func (m *foldModel) sectionHeight(i int) int {
+	if m.sections[i].expanded {
+		return 1 + len(m.sections[i].body)
+	}
+	return 1
+}
+
+// lines returns all the lines of the sections, with the selected section highlighted
+func (m *foldModel) lines() []string {
+	var lines []string
+	for i, section := range m.sections {
+		marker := "▸"
+		if section.expanded {
+			marker = "▾"
+		}
+		header := fmt.Sprintf("%s %s", marker, section.title)
+		if section.summary != "" && section.summary != section.title {
+			header += "  " + section.summary
+		}
+		if i == m.cursor {
+			header = "\x1b[7m" + header + "\x1b[0m" // reverse video
+		}
+		lines = append(lines, header)
+		if section.expanded {
+			for _, line := range section.body {
+				lines = append(lines, "  "+line)
+			}
+		}
+	}
+	return lines
+}
+
+// view renders the visible lines and the help line, truncating lines wider than the screen
+func (m *foldModel) view() string {
+	lines := m.lines()
+	end := min(m.offset+m.pageSize(), len(lines))
+
+	var sb strings.Builder
+	for i := m.offset; i < end; i++ {
+		sb.WriteString(truncateANSI(lines[i], m.width))
+		sb.WriteString("\r\n") // The terminal is in raw mode
+	}
+	for i := end - m.offset; i < m.pageSize(); i++ {
+		sb.WriteString("\r\n")
+	}
+	sb.WriteString("\x1b[2m" + truncateANSI(foldHelp, m.width) + "\x1b[0m")
+	return sb.String()
+}
+
+// truncateANSI truncates the line to the width of the screen, keeping colors
+func truncateANSI(line string, width int) string {
+	if width <= 0 || displayWidth(line) <= width {
+		return line
+	}
+
+	var sb strings.Builder
+	var w int
+	for len(line) > 0 {
+		if loc := ansiEscape.FindStringIndex(line); loc != nil && loc[0] == 0 {
+			sb.WriteString(line[:loc[1]])
+			line = line[loc[1]:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(line)
+		rw := runewidth.RuneWidth(r)
+		if w+rw > width-1 {
+			break
+		}
+		sb.WriteRune(r)
+		w += rw
+		line = line[size:]
+	}
+	return sb.String() + "…\x1b[0m"
+}
+
+// readKey reads a key pressed in the terminal in raw mode
+func readKey(r *bufio.Reader) (string, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return "", err
+	}
+	switch b {
+	case 'k':
+		return keyUp, nil
+	case 'j':
+		return keyDown, nil
+	case '\r', '\n', ' ':
+		return keyToggle, nil
+	case 'e':
+		return keyExpand, nil
+	case 'c':
+		return keyCollapse, nil
+	case 'g':
+		return keyTop, nil
+	case 'G':
+		return keyBottom, nil
+	case 'q', 0x03: // Ctrl-C
+		return keyQuit, nil
+	case 0x1b: // Escape sequences, e.g. "\x1b[A"
+		if r.Buffered() == 0 {
+			return keyQuit, nil // Esc
+		}
+		seq := make([]byte, 0, 3)
+		for r.Buffered() > 0 && len(seq) < 3 {
+			c, _ := r.ReadByte()
+			seq = append(seq, c)
+			if c >= 'A' && c <= 'Z' || c == '~' {
+				break
+			}
+		}
+		switch string(seq) {
+		case "[A":
+			return keyUp, nil
+		case "[B":
+			return keyDown, nil
+		case "[5~":
+			return keyPageUp, nil
+		case "[6~":
+			return keyPageDown, nil
+		case "[H":
+			return keyTop, nil
+		case "[F":
+			return keyBottom, nil
+		}
+	}
+	return "", nil
+}
+
+// canRunInteractive returns true if both stdin and stdout are terminals
+func canRunInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// runInteractive shows the rendered results collapsed to their summaries in the alternate screen,
+// and lets users navigate and expand them until they quit.
+func runInteractive(w io.Writer, outputs []string) error {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return xerrors.Errorf("failed to get the terminal size: %w", err)
+	}
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return xerrors.Errorf("failed to enable the raw mode: %w", err)
+	}
+	defer func() { _ = term.Restore(int(os.Stdin.Fd()), state) }()
+
+	// Use the alternate screen and hide the cursor so that the report doesn't remain in the scrollback
+	_, _ = fmt.Fprint(w, "\x1b[?1049h\x1b[?25l")
+	defer func() { _, _ = fmt.Fprint(w, "\x1b[?25h\x1b[?1049l") }()
+
+	m := newFoldModel(outputs, width, height)
+	r := bufio.NewReader(os.Stdin)
+	for {
+		_, _ = fmt.Fprint(w, "\x1b[H\x1b[2J"+m.view())
+		key, err := readKey(r)
+		if err != nil {
+			return xerrors.Errorf("failed to read a key: %w", err)
+		}
+		if m.update(key) {
+			return nil
+		}
+	}
+}
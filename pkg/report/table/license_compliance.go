@@ -0,0 +1,54 @@
+package table
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// renderLicenseCompliance lists the licenses across all the results with the verdict of the license policy,
+// after the table per result.
+func renderLicenseCompliance(w io.Writer, results types.Results, isTerminal, noCellMerge bool) {
+	var passed, failed int
+	tableWriter := newTableWriter(w, isTerminal, !noCellMerge)
+	tableWriter.SetHeaders("Target", "Package", "License", "Verdict")
+	for _, result := range results {
+		for _, license := range result.Licenses {
+			switch license.Verdict {
+			case types.LicenseVerdictPass:
+				passed++
+			case types.LicenseVerdictFail:
+				failed++
+			}
+			// File licenses have the file path instead of the package name
+			pkg := license.PkgName
+			if pkg == "" {
+				pkg = license.FilePath
+			}
+			tableWriter.AddRow(result.Target, pkg, license.Name, licenseVerdictLabel(license.Verdict, isTerminal))
+		}
+	}
+
+	RenderTarget(w, "License Compliance", isTerminal)
+	_, _ = fmt.Fprintf(w, "Total: %d (PASS: %d, FAIL: %d)\n\n", passed+failed, passed, failed)
+	if passed+failed > 0 {
+		tableWriter.Render()
+	}
+}
+
+// licenseVerdictLabel returns the value of the "Verdict" column, colored in the terminal.
+func licenseVerdictLabel(verdict types.LicenseVerdict, isTerminal bool) string {
+	if !isTerminal {
+		return string(verdict)
+	}
+	switch verdict {
+	case types.LicenseVerdictPass:
+		return color.New(color.FgGreen).Sprint(verdict)
+	case types.LicenseVerdictFail:
+		return color.New(color.FgRed).Sprint(verdict)
+	}
+	return string(verdict)
+}
@@ -18,23 +18,28 @@ import (
 )
 
 type pkgLicenseRenderer struct {
-	w           *bytes.Buffer
-	tableWriter *table.Table
-	result      types.Result
-	isTerminal  bool
-	severities  []dbTypes.Severity
-	once        *sync.Once
+	w              *bytes.Buffer
+	tableWriter    *table.Table
+	result         types.Result
+	isTerminal     bool
+	severities     []dbTypes.Severity
+	severityOrder  []string
+	severityLabels map[string]string // Labels rendered instead of the severity names
+	once           *sync.Once
 }
 
-func NewPkgLicenseRenderer(result types.Result, isTerminal bool, severities []dbTypes.Severity) pkgLicenseRenderer {
+func NewPkgLicenseRenderer(result types.Result, isTerminal bool, severities []dbTypes.Severity, noCellMerge bool,
+	severityOrder []string, severityLabels map[string]string) pkgLicenseRenderer {
 	buf := bytes.NewBuffer([]byte{})
 	return pkgLicenseRenderer{
-		w:           buf,
-		tableWriter: newTableWriter(buf, isTerminal),
-		result:      result,
-		isTerminal:  isTerminal,
-		severities:  severities,
-		once:        new(sync.Once),
+		w:              buf,
+		tableWriter:    newTableWriter(buf, isTerminal, !noCellMerge),
+		result:         result,
+		isTerminal:     isTerminal,
+		severities:     severities,
+		severityOrder:  severityOrder,
+		severityLabels: severityLabels,
+		once:           new(sync.Once),
 	}
 }
 
@@ -48,7 +53,7 @@ func (r pkgLicenseRenderer) Render() string {
 	r.setHeaders()
 	r.setRows()
 
-	total, summaries := summarize(r.severities, r.countSeverities())
+	total, summaries := summarize(r.severities, r.severityOrder, r.severityLabels, r.countSeverities(), r.result.PreviousCounts)
 
 	target := r.result.Target + " (license)"
 	RenderTarget(r.w, target, r.isTerminal)
@@ -77,14 +82,14 @@ func (r pkgLicenseRenderer) setRows() {
 				l.PkgName,
 				l.Name,
 				colorizeLicenseCategory(l.Category),
-				ColorizeSeverity(l.Severity, l.Severity),
+				ColorizeSeverity(severityLabel(l.Severity, r.severityLabels), l.Severity),
 			}
 		} else {
 			row = []string{
 				l.PkgName,
 				l.Name,
 				string(l.Category),
-				l.Severity,
+				severityLabel(l.Severity, r.severityLabels),
 			}
 		}
 		r.tableWriter.AddRow(row...)
@@ -105,23 +110,28 @@ func (r *pkgLicenseRenderer) printf(format string, args ...any) {
 }
 
 type fileLicenseRenderer struct {
-	w           *bytes.Buffer
-	tableWriter *table.Table
-	result      types.Result
-	isTerminal  bool
-	severities  []dbTypes.Severity
-	once        *sync.Once
+	w              *bytes.Buffer
+	tableWriter    *table.Table
+	result         types.Result
+	isTerminal     bool
+	severities     []dbTypes.Severity
+	severityOrder  []string
+	severityLabels map[string]string // Labels rendered instead of the severity names
+	once           *sync.Once
 }
 
-func NewFileLicenseRenderer(result types.Result, isTerminal bool, severities []dbTypes.Severity) fileLicenseRenderer {
+func NewFileLicenseRenderer(result types.Result, isTerminal bool, severities []dbTypes.Severity, noCellMerge bool,
+	severityOrder []string, severityLabels map[string]string) fileLicenseRenderer {
 	buf := bytes.NewBuffer([]byte{})
 	return fileLicenseRenderer{
-		w:           buf,
-		tableWriter: newTableWriter(buf, isTerminal),
-		result:      result,
-		isTerminal:  isTerminal,
-		severities:  severities,
-		once:        new(sync.Once),
+		w:              buf,
+		tableWriter:    newTableWriter(buf, isTerminal, !noCellMerge),
+		result:         result,
+		isTerminal:     isTerminal,
+		severities:     severities,
+		severityOrder:  severityOrder,
+		severityLabels: severityLabels,
+		once:           new(sync.Once),
 	}
 }
 
@@ -135,7 +145,7 @@ func (r fileLicenseRenderer) Render() string {
 	r.setHeaders()
 	r.setRows()
 
-	total, summaries := summarize(r.severities, r.countSeverities())
+	total, summaries := summarize(r.severities, r.severityOrder, r.severityLabels, r.countSeverities(), r.result.PreviousCounts)
 
 	target := r.result.Target + " (license)"
 	RenderTarget(r.w, target, r.isTerminal)
@@ -177,14 +187,14 @@ func (r fileLicenseRenderer) setRows() {
 		if r.isTerminal {
 			row = []string{
 				colorizeLicenseCategory(l.Category),
-				ColorizeSeverity(l.Severity, l.Severity),
+				ColorizeSeverity(severityLabel(l.Severity, r.severityLabels), l.Severity),
 				l.Name,
 				l.FilePath,
 			}
 		} else {
 			row = []string{
 				string(l.Category),
-				l.Severity,
+				severityLabel(l.Severity, r.severityLabels),
 				l.Name,
 				l.FilePath,
 			}
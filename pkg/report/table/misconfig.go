@@ -3,7 +3,13 @@ package table
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 	"golang.org/x/term"
@@ -18,6 +24,9 @@ const (
 	severityHigh     = "HIGH"
 	severityMedium   = "MEDIUM"
 	severityLow      = "LOW"
+
+	// Number of lines rendered before and after the offending lines in code frames
+	codeFrameContext = 2
 )
 
 type misconfigRenderer struct {
@@ -25,12 +34,19 @@ type misconfigRenderer struct {
 	result             types.Result
 	severities         []dbTypes.Severity
 	trace              bool
+	policySource       bool
 	includeNonFailures bool
 	width              int
 	ansi               bool
+	severityOrder      []string
+	severityLabels     map[string]string // Labels rendered instead of the severity names
+	source             func() []string   // Lines of the source file for code frames
 }
 
-func NewMisconfigRenderer(result types.Result, severities []dbTypes.Severity, trace, includeNonFailures, ansi bool) *misconfigRenderer {
+// NewMisconfigRenderer returns a renderer of misconfigurations.
+// If codeDir is not empty, the offending lines are rendered with the surrounding lines of the source file in the directory.
+func NewMisconfigRenderer(result types.Result, severities []dbTypes.Severity, trace, policySource, includeNonFailures,
+	ansi bool, severityOrder []string, severityLabels map[string]string, codeDir string) *misconfigRenderer {
 	width, _, err := term.GetSize(0)
 	if err != nil || width == 0 {
 		width = 40
@@ -43,12 +59,33 @@ func NewMisconfigRenderer(result types.Result, severities []dbTypes.Severity, tr
 		result:             result,
 		severities:         severities,
 		trace:              trace,
+		policySource:       policySource,
 		includeNonFailures: includeNonFailures,
 		width:              width,
 		ansi:               ansi,
+		severityOrder:      severityOrder,
+		severityLabels:     severityLabels,
+		source:             sync.OnceValue(func() []string { return readSource(codeDir, result.Target) }),
 	}
 }
 
+// readSource returns the lines of the target file, or nil if the file is not accessible.
+func readSource(dir, target string) []string {
+	if dir == "" {
+		return nil
+	}
+	path := target
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, filepath.FromSlash(target))
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	content := strings.TrimSuffix(strings.ReplaceAll(string(b), "\r\n", "\n"), "\n")
+	return strings.Split(content, "\n")
+}
+
 func (r *misconfigRenderer) Render() string {
 	// Trivy doesn't currently support showing suppressed misconfigs
 	// So just skip this result
@@ -58,12 +95,17 @@ func (r *misconfigRenderer) Render() string {
 	target := fmt.Sprintf("%s (%s)", r.result.Target, r.result.Type)
 	RenderTarget(r.w, target, r.ansi)
 
-	total, summaries := summarize(r.severities, r.countSeverities())
+	total, summaries := summarize(r.severities, r.severityOrder, r.severityLabels, r.countSeverities(), r.result.PreviousCounts)
 
 	summary := r.result.MisconfSummary
 	r.printf("Tests: %d (SUCCESSES: %d, FAILURES: %d)\n",
 		summary.Successes+summary.Failures, summary.Successes, summary.Failures)
-	r.printf("Failures: %d (%s)\n\n", total, strings.Join(summaries, ", "))
+	r.printf("Failures: %d (%s)\n", total, strings.Join(summaries, ", "))
+	if filtered := r.result.FilteredCounts; filtered != nil {
+		// Counted only with "--show-filtered-count"
+		r.printf("%s\n", filteredSummary(*filtered))
+	}
+	r.printf("\n")
 
 	for _, m := range r.result.Misconfigurations {
 		r.renderSingle(m)
@@ -112,30 +154,36 @@ func (r *misconfigRenderer) renderSingle(misconf types.DetectedMisconfiguration)
 
 func (r *misconfigRenderer) renderSummary(misconf types.DetectedMisconfiguration) {
 
-	// show pass/fail/exception unless we are only showing failures
-	if r.includeNonFailures {
+	// show pass/fail/exception unless we are only showing failures.
+	// "--misconfig-diff" shows resolved checks as well as newly failing ones.
+	if r.includeNonFailures || misconf.BaselineStatus != "" {
+		status := string(misconf.Status)
+		if misconf.BaselineStatus != "" {
+			status += fmt.Sprintf(" (%s)", misconf.BaselineStatus)
+		}
 		switch misconf.Status {
 		case types.MisconfStatusPassed:
-			r.printf("<green><bold>%s: ", misconf.Status)
+			r.printf("<green><bold>%s: ", status)
 		case types.MisconfStatusFailure:
-			r.printf("<red><bold>%s: ", misconf.Status)
+			r.printf("<red><bold>%s: ", status)
 		case types.MisconfStatusException:
-			r.printf("<yellow><bold>%s: ", misconf.Status)
+			r.printf("<yellow><bold>%s: ", status)
 		}
 	}
 
 	// ID & severity
+	severity := severityLabel(misconf.Severity, r.severityLabels)
 	switch misconf.Severity {
 	case severityCritical:
-		r.printf("%s <red><bold>(%s): ", misconf.AVDID, misconf.Severity)
+		r.printf("%s <red><bold>(%s): ", misconf.AVDID, severity)
 	case severityHigh:
-		r.printf("%s <red>(%s): ", misconf.AVDID, misconf.Severity)
+		r.printf("%s <red>(%s): ", misconf.AVDID, severity)
 	case severityMedium:
-		r.printf("%s <yellow>(%s): ", misconf.AVDID, misconf.Severity)
+		r.printf("%s <yellow>(%s): ", misconf.AVDID, severity)
 	case severityLow:
-		r.printf("%s (%s): ", misconf.AVDID, misconf.Severity)
+		r.printf("%s (%s): ", misconf.AVDID, severity)
 	default:
-		r.printf("%s <blue>(%s): ", misconf.AVDID, misconf.Severity)
+		r.printf("%s <blue>(%s): ", misconf.AVDID, severity)
 	}
 
 	// heading
@@ -154,8 +202,9 @@ func (r *misconfigRenderer) renderSummary(misconf types.DetectedMisconfiguration
 }
 
 func (r *misconfigRenderer) renderCode(misconf types.DetectedMisconfiguration) {
+	start, end := r.codeFrameLines(misconf)
 	// highlight code if we can...
-	if lines := misconf.CauseMetadata.Code.Lines; len(lines) > 0 {
+	if lines := misconf.CauseMetadata.Code.Lines; len(lines) > 0 || start > 0 {
 
 		var lineInfo string
 		if misconf.CauseMetadata.StartLine > 0 {
@@ -181,6 +230,11 @@ func (r *misconfigRenderer) renderCode(misconf types.DetectedMisconfiguration) {
 		}
 
 		r.printSingleDivider()
+		if start > 0 {
+			r.renderCodeFrame(start, end)
+			r.printSingleDivider()
+			return
+		}
 		for i, line := range lines {
 			switch {
 			case line.Truncated:
@@ -211,6 +265,45 @@ func (r *misconfigRenderer) renderCode(misconf types.DetectedMisconfiguration) {
 	}
 }
 
+// codeFrameLines returns the range of the offending lines in the source file,
+// or zeros if the source file is not accessible or the misconfiguration has no location.
+func (r *misconfigRenderer) codeFrameLines(misconf types.DetectedMisconfiguration) (int, int) {
+	start, end := misconf.CauseMetadata.StartLine, misconf.CauseMetadata.EndLine
+	lines := r.source()
+	if start <= 0 || start > len(lines) {
+		return 0, 0
+	}
+	return start, max(start, min(end, len(lines)))
+}
+
+// renderCodeFrame renders the offending lines with the surrounding lines of the source file.
+// Offending lines are marked with ">", and a single offending line is underlined with carets.
+//
+//	  2 | FROM alpine:3.20
+//	> 3 | USER root
+//	    | ^^^^^^^^^
+//	  4 | CMD ["sh"]
+func (r *misconfigRenderer) renderCodeFrame(start, end int) {
+	lines := r.source()
+	first, last := max(1, start-codeFrameContext), min(len(lines), end+codeFrameContext)
+	width := len(strconv.Itoa(last))
+	for n := first; n <= last; n++ {
+		line := strings.TrimRight(lines[n-1], " \t")
+		if n < start || n > end {
+			r.printf("<dim>  %*d | </dim>%s\r\n", width, n, line)
+			continue
+		}
+		r.printf("<red><bold>> %*d | </bold>%s\r\n", width, n, line)
+		if start == end {
+			// Keep tabs in the indentation so that carets are aligned with the code
+			code := strings.TrimLeft(line, " \t")
+			indent := line[:len(line)-len(code)]
+			r.printf("  %s | %s<red>%s\r\n", strings.Repeat(" ", width), indent,
+				strings.Repeat("^", max(1, utf8.RuneCountInString(code))))
+		}
+	}
+}
+
 func (r *misconfigRenderer) outputTrace() {
 	blue := color.New(color.FgBlue).SprintFunc()
 	green := color.New(color.FgGreen).SprintfFunc()
@@ -234,6 +327,52 @@ func (r *misconfigRenderer) outputTrace() {
 		for _, t := range misconf.Traces {
 			r.println(blue("TRACE ") + t)
 		}
+		if r.policySource {
+			r.outputPolicySource(misconf)
+		}
 		r.println("")
 	}
 }
+
+func (r *misconfigRenderer) outputPolicySource(misconf types.DetectedMisconfiguration) {
+	blue := color.New(color.FgBlue).SprintFunc()
+	if misconf.PolicySource == "" {
+		r.println(blue("SOURCE ") + "not available (e.g. built-in compiled checks)")
+		return
+	}
+	source := misconf.PolicySource
+	if r.ansi {
+		source = highlightRego(source)
+	}
+	for _, line := range strings.Split(source, "\n") {
+		r.println(blue("SOURCE ") + line)
+	}
+}
+
+var (
+	regoTokenRegexp = regexp.MustCompile("#.*$|\"(?:\\\\.|[^\"\\\\])*\"|`[^`]*`|" +
+		`\b(?:package|import|default|not|some|every|in|if|contains|else|with|as|true|false|null)\b`)
+
+	regoComment = color.New(color.FgHiBlack).SprintFunc()
+	regoString  = color.New(color.FgGreen).SprintFunc()
+	regoKeyword = color.New(color.FgMagenta).SprintFunc()
+)
+
+// highlightRego colors comments, strings and keywords of the Rego source, line by line
+// so that a line never contains an unterminated escape sequence.
+func highlightRego(source string) string {
+	lines := strings.Split(source, "\n")
+	for i, line := range lines {
+		lines[i] = regoTokenRegexp.ReplaceAllStringFunc(line, func(token string) string {
+			switch token[0] {
+			case '#':
+				return regoComment(token)
+			case '"', '`':
+				return regoString(token)
+			default:
+				return regoKeyword(token)
+			}
+		})
+	}
+	return strings.Join(lines, "\n")
+}
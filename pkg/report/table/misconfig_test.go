@@ -18,6 +18,9 @@ func TestMisconfigRenderer(t *testing.T) {
 		name               string
 		input              types.Result
 		includeNonFailures bool
+		trace              bool
+		policySource       bool
+		codeDir            string
 		want               string
 	}{
 		{
@@ -53,6 +56,153 @@ See https://google.com/search?q=bad%20config
 ────────────────────────────────────────
 
 
+`,
+		},
+		{
+			name: "diff against the baseline",
+			input: types.Result{
+				Target:         "my-file",
+				MisconfSummary: &types.MisconfSummary{Successes: 1, Failures: 1},
+				Misconfigurations: []types.DetectedMisconfiguration{
+					{
+						AVDID:          "AVD-XYZ-0123",
+						Description:    "Your config file is not good.",
+						Message:        "Oh no, a bad config.",
+						Severity:       "HIGH",
+						Status:         "FAIL",
+						BaselineStatus: types.BaselineStatusNew,
+					},
+					{
+						AVDID:          "AVD-XYZ-0456",
+						Description:    "Your config file is good now.",
+						Message:        "No issues found",
+						Severity:       "LOW",
+						Status:         "PASS",
+						BaselineStatus: types.BaselineStatusResolved,
+					},
+				},
+			},
+			includeNonFailures: false,
+			want: `
+my-file ()
+==========
+Tests: 2 (SUCCESSES: 1, FAILURES: 1)
+Failures: 1 (LOW: 0, MEDIUM: 0, HIGH: 1, CRITICAL: 0)
+
+FAIL (new): AVD-XYZ-0123 (HIGH): Oh no, a bad config.
+════════════════════════════════════════
+Your config file is not good.
+────────────────────────────────────────
+
+
+PASS (resolved): AVD-XYZ-0456 (LOW): No issues found
+════════════════════════════════════════
+Your config file is good now.
+────────────────────────────────────────
+
+
+`,
+		},
+		{
+			name: "trace with policy source",
+			input: types.Result{
+				Target:         "my-file",
+				MisconfSummary: &types.MisconfSummary{Successes: 0, Failures: 1},
+				Misconfigurations: []types.DetectedMisconfiguration{
+					{
+						ID:           "some-alias-for-a-check",
+						AVDID:        "AVD-XYZ-0123",
+						Title:        "Config file is bad",
+						Description:  "Your config file is not good.",
+						Message:      "Oh no, a bad config.",
+						Severity:     "HIGH",
+						PrimaryURL:   "https://google.com/search?q=bad%20config",
+						Status:       "FAIL",
+						Namespace:    "user.test",
+						Query:        "data.user.test.deny",
+						Traces:       []string{"Enter data.user.test.deny = _"},
+						PolicySource: "deny contains msg if {\n\tinput.bad\n\tmsg := \"Oh no, a bad config.\"\n}",
+					},
+				},
+			},
+			includeNonFailures: false,
+			trace:              true,
+			policySource:       true,
+			want: `
+my-file ()
+==========
+Tests: 1 (SUCCESSES: 0, FAILURES: 1)
+Failures: 1 (LOW: 0, MEDIUM: 0, HIGH: 1, CRITICAL: 0)
+
+AVD-XYZ-0123 (HIGH): Oh no, a bad config.
+════════════════════════════════════════
+Your config file is not good.
+
+See https://google.com/search?q=bad%20config
+────────────────────────────────────────
+
+
+
+ID: some-alias-for-a-check
+File: my-file
+Namespace: user.test
+Query: data.user.test.deny
+Message: Oh no, a bad config.
+TRACE Enter data.user.test.deny = _
+SOURCE deny contains msg if {
+SOURCE 	input.bad
+SOURCE 	msg := "Oh no, a bad config."
+SOURCE }
+
+`,
+		},
+		{
+			name: "trace without policy source",
+			input: types.Result{
+				Target:         "my-file",
+				MisconfSummary: &types.MisconfSummary{Successes: 0, Failures: 1},
+				Misconfigurations: []types.DetectedMisconfiguration{
+					{
+						ID:          "some-alias-for-a-check",
+						AVDID:       "AVD-XYZ-0123",
+						Title:       "Config file is bad",
+						Description: "Your config file is not good.",
+						Message:     "Oh no, a bad config.",
+						Severity:    "HIGH",
+						PrimaryURL:  "https://google.com/search?q=bad%20config",
+						Status:      "FAIL",
+						Namespace:   "user.test",
+						Query:       "data.user.test.deny",
+						Traces:      []string{"Enter data.user.test.deny = _"},
+					},
+				},
+			},
+			includeNonFailures: false,
+			trace:              true,
+			policySource:       true,
+			want: `
+my-file ()
+==========
+Tests: 1 (SUCCESSES: 0, FAILURES: 1)
+Failures: 1 (LOW: 0, MEDIUM: 0, HIGH: 1, CRITICAL: 0)
+
+AVD-XYZ-0123 (HIGH): Oh no, a bad config.
+════════════════════════════════════════
+Your config file is not good.
+
+See https://google.com/search?q=bad%20config
+────────────────────────────────────────
+
+
+
+ID: some-alias-for-a-check
+File: my-file
+Namespace: user.test
+Query: data.user.test.deny
+Message: Oh no, a bad config.
+TRACE Enter data.user.test.deny = _
+SOURCE not available (e.g. built-in compiled checks)
+
 `,
 		},
 		{
@@ -118,6 +268,135 @@ See https://google.com/search?q=bad%20config
 ────────────────────────────────────────
 
 
+`,
+		},
+		{
+			name: "single result with code frame",
+			input: types.Result{
+				Target:         "Dockerfile",
+				MisconfSummary: &types.MisconfSummary{Successes: 0, Failures: 2},
+				Misconfigurations: []types.DetectedMisconfiguration{
+					{
+						AVDID:       "AVD-DS-0002",
+						Title:       "Image user should not be 'root'",
+						Description: "Running containers with 'root' user can lead to a container escape situation.",
+						Message:     "Last USER command in Dockerfile should not be 'root'",
+						Severity:    "HIGH",
+						Status:      "FAIL",
+						CauseMetadata: ftypes.CauseMetadata{
+							StartLine: 3,
+							EndLine:   3,
+							Code: ftypes.Code{
+								Lines: []ftypes.Line{
+									{
+										Number:     3,
+										Content:    "USER root",
+										IsCause:    true,
+										FirstCause: true,
+										LastCause:  true,
+									},
+								},
+							},
+						},
+					},
+					{
+						AVDID:       "AVD-DS-0026",
+						Title:       "No HEALTHCHECK defined",
+						Description: "You should add HEALTHCHECK instruction in your docker container images.",
+						Message:     "Add HEALTHCHECK instruction in your Dockerfile",
+						Severity:    "LOW",
+						Status:      "FAIL",
+						CauseMetadata: ftypes.CauseMetadata{
+							StartLine: 1,
+							EndLine:   2,
+						},
+					},
+				},
+			},
+			codeDir: "testdata",
+			want: `
+Dockerfile ()
+=============
+Tests: 2 (SUCCESSES: 0, FAILURES: 2)
+Failures: 2 (LOW: 1, MEDIUM: 0, HIGH: 1, CRITICAL: 0)
+
+AVD-DS-0002 (HIGH): Last USER command in Dockerfile should not be 'root'
+════════════════════════════════════════
+Running containers with 'root' user can lead to a container escape situation.
+────────────────────────────────────────
+ Dockerfile:3
+────────────────────────────────────────
+  1 | FROM alpine:3.20
+  2 | RUN apk add --no-cache curl
+> 3 | USER root
+    | ^^^^^^^^^
+  4 | CMD ["sh"]
+────────────────────────────────────────
+
+
+AVD-DS-0026 (LOW): Add HEALTHCHECK instruction in your Dockerfile
+════════════════════════════════════════
+You should add HEALTHCHECK instruction in your docker container images.
+────────────────────────────────────────
+ Dockerfile:1-2
+────────────────────────────────────────
+> 1 | FROM alpine:3.20
+> 2 | RUN apk add --no-cache curl
+  3 | USER root
+  4 | CMD ["sh"]
+────────────────────────────────────────
+
+
+`,
+		},
+		{
+			name: "code frame without source file",
+			input: types.Result{
+				Target:         "missing/Dockerfile",
+				MisconfSummary: &types.MisconfSummary{Successes: 0, Failures: 1},
+				Misconfigurations: []types.DetectedMisconfiguration{
+					{
+						AVDID:       "AVD-DS-0002",
+						Title:       "Image user should not be 'root'",
+						Description: "Running containers with 'root' user can lead to a container escape situation.",
+						Message:     "Last USER command in Dockerfile should not be 'root'",
+						Severity:    "HIGH",
+						Status:      "FAIL",
+						CauseMetadata: ftypes.CauseMetadata{
+							StartLine: 3,
+							EndLine:   3,
+							Code: ftypes.Code{
+								Lines: []ftypes.Line{
+									{
+										Number:     3,
+										Content:    "USER root",
+										IsCause:    true,
+										FirstCause: true,
+										LastCause:  true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			codeDir: "testdata",
+			want: `
+missing/Dockerfile ()
+=====================
+Tests: 1 (SUCCESSES: 0, FAILURES: 1)
+Failures: 1 (LOW: 0, MEDIUM: 0, HIGH: 1, CRITICAL: 0)
+
+AVD-DS-0002 (HIGH): Last USER command in Dockerfile should not be 'root'
+════════════════════════════════════════
+Running containers with 'root' user can lead to a container escape situation.
+────────────────────────────────────────
+ missing/Dockerfile:3
+────────────────────────────────────────
+   3 [ USER root
+────────────────────────────────────────
+
+
 `,
 		},
 		{
@@ -343,7 +622,8 @@ See https://avd.aquasec.com/misconfig/avd-aws-0107
 		t.Run(test.name, func(t *testing.T) {
 			severities := []dbTypes.Severity{dbTypes.SeverityLow, dbTypes.SeverityMedium, dbTypes.SeverityHigh,
 				dbTypes.SeverityCritical}
-			renderer := table.NewMisconfigRenderer(test.input, severities, false, test.includeNonFailures, false)
+			renderer := table.NewMisconfigRenderer(test.input, severities, test.trace, test.policySource,
+				test.includeNonFailures, false, nil, nil, test.codeDir)
 			assert.Equal(t, test.want, strings.ReplaceAll(renderer.Render(), "\r\n", "\n"))
 		})
 	}
@@ -0,0 +1,75 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/report/qrcode"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// advisory represents the advisory of a finding
+type advisory struct {
+	ID       string
+	Severity string
+	URL      string
+}
+
+// renderQRCode prints a QR code linking to the advisory of the most critical finding.
+// Only one QR code is printed per report to avoid cluttering the terminal.
+func renderQRCode(w io.Writer, results types.Results, severityOrder []string) {
+	adv, found := mostCriticalAdvisory(results, severityOrder)
+	if !found {
+		return
+	}
+	code, err := qrcode.Encode(adv.URL)
+	if err != nil {
+		log.Debug("Unable to generate a QR code", log.String("url", adv.URL), log.Err(err))
+		return
+	}
+
+	RenderTarget(w, "Most Critical Finding", true)
+	_, _ = fmt.Fprintf(w, "%s (%s): %s\n\n", adv.ID, ColorizeSeverity(adv.Severity, adv.Severity), adv.URL)
+	_ = code.Render(w)
+}
+
+// mostCriticalAdvisory returns the advisory of the finding with the highest severity.
+// The first finding in the report wins if several findings have the same severity.
+func mostCriticalAdvisory(results types.Results, severityOrder []string) (advisory, bool) {
+	order := orderOrDefault(severityOrder)
+
+	var advisories []advisory
+	for _, result := range results {
+		for _, vuln := range result.Vulnerabilities {
+			advisories = append(advisories, advisory{
+				ID:       vuln.VulnerabilityID,
+				Severity: vuln.Severity,
+				URL:      vuln.PrimaryURL,
+			})
+		}
+		for _, misconf := range result.Misconfigurations {
+			if misconf.Status != types.MisconfStatusFailure {
+				continue
+			}
+			advisories = append(advisories, advisory{
+				ID:       misconf.ID,
+				Severity: misconf.Severity,
+				URL:      misconf.PrimaryURL,
+			})
+		}
+	}
+
+	var best advisory
+	var found bool
+	for _, adv := range advisories {
+		if adv.URL == "" {
+			continue
+		}
+		if !found || slices.Index(order, adv.Severity) > slices.Index(order, best.Severity) {
+			best, found = adv, true
+		}
+	}
+	return best, found
+}
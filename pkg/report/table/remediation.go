@@ -0,0 +1,197 @@
+package table
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/aquasecurity/go-version/pkg/version"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// upgrade represents a package and the vulnerabilities resolved by upgrading it.
+// fixedVersion is empty when no fixed version is available.
+type upgrade struct {
+	target           string
+	pkgName          string
+	installedVersion string
+	fixedVersion     string
+	vulnIDs          []string
+}
+
+// remediationPlan aggregates the vulnerabilities by package and the version to upgrade to.
+// The vulnerabilities without fixed versions are returned separately.
+func remediationPlan(results types.Results) (upgrades, unfixable []upgrade) {
+	for _, result := range results {
+		type pkgKey struct {
+			name, version string
+		}
+		var keys []pkgKey
+		vulnsByPkg := make(map[pkgKey][]types.DetectedVulnerability)
+		for _, v := range result.Vulnerabilities {
+			key := pkgKey{
+				name:    v.PkgName,
+				version: v.InstalledVersion,
+			}
+			if _, ok := vulnsByPkg[key]; !ok {
+				keys = append(keys, key)
+			}
+			vulnsByPkg[key] = append(vulnsByPkg[key], v)
+		}
+
+		for _, key := range keys {
+			var fixable, unfixed []types.DetectedVulnerability
+			for _, v := range vulnsByPkg[key] {
+				if v.FixedVersion == "" {
+					unfixed = append(unfixed, v)
+				} else {
+					fixable = append(fixable, v)
+				}
+			}
+
+			pkg := upgrade{
+				target:           result.Target,
+				pkgName:          key.name,
+				installedVersion: key.version,
+			}
+			if fixedVersion, resolved := recommendUpgrade(key.version, fixable); len(resolved) > 0 {
+				u := pkg
+				u.fixedVersion = fixedVersion
+				u.vulnIDs = vulnIDs(resolved)
+				upgrades = append(upgrades, u)
+			}
+			if len(unfixed) > 0 {
+				u := pkg
+				u.vulnIDs = vulnIDs(unfixed)
+				unfixable = append(unfixable, u)
+			}
+		}
+	}
+
+	// The upgrades resolving the most vulnerabilities come first
+	slices.SortStableFunc(upgrades, func(a, b upgrade) int {
+		return cmp.Compare(len(b.vulnIDs), len(a.vulnIDs))
+	})
+	return upgrades, unfixable
+}
+
+// recommendUpgrade returns the minimal fixed version resolving the most vulnerabilities and the resolved vulnerabilities.
+// A vulnerability is resolved by a version if it is equal to or newer than any of its fixed versions.
+// Versions are compared as generic versions, and versions which cannot be parsed are compared as strings.
+func recommendUpgrade(installedVersion string, vulns []types.DetectedVulnerability) (string, []types.DetectedVulnerability) {
+	var candidates []string
+	for _, v := range vulns {
+		candidates = append(candidates, newerFixedVersions(installedVersion, v.FixedVersion)...)
+	}
+	slices.SortFunc(candidates, compareVersions)
+	candidates = slices.Compact(candidates)
+
+	var recommended string
+	var resolved []types.DetectedVulnerability
+	for _, candidate := range candidates {
+		var rs []types.DetectedVulnerability
+		for _, v := range vulns {
+			if resolves(candidate, v.FixedVersion) {
+				rs = append(rs, v)
+			}
+		}
+		// Candidates are sorted, so the minimal version is kept on ties
+		if len(rs) > len(resolved) {
+			recommended, resolved = candidate, rs
+		}
+	}
+	return recommended, resolved
+}
+
+// TargetVersion returns the minimal fixed version newer than the installed version, e.g. "4.17.21" for "4.17.21, 5.0.0",
+// or an empty string if there is no such version.
+func TargetVersion(installedVersion, fixedVersions string) string {
+	candidates := newerFixedVersions(installedVersion, fixedVersions)
+	if len(candidates) == 0 {
+		return ""
+	}
+	return slices.MinFunc(candidates, compareVersions)
+}
+
+// newerFixedVersions returns the fixed versions newer than the installed version.
+// Fixed versions older than the installed version are for other release branches and are skipped.
+func newerFixedVersions(installedVersion, fixedVersions string) []string {
+	var versions []string
+	for _, fixed := range strings.Split(fixedVersions, ",") {
+		fixed = strings.TrimSpace(fixed)
+		if fixed == "" || (installedVersion != "" && compareVersions(fixed, installedVersion) <= 0) {
+			continue
+		}
+		versions = append(versions, fixed)
+	}
+	return versions
+}
+
+func resolves(candidate, fixedVersions string) bool {
+	for _, fixed := range strings.Split(fixedVersions, ",") {
+		if fixed = strings.TrimSpace(fixed); fixed != "" && compareVersions(candidate, fixed) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func compareVersions(a, b string) int {
+	va, errA := version.Parse(a)
+	vb, errB := version.Parse(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return va.Compare(vb)
+}
+
+func vulnIDs(vulns []types.DetectedVulnerability) []string {
+	return lo.Uniq(lo.Map(vulns, func(v types.DetectedVulnerability, _ int) string {
+		return v.VulnerabilityID
+	}))
+}
+
+// renderRemediationPlan lists the package upgrades and the vulnerabilities resolved by each of them
+// across all the results, instead of rendering a table per result.
+func renderRemediationPlan(w io.Writer, results types.Results, isTerminal bool) {
+	upgrades, unfixable := remediationPlan(results)
+
+	var fixes int
+	for _, u := range upgrades {
+		fixes += len(u.vulnIDs)
+	}
+
+	RenderTarget(w, "Remediation Plan", isTerminal)
+	_, _ = fmt.Fprintf(w, "Upgrades: %d packages to fix %d vulnerabilities\n\n", len(upgrades), fixes)
+	if len(upgrades) > 0 {
+		// Cells are not merged so that the numbers of fixes of different packages are not merged
+		tableWriter := newTableWriter(w, isTerminal, false)
+		tableWriter.SetHeaders("Target", "Library", "Installed Version", "Upgrade To", "Fixes", "Vulnerabilities")
+		for _, u := range upgrades {
+			tableWriter.AddRow(u.target, u.pkgName, u.installedVersion, u.fixedVersion,
+				strconv.Itoa(len(u.vulnIDs)), strings.Join(u.vulnIDs, "\n"))
+		}
+		tableWriter.Render()
+	}
+
+	if len(unfixable) == 0 {
+		return
+	}
+	var unfixed int
+	for _, u := range unfixable {
+		unfixed += len(u.vulnIDs)
+	}
+	RenderTarget(w, "No Remediation", isTerminal)
+	_, _ = fmt.Fprintf(w, "No fixed version is available for %d vulnerabilities in %d packages\n\n", unfixed, len(unfixable))
+	tableWriter := newTableWriter(w, isTerminal, false)
+	tableWriter.SetHeaders("Target", "Library", "Installed Version", "Vulnerabilities")
+	for _, u := range unfixable {
+		tableWriter.AddRow(u.target, u.pkgName, u.installedVersion, strings.Join(u.vulnIDs, "\n"))
+	}
+	tableWriter.Render()
+}
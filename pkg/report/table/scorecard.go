@@ -0,0 +1,66 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/fatih/color"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// renderScorecard renders the percentage of the misconfiguration checks passing with the letter grade,
+// and the checks per severity, after the table per result.
+func renderScorecard(w io.Writer, scorecard *types.Scorecard, isTerminal bool) {
+	RenderTarget(w, "Compliance Scorecard", isTerminal)
+	_, _ = fmt.Fprintf(w, "Score: %s (Grade: %s)\n", formatScore(scorecard.Score()),
+		gradeLabel(scorecard.Grade(), isTerminal))
+	_, _ = fmt.Fprintf(w, "Checks: %d (PASS: %d, FAIL: %d, EXCEPTION: %d)\n\n",
+		scorecard.Successes+scorecard.Failures+scorecard.Exceptions, scorecard.Successes, scorecard.Failures,
+		scorecard.Exceptions)
+	if len(scorecard.Severities) == 0 {
+		return
+	}
+
+	// The passed checks are unknown per severity unless they are in the results
+	breakdown := scorecard.SeverityBreakdown()
+	tableWriter := newTableWriter(w, isTerminal, false)
+	tableWriter.SetHeaders("Severity", "Passed", "Failed", "Score", "Grade")
+	for _, sev := range scorecard.Severities {
+		passed, score, grade := "-", "-", "-"
+		if breakdown {
+			passed = strconv.Itoa(sev.Successes)
+			score = formatScore(sev.Score())
+			grade = gradeLabel(types.Grade(sev.Score()), isTerminal)
+		}
+		severity := sev.Severity
+		if isTerminal {
+			severity = ColorizeSeverity(severity, severity)
+		}
+		tableWriter.AddRow(severity, passed, strconv.Itoa(sev.Failures), score, grade)
+	}
+	tableWriter.Render()
+	if !breakdown {
+		_, _ = fmt.Fprintln(w, "Passed checks per severity are shown with '--include-non-failures'.")
+	}
+}
+
+// formatScore formats the percentage with one decimal place, e.g. "92.5%"
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', 1, 64) + "%"
+}
+
+// gradeLabel returns the letter grade, colored in the terminal.
+func gradeLabel(grade string, isTerminal bool) string {
+	if !isTerminal {
+		return grade
+	}
+	switch grade {
+	case "A", "B":
+		return color.New(color.FgGreen).Sprint(grade)
+	case "C", "D":
+		return color.New(color.FgYellow).Sprint(grade)
+	}
+	return color.New(color.FgRed).Sprint(grade)
+}
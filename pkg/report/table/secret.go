@@ -2,8 +2,11 @@ package table
 
 import (
 	"bytes"
+	"cmp"
 	"fmt"
+	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"golang.org/x/term"
 
@@ -13,15 +16,24 @@ import (
 )
 
 type secretRenderer struct {
-	w          *bytes.Buffer
-	target     string
-	secrets    []types.DetectedSecret
-	severities []dbTypes.Severity
-	width      int
-	ansi       bool
+	w              *bytes.Buffer
+	target         string
+	secrets        []types.DetectedSecret
+	severities     []dbTypes.Severity
+	width          int
+	ansi           bool
+	maxRows        int
+	matchWidth     int  // Maximum number of runes rendered per code line (0 means unlimited)
+	showConfidence bool // Show the confidence and the entropy, sorting secrets with higher confidence first
+	severityOrder  []string
+	severityLabels map[string]string     // Labels rendered instead of the severity names
+	previousCount  map[string]int        // Counts of the previous run given by "--trend-file"
+	filteredCounts *types.FilteredCounts // Counts of the filtered secrets given by "--show-filtered-count"
+	byLine         bool                  // Sort secrets by line number, overriding the order by confidence
 }
 
-func NewSecretRenderer(target string, secrets []types.DetectedSecret, ansi bool, severities []dbTypes.Severity) *secretRenderer {
+func NewSecretRenderer(target string, secrets []types.DetectedSecret, ansi bool, severities []dbTypes.Severity,
+	maxRows, matchWidth int, showConfidence bool, severityOrder []string, severityLabels map[string]string) *secretRenderer {
 	width, _, err := term.GetSize(0)
 	if err != nil || width == 0 {
 		width = 40
@@ -30,12 +42,17 @@ func NewSecretRenderer(target string, secrets []types.DetectedSecret, ansi bool,
 		tml.DisableFormatting()
 	}
 	return &secretRenderer{
-		w:          bytes.NewBuffer([]byte{}),
-		target:     target,
-		secrets:    secrets,
-		severities: severities,
-		width:      width,
-		ansi:       ansi,
+		w:              bytes.NewBuffer([]byte{}),
+		target:         target,
+		secrets:        secrets,
+		severities:     severities,
+		width:          width,
+		ansi:           ansi,
+		maxRows:        maxRows,
+		matchWidth:     matchWidth,
+		showConfidence: showConfidence,
+		severityOrder:  severityOrder,
+		severityLabels: severityLabels,
 	}
 }
 
@@ -49,13 +66,34 @@ func (r *secretRenderer) Render() string {
 	RenderTarget(r.w, target, r.ansi)
 
 	severityCount := r.countSeverities()
-	total, summaries := summarize(r.severities, severityCount)
+	total, summaries := summarize(r.severities, r.severityOrder, r.severityLabels, severityCount, r.previousCount)
 
-	r.printf("Total: %d (%s)\n\n", total, strings.Join(summaries, ", "))
+	r.printf("Total: %d (%s)\n", total, strings.Join(summaries, ", "))
+	if r.filteredCounts != nil {
+		r.printf("%s\n", filteredSummary(*r.filteredCounts))
+	}
+	r.printf("\n")
+
+	switch {
+	case r.byLine:
+		// Secrets are read from the top of the file when grouped by file
+		r.secrets = slices.Clone(r.secrets)
+		slices.SortStableFunc(r.secrets, func(a, b types.DetectedSecret) int {
+			return cmp.Compare(a.StartLine, b.StartLine)
+		})
+	case r.showConfidence:
+		// Likely-real secrets come first so that they can be triaged first
+		r.secrets = slices.Clone(r.secrets)
+		slices.SortStableFunc(r.secrets, func(a, b types.DetectedSecret) int {
+			return b.Confidence.Compare(a.Confidence)
+		})
+	}
 
-	for _, m := range r.secrets {
+	secrets, omitted := limitRows(r.secrets, r.maxRows)
+	for _, m := range secrets {
 		r.renderSingle(m)
 	}
+	renderOmitted(r.w, omitted)
 	return r.w.String()
 }
 
@@ -90,21 +128,26 @@ func (r *secretRenderer) renderSingle(secret types.DetectedSecret) {
 func (r *secretRenderer) renderSummary(secret types.DetectedSecret) {
 
 	// severity
+	severity := severityLabel(secret.Severity, r.severityLabels)
 	switch secret.Severity {
 	case severityCritical:
-		r.printf("<red><bold>%s: ", secret.Severity)
+		r.printf("<red><bold>%s: ", severity)
 	case severityHigh:
-		r.printf("<red>%s: ", secret.Severity)
+		r.printf("<red>%s: ", severity)
 	case severityMedium:
-		r.printf("<yellow>%s: ", secret.Severity)
+		r.printf("<yellow>%s: ", severity)
 	case severityLow:
-		r.printf("%s: ", secret.Severity)
+		r.printf("%s: ", severity)
 	default:
-		r.printf("<blue>%s: ", secret.Severity)
+		r.printf("<blue>%s: ", severity)
 	}
 
 	// heading
-	r.printf("%s (%s)\r\n", secret.Category, secret.RuleID)
+	r.printf("%s (%s)", secret.Category, secret.RuleID)
+	if r.showConfidence && secret.Confidence != "" {
+		r.printf(" <dim>[confidence: %s, entropy: %.2f]", secret.Confidence, secret.Entropy)
+	}
+	r.printf("\r\n")
 	r.printDoubleDivider()
 
 	// description
@@ -126,7 +169,10 @@ func (r *secretRenderer) renderCode(secret types.DetectedSecret) {
 		}
 
 		var note string
-		if c := secret.Layer.CreatedBy; c != "" {
+		if c := secret.Commit; c != nil {
+			// The secret was found in the git history
+			note = fmt.Sprintf(" (added in commit '%s' by %s)", c.Hash[:min(len(c.Hash), 12)], c.Author)
+		} else if c := secret.Layer.CreatedBy; c != "" {
 			if len(c) > 40 {
 				// Too long
 				c = c[:40]
@@ -157,12 +203,42 @@ func (r *secretRenderer) renderCode(secret types.DetectedSecret) {
 			default:
 				r.printf("<dim>%4d   ", line.Number)
 			}
+			content := line.Content
 			if r.ansi {
-				r.printf("%s\r\n", line.Highlighted)
-			} else {
-				r.printf("%s\r\n", line.Content)
+				content = line.Highlighted
 			}
+			// The content is already redacted by the secret scanner.
+			r.printf("%s\r\n", truncateLine(content, r.matchWidth))
 		}
 		r.printSingleDivider()
 	}
 }
+
+// truncateLine truncates the line to width runes with an ellipsis.
+// Escape sequences of highlighted lines are kept and not counted, and the attributes are reset before the ellipsis.
+func truncateLine(line string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(ansiEscape.ReplaceAllString(line, "")) <= width {
+		return line
+	}
+
+	var sb strings.Builder
+	var n int
+	for rest := line; len(rest) > 0; {
+		if loc := ansiEscape.FindStringIndex(rest); loc != nil && loc[0] == 0 {
+			sb.WriteString(rest[:loc[1]])
+			rest = rest[loc[1]:]
+			continue
+		}
+		if n == width {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(rest)
+		sb.WriteString(rest[:size])
+		n++
+		rest = rest[size:]
+	}
+	if ansiEscape.MatchString(line) {
+		sb.WriteString("\x1b[0m")
+	}
+	return sb.String() + "…"
+}
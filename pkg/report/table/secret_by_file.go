@@ -0,0 +1,44 @@
+package table
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// groupSecretsByFile merges the secret results of the same file, e.g. found in several layers,
+// and orders them by the number of secrets so that the files leaking the most secrets come first.
+// The secret results are placed where the first one was, and the other results keep their order.
+func groupSecretsByFile(results types.Results) types.Results {
+	var others, files types.Results
+	index := make(map[string]int)
+	first := -1
+	for _, result := range results {
+		if result.Class != types.ClassSecret {
+			others = append(others, result)
+			continue
+		}
+		if first < 0 {
+			first = len(others)
+		}
+		if i, ok := index[result.Target]; ok {
+			files[i].Secrets = append(files[i].Secrets, result.Secrets...)
+			continue
+		}
+		index[result.Target] = len(files)
+		result.Secrets = slices.Clone(result.Secrets) // Not to modify the report
+		files = append(files, result)
+	}
+	if first < 0 {
+		return results
+	}
+
+	slices.SortStableFunc(files, func(a, b types.Result) int {
+		return cmp.Or(
+			cmp.Compare(len(b.Secrets), len(a.Secrets)),
+			cmp.Compare(a.Target, b.Target),
+		)
+	})
+	return slices.Concat(others[:first], files, others[first:])
+}
@@ -15,9 +15,11 @@ import (
 func TestSecretRenderer(t *testing.T) {
 
 	tests := []struct {
-		name  string
-		input []types.DetectedSecret
-		want  string
+		name           string
+		input          []types.DetectedSecret
+		matchWidth     int
+		showConfidence bool
+		want           string
 	}{
 		{
 			name: "single line",
@@ -130,6 +132,177 @@ this is a title
 ────────────────────────────────────────
 
 
+`,
+		},
+		{
+			name: "truncated lines",
+			input: []types.DetectedSecret{
+				{
+					RuleID:    "rule-id",
+					Category:  ftypes.SecretRuleCategory("category"),
+					Title:     "this is a title",
+					Severity:  "HIGH",
+					StartLine: 2,
+					EndLine:   3,
+					Code: ftypes.Code{
+						Lines: []ftypes.Line{
+							{
+								Number:  1,
+								Content: "# credentials",
+							},
+							{
+								Number:     2,
+								Content:    "export AWS_SECRET_ACCESS_KEY=****************************************",
+								IsCause:    true,
+								FirstCause: true,
+							},
+							{
+								Number:    3,
+								Content:   "export GITHUB_PAT=********************",
+								IsCause:   true,
+								LastCause: true,
+							},
+						},
+					},
+					Match: "AWS_SECRET_ACCESS_KEY=****************************************",
+				},
+			},
+			matchWidth: 30,
+			want: `
+my-file (secrets)
+=================
+Total: 1 (MEDIUM: 0, HIGH: 1)
+
+HIGH: category (rule-id)
+════════════════════════════════════════
+this is a title
+────────────────────────────────────────
+ my-file:2-3
+────────────────────────────────────────
+   1   # credentials
+   2 ┌ export AWS_SECRET_ACCESS_KEY=*…
+   3 └ export GITHUB_PAT=************…
+────────────────────────────────────────
+
+
+`,
+		},
+		{
+			name: "git history",
+			input: []types.DetectedSecret{
+				{
+					RuleID:    "github-pat",
+					Category:  ftypes.SecretRuleCategory("GitHub"),
+					Title:     "GitHub Personal Access Token",
+					Severity:  "HIGH",
+					StartLine: 1,
+					EndLine:   1,
+					Commit: &ftypes.SecretCommit{
+						Hash:   "3c4a8f2e1b9d7c6a5e4f3b2a1c0d9e8f7a6b5c4d",
+						Author: "Alice <alice@example.com>",
+					},
+					Code: ftypes.Code{
+						Lines: []ftypes.Line{
+							{
+								Number:     1,
+								Content:    "GITHUB_PAT=****************************************",
+								IsCause:    true,
+								FirstCause: true,
+								LastCause:  true,
+							},
+						},
+					},
+				},
+			},
+			want: `
+my-file (secrets)
+=================
+Total: 1 (MEDIUM: 0, HIGH: 1)
+
+HIGH: GitHub (github-pat)
+════════════════════════════════════════
+GitHub Personal Access Token
+────────────────────────────────────────
+ my-file:1 (added in commit '3c4a8f2e1b9d' by Alice <alice@example.com>)
+────────────────────────────────────────
+   1 [ GITHUB_PAT=****************************************
+────────────────────────────────────────
+
+
+`,
+		},
+		{
+			name: "confidence",
+			input: []types.DetectedSecret{
+				{
+					RuleID:     "generic-password",
+					Category:   ftypes.SecretRuleCategory("general"),
+					Title:      "Password",
+					Severity:   "HIGH",
+					StartLine:  1,
+					EndLine:    1,
+					Entropy:    2.25,
+					Confidence: ftypes.SecretConfidenceLow,
+					Code: ftypes.Code{
+						Lines: []ftypes.Line{
+							{
+								Number:     1,
+								Content:    "password=******",
+								IsCause:    true,
+								FirstCause: true,
+								LastCause:  true,
+							},
+						},
+					},
+				},
+				{
+					RuleID:     "aws-access-key-id",
+					Category:   ftypes.SecretRuleCategory("AWS"),
+					Title:      "AWS Access Key ID",
+					Severity:   "HIGH",
+					StartLine:  2,
+					EndLine:    2,
+					Entropy:    3.98,
+					Confidence: ftypes.SecretConfidenceHigh,
+					Code: ftypes.Code{
+						Lines: []ftypes.Line{
+							{
+								Number:     2,
+								Content:    "AWS_ACCESS_KEY_ID=********************",
+								IsCause:    true,
+								FirstCause: true,
+								LastCause:  true,
+							},
+						},
+					},
+				},
+			},
+			showConfidence: true,
+			want: `
+my-file (secrets)
+=================
+Total: 2 (MEDIUM: 0, HIGH: 2)
+
+HIGH: AWS (aws-access-key-id) [confidence: high, entropy: 3.98]
+════════════════════════════════════════
+AWS Access Key ID
+────────────────────────────────────────
+ my-file:2
+────────────────────────────────────────
+   2 [ AWS_ACCESS_KEY_ID=********************
+────────────────────────────────────────
+
+
+HIGH: general (generic-password) [confidence: low, entropy: 2.25]
+════════════════════════════════════════
+Password
+────────────────────────────────────────
+ my-file:1
+────────────────────────────────────────
+   1 [ password=******
+────────────────────────────────────────
+
+
 `,
 		},
 	}
@@ -139,8 +312,36 @@ this is a title
 			renderer := table.NewSecretRenderer("my-file", test.input, false, []dbTypes.Severity{
 				dbTypes.SeverityHigh,
 				dbTypes.SeverityMedium,
-			})
+			}, 0, test.matchWidth, test.showConfidence, nil, nil)
 			assert.Equal(t, test.want, strings.ReplaceAll(renderer.Render(), "\r\n", "\n"))
 		})
 	}
 }
+
+func TestSecretRenderer_highlighted(t *testing.T) {
+	secrets := []types.DetectedSecret{
+		{
+			RuleID:    "rule-id",
+			Category:  ftypes.SecretRuleCategory("category"),
+			Title:     "this is a title",
+			Severity:  "HIGH",
+			StartLine: 1,
+			EndLine:   1,
+			Code: ftypes.Code{
+				Lines: []ftypes.Line{
+					{
+						Number:      1,
+						Content:     "export AWS_SECRET_ACCESS_KEY=****************************************",
+						Highlighted: "\x1b[38;5;33mexport\x1b[0m AWS_SECRET_ACCESS_KEY=\x1b[38;5;64m****************************************\x1b[0m",
+						IsCause:     true,
+						FirstCause:  true,
+						LastCause:   true,
+					},
+				},
+			},
+		},
+	}
+
+	renderer := table.NewSecretRenderer("my-file", secrets, true, []dbTypes.Severity{dbTypes.SeverityHigh}, 0, 30, false, nil, nil)
+	assert.Contains(t, renderer.Render(), "\x1b[38;5;33mexport\x1b[0m AWS_SECRET_ACCESS_KEY=\x1b[38;5;64m*\x1b[0m…\r\n")
+}
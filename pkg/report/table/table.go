@@ -1,15 +1,19 @@
 package table
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"io"
 	"os"
 	"runtime"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/fatih/color"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/xerrors"
 
 	"github.com/aquasecurity/table"
 	"github.com/aquasecurity/tml"
@@ -32,19 +36,155 @@ type Writer struct {
 	Severities []dbTypes.Severity
 	Output     io.Writer
 
+	// Severities overriding Severities for each class of findings
+	VulnSeverities    []dbTypes.Severity
+	MisconfSeverities []dbTypes.Severity
+	SecretSeverities  []dbTypes.Severity
+
+	// Warning shown at the top when the vulnerability database is stale
+	StaleDBWarning string
+
 	// Show dependency origin tree
 	Tree bool
 
+	// Direction of the dependency tree ("up" or "down")
+	TreeDirection string
+
+	// Show only the shortest path to each vulnerable package in the dependency tree
+	TreeShortestPath bool
+
+	// Minimum number of vulnerable packages to render the dependency tree (0 means always)
+	TreeMinPackages int
+
 	// Show suppressed findings
 	ShowSuppressed bool
 
+	// Show vulnerabilities suppressed by VEX in the vulnerability table with their VEX status
+	ShowVEXSuppressed bool
+
+	// Maximum number of findings rendered per result (0 means unlimited)
+	MaxRows int
+
+	// Maximum number of runes rendered per code line of secrets (0 means unlimited)
+	SecretMatchWidth int
+
+	// Show the confidence and the entropy of secrets
+	ShowSecretConfidence bool
+
+	// Group secrets by file, ordering the files by the number of secrets and the secrets by line number
+	SecretsByFile bool
+
+	// Show whether the vulnerable code is reachable
+	ShowReachability bool
+
+	// Group vulnerabilities by severity
+	GroupBySeverity bool
+
+	// Group vulnerabilities by the Dockerfile instruction that introduced the vulnerable package
+	GroupByInstruction bool
+
+	// Show the layer that introduced the vulnerable package
+	ShowLayer bool
+
+	// Show the package URL of the vulnerable package
+	ShowPURL bool
+
+	// Show the EPSS score and percentile of each vulnerability
+	ShowEPSS bool
+
+	// Show whether each vulnerability is in the CISA Known Exploited Vulnerabilities catalog
+	ShowKEV bool
+
+	// Show the remediation due date of each vulnerability in the CISA Known Exploited Vulnerabilities catalog
+	ShowKEVDue bool
+
+	// Show whether the vendor has released a patch for each vulnerability, e.g. "patch available" or "won't fix"
+	ShowVendorStatus bool
+
+	// Show the vulnerable versions in the advisory of each vulnerability, e.g. ">=1.0.0, <1.4.2"
+	ShowAffectedRange bool
+
+	// Show the labels attached to each vulnerability by "--labels-file"
+	ShowLabels bool
+
+	// Show whether each vulnerability is fixed within the SLA given by "--sla"
+	ShowSLA bool
+
+	// Show the number of packages depending on each vulnerable package directly or transitively
+	ShowBlastRadius bool
+
+	// Show the command upgrading each vulnerable package to the fixed version
+	ShowFixCommand bool
+
+	// Sort vulnerabilities with fixed versions first, and then by severity
+	FixableFirst bool
+
+	// Annotate fixed versions that are pre-releases, e.g. "2.0.0-rc1 (pre-release)"
+	FlagPrereleaseFixes bool
+
+	// Mark severities differing from the rating of another source by two or more levels, e.g. "MEDIUM ⚠"
+	SeverityConflicts bool
+
+	// Render the number of vulnerabilities per severity as a colored bar under the summary when writing to a terminal
+	SummaryBar bool
+
+	// Show only vulnerabilities in direct dependencies, counting the others separately
+	DirectOnly bool
+
+	// Show only results of the given classes (all classes by default)
+	ShowClasses []types.ResultClass
+
+	// Render what was scanned, e.g. the number of packages, when no findings are found
+	ShowCleanSummary bool
+
+	// Scanners run in the scan, rendered with "ShowCleanSummary"
+	Scanners types.Scanners
+
+	// List the targets and packages affected by the vulnerability IDs, e.g. CVE-2024-0001, instead of the table per result
+	FocusCVEs []string
+
+	// List the package upgrades and the vulnerabilities fixed by each of them instead of the table per result
+	RemediationPlan bool
+
+	// Print a QR code linking to the advisory of the most critical finding when writing to a terminal
+	QRCode bool
+
+	// Disable merging identical adjacent cells so that every row is fully populated
+	NoCellMerge bool
+
+	// Labels rendered instead of the severity names in the severity column and the summary, e.g. "C" for "CRITICAL"
+	SeverityLabels map[string]string
+
+	// Browse the results collapsed to their summaries in the terminal, expanding them with keys
+	Interactive bool
+
+	// Number of results rendered side by side when writing to a terminal wide enough for them (1 or less disables it)
+	ColumnsLayout int
+
+	// Order of severities from the lowest to the highest (dbTypes.SeverityNames by default)
+	SeverityOrder []string
+
 	// For misconfigurations
 	IncludeNonFailures bool
 	Trace              bool
+	ShowPolicySource   bool
+
+	// Show the lines of the source file around each misconfiguration
+	ShowCode bool
+
+	// SourceDir is the local directory of the scanned files, used to read the source files with "ShowCode".
+	// Code frames are not rendered if it is empty, e.g. for container images.
+	SourceDir string
 
 	// For licenses
 	LicenseRiskThreshold int
 	IgnoredLicenses      []string
+
+	// Render a table with the verdict of each license given by "--license-allow" and "--license-deny"
+	LicenseCompliance bool
+
+	// Number of results rendered concurrently (the number of CPUs by default)
+	Parallel int
 }
 
 type Renderer interface {
@@ -53,64 +193,310 @@ type Renderer interface {
 
 // Write writes the result on standard output
 func (tw Writer) Write(_ context.Context, report types.Report) error {
+	isTerminal := tw.isOutputToTerminal()
+	if report.Banner != "" {
+		// The banner encloses the whole output once, whichever view is rendered
+		renderBanner(tw.Output, report.Banner, isTerminal)
+		defer renderBanner(tw.Output, report.Banner, isTerminal)
+	}
+	if tw.StaleDBWarning != "" {
+		RenderTarget(tw.Output, "WARNING: Stale vulnerability database", isTerminal)
+		_, _ = fmt.Fprintln(tw.Output, tw.StaleDBWarning)
+	}
+
+	if len(tw.FocusCVEs) > 0 {
+		renderFocusedVulnerabilities(tw.Output, report.Results, tw.FocusCVEs, isTerminal)
+		return nil
+	} else if tw.RemediationPlan {
+		renderRemediationPlan(tw.Output, report.Results, isTerminal)
+		return nil
+	}
+
+	// Dependency graphs are shared by results with the same packages
+	var graphs *dependencyGraphCache
+	if tw.Tree || tw.ShowBlastRadius {
+		graphs = newDependencyGraphCache()
+	}
+
+	results := report.Results
+	if tw.SecretsByFile {
+		results = groupSecretsByFile(results)
+	}
 
-	for _, result := range report.Results {
+	// Renderers are created sequentially since they may update the global formatting state.
+	var renderers []Renderer
+	for _, result := range results {
 		// Not display a table of custom resources
 		if result.Class == types.ClassCustom {
 			continue
 		}
-		tw.write(result)
+		if len(tw.ShowClasses) > 0 && !slices.Contains(tw.ShowClasses, result.Class) {
+			continue
+		}
+		if r := tw.renderer(result, isTerminal, graphs); r != nil {
+			renderers = append(renderers, r)
+		}
+	}
+
+	// Each result is rendered to its own buffer concurrently as rendering large tables and trees is CPU-bound,
+	// and then written in the original order.
+	outputs := make([]string, len(renderers))
+	var g errgroup.Group
+	g.SetLimit(cmp.Or(tw.Parallel, runtime.NumCPU()))
+	for i, r := range renderers {
+		g.Go(func() error {
+			outputs[i] = r.Render()
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	switch {
+	case tw.Interactive && isTerminal && canRunInteractive():
+		// Keys are read from stdin, so it falls back to the normal rendering if stdin is not a terminal
+		if err := runInteractive(tw.Output, outputs); err != nil {
+			return xerrors.Errorf("interactive view error: %w", err)
+		}
+	case tw.ColumnsLayout > 1 && isTerminal:
+		// Side-by-side layouts are only for interactive use
+		outputs = layoutColumns(outputs, tw.ColumnsLayout, terminalWidth())
+		fallthrough
+	default:
+		for _, output := range outputs {
+			_, _ = fmt.Fprint(tw.Output, output)
+		}
+	}
+
+	if report.AgeHistogram != nil {
+		renderAgeHistogram(tw.Output, report.AgeHistogram, isTerminal)
+	}
+
+	if report.Scorecard != nil {
+		renderScorecard(tw.Output, report.Scorecard, isTerminal)
+	}
+
+	if tw.LicenseCompliance {
+		renderLicenseCompliance(tw.Output, report.Results, isTerminal, tw.NoCellMerge)
+	}
+
+	if tw.ShowCleanSummary && isClean(report.Results) {
+		renderCleanSummary(tw.Output, report, tw.Scanners, isTerminal)
+	}
+
+	// The QR code is useless in files or pipes
+	if tw.QRCode && isTerminal {
+		renderQRCode(tw.Output, report.Results, tw.SeverityOrder)
 	}
 	return nil
 }
 
-func (tw Writer) write(result types.Result) {
+// renderer returns the renderer for the result, or nil if the result is not displayed.
+func (tw Writer) renderer(result types.Result, isTerminal bool, graphs *dependencyGraphCache) Renderer {
 	if result.IsEmpty() && result.Class != types.ClassOSPkg {
-		return
+		return nil
 	}
 
-	var renderer Renderer
 	switch {
 	// vulnerability
 	case result.Class == types.ClassOSPkg || result.Class == types.ClassLangPkg:
-		renderer = NewVulnerabilityRenderer(result, tw.isOutputToTerminal(), tw.Tree, tw.ShowSuppressed, tw.Severities)
+		severities := overrideSeverities(tw.VulnSeverities, tw.Severities)
+		r := NewVulnerabilityRenderer(result, isTerminal, VulnerabilityOptions{
+			Severities:          severities,
+			Tree:                tw.Tree,
+			TreeDirection:       tw.TreeDirection,
+			TreeShortestPath:    tw.TreeShortestPath,
+			TreeMinPackages:     tw.TreeMinPackages,
+			ShowSuppressed:      tw.ShowSuppressed,
+			ShowVEXSuppressed:   tw.ShowVEXSuppressed,
+			MaxRows:             tw.MaxRows,
+			DirectOnly:          tw.DirectOnly,
+			FixableFirst:        tw.FixableFirst,
+			GroupBySeverity:     tw.GroupBySeverity,
+			GroupByInstruction:  tw.GroupByInstruction,
+			ShowReachability:    tw.ShowReachability,
+			ShowLayer:           tw.ShowLayer,
+			ShowPURL:            tw.ShowPURL,
+			ShowEPSS:            tw.ShowEPSS,
+			ShowKEV:             tw.ShowKEV,
+			ShowKEVDue:          tw.ShowKEVDue,
+			ShowVendorStatus:    tw.ShowVendorStatus,
+			ShowAffectedRange:   tw.ShowAffectedRange,
+			ShowLabels:          tw.ShowLabels,
+			ShowSLA:             tw.ShowSLA,
+			ShowBlastRadius:     tw.ShowBlastRadius,
+			ShowFixCommand:      tw.ShowFixCommand,
+			FlagPrereleaseFixes: tw.FlagPrereleaseFixes,
+			SeverityConflicts:   tw.SeverityConflicts,
+			SummaryBar:          tw.SummaryBar,
+			NoCellMerge:         tw.NoCellMerge,
+			SeverityOrder:       tw.SeverityOrder,
+			SeverityLabels:      tw.SeverityLabels,
+		})
+		r.graphs = graphs
+		return r
 	// misconfiguration
 	case result.Class == types.ClassConfig:
-		renderer = NewMisconfigRenderer(result, tw.Severities, tw.Trace, tw.IncludeNonFailures, tw.isOutputToTerminal())
+		severities := overrideSeverities(tw.MisconfSeverities, tw.Severities)
+		var codeDir string
+		if tw.ShowCode {
+			codeDir = tw.SourceDir
+		}
+		return NewMisconfigRenderer(result, severities, tw.Trace, tw.ShowPolicySource, tw.IncludeNonFailures,
+			isTerminal, tw.SeverityOrder, tw.SeverityLabels, codeDir)
 	// secret
 	case result.Class == types.ClassSecret:
-		renderer = NewSecretRenderer(result.Target, result.Secrets, tw.isOutputToTerminal(), tw.Severities)
+		severities := overrideSeverities(tw.SecretSeverities, tw.Severities)
+		r := NewSecretRenderer(result.Target, result.Secrets, isTerminal, severities, tw.MaxRows,
+			tw.SecretMatchWidth, tw.ShowSecretConfidence, tw.SeverityOrder, tw.SeverityLabels)
+		r.previousCount = result.PreviousCounts
+		r.filteredCounts = result.FilteredCounts
+		r.byLine = tw.SecretsByFile
+		return r
 	// package license
 	case result.Class == types.ClassLicense:
-		renderer = NewPkgLicenseRenderer(result, tw.isOutputToTerminal(), tw.Severities)
+		return NewPkgLicenseRenderer(result, isTerminal, tw.Severities, tw.NoCellMerge, tw.SeverityOrder, tw.SeverityLabels)
 	// file license
 	case result.Class == types.ClassLicenseFile:
-		renderer = NewFileLicenseRenderer(result, tw.isOutputToTerminal(), tw.Severities)
+		return NewFileLicenseRenderer(result, isTerminal, tw.Severities, tw.NoCellMerge, tw.SeverityOrder, tw.SeverityLabels)
 	default:
-		return
+		return nil
 	}
+}
 
-	_, _ = fmt.Fprint(tw.Output, renderer.Render())
+// overrideSeverities returns the severities overridden for a class of findings, or the global severities if not overridden.
+func overrideSeverities(override, severities []dbTypes.Severity) []dbTypes.Severity {
+	if len(override) > 0 {
+		return override
+	}
+	return severities
 }
 
 func (tw Writer) isOutputToTerminal() bool {
 	return IsOutputToTerminal(tw.Output)
 }
 
-func newTableWriter(output io.Writer, isTerminal bool) *table.Table {
+// maxAgeBarWidth is the width of the longest bar in the age histogram
+const maxAgeBarWidth = 40
+
+// renderAgeHistogram renders the number of vulnerabilities per age bucket as a bar chart.
+func renderAgeHistogram(w io.Writer, histogram *types.AgeHistogram, isTerminal bool) {
+	buckets := histogram.Buckets()
+	var maxCount, labelWidth int
+	for _, b := range buckets {
+		maxCount = max(maxCount, b.Count)
+		labelWidth = max(labelWidth, len(b.Label))
+	}
+	countWidth := len(strconv.Itoa(maxCount))
+
+	RenderTarget(w, "Vulnerability Age", isTerminal)
+	for _, b := range buckets {
+		var bar int
+		if b.Count > 0 {
+			// Show at least one mark for non-empty buckets
+			bar = max(b.Count*maxAgeBarWidth/maxCount, 1)
+		}
+		line := fmt.Sprintf("%-*s | %*d %s", labelWidth, b.Label, countWidth, b.Count, strings.Repeat("#", bar))
+		_, _ = fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+}
+
+// severityBarMark is the character filling the segments of the severity bar
+const severityBarMark = "█"
+
+// renderSeverityBar renders the number of findings per severity as a horizontal bar of the given width,
+// each severity colored with SeverityColor and sized in proportion to its count.
+func renderSeverityBar(w io.Writer, specifiedSeverities []dbTypes.Severity, severityOrder []string, severityCount map[string]int, width int) {
+	var severities []string
+	for _, severity := range orderOrDefault(severityOrder) {
+		if slices.ContainsFunc(specifiedSeverities, func(s dbTypes.Severity) bool { return s.String() == severity }) {
+			severities = append(severities, severity)
+		}
+	}
+	counts := make([]int, len(severities))
+	for i, severity := range severities {
+		counts[i] = severityCount[severity]
+	}
+
+	var bar strings.Builder
+	for i, n := range severityBarSegments(counts, width) {
+		if n > 0 {
+			bar.WriteString(ColorizeSeverity(strings.Repeat(severityBarMark, n), severities[i]))
+		}
+	}
+	if bar.Len() > 0 {
+		_, _ = fmt.Fprintln(w, bar.String())
+	}
+}
+
+// severityBarSegments splits the width into segments proportional to the counts.
+// A non-zero count always gets at least one mark, taking marks from the widest segments if needed,
+// so the bar is wider than the given width only if the width is less than the number of non-zero counts.
+func severityBarSegments(counts []int, width int) []int {
+	segments := make([]int, len(counts))
+	var total int
+	for _, c := range counts {
+		total += c
+	}
+	if total == 0 || width <= 0 {
+		return segments
+	}
+
+	var used int
+	remainders := make([]int, len(counts))
+	for i, c := range counts {
+		segments[i] = c * width / total
+		remainders[i] = c * width % total
+		if c > 0 && segments[i] == 0 {
+			segments[i] = 1
+			remainders[i] = 0
+		}
+		used += segments[i]
+	}
+
+	// Distribute the rest of the width by the largest remainder
+	for ; used < width; used++ {
+		i := maxIndex(remainders)
+		segments[i]++
+		remainders[i] = -1
+	}
+	for ; used > width; used-- {
+		i := maxIndex(segments)
+		if segments[i] <= 1 {
+			break
+		}
+		segments[i]--
+	}
+	return segments
+}
+
+// maxIndex returns the index of the first maximum value
+func maxIndex(values []int) int {
+	var index int
+	for i, v := range values {
+		if v > values[index] {
+			index = i
+		}
+	}
+	return index
+}
+
+func newTableWriter(output io.Writer, isTerminal, autoMerge bool) *table.Table {
 	tableWriter := table.New(output)
 	if isTerminal { // use ansi output if we're not piping elsewhere
 		tableWriter.SetHeaderStyle(table.StyleBold)
 		tableWriter.SetLineStyle(table.StyleDim)
 	}
 	tableWriter.SetBorders(true)
-	tableWriter.SetAutoMerge(true)
+	tableWriter.SetAutoMerge(autoMerge)
 	tableWriter.SetRowLines(true)
 
 	return tableWriter
 }
 
-func summarize(specifiedSeverities []dbTypes.Severity, severityCount map[string]int) (int, []string) {
+// summarize returns the total count and the count of each severity, e.g. "CRITICAL: 3".
+// With the counts of the previous run, the change is appended to each severity, e.g. "CRITICAL: 3 (▲1)".
+func summarize(specifiedSeverities []dbTypes.Severity, severityOrder []string, severityLabels map[string]string,
+	severityCount, previousCount map[string]int) (int, []string) {
 	var total int
 	var severities []string
 	for _, sev := range specifiedSeverities {
@@ -118,12 +504,15 @@ func summarize(specifiedSeverities []dbTypes.Severity, severityCount map[string]
 	}
 
 	var summaries []string
-	for _, severity := range dbTypes.SeverityNames {
+	for _, severity := range orderOrDefault(severityOrder) {
 		if !slices.Contains(severities, severity) {
 			continue
 		}
 		count := severityCount[severity]
-		r := fmt.Sprintf("%s: %d", severity, count)
+		r := fmt.Sprintf("%s: %d", severityLabel(severity, severityLabels), count)
+		if previousCount != nil {
+			r += trend(count - previousCount[severity])
+		}
 		summaries = append(summaries, r)
 		total += count
 	}
@@ -131,6 +520,50 @@ func summarize(specifiedSeverities []dbTypes.Severity, severityCount map[string]
 	return total, summaries
 }
 
+// trend returns the change from the previous run with an arrow, red for increases and green for decreases.
+// Unchanged counts have nothing appended.
+func trend(delta int) string {
+	switch {
+	case delta > 0:
+		return tml.Sprintf(" (<red>▲%d</red>)", delta)
+	case delta < 0:
+		return tml.Sprintf(" (<green>▼%d</green>)", -delta)
+	}
+	return ""
+}
+
+// severityLabel returns the label of the severity given by "--severity-labels", or the severity name if not given.
+func severityLabel(severity string, severityLabels map[string]string) string {
+	if label, ok := severityLabels[severity]; ok {
+		return label
+	}
+	return severity
+}
+
+// orderOrDefault returns the order of severities from the lowest to the highest.
+// The default order is used if no order is specified.
+func orderOrDefault(severityOrder []string) []string {
+	if len(severityOrder) == 0 {
+		return dbTypes.SeverityNames
+	}
+	return severityOrder
+}
+
+// limitRows returns the first maxRows findings in the rendered order and the number of omitted findings.
+func limitRows[T any](findings []T, maxRows int) ([]T, int) {
+	if maxRows <= 0 || len(findings) <= maxRows {
+		return findings, 0
+	}
+	return findings[:maxRows], len(findings) - maxRows
+}
+
+func renderOmitted(w io.Writer, omitted int) {
+	if omitted == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "... %d more rows omitted (use --format json for all)\n", omitted)
+}
+
 func IsOutputToTerminal(output io.Writer) bool {
 	if runtime.GOOS == "windows" {
 		// if its windows, we don't support formatting
@@ -157,6 +590,35 @@ func RenderTarget(w io.Writer, target string, isTerminal bool) {
 	}
 }
 
+// filteredSummary returns the summary of the findings filtered out, populated with "--show-filtered-count",
+// e.g. "Filtered: 6 (by severity: 3, by ignore: 1, unfixed: 2)".
+// Only the filters filtering out some findings are listed.
+func filteredSummary(c types.FilteredCounts) string {
+	var counts []string
+	for _, f := range []struct {
+		label string
+		count int
+	}{
+		{"by severity", c.Severity},
+		{"by ignore", c.IgnoreFile},
+		{"unfixed", c.Status},
+		{"by package", c.Package},
+		{"by confidence", c.Confidence},
+		{"by policy", c.Policy},
+		{"by VEX", c.VEX},
+		{"not in KEV", c.KEV},
+		{"in baseline", c.Baseline},
+	} {
+		if f.count > 0 {
+			counts = append(counts, fmt.Sprintf("%s: %d", f.label, f.count))
+		}
+	}
+	if len(counts) == 0 {
+		return "Filtered: 0"
+	}
+	return fmt.Sprintf("Filtered: %d (%s)", c.Total(), strings.Join(counts, ", "))
+}
+
 func ColorizeSeverity(value, severity string) string {
 	for i, name := range dbTypes.SeverityNames {
 		if severity == name {
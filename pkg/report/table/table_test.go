@@ -1,9 +1,15 @@
 package table_test
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -17,8 +23,19 @@ func TestWriter_Write(t *testing.T) {
 	testCases := []struct {
 		name               string
 		results            types.Results
+		ageHistogram       *types.AgeHistogram
+		banner             string
 		expectedOutput     string
 		includeNonFailures bool
+		noCellMerge        bool
+		showClasses        []types.ResultClass
+		vulnSeverities     []dbTypes.Severity
+		secretSeverities   []dbTypes.Severity
+		focusCVEs          []string
+		remediationPlan    bool
+		showCleanSummary   bool
+		scanners           types.Scanners
+		os                 *ftypes.OS
 	}{
 		{
 			name: "vulnerability and custom resource",
@@ -61,6 +78,246 @@ Total: 1 (MEDIUM: 0, HIGH: 1)
 └─────────┴───────────────┴──────────┴──────────────┴───────────────────┴───────────────┴───────────────────────────────────────────┘
 `,
 		},
+		{
+			name: "no cell merge",
+			results: types.Results{
+				{
+					Target: "test",
+					Class:  types.ClassLangPkg,
+					Vulnerabilities: []types.DetectedVulnerability{
+						{
+							VulnerabilityID:  "CVE-2020-0001",
+							PkgName:          "foo",
+							InstalledVersion: "1.2.3",
+							FixedVersion:     "1.2.4",
+							Status:           dbTypes.StatusAffected,
+							Vulnerability: dbTypes.Vulnerability{
+								Title:    "foobar",
+								Severity: "HIGH",
+							},
+						},
+						{
+							VulnerabilityID:  "CVE-2020-0002",
+							PkgName:          "foo",
+							InstalledVersion: "1.2.3",
+							FixedVersion:     "1.2.4",
+							Status:           dbTypes.StatusAffected,
+							Vulnerability: dbTypes.Vulnerability{
+								Title:    "foobar",
+								Severity: "HIGH",
+							},
+						},
+					},
+				},
+			},
+			noCellMerge: true,
+			expectedOutput: `
+test ()
+=======
+Total: 2 (MEDIUM: 0, HIGH: 2)
+
+┌─────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │ Title  │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼────────┤
+│ foo     │ CVE-2020-0001 │ HIGH     │ affected │ 1.2.3             │ 1.2.4         │ foobar │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼────────┤
+│ foo     │ CVE-2020-0002 │ HIGH     │ affected │ 1.2.3             │ 1.2.4         │ foobar │
+└─────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴────────┘
+`,
+		},
+		{
+			name: "show secrets only",
+			results: types.Results{
+				{
+					Target: "test",
+					Class:  types.ClassLangPkg,
+					Vulnerabilities: []types.DetectedVulnerability{
+						{
+							VulnerabilityID:  "CVE-2020-0001",
+							PkgName:          "foo",
+							InstalledVersion: "1.2.3",
+							Vulnerability: dbTypes.Vulnerability{
+								Title:    "foobar",
+								Severity: "HIGH",
+							},
+						},
+					},
+				},
+				{
+					Target: "my-file",
+					Class:  types.ClassSecret,
+					Secrets: []types.DetectedSecret{
+						{
+							RuleID:    "rule-id",
+							Category:  ftypes.SecretRuleCategory("category"),
+							Title:     "this is a title",
+							Severity:  "HIGH",
+							StartLine: 1,
+							EndLine:   1,
+							Code: ftypes.Code{
+								Lines: []ftypes.Line{
+									{
+										Number:     1,
+										Content:    "password=secret",
+										IsCause:    true,
+										FirstCause: true,
+										LastCause:  true,
+									},
+								},
+							},
+							Match: "secret",
+						},
+					},
+				},
+			},
+			showClasses: []types.ResultClass{types.ClassSecret},
+			// Secrets are rendered with CRLF line endings, which raw string literals cannot hold
+			expectedOutput: "\n" +
+				"my-file (secrets)\n" +
+				"=================\n" +
+				"Total: 1 (MEDIUM: 0, HIGH: 1)\n" +
+				"\n" +
+				"HIGH: category (rule-id)\r\n" +
+				"════════════════════════════════════════\r\n" +
+				"this is a title\r\n" +
+				"────────────────────────────────────────\r\n" +
+				" my-file:1\r\n" +
+				"────────────────────────────────────────\r\n" +
+				"   1 [ password=secret\r\n" +
+				"────────────────────────────────────────\r\n" +
+				"\r\n" +
+				"\r\n",
+		},
+		{
+			name: "filtered secrets",
+			results: types.Results{
+				{
+					Target: "my-file",
+					Class:  types.ClassSecret,
+					Secrets: []types.DetectedSecret{
+						{
+							RuleID:    "rule-id",
+							Category:  ftypes.SecretRuleCategory("category"),
+							Title:     "this is a title",
+							Severity:  "HIGH",
+							StartLine: 1,
+							EndLine:   1,
+							Code: ftypes.Code{
+								Lines: []ftypes.Line{
+									{
+										Number:     1,
+										Content:    "password=secret",
+										IsCause:    true,
+										FirstCause: true,
+										LastCause:  true,
+									},
+								},
+							},
+							Match: "secret",
+						},
+					},
+					FilteredCounts: &types.FilteredCounts{
+						Confidence: 2,
+						IgnoreFile: 1,
+					},
+				},
+			},
+			expectedOutput: "\n" +
+				"my-file (secrets)\n" +
+				"=================\n" +
+				"Total: 1 (MEDIUM: 0, HIGH: 1)\n" +
+				"Filtered: 3 (by ignore: 1, by confidence: 2)\n" +
+				"\n" +
+				"HIGH: category (rule-id)\r\n" +
+				"════════════════════════════════════════\r\n" +
+				"this is a title\r\n" +
+				"────────────────────────────────────────\r\n" +
+				" my-file:1\r\n" +
+				"────────────────────────────────────────\r\n" +
+				"   1 [ password=secret\r\n" +
+				"────────────────────────────────────────\r\n" +
+				"\r\n" +
+				"\r\n",
+		},
+		{
+			name: "class-specific severities",
+			results: types.Results{
+				{
+					Target: "test",
+					Class:  types.ClassLangPkg,
+					Vulnerabilities: []types.DetectedVulnerability{
+						{
+							VulnerabilityID:  "CVE-2020-0001",
+							PkgName:          "foo",
+							InstalledVersion: "1.2.3",
+							FixedVersion:     "1.2.4",
+							Status:           dbTypes.StatusAffected,
+							Vulnerability: dbTypes.Vulnerability{
+								Title:    "foobar",
+								Severity: "CRITICAL",
+							},
+						},
+					},
+				},
+				{
+					Target: "my-file",
+					Class:  types.ClassSecret,
+					Secrets: []types.DetectedSecret{
+						{
+							RuleID:    "rule-id",
+							Category:  ftypes.SecretRuleCategory("category"),
+							Title:     "this is a title",
+							Severity:  "LOW",
+							StartLine: 1,
+							EndLine:   1,
+							Code: ftypes.Code{
+								Lines: []ftypes.Line{
+									{
+										Number:     1,
+										Content:    "password=secret",
+										IsCause:    true,
+										FirstCause: true,
+										LastCause:  true,
+									},
+								},
+							},
+							Match: "secret",
+						},
+					},
+				},
+			},
+			vulnSeverities: []dbTypes.Severity{
+				dbTypes.SeverityCritical,
+			},
+			secretSeverities: []dbTypes.Severity{
+				dbTypes.SeverityLow,
+			},
+			expectedOutput: "\n" +
+				"test ()\n" +
+				"=======\n" +
+				"Total: 1 (CRITICAL: 1)\n" +
+				"\n" +
+				"┌─────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬────────┐\n" +
+				"│ Library │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │ Title  │\n" +
+				"├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼────────┤\n" +
+				"│ foo     │ CVE-2020-0001 │ CRITICAL │ affected │ 1.2.3             │ 1.2.4         │ foobar │\n" +
+				"└─────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴────────┘\n" +
+				"\n" +
+				"my-file (secrets)\n" +
+				"=================\n" +
+				"Total: 1 (LOW: 1)\n" +
+				"\n" +
+				"LOW: category (rule-id)\r\n" +
+				"════════════════════════════════════════\r\n" +
+				"this is a title\r\n" +
+				"────────────────────────────────────────\r\n" +
+				" my-file:1\r\n" +
+				"────────────────────────────────────────\r\n" +
+				"   1 [ password=secret\r\n" +
+				"────────────────────────────────────────\r\n" +
+				"\r\n" +
+				"\r\n",
+		},
 		{
 			name: "no vulns",
 			results: types.Results{
@@ -71,6 +328,271 @@ Total: 1 (MEDIUM: 0, HIGH: 1)
 			},
 			expectedOutput: ``,
 		},
+		{
+			name: "age histogram",
+			results: types.Results{
+				{
+					Target: "test",
+					Class:  types.ClassLangPkg,
+				},
+			},
+			ageHistogram: &types.AgeHistogram{
+				LessThan30Days:  2,
+				From30To90Days:  0,
+				From90To365Days: 10,
+				MoreThan1Year:   4,
+				Unknown:         1,
+			},
+			expectedOutput: `
+Vulnerability Age
+=================
+<30d        |  2 ########
+30-90d      |  0
+90-365d     | 10 ########################################
+>1y         |  4 ################
+unknown age |  1 ####
+`,
+		},
+		{
+			name: "banner",
+			results: types.Results{
+				{
+					Target: "test1",
+					Class:  types.ClassOSPkg,
+				},
+				{
+					Target: "test2",
+					Class:  types.ClassOSPkg,
+				},
+			},
+			banner: "CONFIDENTIAL",
+			expectedOutput: `
+##################
+#  CONFIDENTIAL  #
+##################
+
+test1
+=====
+Total: 0 (MEDIUM: 0, HIGH: 0)
+
+
+test2
+=====
+Total: 0 (MEDIUM: 0, HIGH: 0)
+
+
+##################
+#  CONFIDENTIAL  #
+##################
+`,
+		},
+		{
+			name: "focus vulnerabilities",
+			results: types.Results{
+				{
+					Target: "app/package-lock.json",
+					Class:  types.ClassLangPkg,
+					Vulnerabilities: []types.DetectedVulnerability{
+						{
+							VulnerabilityID:  "CVE-2022-24999",
+							PkgName:          "qs",
+							InstalledVersion: "6.5.2",
+							FixedVersion:     "6.5.3",
+							Status:           dbTypes.StatusFixed,
+							Vulnerability: dbTypes.Vulnerability{
+								Title:    "express: \"qs\" prototype poisoning causes the hang of the node process",
+								Severity: "HIGH",
+							},
+						},
+					},
+				},
+				{
+					Target: "web/package-lock.json",
+					Class:  types.ClassLangPkg,
+					Vulnerabilities: []types.DetectedVulnerability{
+						{
+							VulnerabilityID:  "CVE-2022-24999",
+							PkgName:          "qs",
+							InstalledVersion: "6.5.2",
+							FixedVersion:     "6.5.3",
+							Status:           dbTypes.StatusFixed,
+							Vulnerability: dbTypes.Vulnerability{
+								Title:    "express: \"qs\" prototype poisoning causes the hang of the node process",
+								Severity: "HIGH",
+							},
+						},
+					},
+				},
+				{
+					Target: "api/go.mod",
+					Class:  types.ClassLangPkg,
+				},
+			},
+			focusCVEs: []string{
+				"CVE-2022-24999",
+				"CVE-2024-0001",
+			},
+			expectedOutput: `
+CVE-2022-24999
+==============
+Severity: HIGH
+Title: express: "qs" prototype poisoning causes the hang of the node process
+Affected: 2 packages in 2 of 3 targets
+
+┌───────────────────────┬─────────┬───────────────────┬───────────────┬────────┐
+│        Target         │ Library │ Installed Version │ Fixed Version │ Status │
+├───────────────────────┼─────────┼───────────────────┼───────────────┼────────┤
+│ app/package-lock.json │ qs      │ 6.5.2             │ 6.5.3         │ fixed  │
+├───────────────────────┤         │                   │               │        │
+│ web/package-lock.json │         │                   │               │        │
+└───────────────────────┴─────────┴───────────────────┴───────────────┴────────┘
+
+CVE-2024-0001
+=============
+Not affected: CVE-2024-0001 was not found in any of the 3 targets
+`,
+		},
+		{
+			name: "remediation plan",
+			results: types.Results{
+				{
+					Target: "app/package-lock.json",
+					Class:  types.ClassLangPkg,
+					Vulnerabilities: []types.DetectedVulnerability{
+						{
+							VulnerabilityID:  "CVE-2022-24999",
+							PkgName:          "qs",
+							InstalledVersion: "6.5.2",
+							FixedVersion:     "6.5.3",
+							Vulnerability: dbTypes.Vulnerability{
+								Severity: "HIGH",
+							},
+						},
+						{
+							VulnerabilityID:  "CVE-2024-29041",
+							PkgName:          "express",
+							InstalledVersion: "4.17.1",
+							FixedVersion:     "4.19.2",
+							Vulnerability: dbTypes.Vulnerability{
+								Severity: "HIGH",
+							},
+						},
+						{
+							VulnerabilityID:  "CVE-2024-43796",
+							PkgName:          "express",
+							InstalledVersion: "4.17.1",
+							FixedVersion:     "4.20.0",
+							Vulnerability: dbTypes.Vulnerability{
+								Severity: "HIGH",
+							},
+						},
+						{
+							VulnerabilityID:  "CVE-2017-20165",
+							PkgName:          "debug",
+							InstalledVersion: "2.6.8",
+							Vulnerability: dbTypes.Vulnerability{
+								Severity: "HIGH",
+							},
+						},
+					},
+				},
+			},
+			remediationPlan: true,
+			expectedOutput: `
+Remediation Plan
+================
+Upgrades: 2 packages to fix 3 vulnerabilities
+
+┌───────────────────────┬─────────┬───────────────────┬────────────┬───────┬─────────────────┐
+│        Target         │ Library │ Installed Version │ Upgrade To │ Fixes │ Vulnerabilities │
+├───────────────────────┼─────────┼───────────────────┼────────────┼───────┼─────────────────┤
+│ app/package-lock.json │ express │ 4.17.1            │ 4.20.0     │ 2     │ CVE-2024-29041  │
+│                       │         │                   │            │       │ CVE-2024-43796  │
+├───────────────────────┼─────────┼───────────────────┼────────────┼───────┼─────────────────┤
+│ app/package-lock.json │ qs      │ 6.5.2             │ 6.5.3      │ 1     │ CVE-2022-24999  │
+└───────────────────────┴─────────┴───────────────────┴────────────┴───────┴─────────────────┘
+
+No Remediation
+==============
+No fixed version is available for 1 vulnerabilities in 1 packages
+
+┌───────────────────────┬─────────┬───────────────────┬─────────────────┐
+│        Target         │ Library │ Installed Version │ Vulnerabilities │
+├───────────────────────┼─────────┼───────────────────┼─────────────────┤
+│ app/package-lock.json │ debug   │ 2.6.8             │ CVE-2017-20165  │
+└───────────────────────┴─────────┴───────────────────┴─────────────────┘
+`,
+		},
+		{
+			name: "clean summary",
+			results: types.Results{
+				{
+					Target: "alpine:3.20 (alpine 3.20.0)",
+					Class:  types.ClassOSPkg,
+					Type:   ftypes.Alpine,
+					Packages: []ftypes.Package{
+						{
+							ID:      "musl@1.2.5-r0",
+							Name:    "musl",
+							Version: "1.2.5-r0",
+						},
+						{
+							ID:      "busybox@1.36.1-r29",
+							Name:    "busybox",
+							Version: "1.36.1-r29",
+						},
+					},
+				},
+				{
+					Target: "app/package-lock.json",
+					Class:  types.ClassLangPkg,
+					Type:   ftypes.Npm,
+					Packages: []ftypes.Package{
+						{
+							ID:      "express@4.19.2",
+							Name:    "express",
+							Version: "4.19.2",
+						},
+					},
+				},
+			},
+			showCleanSummary: true,
+			scanners: types.Scanners{
+				types.VulnerabilityScanner,
+				types.SecretScanner,
+				types.SBOMScanner,
+			},
+			os: &ftypes.OS{
+				Family: ftypes.Alpine,
+				Name:   "3.20.0",
+			},
+			expectedOutput: `
+alpine:3.20 (alpine 3.20.0)
+===========================
+Total: 0 (MEDIUM: 0, HIGH: 0)
+
+
+Clean Scan Summary
+==================
+No findings.
+Scanners: vuln, secret
+OS: alpine 3.20.0
+Packages: 3 (in 2 targets)
+`,
+		},
+		{
+			name:             "clean summary without anything to scan",
+			showCleanSummary: true,
+			scanners: types.Scanners{
+				types.SecretScanner,
+			},
+			expectedOutput: `
+Clean Scan Summary
+==================
+No findings, but nothing was detected to scan: no OS, packages or configuration files were found.
+Scanners: secret
+`,
+		},
 	}
 
 	t.Setenv("TRIVY_DISABLE_VEX_NOTICE", "1")
@@ -81,14 +603,1063 @@ Total: 1 (MEDIUM: 0, HIGH: 1)
 				Output:             &tableWritten,
 				Tree:               true,
 				IncludeNonFailures: tc.includeNonFailures,
+				NoCellMerge:        tc.noCellMerge,
+				ShowClasses:        tc.showClasses,
+				VulnSeverities:     tc.vulnSeverities,
+				SecretSeverities:   tc.secretSeverities,
+				FocusCVEs:          tc.focusCVEs,
+				RemediationPlan:    tc.remediationPlan,
+				ShowCleanSummary:   tc.showCleanSummary,
+				Scanners:           tc.scanners,
 				Severities: []dbTypes.Severity{
 					dbTypes.SeverityHigh,
 					dbTypes.SeverityMedium,
 				},
 			}
-			err := writer.Write(nil, types.Report{Results: tc.results})
+			err := writer.Write(nil, types.Report{
+				Results:      tc.results,
+				AgeHistogram: tc.ageHistogram,
+				Banner:       tc.banner,
+				Metadata: types.Metadata{
+					OS: tc.os,
+				},
+			})
 			require.NoError(t, err)
 			assert.Equal(t, tc.expectedOutput, tableWritten.String(), tc.name)
 		})
 	}
 }
+
+func TestWriter_Write_vexNotice(t *testing.T) {
+	vulnResult := types.Result{
+		Target: "package-lock.json",
+		Class:  types.ClassLangPkg,
+		Type:   ftypes.Npm,
+		Vulnerabilities: []types.DetectedVulnerability{
+			{
+				VulnerabilityID:  "CVE-2020-0001",
+				PkgName:          "foo",
+				InstalledVersion: "1.2.3",
+				Vulnerability: dbTypes.Vulnerability{
+					Severity: "HIGH",
+				},
+			},
+		},
+	}
+	cleanResult := types.Result{
+		Target: "yarn.lock",
+		Class:  types.ClassLangPkg,
+		Type:   ftypes.Yarn,
+	}
+
+	tests := []struct {
+		name          string
+		ci            string
+		disableNotice string
+		results       types.Results
+		want          int
+	}{
+		{
+			name:    "CI without VEX",
+			ci:      "true",
+			results: types.Results{vulnResult},
+			want:    1,
+		},
+		{
+			name:    "result without vulnerabilities doesn't use up the notice",
+			ci:      "true",
+			results: types.Results{cleanResult, vulnResult, vulnResult},
+			want:    1,
+		},
+		{
+			name:          "disabled notice",
+			ci:            "true",
+			disableNotice: "1",
+			results:       types.Results{vulnResult},
+			want:          0,
+		},
+		{
+			name:    "not CI",
+			results: types.Results{vulnResult},
+			want:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CI", tt.ci)
+			t.Setenv("TRIVY_DISABLE_VEX_NOTICE", tt.disableNotice)
+			table.ResetVEXNotice()
+
+			output := bytes.Buffer{}
+			writer := table.Writer{
+				Output: &output,
+				Severities: []dbTypes.Severity{
+					dbTypes.SeverityHigh,
+				},
+			}
+			err := writer.Write(context.Background(), types.Report{
+				Results: tt.results,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, strings.Count(output.String(), "For OSS Maintainers: VEX Notice"))
+		})
+	}
+}
+
+func BenchmarkWriter_Write(b *testing.B) {
+	b.Setenv("TRIVY_DISABLE_VEX_NOTICE", "1")
+
+	// A report with many targets, such as a monorepo
+	var results types.Results
+	for i := range 500 {
+		var vulns []types.DetectedVulnerability
+		for j := range 50 {
+			vulns = append(vulns, types.DetectedVulnerability{
+				VulnerabilityID:  fmt.Sprintf("CVE-2024-%04d", j),
+				PkgName:          fmt.Sprintf("pkg-%d", j%10),
+				InstalledVersion: "1.2.3",
+				FixedVersion:     "1.2.4",
+				PrimaryURL:       fmt.Sprintf("https://avd.aquasec.com/nvd/cve-2024-%04d", j),
+				Vulnerability: dbTypes.Vulnerability{
+					Title:    "a vulnerability with a reasonably long title to be wrapped in the table",
+					Severity: dbTypes.SeverityNames[j%len(dbTypes.SeverityNames)],
+				},
+			})
+		}
+		results = append(results, types.Result{
+			Target:          fmt.Sprintf("app-%d/package-lock.json", i),
+			Class:           types.ClassLangPkg,
+			Type:            ftypes.Npm,
+			Vulnerabilities: vulns,
+		})
+	}
+
+	for _, parallel := range []int{1, 0} {
+		name := "serial"
+		if parallel == 0 {
+			name = "parallel"
+		}
+		b.Run(name, func(b *testing.B) {
+			for range b.N {
+				writer := table.Writer{
+					Output: io.Discard,
+					Severities: []dbTypes.Severity{
+						dbTypes.SeverityUnknown,
+						dbTypes.SeverityLow,
+						dbTypes.SeverityMedium,
+						dbTypes.SeverityHigh,
+						dbTypes.SeverityCritical,
+					},
+					Parallel: parallel,
+				}
+				err := writer.Write(context.Background(), types.Report{Results: results})
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+func BenchmarkWriter_Write_DependencyTree(b *testing.B) {
+	b.Setenv("TRIVY_DISABLE_VEX_NOTICE", "1")
+
+	// A large dependency graph where each package depends on the next ones
+	var pkgs []ftypes.Package
+	for i := range 1000 {
+		pkg := ftypes.Package{
+			ID:           fmt.Sprintf("pkg-%d@1.0.0", i),
+			Name:         fmt.Sprintf("pkg-%d", i),
+			Version:      "1.0.0",
+			Relationship: ftypes.RelationshipIndirect,
+		}
+		if i < 10 {
+			pkg.Relationship = ftypes.RelationshipDirect
+		}
+		for j := i + 1; j < min(i+4, 1000); j++ {
+			pkg.DependsOn = append(pkg.DependsOn, fmt.Sprintf("pkg-%d@1.0.0", j))
+		}
+		pkgs = append(pkgs, pkg)
+	}
+
+	// Many results sharing the graph, such as the same lock file found in many images
+	var results types.Results
+	for i := range 100 {
+		var vulns []types.DetectedVulnerability
+		for j := range 20 {
+			pkgID := fmt.Sprintf("pkg-%d@1.0.0", 990-j*10)
+			vulns = append(vulns, types.DetectedVulnerability{
+				VulnerabilityID:  fmt.Sprintf("CVE-2024-%04d", j),
+				PkgID:            pkgID,
+				PkgName:          pkgID,
+				InstalledVersion: "1.0.0",
+				Vulnerability: dbTypes.Vulnerability{
+					Severity: dbTypes.SeverityHigh.String(),
+				},
+			})
+		}
+		results = append(results, types.Result{
+			Target:          fmt.Sprintf("app-%d/package-lock.json", i),
+			Class:           types.ClassLangPkg,
+			Type:            ftypes.Npm,
+			Packages:        pkgs,
+			Vulnerabilities: vulns,
+		})
+	}
+
+	for _, direction := range []string{"up", table.TreeDirectionDown} {
+		b.Run(direction, func(b *testing.B) {
+			for range b.N {
+				writer := table.Writer{
+					Output:        io.Discard,
+					Severities:    []dbTypes.Severity{dbTypes.SeverityHigh},
+					Tree:          true,
+					TreeDirection: direction,
+				}
+				err := writer.Write(context.Background(), types.Report{Results: results})
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+func TestWriter_Write_remediationPlan(t *testing.T) {
+	vuln := func(id, pkgName, installedVersion, fixedVersion string) types.DetectedVulnerability {
+		return types.DetectedVulnerability{
+			VulnerabilityID:  id,
+			PkgName:          pkgName,
+			InstalledVersion: installedVersion,
+			FixedVersion:     fixedVersion,
+			Vulnerability: dbTypes.Vulnerability{
+				Severity: "HIGH",
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		results types.Results
+		want    string
+	}{
+		{
+			name: "multiple vulnerabilities fixed by one upgrade",
+			results: types.Results{
+				{
+					Target: "package-lock.json",
+					Vulnerabilities: []types.DetectedVulnerability{
+						vuln("CVE-2024-0001", "debug", "2.6.8", "2.6.9"),
+						vuln("CVE-2024-0002", "express", "4.17.1", "4.19.2"),
+						vuln("CVE-2024-0003", "express", "4.17.1", "4.17.3, 5.0.0"),
+						vuln("CVE-2024-0004", "express", "4.17.1", "4.20.0"),
+					},
+				},
+			},
+			want: `
+Remediation Plan
+================
+Upgrades: 2 packages to fix 4 vulnerabilities
+
+┌───────────────────┬─────────┬───────────────────┬────────────┬───────┬─────────────────┐
+│      Target       │ Library │ Installed Version │ Upgrade To │ Fixes │ Vulnerabilities │
+├───────────────────┼─────────┼───────────────────┼────────────┼───────┼─────────────────┤
+│ package-lock.json │ express │ 4.17.1            │ 4.20.0     │ 3     │ CVE-2024-0002   │
+│                   │         │                   │            │       │ CVE-2024-0003   │
+│                   │         │                   │            │       │ CVE-2024-0004   │
+├───────────────────┼─────────┼───────────────────┼────────────┼───────┼─────────────────┤
+│ package-lock.json │ debug   │ 2.6.8             │ 2.6.9      │ 1     │ CVE-2024-0001   │
+└───────────────────┴─────────┴───────────────────┴────────────┴───────┴─────────────────┘
+`,
+		},
+		{
+			name: "fixed versions for other release branches",
+			results: types.Results{
+				{
+					Target: "go.mod",
+					Vulnerabilities: []types.DetectedVulnerability{
+						vuln("CVE-2024-0001", "golang.org/x/net", "0.20.0", "0.17.0, 0.23.0"),
+						vuln("CVE-2024-0002", "golang.org/x/net", "0.20.0", "0.23.0"),
+					},
+				},
+			},
+			want: `
+Remediation Plan
+================
+Upgrades: 1 packages to fix 2 vulnerabilities
+
+┌────────┬──────────────────┬───────────────────┬────────────┬───────┬─────────────────┐
+│ Target │     Library      │ Installed Version │ Upgrade To │ Fixes │ Vulnerabilities │
+├────────┼──────────────────┼───────────────────┼────────────┼───────┼─────────────────┤
+│ go.mod │ golang.org/x/net │ 0.20.0            │ 0.23.0     │ 2     │ CVE-2024-0001   │
+│        │                  │                   │            │       │ CVE-2024-0002   │
+└────────┴──────────────────┴───────────────────┴────────────┴───────┴─────────────────┘
+`,
+		},
+		{
+			name: "no remediation",
+			results: types.Results{
+				{
+					Target: "debian (debian 12.7)",
+					Vulnerabilities: []types.DetectedVulnerability{
+						vuln("CVE-2024-0001", "libssl3", "3.0.14-1~deb12u2", ""),
+						vuln("CVE-2024-0002", "libssl3", "3.0.14-1~deb12u2", "3.0.15-1~deb12u1"),
+						vuln("CVE-2024-0003", "libc6", "2.36-9+deb12u8", ""),
+					},
+				},
+			},
+			want: `
+Remediation Plan
+================
+Upgrades: 1 packages to fix 1 vulnerabilities
+
+┌──────────────────────┬─────────┬───────────────────┬──────────────────┬───────┬─────────────────┐
+│        Target        │ Library │ Installed Version │    Upgrade To    │ Fixes │ Vulnerabilities │
+├──────────────────────┼─────────┼───────────────────┼──────────────────┼───────┼─────────────────┤
+│ debian (debian 12.7) │ libssl3 │ 3.0.14-1~deb12u2  │ 3.0.15-1~deb12u1 │ 1     │ CVE-2024-0002   │
+└──────────────────────┴─────────┴───────────────────┴──────────────────┴───────┴─────────────────┘
+
+No Remediation
+==============
+No fixed version is available for 2 vulnerabilities in 2 packages
+
+┌──────────────────────┬─────────┬───────────────────┬─────────────────┐
+│        Target        │ Library │ Installed Version │ Vulnerabilities │
+├──────────────────────┼─────────┼───────────────────┼─────────────────┤
+│ debian (debian 12.7) │ libssl3 │ 3.0.14-1~deb12u2  │ CVE-2024-0001   │
+├──────────────────────┼─────────┼───────────────────┼─────────────────┤
+│ debian (debian 12.7) │ libc6   │ 2.36-9+deb12u8    │ CVE-2024-0003   │
+└──────────────────────┴─────────┴───────────────────┴─────────────────┘
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := table.Writer{
+				Output:          &buf,
+				Severities:      []dbTypes.Severity{dbTypes.SeverityHigh},
+				RemediationPlan: true,
+			}
+			require.NoError(t, w.Write(context.Background(), types.Report{Results: tt.results}))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestTargetVersion(t *testing.T) {
+	tests := []struct {
+		name             string
+		installedVersion string
+		fixedVersions    string
+		want             string
+	}{
+		{
+			name:             "minimal fixed version",
+			installedVersion: "4.17.20",
+			fixedVersions:    "4.17.21, 5.0.0",
+			want:             "4.17.21",
+		},
+		{
+			name:             "fixed version of another release branch",
+			installedVersion: "5.0.0",
+			fixedVersions:    "4.17.21, 5.0.1",
+			want:             "5.0.1",
+		},
+		{
+			name:             "no newer fixed version",
+			installedVersion: "5.0.1",
+			fixedVersions:    "4.17.21, 5.0.1",
+		},
+		{
+			name:             "no fixed version",
+			installedVersion: "1.2.5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, table.TargetVersion(tt.installedVersion, tt.fixedVersions))
+		})
+	}
+}
+
+func TestWriter_Write_licenseCompliance(t *testing.T) {
+	results := types.Results{
+		{
+			Target: "OS Packages",
+			Class:  types.ClassLicense,
+			Licenses: []types.DetectedLicense{
+				{
+					PkgName: "musl",
+					Name:    "MIT",
+				},
+				{
+					PkgName: "busybox",
+					Name:    "GPL-2.0-only",
+				},
+			},
+		},
+		{
+			Target: "Loose File License(s)",
+			Class:  types.ClassLicenseFile,
+			Licenses: []types.DetectedLicense{
+				{
+					FilePath: "LICENSE",
+					Name:     "Custom License",
+				},
+			},
+		},
+	}
+	// MIT is allowed, GPL-2.0-only is denied, and the custom license is unknown
+	policy, err := types.NewLicensePolicy([]string{"MIT"}, []string{"GPL-2.0-only"}, "")
+	require.NoError(t, err)
+	policy.Apply(results)
+
+	var buf bytes.Buffer
+	w := table.Writer{
+		Output:            &buf,
+		Severities:        []dbTypes.Severity{dbTypes.SeverityHigh},
+		ShowClasses:       []types.ResultClass{types.ClassOSPkg}, // Only the compliance table is rendered
+		LicenseCompliance: true,
+	}
+	require.NoError(t, w.Write(context.Background(), types.Report{Results: results}))
+	want := `
+License Compliance
+==================
+Total: 3 (PASS: 1, FAIL: 2)
+
+┌───────────────────────┬─────────┬────────────────┬─────────┐
+│        Target         │ Package │    License     │ Verdict │
+├───────────────────────┼─────────┼────────────────┼─────────┤
+│ OS Packages           │ musl    │ MIT            │ PASS    │
+│                       ├─────────┼────────────────┼─────────┤
+│                       │ busybox │ GPL-2.0-only   │ FAIL    │
+├───────────────────────┼─────────┼────────────────┤         │
+│ Loose File License(s) │ LICENSE │ Custom License │         │
+└───────────────────────┴─────────┴────────────────┴─────────┘
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestWriter_Write_scorecard(t *testing.T) {
+	tests := []struct {
+		name      string
+		scorecard *types.Scorecard
+		want      string
+	}{
+		{
+			name: "with non-failures",
+			scorecard: &types.Scorecard{
+				Successes:  7,
+				Failures:   1,
+				Exceptions: 1,
+				Severities: []types.SeverityScore{
+					{Severity: "HIGH", Successes: 3, Failures: 1},
+					{Severity: "LOW", Successes: 4},
+				},
+			},
+			want: `
+Compliance Scorecard
+====================
+Score: 87.5% (Grade: B)
+Checks: 9 (PASS: 7, FAIL: 1, EXCEPTION: 1)
+
+┌──────────┬────────┬────────┬────────┬───────┐
+│ Severity │ Passed │ Failed │ Score  │ Grade │
+├──────────┼────────┼────────┼────────┼───────┤
+│ HIGH     │ 3      │ 1      │ 75.0%  │ C     │
+├──────────┼────────┼────────┼────────┼───────┤
+│ LOW      │ 4      │ 0      │ 100.0% │ A     │
+└──────────┴────────┴────────┴────────┴───────┘
+`,
+		},
+		{
+			name: "only failures",
+			scorecard: &types.Scorecard{
+				Successes: 3,
+				Failures:  1,
+				Severities: []types.SeverityScore{
+					{Severity: "CRITICAL", Failures: 1},
+				},
+			},
+			want: `
+Compliance Scorecard
+====================
+Score: 75.0% (Grade: C)
+Checks: 4 (PASS: 3, FAIL: 1, EXCEPTION: 0)
+
+┌──────────┬────────┬────────┬───────┬───────┐
+│ Severity │ Passed │ Failed │ Score │ Grade │
+├──────────┼────────┼────────┼───────┼───────┤
+│ CRITICAL │ -      │ 1      │ -     │ -     │
+└──────────┴────────┴────────┴───────┴───────┘
+Passed checks per severity are shown with '--include-non-failures'.
+`,
+		},
+		{
+			name: "all passed",
+			scorecard: &types.Scorecard{
+				Successes: 12,
+			},
+			want: `
+Compliance Scorecard
+====================
+Score: 100.0% (Grade: A)
+Checks: 12 (PASS: 12, FAIL: 0, EXCEPTION: 0)
+
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := table.Writer{
+				Output:     &buf,
+				Severities: []dbTypes.Severity{dbTypes.SeverityHigh},
+			}
+			require.NoError(t, w.Write(context.Background(), types.Report{Scorecard: tt.scorecard}))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func secretsAt(lines ...int) []types.DetectedSecret {
+	var secrets []types.DetectedSecret
+	for _, line := range lines {
+		secrets = append(secrets, types.DetectedSecret{
+			RuleID:    "aws-access-key-id",
+			Category:  "AWS",
+			Title:     "AWS Access Key ID",
+			Severity:  "CRITICAL",
+			StartLine: line,
+			EndLine:   line,
+			Code: ftypes.Code{
+				Lines: []ftypes.Line{
+					{
+						Number:     line,
+						Content:    "AWS_ACCESS_KEY_ID=*****",
+						IsCause:    true,
+						FirstCause: true,
+						LastCause:  true,
+					},
+				},
+			},
+		})
+	}
+	return secrets
+}
+
+func TestWriter_Write_secretsByFile(t *testing.T) {
+	report := types.Report{
+		Results: types.Results{
+			{
+				Target: "go.mod",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.GoModule,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2024-0001",
+						PkgName:          "golang.org/x/net",
+						InstalledVersion: "0.20.0",
+						Vulnerability: dbTypes.Vulnerability{
+							Severity: "CRITICAL",
+						},
+					},
+				},
+			},
+			{
+				Target:  "app/config.yaml",
+				Class:   types.ClassSecret,
+				Secrets: secretsAt(12),
+			},
+			{
+				Target:  "deploy/.env",
+				Class:   types.ClassSecret,
+				Secrets: secretsAt(7, 3),
+			},
+			{
+				Target:  "app/a.yaml",
+				Class:   types.ClassSecret,
+				Secrets: secretsAt(1),
+			},
+			// The same file found in another layer
+			{
+				Target:  "app/config.yaml",
+				Class:   types.ClassSecret,
+				Secrets: secretsAt(5, 9),
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	w := table.Writer{
+		Output:               &buf,
+		Severities:           []dbTypes.Severity{dbTypes.SeverityCritical},
+		SecretsByFile:        true,
+		ShowSecretConfidence: true,
+	}
+	require.NoError(t, w.Write(context.Background(), report))
+	out := buf.String()
+
+	// Results other than secrets stay in place, files with more secrets come first,
+	// and secrets of the same file are merged and sorted by line number
+	rest := out
+	for _, s := range []string{
+		"go.mod (gomod)",
+		"app/config.yaml (secrets)",
+		"Total: 3 (CRITICAL: 3)",
+		"app/config.yaml:5",
+		"app/config.yaml:9",
+		"app/config.yaml:12",
+		"deploy/.env (secrets)",
+		"Total: 2 (CRITICAL: 2)",
+		"deploy/.env:3",
+		"deploy/.env:7",
+		"app/a.yaml (secrets)",
+		"Total: 1 (CRITICAL: 1)",
+		"app/a.yaml:1",
+	} {
+		_, after, found := strings.Cut(rest, s)
+		require.True(t, found, s)
+		rest = after
+	}
+	assert.Equal(t, 1, strings.Count(out, "app/config.yaml (secrets)"))
+
+	// The report is not modified
+	assert.Len(t, report.Results[1].Secrets, 1)
+}
+
+func TestWriter_Write_dependencyGraphs(t *testing.T) {
+	pkgs := []ftypes.Package{
+		{
+			ID:           "app@1.0.0",
+			Relationship: ftypes.RelationshipRoot,
+			DependsOn:    []string{"express@4.17.1"},
+		},
+		{
+			ID:           "express@4.17.1",
+			Relationship: ftypes.RelationshipDirect,
+			DependsOn:    []string{"qs@6.7.0"},
+		},
+		{
+			ID:           "qs@6.7.0",
+			Relationship: ftypes.RelationshipIndirect,
+		},
+	}
+	// Results whose dependency graphs look alike, and are rendered as if each result were rendered alone
+	pkgSets := [][]ftypes.Package{
+		pkgs,
+		// The same graph in another result, e.g. another image with the same lock file
+		lo.Map(pkgs, func(pkg ftypes.Package, _ int) ftypes.Package {
+			pkg.FilePath = "another/package-lock.json"
+			return pkg
+		}),
+		// The same packages with a different relationship
+		{
+			pkgs[0],
+			{
+				ID:           "express@4.17.1",
+				Relationship: ftypes.RelationshipIndirect,
+				DependsOn:    []string{"qs@6.7.0"},
+			},
+			pkgs[2],
+		},
+		// The same packages with different dependencies
+		{
+			{
+				ID:           "app@1.0.0",
+				Relationship: ftypes.RelationshipRoot,
+				DependsOn:    []string{"express@4.17.1", "qs@6.7.0"},
+			},
+			{
+				ID:           "express@4.17.1",
+				Relationship: ftypes.RelationshipDirect,
+			},
+			{
+				ID:           "qs@6.7.0",
+				Relationship: ftypes.RelationshipDirect,
+			},
+		},
+		// The same packages in another order
+		{
+			pkgs[2],
+			pkgs[1],
+			pkgs[0],
+		},
+		// IDs and dependencies that are the same when concatenated
+		{
+			{
+				ID:           "express@4.17.1q",
+				Relationship: ftypes.RelationshipDirect,
+				DependsOn:    []string{"s@6.7.0"},
+			},
+			{
+				ID:           "s@6.7.0",
+				Relationship: ftypes.RelationshipIndirect,
+			},
+			pkgs[2],
+		},
+	}
+
+	var results types.Results
+	for i, ps := range pkgSets {
+		results = append(results, types.Result{
+			Target:   fmt.Sprintf("package-lock-%d.json", i),
+			Class:    types.ClassLangPkg,
+			Type:     ftypes.Npm,
+			Packages: ps,
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:  "CVE-2022-24999",
+					PkgID:            "qs@6.7.0",
+					PkgName:          "qs",
+					InstalledVersion: "6.7.0",
+					Vulnerability: dbTypes.Vulnerability{
+						Severity: "HIGH",
+					},
+				},
+			},
+		})
+	}
+
+	write := func(results types.Results) string {
+		var buf bytes.Buffer
+		w := table.Writer{
+			Output:          &buf,
+			Severities:      []dbTypes.Severity{dbTypes.SeverityHigh},
+			Tree:            true,
+			ShowBlastRadius: true,
+			Parallel:        len(results),
+		}
+		require.NoError(t, w.Write(context.Background(), types.Report{Results: results}))
+		return buf.String()
+	}
+
+	var want string
+	for _, result := range results {
+		want += write(types.Results{result})
+	}
+	assert.Equal(t, want, write(results))
+	assert.Contains(t, want, `package-lock-0.json
+└── qs@6.7.0, (HIGH: 1)
+    └── express@4.17.1
+`)
+}
+
+func TestLayoutColumns(t *testing.T) {
+	outputs := []string{
+		"\nfoo\n===\nTotal: 1\n",
+		"\nbar/baz\n=======\nTotal: 10\nsecret\n",
+		"\nqux\n===\n",
+	}
+
+	tests := []struct {
+		name    string
+		outputs []string
+		columns int
+		width   int
+		want    []string
+	}{
+		{
+			name:    "side by side",
+			outputs: outputs,
+			columns: 2,
+			width:   80,
+			want: []string{
+				"\n" +
+					"foo         bar/baz\n" +
+					"===         =======\n" +
+					"Total: 1    Total: 10\n" +
+					"            secret\n",
+				"\nqux\n===\n",
+			},
+		},
+		{
+			name:    "three columns",
+			outputs: outputs,
+			columns: 3,
+			width:   80,
+			want: []string{
+				"\n" +
+					"foo         bar/baz      qux\n" +
+					"===         =======      ===\n" +
+					"Total: 1    Total: 10\n" +
+					"            secret\n",
+			},
+		},
+		{
+			name:    "exactly fits",
+			outputs: outputs[:2],
+			columns: 2,
+			width:   21,
+			want: []string{
+				"\n" +
+					"foo         bar/baz\n" +
+					"===         =======\n" +
+					"Total: 1    Total: 10\n" +
+					"            secret\n",
+			},
+		},
+		{
+			name:    "fall back when the terminal is too narrow",
+			outputs: outputs,
+			columns: 2,
+			width:   20,
+			want:    outputs,
+		},
+		{
+			name:    "unknown width",
+			outputs: outputs,
+			columns: 2,
+			width:   0,
+			want:    outputs,
+		},
+		{
+			name:    "single column",
+			outputs: outputs,
+			columns: 1,
+			width:   80,
+			want:    outputs,
+		},
+		{
+			name: "colors and line endings",
+			outputs: []string{
+				"\x1b[1mfoo\x1b[0m\r\n\x1b[2m───\x1b[0m\r\n",
+				"bar\n",
+			},
+			columns: 2,
+			width:   10,
+			want: []string{
+				"\x1b[1mfoo\x1b[0m    bar\n" +
+					"\x1b[2m───\x1b[0m\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := table.LayoutColumns(tt.outputs, tt.columns, tt.width)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFoldView(t *testing.T) {
+	outputs := []string{
+		"\nfoo\n===\nTotal: 1\n\nfoo-1\n",
+		"\nbar\n===\nTotal: 2\n\nbar-1\nbar-2\n",
+	}
+
+	tests := []struct {
+		name    string
+		outputs []string
+		keys    []string
+		height  int
+		want    []string
+		quit    bool
+	}{
+		{
+			name:    "collapsed",
+			outputs: outputs,
+			height:  10,
+			want: []string{
+				"\x1b[7m▸ foo  Total: 1\x1b[0m",
+				"▸ bar  Total: 2",
+			},
+		},
+		{
+			name:    "expand the second section",
+			outputs: outputs,
+			keys:    []string{table.KeyDown, table.KeyToggle},
+			height:  10,
+			want: []string{
+				"▸ foo  Total: 1",
+				"\x1b[7m▾ bar  Total: 2\x1b[0m",
+				"  Total: 2",
+				"  ",
+				"  bar-1",
+				"  bar-2",
+			},
+		},
+		{
+			name:    "expand and collapse all",
+			outputs: outputs,
+			keys:    []string{table.KeyExpand, table.KeyCollapse},
+			height:  10,
+			want: []string{
+				"\x1b[7m▸ foo  Total: 1\x1b[0m",
+				"▸ bar  Total: 2",
+			},
+		},
+		{
+			name:    "cursor stays in the sections",
+			outputs: outputs,
+			keys:    []string{table.KeyUp, table.KeyBottom, table.KeyDown, table.KeyDown},
+			height:  10,
+			want: []string{
+				"▸ foo  Total: 1",
+				"\x1b[7m▸ bar  Total: 2\x1b[0m",
+			},
+		},
+		{
+			name:    "scroll",
+			outputs: outputs,
+			keys:    []string{table.KeyExpand, table.KeyBottom},
+			height:  3,
+			want: []string{
+				"  foo-1",
+				"\x1b[7m▾ bar  Total: 2\x1b[0m",
+			},
+		},
+		{
+			name:    "quit",
+			outputs: outputs,
+			keys:    []string{table.KeyQuit},
+			height:  10,
+			want: []string{
+				"\x1b[7m▸ foo  Total: 1\x1b[0m",
+				"▸ bar  Total: 2",
+			},
+			quit: true,
+		},
+		{
+			name:    "vulnerabilities",
+			outputs: []string{"\nalpine:3.20 (alpine 3.20.0)\n===========================\nTotal: 1 (HIGH: 1)\n\n┌─────┐\n│ foo │\n└─────┘\n"},
+			keys:    []string{table.KeyToggle},
+			height:  10,
+			want: []string{
+				"\x1b[7m▾ alpine:3.20 (alpine 3.20.0)  Total: 1 (HIGH: 1)\x1b[0m",
+				"  Total: 1 (HIGH: 1)",
+				"  ",
+				"  ┌─────┐",
+				"  │ foo │",
+				"  └─────┘",
+			},
+		},
+		{
+			name:    "colored secrets",
+			outputs: []string{"\r\n\x1b[1mconfig.yaml (secrets)\x1b[0m\r\n=====================\r\nTotal: 1 (CRITICAL: 1)\r\n\r\nsecret\r\n"},
+			keys:    []string{table.KeyToggle},
+			height:  10,
+			want: []string{
+				"\x1b[7m▾ config.yaml (secrets)  Total: 1 (CRITICAL: 1)\x1b[0m",
+				"  Total: 1 (CRITICAL: 1)",
+				"  ",
+				"  secret",
+			},
+		},
+		{
+			name:    "no summary",
+			outputs: []string{"\nLegend:\n- '-': Not scanned\n"},
+			keys:    []string{table.KeyToggle},
+			height:  10,
+			want: []string{
+				"\x1b[7m▾ Legend:\x1b[0m",
+				"  - '-': Not scanned",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view, quit := table.FoldView(tt.outputs, 120, tt.height, tt.keys...)
+			assert.Equal(t, tt.quit, quit)
+
+			lines := strings.Split(view, "\r\n")
+			require.Len(t, lines, tt.height)
+			assert.Equal(t, tt.want, lines[:len(tt.want)])
+			assert.Contains(t, lines[len(lines)-1], "q: quit")
+		})
+	}
+}
+
+func TestReadKey(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("jk \x1b[A\x1b[B\x1b[5~\x1b[6~egGcxq"))
+	want := []string{
+		table.KeyDown,
+		table.KeyUp,
+		table.KeyToggle,
+		table.KeyUp,
+		table.KeyDown,
+		table.KeyPageUp,
+		table.KeyPageDown,
+		table.KeyExpand,
+		table.KeyTop,
+		table.KeyBottom,
+		table.KeyCollapse,
+		"",
+		table.KeyQuit,
+	}
+	for _, w := range want {
+		got, err := table.ReadKey(r)
+		require.NoError(t, err)
+		assert.Equal(t, w, got)
+	}
+}
+
+func TestRenderQRCode(t *testing.T) {
+	results := types.Results{
+		{
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "CVE-2020-0001",
+					PrimaryURL:      "https://avd.aquasec.com/nvd/cve-2020-0001",
+					Vulnerability:   dbTypes.Vulnerability{Severity: "HIGH"},
+				},
+				{
+					VulnerabilityID: "CVE-2020-0002",
+					Vulnerability:   dbTypes.Vulnerability{Severity: "CRITICAL"},
+				},
+				{
+					VulnerabilityID: "CVE-2020-0003",
+					PrimaryURL:      "https://avd.aquasec.com/nvd/cve-2020-0003",
+					Vulnerability:   dbTypes.Vulnerability{Severity: "MEDIUM"},
+				},
+			},
+		},
+		{
+			Misconfigurations: []types.DetectedMisconfiguration{
+				{
+					ID:         "KSV001",
+					Severity:   "CRITICAL",
+					PrimaryURL: "https://avd.aquasec.com/misconfig/ksv001",
+					Status:     types.MisconfStatusPassed,
+				},
+				{
+					ID:         "KSV002",
+					Severity:   "HIGH",
+					PrimaryURL: "https://avd.aquasec.com/misconfig/ksv002",
+					Status:     types.MisconfStatusFailure,
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		results       types.Results
+		severityOrder []string
+		want          string
+	}{
+		{
+			name:    "first finding with the highest severity and an advisory",
+			results: results,
+			want:    "CVE-2020-0001 (HIGH): https://avd.aquasec.com/nvd/cve-2020-0001\n",
+		},
+		{
+			name:          "custom severity order",
+			results:       results,
+			severityOrder: []string{"UNKNOWN", "LOW", "CRITICAL", "HIGH", "MEDIUM"},
+			want:          "CVE-2020-0003 (MEDIUM): https://avd.aquasec.com/nvd/cve-2020-0003\n",
+		},
+		{
+			name:    "no findings",
+			results: types.Results{{Target: "test"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			table.RenderQRCode(&buf, tt.results, tt.severityOrder)
+			if tt.want == "" {
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
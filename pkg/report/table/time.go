@@ -0,0 +1,21 @@
+package table
+
+import (
+	"cmp"
+	"time"
+)
+
+// TimeFormat formats timestamps displayed in human-readable reports.
+// The zero value formats them in UTC with RFC 3339.
+type TimeFormat struct {
+	// Time zone of displayed timestamps (UTC by default)
+	Location *time.Location
+
+	// Go layout of displayed timestamps (time.RFC3339 by default)
+	Layout string
+}
+
+// Format returns the timestamp in the configured time zone and layout
+func (f TimeFormat) Format(t time.Time) string {
+	return t.In(cmp.Or(f.Location, time.UTC)).Format(cmp.Or(f.Layout, time.RFC3339))
+}
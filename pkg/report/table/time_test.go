@@ -0,0 +1,46 @@
+package table_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/report/table"
+)
+
+func TestTimeFormat_Format(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	ts := time.Date(2024, 6, 1, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	tests := []struct {
+		name   string
+		format table.TimeFormat
+		want   string
+	}{
+		{
+			name: "default",
+			want: "2024-06-01T10:30:00Z",
+		},
+		{
+			name:   "timezone",
+			format: table.TimeFormat{Location: tokyo},
+			want:   "2024-06-01T19:30:00+09:00",
+		},
+		{
+			name: "timezone and layout",
+			format: table.TimeFormat{
+				Location: tokyo,
+				Layout:   "2006-01-02 15:04 MST",
+			},
+			want: "2024-06-01 19:30 JST",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.format.Format(ts))
+		})
+	}
+}
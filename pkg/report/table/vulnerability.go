@@ -2,17 +2,20 @@ package table
 
 import (
 	"bytes"
+	"cmp"
 	"fmt"
 	"os"
 	"path/filepath"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/fatih/color"
 	"github.com/samber/lo"
 	"github.com/xlab/treeprint"
+	"golang.org/x/term"
 
 	"github.com/aquasecurity/table"
 	"github.com/aquasecurity/tml"
@@ -35,6 +38,10 @@ To disable this notice, set the TRIVY_DISABLE_VEX_NOTICE environment variable.
 
 `
 	envDisableNotice = "TRIVY_DISABLE_VEX_NOTICE"
+
+	// TreeDirectionDown renders the dependency tree from direct dependencies down to vulnerable packages.
+	// Otherwise, the tree is rendered from vulnerable packages up to direct dependencies.
+	TreeDirectionDown = "down"
 )
 
 var (
@@ -45,28 +52,158 @@ var (
 )
 
 type vulnerabilityRenderer struct {
-	w              *bytes.Buffer
-	result         types.Result
-	isTerminal     bool
-	tree           bool // Show dependency tree
-	showSuppressed bool // Show suppressed vulnerabilities
-	severities     []dbTypes.Severity
-	once           *sync.Once
+	w               *bytes.Buffer
+	result          types.Result
+	isTerminal      bool
+	tree            bool // Show dependency tree
+	treeDirection   string
+	treeMinPackages int  // Minimum number of vulnerable packages to render the dependency tree
+	shortestPath    bool // Show only the shortest path to each vulnerable package in the dependency tree
+	showSuppressed  bool // Show suppressed vulnerabilities
+	vexSuppressed   bool // Show vulnerabilities suppressed by VEX in the vulnerability table
+	vexStatuses     map[string]string
+	severities      []dbTypes.Severity
+	maxRows         int  // Maximum number of vulnerabilities to render (0 means unlimited)
+	reachability    bool // Show the "Reachable" column
+	groupBySeverity bool // Group vulnerabilities by severity with subheader rows
+	byInstruction   bool // Group vulnerabilities by the Dockerfile instruction with subheader rows
+	layer           bool // Show the "Layer" column
+	purl            bool // Show the "PURL" column
+	epss            bool // Show the "EPSS Score" and "EPSS Percentile" columns
+	kev             bool // Show the "KEV" column
+	kevDue          bool // Show the "KEV Due" column
+	vendorStatus    bool // Show the "Vendor Status" column
+	affectedRange   bool // Show the "Affected Range" column
+	labels          bool // Show the "Labels" column
+	platforms       bool // Show the "Platforms" column, i.e. vulnerabilities merged across platforms
+	baseline        bool // Show the "Baseline" column, i.e. vulnerabilities compared with the baseline
+	sla             bool // Show the "SLA" column
+	blastRadius     bool // Show the "Dependents" column
+	directOnly      bool // Hide vulnerabilities in indirect dependencies
+	fixCommands     bool // Show the "Fix Command" column
+	fixableFirst    bool // Sort vulnerabilities with fixed versions first
+	prerelease      bool // Annotate fixed versions that are pre-releases
+	conflicts       bool // Mark severities conflicting with the ratings of other sources
+	summaryBar      bool // Render the severity bar under the summary
+	width           int  // Width of the terminal
+	indirectVulns   []types.DetectedVulnerability
+	unknownVulns    []types.DetectedVulnerability // Hidden by "--direct-only" as the relationships are unknown
+	noCellMerge     bool                          // Disable merging identical adjacent cells
+	severityOrder   []string
+	severityLabels  map[string]string // Labels rendered instead of the severity names
+	showVEXNotice   bool              // Show the VEX notice for OSS maintainers
+	graphs          *dependencyGraphCache
+	once            *sync.Once
+}
+
+// VulnerabilityOptions holds the options of the vulnerability table.
+// See Writer for the description of each option.
+type VulnerabilityOptions struct {
+	Severities []dbTypes.Severity
+
+	Tree             bool
+	TreeDirection    string
+	TreeShortestPath bool
+	TreeMinPackages  int
+
+	ShowSuppressed    bool
+	ShowVEXSuppressed bool
+	MaxRows           int
+	DirectOnly        bool
+	FixableFirst      bool
+
+	GroupBySeverity    bool
+	GroupByInstruction bool
+
+	ShowReachability    bool
+	ShowLayer           bool
+	ShowPURL            bool
+	ShowEPSS            bool
+	ShowKEV             bool
+	ShowKEVDue          bool
+	ShowVendorStatus    bool
+	ShowAffectedRange   bool
+	ShowLabels          bool
+	ShowSLA             bool
+	ShowBlastRadius     bool
+	ShowFixCommand      bool
+	FlagPrereleaseFixes bool
+	SeverityConflicts   bool
+	SummaryBar          bool
+	NoCellMerge         bool
+
+	SeverityOrder  []string
+	SeverityLabels map[string]string
 }
 
-func NewVulnerabilityRenderer(result types.Result, isTerminal, tree, suppressed bool, severities []dbTypes.Severity) *vulnerabilityRenderer {
+func NewVulnerabilityRenderer(result types.Result, isTerminal bool, opts VulnerabilityOptions) *vulnerabilityRenderer {
 	buf := bytes.NewBuffer([]byte{})
 	if !isTerminal {
 		tml.DisableFormatting()
 	}
+	width, _, err := term.GetSize(0)
+	if err != nil || width == 0 {
+		width = 40
+	}
+
+	// Show VEX notice only on CI and only with the first result having vulnerabilities,
+	// so that results without vulnerabilities don't use up the notice.
+	// It is decided here rather than in Render so that the notice goes to the first result even if results are rendered concurrently.
+	var showVEXNotice bool
+	if len(result.Vulnerabilities) > 0 {
+		showVEXNoticeOnce.Do(func() {
+			showVEXNotice = os.Getenv(envDisableNotice) == "" && os.Getenv("CI") != ""
+		})
+	}
+
+	// Vulnerabilities merged across platforms with "--merge-platforms" have the platforms
+	platforms := lo.ContainsBy(result.Vulnerabilities, func(v types.DetectedVulnerability) bool {
+		return len(v.Platforms) > 0
+	})
+	// Vulnerabilities compared with "--baseline-file" are new or known
+	baseline := lo.ContainsBy(result.Vulnerabilities, func(v types.DetectedVulnerability) bool {
+		return v.BaselineStatus != ""
+	})
+
 	return &vulnerabilityRenderer{
-		w:              buf,
-		result:         result,
-		isTerminal:     isTerminal,
-		tree:           tree,
-		showSuppressed: suppressed,
-		severities:     severities,
-		once:           new(sync.Once),
+		w:               buf,
+		result:          result,
+		isTerminal:      isTerminal,
+		tree:            opts.Tree,
+		treeDirection:   opts.TreeDirection,
+		treeMinPackages: opts.TreeMinPackages,
+		shortestPath:    opts.TreeShortestPath,
+		showSuppressed:  opts.ShowSuppressed,
+		vexSuppressed:   opts.ShowVEXSuppressed,
+		severities:      opts.Severities,
+		maxRows:         opts.MaxRows,
+		reachability:    opts.ShowReachability,
+		groupBySeverity: opts.GroupBySeverity,
+		byInstruction:   opts.GroupByInstruction,
+		layer:           opts.ShowLayer,
+		purl:            opts.ShowPURL,
+		epss:            opts.ShowEPSS,
+		kev:             opts.ShowKEV,
+		kevDue:          opts.ShowKEVDue,
+		vendorStatus:    opts.ShowVendorStatus,
+		affectedRange:   opts.ShowAffectedRange,
+		labels:          opts.ShowLabels,
+		platforms:       platforms,
+		baseline:        baseline,
+		sla:             opts.ShowSLA,
+		blastRadius:     opts.ShowBlastRadius,
+		directOnly:      opts.DirectOnly,
+		fixCommands:     opts.ShowFixCommand,
+		fixableFirst:    opts.FixableFirst,
+		prerelease:      opts.FlagPrereleaseFixes,
+		conflicts:       opts.SeverityConflicts,
+		summaryBar:      opts.SummaryBar,
+		width:           width,
+		noCellMerge:     opts.NoCellMerge,
+		severityOrder:   opts.SeverityOrder,
+		severityLabels:  opts.SeverityLabels,
+		showVEXNotice:   showVEXNotice,
+		once:            new(sync.Once),
 	}
 }
 
@@ -75,8 +212,14 @@ func (r *vulnerabilityRenderer) Render() string {
 	// When Result contains vulnerabilities;
 	// When Result target is OS packages even if no vulnerabilities are found;
 	// When we show non-empty `Suppressed Vulnerabilities` table.
-	if len(r.result.Vulnerabilities) > 0 || r.result.Class == types.ClassOSPkg || (r.showSuppressed && len(r.result.ModifiedFindings) > 0) {
-		r.renderDetectedVulnerabilities()
+	// Vulnerabilities suppressed by VEX are rendered in the vulnerability table with "--show-vex-suppressed".
+	vexVulns := r.vexSuppressedVulnerabilities()
+	if r.directOnly {
+		r.result.Vulnerabilities, r.indirectVulns, r.unknownVulns = splitIndirectVulnerabilities(r.result)
+	}
+	if len(r.result.Vulnerabilities) > 0 || r.result.Class == types.ClassOSPkg || (r.showSuppressed && len(r.result.ModifiedFindings) > 0) ||
+		len(vexVulns) > 0 || len(r.indirectVulns) > 0 || len(r.unknownVulns) > 0 {
+		r.renderDetectedVulnerabilities(vexVulns)
 
 		if r.tree {
 			r.renderDependencyTree()
@@ -85,56 +228,295 @@ func (r *vulnerabilityRenderer) Render() string {
 
 	if r.showSuppressed {
 		r.renderModifiedVulnerabilities()
-	} else if len(r.result.ModifiedFindings) > 0 {
+	} else if len(r.result.ModifiedFindings) > len(vexVulns) {
 		showSuppressedOnce()
 	}
 
 	return r.w.String()
 }
 
-func (r *vulnerabilityRenderer) renderDetectedVulnerabilities() {
-	// Show VEX notice only on CI
-	showVEXNoticeOnce.Do(func() {
-		if os.Getenv(envDisableNotice) != "" || os.Getenv("CI") == "" {
-			return
+// splitIndirectVulnerabilities splits the vulnerabilities of language packages into the ones in direct dependencies,
+// the ones in indirect dependencies and the ones in packages with unknown relationships, e.g. packages in Gradle lock files.
+// Relationships don't apply to OS packages, so all their vulnerabilities are regarded as direct.
+func splitIndirectVulnerabilities(result types.Result) (direct, indirect, unknown []types.DetectedVulnerability) {
+	if result.Class != types.ClassLangPkg {
+		return result.Vulnerabilities, nil, nil
+	}
+	relationships := make(map[string]ftypes.Relationship, len(result.Packages))
+	for _, pkg := range result.Packages {
+		relationships[pkg.ID] = pkg.Relationship
+	}
+	for _, v := range result.Vulnerabilities {
+		switch relationships[v.PkgID] {
+		case ftypes.RelationshipRoot, ftypes.RelationshipDirect:
+			direct = append(direct, v)
+		case ftypes.RelationshipIndirect:
+			indirect = append(indirect, v)
+		default:
+			unknown = append(unknown, v)
+		}
+	}
+	return direct, indirect, unknown
+}
+
+// vexSuppressedVulnerabilities returns the vulnerabilities suppressed by VEX if "--show-vex-suppressed" is enabled.
+// The VEX status of each vulnerability is stored so that it can be rendered in the "Status" column.
+func (r *vulnerabilityRenderer) vexSuppressedVulnerabilities() []types.DetectedVulnerability {
+	if !r.vexSuppressed {
+		return nil
+	}
+
+	var vulns []types.DetectedVulnerability
+	r.vexStatuses = make(map[string]string)
+	for _, m := range r.result.ModifiedFindings {
+		if m.Type != types.FindingTypeVulnerability || !m.IsVEX() {
+			continue
 		}
+		vuln := m.Finding.(types.DetectedVulnerability)
+		vulns = append(vulns, vuln)
+
+		status := "VEX: " + string(m.Status)
+		if m.Statement != "" {
+			status += fmt.Sprintf(" (%s)", m.Statement)
+		}
+		r.vexStatuses[vexKey(vuln)] = status
+	}
+	return vulns
+}
+
+func vexKey(v types.DetectedVulnerability) string {
+	return strings.Join([]string{v.VulnerabilityID, v.PkgID, v.PkgName, v.InstalledVersion, v.PkgPath}, "|")
+}
+
+func (r *vulnerabilityRenderer) renderDetectedVulnerabilities(vexVulns []types.DetectedVulnerability) {
+	if r.showVEXNotice {
 		_, _ = color.New(color.FgCyan).Fprintf(r.w, vexNotice, doc.URL("docs/supply-chain/vex/repo", "publishing-vex-documents"))
-	})
+	}
+
+	vulns := r.result.Vulnerabilities
+	if len(vexVulns) > 0 {
+		// Keep the vulnerabilities of the same package together
+		vulns = append(slices.Clone(vulns), vexVulns...)
+		slices.SortStableFunc(vulns, func(a, b types.DetectedVulnerability) int {
+			return cmp.Or(
+				cmp.Compare(a.PkgName, b.PkgName),
+				cmp.Compare(a.InstalledVersion, b.InstalledVersion),
+			)
+		})
+	}
 
-	tw := newTableWriter(r.w, r.isTerminal)
-	r.setHeaders(tw)
-	r.setVulnerabilityRows(tw, r.result.Vulnerabilities)
+	tw := newTableWriter(r.w, r.isTerminal, !r.noCellMerge)
+	if len(vulns) > 0 {
+		tw.SetHeaders(r.headers()...)
+	}
+	vulns, omitted := limitRows(vulns, r.maxRows)
+	switch {
+	case r.byInstruction:
+		r.setInstructionGroupedRows(tw, vulns)
+	case r.groupBySeverity:
+		// Each severity group is rendered as a table of its own below the summary
+	default:
+		r.setVulnerabilityRows(tw, vulns)
+	}
 
+	// The summary counts all vulnerabilities, including omitted ones.
 	severityCount := r.countSeverities(r.result.Vulnerabilities)
-	total, summaries := summarize(r.severities, severityCount)
+	total, summaries := summarize(r.severities, r.severityOrder, r.severityLabels, severityCount, r.result.PreviousCounts)
 
 	target := r.result.Target
 	if r.result.Class == types.ClassLangPkg {
 		target += fmt.Sprintf(" (%s)", r.result.Type)
 	}
 	RenderTarget(r.w, target, r.isTerminal)
-	r.printf("Total: %d (%s)\n\n", total, strings.Join(summaries, ", "))
+	r.printf("Total: %d (%s)\n", total, strings.Join(summaries, ", "))
+	// The bar is useless in files or pipes, where only the text summary is rendered
+	if r.summaryBar && r.isTerminal {
+		renderSeverityBar(r.w, r.severities, r.severityOrder, severityCount, r.width)
+	}
+	if len(r.indirectVulns) > 0 {
+		// Vulnerabilities hidden by "--direct-only" are counted separately
+		total, summaries = summarize(r.severities, r.severityOrder, r.severityLabels, r.countSeverities(r.indirectVulns), nil)
+		r.printf("Hidden in indirect dependencies: %d (%s)\n", total, strings.Join(summaries, ", "))
+	}
+	if len(r.unknownVulns) > 0 {
+		total, summaries = summarize(r.severities, r.severityOrder, r.severityLabels, r.countSeverities(r.unknownVulns), nil)
+		r.printf("Hidden in dependencies with unknown relationships: %d (%s)\n", total, strings.Join(summaries, ", "))
+	}
+	if filtered := r.result.FilteredCounts; filtered != nil {
+		// Counted only with "--show-filtered-count"
+		r.printf("%s\n", filteredSummary(*filtered))
+	}
+	r.printf("\n")
 
-	tw.Render()
+	if r.groupBySeverity && !r.byInstruction {
+		r.renderSeverityGroups(vulns)
+	} else {
+		tw.Render()
+	}
+	renderOmitted(r.w, omitted)
 }
 
-func (r *vulnerabilityRenderer) setHeaders(tw *table.Table) {
-	if len(r.result.Vulnerabilities) == 0 {
-		return
-	}
+func (r *vulnerabilityRenderer) headers() []string {
 	header := []string{
 		"Library",
 		"Vulnerability",
 		"Severity",
 		"Status",
+	}
+	if r.vendorStatus {
+		header = append(header, "Vendor Status")
+	}
+	if r.sla {
+		header = append(header, "SLA")
+	}
+	if r.reachability {
+		header = append(header, "Reachable")
+	}
+	if r.epss {
+		header = append(header, "EPSS Score", "EPSS Percentile")
+	}
+	if r.kev {
+		header = append(header, "KEV")
+	}
+	if r.kevDue {
+		header = append(header, "KEV Due")
+	}
+	header = append(header,
 		"Installed Version",
 		"Fixed Version",
-		"Title",
+	)
+	if r.affectedRange {
+		header = append(header, "Affected Range")
 	}
-	tw.SetHeaders(header...)
+	if r.fixCommands {
+		header = append(header, "Fix Command")
+	}
+	if r.blastRadius {
+		header = append(header, "Dependents")
+	}
+	if r.layer {
+		header = append(header, "Layer")
+	}
+	if r.purl {
+		header = append(header, "PURL")
+	}
+	if r.labels {
+		header = append(header, "Labels")
+	}
+	if r.platforms {
+		header = append(header, "Platforms")
+	}
+	if r.baseline {
+		header = append(header, "Baseline")
+	}
+	return append(header, "Title")
+}
+
+// renderSeverityGroups renders the vulnerabilities in descending order of severity,
+// a table per severity group preceded by a line with the severity and the number of vulnerabilities.
+func (r *vulnerabilityRenderer) renderSeverityGroups(vulns []types.DetectedVulnerability) {
+	var rendered bool
+	for _, severity := range lo.Reverse(slices.Clone(orderOrDefault(r.severityOrder))) {
+		group := lo.Filter(vulns, func(v types.DetectedVulnerability, _ int) bool {
+			return v.Severity == severity
+		})
+		if len(group) == 0 {
+			continue
+		}
+		if rendered {
+			r.printf("\n")
+		}
+		rendered = true
+
+		label := fmt.Sprintf("─── %s (%d) ───", severityLabel(severity, r.severityLabels), len(group))
+		if r.isTerminal {
+			label = ColorizeSeverity(label, severity)
+		}
+		fmt.Fprintln(r.w, label)
+
+		tw := newTableWriter(r.w, r.isTerminal, !r.noCellMerge)
+		tw.SetHeaders(r.headers()...)
+		r.setVulnerabilityRows(tw, group)
+		tw.Render()
+	}
+}
+
+// setInstructionGroupedRows adds the vulnerabilities grouped by the Dockerfile instruction that created the layer
+// of the vulnerable package, inserting a subheader row with the instruction before each group.
+// Groups are rendered in the order they first appear, and packages without the layer history go to the "unknown" group at the end.
+func (r *vulnerabilityRenderer) setInstructionGroupedRows(tw *table.Table, vulns []types.DetectedVulnerability) {
+	// Layers are distinguished by their digests as well since the same instruction may create multiple layers
+	type layerKey struct {
+		diffID    string
+		createdBy string
+	}
+	var keys []layerKey
+	groups := make(map[layerKey][]types.DetectedVulnerability)
+	for _, v := range vulns {
+		key := layerKey{
+			diffID:    lo.CoalesceOrEmpty(v.Layer.DiffID, v.Layer.Digest),
+			createdBy: strings.TrimSpace(v.Layer.CreatedBy),
+		}
+		if key.createdBy == "" {
+			key = layerKey{}
+		}
+		if _, ok := groups[key]; !ok && key != (layerKey{}) {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], v)
+	}
+	if _, ok := groups[layerKey{}]; ok {
+		keys = append(keys, layerKey{})
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		label := fmt.Sprintf("─── %s (%d) ───", instructionLabel(key.createdBy), len(group))
+		subheader := make([]string, len(r.headers()))
+		subheader[0] = label
+		tw.AddRow(subheader...)
+
+		r.setVulnerabilityRows(tw, group)
+	}
+}
+
+// instructionLabel returns the Dockerfile instruction from the history of the layer,
+// e.g. "RUN apk add curl" for "/bin/sh -c apk add curl" and "COPY ./app /app" for "COPY ./app /app # buildkit".
+func instructionLabel(createdBy string) string {
+	var instruction string
+	switch {
+	case createdBy == "":
+		return "unknown"
+	case strings.HasPrefix(createdBy, "/bin/sh -c #(nop)"):
+		// Instruction other than RUN
+		instruction = strings.TrimPrefix(createdBy, "/bin/sh -c #(nop)")
+	case strings.HasPrefix(createdBy, "/bin/sh -c"):
+		// RUN instruction
+		instruction = "RUN" + strings.TrimPrefix(createdBy, "/bin/sh -c")
+	default:
+		// buildkit instructions, e.g. "RUN /bin/sh -c apk add curl # buildkit"
+		instruction = strings.TrimSuffix(createdBy, "# buildkit")
+		if strings.HasPrefix(instruction, "RUN /bin/sh -c") {
+			instruction = "RUN" + strings.TrimPrefix(instruction, "RUN /bin/sh -c")
+		}
+	}
+	instruction = strings.Join(strings.Fields(instruction), " ")
+	if r := []rune(instruction); len(r) > 60 {
+		// Too long. Truncate by rune so as not to split a multi-byte character.
+		instruction = string(r[:60]) + "..."
+	}
+	return instruction
 }
 
 func (r *vulnerabilityRenderer) setVulnerabilityRows(tw *table.Table, vulns []types.DetectedVulnerability) {
+	var graph *dependencyGraph
+	if r.blastRadius {
+		graph = r.graphs.graph(r.result.Packages)
+	}
+	if r.fixableFirst {
+		vulns = sortFixableFirst(vulns, r.severityOrder)
+	}
+
 	for _, v := range vulns {
 		lib := v.PkgName
 		if v.PkgPath != "" {
@@ -165,33 +547,260 @@ func (r *vulnerabilityRenderer) setVulnerabilityRows(tw *table.Table, vulns []ty
 			}
 		}
 
-		var row []string
+		severity := severityLabel(v.Severity, r.severityLabels)
 		if r.isTerminal {
-			row = []string{
-				lib,
-				v.VulnerabilityID,
-				ColorizeSeverity(v.Severity, v.Severity),
-				v.Status.String(),
-				v.InstalledVersion,
-				v.FixedVersion,
-				strings.TrimSpace(title),
-			}
-		} else {
-			row = []string{
-				lib,
-				v.VulnerabilityID,
-				v.Severity,
-				v.Status.String(),
-				v.InstalledVersion,
-				v.FixedVersion,
-				strings.TrimSpace(title),
-			}
+			severity = ColorizeSeverity(severity, v.Severity)
+		}
+		if r.conflicts {
+			severity = severityConflictLabel(severity, v)
 		}
 
+		status := v.Status.String()
+		if vexStatus, ok := r.vexStatuses[vexKey(v)]; ok {
+			status = vexStatus
+		}
+
+		row := []string{
+			lib,
+			v.VulnerabilityID,
+			severity,
+			status,
+		}
+		if r.vendorStatus {
+			row = append(row, vendorStatusLabel(v))
+		}
+		if r.sla {
+			row = append(row, r.slaLabel(v.SLAStatus))
+		}
+		if r.reachability {
+			row = append(row, reachabilityLabel(v.Reachability))
+		}
+		if r.epss {
+			row = append(row, epssLabels(v.EPSS)...)
+		}
+		if r.kev {
+			row = append(row, kevLabel(v.KEV))
+		}
+		if r.kevDue {
+			row = append(row, kevDueLabel(v.KEV))
+		}
+		fixedVersion := v.FixedVersion
+		if r.prerelease {
+			fixedVersion = annotatePrerelease(r.result.Type, fixedVersion)
+		}
+		row = append(row,
+			v.InstalledVersion,
+			fixedVersion,
+		)
+		if r.affectedRange {
+			row = append(row, v.AffectedRange)
+		}
+		if r.fixCommands {
+			row = append(row, fixCommand(r.result.Type, v.PkgName, v.InstalledVersion, v.FixedVersion))
+		}
+		if r.blastRadius {
+			row = append(row, dependentsLabel(graph, v.PkgID))
+		}
+		if r.layer {
+			row = append(row, layerLabel(v.Layer))
+		}
+		if r.purl {
+			row = append(row, purlLabel(v.PkgIdentifier))
+		}
+		if r.labels {
+			row = append(row, labelsLabel(v.Labels))
+		}
+		if r.platforms {
+			row = append(row, strings.Join(v.Platforms, "\n"))
+		}
+		if r.baseline {
+			row = append(row, r.baselineLabel(v.BaselineStatus))
+		}
+		row = append(row, strings.TrimSpace(title))
+
 		tw.AddRow(row...)
 	}
 }
 
+// sortFixableFirst returns the vulnerabilities with fixed versions first,
+// sorted in descending order of severity within fixable and unfixable ones.
+func sortFixableFirst(vulns []types.DetectedVulnerability, severityOrder []string) []types.DetectedVulnerability {
+	fixable := func(v types.DetectedVulnerability) int {
+		return lo.Ternary(v.FixedVersion != "", 1, 0)
+	}
+	order := orderOrDefault(severityOrder)
+	sorted := slices.Clone(vulns)
+	slices.SortStableFunc(sorted, func(a, b types.DetectedVulnerability) int {
+		return cmp.Or(
+			cmp.Compare(fixable(b), fixable(a)),
+			cmp.Compare(slices.Index(order, b.Severity), slices.Index(order, a.Severity)),
+		)
+	})
+	return sorted
+}
+
+// reachabilityLabel returns the value of the "Reachable" column.
+// "N/A" means no VEX document states the reachability.
+func reachabilityLabel(reachability types.Reachability) string {
+	switch reachability {
+	case types.ReachabilityReachable:
+		return "yes"
+	case types.ReachabilityUnreachable:
+		return "no"
+	case types.ReachabilityUnknown:
+		return "unknown"
+	default:
+		return "N/A"
+	}
+}
+
+// slaLabel returns the value of the "SLA" column, highlighting breaches in the terminal.
+// It is empty when the severity has no SLA or the published date is unknown.
+func (r *vulnerabilityRenderer) slaLabel(status types.SLAStatus) string {
+	if status == types.SLAStatusBreached && r.isTerminal {
+		return color.New(color.FgRed, color.Bold).Sprint(status)
+	}
+	return string(status)
+}
+
+// baselineLabel returns the value of the "Baseline" column, highlighting new vulnerabilities in the terminal.
+func (r *vulnerabilityRenderer) baselineLabel(status types.BaselineStatus) string {
+	if status == types.BaselineStatusNew && r.isTerminal {
+		return color.New(color.FgYellow, color.Bold).Sprint(status)
+	}
+	return string(status)
+}
+
+// dependentsLabel returns the value of the "Dependents" column.
+// "N/A" means the dependency graph is not available for the result or the package.
+func dependentsLabel(graph *dependencyGraph, pkgID string) string {
+	if len(graph.parents) == 0 || pkgID == "" {
+		return "N/A"
+	}
+	return strconv.Itoa(graph.dependents(pkgID))
+}
+
+// labelsLabel returns the value of the "Labels" column, with a "key=value" line per label sorted by key.
+func labelsLabel(labels map[string]string) string {
+	keys := lo.Keys(labels)
+	slices.Sort(keys)
+	return strings.Join(lo.Map(keys, func(k string, _ int) string {
+		return k + "=" + labels[k]
+	}), "\n")
+}
+
+// kevLabel returns the value of the "KEV" column, noting the use in ransomware campaigns.
+// It is blank when the CVE is not in the KEV catalog.
+func kevLabel(kev *types.KEV) string {
+	switch {
+	case kev == nil:
+		return ""
+	case kev.Ransomware:
+		return "yes (ransomware)"
+	default:
+		return "yes"
+	}
+}
+
+// kevDueLabel returns the value of the "KEV Due" column, i.e. the date by which CISA requires federal agencies
+// to remediate the CVE. It is blank when the CVE is not in the KEV catalog.
+func kevDueLabel(kev *types.KEV) string {
+	if kev == nil {
+		return ""
+	}
+	return kev.DueDate
+}
+
+// vendorStatusLabel returns the value of the "Vendor Status" column, i.e. the status in the advisory of the vendor,
+// e.g. Red Hat's fix state, with the vendor and its severity, e.g. "won't fix (Red Hat, LOW)",
+// so that vulnerabilities without patches can be distinguished from unpatched ones.
+// It is blank when the vulnerability doesn't come from a vendor advisory.
+func vendorStatusLabel(v types.DetectedVulnerability) string {
+	if v.DataSource == nil || v.DataSource.Name == "" {
+		return ""
+	}
+
+	var status string
+	switch v.Status {
+	case dbTypes.StatusFixed:
+		status = "patch available"
+	case dbTypes.StatusWillNotFix:
+		status = "won't fix"
+	case dbTypes.StatusFixDeferred:
+		status = "fix deferred"
+	case dbTypes.StatusUnderInvestigation:
+		status = "under investigation"
+	case dbTypes.StatusEndOfLife:
+		status = "end of life"
+	case dbTypes.StatusNotAffected:
+		status = "not affected"
+	default:
+		switch {
+		case v.FixedVersion != "":
+			// Some advisories have fixed versions without the status
+			status = "patch available"
+		case v.Status == dbTypes.StatusAffected:
+			status = "no patch"
+		default:
+			status = "unknown"
+		}
+	}
+
+	vendor := v.DataSource.Name
+	if severity, ok := v.VendorSeverity[v.DataSource.ID]; ok {
+		vendor += ", " + severity.String()
+	}
+	return fmt.Sprintf("%s (%s)", status, vendor)
+}
+
+// epssLabels returns the values of the "EPSS Score" and "EPSS Percentile" columns.
+// They are blank when the CVE is not in the EPSS dataset.
+func epssLabels(epss *types.EPSS) []string {
+	if epss == nil {
+		return []string{"", ""}
+	}
+	return []string{
+		strconv.FormatFloat(epss.Score, 'f', -1, 64),
+		strconv.FormatFloat(epss.Percentile, 'f', -1, 64),
+	}
+}
+
+// layerLabel returns the value of the "Layer" column.
+// The command that created the layer is preferred as it points to the Dockerfile instruction.
+// It is blank when the layer is unknown, e.g. filesystem scanning.
+func layerLabel(layer ftypes.Layer) string {
+	if c := strings.TrimSpace(layer.CreatedBy); c != "" {
+		if r := []rune(c); len(r) > 40 {
+			// Too long. Truncate by rune so as not to split a multi-byte character.
+			c = string(r[:40]) + "..."
+		}
+		return c
+	}
+
+	digest := layer.Digest
+	if digest == "" {
+		digest = layer.DiffID
+	}
+	digest = strings.TrimPrefix(digest, "sha256:")
+	if len(digest) > 12 {
+		digest = digest[:12]
+	}
+	return digest
+}
+
+// purlLabel returns the value of the "PURL" column.
+// Qualifiers are moved to the next line so that long PURLs don't widen the table too much.
+func purlLabel(pkgID ftypes.PkgIdentifier) string {
+	if pkgID.PURL == nil {
+		return ""
+	}
+	purl := pkgID.PURL.String()
+	if base, qualifiers, ok := strings.Cut(purl, "?"); ok {
+		return base + "\n?" + qualifiers
+	}
+	return purl
+}
+
 func (r *vulnerabilityRenderer) countSeverities(vulns []types.DetectedVulnerability) map[string]int {
 	severityCount := make(map[string]int)
 	for _, v := range vulns {
@@ -201,7 +810,7 @@ func (r *vulnerabilityRenderer) countSeverities(vulns []types.DetectedVulnerabil
 }
 
 func (r *vulnerabilityRenderer) renderModifiedVulnerabilities() {
-	tw := newTableWriter(r.w, r.isTerminal)
+	tw := newTableWriter(r.w, r.isTerminal, !r.noCellMerge)
 	header := []string{
 		"Library",
 		"Vulnerability",
@@ -214,7 +823,8 @@ func (r *vulnerabilityRenderer) renderModifiedVulnerabilities() {
 
 	var total int
 	for _, m := range r.result.ModifiedFindings {
-		if m.Type != types.FindingTypeVulnerability {
+		// VEX decisions are already rendered in the vulnerability table
+		if m.Type != types.FindingTypeVulnerability || (r.vexSuppressed && m.IsVEX()) {
 			continue
 		}
 		vuln := m.Finding.(types.DetectedVulnerability)
@@ -241,30 +851,31 @@ func (r *vulnerabilityRenderer) renderModifiedVulnerabilities() {
 }
 
 func (r *vulnerabilityRenderer) renderDependencyTree() {
+	// The tree of a few vulnerable packages adds nothing to the table
+	if n := len(r.pkgSeverityCount()); n < r.treeMinPackages {
+		log.Debug("Skipping the dependency tree as there are fewer vulnerable packages than '--tree-min-packages'",
+			log.String("target", r.result.Target), log.Int("packages", n), log.Int("min", r.treeMinPackages))
+		return
+	}
+
+	if r.treeDirection == TreeDirectionDown {
+		r.renderTopDownDependencyTree()
+		return
+	}
+
 	// Get parents of each dependency
-	parents := ftypes.Packages(r.result.Packages).ParentDeps()
+	graph := r.graphs.graph(r.result.Packages)
+	parents := graph.parents
 	if len(parents) == 0 {
 		return
 	}
-	ancestors := traverseAncestors(r.result.Packages, parents)
 
 	root := treeprint.NewWithRoot(fmt.Sprintf(`
 Dependency Origin Tree (Reversed)
 =================================
 %s`, r.result.Target))
 
-	// This count is next to the package ID.
-	// e.g. node-fetch@1.7.3 (MEDIUM: 2, HIGH: 1, CRITICAL: 3)
-	pkgSeverityCount := make(map[string]map[string]int)
-	for _, vuln := range r.result.Vulnerabilities {
-		cnts, ok := pkgSeverityCount[vuln.PkgID]
-		if !ok {
-			cnts = make(map[string]int)
-		}
-
-		cnts[vuln.Severity]++
-		pkgSeverityCount[vuln.PkgID] = cnts
-	}
+	pkgSeverityCount := r.pkgSeverityCount()
 
 	// Extract vulnerable packages
 	vulnPkgs := lo.Filter(r.result.Packages, func(pkg ftypes.Package, _ int) bool {
@@ -275,16 +886,152 @@ Dependency Origin Tree (Reversed)
 
 	// Render tree
 	for _, vulnPkg := range vulnPkgs {
-		_, summaries := summarize(r.severities, pkgSeverityCount[vulnPkg.ID])
-		topLvlID := tml.Sprintf("<red>%s, (%s)</red>", vulnPkg.ID, strings.Join(summaries, ", "))
+		branch := root.AddBranch(r.vulnerableNode(vulnPkg.ID, pkgSeverityCount[vulnPkg.ID]))
+		if r.shortestPath {
+			// Only the shortest chain of parents up to a direct dependency, without omitting intermediate dependencies
+			for _, parentID := range graph.shortestPath(vulnPkg)[1:] {
+				branch = branch.AddBranch(parentID)
+			}
+			continue
+		}
+		addParents(branch, vulnPkg, parents, graph.ancestors(), map[string]struct{}{vulnPkg.ID: {}}, 1)
+
+	}
+	r.printf(root.String())
+}
 
-		branch := root.AddBranch(topLvlID)
-		addParents(branch, vulnPkg, parents, ancestors, map[string]struct{}{vulnPkg.ID: {}}, 1)
+// renderTopDownDependencyTree renders the dependency tree from direct dependencies.
+// Only the branches leading to vulnerable packages are rendered.
+func (r *vulnerabilityRenderer) renderTopDownDependencyTree() {
+	graph := r.graphs.graph(r.result.Packages)
+	parents := graph.parents
+	if len(parents) == 0 {
+		return
+	}
+	pkgSeverityCount := r.pkgSeverityCount()
+
+	root := treeprint.NewWithRoot(fmt.Sprintf(`
+Dependency Tree
+===============
+%s`, r.result.Target))
 
+	if r.shortestPath {
+		r.addShortestPaths(root, graph, pkgSeverityCount)
+		r.printf(root.String())
+		return
+	}
+
+	// Collect vulnerable packages and the packages depending on them
+	onPath := make(map[string]struct{})
+	queue := lo.Keys(pkgSeverityCount)
+	for len(queue) > 0 {
+		pkgID := queue[0]
+		queue = queue[1:]
+		if _, ok := onPath[pkgID]; ok {
+			continue
+		}
+		onPath[pkgID] = struct{}{}
+		for _, parent := range parents[pkgID] {
+			queue = append(queue, parent.ID)
+		}
+	}
+
+	pkgs := lo.SliceToMap(r.result.Packages, func(pkg ftypes.Package) (string, ftypes.Package) {
+		return pkg.ID, pkg
+	})
+	for _, pkg := range r.result.Packages {
+		if _, ok := onPath[pkg.ID]; !ok {
+			continue
+		}
+		// Some package managers, such as "package-lock.json" v1, can retrieve package dependencies but not relationships.
+		// A dependency with no parents is regarded as a direct dependency in this case.
+		if pkg.Relationship != ftypes.RelationshipDirect && (pkg.Relationship == ftypes.RelationshipRoot || len(parents[pkg.ID]) > 0) {
+			continue
+		}
+		branch := root.AddBranch(r.dependencyNode(pkg.ID, pkgSeverityCount))
+		r.addDependencies(branch, pkg, pkgs, onPath, pkgSeverityCount, map[string]struct{}{pkg.ID: {}})
 	}
 	r.printf(root.String())
 }
 
+func (r *vulnerabilityRenderer) addDependencies(branch treeprint.Tree, pkg ftypes.Package, pkgs map[string]ftypes.Package,
+	onPath map[string]struct{}, pkgSeverityCount map[string]map[string]int, seen map[string]struct{}) {
+	dependsOn := slices.Clone(pkg.DependsOn)
+	sort.Strings(dependsOn)
+	for _, dep := range dependsOn {
+		if _, ok := onPath[dep]; !ok {
+			continue
+		}
+		if _, ok := seen[dep]; ok {
+			continue // to avoid infinite loops
+		}
+
+		seen[dep] = struct{}{}
+		child := branch.AddBranch(r.dependencyNode(dep, pkgSeverityCount))
+		r.addDependencies(child, pkgs[dep], pkgs, onPath, pkgSeverityCount, seen)
+		delete(seen, dep)
+	}
+}
+
+// addShortestPaths renders only the shortest path from a direct dependency to each vulnerable package.
+// Paths sharing the same direct and intermediate dependencies are merged into one branch.
+func (r *vulnerabilityRenderer) addShortestPaths(root treeprint.Tree, graph *dependencyGraph, pkgSeverityCount map[string]map[string]int) {
+	var paths [][]string
+	for _, pkg := range r.result.Packages {
+		if _, ok := pkgSeverityCount[pkg.ID]; !ok {
+			continue
+		}
+		path := graph.shortestPath(pkg)
+		slices.Reverse(path)
+		paths = append(paths, path)
+	}
+	slices.SortFunc(paths, slices.Compare)
+
+	// Branches keyed by the path from the direct dependency
+	branches := make(map[string]treeprint.Tree)
+	for _, path := range paths {
+		branch := root
+		for i, pkgID := range path {
+			key := strings.Join(path[:i+1], " > ")
+			child, ok := branches[key]
+			if !ok {
+				child = branch.AddBranch(r.dependencyNode(pkgID, pkgSeverityCount))
+				branches[key] = child
+			}
+			branch = child
+		}
+	}
+}
+
+// pkgSeverityCount returns the number of vulnerabilities per severity for each package ID.
+func (r *vulnerabilityRenderer) pkgSeverityCount() map[string]map[string]int {
+	// This count is next to the package ID.
+	// e.g. node-fetch@1.7.3 (MEDIUM: 2, HIGH: 1, CRITICAL: 3)
+	pkgSeverityCount := make(map[string]map[string]int)
+	for _, vuln := range r.result.Vulnerabilities {
+		cnts, ok := pkgSeverityCount[vuln.PkgID]
+		if !ok {
+			cnts = make(map[string]int)
+		}
+
+		cnts[vuln.Severity]++
+		pkgSeverityCount[vuln.PkgID] = cnts
+	}
+	return pkgSeverityCount
+}
+
+func (r *vulnerabilityRenderer) dependencyNode(pkgID string, pkgSeverityCount map[string]map[string]int) string {
+	if cnts, ok := pkgSeverityCount[pkgID]; ok {
+		return r.vulnerableNode(pkgID, cnts)
+	}
+	return pkgID
+}
+
+func (r *vulnerabilityRenderer) vulnerableNode(pkgID string, cnts map[string]int) string {
+	_, summaries := summarize(r.severities, r.severityOrder, r.severityLabels, cnts, nil)
+	return tml.Sprintf("<red>%s, (%s)</red>", pkgID, strings.Join(summaries, ", "))
+}
+
 func (r *vulnerabilityRenderer) printf(format string, args ...any) {
 	// nolint
 	_ = tml.Fprintf(r.w, format, args...)
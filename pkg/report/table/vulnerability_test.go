@@ -1,9 +1,17 @@
 package table_test
 
 import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 	"testing"
+	"unicode/utf8"
 
+	"github.com/fatih/color"
+	"github.com/package-url/packageurl-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
@@ -18,6 +26,30 @@ func Test_vulnerabilityRenderer_Render(t *testing.T) {
 		want               string
 		includeNonFailures bool
 		showSuppressed     bool
+		showVEXSuppressed  bool
+		maxRows            int
+		reachability       bool
+		groupBySeverity    bool
+		groupByInstruction bool
+		showLayer          bool
+		showPURL           bool
+		showEPSS           bool
+		showKEV            bool
+		showKEVDue         bool
+		showVendorStatus   bool
+		showAffectedRange  bool
+		showLabels         bool
+		showSLA            bool
+		showBlastRadius    bool
+		directOnly         bool
+		showFixCommand     bool
+		flagPrerelease     bool
+		severityConflicts  bool
+		severityOrder      []string
+		severityLabels     map[string]string
+		treeDirection      string
+		treeMinPackages    int
+		shortestPath       bool
 	}{
 		{
 			name: "happy path full",
@@ -99,72 +131,1744 @@ Total: 1 (MEDIUM: 0, HIGH: 1)
 `,
 		},
 		{
-			name: "no title for vuln and missing primary link",
+			name: "happy path with max rows",
 			result: types.Result{
 				Target: "test",
 				Class:  types.ClassLangPkg,
 				Vulnerabilities: []types.DetectedVulnerability{
 					{
 						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "bar",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "MEDIUM",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0002",
 						PkgName:          "foo",
 						InstalledVersion: "1.2.3",
-						FixedVersion:     "3.4.5",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobaz",
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			maxRows: 1,
+			want: `
+test ()
+=======
+Total: 2 (MEDIUM: 1, HIGH: 1)
+
+┌─────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │ Title  │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼────────┤
+│ bar     │ CVE-2020-0001 │ MEDIUM   │ affected │ 1.2.3             │               │ foobar │
+└─────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴────────┘
+... 1 more rows omitted (use --format json for all)
+`,
+		},
+		{
+			name: "happy path with severity groups",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "MEDIUM",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0002",
+						PkgName:          "bar",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobaz",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0003",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobaz",
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			groupBySeverity: true,
+			want: `
+test ()
+=======
+Total: 3 (MEDIUM: 1, HIGH: 2)
+
+─── HIGH (2) ───
+┌─────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │ Title  │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼────────┤
+│ bar     │ CVE-2020-0002 │ HIGH     │ affected │ 1.2.3             │               │ foobaz │
+├─────────┼───────────────┤          │          │                   ├───────────────┤        │
+│ foo     │ CVE-2020-0003 │          │          │                   │               │        │
+└─────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴────────┘
+
+─── MEDIUM (1) ───
+┌─────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │ Title  │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼────────┤
+│ foo     │ CVE-2020-0001 │ MEDIUM   │ affected │ 1.2.3             │               │ foobar │
+└─────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴────────┘
+`,
+		},
+		{
+			name: "happy path with group by instruction",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "curl",
+						InstalledVersion: "8.5.0-r0",
+						FixedVersion:     "8.9.0-r0",
 						Status:           dbTypes.StatusFixed,
+						Layer: ftypes.Layer{
+							DiffID:    "sha256:5f70bf18a086007016e948b04aed3b82103a36bea41755b6cddfaf10ace3c6ef",
+							CreatedBy: "/bin/sh -c apk add --no-cache curl",
+						},
 						Vulnerability: dbTypes.Vulnerability{
-							Description: "foobar",
-							Severity:    "HIGH",
+							Title:    "curl: foo",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0002",
+						PkgName:          "lodash",
+						InstalledVersion: "4.17.20",
+						FixedVersion:     "4.17.21",
+						Status:           dbTypes.StatusFixed,
+						Layer: ftypes.Layer{
+							DiffID:    "sha256:beee9f30bc1f711043e78d4a2be0668955d4b761d587d6f60c2c8dc081efb203",
+							CreatedBy: "COPY ./app /app # buildkit",
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "lodash: bar",
+							Severity: "MEDIUM",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "libcurl",
+						InstalledVersion: "8.5.0-r0",
+						FixedVersion:     "8.9.0-r0",
+						Status:           dbTypes.StatusFixed,
+						Layer: ftypes.Layer{
+							DiffID:    "sha256:5f70bf18a086007016e948b04aed3b82103a36bea41755b6cddfaf10ace3c6ef",
+							CreatedBy: "/bin/sh -c apk add --no-cache curl",
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "curl: foo",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0003",
+						PkgName:          "musl",
+						InstalledVersion: "1.2.4-r2",
+						Status:           dbTypes.StatusAffected,
+						// No layer history
+						Layer: ftypes.Layer{
+							DiffID: "sha256:d4fc045c9e3a848011de66f34b81f052d4f2c15a17bb196d637e526349601820",
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "musl: baz",
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			groupByInstruction: true,
+			want: `
+test ()
+=======
+Total: 4 (MEDIUM: 1, HIGH: 3)
+
+┌─────────────────────────────────────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬─────────────┐
+│                 Library                 │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │    Title    │
+├─────────────────────────────────────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼─────────────┤
+│ ─── RUN apk add --no-cache curl (2) ─── │               │          │          │                   │               │             │
+├─────────────────────────────────────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼─────────────┤
+│ curl                                    │ CVE-2020-0001 │ HIGH     │ fixed    │ 8.5.0-r0          │ 8.9.0-r0      │ curl: foo   │
+├─────────────────────────────────────────┤               │          │          │                   │               │             │
+│ libcurl                                 │               │          │          │                   │               │             │
+├─────────────────────────────────────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼─────────────┤
+│ ─── COPY ./app /app (1) ───             │               │          │          │                   │               │             │
+├─────────────────────────────────────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼─────────────┤
+│ lodash                                  │ CVE-2020-0002 │ MEDIUM   │ fixed    │ 4.17.20           │ 4.17.21       │ lodash: bar │
+├─────────────────────────────────────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼─────────────┤
+│ ─── unknown (1) ───                     │               │          │          │                   │               │             │
+├─────────────────────────────────────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼─────────────┤
+│ musl                                    │ CVE-2020-0003 │ HIGH     │ affected │ 1.2.4-r2          │               │ musl: baz   │
+└─────────────────────────────────────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴─────────────┘
+`,
+		},
+		{
+			name: "group by instruction with a long non-ASCII instruction",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "curl",
+						InstalledVersion: "8.5.0-r0",
+						FixedVersion:     "8.9.0-r0",
+						Status:           dbTypes.StatusFixed,
+						Layer: ftypes.Layer{
+							DiffID:    "sha256:5f70bf18a086007016e948b04aed3b82103a36bea41755b6cddfaf10ace3c6ef",
+							CreatedBy: "/bin/sh -c echo " + strings.Repeat("é", 60),
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "curl: foo",
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			groupByInstruction: true,
+			want: `
+test ()
+=======
+Total: 1 (MEDIUM: 0, HIGH: 1)
+
+┌────────────────────────────────────────────────────────────┬───────────────┬──────────┬────────┬───────────────────┬───────────────┬───────────┐
+│                          Library                           │ Vulnerability │ Severity │ Status │ Installed Version │ Fixed Version │   Title   │
+├────────────────────────────────────────────────────────────┼───────────────┼──────────┼────────┼───────────────────┼───────────────┼───────────┤
+│ ─── RUN echo                                               │               │          │        │                   │               │           │
+│ ééééééééééééééééééééééééééééééééééééééééééééééééééé... (1) │               │          │        │                   │               │           │
+│ ───                                                        │               │          │        │                   │               │           │
+├────────────────────────────────────────────────────────────┼───────────────┼──────────┼────────┼───────────────────┼───────────────┼───────────┤
+│ curl                                                       │ CVE-2020-0001 │ HIGH     │ fixed  │ 8.5.0-r0          │ 8.9.0-r0      │ curl: foo │
+└────────────────────────────────────────────────────────────┴───────────────┴──────────┴────────┴───────────────────┴───────────────┴───────────┘
+`,
+		},
+		{
+			name: "custom severity order",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "MEDIUM",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0002",
+						PkgName:          "bar",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobaz",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0003",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobaz",
+							Severity: "HIGH",
 						},
 					},
 				},
 			},
+			groupBySeverity: true,
+			severityOrder: []string{
+				"UNKNOWN",
+				"LOW",
+				"HIGH",
+				"MEDIUM",
+				"CRITICAL",
+			},
 			want: `
 test ()
 =======
+Total: 3 (HIGH: 2, MEDIUM: 1)
+
+─── MEDIUM (1) ───
+┌─────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │ Title  │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼────────┤
+│ foo     │ CVE-2020-0001 │ MEDIUM   │ affected │ 1.2.3             │               │ foobar │
+└─────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴────────┘
+
+─── HIGH (2) ───
+┌─────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │ Title  │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼────────┤
+│ bar     │ CVE-2020-0002 │ HIGH     │ affected │ 1.2.3             │               │ foobaz │
+├─────────┼───────────────┤          │          │                   ├───────────────┤        │
+│ foo     │ CVE-2020-0003 │          │          │                   │               │        │
+└─────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴────────┘
+`,
+		},
+		{
+			name: "happy path with filtered count",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassOSPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						FixedVersion:     "1.2.4",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+				},
+				FilteredCounts: &types.FilteredCounts{
+					Severity:   3,
+					Status:     2,
+					IgnoreFile: 1,
+				},
+			},
+			want: `
+test
+====
 Total: 1 (MEDIUM: 0, HIGH: 1)
+Filtered: 6 (by severity: 3, by ignore: 1, unfixed: 2)
+
+┌─────────┬───────────────┬──────────┬────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability │ Severity │ Status │ Installed Version │ Fixed Version │ Title  │
+├─────────┼───────────────┼──────────┼────────┼───────────────────┼───────────────┼────────┤
+│ foo     │ CVE-2020-0001 │ HIGH     │ fixed  │ 1.2.3             │ 1.2.4         │ foobar │
+└─────────┴───────────────┴──────────┴────────┴───────────────────┴───────────────┴────────┘
+`,
+		},
+		{
+			name: "happy path with trend",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassOSPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						FixedVersion:     "1.2.4",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+				},
+				PreviousCounts: map[string]int{
+					"MEDIUM": 2,
+				},
+			},
+			want: `
+test
+====
+Total: 1 (MEDIUM: 0 (▼2), HIGH: 1 (▲1))
+
+┌─────────┬───────────────┬──────────┬────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability │ Severity │ Status │ Installed Version │ Fixed Version │ Title  │
+├─────────┼───────────────┼──────────┼────────┼───────────────────┼───────────────┼────────┤
+│ foo     │ CVE-2020-0001 │ HIGH     │ fixed  │ 1.2.3             │ 1.2.4         │ foobar │
+└─────────┴───────────────┴──────────┴────────┴───────────────────┴───────────────┴────────┘
+`,
+		},
+		{
+			name: "happy path with vendor status",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassOSPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						FixedVersion:     "1.2.4",
+						Status:           dbTypes.StatusFixed,
+						DataSource: &dbTypes.DataSource{
+							ID:   "redhat",
+							Name: "Red Hat",
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0002",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						DataSource: &dbTypes.DataSource{
+							ID:   "redhat",
+							Name: "Red Hat",
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0003",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusWillNotFix,
+						DataSource: &dbTypes.DataSource{
+							ID:   "redhat",
+							Name: "Red Hat",
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+							VendorSeverity: dbTypes.VendorSeverity{
+								"redhat": dbTypes.SeverityLow,
+							},
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0004",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusUnderInvestigation,
+						DataSource: &dbTypes.DataSource{
+							ID:   "redhat",
+							Name: "Red Hat",
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						// Not from a vendor advisory
+						VulnerabilityID:  "CVE-2020-0005",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusFixDeferred,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			showVendorStatus: true,
+			want: `
+test
+====
+Total: 5 (MEDIUM: 0, HIGH: 5)
+
+┌─────────┬───────────────┬──────────┬─────────────────────┬───────────────────────────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability │ Severity │       Status        │         Vendor Status         │ Installed Version │ Fixed Version │ Title  │
+├─────────┼───────────────┼──────────┼─────────────────────┼───────────────────────────────┼───────────────────┼───────────────┼────────┤
+│ foo     │ CVE-2020-0001 │ HIGH     │ fixed               │ patch available (Red Hat)     │ 1.2.3             │ 1.2.4         │ foobar │
+│         ├───────────────┤          ├─────────────────────┼───────────────────────────────┤                   ├───────────────┤        │
+│         │ CVE-2020-0002 │          │ affected            │ no patch (Red Hat)            │                   │               │        │
+│         ├───────────────┤          ├─────────────────────┼───────────────────────────────┤                   ├───────────────┤        │
+│         │ CVE-2020-0003 │          │ will_not_fix        │ won't fix (Red Hat, LOW)      │                   │               │        │
+│         ├───────────────┤          ├─────────────────────┼───────────────────────────────┤                   ├───────────────┤        │
+│         │ CVE-2020-0004 │          │ under_investigation │ under investigation (Red Hat) │                   │               │        │
+│         ├───────────────┤          ├─────────────────────┼───────────────────────────────┤                   ├───────────────┤        │
+│         │ CVE-2020-0005 │          │ fix_deferred        │                               │                   │               │        │
+└─────────┴───────────────┴──────────┴─────────────────────┴───────────────────────────────┴───────────────────┴───────────────┴────────┘
+`,
+		},
+		{
+			name: "happy path with severity labels",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.Jar,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "MEDIUM",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0002",
+						PkgName:          "bar",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobaz",
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			groupBySeverity: true,
+			severityLabels: map[string]string{
+				"HIGH":   "H",
+				"MEDIUM": "M",
+			},
+			want: `
+test (jar)
+==========
+Total: 2 (M: 1, H: 1)
+
+─── H (1) ───
+┌─────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │ Title  │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼────────┤
+│ bar     │ CVE-2020-0002 │ H        │ affected │ 1.2.3             │               │ foobaz │
+└─────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴────────┘
+
+─── M (1) ───
+┌─────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │ Title  │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼────────┤
+│ foo     │ CVE-2020-0001 │ M        │ affected │ 1.2.3             │               │ foobar │
+└─────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴────────┘
+`,
+		},
+		{
+			name: "happy path with PURLs",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassOSPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "openssl",
+						InstalledVersion: "1.1.1k-7.el8",
+						Status:           dbTypes.StatusAffected,
+						PkgIdentifier: ftypes.PkgIdentifier{
+							PURL: &packageurl.PackageURL{
+								Type:      packageurl.TypeRPM,
+								Namespace: "redhat",
+								Name:      "openssl",
+								Version:   "1.1.1k-7.el8",
+								Qualifiers: packageurl.Qualifiers{
+									{
+										Key:   "arch",
+										Value: "x86_64",
+									},
+								},
+							},
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0002",
+						PkgName:          "zlib",
+						InstalledVersion: "1.2.11",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobaz",
+							Severity: "MEDIUM",
+						},
+					},
+				},
+			},
+			showPURL: true,
+			want: `
+test
+====
+Total: 2 (MEDIUM: 1, HIGH: 1)
+
+┌─────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬─────────────────────────────────────┬────────┐
+│ Library │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │                PURL                 │ Title  │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼─────────────────────────────────────┼────────┤
+│ openssl │ CVE-2020-0001 │ HIGH     │ affected │ 1.1.1k-7.el8      │               │ pkg:rpm/redhat/openssl@1.1.1k-7.el8 │ foobar │
+│         │               │          │          │                   │               │ ?arch=x86_64                        │        │
+├─────────┼───────────────┼──────────┤          ├───────────────────┼───────────────┼─────────────────────────────────────┼────────┤
+│ zlib    │ CVE-2020-0002 │ MEDIUM   │          │ 1.2.11            │               │                                     │ foobaz │
+└─────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴─────────────────────────────────────┴────────┘
+`,
+		},
+		{
+			name: "happy path with EPSS",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassOSPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2021-44228",
+						PkgName:          "openssl",
+						InstalledVersion: "1.1.1k-7.el8",
+						Status:           dbTypes.StatusAffected,
+						EPSS: &types.EPSS{
+							Score:      0.97565,
+							Percentile: 0.99996,
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0002",
+						PkgName:          "zlib",
+						InstalledVersion: "1.2.11",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobaz",
+							Severity: "MEDIUM",
+						},
+					},
+				},
+			},
+			showEPSS: true,
+			want: `
+test
+====
+Total: 2 (MEDIUM: 1, HIGH: 1)
+
+┌─────────┬────────────────┬──────────┬──────────┬────────────┬─────────────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability  │ Severity │  Status  │ EPSS Score │ EPSS Percentile │ Installed Version │ Fixed Version │ Title  │
+├─────────┼────────────────┼──────────┼──────────┼────────────┼─────────────────┼───────────────────┼───────────────┼────────┤
+│ openssl │ CVE-2021-44228 │ HIGH     │ affected │ 0.97565    │ 0.99996         │ 1.1.1k-7.el8      │               │ foobar │
+├─────────┼────────────────┼──────────┤          ├────────────┼─────────────────┼───────────────────┼───────────────┼────────┤
+│ zlib    │ CVE-2020-0002  │ MEDIUM   │          │            │                 │ 1.2.11            │               │ foobaz │
+└─────────┴────────────────┴──────────┴──────────┴────────────┴─────────────────┴───────────────────┴───────────────┴────────┘
+`,
+		},
+		{
+			name: "happy path with KEV",
+			result: types.Result{
+				Target: "pom.xml",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.Pom,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2021-44228",
+						PkgName:          "org.apache.logging.log4j:log4j-core",
+						InstalledVersion: "2.14.1",
+						FixedVersion:     "2.15.0",
+						Status:           dbTypes.StatusFixed,
+						KEV: &types.KEV{
+							DateAdded:  "2021-12-10",
+							DueDate:    "2021-12-24",
+							Ransomware: true,
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2022-22965",
+						PkgName:          "org.springframework:spring-beans",
+						InstalledVersion: "5.3.17",
+						FixedVersion:     "5.3.18",
+						Status:           dbTypes.StatusFixed,
+						KEV: &types.KEV{
+							DateAdded: "2022-04-04",
+							DueDate:   "2022-04-25",
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobaz",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0002",
+						PkgName:          "org.yaml:snakeyaml",
+						InstalledVersion: "1.26",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobaz",
+							Severity: "MEDIUM",
+						},
+					},
+				},
+			},
+			showKEV: true,
+			want: `
+pom.xml (pom)
+=============
+Total: 3 (MEDIUM: 1, HIGH: 2)
+
+┌─────────────────────────────────────┬────────────────┬──────────┬──────────┬──────────────────┬───────────────────┬───────────────┬────────┐
+│               Library               │ Vulnerability  │ Severity │  Status  │       KEV        │ Installed Version │ Fixed Version │ Title  │
+├─────────────────────────────────────┼────────────────┼──────────┼──────────┼──────────────────┼───────────────────┼───────────────┼────────┤
+│ org.apache.logging.log4j:log4j-core │ CVE-2021-44228 │ HIGH     │ fixed    │ yes (ransomware) │ 2.14.1            │ 2.15.0        │ foobar │
+├─────────────────────────────────────┼────────────────┤          │          ├──────────────────┼───────────────────┼───────────────┼────────┤
+│ org.springframework:spring-beans    │ CVE-2022-22965 │          │          │ yes              │ 5.3.17            │ 5.3.18        │ foobaz │
+├─────────────────────────────────────┼────────────────┼──────────┼──────────┼──────────────────┼───────────────────┼───────────────┤        │
+│ org.yaml:snakeyaml                  │ CVE-2020-0002  │ MEDIUM   │ affected │                  │ 1.26              │               │        │
+└─────────────────────────────────────┴────────────────┴──────────┴──────────┴──────────────────┴───────────────────┴───────────────┴────────┘
+`,
+		},
+		{
+			name: "happy path with KEV due date",
+			result: types.Result{
+				Target: "pom.xml",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.Pom,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2022-22965",
+						PkgName:          "org.springframework:spring-beans",
+						InstalledVersion: "5.3.17",
+						FixedVersion:     "5.3.18",
+						Status:           dbTypes.StatusFixed,
+						KEV: &types.KEV{
+							DateAdded: "2022-04-04",
+							DueDate:   "2022-04-25",
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobaz",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0002",
+						PkgName:          "org.yaml:snakeyaml",
+						InstalledVersion: "1.26",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobaz",
+							Severity: "MEDIUM",
+						},
+					},
+				},
+			},
+			showKEV:    true,
+			showKEVDue: true,
+			want: `
+pom.xml (pom)
+=============
+Total: 2 (MEDIUM: 1, HIGH: 1)
+
+┌──────────────────────────────────┬────────────────┬──────────┬──────────┬─────┬────────────┬───────────────────┬───────────────┬────────┐
+│             Library              │ Vulnerability  │ Severity │  Status  │ KEV │  KEV Due   │ Installed Version │ Fixed Version │ Title  │
+├──────────────────────────────────┼────────────────┼──────────┼──────────┼─────┼────────────┼───────────────────┼───────────────┼────────┤
+│ org.springframework:spring-beans │ CVE-2022-22965 │ HIGH     │ fixed    │ yes │ 2022-04-25 │ 5.3.17            │ 5.3.18        │ foobaz │
+├──────────────────────────────────┼────────────────┼──────────┼──────────┼─────┼────────────┼───────────────────┼───────────────┤        │
+│ org.yaml:snakeyaml               │ CVE-2020-0002  │ MEDIUM   │ affected │     │            │ 1.26              │               │        │
+└──────────────────────────────────┴────────────────┴──────────┴──────────┴─────┴────────────┴───────────────────┴───────────────┴────────┘
+`,
+		},
+		{
+			name: "happy path with affected range",
+			result: types.Result{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.Npm,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2021-23337",
+						PkgName:          "lodash",
+						InstalledVersion: "4.17.20",
+						FixedVersion:     "4.17.21",
+						Status:           dbTypes.StatusFixed,
+						AffectedRange:    ">=4.0.0, <4.17.21",
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0002",
+						PkgName:          "minimist",
+						InstalledVersion: "1.2.5",
+						Status:           dbTypes.StatusAffected,
+						AffectedRange:    "1.2.5, 1.2.6",
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobaz",
+							Severity: "MEDIUM",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0003",
+						PkgName:          "qs",
+						InstalledVersion: "6.5.2",
+						FixedVersion:     "6.5.3",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "no range",
+							Severity: "MEDIUM",
+						},
+					},
+				},
+			},
+			showAffectedRange: true,
+			want: `
+package-lock.json (npm)
+=======================
+Total: 3 (MEDIUM: 2, HIGH: 1)
+
+┌──────────┬────────────────┬──────────┬──────────┬───────────────────┬───────────────┬───────────────────┬──────────┐
+│ Library  │ Vulnerability  │ Severity │  Status  │ Installed Version │ Fixed Version │  Affected Range   │  Title   │
+├──────────┼────────────────┼──────────┼──────────┼───────────────────┼───────────────┼───────────────────┼──────────┤
+│ lodash   │ CVE-2021-23337 │ HIGH     │ fixed    │ 4.17.20           │ 4.17.21       │ >=4.0.0, <4.17.21 │ foobar   │
+├──────────┼────────────────┼──────────┼──────────┼───────────────────┼───────────────┼───────────────────┼──────────┤
+│ minimist │ CVE-2020-0002  │ MEDIUM   │ affected │ 1.2.5             │               │ 1.2.5, 1.2.6      │ foobaz   │
+├──────────┼────────────────┤          ├──────────┼───────────────────┼───────────────┼───────────────────┼──────────┤
+│ qs       │ CVE-2020-0003  │          │ fixed    │ 6.5.2             │ 6.5.3         │                   │ no range │
+└──────────┴────────────────┴──────────┴──────────┴───────────────────┴───────────────┴───────────────────┴──────────┘
+`,
+		},
+		{
+			name: "happy path with labels",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassOSPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						FixedVersion:     "1.2.4",
+						Status:           dbTypes.StatusAffected,
+						Labels: map[string]string{
+							"team": "payment",
+							"sla":  "7d",
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			showLabels: true,
+			want: `
+test
+====
+Total: 1 (MEDIUM: 0, HIGH: 1)
+
+┌─────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬──────────────┬────────┐
+│ Library │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │    Labels    │ Title  │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼──────────────┼────────┤
+│ foo     │ CVE-2020-0001 │ HIGH     │ affected │ 1.2.3             │ 1.2.4         │ sla=7d       │ foobar │
+│         │               │          │          │                   │               │ team=payment │        │
+└─────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴──────────────┴────────┘
+`,
+		},
+		{
+			name: "happy path with layers",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "bar",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Layer: ftypes.Layer{
+							DiffID: "sha256:beee9f30bc1f711043e78d4a2be0668955d4b761d587d6f60c2c8dc081efb203",
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0002",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Layer: ftypes.Layer{
+							DiffID:    "sha256:a6a7d6d3c1c5e1b9a0a4f2e5b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2",
+							CreatedBy: "COPY app.jar /app.jar",
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0003",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			showLayer: true,
+			want: `
+test ()
+=======
+Total: 3 (MEDIUM: 0, HIGH: 3)
+
+┌─────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬───────────────────────┬────────┐
+│ Library │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │         Layer         │ Title  │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼───────────────────────┼────────┤
+│ bar     │ CVE-2020-0001 │ HIGH     │ affected │ 1.2.3             │               │ beee9f30bc1f          │ foobar │
+├─────────┼───────────────┤          │          │                   ├───────────────┼───────────────────────┤        │
+│ foo     │ CVE-2020-0002 │          │          │                   │               │ COPY app.jar /app.jar │        │
+│         ├───────────────┤          │          │                   ├───────────────┼───────────────────────┤        │
+│         │ CVE-2020-0003 │          │          │                   │               │                       │        │
+└─────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴───────────────────────┴────────┘
+`,
+		},
+		{
+			name: "layer with a long instruction",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Layer: ftypes.Layer{
+							CreatedBy: "RUN echo 'デフォルトのユーザーを設定しています' > /etc/motd",
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			showLayer: true,
+			want: `
+test ()
+=======
+Total: 1 (MEDIUM: 0, HIGH: 1)
+
+┌─────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬───────────────────────────────────────────────────┬────────┐
+│ Library │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │                       Layer                       │ Title  │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼───────────────────────────────────────────────────┼────────┤
+│ foo     │ CVE-2020-0001 │ HIGH     │ affected │ 1.2.3             │               │ RUN echo 'デフォルトのユーザーを設定しています' > │ foobar │
+│         │               │          │          │                   │               │ /etc/mot...                                       │        │
+└─────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴───────────────────────────────────────────────────┴────────┘
+`,
+		},
+		{
+			name: "happy path with reachability",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Reachability:     types.ReachabilityReachable,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0002",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Reachability:     types.ReachabilityUnreachable,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0003",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Reachability:     types.ReachabilityUnknown,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0004",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			reachability: true,
+			want: `
+test ()
+=======
+Total: 4 (MEDIUM: 0, HIGH: 4)
+
+┌─────────┬───────────────┬──────────┬──────────┬───────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability │ Severity │  Status  │ Reachable │ Installed Version │ Fixed Version │ Title  │
+├─────────┼───────────────┼──────────┼──────────┼───────────┼───────────────────┼───────────────┼────────┤
+│ foo     │ CVE-2020-0001 │ HIGH     │ affected │ yes       │ 1.2.3             │               │ foobar │
+│         ├───────────────┤          │          ├───────────┤                   ├───────────────┤        │
+│         │ CVE-2020-0002 │          │          │ no        │                   │               │        │
+│         ├───────────────┤          │          ├───────────┤                   ├───────────────┤        │
+│         │ CVE-2020-0003 │          │          │ unknown   │                   │               │        │
+│         ├───────────────┤          │          ├───────────┤                   ├───────────────┤        │
+│         │ CVE-2020-0004 │          │          │ N/A       │                   │               │        │
+└─────────┴───────────────┴──────────┴──────────┴───────────┴───────────────────┴───────────────┴────────┘
+`,
+		},
+		{
+			name: "no title for vuln and missing primary link",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						FixedVersion:     "3.4.5",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Description: "foobar",
+							Severity:    "HIGH",
+						},
+					},
+				},
+			},
+			want: `
+test ()
+=======
+Total: 1 (MEDIUM: 0, HIGH: 1)
+
+┌─────────┬───────────────┬──────────┬────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability │ Severity │ Status │ Installed Version │ Fixed Version │ Title  │
+├─────────┼───────────────┼──────────┼────────┼───────────────────┼───────────────┼────────┤
+│ foo     │ CVE-2020-0001 │ HIGH     │ fixed  │ 1.2.3             │ 3.4.5         │ foobar │
+└─────────┴───────────────┴──────────┴────────┴───────────────────┴───────────────┴────────┘
+`,
+		},
+		{
+			name: "long title for vuln",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-1234",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						FixedVersion:     "3.4.5",
+						PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2020-1234",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:       "a b c d e f g h i j k l m n o p q r s t u v",
+							Description: "foobar",
+							Severity:    "HIGH",
+						},
+					},
+				},
+			},
+			want: `
+test ()
+=======
+Total: 1 (MEDIUM: 0, HIGH: 1)
+
+┌─────────┬───────────────┬──────────┬────────┬───────────────────┬───────────────┬───────────────────────────────────────────┐
+│ Library │ Vulnerability │ Severity │ Status │ Installed Version │ Fixed Version │                   Title                   │
+├─────────┼───────────────┼──────────┼────────┼───────────────────┼───────────────┼───────────────────────────────────────────┤
+│ foo     │ CVE-2020-1234 │ HIGH     │ fixed  │ 1.2.3             │ 3.4.5         │ a b c d e f g h i j k l...                │
+│         │               │          │        │                   │               │ https://avd.aquasec.com/nvd/cve-2020-1234 │
+└─────────┴───────────────┴──────────┴────────┴───────────────────┴───────────────┴───────────────────────────────────────────┘
+`,
+		},
+		{
+			name: "happy path with vulnerability origin graph with direct dependency info",
+			result: types.Result{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   "npm",
+				Packages: []ftypes.Package{
+					{
+						ID:           "node-fetch@1.7.3",
+						Name:         "node-fetch",
+						Version:      "1.7.3",
+						Relationship: ftypes.RelationshipIndirect,
+					},
+					{
+						ID:           "isomorphic-fetch@2.2.1",
+						Name:         "isomorphic-fetch",
+						Version:      "2.2.1",
+						Relationship: ftypes.RelationshipIndirect,
+						DependsOn: []string{
+							"node-fetch@1.7.3",
+						},
+					},
+					{
+						ID:           "fbjs@0.8.18",
+						Name:         "fbjs",
+						Version:      "0.8.18",
+						Relationship: ftypes.RelationshipIndirect,
+						DependsOn: []string{
+							"isomorphic-fetch@2.2.1",
+						},
+					},
+					{
+						ID:           "sanitize-html@1.20.0",
+						Name:         "sanitize-html",
+						Version:      "1.20.0",
+						Relationship: ftypes.RelationshipDirect,
+					},
+					{
+						ID:           "styled-components@3.1.3",
+						Name:         "styled-components",
+						Version:      "3.1.3",
+						Relationship: ftypes.RelationshipDirect,
+						DependsOn: []string{
+							"fbjs@0.8.18",
+						},
+					},
+				},
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID: "CVE-2022-0235",
+						PkgID:           "node-fetch@1.7.3",
+						PkgName:         "node-fetch",
+						Vulnerability: dbTypes.Vulnerability{
+							Title:       "foobar",
+							Description: "baz",
+							Severity:    "HIGH",
+						},
+						InstalledVersion: "1.7.3",
+						FixedVersion:     "2.6.7, 3.1.1",
+						Status:           dbTypes.StatusFixed,
+					},
+					{
+						VulnerabilityID: "CVE-2021-26539",
+						PkgID:           "sanitize-html@1.20.0",
+						PkgName:         "sanitize-html",
+						Vulnerability: dbTypes.Vulnerability{
+							Title:       "foobar",
+							Description: "baz",
+							Severity:    "MEDIUM",
+						},
+						InstalledVersion: "1.20.0",
+						FixedVersion:     "2.3.1",
+						Status:           dbTypes.StatusFixed,
+					},
+				},
+			},
+			want: `
+package-lock.json (npm)
+=======================
+Total: 2 (MEDIUM: 1, HIGH: 1)
+
+┌───────────────┬────────────────┬──────────┬────────┬───────────────────┬───────────────┬────────┐
+│    Library    │ Vulnerability  │ Severity │ Status │ Installed Version │ Fixed Version │ Title  │
+├───────────────┼────────────────┼──────────┼────────┼───────────────────┼───────────────┼────────┤
+│ node-fetch    │ CVE-2022-0235  │ HIGH     │ fixed  │ 1.7.3             │ 2.6.7, 3.1.1  │ foobar │
+├───────────────┼────────────────┼──────────┤        ├───────────────────┼───────────────┤        │
+│ sanitize-html │ CVE-2021-26539 │ MEDIUM   │        │ 1.20.0            │ 2.3.1         │        │
+└───────────────┴────────────────┴──────────┴────────┴───────────────────┴───────────────┴────────┘
+
+Dependency Origin Tree (Reversed)
+=================================
+package-lock.json
+├── node-fetch@1.7.3, (MEDIUM: 0, HIGH: 1)
+│   └── ...(omitted)...
+│       └── styled-components@3.1.3
+└── sanitize-html@1.20.0, (MEDIUM: 1, HIGH: 0)
+`,
+		},
+		{
+			name: "dependency tree skipped with a single vulnerable package",
+			result: types.Result{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   "npm",
+				Packages: []ftypes.Package{
+					{
+						ID:           "node-fetch@1.7.3",
+						Name:         "node-fetch",
+						Version:      "1.7.3",
+						Relationship: ftypes.RelationshipIndirect,
+					},
+					{
+						ID:           "isomorphic-fetch@2.2.1",
+						Name:         "isomorphic-fetch",
+						Version:      "2.2.1",
+						Relationship: ftypes.RelationshipDirect,
+						DependsOn: []string{
+							"node-fetch@1.7.3",
+						},
+					},
+				},
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID: "CVE-2022-0235",
+						PkgID:           "node-fetch@1.7.3",
+						PkgName:         "node-fetch",
+						Vulnerability: dbTypes.Vulnerability{
+							Title:       "foobar",
+							Description: "baz",
+							Severity:    "HIGH",
+						},
+						InstalledVersion: "1.7.3",
+						FixedVersion:     "2.6.7, 3.1.1",
+						Status:           dbTypes.StatusFixed,
+					},
+				},
+			},
+			treeMinPackages: 2,
+			want: `
+package-lock.json (npm)
+=======================
+Total: 1 (MEDIUM: 0, HIGH: 1)
+
+┌────────────┬───────────────┬──────────┬────────┬───────────────────┬───────────────┬────────┐
+│  Library   │ Vulnerability │ Severity │ Status │ Installed Version │ Fixed Version │ Title  │
+├────────────┼───────────────┼──────────┼────────┼───────────────────┼───────────────┼────────┤
+│ node-fetch │ CVE-2022-0235 │ HIGH     │ fixed  │ 1.7.3             │ 2.6.7, 3.1.1  │ foobar │
+└────────────┴───────────────┴──────────┴────────┴───────────────────┴───────────────┴────────┘
+`,
+		},
+		{
+			name: "happy path with blast radius",
+			result: types.Result{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   "npm",
+				Packages: []ftypes.Package{
+					{
+						ID:           "node-fetch@1.7.3",
+						Name:         "node-fetch",
+						Version:      "1.7.3",
+						Relationship: ftypes.RelationshipIndirect,
+					},
+					{
+						ID:           "isomorphic-fetch@2.2.1",
+						Name:         "isomorphic-fetch",
+						Version:      "2.2.1",
+						Relationship: ftypes.RelationshipIndirect,
+						DependsOn: []string{
+							"node-fetch@1.7.3",
+						},
+					},
+					{
+						ID:           "fbjs@0.8.18",
+						Name:         "fbjs",
+						Version:      "0.8.18",
+						Relationship: ftypes.RelationshipIndirect,
+						DependsOn: []string{
+							"isomorphic-fetch@2.2.1",
+						},
+					},
+					{
+						ID:           "sanitize-html@1.20.0",
+						Name:         "sanitize-html",
+						Version:      "1.20.0",
+						Relationship: ftypes.RelationshipDirect,
+					},
+					{
+						ID:           "styled-components@3.1.3",
+						Name:         "styled-components",
+						Version:      "3.1.3",
+						Relationship: ftypes.RelationshipDirect,
+						DependsOn: []string{
+							"fbjs@0.8.18",
+						},
+					},
+				},
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID: "CVE-2022-0235",
+						PkgID:           "node-fetch@1.7.3",
+						PkgName:         "node-fetch",
+						Vulnerability: dbTypes.Vulnerability{
+							Title:       "foobar",
+							Description: "baz",
+							Severity:    "HIGH",
+						},
+						InstalledVersion: "1.7.3",
+						FixedVersion:     "2.6.7, 3.1.1",
+						Status:           dbTypes.StatusFixed,
+					},
+					{
+						VulnerabilityID: "CVE-2021-26539",
+						PkgID:           "sanitize-html@1.20.0",
+						PkgName:         "sanitize-html",
+						Vulnerability: dbTypes.Vulnerability{
+							Title:       "foobar",
+							Description: "baz",
+							Severity:    "MEDIUM",
+						},
+						InstalledVersion: "1.20.0",
+						FixedVersion:     "2.3.1",
+						Status:           dbTypes.StatusFixed,
+					},
+				},
+			},
+			showBlastRadius: true,
+			want: `
+package-lock.json (npm)
+=======================
+Total: 2 (MEDIUM: 1, HIGH: 1)
+
+┌───────────────┬────────────────┬──────────┬────────┬───────────────────┬───────────────┬────────────┬────────┐
+│    Library    │ Vulnerability  │ Severity │ Status │ Installed Version │ Fixed Version │ Dependents │ Title  │
+├───────────────┼────────────────┼──────────┼────────┼───────────────────┼───────────────┼────────────┼────────┤
+│ node-fetch    │ CVE-2022-0235  │ HIGH     │ fixed  │ 1.7.3             │ 2.6.7, 3.1.1  │ 3          │ foobar │
+├───────────────┼────────────────┼──────────┤        ├───────────────────┼───────────────┼────────────┤        │
+│ sanitize-html │ CVE-2021-26539 │ MEDIUM   │        │ 1.20.0            │ 2.3.1         │ 0          │        │
+└───────────────┴────────────────┴──────────┴────────┴───────────────────┴───────────────┴────────────┴────────┘
+
+Dependency Origin Tree (Reversed)
+=================================
+package-lock.json
+├── node-fetch@1.7.3, (MEDIUM: 0, HIGH: 1)
+│   └── ...(omitted)...
+│       └── styled-components@3.1.3
+└── sanitize-html@1.20.0, (MEDIUM: 1, HIGH: 0)
+`,
+		},
+		{
+			name: "blast radius without dependency graph",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassOSPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgID:            "foo@1.2.3",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						FixedVersion:     "1.2.4",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			showBlastRadius: true,
+			want: `
+test
+====
+Total: 1 (MEDIUM: 0, HIGH: 1)
+
+┌─────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬────────────┬────────┐
+│ Library │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │ Dependents │ Title  │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼────────────┼────────┤
+│ foo     │ CVE-2020-0001 │ HIGH     │ affected │ 1.2.3             │ 1.2.4         │ N/A        │ foobar │
+└─────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴────────────┴────────┘
+`,
+		},
+		{
+			name: "direct dependencies only",
+			result: types.Result{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   "npm",
+				Packages: []ftypes.Package{
+					{
+						ID:           "express@4.17.1",
+						Name:         "express",
+						Version:      "4.17.1",
+						Relationship: ftypes.RelationshipDirect,
+					},
+					{
+						ID:           "qs@6.7.0",
+						Name:         "qs",
+						Version:      "6.7.0",
+						Relationship: ftypes.RelationshipIndirect,
+					},
+					{
+						ID:           "body-parser@1.19.0",
+						Name:         "body-parser",
+						Version:      "1.19.0",
+						Relationship: ftypes.RelationshipIndirect,
+					},
+					{
+						ID:           "pkg-a@1.0.0",
+						Name:         "pkg-a",
+						Version:      "1.0.0",
+						Relationship: ftypes.RelationshipUnknown,
+					},
+				},
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2024-29041",
+						PkgID:            "express@4.17.1",
+						PkgName:          "express",
+						InstalledVersion: "4.17.1",
+						FixedVersion:     "4.19.2",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2022-24999",
+						PkgID:            "qs@6.7.0",
+						PkgName:          "qs",
+						InstalledVersion: "6.7.0",
+						FixedVersion:     "6.7.3",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "MEDIUM",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2024-45590",
+						PkgID:            "body-parser@1.19.0",
+						PkgName:          "body-parser",
+						InstalledVersion: "1.19.0",
+						FixedVersion:     "1.20.3",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2024-0001",
+						PkgID:            "pkg-a@1.0.0",
+						PkgName:          "pkg-a",
+						InstalledVersion: "1.0.0",
+						FixedVersion:     "1.0.1",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "MEDIUM",
+						},
+					},
+				},
+			},
+			directOnly: true,
+			want: `
+package-lock.json (npm)
+=======================
+Total: 1 (MEDIUM: 0, HIGH: 1)
+Hidden in indirect dependencies: 2 (MEDIUM: 1, HIGH: 1)
+Hidden in dependencies with unknown relationships: 1 (MEDIUM: 1, HIGH: 0)
+
+┌─────────┬────────────────┬──────────┬────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability  │ Severity │ Status │ Installed Version │ Fixed Version │ Title  │
+├─────────┼────────────────┼──────────┼────────┼───────────────────┼───────────────┼────────┤
+│ express │ CVE-2024-29041 │ HIGH     │ fixed  │ 4.17.1            │ 4.19.2        │ foobar │
+└─────────┴────────────────┴──────────┴────────┴───────────────────┴───────────────┴────────┘
+`,
+		},
+		{
+			name: "direct dependencies only without direct vulnerabilities",
+			result: types.Result{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   "npm",
+				Packages: []ftypes.Package{
+					{
+						ID:           "express@4.17.1",
+						Name:         "express",
+						Version:      "4.17.1",
+						Relationship: ftypes.RelationshipDirect,
+					},
+					{
+						ID:           "body-parser@1.19.0",
+						Name:         "body-parser",
+						Version:      "1.19.0",
+						Relationship: ftypes.RelationshipIndirect,
+					},
+				},
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2024-45590",
+						PkgID:            "body-parser@1.19.0",
+						PkgName:          "body-parser",
+						InstalledVersion: "1.19.0",
+						FixedVersion:     "1.20.3",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			directOnly: true,
+			want: `
+package-lock.json (npm)
+=======================
+Total: 0 (MEDIUM: 0, HIGH: 0)
+Hidden in indirect dependencies: 1 (MEDIUM: 0, HIGH: 1)
+
+`,
+		},
+		{
+			name: "direct dependencies only with unknown relationships",
+			result: types.Result{
+				Target: "gradle.lockfile",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.Gradle,
+				Packages: []ftypes.Package{
+					{
+						ID:      "org.springframework:spring-web:5.3.0",
+						Name:    "org.springframework:spring-web",
+						Version: "5.3.0",
+					},
+				},
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2024-22243",
+						PkgID:            "org.springframework:spring-web:5.3.0",
+						PkgName:          "org.springframework:spring-web",
+						InstalledVersion: "5.3.0",
+						FixedVersion:     "5.3.32",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			directOnly: true,
+			want: `
+gradle.lockfile (gradle)
+========================
+Total: 0 (MEDIUM: 0, HIGH: 0)
+Hidden in dependencies with unknown relationships: 1 (MEDIUM: 0, HIGH: 1)
+
+`,
+		},
+		{
+			name: "happy path with SLA",
+			result: types.Result{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   "npm",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2024-29041",
+						PkgName:          "express",
+						InstalledVersion: "4.17.1",
+						FixedVersion:     "4.19.2",
+						Status:           dbTypes.StatusFixed,
+						SLAStatus:        types.SLAStatusBreached,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2024-0001",
+						PkgName:          "pkg-a",
+						InstalledVersion: "1.0.0",
+						FixedVersion:     "1.0.1",
+						Status:           dbTypes.StatusFixed,
+						SLAStatus:        types.SLAStatusOnTrack,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "MEDIUM",
+						},
+					},
+				},
+			},
+			showSLA: true,
+			want: `
+package-lock.json (npm)
+=======================
+Total: 2 (MEDIUM: 1, HIGH: 1)
+
+┌─────────┬────────────────┬──────────┬────────┬──────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability  │ Severity │ Status │   SLA    │ Installed Version │ Fixed Version │ Title  │
+├─────────┼────────────────┼──────────┼────────┼──────────┼───────────────────┼───────────────┼────────┤
+│ express │ CVE-2024-29041 │ HIGH     │ fixed  │ BREACHED │ 4.17.1            │ 4.19.2        │ foobar │
+├─────────┼────────────────┼──────────┤        ├──────────┼───────────────────┼───────────────┤        │
+│ pkg-a   │ CVE-2024-0001  │ MEDIUM   │        │ on-track │ 1.0.0             │ 1.0.1         │        │
+└─────────┴────────────────┴──────────┴────────┴──────────┴───────────────────┴───────────────┴────────┘
+`,
+		},
+		{
+			name: "happy path with fix commands",
+			result: types.Result{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   "npm",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2024-29041",
+						PkgName:          "express",
+						InstalledVersion: "4.17.1",
+						FixedVersion:     "4.19.2",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2024-0001",
+						PkgName:          "pkg-a",
+						InstalledVersion: "1.0.0",
+						Status:           dbTypes.StatusAffected,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "MEDIUM",
+						},
+					},
+				},
+			},
+			showFixCommand: true,
+			want: `
+package-lock.json (npm)
+=======================
+Total: 2 (MEDIUM: 1, HIGH: 1)
+
+┌─────────┬────────────────┬──────────┬──────────┬───────────────────┬───────────────┬────────────────────────────┬────────┐
+│ Library │ Vulnerability  │ Severity │  Status  │ Installed Version │ Fixed Version │        Fix Command         │ Title  │
+├─────────┼────────────────┼──────────┼──────────┼───────────────────┼───────────────┼────────────────────────────┼────────┤
+│ express │ CVE-2024-29041 │ HIGH     │ fixed    │ 4.17.1            │ 4.19.2        │ npm install express@4.19.2 │ foobar │
+├─────────┼────────────────┼──────────┼──────────┼───────────────────┼───────────────┼────────────────────────────┤        │
+│ pkg-a   │ CVE-2024-0001  │ MEDIUM   │ affected │ 1.0.0             │               │                            │        │
+└─────────┴────────────────┴──────────┴──────────┴───────────────────┴───────────────┴────────────────────────────┴────────┘
+`,
+		},
+		{
+			name: "happy path with pre-release fixed versions",
+			result: types.Result{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   "npm",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2024-0001",
+						PkgName:          "pkg-a",
+						InstalledVersion: "1.0.0",
+						FixedVersion:     "2.0.0-rc1",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2024-0002",
+						PkgName:          "pkg-b",
+						InstalledVersion: "1.0.0",
+						FixedVersion:     "2.0.0",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "MEDIUM",
+						},
+					},
+				},
+			},
+			flagPrerelease: true,
+			want: `
+package-lock.json (npm)
+=======================
+Total: 2 (MEDIUM: 1, HIGH: 1)
 
-┌─────────┬───────────────┬──────────┬────────┬───────────────────┬───────────────┬────────┐
-│ Library │ Vulnerability │ Severity │ Status │ Installed Version │ Fixed Version │ Title  │
-├─────────┼───────────────┼──────────┼────────┼───────────────────┼───────────────┼────────┤
-│ foo     │ CVE-2020-0001 │ HIGH     │ fixed  │ 1.2.3             │ 3.4.5         │ foobar │
-└─────────┴───────────────┴──────────┴────────┴───────────────────┴───────────────┴────────┘
+┌─────────┬───────────────┬──────────┬────────┬───────────────────┬─────────────────────────┬────────┐
+│ Library │ Vulnerability │ Severity │ Status │ Installed Version │      Fixed Version      │ Title  │
+├─────────┼───────────────┼──────────┼────────┼───────────────────┼─────────────────────────┼────────┤
+│ pkg-a   │ CVE-2024-0001 │ HIGH     │ fixed  │ 1.0.0             │ 2.0.0-rc1 (pre-release) │ foobar │
+├─────────┼───────────────┼──────────┤        │                   ├─────────────────────────┤        │
+│ pkg-b   │ CVE-2024-0002 │ MEDIUM   │        │                   │ 2.0.0                   │        │
+└─────────┴───────────────┴──────────┴────────┴───────────────────┴─────────────────────────┴────────┘
 `,
 		},
 		{
-			name: "long title for vuln",
+			name: "happy path with conflicting severities",
 			result: types.Result{
-				Target: "test",
+				Target: "package-lock.json",
 				Class:  types.ClassLangPkg,
+				Type:   "npm",
 				Vulnerabilities: []types.DetectedVulnerability{
 					{
-						VulnerabilityID:  "CVE-2020-1234",
-						PkgName:          "foo",
-						InstalledVersion: "1.2.3",
-						FixedVersion:     "3.4.5",
-						PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2020-1234",
+						VulnerabilityID:  "CVE-2024-0001",
+						PkgName:          "pkg-a",
+						InstalledVersion: "1.0.0",
+						FixedVersion:     "1.0.1",
 						Status:           dbTypes.StatusFixed,
+						SeveritySource:   "nvd",
 						Vulnerability: dbTypes.Vulnerability{
-							Title:       "a b c d e f g h i j k l m n o p q r s t u v",
-							Description: "foobar",
-							Severity:    "HIGH",
+							Title:    "foobar",
+							Severity: "MEDIUM",
+							VendorSeverity: dbTypes.VendorSeverity{
+								"ghsa":   dbTypes.SeverityHigh,
+								"nvd":    dbTypes.SeverityMedium,
+								"redhat": dbTypes.SeverityCritical,
+							},
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2024-0002",
+						PkgName:          "pkg-b",
+						InstalledVersion: "1.0.0",
+						FixedVersion:     "1.0.1",
+						Status:           dbTypes.StatusFixed,
+						SeveritySource:   "nvd",
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+							VendorSeverity: dbTypes.VendorSeverity{
+								"nvd":    dbTypes.SeverityHigh,
+								"ubuntu": dbTypes.SeverityMedium,
+							},
 						},
 					},
 				},
 			},
+			severityConflicts: true,
 			want: `
-test ()
-=======
-Total: 1 (MEDIUM: 0, HIGH: 1)
+package-lock.json (npm)
+=======================
+Total: 2 (MEDIUM: 1, HIGH: 1)
 
-┌─────────┬───────────────┬──────────┬────────┬───────────────────┬───────────────┬───────────────────────────────────────────┐
-│ Library │ Vulnerability │ Severity │ Status │ Installed Version │ Fixed Version │                   Title                   │
-├─────────┼───────────────┼──────────┼────────┼───────────────────┼───────────────┼───────────────────────────────────────────┤
-│ foo     │ CVE-2020-1234 │ HIGH     │ fixed  │ 1.2.3             │ 3.4.5         │ a b c d e f g h i j k l...                │
-│         │               │          │        │                   │               │ https://avd.aquasec.com/nvd/cve-2020-1234 │
-└─────────┴───────────────┴──────────┴────────┴───────────────────┴───────────────┴───────────────────────────────────────────┘
+┌─────────┬───────────────┬──────────────────┬────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability │     Severity     │ Status │ Installed Version │ Fixed Version │ Title  │
+├─────────┼───────────────┼──────────────────┼────────┼───────────────────┼───────────────┼────────┤
+│ pkg-a   │ CVE-2024-0001 │ MEDIUM ⚠         │ fixed  │ 1.0.0             │ 1.0.1         │ foobar │
+│         │               │ redhat: CRITICAL │        │                   │               │        │
+├─────────┼───────────────┼──────────────────┤        │                   │               │        │
+│ pkg-b   │ CVE-2024-0002 │ HIGH             │        │                   │               │        │
+└─────────┴───────────────┴──────────────────┴────────┴───────────────────┴───────────────┴────────┘
 `,
 		},
 		{
-			name: "happy path with vulnerability origin graph with direct dependency info",
+			name: "top-down dependency tree",
 			result: types.Result{
 				Target: "package-lock.json",
 				Class:  types.ClassLangPkg,
@@ -239,6 +1943,7 @@ Total: 1 (MEDIUM: 0, HIGH: 1)
 					},
 				},
 			},
+			treeDirection: table.TreeDirectionDown,
 			want: `
 package-lock.json (npm)
 =======================
@@ -252,13 +1957,287 @@ Total: 2 (MEDIUM: 1, HIGH: 1)
 │ sanitize-html │ CVE-2021-26539 │ MEDIUM   │        │ 1.20.0            │ 2.3.1         │        │
 └───────────────┴────────────────┴──────────┴────────┴───────────────────┴───────────────┴────────┘
 
+Dependency Tree
+===============
+package-lock.json
+├── sanitize-html@1.20.0, (MEDIUM: 1, HIGH: 0)
+└── styled-components@3.1.3
+    └── fbjs@0.8.18
+        └── isomorphic-fetch@2.2.1
+            └── node-fetch@1.7.3, (MEDIUM: 0, HIGH: 1)
+`,
+		},
+		{
+			name: "dependency tree with the shortest paths",
+			result: types.Result{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   "npm",
+				Packages: []ftypes.Package{
+					{
+						ID:           "express@4.17.1",
+						Name:         "express",
+						Version:      "4.17.1",
+						Relationship: ftypes.RelationshipDirect,
+						DependsOn: []string{
+							"body-parser@1.19.0",
+						},
+					},
+					{
+						ID:           "koa@2.13.0",
+						Name:         "koa",
+						Version:      "2.13.0",
+						Relationship: ftypes.RelationshipDirect,
+						DependsOn: []string{
+							"co-body@6.1.0",
+						},
+					},
+					{
+						ID:           "webpack@5.0.0",
+						Name:         "webpack",
+						Version:      "5.0.0",
+						Relationship: ftypes.RelationshipDirect,
+						DependsOn: []string{
+							"terser@5.0.0",
+						},
+					},
+					{
+						ID:           "body-parser@1.19.0",
+						Name:         "body-parser",
+						Version:      "1.19.0",
+						Relationship: ftypes.RelationshipIndirect,
+						DependsOn: []string{
+							"lodash@4.17.20",
+							"qs@6.7.0",
+						},
+					},
+					{
+						ID:           "co-body@6.1.0",
+						Name:         "co-body",
+						Version:      "6.1.0",
+						Relationship: ftypes.RelationshipIndirect,
+						DependsOn: []string{
+							"lodash@4.17.20",
+							"qs@6.7.0",
+						},
+					},
+					{
+						ID:           "terser@5.0.0",
+						Name:         "terser",
+						Version:      "5.0.0",
+						Relationship: ftypes.RelationshipIndirect,
+						DependsOn: []string{
+							"uglify-js@3.0.0",
+						},
+					},
+					{
+						ID:           "uglify-js@3.0.0",
+						Name:         "uglify-js",
+						Version:      "3.0.0",
+						Relationship: ftypes.RelationshipIndirect,
+						DependsOn: []string{
+							"lodash@4.17.20",
+						},
+					},
+					{
+						ID:           "lodash@4.17.20",
+						Name:         "lodash",
+						Version:      "4.17.20",
+						Relationship: ftypes.RelationshipIndirect,
+					},
+					{
+						ID:           "qs@6.7.0",
+						Name:         "qs",
+						Version:      "6.7.0",
+						Relationship: ftypes.RelationshipIndirect,
+					},
+				},
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2021-23337",
+						PkgID:            "lodash@4.17.20",
+						PkgName:          "lodash",
+						InstalledVersion: "4.17.20",
+						FixedVersion:     "4.17.21",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2022-24999",
+						PkgID:            "qs@6.7.0",
+						PkgName:          "qs",
+						InstalledVersion: "6.7.0",
+						FixedVersion:     "6.7.3",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			shortestPath: true,
+			want: `
+package-lock.json (npm)
+=======================
+Total: 2 (MEDIUM: 0, HIGH: 2)
+
+┌─────────┬────────────────┬──────────┬────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability  │ Severity │ Status │ Installed Version │ Fixed Version │ Title  │
+├─────────┼────────────────┼──────────┼────────┼───────────────────┼───────────────┼────────┤
+│ lodash  │ CVE-2021-23337 │ HIGH     │ fixed  │ 4.17.20           │ 4.17.21       │ foobar │
+├─────────┼────────────────┤          │        ├───────────────────┼───────────────┤        │
+│ qs      │ CVE-2022-24999 │          │        │ 6.7.0             │ 6.7.3         │        │
+└─────────┴────────────────┴──────────┴────────┴───────────────────┴───────────────┴────────┘
+
 Dependency Origin Tree (Reversed)
 =================================
 package-lock.json
-├── node-fetch@1.7.3, (MEDIUM: 0, HIGH: 1)
-│   └── ...(omitted)...
-│       └── styled-components@3.1.3
-└── sanitize-html@1.20.0, (MEDIUM: 1, HIGH: 0)
+├── lodash@4.17.20, (MEDIUM: 0, HIGH: 1)
+│   └── body-parser@1.19.0
+│       └── express@4.17.1
+└── qs@6.7.0, (MEDIUM: 0, HIGH: 1)
+    └── body-parser@1.19.0
+        └── express@4.17.1
+`,
+		},
+		{
+			name: "top-down dependency tree with the shortest paths",
+			result: types.Result{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   "npm",
+				Packages: []ftypes.Package{
+					{
+						ID:           "express@4.17.1",
+						Name:         "express",
+						Version:      "4.17.1",
+						Relationship: ftypes.RelationshipDirect,
+						DependsOn: []string{
+							"body-parser@1.19.0",
+						},
+					},
+					{
+						ID:           "koa@2.13.0",
+						Name:         "koa",
+						Version:      "2.13.0",
+						Relationship: ftypes.RelationshipDirect,
+						DependsOn: []string{
+							"co-body@6.1.0",
+						},
+					},
+					{
+						ID:           "webpack@5.0.0",
+						Name:         "webpack",
+						Version:      "5.0.0",
+						Relationship: ftypes.RelationshipDirect,
+						DependsOn: []string{
+							"terser@5.0.0",
+						},
+					},
+					{
+						ID:           "body-parser@1.19.0",
+						Name:         "body-parser",
+						Version:      "1.19.0",
+						Relationship: ftypes.RelationshipIndirect,
+						DependsOn: []string{
+							"lodash@4.17.20",
+							"qs@6.7.0",
+						},
+					},
+					{
+						ID:           "co-body@6.1.0",
+						Name:         "co-body",
+						Version:      "6.1.0",
+						Relationship: ftypes.RelationshipIndirect,
+						DependsOn: []string{
+							"lodash@4.17.20",
+							"qs@6.7.0",
+						},
+					},
+					{
+						ID:           "terser@5.0.0",
+						Name:         "terser",
+						Version:      "5.0.0",
+						Relationship: ftypes.RelationshipIndirect,
+						DependsOn: []string{
+							"uglify-js@3.0.0",
+						},
+					},
+					{
+						ID:           "uglify-js@3.0.0",
+						Name:         "uglify-js",
+						Version:      "3.0.0",
+						Relationship: ftypes.RelationshipIndirect,
+						DependsOn: []string{
+							"lodash@4.17.20",
+						},
+					},
+					{
+						ID:           "lodash@4.17.20",
+						Name:         "lodash",
+						Version:      "4.17.20",
+						Relationship: ftypes.RelationshipIndirect,
+					},
+					{
+						ID:           "qs@6.7.0",
+						Name:         "qs",
+						Version:      "6.7.0",
+						Relationship: ftypes.RelationshipIndirect,
+					},
+				},
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2021-23337",
+						PkgID:            "lodash@4.17.20",
+						PkgName:          "lodash",
+						InstalledVersion: "4.17.20",
+						FixedVersion:     "4.17.21",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2022-24999",
+						PkgID:            "qs@6.7.0",
+						PkgName:          "qs",
+						InstalledVersion: "6.7.0",
+						FixedVersion:     "6.7.3",
+						Status:           dbTypes.StatusFixed,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "foobar",
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			treeDirection: table.TreeDirectionDown,
+			shortestPath:  true,
+			want: `
+package-lock.json (npm)
+=======================
+Total: 2 (MEDIUM: 0, HIGH: 2)
+
+┌─────────┬────────────────┬──────────┬────────┬───────────────────┬───────────────┬────────┐
+│ Library │ Vulnerability  │ Severity │ Status │ Installed Version │ Fixed Version │ Title  │
+├─────────┼────────────────┼──────────┼────────┼───────────────────┼───────────────┼────────┤
+│ lodash  │ CVE-2021-23337 │ HIGH     │ fixed  │ 4.17.20           │ 4.17.21       │ foobar │
+├─────────┼────────────────┤          │        ├───────────────────┼───────────────┤        │
+│ qs      │ CVE-2022-24999 │          │        │ 6.7.0             │ 6.7.3         │        │
+└─────────┴────────────────┴──────────┴────────┴───────────────────┴───────────────┴────────┘
+
+Dependency Tree
+===============
+package-lock.json
+└── express@4.17.1
+    └── body-parser@1.19.0
+        ├── lodash@4.17.20, (MEDIUM: 0, HIGH: 1)
+        └── qs@6.7.0, (MEDIUM: 0, HIGH: 1)
 `,
 		},
 		{
@@ -396,6 +2375,124 @@ Suppressed Vulnerabilities (Total: 1)
 ├─────────┼───────────────┼──────────┼─────────┼─────────────────┼───────────────────┤
 │ bar     │ CVE-2020-0002 │ MEDIUM   │ ignored │ Not exploitable │ .trivyignore.yaml │
 └─────────┴───────────────┴──────────┴─────────┴─────────────────┴───────────────────┘
+`,
+		},
+		{
+			name: "show vulnerabilities suppressed by VEX",
+			result: types.Result{
+				Target: "test",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2020-0001",
+						Status:           dbTypes.StatusWillNotFix,
+						Vulnerability: dbTypes.Vulnerability{
+							Title:       "title1",
+							Description: "desc1",
+							Severity:    "HIGH",
+						},
+					},
+				},
+				ModifiedFindings: []types.ModifiedFinding{
+					{
+						Type:      types.FindingTypeVulnerability,
+						Status:    types.FindingStatusNotAffected,
+						Statement: "vulnerable_code_not_in_execute_path",
+						Source:    "openvex.json",
+						Finding: types.DetectedVulnerability{
+							VulnerabilityID:  "CVE-2020-0002",
+							PkgName:          "bar",
+							InstalledVersion: "4.5.6",
+							Vulnerability: dbTypes.Vulnerability{
+								Title:       "title2",
+								Description: "desc2",
+								Severity:    "MEDIUM",
+							},
+						},
+					},
+					// It won't be shown as it is not suppressed by VEX.
+					{
+						Type:      types.FindingTypeVulnerability,
+						Status:    types.FindingStatusIgnored,
+						Statement: "Not exploitable",
+						Source:    ".trivyignore.yaml",
+						Finding: types.DetectedVulnerability{
+							VulnerabilityID:  "CVE-2020-0003",
+							PkgName:          "baz",
+							InstalledVersion: "7.8.9",
+						},
+					},
+				},
+			},
+			showVEXSuppressed: true,
+			want: `
+test ()
+=======
+Total: 1 (MEDIUM: 0, HIGH: 1)
+
+┌─────────┬───────────────┬──────────┬─────────────────────────────────────────────────────────┬───────────────────┬───────────────┬───────────────────────────────────────────┐
+│ Library │ Vulnerability │ Severity │                         Status                          │ Installed Version │ Fixed Version │                   Title                   │
+├─────────┼───────────────┼──────────┼─────────────────────────────────────────────────────────┼───────────────────┼───────────────┼───────────────────────────────────────────┤
+│ bar     │ CVE-2020-0002 │ MEDIUM   │ VEX: not_affected (vulnerable_code_not_in_execute_path) │ 4.5.6             │               │ title2                                    │
+├─────────┼───────────────┼──────────┼─────────────────────────────────────────────────────────┼───────────────────┼───────────────┼───────────────────────────────────────────┤
+│ foo     │ CVE-2020-0001 │ HIGH     │ will_not_fix                                            │ 1.2.3             │               │ title1                                    │
+│         │               │          │                                                         │                   │               │ https://avd.aquasec.com/nvd/cve-2020-0001 │
+└─────────┴───────────────┴──────────┴─────────────────────────────────────────────────────────┴───────────────────┴───────────────┴───────────────────────────────────────────┘
+`,
+		},
+		{
+			name: "vulnerabilities merged across platforms",
+			result: types.Result{
+				Target: "alpine:3.20 (alpine 3.20.0)",
+				Class:  types.ClassOSPkg,
+				Type:   ftypes.Alpine,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						FixedVersion:     "3.4.5",
+						Status:           dbTypes.StatusFixed,
+						Platforms: []string{
+							"linux/amd64",
+							"linux/arm64/v8",
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "title1",
+							Severity: "HIGH",
+						},
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0002",
+						PkgName:          "bar",
+						InstalledVersion: "5.6.7",
+						Status:           dbTypes.StatusAffected,
+						Platforms: []string{
+							"linux/arm64/v8",
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Title:    "title2",
+							Severity: "MEDIUM",
+						},
+					},
+				},
+			},
+			want: `
+alpine:3.20 (alpine 3.20.0)
+===========================
+Total: 2 (MEDIUM: 1, HIGH: 1)
+
+┌─────────┬───────────────┬──────────┬──────────┬───────────────────┬───────────────┬────────────────┬────────┐
+│ Library │ Vulnerability │ Severity │  Status  │ Installed Version │ Fixed Version │   Platforms    │ Title  │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼────────────────┼────────┤
+│ foo     │ CVE-2020-0001 │ HIGH     │ fixed    │ 1.2.3             │ 3.4.5         │ linux/amd64    │ title1 │
+│         │               │          │          │                   │               │ linux/arm64/v8 │        │
+├─────────┼───────────────┼──────────┼──────────┼───────────────────┼───────────────┼────────────────┼────────┤
+│ bar     │ CVE-2020-0002 │ MEDIUM   │ affected │ 5.6.7             │               │ linux/arm64/v8 │ title2 │
+└─────────┴───────────────┴──────────┴──────────┴───────────────────┴───────────────┴────────────────┴────────┘
 `,
 		},
 		{
@@ -507,11 +2604,789 @@ Suppressed Vulnerabilities (Total: 1)
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := table.NewVulnerabilityRenderer(tt.result, false, true, tt.showSuppressed, []dbTypes.Severity{
-				dbTypes.SeverityHigh,
-				dbTypes.SeverityMedium,
+			r := table.NewVulnerabilityRenderer(tt.result, false, table.VulnerabilityOptions{
+				Severities: []dbTypes.Severity{
+					dbTypes.SeverityHigh,
+					dbTypes.SeverityMedium,
+				},
+				Tree:                true,
+				TreeDirection:       tt.treeDirection,
+				TreeShortestPath:    tt.shortestPath,
+				TreeMinPackages:     tt.treeMinPackages,
+				ShowSuppressed:      tt.showSuppressed,
+				ShowVEXSuppressed:   tt.showVEXSuppressed,
+				MaxRows:             tt.maxRows,
+				DirectOnly:          tt.directOnly,
+				GroupBySeverity:     tt.groupBySeverity,
+				GroupByInstruction:  tt.groupByInstruction,
+				ShowReachability:    tt.reachability,
+				ShowLayer:           tt.showLayer,
+				ShowPURL:            tt.showPURL,
+				ShowEPSS:            tt.showEPSS,
+				ShowKEV:             tt.showKEV,
+				ShowKEVDue:          tt.showKEVDue,
+				ShowVendorStatus:    tt.showVendorStatus,
+				ShowAffectedRange:   tt.showAffectedRange,
+				ShowLabels:          tt.showLabels,
+				ShowSLA:             tt.showSLA,
+				ShowBlastRadius:     tt.showBlastRadius,
+				ShowFixCommand:      tt.showFixCommand,
+				FlagPrereleaseFixes: tt.flagPrerelease,
+				SeverityConflicts:   tt.severityConflicts,
+				SeverityOrder:       tt.severityOrder,
+				SeverityLabels:      tt.severityLabels,
 			})
 			assert.Equal(t, tt.want, r.Render(), tt.name)
 		})
 	}
 }
+
+// tableColumn returns the cells of the column with the given header in each line of the rendered table
+func tableColumn(t *testing.T, rendered, header string) []string {
+	index := -1
+	var cells []string
+	for _, line := range strings.Split(rendered, "\n") {
+		if !strings.HasPrefix(line, "│") {
+			continue
+		}
+		fields := strings.Split(strings.Trim(line, "│"), "│")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		if index < 0 {
+			index = slices.Index(fields, header)
+			require.NotEqual(t, -1, index, header)
+			continue
+		}
+		cells = append(cells, fields[index])
+	}
+	return cells
+}
+
+func TestVulnerabilityRenderer_fixCommand(t *testing.T) {
+	tests := []struct {
+		name             string
+		targetType       ftypes.TargetType
+		pkgName          string
+		installedVersion string
+		fixedVersion     string
+		want             string
+	}{
+		{
+			name:         "npm",
+			targetType:   ftypes.Npm,
+			pkgName:      "express",
+			fixedVersion: "4.19.2",
+			want:         "npm install express@4.19.2",
+		},
+		{
+			name:         "npm scoped package with several fixed versions",
+			targetType:   ftypes.Npm,
+			pkgName:      "@babel/traverse",
+			fixedVersion: "7.23.2, 8.0.0-alpha.4",
+			want:         "npm install @babel/traverse@7.23.2",
+		},
+		{
+			name:             "fixed version of an older release branch",
+			targetType:       ftypes.Npm,
+			pkgName:          "lodash",
+			installedVersion: "5.0.0",
+			fixedVersion:     "4.17.21, 5.0.1",
+			want:             "npm install lodash@5.0.1",
+		},
+		{
+			name:         "go",
+			targetType:   ftypes.GoModule,
+			pkgName:      "golang.org/x/net",
+			fixedVersion: "0.23.0",
+			want:         "go get golang.org/x/net@v0.23.0",
+		},
+		{
+			name:         "go with the v prefix",
+			targetType:   ftypes.GoModule,
+			pkgName:      "github.com/gin-gonic/gin",
+			fixedVersion: "v1.9.1",
+			want:         "go get github.com/gin-gonic/gin@v1.9.1",
+		},
+		{
+			name:             "go standard library",
+			targetType:       ftypes.GoModule,
+			pkgName:          "stdlib",
+			installedVersion: "v1.22.1",
+			fixedVersion:     "1.21.11, 1.22.4",
+			want:             "go get toolchain@go1.22.4",
+		},
+		{
+			name:         "maven",
+			targetType:   ftypes.Pom,
+			pkgName:      "org.apache.logging.log4j:log4j-core",
+			fixedVersion: "2.15.0",
+			want:         "mvn versions:use-dep-version -Dincludes=org.apache.logging.log4j:log4j-core -DdepVersion=2.15.0 -DforceVersion=true",
+		},
+		{
+			name:         "not fixed",
+			targetType:   ftypes.Npm,
+			pkgName:      "express",
+			fixedVersion: "",
+			want:         "",
+		},
+		{
+			name:         "no command",
+			targetType:   ftypes.Gradle,
+			pkgName:      "org.apache.logging.log4j:log4j-core",
+			fixedVersion: "2.15.0",
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := table.NewVulnerabilityRenderer(types.Result{
+				Target: "test",
+				Class:  types.ClassLangPkg,
+				Type:   tt.targetType,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2024-0001",
+						PkgName:          tt.pkgName,
+						InstalledVersion: tt.installedVersion,
+						FixedVersion:     tt.fixedVersion,
+						Vulnerability: dbTypes.Vulnerability{
+							Severity: "HIGH",
+						},
+					},
+				},
+			}, false, table.VulnerabilityOptions{
+				Severities:     []dbTypes.Severity{dbTypes.SeverityHigh},
+				ShowFixCommand: true,
+			})
+			// Long commands are wrapped
+			assert.Equal(t, tt.want, strings.Join(tableColumn(t, r.Render(), "Fix Command"), " "))
+		})
+	}
+}
+
+func TestVulnerabilityRenderer_prereleaseFixes(t *testing.T) {
+	tests := []struct {
+		name         string
+		targetType   ftypes.TargetType
+		fixedVersion string
+		want         string
+	}{
+		{
+			name:         "release",
+			targetType:   ftypes.Npm,
+			fixedVersion: "2.0.0",
+			want:         "2.0.0",
+		},
+		{
+			name:         "pre-release",
+			targetType:   ftypes.Npm,
+			fixedVersion: "2.0.0-rc1",
+			want:         "2.0.0-rc1 (pre-release)",
+		},
+		{
+			name:         "several fixed versions",
+			targetType:   ftypes.Npm,
+			fixedVersion: "7.23.2, 8.0.0-alpha.4",
+			want:         "7.23.2, 8.0.0-alpha.4 (pre-release)",
+		},
+		{
+			name:         "go with the v prefix",
+			targetType:   ftypes.GoModule,
+			fixedVersion: "v2.0.0-beta.1",
+			want:         "v2.0.0-beta.1 (pre-release)",
+		},
+		{
+			name:         "build metadata",
+			targetType:   ftypes.Cargo,
+			fixedVersion: "2.0.0+build.1",
+			want:         "2.0.0+build.1",
+		},
+		{
+			name:         "not semver ecosystem",
+			targetType:   ftypes.Pip,
+			fixedVersion: "2.0.0rc1",
+			want:         "2.0.0rc1",
+		},
+		{
+			name:         "OS package",
+			targetType:   ftypes.Debian,
+			fixedVersion: "2.0.0-1+deb12u1",
+			want:         "2.0.0-1+deb12u1",
+		},
+		{
+			name:         "not fixed",
+			targetType:   ftypes.Npm,
+			fixedVersion: "",
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := table.NewVulnerabilityRenderer(types.Result{
+				Target: "test",
+				Class:  types.ClassLangPkg,
+				Type:   tt.targetType,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2024-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.0.0",
+						FixedVersion:     tt.fixedVersion,
+						Vulnerability: dbTypes.Vulnerability{
+							Severity: "HIGH",
+						},
+					},
+				},
+			}, false, table.VulnerabilityOptions{
+				Severities:          []dbTypes.Severity{dbTypes.SeverityHigh},
+				FlagPrereleaseFixes: true,
+			})
+			assert.Equal(t, []string{tt.want}, tableColumn(t, r.Render(), "Fixed Version"))
+		})
+	}
+}
+
+func TestVulnerabilityRenderer_severityConflicts(t *testing.T) {
+	tests := []struct {
+		name           string
+		severity       string
+		vendorSeverity dbTypes.VendorSeverity
+		want           []string
+	}{
+		{
+			name:     "vendor rates higher by two levels",
+			severity: "MEDIUM",
+			vendorSeverity: dbTypes.VendorSeverity{
+				"nvd":    dbTypes.SeverityMedium,
+				"redhat": dbTypes.SeverityCritical,
+			},
+			want: []string{
+				"MEDIUM ⚠",
+				"redhat: CRITICAL",
+			},
+		},
+		{
+			name:     "vendors rate lower by two or more levels",
+			severity: "CRITICAL",
+			vendorSeverity: dbTypes.VendorSeverity{
+				"nvd":    dbTypes.SeverityCritical,
+				"ubuntu": dbTypes.SeverityLow,
+				"debian": dbTypes.SeverityMedium,
+				"amazon": dbTypes.SeverityHigh,
+			},
+			want: []string{
+				"CRITICAL ⚠",
+				"debian: MEDIUM",
+				"ubuntu: LOW",
+			},
+		},
+		{
+			name:     "one level apart",
+			severity: "HIGH",
+			vendorSeverity: dbTypes.VendorSeverity{
+				"nvd":    dbTypes.SeverityHigh,
+				"redhat": dbTypes.SeverityMedium,
+				"ghsa":   dbTypes.SeverityCritical,
+			},
+			want: []string{"HIGH"},
+		},
+		{
+			name:     "unknown rating",
+			severity: "HIGH",
+			vendorSeverity: dbTypes.VendorSeverity{
+				"nvd": dbTypes.SeverityUnknown,
+			},
+			want: []string{"HIGH"},
+		},
+		{
+			name:     "unknown severity",
+			severity: "UNKNOWN",
+			vendorSeverity: dbTypes.VendorSeverity{
+				"nvd": dbTypes.SeverityCritical,
+			},
+			want: []string{"UNKNOWN"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := table.NewVulnerabilityRenderer(types.Result{
+				Target: "test",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2024-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.0.0",
+						Vulnerability: dbTypes.Vulnerability{
+							Severity:       tt.severity,
+							VendorSeverity: tt.vendorSeverity,
+						},
+					},
+				},
+			}, false, table.VulnerabilityOptions{
+				Severities: []dbTypes.Severity{
+					dbTypes.SeverityUnknown,
+					dbTypes.SeverityMedium,
+					dbTypes.SeverityHigh,
+					dbTypes.SeverityCritical,
+				},
+				SeverityConflicts: true,
+			})
+			assert.Equal(t, tt.want, tableColumn(t, r.Render(), "Severity"))
+		})
+	}
+}
+
+func TestVulnerabilityRenderer_fixableFirst(t *testing.T) {
+	vuln := func(id, severity, fixedVersion string) types.DetectedVulnerability {
+		return types.DetectedVulnerability{
+			VulnerabilityID:  id,
+			PkgName:          strings.ToLower(id),
+			InstalledVersion: "1.0.0",
+			FixedVersion:     fixedVersion,
+			Vulnerability: dbTypes.Vulnerability{
+				Severity: severity,
+			},
+		}
+	}
+	vulns := []types.DetectedVulnerability{
+		vuln("CVE-2024-0001", "CRITICAL", ""),
+		vuln("CVE-2024-0002", "MEDIUM", "1.0.1"),
+		vuln("CVE-2024-0003", "LOW", ""),
+		vuln("CVE-2024-0004", "CRITICAL", "1.0.2"),
+		vuln("CVE-2024-0005", "MEDIUM", "1.0.3"),
+	}
+
+	tests := []struct {
+		name          string
+		severityOrder []string
+		want          []string
+	}{
+		{
+			name: "default severity order",
+			want: []string{
+				"CVE-2024-0004", // fixable CRITICAL
+				"CVE-2024-0002", // fixable MEDIUM
+				"CVE-2024-0005", // fixable MEDIUM in the original order
+				"CVE-2024-0001", // unfixable CRITICAL
+				"CVE-2024-0003", // unfixable LOW
+			},
+		},
+		{
+			name:          "custom severity order",
+			severityOrder: []string{"UNKNOWN", "LOW", "CRITICAL", "HIGH", "MEDIUM"},
+			want: []string{
+				"CVE-2024-0002",
+				"CVE-2024-0005",
+				"CVE-2024-0004",
+				"CVE-2024-0001",
+				"CVE-2024-0003",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := table.NewVulnerabilityRenderer(types.Result{
+				Target:          "test",
+				Class:           types.ClassLangPkg,
+				Vulnerabilities: vulns,
+			}, false, table.VulnerabilityOptions{
+				Severities: []dbTypes.Severity{
+					dbTypes.SeverityLow,
+					dbTypes.SeverityMedium,
+					dbTypes.SeverityCritical,
+				},
+				FixableFirst:  true,
+				SeverityOrder: tt.severityOrder,
+			})
+			assert.Equal(t, tt.want, tableColumn(t, r.Render(), "Vulnerability"))
+			assert.Equal(t, "CVE-2024-0001", vulns[0].VulnerabilityID, "the input must not be modified")
+		})
+	}
+}
+
+// severityVulns returns the vulnerabilities with the given numbers of vulnerabilities per severity
+func severityVulns(counts map[string]int) []types.DetectedVulnerability {
+	var vulns []types.DetectedVulnerability
+	for _, severity := range dbTypes.SeverityNames {
+		for range counts[severity] {
+			vulns = append(vulns, types.DetectedVulnerability{
+				VulnerabilityID:  fmt.Sprintf("CVE-2020-%04d", len(vulns)+1),
+				PkgName:          "foo",
+				InstalledVersion: "1.2.3",
+				Vulnerability: dbTypes.Vulnerability{
+					Severity: severity,
+				},
+			})
+		}
+	}
+	return vulns
+}
+
+func TestVulnerabilityRenderer_summaryBar(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	t.Cleanup(func() { color.NoColor = noColor })
+
+	severities := []dbTypes.Severity{
+		dbTypes.SeverityLow,
+		dbTypes.SeverityMedium,
+		dbTypes.SeverityHigh,
+		dbTypes.SeverityCritical,
+	}
+	// marks returns the number of marks per severity in the bar
+	marks := func(t *testing.T, rendered string) map[string]int {
+		counts := make(map[string]int)
+		for i, severity := range dbTypes.SeverityNames {
+			// Each segment is a run of marks in the color of the severity
+			prefix, suffix, _ := strings.Cut(table.SeverityColor[i]("█"), "█")
+			re := regexp.MustCompile(regexp.QuoteMeta(prefix) + "(█+)" + regexp.QuoteMeta(suffix))
+			if m := re.FindStringSubmatch(rendered); m != nil {
+				counts[severity] = utf8.RuneCountInString(m[1])
+			}
+		}
+		return counts
+	}
+
+	tests := []struct {
+		name   string
+		counts map[string]int
+		want   func(t *testing.T, got map[string]int)
+	}{
+		{
+			name: "small counts are visible",
+			counts: map[string]int{
+				"MEDIUM":   1,
+				"HIGH":     998,
+				"CRITICAL": 1,
+			},
+			want: func(t *testing.T, got map[string]int) {
+				assert.Equal(t, 1, got["MEDIUM"])
+				assert.Equal(t, 1, got["CRITICAL"])
+				assert.Greater(t, got["HIGH"], 1)
+				assert.NotContains(t, got, "LOW")
+			},
+		},
+		{
+			name: "proportional",
+			counts: map[string]int{
+				"LOW":  1,
+				"HIGH": 1,
+			},
+			want: func(t *testing.T, got map[string]int) {
+				assert.InDelta(t, got["LOW"], got["HIGH"], 1)
+				assert.NotContains(t, got, "MEDIUM")
+				assert.NotContains(t, got, "CRITICAL")
+			},
+		},
+		{
+			name:   "no findings",
+			counts: map[string]int{},
+			want: func(t *testing.T, got map[string]int) {
+				assert.Empty(t, got)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := table.NewVulnerabilityRenderer(types.Result{
+				Target:          "test",
+				Class:           types.ClassOSPkg,
+				Vulnerabilities: severityVulns(tt.counts),
+			}, true, table.VulnerabilityOptions{
+				Severities: severities,
+				SummaryBar: true,
+			})
+			tt.want(t, marks(t, r.Render()))
+		})
+	}
+
+	t.Run("not a terminal", func(t *testing.T) {
+		r := table.NewVulnerabilityRenderer(types.Result{
+			Target:          "test",
+			Class:           types.ClassOSPkg,
+			Vulnerabilities: severityVulns(map[string]int{"HIGH": 1}),
+		}, false, table.VulnerabilityOptions{
+			Severities: severities,
+			SummaryBar: true,
+		})
+		assert.NotContains(t, r.Render(), "█")
+	})
+}
+
+func TestVulnerabilityRenderer_severityLabels(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	t.Cleanup(func() { color.NoColor = noColor })
+
+	r := table.NewVulnerabilityRenderer(types.Result{
+		Target:          "test",
+		Vulnerabilities: severityVulns(map[string]int{"CRITICAL": 1}),
+	}, true, table.VulnerabilityOptions{
+		Severities: []dbTypes.Severity{
+			dbTypes.SeverityMedium,
+			dbTypes.SeverityHigh,
+			dbTypes.SeverityCritical,
+		},
+		SeverityLabels: map[string]string{
+			"CRITICAL": "C",
+			"HIGH":     "H",
+		},
+	})
+	got := r.Render()
+	assert.Contains(t, got, "Total: 1 (MEDIUM: 0, H: 0, C: 1)")
+	// Colors are still chosen by the severity name
+	assert.Contains(t, got, table.SeverityColor[4]("C"))
+	assert.NotContains(t, got, "CRITICAL")
+}
+
+func TestVulnerabilityRenderer_trend(t *testing.T) {
+	counts := map[string]int{
+		"MEDIUM":   1,
+		"HIGH":     2,
+		"CRITICAL": 3,
+	}
+	tests := []struct {
+		name     string
+		previous map[string]int
+		want     string
+	}{
+		{
+			name: "increased",
+			previous: map[string]int{
+				"HIGH":     1,
+				"CRITICAL": 1,
+			},
+			want: "Total: 6 (MEDIUM: 1 (▲1), HIGH: 2 (▲1), CRITICAL: 3 (▲2))",
+		},
+		{
+			name: "decreased",
+			previous: map[string]int{
+				"MEDIUM":   2,
+				"HIGH":     5,
+				"CRITICAL": 4,
+			},
+			want: "Total: 6 (MEDIUM: 1 (▼1), HIGH: 2 (▼3), CRITICAL: 3 (▼1))",
+		},
+		{
+			name: "unchanged",
+			previous: map[string]int{
+				"MEDIUM":   1,
+				"HIGH":     2,
+				"CRITICAL": 3,
+			},
+			want: "Total: 6 (MEDIUM: 1, HIGH: 2, CRITICAL: 3)",
+		},
+		{
+			name:     "new target",
+			previous: map[string]int{},
+			want:     "Total: 6 (MEDIUM: 1 (▲1), HIGH: 2 (▲2), CRITICAL: 3 (▲3))",
+		},
+		{
+			name: "first run",
+			want: "Total: 6 (MEDIUM: 1, HIGH: 2, CRITICAL: 3)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := table.NewVulnerabilityRenderer(types.Result{
+				Target:          "test",
+				Class:           types.ClassOSPkg,
+				Vulnerabilities: severityVulns(counts),
+				PreviousCounts:  tt.previous,
+			}, false, table.VulnerabilityOptions{
+				Severities: []dbTypes.Severity{
+					dbTypes.SeverityMedium,
+					dbTypes.SeverityHigh,
+					dbTypes.SeverityCritical,
+				},
+			})
+			assert.Contains(t, r.Render(), tt.want+"\n")
+		})
+	}
+}
+
+func TestVulnerabilityRenderer_blastRadius(t *testing.T) {
+	pkgs := []ftypes.Package{
+		{
+			ID:           "app",
+			Relationship: ftypes.RelationshipRoot,
+			DependsOn:    []string{"a@1.0.0"},
+		},
+		{
+			ID:           "a@1.0.0",
+			Name:         "a",
+			Version:      "1.0.0",
+			Relationship: ftypes.RelationshipDirect,
+			DependsOn:    []string{"b@1.0.0"},
+		},
+		{
+			ID:           "b@1.0.0",
+			Name:         "b",
+			Version:      "1.0.0",
+			Relationship: ftypes.RelationshipIndirect,
+			DependsOn:    []string{"c@1.0.0"},
+		},
+		{
+			// Cyclic dependency
+			ID:           "c@1.0.0",
+			Name:         "c",
+			Version:      "1.0.0",
+			Relationship: ftypes.RelationshipIndirect,
+			DependsOn:    []string{"b@1.0.0"},
+		},
+	}
+	vuln := func(id, pkgID string) types.DetectedVulnerability {
+		name, version, _ := strings.Cut(pkgID, "@")
+		return types.DetectedVulnerability{
+			VulnerabilityID:  id,
+			PkgID:            pkgID,
+			PkgName:          name,
+			InstalledVersion: version,
+			Vulnerability: dbTypes.Vulnerability{
+				Severity: "HIGH",
+			},
+		}
+	}
+
+	r := table.NewVulnerabilityRenderer(types.Result{
+		Target:   "package-lock.json",
+		Class:    types.ClassLangPkg,
+		Type:     ftypes.Npm,
+		Packages: pkgs,
+		Vulnerabilities: []types.DetectedVulnerability{
+			vuln("CVE-2024-0001", "a@1.0.0"),
+			vuln("CVE-2024-0002", "b@1.0.0"),
+			vuln("CVE-2024-0003", "c@1.0.0"),
+			vuln("CVE-2024-0004", "unknown@1.0.0"),
+		},
+	}, false, table.VulnerabilityOptions{
+		Severities:      []dbTypes.Severity{dbTypes.SeverityHigh},
+		ShowBlastRadius: true,
+		NoCellMerge:     true,
+	})
+	out := r.Render()
+	assert.Equal(t, []string{"a", "b", "c", "unknown"}, tableColumn(t, out, "Library"))
+	assert.Equal(t, []string{"0", "2", "2", "0"}, tableColumn(t, out, "Dependents"))
+}
+
+func TestVulnerabilityRenderer_shortestPath(t *testing.T) {
+	pkgs := []ftypes.Package{
+		{
+			ID:           "app@1.0.0",
+			Relationship: ftypes.RelationshipRoot,
+			DependsOn:    []string{"express@4.17.1", "koa@2.13.0", "webpack@5.0.0"},
+		},
+		{
+			ID:           "express@4.17.1",
+			Relationship: ftypes.RelationshipDirect,
+			DependsOn:    []string{"body-parser@1.19.0"},
+		},
+		{
+			ID:           "koa@2.13.0",
+			Relationship: ftypes.RelationshipDirect,
+			DependsOn:    []string{"co-body@6.1.0"},
+		},
+		{
+			ID:           "webpack@5.0.0",
+			Relationship: ftypes.RelationshipDirect,
+			DependsOn:    []string{"qs@6.7.0", "terser@5.0.0"},
+		},
+		{
+			ID:           "body-parser@1.19.0",
+			Relationship: ftypes.RelationshipIndirect,
+			DependsOn:    []string{"qs@6.7.0", "raw-body@2.4.0"},
+		},
+		{
+			ID:           "co-body@6.1.0",
+			Relationship: ftypes.RelationshipIndirect,
+			DependsOn:    []string{"raw-body@2.4.0"},
+		},
+		{
+			ID:           "raw-body@2.4.0",
+			Relationship: ftypes.RelationshipIndirect,
+			DependsOn:    []string{"body-parser@1.19.0"}, // cyclic
+		},
+		{
+			ID:           "terser@5.0.0",
+			Relationship: ftypes.RelationshipIndirect,
+		},
+		{
+			ID:           "qs@6.7.0",
+			Relationship: ftypes.RelationshipIndirect,
+		},
+		{
+			ID:           "orphan@1.0.0",
+			Relationship: ftypes.RelationshipIndirect,
+		},
+	}
+	vuln := func(id, pkgID string) types.DetectedVulnerability {
+		name, version, _ := strings.Cut(pkgID, "@")
+		return types.DetectedVulnerability{
+			VulnerabilityID:  id,
+			PkgID:            pkgID,
+			PkgName:          name,
+			InstalledVersion: version,
+			Vulnerability: dbTypes.Vulnerability{
+				Severity: "HIGH",
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		pkgID string
+		want  string
+	}{
+		{
+			name:  "direct dependency",
+			pkgID: "express@4.17.1",
+			want: `package-lock.json
+└── express@4.17.1, (HIGH: 1)
+`,
+		},
+		{
+			name:  "shorter path",
+			pkgID: "qs@6.7.0",
+			want: `package-lock.json
+└── qs@6.7.0, (HIGH: 1)
+    └── webpack@5.0.0
+`,
+		},
+		{
+			name:  "paths with the same length",
+			pkgID: "raw-body@2.4.0",
+			want: `package-lock.json
+└── raw-body@2.4.0, (HIGH: 1)
+    └── body-parser@1.19.0
+        └── express@4.17.1
+`,
+		},
+		{
+			name:  "no parents",
+			pkgID: "orphan@1.0.0",
+			want: `package-lock.json
+└── orphan@1.0.0, (HIGH: 1)
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := table.NewVulnerabilityRenderer(types.Result{
+				Target:          "package-lock.json",
+				Class:           types.ClassLangPkg,
+				Type:            ftypes.Npm,
+				Packages:        pkgs,
+				Vulnerabilities: []types.DetectedVulnerability{vuln("CVE-2024-0001", tt.pkgID)},
+			}, false, table.VulnerabilityOptions{
+				Severities:       []dbTypes.Severity{dbTypes.SeverityHigh},
+				Tree:             true,
+				TreeShortestPath: true,
+			})
+			_, tree, found := strings.Cut(r.Render(), "Dependency Origin Tree (Reversed)\n=================================\n")
+			require.True(t, found)
+			assert.Equal(t, tt.want, tree)
+		})
+	}
+}
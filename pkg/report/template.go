@@ -14,6 +14,7 @@ import (
 	"golang.org/x/xerrors"
 
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/types"
 )
@@ -58,6 +59,11 @@ func NewTemplateWriter(output io.Writer, outputTemplate, appVersion string) (*Te
 	templateFuncMap["appVersion"] = func() string {
 		return appVersion
 	}
+	// reverseDeps returns the parents of each package in the result keyed by package ID,
+	// so that templates can walk the dependency tree from vulnerable packages up to direct dependencies.
+	templateFuncMap["reverseDeps"] = func(result types.Result) map[string]ftypes.Packages {
+		return ftypes.Packages(result.Packages).ParentDeps()
+	}
 
 	// Overwrite functions
 	for k, v := range CustomTemplateFuncMap {
@@ -20,6 +20,7 @@ import (
 func TestReportWriter_Template(t *testing.T) {
 	testCases := []struct {
 		name          string
+		packages      []ftypes.Package
 		detectedVulns []types.DetectedVulnerability
 		template      string
 		expected      string
@@ -184,6 +185,47 @@ func TestReportWriter_Template(t *testing.T) {
   "Status": "affected",
   "Layer": {}
 }`,
+		},
+		{
+			name: "walk parents in the dependency tree",
+			packages: []ftypes.Package{
+				{
+					ID:           "app@1.0.0",
+					Relationship: ftypes.RelationshipRoot,
+					DependsOn:    []string{"express@4.17.1"},
+				},
+				{
+					ID:           "express@4.17.1",
+					Relationship: ftypes.RelationshipDirect,
+					DependsOn:    []string{"body-parser@1.19.0", "qs@6.7.0"},
+				},
+				{
+					ID:           "body-parser@1.19.0",
+					Relationship: ftypes.RelationshipIndirect,
+					DependsOn:    []string{"qs@6.7.0"},
+				},
+				{
+					ID:           "qs@6.7.0",
+					Relationship: ftypes.RelationshipIndirect,
+				},
+			},
+			detectedVulns: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "CVE-2022-24999",
+					PkgID:           "qs@6.7.0",
+					PkgName:         "qs",
+				},
+			},
+			template: `{{- define "parents" }}{{ range index .deps .id }}
+{{ $.indent }}<- {{ .ID }}{{ template "parents" dict "deps" $.deps "id" .ID "indent" (print $.indent "  ") }}{{ end }}{{ end -}}
+{{- range . }}{{ $deps := reverseDeps . }}{{ range .Vulnerabilities }}{{ .VulnerabilityID }} {{ .PkgID }}
+{{- template "parents" dict "deps" $deps "id" .PkgID "indent" "" }}{{ end }}{{ end }}`,
+			expected: `CVE-2022-24999 qs@6.7.0
+<- express@4.17.1
+  <- app@1.0.0
+<- body-parser@1.19.0
+  <- express@4.17.1
+    <- app@1.0.0`,
 		},
 		{
 			name:          "happy path: env var parsing",
@@ -203,6 +245,7 @@ func TestReportWriter_Template(t *testing.T) {
 					{
 						Target:          "foojunit",
 						Type:            "test",
+						Packages:        tc.packages,
 						Vulnerabilities: tc.detectedVulns,
 					},
 				},
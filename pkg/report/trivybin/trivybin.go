@@ -0,0 +1,84 @@
+// Package trivybin implements Trivy's own binary format of reports, written with "--format trivy-bin".
+// It is faster to load than JSON for programmatic consumers that repeatedly reload large reports.
+//
+// A report consists of the magic bytes, the format version and the report encoded with encoding/gob.
+// The format carries the same information as the JSON format, so converting a report back to JSON gives the same JSON.
+package trivybin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/gob"
+	"io"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const (
+	// Version is the version of the binary format written by Writer.
+	// gob tolerates added and removed fields, so it must be bumped only for incompatible changes,
+	// such as a field changing its type.
+	Version byte = 1
+
+	magic = "TRIVYBIN"
+)
+
+func init() {
+	// Concrete types stored in interface fields
+	gob.Register(types.DetectedVulnerability{})
+	gob.Register(types.DetectedMisconfiguration{})
+	gob.Register(types.DetectedSecret{})
+	gob.Register(types.DetectedLicense{})
+	// Custom fields and custom resources hold arbitrary values decoded from JSON
+	gob.Register(map[string]any{})
+	gob.Register([]any{})
+}
+
+// Writer writes the report in the binary format
+type Writer struct {
+	Output io.Writer
+}
+
+func (w Writer) Write(_ context.Context, report types.Report) error {
+	// The BOM is internal and not exported in JSON either
+	report.BOM = nil
+
+	if _, err := w.Output.Write(append([]byte(magic), Version)); err != nil {
+		return xerrors.Errorf("failed to write the header: %w", err)
+	}
+	if err := gob.NewEncoder(w.Output).Encode(report); err != nil {
+		return xerrors.Errorf("failed to encode the report: %w", err)
+	}
+	return nil
+}
+
+// Read reads the report in the binary format.
+// Reports written in a newer version of the format than Version are rejected.
+func Read(r io.Reader) (types.Report, error) {
+	header := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return types.Report{}, xerrors.Errorf("failed to read the header: %w", err)
+	}
+	if string(header[:len(magic)]) != magic {
+		return types.Report{}, xerrors.New("not a report in the Trivy binary format")
+	}
+	if v := header[len(magic)]; v == 0 || v > Version {
+		return types.Report{}, xerrors.Errorf("unsupported format version %d (supported up to %d), upgrade Trivy to read the report", v, Version)
+	}
+
+	var report types.Report
+	if err := gob.NewDecoder(r).Decode(&report); err != nil {
+		return types.Report{}, xerrors.Errorf("failed to decode the report: %w", err)
+	}
+	return report, nil
+}
+
+// Detect returns true if the reader starts with the header of the binary format.
+// The header is not consumed so that the reader can be passed to Read or another decoder.
+func Detect(r *bufio.Reader) bool {
+	b, err := r.Peek(len(magic))
+	return err == nil && bytes.Equal(b, []byte(magic))
+}
@@ -0,0 +1,256 @@
+package trivybin_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/package-url/packageurl-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report/trivybin"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+var (
+	publishedDate = time.Date(2024, 6, 27, 11, 15, 12, 0, time.UTC)
+
+	vuln = types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2024-5535",
+		PkgID:            "libssl3@3.3.0-r2",
+		PkgName:          "libssl3",
+		InstalledVersion: "3.3.0-r2",
+		FixedVersion:     "3.3.1-r1",
+		Status:           dbTypes.StatusFixed,
+		PkgIdentifier: ftypes.PkgIdentifier{
+			UID: "3b2a2ba1e0d4d0a2",
+			PURL: &packageurl.PackageURL{
+				Type:      packageurl.TypeApk,
+				Namespace: "alpine",
+				Name:      "libssl3",
+				Version:   "3.3.0-r2",
+				Qualifiers: packageurl.Qualifiers{
+					{
+						Key:   "distro",
+						Value: "3.20.0",
+					},
+				},
+			},
+		},
+		SeveritySource: "nvd",
+		PrimaryURL:     "https://avd.aquasec.com/nvd/cve-2024-5535",
+		Vulnerability: dbTypes.Vulnerability{
+			Title:    `openssl: SSL_select_next_proto "buffer overread"`,
+			Severity: "CRITICAL",
+			CweIDs:   []string{"CWE-200"},
+			VendorSeverity: dbTypes.VendorSeverity{
+				"nvd":    dbTypes.SeverityCritical,
+				"redhat": dbTypes.SeverityLow,
+			},
+			CVSS: dbTypes.VendorCVSS{
+				"nvd": {
+					V3Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:H",
+					V3Score:  9.1,
+				},
+			},
+			References:    []string{"https://www.openssl.org/news/secadv/20240627.txt"},
+			PublishedDate: &publishedDate,
+			Custom: map[string]any{
+				"exploited": true,
+				"refs":      []any{"KEV", 1.5, nil},
+			},
+		},
+	}
+
+	report = types.Report{
+		SchemaVersion: 2,
+		CreatedAt:     time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+		ArtifactName:  "alpine:3.20",
+		ArtifactType:  "container_image",
+		Metadata: types.Metadata{
+			OS: &ftypes.OS{
+				Family: ftypes.Alpine,
+				Name:   "3.20.0",
+			},
+			ImageID:  "sha256:1d34ffeaf190be23d3de5a8de0a436676b758f48f835c3a2d4768b798c15a7f1",
+			RepoTags: []string{"alpine:3.20"},
+			ImageConfig: v1.ConfigFile{
+				Architecture: "amd64",
+				OS:           "linux",
+				Config: v1.Config{
+					Cmd:          []string{"/bin/sh"},
+					ExposedPorts: map[string]struct{}{"8080/tcp": {}},
+				},
+			},
+		},
+		Results: types.Results{
+			{
+				Target: "alpine:3.20 (alpine 3.20.0)",
+				Class:  types.ClassOSPkg,
+				Type:   ftypes.Alpine,
+				Packages: ftypes.Packages{
+					{
+						ID:         "libssl3@3.3.0-r2",
+						Name:       "libssl3",
+						Version:    "3.3.0-r2",
+						Identifier: vuln.PkgIdentifier,
+						Licenses:   []string{"Apache-2.0"},
+						Layer: ftypes.Layer{
+							DiffID: "sha256:94e5f06ff8e3d4441dc3cd8b090ff38dc911bfa8ebdb0dc28395bc98f82f983f",
+						},
+					},
+				},
+				Vulnerabilities: []types.DetectedVulnerability{vuln},
+				ModifiedFindings: []types.ModifiedFinding{
+					types.NewModifiedFinding(vuln, types.FindingStatusNotAffected, "vulnerable_code_not_in_execute_path", "OpenVEX"),
+				},
+			},
+			{
+				Target: "/app/.env",
+				Class:  types.ClassSecret,
+				Secrets: []types.DetectedSecret{
+					{
+						RuleID:    "aws-access-key-id",
+						Category:  "AWS",
+						Title:     "AWS Access Key ID",
+						Severity:  "CRITICAL",
+						StartLine: 1,
+						EndLine:   1,
+						Match:     "AWS_ACCESS_KEY_ID=********************",
+					},
+				},
+				ModifiedFindings: []types.ModifiedFinding{
+					types.NewModifiedFinding(types.DetectedSecret{
+						RuleID:   "github-pat",
+						Severity: "CRITICAL",
+					}, types.FindingStatusIgnored, "", ".trivyignore"),
+				},
+			},
+			{
+				Class: types.ClassCustom,
+				CustomResources: []ftypes.CustomResource{
+					{
+						Type:     "wordpress",
+						FilePath: "wp-includes/version.php",
+						Data: map[string]any{
+							"version": "6.6.1",
+						},
+					},
+				},
+			},
+		},
+	}
+)
+
+func TestWriter_Write(t *testing.T) {
+	var buf bytes.Buffer
+	err := trivybin.Writer{Output: &buf}.Write(context.Background(), report)
+	require.NoError(t, err)
+
+	got, err := trivybin.Read(&buf)
+	require.NoError(t, err)
+
+	// The binary format must round-trip losslessly with the JSON format
+	want, err := json.Marshal(report)
+	require.NoError(t, err)
+	gotJSON, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(want), string(gotJSON))
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		wantErr string
+	}{
+		{
+			name:    "JSON report",
+			input:   []byte(`{"SchemaVersion": 2}`),
+			wantErr: "not a report in the Trivy binary format",
+		},
+		{
+			name:    "newer version",
+			input:   []byte("TRIVYBIN\x02"),
+			wantErr: "unsupported format version 2",
+		},
+		{
+			name:    "truncated header",
+			input:   []byte("TRIVY"),
+			wantErr: "failed to read the header",
+		},
+		{
+			name:    "truncated report",
+			input:   []byte("TRIVYBIN\x01\x10"),
+			wantErr: "failed to decode the report",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := trivybin.Read(bytes.NewReader(tt.input))
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	var buf bytes.Buffer
+	err := trivybin.Writer{Output: &buf}.Write(context.Background(), report)
+	require.NoError(t, err)
+
+	// Detect doesn't consume the header
+	r := bufio.NewReader(&buf)
+	assert.True(t, trivybin.Detect(r))
+	_, err = trivybin.Read(r)
+	require.NoError(t, err)
+
+	assert.False(t, trivybin.Detect(bufio.NewReader(bytes.NewReader([]byte(`{"SchemaVersion": 2}`)))))
+	assert.False(t, trivybin.Detect(bufio.NewReader(bytes.NewReader(nil))))
+}
+
+// BenchmarkRead compares the time to load a large report in the binary format and in JSON
+func BenchmarkRead(b *testing.B) {
+	large := report
+	large.Results = nil
+	for i := range 100 {
+		result := report.Results[0]
+		result.Target = fmt.Sprintf("app%d/package-lock.json", i)
+		result.Vulnerabilities = make([]types.DetectedVulnerability, 200)
+		for j := range result.Vulnerabilities {
+			v := vuln
+			v.VulnerabilityID = fmt.Sprintf("CVE-2024-%05d", j)
+			result.Vulnerabilities[j] = v
+		}
+		large.Results = append(large.Results, result)
+	}
+
+	jsonReport, err := json.Marshal(large)
+	require.NoError(b, err)
+	var binReport bytes.Buffer
+	err = trivybin.Writer{Output: &binReport}.Write(context.Background(), large)
+	require.NoError(b, err)
+
+	b.Run("json", func(b *testing.B) {
+		b.SetBytes(int64(len(jsonReport)))
+		for range b.N {
+			var r types.Report
+			err := json.Unmarshal(jsonReport, &r)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("trivy-bin", func(b *testing.B) {
+		b.SetBytes(int64(binReport.Len()))
+		for range b.N {
+			_, err := trivybin.Read(bytes.NewReader(binReport.Bytes()))
+			require.NoError(b, err)
+		}
+	})
+}
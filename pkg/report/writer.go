@@ -1,22 +1,38 @@
 package report
 
 import (
+	"cmp"
 	"context"
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"golang.org/x/xerrors"
 
+	"github.com/aquasecurity/trivy/pkg/clock"
 	cr "github.com/aquasecurity/trivy/pkg/compliance/report"
+	"github.com/aquasecurity/trivy/pkg/epss"
 	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
 	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/labels"
 	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/report/cyclonedx"
+	"github.com/aquasecurity/trivy/pkg/report/defectdojo"
+	"github.com/aquasecurity/trivy/pkg/report/elasticsearch"
 	"github.com/aquasecurity/trivy/pkg/report/github"
+	"github.com/aquasecurity/trivy/pkg/report/kafka"
 	"github.com/aquasecurity/trivy/pkg/report/predicate"
 	"github.com/aquasecurity/trivy/pkg/report/spdx"
+	"github.com/aquasecurity/trivy/pkg/report/sqlite"
+	"github.com/aquasecurity/trivy/pkg/report/syslog"
 	"github.com/aquasecurity/trivy/pkg/report/table"
+	"github.com/aquasecurity/trivy/pkg/report/trivybin"
+	"github.com/aquasecurity/trivy/pkg/trend"
 	"github.com/aquasecurity/trivy/pkg/types"
 )
 
@@ -25,7 +41,200 @@ const (
 )
 
 // Write writes the result to output, format as passed in argument
-func Write(ctx context.Context, report types.Report, option flag.Options) (err error) {
+func Write(ctx context.Context, report types.Report, option flag.Options) error {
+	// Timestamps are displayed in the given time zone and layout only in the table format
+	var timeFormat table.TimeFormat
+	if option.Format == types.FormatTable {
+		timeFormat = table.TimeFormat{
+			Location: option.Timezone,
+			Layout:   option.TimeFormat,
+		}
+	}
+
+	// The warning is rendered inline for the table format.
+	// Otherwise, it goes to stderr so that the output can be parsed.
+	staleWarning := staleDBWarning(clock.Now(ctx), report.DBUpdatedAt, option.DBStaleWarning, timeFormat)
+	if staleWarning != "" && (option.Format != types.FormatTable || option.Compliance.Spec.ID != "") {
+		log.WarnContext(ctx, staleWarning)
+	}
+
+	if len(option.SeverityOrder) > 0 {
+		sortBySeverityOrder(report.Results, option.SeverityOrder)
+	}
+
+	if option.ShowEPSS {
+		scores, err := epss.Load(ctx, option.EPSSSource, option.FeedOpts())
+		if err != nil {
+			return xerrors.Errorf("failed to load the EPSS dataset: %w", err)
+		}
+		scores.Fill(report.Results)
+
+		if option.SortBy == flag.SortByEPSS {
+			sortByEPSS(report.Results)
+		}
+	}
+
+	// The KEV catalog is filled by the filter, which also applies "--kev-only"
+	if option.ShowKEVDue && option.SortBy == flag.SortByKEVDue {
+		sortByKEVDue(report.Results)
+	}
+
+	if option.ShowAffectedRange {
+		if err := fillAffectedRanges(ctx, report.Results); err != nil {
+			return xerrors.Errorf("affected range error: %w", err)
+		}
+	}
+
+	if option.RelativePaths {
+		if base := relativePathsBase(report.ArtifactType, option); base != "" {
+			relativizePaths(&report, base)
+		}
+	}
+
+	if option.LabelsFile != "" {
+		rules, err := labels.Load(option.LabelsFile)
+		if err != nil {
+			return xerrors.Errorf("failed to load the labels file: %w", err)
+		}
+		rules.Apply(report.Results)
+	}
+
+	if len(option.SLA) > 0 {
+		option.SLA.Apply(report.Results, reportTime(ctx, report))
+	}
+
+	option.LicensePolicy.Apply(report.Results)
+
+	if option.TrendFile != "" {
+		state, err := trend.Load(option.TrendFile)
+		if err != nil {
+			return xerrors.Errorf("failed to load the trend file: %w", err)
+		}
+		state.Apply(report.Results)
+	}
+
+	var err error
+	if option.OutputDir != "" {
+		err = writeOutputDir(ctx, report, option, staleWarning)
+	} else {
+		err = write(ctx, report, option, staleWarning)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The state is updated only after the report is written, so that a failed run doesn't reset the trend
+	if option.TrendFile != "" {
+		if err = trend.Save(option.TrendFile, report.Results); err != nil {
+			return xerrors.Errorf("failed to save the trend file: %w", err)
+		}
+	}
+	return nil
+}
+
+// reportTime returns the time when the report was created, which is the reference time of ages of vulnerabilities.
+// Reports without the creation time, e.g. converted from old JSON reports, fall back to the current time.
+func reportTime(ctx context.Context, report types.Report) time.Time {
+	if report.CreatedAt.IsZero() {
+		return clock.Now(ctx)
+	}
+	return report.CreatedAt
+}
+
+// writeOutputDir writes the report of each target into a separate file in the output directory.
+func writeOutputDir(ctx context.Context, report types.Report, option flag.Options, staleWarning string) error {
+	if err := os.MkdirAll(option.OutputDir, 0o755); err != nil {
+		return xerrors.Errorf("failed to create the output directory: %w", err)
+	}
+
+	ext := outputFileExt(option.Format)
+	if option.Compress == flag.CompressGzip {
+		ext += ".gz"
+	}
+	names := outputFileNames(report.Results, ext)
+	for i, result := range report.Results {
+		r := report
+		r.Results = types.Results{result}
+
+		opt := option
+		opt.Output = filepath.Join(option.OutputDir, names[i])
+		opt.SetOutputWriter(nil)
+		if err := write(ctx, r, opt, staleWarning); err != nil {
+			return xerrors.Errorf("failed to write the report of %s: %w", result.Target, err)
+		}
+		log.DebugContext(ctx, "Report written", log.String("target", result.Target), log.FilePath(opt.Output))
+	}
+	return nil
+}
+
+// outputFileNames returns the file names for the results, named after the sanitized targets.
+// Names colliding with the previous ones are disambiguated with a counter, e.g. "package-lock.json-2.json".
+func outputFileNames(results types.Results, ext string) []string {
+	used := make(map[string]struct{})
+	names := make([]string, 0, len(results))
+	for _, result := range results {
+		base := sanitizeFileName(result.Target)
+		name := base + ext
+		for n := 2; ; n++ {
+			if _, ok := used[name]; !ok {
+				break
+			}
+			name = fmt.Sprintf("%s-%d%s", base, n, ext)
+		}
+		used[name] = struct{}{}
+		names = append(names, name)
+	}
+	return names
+}
+
+// sanitizeFileName replaces characters that are not safe in file names with underscores,
+// e.g. "alpine:3.20 (alpine 3.20.0)" => "alpine_3.20_alpine_3.20.0".
+func sanitizeFileName(target string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		}
+		return '_'
+	}, target)
+
+	// Collapse consecutive underscores and trim the leading dots not to create hidden files
+	for strings.Contains(name, "__") {
+		name = strings.ReplaceAll(name, "__", "_")
+	}
+	name = strings.Trim(strings.TrimLeft(name, "._"), "_")
+	if name == "" {
+		return "result"
+	}
+	return name
+}
+
+// outputFileExt returns the file extension for the format.
+func outputFileExt(format types.Format) string {
+	switch format {
+	case types.FormatJSON, types.FormatGitHub, types.FormatCosignVuln, types.FormatDefectDojo, types.FormatRemediationJSON:
+		return ".json"
+	case types.FormatSarif:
+		return ".sarif"
+	case types.FormatCycloneDX:
+		return ".cdx.json"
+	case types.FormatSPDX:
+		return ".spdx"
+	case types.FormatSPDXJSON:
+		return ".spdx.json"
+	case types.FormatHTML:
+		return ".html"
+	case types.FormatBadge:
+		return ".badge.json"
+	case types.FormatTrivyBin:
+		return ".trivybin"
+	default:
+		return ".txt"
+	}
+}
+
+// write writes the report to the output in the format.
+func write(ctx context.Context, report types.Report, option flag.Options, staleWarning string) (err error) {
 	output, cleanup, err := option.OutputWriter(ctx)
 	if err != nil {
 		return xerrors.Errorf("failed to create a file: %w", err)
@@ -41,24 +250,97 @@ func Write(ctx context.Context, report types.Report, option flag.Options) (err e
 		return complianceWrite(ctx, report, option, output)
 	}
 
+	if option.AgeHistogram {
+		report.AgeHistogram = types.NewAgeHistogram(report.Results, reportTime(ctx, report))
+	}
+	if option.Scorecard {
+		report.Scorecard = types.NewScorecard(report.Results)
+	}
+	if option.Banner != "" {
+		report.Banner = option.Banner
+	}
+
 	var writer Writer
 	switch option.Format {
 	case types.FormatTable:
 		writer = &table.Writer{
 			Output:               output,
 			Severities:           option.Severities,
+			VulnSeverities:       option.VulnSeverities,
+			MisconfSeverities:    option.MisconfSeverities,
+			SecretSeverities:     option.SecretSeverities,
+			StaleDBWarning:       staleWarning,
 			Tree:                 option.DependencyTree,
+			TreeDirection:        option.TreeDirection,
+			TreeShortestPath:     option.TreeShortestPath,
+			TreeMinPackages:      option.TreeMinPackages,
 			ShowSuppressed:       option.ShowSuppressed,
+			ShowVEXSuppressed:    option.ShowVEXSuppressed,
+			MaxRows:              option.MaxRows,
+			SecretMatchWidth:     option.SecretMatchWidth,
+			ShowSecretConfidence: option.ShowSecretConf,
+			SecretsByFile:        option.SecretsByFile,
+			ShowReachability:     option.ShowReachability,
+			GroupBySeverity:      option.GroupBySeverity,
+			GroupByInstruction:   option.GroupByInstr,
+			ShowLayer:            option.ShowLayer,
+			ShowPURL:             option.ShowPURL,
+			ShowBlastRadius:      option.ShowBlastRadius,
+			ShowFixCommand:       option.ShowFixCommand,
+			DirectOnly:           option.DirectOnly,
+			FixableFirst:         option.FixableFirst,
+			FlagPrereleaseFixes:  option.PrereleaseFixes,
+			SeverityConflicts:    option.SeverityConflicts,
+			SummaryBar:           option.SummaryBar,
+			FocusCVEs:            option.FocusCVEs,
+			RemediationPlan:      option.RemediationPlan,
+			ShowEPSS:             option.ShowEPSS,
+			ShowAffectedRange:    option.ShowAffectedRange,
+			ShowKEV:              option.ShowKEV,
+			ShowKEVDue:           option.ShowKEVDue,
+			ShowVendorStatus:     option.ShowVendorStatus,
+			ShowLabels:           option.LabelsFile != "",
+			ShowSLA:              len(option.SLA) > 0,
+			LicenseCompliance:    option.LicensePolicy.Enabled(),
+			ShowClasses:          option.ShowClasses,
+			ShowCleanSummary:     option.ShowCleanSummary,
+			Scanners:             option.Scanners,
+			QRCode:               option.QRCode,
+			NoCellMerge:          option.NoCellMerge,
+			ColumnsLayout:        option.ColumnsLayout,
+			Interactive:          option.Interactive,
+			SeverityOrder:        option.SeverityOrder,
+			SeverityLabels:       option.SeverityLabels,
 			IncludeNonFailures:   option.IncludeNonFailures,
 			Trace:                option.Trace,
+			ShowPolicySource:     option.ShowPolicySource,
+			ShowCode:             option.ShowCode,
+			SourceDir:            sourceDir(report.ArtifactType, option.Target),
 			LicenseRiskThreshold: option.LicenseRiskThreshold,
 			IgnoredLicenses:      option.IgnoredLicenses,
 		}
 	case types.FormatJSON:
+		if strings.HasPrefix(option.Output, kafka.Scheme) {
+			writer = &kafka.Writer{
+				URL: option.Output,
+			}
+			break
+		}
+		if strings.HasPrefix(option.Output, elasticsearch.Scheme) || strings.HasPrefix(option.Output, elasticsearch.SchemeHTTP) {
+			writer = &elasticsearch.Writer{
+				URL:      option.Output,
+				Username: option.ESUsername,
+				Password: option.ESPassword,
+				APIKey:   option.ESAPIKey,
+			}
+			break
+		}
 		writer = &JSONWriter{
 			Output:         output,
 			ListAllPkgs:    option.ListAllPkgs,
 			ShowSuppressed: option.ShowSuppressed,
+			Compact:        option.JSONCompact,
+			Validate:       option.ValidateOutput,
 		}
 	case types.FormatGitHub:
 		writer = &github.Writer{
@@ -69,7 +351,7 @@ func Write(ctx context.Context, report types.Report, option flag.Options) (err e
 		// TODO: support xml format option with cyclonedx writer
 		writer = cyclonedx.NewWriter(output, option.AppVersion)
 	case types.FormatSPDX, types.FormatSPDXJSON:
-		writer = spdx.NewWriter(output, option.AppVersion, option.Format)
+		writer = spdx.NewWriter(output, option.AppVersion, option.Format, option.SPDXRelationships)
 	case types.FormatTemplate:
 		// We keep `sarif.tpl` template working for backward compatibility for a while.
 		if strings.HasPrefix(option.Template, "@") && strings.HasSuffix(option.Template, "sarif.tpl") {
@@ -95,6 +377,49 @@ func Write(ctx context.Context, report types.Report, option flag.Options) (err e
 		}
 	case types.FormatCosignVuln:
 		writer = predicate.NewVulnWriter(output, option.AppVersion)
+	case types.FormatDefectDojo:
+		writer = &defectdojo.Writer{
+			Output: output,
+		}
+	case types.FormatCount:
+		writer = &CountWriter{
+			Output:     output,
+			Severities: option.Severities,
+			By:         option.CountBy,
+			Order:      option.SeverityOrder,
+		}
+	case types.FormatSyslog:
+		writer = &syslog.Writer{
+			Addr: option.SyslogAddr,
+		}
+	case types.FormatHTML:
+		writer = &HTMLWriter{
+			Output:  output,
+			Version: option.AppVersion,
+		}
+	case types.FormatSQLite:
+		writer = &sqlite.Writer{
+			Path:    option.Output,
+			Version: option.AppVersion,
+		}
+	case types.FormatBadge:
+		writer = &BadgeWriter{
+			Output: output,
+			SVG:    strings.HasSuffix(option.Output, ".svg"),
+			Order:  option.SeverityOrder,
+		}
+	case types.FormatTrivyBin:
+		writer = &trivybin.Writer{
+			Output: output,
+		}
+	case types.FormatGitHubAnnotations:
+		writer = &GitHubAnnotationsWriter{
+			Output: output,
+		}
+	case types.FormatRemediationJSON:
+		writer = &RemediationJSONWriter{
+			Output: output,
+		}
 	default:
 		return xerrors.Errorf("unknown format: %v", option.Format)
 	}
@@ -119,6 +444,144 @@ func complianceWrite(ctx context.Context, report types.Report, opt flag.Options,
 	})
 }
 
+// sortBySeverityOrder sorts vulnerabilities in the same way as types.BySeverity,
+// but based on the custom order of severities from the lowest to the highest.
+func sortBySeverityOrder(results types.Results, severityOrder []string) {
+	for _, result := range results {
+		slices.SortStableFunc(result.Vulnerabilities, func(a, b types.DetectedVulnerability) int {
+			return cmp.Or(
+				cmp.Compare(a.PkgName, b.PkgName),
+				cmp.Compare(a.InstalledVersion, b.InstalledVersion),
+				cmp.Compare(slices.Index(severityOrder, b.Severity), slices.Index(severityOrder, a.Severity)),
+				cmp.Compare(a.VulnerabilityID, b.VulnerabilityID),
+				cmp.Compare(a.PkgPath, b.PkgPath),
+			)
+		})
+	}
+}
+
+// sortByEPSS sorts vulnerabilities in descending order of the EPSS score.
+// Vulnerabilities without EPSS data come last in their original order.
+func sortByEPSS(results types.Results) {
+	score := func(v types.DetectedVulnerability) float64 {
+		if v.EPSS == nil {
+			return -1
+		}
+		return v.EPSS.Score
+	}
+	for _, result := range results {
+		slices.SortStableFunc(result.Vulnerabilities, func(a, b types.DetectedVulnerability) int {
+			return cmp.Compare(score(b), score(a))
+		})
+	}
+}
+
+// sortByKEVDue sorts vulnerabilities in ascending order of the KEV due date so that the most urgent fixes come first.
+// Vulnerabilities not in the KEV catalog come last in their original order.
+func sortByKEVDue(results types.Results) {
+	// Due dates are in "YYYY-MM-DD" and can be compared as strings
+	dueDate := func(v types.DetectedVulnerability) string {
+		if v.KEV == nil || v.KEV.DueDate == "" {
+			return "9999-12-31"
+		}
+		return v.KEV.DueDate
+	}
+	for _, result := range results {
+		slices.SortStableFunc(result.Vulnerabilities, func(a, b types.DetectedVulnerability) int {
+			return cmp.Compare(dueDate(a), dueDate(b))
+		})
+	}
+}
+
+// relativePathsBase returns the absolute base directory for "--relative-paths".
+// Unless it is configured, the scanned directory is used for filesystem scans.
+func relativePathsBase(artifactType artifact.Type, option flag.Options) string {
+	base := option.RelativePathsBase
+	if base == "" {
+		if artifactType != artifact.TypeFilesystem {
+			return ""
+		}
+		base = option.Target
+		// A single file was scanned
+		if fi, err := os.Stat(base); err == nil && !fi.IsDir() {
+			base = filepath.Dir(base)
+		}
+	}
+
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		log.Debug("Unable to get the absolute path", log.FilePath(base), log.Err(err))
+		return ""
+	}
+	return absBase
+}
+
+// sourceDir returns the local directory where the scanned files can be read for code frames.
+// It is empty for artifacts without local sources, such as container images and remote repositories.
+func sourceDir(artifactType artifact.Type, target string) string {
+	if artifactType != artifact.TypeFilesystem && artifactType != artifact.TypeRepository {
+		return ""
+	}
+	fi, err := os.Stat(target)
+	if err != nil {
+		return ""
+	} else if !fi.IsDir() {
+		// A single file was scanned
+		return filepath.Dir(target)
+	}
+	return target
+}
+
+// relativizePaths rewrites absolute paths under the base directory to be relative to it,
+// so that reports can be compared across machines with different checkout paths.
+// Paths that are already relative or outside the base directory are kept as they are.
+func relativizePaths(report *types.Report, base string) {
+	rel := func(p string) string {
+		if !filepath.IsAbs(p) {
+			return p
+		}
+		r, err := filepath.Rel(base, p)
+		if err != nil || r == ".." || strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+			return p
+		}
+		return filepath.ToSlash(r)
+	}
+
+	report.ArtifactName = rel(report.ArtifactName)
+	for i := range report.Results {
+		result := &report.Results[i]
+		result.Target = rel(result.Target)
+		for j := range result.Vulnerabilities {
+			result.Vulnerabilities[j].PkgPath = rel(result.Vulnerabilities[j].PkgPath)
+		}
+		for j := range result.Packages {
+			result.Packages[j].FilePath = rel(result.Packages[j].FilePath)
+		}
+		for j := range result.Licenses {
+			result.Licenses[j].FilePath = rel(result.Licenses[j].FilePath)
+		}
+	}
+}
+
+// staleDBWarning returns a warning message if the vulnerability database is older than the threshold.
+func staleDBWarning(now, updatedAt time.Time, threshold time.Duration, timeFormat table.TimeFormat) string {
+	if threshold <= 0 || updatedAt.IsZero() {
+		return ""
+	}
+
+	age := now.Sub(updatedAt)
+	if age <= threshold {
+		return ""
+	}
+
+	ago := fmt.Sprintf("%d days", int(age.Hours()/24))
+	if age < 48*time.Hour {
+		ago = fmt.Sprintf("%d hours", int(age.Hours()))
+	}
+	return fmt.Sprintf("The vulnerability database was last updated %s ago (%s). "+
+		"The results may be incomplete. Please update the database.", ago, timeFormat.Format(updatedAt))
+}
+
 // Writer defines the result write operation
 type Writer interface {
 	Write(context.Context, types.Report) error
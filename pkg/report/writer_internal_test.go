@@ -1,9 +1,21 @@
 package report
 
 import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/report/table"
+	"github.com/aquasecurity/trivy/pkg/types"
 )
 
 func TestReportWriter_toSarifErrorLevel(t *testing.T) {
@@ -42,3 +54,235 @@ func TestReportWriter_toSarifErrorLevel(t *testing.T) {
 		})
 	}
 }
+
+func Test_staleDBWarning(t *testing.T) {
+	now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		updatedAt  time.Time
+		threshold  time.Duration
+		timeFormat table.TimeFormat
+		want       string
+	}{
+		{
+			name:      "stale",
+			updatedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			threshold: 7 * 24 * time.Hour,
+			want: "The vulnerability database was last updated 14 days ago (2024-06-01T00:00:00Z). " +
+				"The results may be incomplete. Please update the database.",
+		},
+		{
+			name:      "stale in hours",
+			updatedAt: time.Date(2024, 6, 14, 12, 0, 0, 0, time.UTC),
+			threshold: 6 * time.Hour,
+			want: "The vulnerability database was last updated 12 hours ago (2024-06-14T12:00:00Z). " +
+				"The results may be incomplete. Please update the database.",
+		},
+		{
+			name:      "timezone and layout",
+			updatedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			threshold: 7 * 24 * time.Hour,
+			timeFormat: table.TimeFormat{
+				Location: time.FixedZone("JST", 9*60*60),
+				Layout:   "2006-01-02 15:04 MST",
+			},
+			want: "The vulnerability database was last updated 14 days ago (2024-06-01 09:00 JST). " +
+				"The results may be incomplete. Please update the database.",
+		},
+		{
+			name:      "fresh",
+			updatedAt: time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC),
+			threshold: 7 * 24 * time.Hour,
+		},
+		{
+			name:      "disabled",
+			updatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "unknown timestamp",
+			threshold: 7 * 24 * time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, staleDBWarning(now, tt.updatedAt, tt.threshold, tt.timeFormat))
+		})
+	}
+}
+
+func Test_relativizePaths(t *testing.T) {
+	base := t.TempDir()
+	outside := filepath.Join(filepath.Dir(base), "other", "package-lock.json")
+
+	report := types.Report{
+		ArtifactName: base,
+		Results: types.Results{
+			{
+				Target: filepath.Join(base, "app", "package-lock.json"),
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID: "CVE-2024-0001",
+						PkgPath:         filepath.Join(base, "app", "node_modules", "foo", "package.json"),
+					},
+				},
+				Packages: []ftypes.Package{
+					{
+						Name:     "foo",
+						FilePath: filepath.Join(base, "app", "node_modules", "foo", "package.json"),
+					},
+				},
+			},
+			{
+				Target: "requirements.txt",
+			},
+			{
+				Target: outside,
+			},
+		},
+	}
+	relativizePaths(&report, base)
+
+	want := types.Report{
+		ArtifactName: ".",
+		Results: types.Results{
+			{
+				Target: "app/package-lock.json",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID: "CVE-2024-0001",
+						PkgPath:         "app/node_modules/foo/package.json",
+					},
+				},
+				Packages: []ftypes.Package{
+					{
+						Name:     "foo",
+						FilePath: "app/node_modules/foo/package.json",
+					},
+				},
+			},
+			{
+				Target: "requirements.txt",
+			},
+			{
+				Target: outside,
+			},
+		},
+	}
+	assert.Equal(t, want, report)
+}
+
+func Test_sortByEPSS(t *testing.T) {
+	results := types.Results{
+		{
+			Vulnerabilities: []types.DetectedVulnerability{
+				{VulnerabilityID: "CVE-2019-0001", EPSS: &types.EPSS{Score: 0.00428, Percentile: 0.74589}},
+				{VulnerabilityID: "GHSA-jfh8-c2jp-5v3q"},
+				{VulnerabilityID: "CVE-2021-44228", EPSS: &types.EPSS{Score: 0.97565, Percentile: 0.99996}},
+				{VulnerabilityID: "GHSA-7rjr-3q55-vv33"},
+				{VulnerabilityID: "CVE-2022-22965", EPSS: &types.EPSS{Score: 0.97467, Percentile: 0.99966}},
+			},
+		},
+	}
+
+	sortByEPSS(results)
+
+	var got []string
+	for _, v := range results[0].Vulnerabilities {
+		got = append(got, v.VulnerabilityID)
+	}
+	assert.Equal(t, []string{
+		"CVE-2021-44228",
+		"CVE-2022-22965",
+		"CVE-2019-0001",
+		"GHSA-jfh8-c2jp-5v3q",
+		"GHSA-7rjr-3q55-vv33",
+	}, got)
+}
+
+func Test_sortByKEVDue(t *testing.T) {
+	results := types.Results{
+		{
+			Vulnerabilities: []types.DetectedVulnerability{
+				{VulnerabilityID: "CVE-2020-0002"},
+				{VulnerabilityID: "CVE-2022-22965", KEV: &types.KEV{DateAdded: "2022-04-04", DueDate: "2022-04-25"}},
+				{VulnerabilityID: "GHSA-7rjr-3q55-vv33"},
+				{VulnerabilityID: "CVE-2021-44228", KEV: &types.KEV{DateAdded: "2021-12-10", DueDate: "2021-12-24"}},
+				{VulnerabilityID: "CVE-2023-4966", KEV: &types.KEV{DateAdded: "2023-10-18", DueDate: "2023-11-08"}},
+			},
+		},
+	}
+
+	sortByKEVDue(results)
+
+	var got []string
+	for _, v := range results[0].Vulnerabilities {
+		got = append(got, v.VulnerabilityID)
+	}
+	assert.Equal(t, []string{
+		"CVE-2021-44228",
+		"CVE-2022-22965",
+		"CVE-2023-4966",
+		"CVE-2020-0002",
+		"GHSA-7rjr-3q55-vv33",
+	}, got)
+}
+
+func Test_outputFileNames(t *testing.T) {
+	results := types.Results{
+		{Target: "alpine:3.20 (alpine 3.20.0)"},
+		{Target: "app/package-lock.json"},
+		{Target: "app:package-lock.json"},
+		{Target: "app/package-lock.json"},
+		{Target: ".env"},
+		{Target: "../secrets.yaml"},
+		{Target: ""},
+	}
+	want := []string{
+		"alpine_3.20_alpine_3.20.0.json",
+		"app_package-lock.json.json",
+		"app_package-lock.json-2.json",
+		"app_package-lock.json-3.json",
+		"env.json",
+		"secrets.yaml.json",
+		"result.json",
+	}
+	assert.Equal(t, want, outputFileNames(results, ".json"))
+}
+
+func TestWrite_OutputDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "reports")
+	report := types.Report{
+		ArtifactName: "app",
+		Results: types.Results{
+			{
+				Target: "app/package-lock.json",
+				Class:  types.ClassLangPkg,
+			},
+			{
+				Target: "app/go.mod",
+				Class:  types.ClassLangPkg,
+			},
+		},
+	}
+	err := Write(context.Background(), report, flag.Options{
+		ReportOptions: flag.ReportOptions{
+			Format:    types.FormatJSON,
+			OutputDir: dir,
+		},
+	})
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"app_go.mod.json",
+		"app_package-lock.json.json",
+	}, lo.Map(entries, func(e os.DirEntry, _ int) string { return e.Name() }))
+
+	b, err := os.ReadFile(filepath.Join(dir, "app_go.mod.json"))
+	require.NoError(t, err)
+	var got types.Report
+	require.NoError(t, json.Unmarshal(b, &got))
+	require.Len(t, got.Results, 1)
+	assert.Equal(t, "app/go.mod", got.Results[0].Target)
+}
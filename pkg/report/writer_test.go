@@ -2,9 +2,12 @@ package report_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	"github.com/aquasecurity/trivy/pkg/types"
 )
 
@@ -82,3 +85,54 @@ func TestResults_Failed(t *testing.T) {
 		})
 	}
 }
+
+func TestNewAgeHistogram(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) *time.Time {
+		return lo.ToPtr(now.AddDate(0, 0, -days))
+	}
+	results := types.Results{
+		{
+			Target: "alpine:3.20 (alpine 3.20.0)",
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "CVE-2024-0001",
+					Vulnerability:   dbTypes.Vulnerability{PublishedDate: daysAgo(1)},
+				},
+				{
+					VulnerabilityID: "CVE-2024-0002",
+					Vulnerability:   dbTypes.Vulnerability{PublishedDate: daysAgo(30)},
+				},
+				{
+					VulnerabilityID: "CVE-2024-0003",
+					Vulnerability:   dbTypes.Vulnerability{PublishedDate: daysAgo(89)},
+				},
+			},
+		},
+		{
+			Target: "app/package-lock.json",
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "CVE-2023-0001",
+					Vulnerability:   dbTypes.Vulnerability{PublishedDate: daysAgo(90)},
+				},
+				{
+					VulnerabilityID: "CVE-2020-0001",
+					Vulnerability:   dbTypes.Vulnerability{PublishedDate: daysAgo(1000)},
+				},
+				{
+					VulnerabilityID: "GHSA-xxxx-xxxx-xxxx",
+				},
+			},
+		},
+	}
+
+	want := &types.AgeHistogram{
+		LessThan30Days:  1,
+		From30To90Days:  2,
+		From90To365Days: 1,
+		MoreThan1Year:   1,
+		Unknown:         1,
+	}
+	assert.Equal(t, want, types.NewAgeHistogram(results, now))
+}
@@ -7,12 +7,18 @@ import (
 	"path/filepath"
 	"slices"
 	"sort"
+	"strings"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/open-policy-agent/opa/rego"
 	"github.com/samber/lo"
 	"golang.org/x/xerrors"
 
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/baseline"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/feed"
+	"github.com/aquasecurity/trivy/pkg/kev"
 	"github.com/aquasecurity/trivy/pkg/types"
 	"github.com/aquasecurity/trivy/pkg/vex"
 )
@@ -24,13 +30,34 @@ const (
 
 type FilterOptions struct {
 	Severities         []dbTypes.Severity
+	VulnSeverities     []dbTypes.Severity // Override Severities for vulnerabilities
+	MisconfSeverities  []dbTypes.Severity // Override Severities for misconfigurations
+	SecretSeverities   []dbTypes.Severity // Override Severities for secrets
 	IgnoreStatuses     []dbTypes.Status
+	HideWontFix        bool // Hide the vulnerabilities that the vendor won't fix
 	IncludeNonFailures bool
 	IgnoreFile         string
 	PolicyFile         string
 	IgnoreLicenses     []string
 	CacheDir           string
 	VEXSources         []vex.Source
+	VEXReachability    bool     // Record whether the vulnerable code is reachable, as stated by the VEX documents
+	PkgFilters         []string // Glob patterns of package names to be reported
+	InternalPackages   []string // Glob patterns of first-party package names to be excluded from vulnerability matching
+	CountFiltered      bool     // Count the findings filtered out by each filter
+
+	// MinSecretConf is the minimum confidence of secrets to be reported
+	MinSecretConf ftypes.SecretConfidence
+
+	// KEVSource is the source of the KEV catalog filled in the vulnerabilities. The catalog is not loaded if empty.
+	KEVSource string
+	KEVOnly   bool         // Report only vulnerabilities in the KEV catalog
+	FeedOpts  feed.Options // Options to download the KEV catalog
+
+	// BaselineFile is the JSON report of a prior scan marking the vulnerabilities as new or known
+	BaselineFile string
+	HideKnown    bool // Hide the vulnerabilities known in the baseline
+	MisconfDiff  bool // Report only the misconfigurations changed since the baseline
 }
 
 // Filter filters out the report
@@ -47,39 +74,99 @@ func Filter(ctx context.Context, report types.Report, opts FilterOptions) error
 	}
 
 	// Filter out vulnerabilities based on the given VEX document.
-	if err = vex.Filter(ctx, &report, vex.Options{
-		CacheDir: opts.CacheDir,
-		Sources:  opts.VEXSources,
-	}); err != nil {
+	err = countRemoved(report.Results, vexCount, func() error {
+		return vex.Filter(ctx, &report, vex.Options{
+			CacheDir:     opts.CacheDir,
+			Sources:      opts.VEXSources,
+			Reachability: opts.VEXReachability,
+		})
+	})
+	if err != nil {
 		return xerrors.Errorf("VEX error: %w", err)
 	}
 
+	if opts.KEVSource != "" {
+		catalog, err := kev.Load(ctx, opts.KEVSource, opts.FeedOpts)
+		if err != nil {
+			return xerrors.Errorf("failed to load the KEV catalog: %w", err)
+		}
+		catalog.Fill(report.Results)
+
+		if opts.KEVOnly {
+			_ = countRemoved(report.Results, kevCount, func() error {
+				filterKEV(report.Results)
+				return nil
+			})
+		}
+	}
+
+	if opts.BaselineFile != "" {
+		b, err := baseline.Load(opts.BaselineFile)
+		if err != nil {
+			return xerrors.Errorf("failed to load the baseline file: %w", err)
+		}
+		_ = countRemoved(report.Results, baselineCount, func() error {
+			b.Apply(report.Results, opts.HideKnown)
+			if opts.MisconfDiff {
+				b.DiffMisconfigurations(report.Results)
+			}
+			return nil
+		})
+	}
+
 	return nil
 }
 
 // FilterResult filters out the result
 func FilterResult(ctx context.Context, result *types.Result, ignoreConf IgnoreConfig, opt FilterOptions) error {
 	// Convert dbTypes.Severity to string
-	severities := lo.Map(opt.Severities, func(s dbTypes.Severity, _ int) string {
-		return s.String()
-	})
+	severities := severityNames(opt.Severities)
 
-	filterVulnerabilities(result, severities, opt.IgnoreStatuses, ignoreConf)
-	filterMisconfigurations(result, severities, opt.IncludeNonFailures, ignoreConf)
-	filterSecrets(result, severities, ignoreConf)
+	ignoreStatuses := opt.IgnoreStatuses
+	if opt.HideWontFix && !slices.Contains(ignoreStatuses, dbTypes.StatusWillNotFix) {
+		ignoreStatuses = append(slices.Clone(ignoreStatuses), dbTypes.StatusWillNotFix)
+	}
+
+	var filtered types.FilteredCounts
+	filterVulnerabilities(result, overrideSeverities(opt.VulnSeverities, severities), ignoreStatuses, opt.PkgFilters,
+		opt.InternalPackages, ignoreConf, &filtered)
+	filterMisconfigurations(result, overrideSeverities(opt.MisconfSeverities, severities), opt.IncludeNonFailures, ignoreConf, &filtered)
+	filterSecrets(result, overrideSeverities(opt.SecretSeverities, severities), opt.MinSecretConf, ignoreConf, &filtered)
 	filterLicenses(result, severities, opt.IgnoreLicenses, ignoreConf)
 
 	if opt.PolicyFile != "" {
+		before := countFindings(*result)
 		if err := applyPolicy(ctx, result, opt.PolicyFile); err != nil {
 			return xerrors.Errorf("failed to apply the policy: %w", err)
 		}
+		filtered.Policy = before - countFindings(*result)
+	}
+	if opt.CountFiltered {
+		// The filters applied to the whole report, such as VEX, add their counts later
+		result.FilteredCounts = &filtered
 	}
 	sort.Sort(types.BySeverity(result.Vulnerabilities))
 
 	return nil
 }
 
-func filterVulnerabilities(result *types.Result, severities []string, ignoreStatuses []dbTypes.Status, ignoreConfig IgnoreConfig) {
+func severityNames(severities []dbTypes.Severity) []string {
+	return lo.Map(severities, func(s dbTypes.Severity, _ int) string {
+		return s.String()
+	})
+}
+
+// overrideSeverities returns the severities overridden for a class of findings, or the global severities if not overridden.
+func overrideSeverities(override []dbTypes.Severity, severities []string) []string {
+	if len(override) == 0 {
+		return severities
+	}
+	return severityNames(override)
+}
+
+// filterVulnerabilities filters out the vulnerabilities and adds the number of vulnerabilities filtered out by each filter to "filtered"
+func filterVulnerabilities(result *types.Result, severities []string, ignoreStatuses []dbTypes.Status, pkgFilters []string,
+	internalPackages []string, ignoreConfig IgnoreConfig, filtered *types.FilteredCounts) {
 	uniqVulns := make(map[string]types.DetectedVulnerability)
 	for _, vuln := range result.Vulnerabilities {
 		if vuln.Severity == "" {
@@ -89,16 +176,28 @@ func filterVulnerabilities(result *types.Result, severities []string, ignoreStat
 		switch {
 		// Filter by severity
 		case !slices.Contains(severities, vuln.Severity):
+			filtered.Severity++
 			continue
 		// Filter by status
 		case slices.Contains(ignoreStatuses, vuln.Status):
+			filtered.Status++
+			continue
+		// Filter by package name
+		case len(pkgFilters) > 0 && !matchPkgPatterns(vuln.PkgName, pkgFilters):
+			filtered.Package++
+			continue
+		// Filter out first-party packages whose names collide with public ones
+		case matchPkgPatterns(vuln.PkgName, internalPackages):
+			filtered.Package++
 			continue
 		}
 
 		// Filter by ignore file
-		if f := ignoreConfig.MatchVulnerability(vuln.VulnerabilityID, result.Target, vuln.PkgPath, vuln.PkgIdentifier.PURL); f != nil {
+		if f := ignoreConfig.MatchVulnerability(vuln.VulnerabilityID, result.Target, vuln.PkgPath, vuln.PkgIdentifier.PURL,
+			vuln.PkgName, vuln.InstalledVersion); f != nil {
 			result.ModifiedFindings = append(result.ModifiedFindings,
 				types.NewModifiedFinding(vuln, types.FindingStatusIgnored, f.Statement, ignoreConfig.FilePath))
+			filtered.IgnoreFile++
 			continue
 		}
 
@@ -117,14 +216,76 @@ func filterVulnerabilities(result *types.Result, severities []string, ignoreStat
 	}
 }
 
+// countFindings returns the number of findings counted in types.FilteredCounts.
+// Misconfigurations are counted only when failed, as passed checks are not findings.
+func countFindings(result types.Result) int {
+	failures := lo.CountBy(result.Misconfigurations, func(m types.DetectedMisconfiguration) bool {
+		return m.Status == types.MisconfStatusFailure
+	})
+	return len(result.Vulnerabilities) + failures + len(result.Secrets)
+}
+
+var (
+	vexCount      = func(c *types.FilteredCounts) *int { return &c.VEX }
+	kevCount      = func(c *types.FilteredCounts) *int { return &c.KEV }
+	baselineCount = func(c *types.FilteredCounts) *int { return &c.Baseline }
+)
+
+// countRemoved runs the filter applied to all the results, and adds the number of findings removed from each result
+// to the counter selected by "counter" if the result counts the filtered findings.
+func countRemoved(results types.Results, counter func(*types.FilteredCounts) *int, filter func() error) error {
+	before := lo.Map(results, func(r types.Result, _ int) int {
+		return countFindings(r)
+	})
+	if err := filter(); err != nil {
+		return err
+	}
+	for i := range results {
+		if results[i].FilteredCounts == nil || i >= len(before) {
+			continue
+		}
+		*counter(results[i].FilteredCounts) += before[i] - countFindings(results[i])
+	}
+	return nil
+}
+
+// matchPkgPatterns returns true if the package name matches any of the given patterns.
+func matchPkgPatterns(pkgName string, patterns []string) bool {
+	return lo.ContainsBy(patterns, func(pattern string) bool {
+		return matchPkgName(pattern, pkgName)
+	})
+}
+
+// matchPkgName returns true if the package name matches the glob pattern.
+// Package names are not file paths, so "*" also matches "/" like "**";
+// e.g. "github.com/aquasecurity/*" matches "github.com/aquasecurity/trivy-db/pkg" and "@babel/*" matches "@babel/core".
+func matchPkgName(pattern, pkgName string) bool {
+	// Replace the separator of doublestar with a character that never appears in package names
+	const sep = "\x00"
+	matched, _ := doublestar.Match(strings.ReplaceAll(pattern, "/", sep), strings.ReplaceAll(pkgName, "/", sep))
+	return matched
+}
+
+// filterKEV removes the vulnerabilities that are not in the KEV catalog so that the summaries count only the rest
+func filterKEV(results types.Results) {
+	for i := range results {
+		results[i].Vulnerabilities = slices.DeleteFunc(results[i].Vulnerabilities, func(v types.DetectedVulnerability) bool {
+			return v.KEV == nil
+		})
+	}
+}
+
 func filterMisconfigurations(result *types.Result, severities []string, includeNonFailures bool,
-	ignoreConfig IgnoreConfig) {
+	ignoreConfig IgnoreConfig, counts *types.FilteredCounts) {
 	var filtered []types.DetectedMisconfiguration
 	result.MisconfSummary = new(types.MisconfSummary)
 
 	for _, misconf := range result.Misconfigurations {
+		failure := lo.Ternary(misconf.Status == types.MisconfStatusFailure, 1, 0)
+
 		// Filter by severity
 		if !slices.Contains(severities, misconf.Severity) {
+			counts.Severity += failure
 			continue
 		}
 
@@ -132,10 +293,11 @@ func filterMisconfigurations(result *types.Result, severities []string, includeN
 		if f := ignoreConfig.MatchMisconfiguration(misconf.ID, misconf.AVDID, result.Target); f != nil {
 			result.ModifiedFindings = append(result.ModifiedFindings,
 				types.NewModifiedFinding(misconf, types.FindingStatusIgnored, f.Statement, ignoreConfig.FilePath))
+			counts.IgnoreFile += failure
 			continue
 		}
 
-		// Count successes and failures
+		// Count successes, failures and exceptions
 		summarize(misconf.Status, result.MisconfSummary)
 
 		if misconf.Status != types.MisconfStatusFailure && !includeNonFailures {
@@ -151,16 +313,23 @@ func filterMisconfigurations(result *types.Result, severities []string, includeN
 	}
 }
 
-func filterSecrets(result *types.Result, severities []string, ignoreConfig IgnoreConfig) {
+func filterSecrets(result *types.Result, severities []string, minConfidence ftypes.SecretConfidence, ignoreConfig IgnoreConfig,
+	counts *types.FilteredCounts) {
 	var filtered []types.DetectedSecret
 	for _, secret := range result.Secrets {
 		if !slices.Contains(severities, secret.Severity) {
 			// Filter by severity
+			counts.Severity++
+			continue
+		} else if minConfidence != "" && secret.Confidence != "" && secret.Confidence.Compare(minConfidence) < 0 {
+			// Filter by confidence. Secrets without the confidence, e.g. detected by old versions, are kept.
+			counts.Confidence++
 			continue
 		} else if f := ignoreConfig.MatchSecret(secret.RuleID, result.Target); f != nil {
 			// Filter by ignore file
 			result.ModifiedFindings = append(result.ModifiedFindings,
 				types.NewModifiedFinding(secret, types.FindingStatusIgnored, f.Statement, ignoreConfig.FilePath))
+			counts.IgnoreFile++
 			continue
 		}
 		filtered = append(filtered, secret)
@@ -209,6 +378,8 @@ func summarize(status types.MisconfStatus, summary *types.MisconfSummary) {
 		summary.Failures++
 	case types.MisconfStatusPassed:
 		summary.Successes++
+	case types.MisconfStatusException:
+		summary.Exceptions++
 	}
 }
 
@@ -258,6 +429,8 @@ func applyPolicy(ctx context.Context, result *types.Result, policyFile string) e
 				result.MisconfSummary.Failures--
 			case types.MisconfStatusPassed:
 				result.MisconfSummary.Successes--
+			case types.MisconfStatusException:
+				result.MisconfSummary.Exceptions--
 			}
 			result.ModifiedFindings = append(result.ModifiedFindings,
 				types.NewModifiedFinding(misconf, types.FindingStatusIgnored, "Filtered by Rego", policyFile))
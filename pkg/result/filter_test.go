@@ -188,12 +188,18 @@ func TestFilter(t *testing.T) {
 		}
 	)
 	type args struct {
-		report         types.Report
-		severities     []dbTypes.Severity
-		ignoreStatuses []dbTypes.Status
-		ignoreFile     string
-		policyFile     string
-		vexPath        string
+		report            types.Report
+		severities        []dbTypes.Severity
+		vulnSeverities    []dbTypes.Severity
+		misconfSeverities []dbTypes.Severity
+		secretSeverities  []dbTypes.Severity
+		ignoreStatuses    []dbTypes.Status
+		ignoreFile        string
+		policyFile        string
+		vexPath           string
+		pkgFilters        []string
+		internalPackages  []string
+		countFiltered     bool
 	}
 	tests := []struct {
 		name string
@@ -246,6 +252,93 @@ func TestFilter(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "class-specific severities",
+			args: args{
+				report: types.Report{
+					Results: []types.Result{
+						{
+							Vulnerabilities: []types.DetectedVulnerability{
+								vuln1,
+								vuln2, // filtered
+							},
+							Misconfigurations: []types.DetectedMisconfiguration{
+								misconf1, // filtered
+								misconf2,
+								misconf3,
+							},
+							Secrets: []types.DetectedSecret{
+								secret1,
+								secret2, // filtered
+							},
+						},
+					},
+				},
+				severities: []dbTypes.Severity{
+					dbTypes.SeverityCritical,
+					dbTypes.SeverityHigh,
+				},
+				vulnSeverities: []dbTypes.Severity{
+					dbTypes.SeverityLow,
+				},
+				misconfSeverities: []dbTypes.Severity{
+					dbTypes.SeverityLow,
+				},
+			},
+			want: types.Report{
+				Results: []types.Result{
+					{
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln1,
+						},
+						MisconfSummary: &types.MisconfSummary{
+							Successes: 1,
+							Failures:  1,
+						},
+						Misconfigurations: []types.DetectedMisconfiguration{
+							misconf3,
+						},
+						// "--severity" is used for secrets
+						Secrets: []types.DetectedSecret{
+							secret1,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "class-specific severities for secrets",
+			args: args{
+				report: types.Report{
+					Results: []types.Result{
+						{
+							Secrets: []types.DetectedSecret{
+								secret1, // filtered
+								secret2,
+								secret3,
+							},
+						},
+					},
+				},
+				severities: []dbTypes.Severity{
+					dbTypes.SeverityCritical,
+					dbTypes.SeverityHigh,
+				},
+				secretSeverities: []dbTypes.Severity{
+					dbTypes.SeverityLow,
+				},
+			},
+			want: types.Report{
+				Results: []types.Result{
+					{
+						Secrets: []types.DetectedSecret{
+							secret2,
+							secret3,
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "filter by VEX",
 			args: args{
@@ -327,6 +420,260 @@ func TestFilter(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "package name filter",
+			args: args{
+				report: types.Report{
+					Results: types.Results{
+						types.Result{
+							Target: "pom.xml",
+							Vulnerabilities: []types.DetectedVulnerability{
+								{
+									VulnerabilityID:  "CVE-2022-22965",
+									PkgName:          "org.springframework:spring-beans",
+									InstalledVersion: "5.3.17",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityCritical.String(),
+									},
+								},
+								{
+									VulnerabilityID:  "CVE-2020-8908",
+									PkgName:          "com.google.guava:guava",
+									InstalledVersion: "29.0-jre",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityLow.String(),
+									},
+								},
+								{
+									VulnerabilityID:  "CVE-2022-42003",
+									PkgName:          "com.fasterxml.jackson.core:jackson-databind",
+									InstalledVersion: "2.13.3",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityHigh.String(),
+									},
+								},
+							},
+						},
+					},
+				},
+				severities: []dbTypes.Severity{
+					dbTypes.SeverityCritical,
+					dbTypes.SeverityHigh,
+					dbTypes.SeverityLow,
+				},
+				pkgFilters: []string{
+					"org.springframework:*",
+					"com.fasterxml.jackson.*:*",
+				},
+			},
+			want: types.Report{
+				Results: types.Results{
+					{
+						Target: "pom.xml",
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2022-42003",
+								PkgName:          "com.fasterxml.jackson.core:jackson-databind",
+								InstalledVersion: "2.13.3",
+								Vulnerability: dbTypes.Vulnerability{
+									Severity: dbTypes.SeverityHigh.String(),
+								},
+							},
+							{
+								VulnerabilityID:  "CVE-2022-22965",
+								PkgName:          "org.springframework:spring-beans",
+								InstalledVersion: "5.3.17",
+								Vulnerability: dbTypes.Vulnerability{
+									Severity: dbTypes.SeverityCritical.String(),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "internal packages",
+			args: args{
+				report: types.Report{
+					Results: types.Results{
+						types.Result{
+							Target: "package-lock.json",
+							Packages: []ftypes.Package{
+								{
+									ID:      "@my-org/lodash@4.17.4",
+									Name:    "@my-org/lodash",
+									Version: "4.17.4",
+								},
+								{
+									ID:      "lodash@4.17.4",
+									Name:    "lodash",
+									Version: "4.17.4",
+								},
+							},
+							Vulnerabilities: []types.DetectedVulnerability{
+								{
+									// The internal package name collides with a public one
+									VulnerabilityID:  "CVE-2019-10744",
+									PkgName:          "@my-org/lodash",
+									InstalledVersion: "4.17.4",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityCritical.String(),
+									},
+								},
+								{
+									VulnerabilityID:  "CVE-2019-10744",
+									PkgName:          "lodash",
+									InstalledVersion: "4.17.4",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityCritical.String(),
+									},
+								},
+							},
+						},
+					},
+				},
+				severities: []dbTypes.Severity{dbTypes.SeverityCritical},
+				internalPackages: []string{
+					"@my-org/*",
+				},
+			},
+			want: types.Report{
+				Results: types.Results{
+					{
+						Target: "package-lock.json",
+						Packages: []ftypes.Package{
+							{
+								ID:      "@my-org/lodash@4.17.4",
+								Name:    "@my-org/lodash",
+								Version: "4.17.4",
+							},
+							{
+								ID:      "lodash@4.17.4",
+								Name:    "lodash",
+								Version: "4.17.4",
+							},
+						},
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2019-10744",
+								PkgName:          "lodash",
+								InstalledVersion: "4.17.4",
+								Vulnerability: dbTypes.Vulnerability{
+									Severity: dbTypes.SeverityCritical.String(),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "package name filter with Go module paths and npm scopes",
+			args: args{
+				report: types.Report{
+					Results: types.Results{
+						types.Result{
+							Target: "go.mod",
+							Vulnerabilities: []types.DetectedVulnerability{
+								{
+									VulnerabilityID:  "CVE-2024-0001",
+									PkgName:          "github.com/aquasecurity/trivy-db",
+									InstalledVersion: "v0.0.0-20240101",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityHigh.String(),
+									},
+								},
+								{
+									VulnerabilityID:  "CVE-2024-0002",
+									PkgName:          "github.com/aquasecurity/go-dep-parser/pkg",
+									InstalledVersion: "v0.0.0-20230101",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityMedium.String(),
+									},
+								},
+								{
+									VulnerabilityID:  "CVE-2024-0003",
+									PkgName:          "github.com/docker/docker",
+									InstalledVersion: "v24.0.0",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityCritical.String(),
+									},
+								},
+							},
+						},
+						types.Result{
+							Target: "package-lock.json",
+							Vulnerabilities: []types.DetectedVulnerability{
+								{
+									VulnerabilityID:  "CVE-2024-0004",
+									PkgName:          "@babel/traverse",
+									InstalledVersion: "7.22.0",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityCritical.String(),
+									},
+								},
+								{
+									VulnerabilityID:  "CVE-2024-0005",
+									PkgName:          "babel-traverse",
+									InstalledVersion: "6.26.0",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityCritical.String(),
+									},
+								},
+							},
+						},
+					},
+				},
+				severities: []dbTypes.Severity{
+					dbTypes.SeverityCritical,
+					dbTypes.SeverityHigh,
+					dbTypes.SeverityMedium,
+				},
+				pkgFilters: []string{
+					"github.com/aquasecurity/*",
+					"@babel/*",
+				},
+			},
+			want: types.Report{
+				Results: types.Results{
+					{
+						Target: "go.mod",
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2024-0002",
+								PkgName:          "github.com/aquasecurity/go-dep-parser/pkg",
+								InstalledVersion: "v0.0.0-20230101",
+								Vulnerability: dbTypes.Vulnerability{
+									Severity: dbTypes.SeverityMedium.String(),
+								},
+							},
+							{
+								VulnerabilityID:  "CVE-2024-0001",
+								PkgName:          "github.com/aquasecurity/trivy-db",
+								InstalledVersion: "v0.0.0-20240101",
+								Vulnerability: dbTypes.Vulnerability{
+									Severity: dbTypes.SeverityHigh.String(),
+								},
+							},
+						},
+					},
+					{
+						Target: "package-lock.json",
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2024-0004",
+								PkgName:          "@babel/traverse",
+								InstalledVersion: "7.22.0",
+								Vulnerability: dbTypes.Vulnerability{
+									Severity: dbTypes.SeverityCritical.String(),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "ignore file",
 			args: args{
@@ -336,12 +683,176 @@ func TestFilter(t *testing.T) {
 							Target: "package-lock.json",
 							Class:  types.ClassLangPkg,
 							Vulnerabilities: []types.DetectedVulnerability{
-								vuln1, // ignored
-								vuln2, // filtered by severity
-								vuln3,
-								vuln4,
-								vuln5, // ignored
-								vuln6, // ignored
+								vuln1, // ignored
+								vuln2, // filtered by severity
+								vuln3,
+								vuln4,
+								vuln5, // ignored
+								vuln6, // ignored
+							},
+						},
+						{
+							Target: "deployment.yaml",
+							Class:  types.ClassConfig,
+							Misconfigurations: []types.DetectedMisconfiguration{
+								misconf1,
+								misconf2,
+								misconf3,
+							},
+						},
+						{
+							Target: "config.yaml",
+							Secrets: []types.DetectedSecret{
+								secret1,
+								secret2,
+							},
+						},
+					},
+				},
+				severities: []dbTypes.Severity{
+					dbTypes.SeverityLow,
+					dbTypes.SeverityHigh,
+				},
+				ignoreFile: "testdata/.trivyignore",
+			},
+			want: types.Report{
+				Results: types.Results{
+					{
+						Target: "package-lock.json",
+						Class:  types.ClassLangPkg,
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln3,
+							vuln4,
+						},
+						ModifiedFindings: []types.ModifiedFinding{
+							{
+								Type:    types.FindingTypeVulnerability,
+								Status:  types.FindingStatusIgnored,
+								Source:  "testdata/.trivyignore",
+								Finding: vuln1,
+							},
+							{
+								Type:    types.FindingTypeVulnerability,
+								Status:  types.FindingStatusIgnored,
+								Source:  "testdata/.trivyignore",
+								Finding: vuln5,
+							},
+							{
+								Type:    types.FindingTypeVulnerability,
+								Status:  types.FindingStatusIgnored,
+								Source:  "testdata/.trivyignore",
+								Finding: vuln6,
+							},
+						},
+					},
+					{
+						Target: "deployment.yaml",
+						Class:  types.ClassConfig,
+						MisconfSummary: &types.MisconfSummary{
+							Successes: 1,
+							Failures:  1,
+						},
+						Misconfigurations: []types.DetectedMisconfiguration{
+							misconf1,
+						},
+						ModifiedFindings: []types.ModifiedFinding{
+							{
+								Type:    types.FindingTypeMisconfiguration,
+								Status:  types.FindingStatusIgnored,
+								Source:  "testdata/.trivyignore",
+								Finding: misconf3,
+							},
+						},
+					},
+					{
+						Target: "config.yaml",
+						Secrets: []types.DetectedSecret{
+							secret1,
+						},
+						ModifiedFindings: []types.ModifiedFinding{
+							{
+								Type:    types.FindingTypeSecret,
+								Status:  types.FindingStatusIgnored,
+								Source:  "testdata/.trivyignore",
+								Finding: secret2,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "count filtered vulnerabilities",
+			args: args{
+				report: types.Report{
+					Results: types.Results{
+						{
+							Target: "package-lock.json",
+							Class:  types.ClassLangPkg,
+							Vulnerabilities: []types.DetectedVulnerability{
+								vuln1, // ignored
+								vuln2, // filtered by severity
+								vuln3,
+								{
+									// filtered by status
+									VulnerabilityID:  "CVE-2020-0001",
+									PkgName:          "foo",
+									InstalledVersion: "1.2.3",
+									Status:           dbTypes.StatusAffected,
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityLow.String(),
+									},
+								},
+							},
+						},
+					},
+				},
+				severities:     []dbTypes.Severity{dbTypes.SeverityLow},
+				ignoreStatuses: []dbTypes.Status{dbTypes.StatusAffected},
+				ignoreFile:     "testdata/.trivyignore",
+				countFiltered:  true,
+			},
+			want: types.Report{
+				Results: types.Results{
+					{
+						Target: "package-lock.json",
+						Class:  types.ClassLangPkg,
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln3,
+						},
+						ModifiedFindings: []types.ModifiedFinding{
+							{
+								Type:    types.FindingTypeVulnerability,
+								Status:  types.FindingStatusIgnored,
+								Source:  "testdata/.trivyignore",
+								Finding: vuln1,
+							},
+						},
+						FilteredCounts: &types.FilteredCounts{
+							Severity:   1,
+							Status:     1,
+							IgnoreFile: 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "count filtered findings of all the filters and classes",
+			args: args{
+				report: types.Report{
+					ArtifactName: ".",
+					ArtifactType: artifact.TypeFilesystem,
+					Results: types.Results{
+						{
+							Target:   "gobinary",
+							Class:    types.ClassLangPkg,
+							Type:     ftypes.GoBinary,
+							Packages: []ftypes.Package{pkg1},
+							Vulnerabilities: []types.DetectedVulnerability{
+								vuln1, // filtered by VEX
+								vuln2,
+								vuln7, // filtered by package name
 							},
 						},
 						{
@@ -349,86 +860,74 @@ func TestFilter(t *testing.T) {
 							Class:  types.ClassConfig,
 							Misconfigurations: []types.DetectedMisconfiguration{
 								misconf1,
-								misconf2,
-								misconf3,
+								misconf2, // passed, not counted
+								misconf3, // filtered by severity
 							},
 						},
 						{
 							Target: "config.yaml",
+							Class:  types.ClassSecret,
 							Secrets: []types.DetectedSecret{
 								secret1,
-								secret2,
+								secret2, // filtered by severity
 							},
 						},
 					},
 				},
-				severities: []dbTypes.Severity{
-					dbTypes.SeverityLow,
-					dbTypes.SeverityHigh,
-				},
-				ignoreFile: "testdata/.trivyignore",
+				severities:        []dbTypes.Severity{dbTypes.SeverityLow, dbTypes.SeverityCritical},
+				misconfSeverities: []dbTypes.Severity{dbTypes.SeverityHigh},
+				secretSeverities:  []dbTypes.Severity{dbTypes.SeverityHigh},
+				vexPath:           "testdata/openvex.json",
+				pkgFilters:        []string{"foo"},
+				countFiltered:     true,
 			},
 			want: types.Report{
+				ArtifactName: ".",
+				ArtifactType: artifact.TypeFilesystem,
 				Results: types.Results{
 					{
-						Target: "package-lock.json",
-						Class:  types.ClassLangPkg,
+						Target:   "gobinary",
+						Class:    types.ClassLangPkg,
+						Type:     ftypes.GoBinary,
+						Packages: []ftypes.Package{pkg1},
 						Vulnerabilities: []types.DetectedVulnerability{
-							vuln3,
-							vuln4,
+							vuln2,
 						},
 						ModifiedFindings: []types.ModifiedFinding{
 							{
-								Type:    types.FindingTypeVulnerability,
-								Status:  types.FindingStatusIgnored,
-								Source:  "testdata/.trivyignore",
-								Finding: vuln1,
-							},
-							{
-								Type:    types.FindingTypeVulnerability,
-								Status:  types.FindingStatusIgnored,
-								Source:  "testdata/.trivyignore",
-								Finding: vuln5,
-							},
-							{
-								Type:    types.FindingTypeVulnerability,
-								Status:  types.FindingStatusIgnored,
-								Source:  "testdata/.trivyignore",
-								Finding: vuln6,
+								Type:      types.FindingTypeVulnerability,
+								Status:    types.FindingStatusNotAffected,
+								Statement: "vulnerable_code_not_in_execute_path",
+								Source:    "testdata/openvex.json",
+								Finding:   vuln1,
 							},
 						},
+						FilteredCounts: &types.FilteredCounts{
+							Package: 1,
+							VEX:     1,
+						},
 					},
 					{
 						Target: "deployment.yaml",
 						Class:  types.ClassConfig,
 						MisconfSummary: &types.MisconfSummary{
-							Successes: 1,
-							Failures:  1,
+							Failures: 1,
 						},
 						Misconfigurations: []types.DetectedMisconfiguration{
 							misconf1,
 						},
-						ModifiedFindings: []types.ModifiedFinding{
-							{
-								Type:    types.FindingTypeMisconfiguration,
-								Status:  types.FindingStatusIgnored,
-								Source:  "testdata/.trivyignore",
-								Finding: misconf3,
-							},
+						FilteredCounts: &types.FilteredCounts{
+							Severity: 1,
 						},
 					},
 					{
 						Target: "config.yaml",
+						Class:  types.ClassSecret,
 						Secrets: []types.DetectedSecret{
 							secret1,
 						},
-						ModifiedFindings: []types.ModifiedFinding{
-							{
-								Type:    types.FindingTypeSecret,
-								Status:  types.FindingStatusIgnored,
-								Source:  "testdata/.trivyignore",
-								Finding: secret2,
-							},
+						FilteredCounts: &types.FilteredCounts{
+							Severity: 1,
 						},
 					},
 				},
@@ -1013,14 +1512,454 @@ func TestFilter(t *testing.T) {
 			}
 
 			err := result.Filter(ctx, tt.args.report, result.FilterOptions{
-				Severities:     tt.args.severities,
-				VEXSources:     vexSources,
-				IgnoreStatuses: tt.args.ignoreStatuses,
-				IgnoreFile:     tt.args.ignoreFile,
-				PolicyFile:     tt.args.policyFile,
+				Severities:        tt.args.severities,
+				VulnSeverities:    tt.args.vulnSeverities,
+				MisconfSeverities: tt.args.misconfSeverities,
+				SecretSeverities:  tt.args.secretSeverities,
+				VEXSources:        vexSources,
+				IgnoreStatuses:    tt.args.ignoreStatuses,
+				IgnoreFile:        tt.args.ignoreFile,
+				PolicyFile:        tt.args.policyFile,
+				PkgFilters:        tt.args.pkgFilters,
+				InternalPackages:  tt.args.internalPackages,
+				CountFiltered:     tt.args.countFiltered,
 			})
 			require.NoError(t, err)
 			assert.Equal(t, tt.want, tt.args.report)
 		})
 	}
 }
+
+func TestFilter_ignorePackageVersions(t *testing.T) {
+	newVuln := func(id, pkgName, version string) types.DetectedVulnerability {
+		return types.DetectedVulnerability{
+			VulnerabilityID:  id,
+			PkgName:          pkgName,
+			InstalledVersion: version,
+			Vulnerability: dbTypes.Vulnerability{
+				Severity: dbTypes.SeverityLow.String(),
+			},
+		}
+	}
+	var (
+		vulnerableLodash = newVuln("CVE-2019-0010", "lodash", "4.16.0")
+		fixedLodash      = newVuln("CVE-2019-0010", "lodash", "4.17.21")
+		prefixedLodash   = newVuln("CVE-2019-0011", "lodash", "v4.17.20")
+		express          = newVuln("CVE-2019-0012", "express", "4.17.1")
+		bodyParser       = newVuln("CVE-2019-0012", "body-parser", "1.19.0")
+		oldLodash        = newVuln("CVE-2019-0013", "lodash", "0.9.0")
+		misconf          = types.DetectedMisconfiguration{
+			ID:       "ID100",
+			AVDID:    "AVD-ID100",
+			Severity: dbTypes.SeverityLow.String(),
+			Status:   types.MisconfStatusFailure,
+		}
+	)
+
+	tests := []struct {
+		name       string
+		ignoreFile string
+	}{
+		{
+			name:       "trivyignore",
+			ignoreFile: "testdata/pkg.trivyignore",
+		},
+		{
+			name:       "trivyignore.yaml",
+			ignoreFile: "testdata/pkg.trivyignore.yaml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := types.Report{
+				Results: types.Results{
+					{
+						Target: "package-lock.json",
+						Class:  types.ClassLangPkg,
+						Vulnerabilities: []types.DetectedVulnerability{
+							vulnerableLodash, // ignored
+							fixedLodash,      // the version doesn't satisfy the constraint
+							prefixedLodash,   // ignored
+							express,          // ignored
+							bodyParser,       // another package
+							oldLodash,        // no valid ignore entry
+						},
+					},
+					{
+						Target: "Dockerfile",
+						Class:  types.ClassConfig,
+						Misconfigurations: []types.DetectedMisconfiguration{
+							misconf, // not a package
+						},
+					},
+				},
+			}
+			err := result.Filter(context.Background(), report, result.FilterOptions{
+				Severities: []dbTypes.Severity{dbTypes.SeverityLow},
+				IgnoreFile: tt.ignoreFile,
+			})
+			require.NoError(t, err)
+
+			want := types.Results{
+				{
+					Target: "package-lock.json",
+					Class:  types.ClassLangPkg,
+					Vulnerabilities: []types.DetectedVulnerability{
+						bodyParser,
+						oldLodash,
+						fixedLodash,
+					},
+					ModifiedFindings: []types.ModifiedFinding{
+						types.NewModifiedFinding(vulnerableLodash, types.FindingStatusIgnored, "", tt.ignoreFile),
+						types.NewModifiedFinding(prefixedLodash, types.FindingStatusIgnored, "", tt.ignoreFile),
+						types.NewModifiedFinding(express, types.FindingStatusIgnored, "", tt.ignoreFile),
+					},
+				},
+				{
+					Target: "Dockerfile",
+					Class:  types.ClassConfig,
+					MisconfSummary: &types.MisconfSummary{
+						Failures: 1,
+					},
+					Misconfigurations: []types.DetectedMisconfiguration{
+						misconf,
+					},
+				},
+			}
+			assert.Equal(t, want, report.Results)
+		})
+	}
+}
+
+func TestFilter_minSecretConfidence(t *testing.T) {
+	newSecret := func(ruleID string, confidence ftypes.SecretConfidence) types.DetectedSecret {
+		return types.DetectedSecret{
+			RuleID:     ruleID,
+			Severity:   dbTypes.SeverityHigh.String(),
+			Confidence: confidence,
+		}
+	}
+	var (
+		lowSecret     = newSecret("generic-password", ftypes.SecretConfidenceLow)
+		mediumSecret  = newSecret("slack-web-hook", ftypes.SecretConfidenceMedium)
+		highSecret    = newSecret("aws-access-key-id", ftypes.SecretConfidenceHigh)
+		unknownSecret = newSecret("github-pat", "")
+	)
+
+	tests := []struct {
+		name          string
+		minConfidence ftypes.SecretConfidence
+		want          []types.DetectedSecret
+		wantFiltered  int
+	}{
+		{
+			name: "no minimum",
+			want: []types.DetectedSecret{
+				lowSecret,
+				mediumSecret,
+				highSecret,
+				unknownSecret,
+			},
+		},
+		{
+			name:          "medium",
+			minConfidence: ftypes.SecretConfidenceMedium,
+			want: []types.DetectedSecret{
+				mediumSecret,
+				highSecret,
+				unknownSecret, // no confidence
+			},
+			wantFiltered: 1,
+		},
+		{
+			name:          "high",
+			minConfidence: ftypes.SecretConfidenceHigh,
+			want: []types.DetectedSecret{
+				highSecret,
+				unknownSecret, // no confidence
+			},
+			wantFiltered: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := types.Report{
+				Results: types.Results{
+					{
+						Target: "config.yaml",
+						Class:  types.ClassSecret,
+						Secrets: []types.DetectedSecret{
+							lowSecret,
+							mediumSecret,
+							highSecret,
+							unknownSecret,
+						},
+					},
+				},
+			}
+			err := result.Filter(context.Background(), report, result.FilterOptions{
+				Severities:    []dbTypes.Severity{dbTypes.SeverityHigh},
+				MinSecretConf: tt.minConfidence,
+				CountFiltered: true,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, report.Results[0].Secrets)
+			assert.Equal(t, &types.FilteredCounts{Confidence: tt.wantFiltered}, report.Results[0].FilteredCounts)
+		})
+	}
+}
+
+func TestFilter_hideWontFix(t *testing.T) {
+	newVuln := func(id string, status dbTypes.Status) types.DetectedVulnerability {
+		return types.DetectedVulnerability{
+			VulnerabilityID:  id,
+			PkgName:          "openssl-libs",
+			InstalledVersion: "1:3.0.7-27.el9",
+			Status:           status,
+			Vulnerability: dbTypes.Vulnerability{
+				Severity: dbTypes.SeverityHigh.String(),
+			},
+		}
+	}
+	var (
+		fixedVuln    = newVuln("CVE-2024-0001", dbTypes.StatusFixed)
+		wontFixVuln  = newVuln("CVE-2024-0002", dbTypes.StatusWillNotFix)
+		deferredVuln = newVuln("CVE-2024-0003", dbTypes.StatusFixDeferred)
+	)
+
+	tests := []struct {
+		name           string
+		hideWontFix    bool
+		ignoreStatuses []dbTypes.Status
+		want           []types.DetectedVulnerability
+		wantFiltered   int
+	}{
+		{
+			name: "not hidden",
+			want: []types.DetectedVulnerability{
+				fixedVuln,
+				wontFixVuln,
+				deferredVuln,
+			},
+		},
+		{
+			name:        "hide won't fix",
+			hideWontFix: true,
+			want: []types.DetectedVulnerability{
+				fixedVuln,
+				deferredVuln,
+			},
+			wantFiltered: 1,
+		},
+		{
+			name:        "with ignored statuses",
+			hideWontFix: true,
+			ignoreStatuses: []dbTypes.Status{
+				dbTypes.StatusFixDeferred,
+			},
+			want: []types.DetectedVulnerability{
+				fixedVuln,
+			},
+			wantFiltered: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := types.Report{
+				Results: types.Results{
+					{
+						Target: "redhat (redhat 9.4)",
+						Class:  types.ClassOSPkg,
+						Vulnerabilities: []types.DetectedVulnerability{
+							fixedVuln,
+							wontFixVuln,
+							deferredVuln,
+						},
+					},
+				},
+			}
+			err := result.Filter(context.Background(), report, result.FilterOptions{
+				Severities:     []dbTypes.Severity{dbTypes.SeverityHigh},
+				IgnoreStatuses: tt.ignoreStatuses,
+				HideWontFix:    tt.hideWontFix,
+				CountFiltered:  true,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, report.Results[0].Vulnerabilities)
+			assert.Equal(t, &types.FilteredCounts{Status: tt.wantFiltered}, report.Results[0].FilteredCounts)
+		})
+	}
+}
+
+func TestFilter_kev(t *testing.T) {
+	log4shell := types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2021-44228",
+		PkgName:          "org.apache.logging.log4j:log4j-core",
+		InstalledVersion: "2.14.1",
+		Vulnerability: dbTypes.Vulnerability{
+			Severity: dbTypes.SeverityCritical.String(),
+		},
+	}
+	other := types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2020-0002",
+		PkgName:          "org.apache.logging.log4j:log4j-core",
+		InstalledVersion: "2.14.1",
+		Vulnerability: dbTypes.Vulnerability{
+			Severity: dbTypes.SeverityCritical.String(),
+		},
+	}
+	kev := &types.KEV{
+		DateAdded:  "2021-12-10",
+		DueDate:    "2021-12-24",
+		Ransomware: true,
+	}
+
+	tests := []struct {
+		name         string
+		kevSource    string
+		kevOnly      bool
+		want         []string
+		wantFiltered int
+	}{
+		{
+			name: "no catalog",
+			want: []string{
+				"CVE-2020-0002",
+				"CVE-2021-44228",
+			},
+		},
+		{
+			name:      "fill the catalog",
+			kevSource: "testdata/known_exploited_vulnerabilities.json",
+			want: []string{
+				"CVE-2020-0002",
+				"CVE-2021-44228",
+			},
+		},
+		{
+			name:      "kev only",
+			kevSource: "testdata/known_exploited_vulnerabilities.json",
+			kevOnly:   true,
+			want: []string{
+				"CVE-2021-44228",
+			},
+			wantFiltered: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := types.Report{
+				Results: types.Results{
+					{
+						Target: "pom.xml",
+						Class:  types.ClassLangPkg,
+						Vulnerabilities: []types.DetectedVulnerability{
+							log4shell,
+							other,
+						},
+					},
+				},
+			}
+			err := result.Filter(context.Background(), report, result.FilterOptions{
+				Severities:    []dbTypes.Severity{dbTypes.SeverityCritical},
+				KEVSource:     tt.kevSource,
+				KEVOnly:       tt.kevOnly,
+				CountFiltered: true,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, &types.FilteredCounts{KEV: tt.wantFiltered}, report.Results[0].FilteredCounts)
+
+			var got []string
+			for _, v := range report.Results[0].Vulnerabilities {
+				got = append(got, v.VulnerabilityID)
+				if v.VulnerabilityID == log4shell.VulnerabilityID && tt.kevSource != "" {
+					assert.Equal(t, kev, v.KEV)
+				} else {
+					assert.Nil(t, v.KEV)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilter_baseline(t *testing.T) {
+	known := types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2024-0001",
+		PkgID:            "musl@1.2.5-r0",
+		PkgName:          "musl",
+		InstalledVersion: "1.2.5-r0",
+		Vulnerability: dbTypes.Vulnerability{
+			Severity: dbTypes.SeverityHigh.String(),
+		},
+	}
+	added := types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2024-0002",
+		PkgID:            "musl@1.2.5-r0",
+		PkgName:          "musl",
+		InstalledVersion: "1.2.5-r0",
+		Vulnerability: dbTypes.Vulnerability{
+			Severity: dbTypes.SeverityHigh.String(),
+		},
+	}
+
+	tests := []struct {
+		name       string
+		hideKnown  bool
+		want       map[string]types.BaselineStatus
+		wantHidden []string
+	}{
+		{
+			name: "mark vulnerabilities",
+			want: map[string]types.BaselineStatus{
+				"CVE-2024-0001": types.BaselineStatusKnown,
+				"CVE-2024-0002": types.BaselineStatusNew,
+			},
+		},
+		{
+			name:      "hide known vulnerabilities",
+			hideKnown: true,
+			want: map[string]types.BaselineStatus{
+				"CVE-2024-0002": types.BaselineStatusNew,
+			},
+			wantHidden: []string{"CVE-2024-0001"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := types.Report{
+				Results: types.Results{
+					{
+						Target: "alpine:3.20 (alpine 3.20.0)",
+						Class:  types.ClassOSPkg,
+						Type:   ftypes.Alpine,
+						Vulnerabilities: []types.DetectedVulnerability{
+							known,
+							added,
+						},
+					},
+				},
+			}
+			err := result.Filter(context.Background(), report, result.FilterOptions{
+				Severities:    []dbTypes.Severity{dbTypes.SeverityHigh},
+				BaselineFile:  "testdata/baseline.json",
+				HideKnown:     tt.hideKnown,
+				CountFiltered: true,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, &types.FilteredCounts{Baseline: len(tt.wantHidden)}, report.Results[0].FilteredCounts)
+
+			got := make(map[string]types.BaselineStatus)
+			for _, v := range report.Results[0].Vulnerabilities {
+				got[v.VulnerabilityID] = v.BaselineStatus
+			}
+			assert.Equal(t, tt.want, got)
+
+			var hidden []string
+			for _, f := range report.Results[0].ModifiedFindings {
+				hidden = append(hidden, f.Finding.(types.DetectedVulnerability).VulnerabilityID)
+			}
+			assert.Equal(t, tt.wantHidden, hidden)
+		})
+	}
+}
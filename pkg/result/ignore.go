@@ -15,6 +15,7 @@ import (
 	"golang.org/x/xerrors"
 	"gopkg.in/yaml.v3"
 
+	"github.com/aquasecurity/go-version/pkg/semver"
 	"github.com/aquasecurity/trivy/pkg/clock"
 	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/purl"
@@ -38,6 +39,20 @@ type IgnoreFinding struct {
 	// required: false
 	PURLs []*purl.PackageURL `yaml:"-"` // Filled in UnmarshalYAML
 
+	// Package is the name of the package to ignore, e.g. "lodash".
+	// If Package is not set, the ignore finding is applied to all packages.
+	// The field is currently available only for vulnerabilities.
+	// required: false
+	Package string `yaml:"package"`
+
+	// Version is the semver constraint of the package versions to ignore, e.g. "<4.17.0".
+	// If Version is not set, the ignore finding is applied to all versions of Package.
+	// Findings in packages whose versions don't satisfy the constraint or are not semver are reported.
+	// required: false
+	Version string `yaml:"version"`
+
+	versions semver.Constraints // Parsed from Version
+
 	// ExpiredAt is the expiration date of the ignore finding.
 	// If ExpiredAt is not set, the ignore finding is always valid.
 	// required: false
@@ -78,17 +93,58 @@ func (i *IgnoreFinding) UnmarshalYAML(value *yaml.Node) error {
 		i.PURLs = append(i.PURLs, parsedPURL)
 	}
 
+	return i.parseVersion()
+}
+
+// parseVersion parses the version constraint of the ignore finding
+func (i *IgnoreFinding) parseVersion() error {
+	if i.Version == "" {
+		return nil
+	} else if i.Package == "" {
+		return xerrors.Errorf("version constraint without package in the ignore file, id: %s, version: %s", i.ID, i.Version)
+	}
+	c, err := semver.NewConstraints(i.Version)
+	if err != nil {
+		return xerrors.Errorf("invalid version constraint in the ignore file, id: %s, version: %s: %w", i.ID, i.Version, err)
+	}
+	i.versions = c
 	return nil
 }
 
+// matchPackage returns true if the package satisfies Package and Version of the ignore finding.
+// Findings without a package, such as misconfigurations, never match ignore findings with Package.
+func (i *IgnoreFinding) matchPackage(name, ver string) bool {
+	if i.Package == "" {
+		return true
+	} else if name != i.Package {
+		return false
+	} else if i.Version == "" {
+		return true
+	}
+
+	v, err := semver.Parse(strings.TrimPrefix(ver, "v"))
+	if err != nil {
+		log.Debug("Unable to parse the package version for the ignore finding", log.String("id", i.ID),
+			log.String("package", name), log.String("version", ver), log.Err(err))
+		return false
+	}
+	return i.versions.Check(v)
+}
+
 type IgnoreFindings []IgnoreFinding
 
 func (f *IgnoreFindings) Match(id, path string, pkg *packageurl.PackageURL) *IgnoreFinding {
+	return f.MatchPackage(id, path, pkg, "", "")
+}
+
+// MatchPackage is the same as Match, but also matches the name and version of the package
+// against ignore findings with Package and Version.
+func (f *IgnoreFindings) MatchPackage(id, path string, pkg *packageurl.PackageURL, pkgName, pkgVersion string) *IgnoreFinding {
 	for _, finding := range *f {
 		if id != finding.ID {
 			continue
 		}
-		if !matchPath(path, finding.Paths) || !matchPURL(pkg, finding.PURLs) {
+		if !matchPath(path, finding.Paths) || !matchPURL(pkg, finding.PURLs) || !finding.matchPackage(pkgName, pkgVersion) {
 			continue
 		}
 
@@ -147,13 +203,13 @@ type IgnoreConfig struct {
 	Licenses          IgnoreFindings `yaml:"licenses"`
 }
 
-func (c *IgnoreConfig) MatchVulnerability(vulnID, filePath, pkgPath string, pkg *packageurl.PackageURL) *IgnoreFinding {
+func (c *IgnoreConfig) MatchVulnerability(vulnID, filePath, pkgPath string, pkg *packageurl.PackageURL, pkgName, pkgVersion string) *IgnoreFinding {
 	paths := []string{
 		filePath,
 		pkgPath,
 	}
 	for _, p := range paths {
-		if f := c.Vulnerabilities.Match(vulnID, p, pkg); f != nil {
+		if f := c.Vulnerabilities.MatchPackage(vulnID, p, pkg, pkgName, pkgVersion); f != nil {
 			return f
 		}
 	}
@@ -259,10 +315,17 @@ func parseIgnore(ignoreFile string) (IgnoreFindings, error) {
 				continue
 			}
 		}
-		ignoredFindings = append(ignoredFindings, IgnoreFinding{
+		finding := IgnoreFinding{
 			ID:        fields[0],
 			ExpiredAt: exp,
-		})
+			Package:   getFieldValue(fields, "pkg:"),
+			Version:   getFieldValue(fields, "version:"),
+		}
+		if err = finding.parseVersion(); err != nil {
+			log.Warn("Error while parsing version constraint in .trivyignore file", log.Err(err))
+			continue
+		}
+		ignoredFindings = append(ignoredFindings, finding)
 	}
 
 	return ignoredFindings, nil
@@ -277,3 +340,13 @@ func getExpirationDate(fields []string) (time.Time, error) {
 
 	return time.Time{}, nil
 }
+
+// getFieldValue returns the value of the field with the prefix, e.g. "lodash" for "pkg:lodash"
+func getFieldValue(fields []string, prefix string) string {
+	for _, field := range fields[1:] {
+		if strings.HasPrefix(field, prefix) {
+			return strings.TrimPrefix(field, prefix)
+		}
+	}
+	return ""
+}
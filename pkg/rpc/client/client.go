@@ -1,6 +1,7 @@
 package client
 
 import (
+	"cmp"
 	"context"
 	"crypto/tls"
 	"net/http"
@@ -15,6 +16,10 @@ import (
 	rpc "github.com/aquasecurity/trivy/rpc/scanner"
 )
 
+// DefaultMaxResponseSize is the maximum size of responses from the server by default.
+// It is large enough for the results of huge artifacts, but finite to protect the client from untrusted servers.
+const DefaultMaxResponseSize int64 = 1 << 30 // 1GiB
+
 type options struct {
 	rpcClient rpc.Scanner
 }
@@ -34,6 +39,9 @@ type ScannerOption struct {
 	Insecure      bool
 	CustomHeaders http.Header
 	PathPrefix    string
+
+	// Maximum size of responses in bytes (DefaultMaxResponseSize if 0)
+	MaxResponseSize int64
 }
 
 // Scanner implements the RPC scanner
@@ -46,7 +54,12 @@ type Scanner struct {
 func NewScanner(scannerOptions ScannerOption, opts ...Option) Scanner {
 	tr := http.DefaultTransport.(*http.Transport).Clone()
 	tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: scannerOptions.Insecure}
-	httpClient := &http.Client{Transport: tr}
+	httpClient := &http.Client{
+		Transport: limitTransport{
+			base:  tr,
+			limit: cmp.Or(scannerOptions.MaxResponseSize, DefaultMaxResponseSize),
+		},
+	}
 
 	var twirpOpts []twirp.ClientOption
 	if scannerOptions.PathPrefix != "" {
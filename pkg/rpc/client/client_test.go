@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -253,3 +254,58 @@ func TestScanner_ScanServerInsecure(t *testing.T) {
 		})
 	}
 }
+
+func TestScanner_ScanMaxResponseSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		chunked bool
+		wantErr string
+	}{
+		{
+			name: "within the limit",
+			size: 0,
+		},
+		{
+			name:    "declared size larger than the limit",
+			size:    2048,
+			wantErr: "response size (2048 bytes) exceeds the limit (1024 bytes)",
+		},
+		{
+			name:    "chunked response larger than the limit",
+			size:    2048,
+			chunked: true,
+			wantErr: "response exceeds the limit (1024 bytes)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A stub server returning a response of the given size
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/protobuf")
+				if !tt.chunked {
+					w.Header().Set("Content-Length", strconv.Itoa(tt.size))
+					w.Write(make([]byte, tt.size))
+					return
+				}
+				// Flushing before writing the whole body makes the response chunked without Content-Length
+				for range tt.size / 256 {
+					w.Write(make([]byte, 256))
+					w.(http.Flusher).Flush()
+				}
+			}))
+			defer ts.Close()
+
+			s := NewScanner(ScannerOption{
+				RemoteURL:       ts.URL,
+				MaxResponseSize: 1024,
+			})
+			_, _, err := s.Scan(context.Background(), "dummy", "", nil, types.ScanOptions{})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"io"
+	"net/http"
+
+	"golang.org/x/xerrors"
+)
+
+// limitTransport aborts responses larger than the limit,
+// so that a misbehaving or malicious server cannot exhaust the memory of the client.
+type limitTransport struct {
+	base  http.RoundTripper
+	limit int64
+}
+
+func (t limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fail fast without reading the body if the server declares the size
+	if resp.ContentLength > t.limit {
+		_ = resp.Body.Close()
+		return nil, xerrors.Errorf("response size (%d bytes) exceeds the limit (%d bytes)", resp.ContentLength, t.limit)
+	}
+
+	// The size may be unknown, e.g. chunked responses, or wrong
+	resp.Body = &limitedBody{
+		ReadCloser: resp.Body,
+		limit:      t.limit,
+	}
+	return resp, nil
+}
+
+// limitedBody returns an error once more bytes than the limit are read
+type limitedBody struct {
+	io.ReadCloser
+	limit int64
+	read  int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		return n, xerrors.Errorf("response exceeds the limit (%d bytes)", b.limit)
+	}
+	return n, err
+}
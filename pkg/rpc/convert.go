@@ -157,15 +157,17 @@ func ConvertToRPCSecretFindings(findings []ftypes.SecretFinding) []*common.Secre
 	var rpcFindings []*common.SecretFinding
 	for _, f := range findings {
 		rpcFindings = append(rpcFindings, &common.SecretFinding{
-			RuleId:    f.RuleID,
-			Category:  string(f.Category),
-			Severity:  f.Severity,
-			Title:     f.Title,
-			EndLine:   int32(f.EndLine),
-			StartLine: int32(f.StartLine),
-			Code:      ConvertToRPCCode(f.Code),
-			Match:     f.Match,
-			Layer:     ConvertToRPCLayer(f.Layer),
+			RuleId:     f.RuleID,
+			Category:   string(f.Category),
+			Severity:   f.Severity,
+			Title:      f.Title,
+			EndLine:    int32(f.EndLine),
+			StartLine:  int32(f.StartLine),
+			Code:       ConvertToRPCCode(f.Code),
+			Match:      f.Match,
+			Layer:      ConvertToRPCLayer(f.Layer),
+			Entropy:    f.Entropy,
+			Confidence: string(f.Confidence),
 		})
 	}
 	return rpcFindings
@@ -514,6 +516,8 @@ func ConvertFromRPCSecretFindings(rpcFindings []*common.SecretFinding) []ftypes.
 				DiffID:    finding.Layer.DiffId,
 				CreatedBy: finding.Layer.CreatedBy,
 			},
+			Entropy:    finding.Entropy,
+			Confidence: ftypes.SecretConfidence(finding.Confidence),
 		})
 	}
 	return findings
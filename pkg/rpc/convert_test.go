@@ -1056,3 +1056,38 @@ func TestConvertFromRPCLicenseFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertSecretFindings(t *testing.T) {
+	findings := []ftypes.SecretFinding{
+		{
+			RuleID:    "aws-access-key-id",
+			Category:  ftypes.SecretRuleCategory("AWS"),
+			Severity:  "CRITICAL",
+			Title:     "AWS Access Key ID",
+			StartLine: 1,
+			EndLine:   1,
+			Code: ftypes.Code{
+				Lines: []ftypes.Line{
+					{
+						Number:  1,
+						Content: "AWS_ACCESS_KEY_ID=********************",
+						IsCause: true,
+					},
+				},
+			},
+			Match: "AWS_ACCESS_KEY_ID=********************",
+			Layer: ftypes.Layer{
+				DiffID: "sha256:beee9f30bc1f711043e78d4a2be0668955d4b761d587d6f60c2c8dc081efb203",
+			},
+			Entropy:    3.98,
+			Confidence: ftypes.SecretConfidenceHigh,
+		},
+	}
+
+	rpcFindings := ConvertToRPCSecretFindings(findings)
+	assert.Equal(t, 3.98, rpcFindings[0].Entropy)
+	assert.Equal(t, "high", rpcFindings[0].Confidence)
+
+	// The entropy and the confidence are kept in client/server mode
+	assert.Equal(t, findings, ConvertFromRPCSecretFindings(rpcFindings))
+}
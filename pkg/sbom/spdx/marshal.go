@@ -209,19 +209,10 @@ func (m *Marshaler) Marshal(ctx context.Context, bom *core.BOM) (*spdx.Document,
 		DataLicense:       spdx.DataLicense,
 		SPDXIdentifier:    DocumentSPDXIdentifier,
 		DocumentName:      root.Name,
-		DocumentNamespace: getDocumentNamespace(root),
+		DocumentNamespace: documentNamespace(string(root.Type), root.Name),
 		CreationInfo: &spdx.CreationInfo{
-			Creators: []common.Creator{
-				{
-					Creator:     CreatorOrganization,
-					CreatorType: "Organization",
-				},
-				{
-					Creator:     fmt.Sprintf("%s-%s", CreatorTool, m.appVersion),
-					CreatorType: "Tool",
-				},
-			},
-			Created: timeNow,
+			Creators: m.creators(),
+			Created:  timeNow,
 		},
 		Packages:      packages,
 		Relationships: relationShips,
@@ -229,6 +220,19 @@ func (m *Marshaler) Marshal(ctx context.Context, bom *core.BOM) (*spdx.Document,
 	}, nil
 }
 
+func (m *Marshaler) creators() []common.Creator {
+	return []common.Creator{
+		{
+			Creator:     CreatorOrganization,
+			CreatorType: "Organization",
+		},
+		{
+			Creator:     fmt.Sprintf("%s-%s", CreatorTool, m.appVersion),
+			CreatorType: "Tool",
+		},
+	}
+}
+
 func (m *Marshaler) packageDownloadLocation(root *core.Component) string {
 	location := noneField
 	// this field is used for git/mercurial/subversion/bazaar:
@@ -509,11 +513,11 @@ func elementID(elementType, pkgID string) spdx.ElementID {
 	return spdx.ElementID(fmt.Sprintf("%s-%s", elementType, pkgID))
 }
 
-func getDocumentNamespace(root *core.Component) string {
+func documentNamespace(artifactType, name string) string {
 	return fmt.Sprintf("%s/%s/%s-%s",
 		DocumentNamespace,
-		string(root.Type),
-		strings.ReplaceAll(strings.ReplaceAll(root.Name, "https://", ""), "http://", ""), // remove http(s):// prefix when scanning repos
+		artifactType,
+		strings.ReplaceAll(strings.ReplaceAll(name, "https://", ""), "http://", ""), // remove http(s):// prefix when scanning repos
 		uuid.New().String(),
 	)
 }
@@ -0,0 +1,108 @@
+package spdx
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spdx/tools-golang/spdx"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/clock"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// MarshalRelationships returns an SPDX document containing only the dependency graph of the report,
+// i.e. a DEPENDS_ON relationship for each edge of "DependsOn" of the packages.
+// The packages in the graph are written as minimal package elements with only the name and the version,
+// so the document is much smaller and faster to produce than the full SBOM for tools consuming only the graph.
+// Packages are referred to by SPDX IDs derived from their PkgIDs, e.g. "SPDXRef-Package-2ff1a7c6f3c6ba1e",
+// so that the same package gets the same SPDX ID across documents.
+// Edges to packages missing from the report are dropped, as they would refer to undefined elements.
+// Edges found in multiple results, e.g. lock files of the same project, are written once.
+func (m *Marshaler) MarshalRelationships(ctx context.Context, report types.Report) (*spdx.Document, error) {
+	// PkgID => package
+	pkgs := make(map[string]ftypes.Package)
+	for _, result := range report.Results {
+		for _, pkg := range result.Packages {
+			if _, ok := pkgs[pkg.ID]; !ok {
+				pkgs[pkg.ID] = pkg
+			}
+		}
+	}
+
+	// PkgID => package element
+	elements := make(map[string]*spdx.Package)
+	element := func(pkgID string) (spdx.ElementID, error) {
+		if e, ok := elements[pkgID]; ok {
+			return e.PackageSPDXIdentifier, nil
+		}
+		hash, err := calcPkgID(m.hasher, pkgID)
+		if err != nil {
+			return "", xerrors.Errorf("failed to get the SPDX ID of %s: %w", pkgID, err)
+		}
+		pkg := pkgs[pkgID]
+		elements[pkgID] = &spdx.Package{
+			PackageName:             lo.CoalesceOrEmpty(pkg.Name, pkgID),
+			PackageVersion:          pkg.Version,
+			PackageSPDXIdentifier:   elementID(ElementPackage, hash),
+			PackageDownloadLocation: noAssertionField,
+		}
+		return elements[pkgID].PackageSPDXIdentifier, nil
+	}
+
+	type edge struct {
+		from, to string
+	}
+	edges := make(map[edge]struct{})
+	var relationships []*spdx.Relationship
+	for _, result := range report.Results {
+		for _, pkg := range result.Packages {
+			for _, dep := range pkg.DependsOn {
+				if _, ok := pkgs[dep]; !ok {
+					continue
+				}
+				e := edge{
+					from: pkg.ID,
+					to:   dep,
+				}
+				if _, ok := edges[e]; ok {
+					continue
+				}
+				edges[e] = struct{}{}
+
+				refA, err := element(pkg.ID)
+				if err != nil {
+					return nil, err
+				}
+				refB, err := element(dep)
+				if err != nil {
+					return nil, err
+				}
+				relationships = append(relationships, m.spdxRelationShip(refA, refB, RelationShipDependsOn))
+			}
+		}
+	}
+	sortRelationships(relationships)
+
+	packages := lo.Values(elements)
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].PackageSPDXIdentifier < packages[j].PackageSPDXIdentifier
+	})
+
+	return &spdx.Document{
+		SPDXVersion:       spdx.Version,
+		DataLicense:       spdx.DataLicense,
+		SPDXIdentifier:    DocumentSPDXIdentifier,
+		DocumentName:      report.ArtifactName,
+		DocumentNamespace: documentNamespace(string(report.ArtifactType), report.ArtifactName),
+		CreationInfo: &spdx.CreationInfo{
+			Creators: m.creators(),
+			Created:  clock.Now(ctx).UTC().Format(time.RFC3339),
+		},
+		Packages:      packages,
+		Relationships: relationships,
+	}, nil
+}
@@ -0,0 +1,223 @@
+package spdx_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/clock"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	tspdx "github.com/aquasecurity/trivy/pkg/sbom/spdx"
+	"github.com/aquasecurity/trivy/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/uuid"
+)
+
+func TestMarshaler_MarshalRelationships(t *testing.T) {
+	packages := []ftypes.Package{
+		{
+			ID:           "express@4.17.1",
+			Name:         "express",
+			Version:      "4.17.1",
+			Relationship: ftypes.RelationshipDirect,
+			DependsOn: []string{
+				"body-parser@1.19.0",
+				"debug@2.6.9",
+			},
+		},
+		{
+			ID:           "body-parser@1.19.0",
+			Name:         "body-parser",
+			Version:      "1.19.0",
+			Relationship: ftypes.RelationshipIndirect,
+			DependsOn: []string{
+				"debug@2.6.9",
+			},
+		},
+		{
+			ID:           "debug@2.6.9",
+			Name:         "debug",
+			Version:      "2.6.9",
+			Relationship: ftypes.RelationshipIndirect,
+			DependsOn: []string{
+				"ms@2.0.0",
+			},
+		},
+		{
+			ID:           "ms@2.0.0",
+			Name:         "ms",
+			Version:      "2.0.0",
+			Relationship: ftypes.RelationshipIndirect,
+		},
+	}
+
+	tests := []struct {
+		name         string
+		results      types.Results
+		wantEdges    int
+		wantPackages int
+	}{
+		{
+			name: "one lock file",
+			results: types.Results{
+				{
+					Target:   "package-lock.json",
+					Class:    types.ClassLangPkg,
+					Type:     ftypes.Npm,
+					Packages: packages,
+				},
+			},
+			wantEdges:    4,
+			wantPackages: 4,
+		},
+		{
+			name: "same graph in multiple lock files",
+			results: types.Results{
+				{
+					Target:   "package-lock.json",
+					Class:    types.ClassLangPkg,
+					Type:     ftypes.Npm,
+					Packages: packages,
+				},
+				{
+					Target:   "app/package-lock.json",
+					Class:    types.ClassLangPkg,
+					Type:     ftypes.Npm,
+					Packages: packages[1:],
+				},
+			},
+			wantEdges:    4,
+			wantPackages: 4,
+		},
+		{
+			name: "dependency missing from the report",
+			results: types.Results{
+				{
+					Target:   "package-lock.json",
+					Class:    types.ClassLangPkg,
+					Type:     ftypes.Npm,
+					Packages: packages[2:3], // debug depends on ms, which is missing
+				},
+			},
+			wantEdges:    0,
+			wantPackages: 0,
+		},
+		{
+			name: "no dependency graph",
+			results: types.Results{
+				{
+					Target: "alpine:3.20 (alpine 3.20.0)",
+					Class:  types.ClassOSPkg,
+					Type:   ftypes.Alpine,
+					Packages: []ftypes.Package{
+						{
+							ID:      "musl@1.2.5-r0",
+							Name:    "musl",
+							Version: "1.2.5-r0",
+						},
+					},
+				},
+			},
+			wantEdges:    0,
+			wantPackages: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := clock.With(context.Background(), time.Date(2021, 8, 25, 12, 20, 30, 5, time.UTC))
+			uuid.SetFakeUUID(t, "3ff14136-e09f-4df9-80ea-%012d")
+
+			report := types.Report{
+				ArtifactName: "app",
+				ArtifactType: artifact.TypeFilesystem,
+				Results:      tt.results,
+			}
+			marshaler := tspdx.NewMarshaler("0.56.2")
+			got, err := marshaler.MarshalRelationships(ctx, report)
+			require.NoError(t, err)
+
+			require.Len(t, got.Packages, tt.wantPackages)
+			assert.Equal(t, "app", got.DocumentName)
+			assert.Equal(t, "http://aquasecurity.github.io/trivy/filesystem/app-3ff14136-e09f-4df9-80ea-000000000001", got.DocumentNamespace)
+			require.Len(t, got.Relationships, tt.wantEdges)
+			for _, rel := range got.Relationships {
+				assert.Equal(t, tspdx.RelationShipDependsOn, rel.Relationship)
+			}
+
+			// Every SPDX ID referred to by the relationships must be defined in the document
+			ids := make(map[spdx.ElementID]struct{})
+			for _, pkg := range got.Packages {
+				ids[pkg.PackageSPDXIdentifier] = struct{}{}
+			}
+			for _, rel := range got.Relationships {
+				assert.Contains(t, ids, rel.RefA.ElementRefID)
+				assert.Contains(t, ids, rel.RefB.ElementRefID)
+			}
+
+			// SPDX IDs are derived from PkgIDs, so they are the same across documents
+			again, err := marshaler.MarshalRelationships(ctx, report)
+			require.NoError(t, err)
+			assert.Equal(t, got.Relationships, again.Relationships)
+		})
+	}
+}
+
+func TestMarshaler_MarshalRelationships_stableIDs(t *testing.T) {
+	ctx := context.Background()
+	report := func(pkgs ...ftypes.Package) types.Report {
+		return types.Report{
+			ArtifactName: "app",
+			ArtifactType: artifact.TypeFilesystem,
+			Results: types.Results{
+				{
+					Target:   "go.mod",
+					Class:    types.ClassLangPkg,
+					Type:     ftypes.GoModule,
+					Packages: pkgs,
+				},
+			},
+		}
+	}
+
+	marshaler := tspdx.NewMarshaler("0.56.2")
+	before, err := marshaler.MarshalRelationships(ctx, report(
+		ftypes.Package{
+			ID:        "github.com/org/app",
+			DependsOn: []string{"golang.org/x/net@v0.20.0"},
+		},
+		ftypes.Package{
+			ID: "golang.org/x/net@v0.20.0",
+		},
+	))
+	require.NoError(t, err)
+	after, err := marshaler.MarshalRelationships(ctx, report(
+		ftypes.Package{
+			ID:        "github.com/org/app",
+			DependsOn: []string{"golang.org/x/net@v0.23.0"},
+		},
+		ftypes.Package{
+			ID:        "golang.org/x/net@v0.23.0",
+			DependsOn: []string{"golang.org/x/text@v0.14.0"},
+		},
+		ftypes.Package{
+			ID: "golang.org/x/text@v0.14.0",
+		},
+	))
+	require.NoError(t, err)
+
+	require.Len(t, before.Relationships, 1)
+	require.Len(t, after.Relationships, 2)
+	assert.Regexp(t, `^Package-[0-9a-f]+$`, before.Relationships[0].RefA.ElementRefID)
+
+	// The same package gets the same SPDX ID, and another version of the package gets another one
+	var refs []spdx.ElementID
+	for _, rel := range after.Relationships {
+		refs = append(refs, rel.RefA.ElementRefID, rel.RefB.ElementRefID)
+	}
+	assert.Contains(t, refs, before.Relationships[0].RefA.ElementRefID)
+	assert.NotContains(t, refs, before.Relationships[0].RefB.ElementRefID)
+}
@@ -347,21 +347,22 @@ func toDetectedMisconfiguration(res ftypes.MisconfResult, defaultSeverity dbType
 	}
 
 	return types.DetectedMisconfiguration{
-		ID:          res.ID,
-		AVDID:       res.AVDID,
-		Type:        res.Type,
-		Title:       res.Title,
-		Description: res.Description,
-		Message:     msg,
-		Resolution:  res.RecommendedActions,
-		Namespace:   res.Namespace,
-		Query:       res.Query,
-		Severity:    severity.String(),
-		PrimaryURL:  primaryURL,
-		References:  res.References,
-		Status:      status,
-		Layer:       layer,
-		Traces:      res.Traces,
+		ID:           res.ID,
+		AVDID:        res.AVDID,
+		Type:         res.Type,
+		Title:        res.Title,
+		Description:  res.Description,
+		Message:      msg,
+		Resolution:   res.RecommendedActions,
+		Namespace:    res.Namespace,
+		Query:        res.Query,
+		Severity:     severity.String(),
+		PrimaryURL:   primaryURL,
+		References:   res.References,
+		Status:       status,
+		Layer:        layer,
+		Traces:       res.Traces,
+		PolicySource: res.PolicySource,
 		CauseMetadata: ftypes.CauseMetadata{
 			Resource:    res.Resource,
 			Provider:    res.Provider,
@@ -0,0 +1,106 @@
+package trend
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"os"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// State holds the number of findings per severity of each result in a run,
+// which is compared with the next run to show the trend.
+type State struct {
+	Results []Summary `json:"Results"`
+}
+
+// Summary is the number of findings per severity of a result
+type Summary struct {
+	Target     string            `json:"Target"`
+	Class      types.ResultClass `json:"Class,omitempty"`
+	Severities map[string]int    `json:"Severities,omitempty"`
+}
+
+// Load reads the state of the previous run.
+// It returns nil without an error if the file doesn't exist yet, e.g. on the first run.
+func Load(path string) (*State, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, xerrors.Errorf("failed to open the trend file: %w", err)
+	}
+	defer f.Close()
+
+	var state State
+	if err = json.NewDecoder(f).Decode(&state); err != nil {
+		return nil, xerrors.Errorf("failed to decode the trend file: %w", err)
+	}
+	return &state, nil
+}
+
+// Apply fills the counts of the previous run into the results.
+// Results not found in the previous run get empty counts so that all their findings are shown as increased.
+// Nothing is filled with a nil state, and no trend is shown on the first run.
+func (s *State) Apply(results types.Results) {
+	if s == nil {
+		return
+	}
+	for i := range results {
+		result := &results[i]
+		result.PreviousCounts = make(map[string]int)
+		for _, summary := range s.Results {
+			if summary.Target == result.Target && summary.Class == result.Class {
+				maps.Copy(result.PreviousCounts, summary.Severities)
+				break
+			}
+		}
+	}
+}
+
+// Save writes the counts of the results to the file as the state for the next run.
+func Save(path string, results types.Results) error {
+	state := State{
+		Results: make([]Summary, 0, len(results)),
+	}
+	for _, result := range results {
+		state.Results = append(state.Results, Summary{
+			Target:     result.Target,
+			Class:      result.Class,
+			Severities: Count(result),
+		})
+	}
+
+	b, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("failed to marshal the trend state: %w", err)
+	}
+	if err = os.WriteFile(path, b, 0o644); err != nil {
+		return xerrors.Errorf("failed to write the trend file: %w", err)
+	}
+	return nil
+}
+
+// Count returns the number of findings per severity in the result in the same way as the summary of the table format.
+// Only failed misconfigurations are counted.
+func Count(result types.Result) map[string]int {
+	counts := make(map[string]int)
+	for _, vuln := range result.Vulnerabilities {
+		counts[vuln.Severity]++
+	}
+	for _, misconf := range result.Misconfigurations {
+		if misconf.Status == types.MisconfStatusFailure {
+			counts[misconf.Severity]++
+		}
+	}
+	for _, secret := range result.Secrets {
+		counts[secret.Severity]++
+	}
+	for _, license := range result.Licenses {
+		counts[license.Severity]++
+	}
+	return counts
+}
@@ -0,0 +1,156 @@
+package trend_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/trend"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		wantNil bool
+		wantErr string
+	}{
+		{
+			name: "happy path",
+			path: "testdata/trend.json",
+		},
+		{
+			name:    "first run",
+			path:    "testdata/missing.json",
+			wantNil: true,
+		},
+		{
+			name:    "invalid JSON",
+			path:    "testdata/invalid.json",
+			wantErr: "failed to decode the trend file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := trend.Load(tt.path)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNil, got == nil)
+		})
+	}
+}
+
+func TestState_Apply(t *testing.T) {
+	results := func() types.Results {
+		return types.Results{
+			{
+				Target: "alpine:3.20 (alpine 3.20.0)",
+				Class:  types.ClassOSPkg,
+			},
+			{
+				// No findings in the previous run
+				Target: "Dockerfile",
+				Class:  types.ClassConfig,
+			},
+			{
+				// Not found in the previous run
+				Target: "app/package-lock.json",
+				Class:  types.ClassLangPkg,
+			},
+		}
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		state, err := trend.Load("testdata/trend.json")
+		require.NoError(t, err)
+
+		got := results()
+		state.Apply(got)
+		assert.Equal(t, map[string]int{
+			"CRITICAL": 1,
+			"HIGH":     3,
+		}, got[0].PreviousCounts)
+		assert.Equal(t, map[string]int{}, got[1].PreviousCounts)
+		assert.Equal(t, map[string]int{}, got[2].PreviousCounts)
+	})
+
+	t.Run("first run", func(t *testing.T) {
+		var state *trend.State
+		got := results()
+		state.Apply(got)
+		for _, result := range got {
+			assert.Nil(t, result.PreviousCounts)
+		}
+	})
+}
+
+func TestSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trend.json")
+	results := types.Results{
+		{
+			Target: "alpine:3.20 (alpine 3.20.0)",
+			Class:  types.ClassOSPkg,
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "CVE-2024-0001",
+					Vulnerability:   dbTypes.Vulnerability{Severity: "CRITICAL"},
+				},
+				{
+					VulnerabilityID: "CVE-2024-0002",
+					Vulnerability:   dbTypes.Vulnerability{Severity: "HIGH"},
+				},
+				{
+					VulnerabilityID: "CVE-2024-0003",
+					Vulnerability:   dbTypes.Vulnerability{Severity: "HIGH"},
+				},
+			},
+		},
+		{
+			Target: "Dockerfile",
+			Class:  types.ClassConfig,
+			Misconfigurations: []types.DetectedMisconfiguration{
+				{
+					AVDID:    "AVD-DS-0002",
+					Severity: "HIGH",
+					Status:   types.MisconfStatusFailure,
+				},
+				{
+					// Passed checks are not counted
+					AVDID:    "AVD-DS-0001",
+					Severity: "MEDIUM",
+					Status:   types.MisconfStatusPassed,
+				},
+			},
+		},
+	}
+	require.NoError(t, trend.Save(path, results))
+
+	// The next run reads the counts of this run
+	state, err := trend.Load(path)
+	require.NoError(t, err)
+	next := types.Results{
+		{
+			Target: "alpine:3.20 (alpine 3.20.0)",
+			Class:  types.ClassOSPkg,
+		},
+		{
+			Target: "Dockerfile",
+			Class:  types.ClassConfig,
+		},
+	}
+	state.Apply(next)
+	assert.Equal(t, map[string]int{
+		"CRITICAL": 1,
+		"HIGH":     2,
+	}, next[0].PreviousCounts)
+	assert.Equal(t, map[string]int{
+		"HIGH": 1,
+	}, next[1].PreviousCounts)
+}
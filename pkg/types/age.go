@@ -0,0 +1,62 @@
+package types
+
+import (
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// AgeHistogram represents the number of vulnerabilities in each age bucket based on their published dates
+type AgeHistogram struct {
+	LessThan30Days  int // Published less than 30 days ago
+	From30To90Days  int // Published 30 to 90 days ago
+	From90To365Days int // Published 90 to 365 days ago
+	MoreThan1Year   int // Published more than 1 year ago
+	Unknown         int // Without a published date
+}
+
+// AgeBucket represents a bucket of AgeHistogram
+type AgeBucket struct {
+	Label string
+	Count int
+}
+
+// NewAgeHistogram counts the vulnerabilities in the results by how long before "now" they were published.
+func NewAgeHistogram(results Results, now time.Time) *AgeHistogram {
+	h := &AgeHistogram{}
+	for _, result := range results {
+		for _, vuln := range result.Vulnerabilities {
+			h.add(vuln.PublishedDate, now)
+		}
+	}
+	return h
+}
+
+func (h *AgeHistogram) add(published *time.Time, now time.Time) {
+	if published == nil || published.IsZero() {
+		h.Unknown++
+		return
+	}
+
+	switch age := now.Sub(*published); {
+	case age < 30*day:
+		h.LessThan30Days++
+	case age < 90*day:
+		h.From30To90Days++
+	case age < 365*day:
+		h.From90To365Days++
+	default:
+		h.MoreThan1Year++
+	}
+}
+
+// Buckets returns the buckets in ascending order of age
+func (h *AgeHistogram) Buckets() []AgeBucket {
+	return []AgeBucket{
+		{Label: "<30d", Count: h.LessThan30Days},
+		{Label: "30-90d", Count: h.From30To90Days},
+		{Label: "90-365d", Count: h.From90To365Days},
+		{Label: ">1y", Count: h.MoreThan1Year},
+		{Label: "unknown age", Count: h.Unknown},
+	}
+}
@@ -52,6 +52,15 @@ func NewModifiedFinding(f finding, status FindingStatus, statement, source strin
 	}
 }
 
+// IsVEX returns true if the finding has been modified by a VEX statement rather than Trivy itself.
+func (m ModifiedFinding) IsVEX() bool {
+	switch m.Status {
+	case FindingStatusNotAffected, FindingStatusAffected, FindingStatusFixed, FindingStatusUnderInvestigation:
+		return true
+	}
+	return false
+}
+
 // UnmarshalJSON unmarshals ModifiedFinding given the type and `UnmarshalJSON` functions of struct fields
 func (m *ModifiedFinding) UnmarshalJSON(data []byte) error {
 	type Alias ModifiedFinding
@@ -31,6 +31,12 @@ type DetectedLicense struct {
 
 	// Link is a SPDX link of the license
 	Link string
+
+	// Labels holds the labels attached by "--labels-file"
+	Labels map[string]string `json:",omitempty"`
+
+	// Verdict holds whether the license complies with the policy given by "--license-allow" and "--license-deny"
+	Verdict LicenseVerdict `json:",omitempty"`
 }
 
 func (DetectedLicense) findingType() FindingType { return FindingTypeLicense }
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// LicenseVerdict represents whether a license complies with the license policy
+type LicenseVerdict string
+
+const (
+	LicenseVerdictPass LicenseVerdict = "PASS"
+	LicenseVerdictFail LicenseVerdict = "FAIL"
+)
+
+// LicensePolicy allows and denies licenses by name, e.g. "MIT" and "GPL-3.0".
+// Licenses neither allowed nor denied get the verdict for unknown licenses.
+type LicensePolicy struct {
+	Allow   []string
+	Deny    []string
+	Unknown LicenseVerdict
+}
+
+// NewLicensePolicy returns the license policy with the allowed and denied license names.
+// The verdict for unknown licenses is "pass" or "fail". If empty, unknown licenses fail with allowed licenses,
+// as an allowlist permits nothing else, and pass with only denied licenses.
+func NewLicensePolicy(allow, deny []string, unknown string) (LicensePolicy, error) {
+	if len(allow) == 0 && len(deny) == 0 {
+		return LicensePolicy{}, nil
+	}
+	for _, name := range allow {
+		if containsLicense(deny, name) {
+			return LicensePolicy{}, xerrors.Errorf("license %q is both allowed and denied", name)
+		}
+	}
+
+	verdict := LicenseVerdict(strings.ToUpper(unknown))
+	switch {
+	case unknown == "" && len(allow) > 0:
+		verdict = LicenseVerdictFail
+	case unknown == "":
+		verdict = LicenseVerdictPass
+	case verdict != LicenseVerdictPass && verdict != LicenseVerdictFail:
+		return LicensePolicy{}, xerrors.Errorf("invalid verdict for unknown licenses %q: must be 'pass' or 'fail'", unknown)
+	}
+	return LicensePolicy{
+		Allow:   allow,
+		Deny:    deny,
+		Unknown: verdict,
+	}, nil
+}
+
+// Enabled returns whether any license is allowed or denied
+func (p LicensePolicy) Enabled() bool {
+	return len(p.Allow) > 0 || len(p.Deny) > 0
+}
+
+// Verdict returns the verdict for the license name, which is matched case-insensitively.
+func (p LicensePolicy) Verdict(name string) LicenseVerdict {
+	switch {
+	case containsLicense(p.Deny, name):
+		return LicenseVerdictFail
+	case containsLicense(p.Allow, name):
+		return LicenseVerdictPass
+	}
+	return p.Unknown
+}
+
+// Apply sets the verdict of the licenses in the results.
+func (p LicensePolicy) Apply(results Results) {
+	if !p.Enabled() {
+		return
+	}
+	for i := range results {
+		for j := range results[i].Licenses {
+			license := &results[i].Licenses[j]
+			license.Verdict = p.Verdict(license.Name)
+		}
+	}
+}
+
+// Violations returns the number of licenses in the results failing the license policy.
+func (p LicensePolicy) Violations(results Results) int {
+	if !p.Enabled() {
+		return 0
+	}
+	var count int
+	for _, result := range results {
+		for _, license := range result.Licenses {
+			if p.Verdict(license.Name) == LicenseVerdictFail {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func containsLicense(names []string, name string) bool {
+	return slices.ContainsFunc(names, func(s string) bool {
+		return strings.EqualFold(s, name)
+	})
+}
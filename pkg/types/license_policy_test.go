@@ -0,0 +1,191 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestNewLicensePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		allow   []string
+		deny    []string
+		unknown string
+		want    types.LicensePolicy
+		wantErr string
+	}{
+		{
+			name:  "unknown licenses fail with allowed licenses",
+			allow: []string{"MIT"},
+			deny:  []string{"GPL-3.0"},
+			want: types.LicensePolicy{
+				Allow:   []string{"MIT"},
+				Deny:    []string{"GPL-3.0"},
+				Unknown: types.LicenseVerdictFail,
+			},
+		},
+		{
+			name: "unknown licenses pass with only denied licenses",
+			deny: []string{"GPL-3.0"},
+			want: types.LicensePolicy{
+				Deny:    []string{"GPL-3.0"},
+				Unknown: types.LicenseVerdictPass,
+			},
+		},
+		{
+			name:    "explicit verdict for unknown licenses",
+			allow:   []string{"MIT"},
+			unknown: "pass",
+			want: types.LicensePolicy{
+				Allow:   []string{"MIT"},
+				Unknown: types.LicenseVerdictPass,
+			},
+		},
+		{
+			name: "no policy",
+			want: types.LicensePolicy{},
+		},
+		{
+			name:    "allowed and denied",
+			allow:   []string{"MIT"},
+			deny:    []string{"mit"},
+			wantErr: `license "MIT" is both allowed and denied`,
+		},
+		{
+			name:    "invalid verdict",
+			allow:   []string{"MIT"},
+			unknown: "warn",
+			wantErr: `invalid verdict for unknown licenses "warn"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := types.NewLicensePolicy(tt.allow, tt.deny, tt.unknown)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLicensePolicy_Apply(t *testing.T) {
+	results := func() types.Results {
+		return types.Results{
+			{
+				Target: "OS Packages",
+				Class:  types.ClassLicense,
+				Licenses: []types.DetectedLicense{
+					{
+						PkgName: "musl",
+						Name:    "MIT",
+					},
+					{
+						PkgName: "busybox",
+						Name:    "GPL-2.0-only",
+					},
+				},
+			},
+			{
+				Target: "Loose File License(s)",
+				Class:  types.ClassLicenseFile,
+				Licenses: []types.DetectedLicense{
+					{
+						FilePath: "LICENSE",
+						Name:     "apache-2.0",
+					},
+				},
+			},
+		}
+	}
+	verdicts := func(results types.Results) []types.LicenseVerdict {
+		var got []types.LicenseVerdict
+		for _, result := range results {
+			for _, license := range result.Licenses {
+				got = append(got, license.Verdict)
+			}
+		}
+		return got
+	}
+
+	tests := []struct {
+		name           string
+		allow          []string
+		deny           []string
+		unknown        string
+		want           []types.LicenseVerdict
+		wantViolations int
+	}{
+		{
+			name:  "allowed",
+			allow: []string{"MIT", "Apache-2.0", "GPL-2.0-only"},
+			want: []types.LicenseVerdict{
+				types.LicenseVerdictPass,
+				types.LicenseVerdictPass,
+				types.LicenseVerdictPass,
+			},
+		},
+		{
+			name:  "denied",
+			allow: []string{"MIT", "Apache-2.0"},
+			deny:  []string{"GPL-2.0-only"},
+			want: []types.LicenseVerdict{
+				types.LicenseVerdictPass,
+				types.LicenseVerdictFail,
+				types.LicenseVerdictPass,
+			},
+			wantViolations: 1,
+		},
+		{
+			name:  "unknown fails with allowed licenses",
+			allow: []string{"MIT"},
+			want: []types.LicenseVerdict{
+				types.LicenseVerdictPass,
+				types.LicenseVerdictFail,
+				types.LicenseVerdictFail,
+			},
+			wantViolations: 2,
+		},
+		{
+			name:    "unknown passes as configured",
+			allow:   []string{"MIT"},
+			unknown: "pass",
+			want: []types.LicenseVerdict{
+				types.LicenseVerdictPass,
+				types.LicenseVerdictPass,
+				types.LicenseVerdictPass,
+			},
+		},
+		{
+			name: "unknown passes with only denied licenses",
+			deny: []string{"GPL-2.0-only"},
+			want: []types.LicenseVerdict{
+				types.LicenseVerdictPass,
+				types.LicenseVerdictFail,
+				types.LicenseVerdictPass,
+			},
+			wantViolations: 1,
+		},
+		{
+			name: "no policy",
+			want: []types.LicenseVerdict{"", "", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := types.NewLicensePolicy(tt.allow, tt.deny, tt.unknown)
+			require.NoError(t, err)
+
+			got := results()
+			policy.Apply(got)
+			assert.Equal(t, tt.want, verdicts(got))
+			assert.Equal(t, tt.wantViolations, policy.Violations(got))
+		})
+	}
+}
@@ -20,8 +20,15 @@ type DetectedMisconfiguration struct {
 	Layer         ftypes.Layer         `json:",omitempty"`
 	CauseMetadata ftypes.CauseMetadata `json:",omitempty"`
 
+	// Labels holds the labels attached by "--labels-file"
+	Labels map[string]string `json:",omitempty"`
+
+	// BaselineStatus holds whether the check newly fails or passes since the baseline, only filled with "--misconfig-diff"
+	BaselineStatus BaselineStatus `json:",omitempty"`
+
 	// For debugging
-	Traces []string `json:",omitempty"`
+	Traces       []string `json:",omitempty"`
+	PolicySource string   `json:",omitempty"`
 }
 
 // MisconfStatus represents a status of misconfiguration
@@ -5,6 +5,7 @@ import (
 
 	v1 "github.com/google/go-containerregistry/pkg/v1" // nolint: goimports
 
+	"github.com/aquasecurity/trivy/pkg/digest"
 	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/sbom/core"
@@ -19,8 +20,20 @@ type Report struct {
 	Metadata      Metadata      `json:",omitempty"`
 	Results       Results       `json:",omitempty"`
 
+	// The number of vulnerabilities per age, only filled with "--age-histogram"
+	AgeHistogram *AgeHistogram `json:",omitempty"`
+
+	// The percentage of the misconfiguration checks passing, only filled with "--scorecard"
+	Scorecard *Scorecard `json:",omitempty"`
+
+	// The classification banner of the report, e.g. "CONFIDENTIAL", only filled with "--banner"
+	Banner string `json:",omitempty"`
+
 	// parsed SBOM
 	BOM *core.BOM `json:"-"` // Just for internal usage, not exported in JSON
+
+	// The time when the vulnerability database used for the scan was built, only filled with "--db-stale-warning"
+	DBUpdatedAt time.Time `json:"-"` // Just for internal usage, not exported in JSON
 }
 
 // Metadata represents a metadata of artifact
@@ -63,15 +76,24 @@ const (
 	ComplianceEksCIS14           = Compliance("eks-cis-1.4")
 	ComplianceRke2CIS124         = Compliance("rke2-cis-1.24")
 
-	FormatTable      Format = "table"
-	FormatJSON       Format = "json"
-	FormatTemplate   Format = "template"
-	FormatSarif      Format = "sarif"
-	FormatCycloneDX  Format = "cyclonedx"
-	FormatSPDX       Format = "spdx"
-	FormatSPDXJSON   Format = "spdx-json"
-	FormatGitHub     Format = "github"
-	FormatCosignVuln Format = "cosign-vuln"
+	FormatTable             Format = "table"
+	FormatJSON              Format = "json"
+	FormatTemplate          Format = "template"
+	FormatSarif             Format = "sarif"
+	FormatCycloneDX         Format = "cyclonedx"
+	FormatSPDX              Format = "spdx"
+	FormatSPDXJSON          Format = "spdx-json"
+	FormatGitHub            Format = "github"
+	FormatCosignVuln        Format = "cosign-vuln"
+	FormatDefectDojo        Format = "defectdojo"
+	FormatCount             Format = "count"
+	FormatSyslog            Format = "syslog"
+	FormatHTML              Format = "html"
+	FormatSQLite            Format = "sqlite"
+	FormatBadge             Format = "badge"
+	FormatTrivyBin          Format = "trivy-bin"
+	FormatGitHubAnnotations Format = "github-annotations"
+	FormatRemediationJSON   Format = "remediation-json"
 )
 
 var (
@@ -85,6 +107,15 @@ var (
 		FormatSPDXJSON,
 		FormatGitHub,
 		FormatCosignVuln,
+		FormatDefectDojo,
+		FormatCount,
+		FormatSyslog,
+		FormatHTML,
+		FormatSQLite,
+		FormatBadge,
+		FormatTrivyBin,
+		FormatGitHubAnnotations,
+		FormatRemediationJSON,
 	}
 	SupportedSBOMFormats = []Format{
 		FormatCycloneDX,
@@ -118,10 +149,20 @@ type Result struct {
 	Licenses          []DetectedLicense          `json:"Licenses,omitempty"`
 	CustomResources   []ftypes.CustomResource    `json:"CustomResources,omitempty"`
 
+	// FileDigest is the SHA-256 digest of the scanned file, populated with "--include-file-hashes"
+	FileDigest digest.Digest `json:"FileDigest,omitempty"`
+
 	// ModifiedFindings holds a list of findings that have been modified from their original state.
 	// This can include vulnerabilities that have been marked as ignored, not affected, or have had
 	// their severity adjusted. It's still in an experimental stage and may change in the future.
 	ModifiedFindings []ModifiedFinding `json:"ExperimentalModifiedFindings,omitempty"`
+
+	// FilteredCounts is the number of findings filtered out by each filter, populated with "--show-filtered-count"
+	FilteredCounts *FilteredCounts `json:"-"`
+
+	// PreviousCounts is the number of findings per severity in the previous run, populated with "--trend-file".
+	// It is nil on the first run, and empty for targets not found in the previous run.
+	PreviousCounts map[string]int `json:"-"`
 }
 
 func (r *Result) IsEmpty() bool {
@@ -129,13 +170,32 @@ func (r *Result) IsEmpty() bool {
 		len(r.Secrets) == 0 && len(r.Licenses) == 0 && len(r.CustomResources) == 0 && len(r.ModifiedFindings) == 0
 }
 
+// FilteredCounts holds the number of findings filtered out by each filter.
+// Vulnerabilities, failed misconfigurations and secrets are counted.
+type FilteredCounts struct {
+	Severity   int // Not in "--severity"
+	Status     int // In "--ignore-status", e.g. unfixed vulnerabilities with "--ignore-unfixed"
+	IgnoreFile int // Ignored by the ignore file, e.g. ".trivyignore"
+	Package    int // Not matching "--pkg-filter", or matching "--internal-packages"
+	Confidence int // Secrets below "--min-secret-confidence"
+	Policy     int // Ignored by the Rego policy given by "--ignore-policy"
+	VEX        int // Not affected or fixed according to the VEX documents
+	KEV        int // Not in the KEV catalog with "--kev-only"
+	Baseline   int // Known in the baseline with "--hide-known", or unchanged misconfigurations with "--misconfig-diff"
+}
+
+func (c FilteredCounts) Total() int {
+	return c.Severity + c.Status + c.IgnoreFile + c.Package + c.Confidence + c.Policy + c.VEX + c.KEV + c.Baseline
+}
+
 type MisconfSummary struct {
-	Successes int
-	Failures  int
+	Successes  int
+	Failures   int
+	Exceptions int `json:",omitempty"`
 }
 
 func (s MisconfSummary) Empty() bool {
-	return s.Successes == 0 && s.Failures == 0
+	return s.Successes == 0 && s.Failures == 0 && s.Exceptions == 0
 }
 
 // Failed returns whether the result includes any vulnerabilities, misconfigurations or secrets
@@ -158,3 +218,22 @@ func (results Results) Failed() bool {
 	}
 	return false
 }
+
+// Empty returns whether nothing is detected in the scan, neither an OS, packages nor findings,
+// which usually means that the scan is misconfigured, e.g. a wrong target or all the files are skipped.
+// A report with packages and no vulnerabilities is not empty.
+func (r Report) Empty() bool {
+	if r.Metadata.OS != nil {
+		return false
+	}
+	for _, result := range r.Results {
+		if !result.IsEmpty() {
+			return false
+		}
+		// Misconfigurations may be filtered out while the checks were evaluated
+		if result.MisconfSummary != nil && !result.MisconfSummary.Empty() {
+			return false
+		}
+	}
+	return true
+}
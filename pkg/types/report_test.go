@@ -0,0 +1,103 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestReport_Empty(t *testing.T) {
+	tests := []struct {
+		name   string
+		report types.Report
+		want   bool
+	}{
+		{
+			name:   "no results",
+			report: types.Report{},
+			want:   true,
+		},
+		{
+			name: "results without packages and findings",
+			report: types.Report{
+				Results: types.Results{
+					{
+						Target: "package-lock.json",
+						Class:  types.ClassLangPkg,
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "packages without vulnerabilities",
+			report: types.Report{
+				Results: types.Results{
+					{
+						Target: "package-lock.json",
+						Class:  types.ClassLangPkg,
+						Packages: []ftypes.Package{
+							{
+								Name:    "express",
+								Version: "4.21.1",
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "OS without packages",
+			report: types.Report{
+				Metadata: types.Metadata{
+					OS: &ftypes.OS{
+						Family: ftypes.Alpine,
+						Name:   "3.20.0",
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "passed misconfigurations",
+			report: types.Report{
+				Results: types.Results{
+					{
+						Target: "Dockerfile",
+						Class:  types.ClassConfig,
+						MisconfSummary: &types.MisconfSummary{
+							Successes: 20,
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "secrets",
+			report: types.Report{
+				Results: types.Results{
+					{
+						Target: "config.yaml",
+						Class:  types.ClassSecret,
+						Secrets: []types.DetectedSecret{
+							{
+								RuleID: "aws-access-key-id",
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.report.Empty())
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package types
+
+import (
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+)
+
+// Scorecard represents the percentage of the misconfiguration checks passing across the results
+type Scorecard struct {
+	Successes  int
+	Failures   int
+	Exceptions int             `json:",omitempty"` // Excepted checks are neither passing nor failing
+	Severities []SeverityScore `json:",omitempty"`
+}
+
+// SeverityScore represents the checks of a severity in Scorecard
+type SeverityScore struct {
+	Severity  string
+	Successes int
+	Failures  int
+}
+
+// NewScorecard sums up the misconfiguration summaries of the results.
+// The checks per severity are counted from the misconfigurations in the results,
+// so the passed checks are counted per severity only with "--include-non-failures".
+// It returns nil if no checks were evaluated.
+func NewScorecard(results Results) *Scorecard {
+	var evaluated bool
+	s := &Scorecard{}
+	severities := make(map[string]*SeverityScore)
+	for _, result := range results {
+		if result.MisconfSummary == nil {
+			continue
+		}
+		evaluated = true
+		s.Successes += result.MisconfSummary.Successes
+		s.Failures += result.MisconfSummary.Failures
+		s.Exceptions += result.MisconfSummary.Exceptions
+
+		for _, misconf := range result.Misconfigurations {
+			if misconf.Status != MisconfStatusPassed && misconf.Status != MisconfStatusFailure {
+				continue
+			}
+			score, ok := severities[misconf.Severity]
+			if !ok {
+				score = &SeverityScore{Severity: misconf.Severity}
+				severities[misconf.Severity] = score
+			}
+			if misconf.Status == MisconfStatusPassed {
+				score.Successes++
+			} else {
+				score.Failures++
+			}
+		}
+	}
+	if !evaluated {
+		return nil
+	}
+
+	// From the most severe
+	for i := len(dbTypes.SeverityNames) - 1; i >= 0; i-- {
+		if score, ok := severities[dbTypes.SeverityNames[i]]; ok {
+			s.Severities = append(s.Severities, *score)
+		}
+	}
+	return s
+}
+
+// Score returns the percentage of the passed checks, excluding the excepted checks.
+// It is 100 if no checks passed or failed.
+func (s Scorecard) Score() float64 {
+	return score(s.Successes, s.Failures)
+}
+
+// Grade returns the letter grade of the score
+func (s Scorecard) Grade() string {
+	return Grade(s.Score())
+}
+
+// SeverityBreakdown returns whether the passed checks are counted per severity,
+// i.e. the passed misconfigurations are in the results.
+func (s Scorecard) SeverityBreakdown() bool {
+	var successes int
+	for _, sev := range s.Severities {
+		successes += sev.Successes
+	}
+	return successes == s.Successes
+}
+
+// Score returns the percentage of the passed checks of the severity
+func (s SeverityScore) Score() float64 {
+	return score(s.Successes, s.Failures)
+}
+
+func score(successes, failures int) float64 {
+	if successes+failures == 0 {
+		return 100
+	}
+	return float64(successes) * 100 / float64(successes+failures)
+}
+
+// Grade returns the letter grade of the percentage, from "A" (90% or higher) to "F" (lower than 60%)
+func Grade(score float64) string {
+	switch {
+	case score >= 90:
+		return "A"
+	case score >= 80:
+		return "B"
+	case score >= 70:
+		return "C"
+	case score >= 60:
+		return "D"
+	default:
+		return "F"
+	}
+}
@@ -0,0 +1,142 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestNewScorecard(t *testing.T) {
+	tests := []struct {
+		name                  string
+		results               types.Results
+		want                  *types.Scorecard
+		wantScore             float64
+		wantGrade             string
+		wantSeverityBreakdown bool
+	}{
+		{
+			name: "summaries across results",
+			results: types.Results{
+				{
+					Target:         "Dockerfile",
+					MisconfSummary: &types.MisconfSummary{Successes: 20, Failures: 2},
+					Misconfigurations: []types.DetectedMisconfiguration{
+						{AVDID: "AVD-DS-0002", Severity: "HIGH", Status: types.MisconfStatusFailure},
+						{AVDID: "AVD-DS-0026", Severity: "LOW", Status: types.MisconfStatusFailure},
+					},
+				},
+				{
+					Target:         "deployment.yaml",
+					MisconfSummary: &types.MisconfSummary{Successes: 16, Failures: 2, Exceptions: 3},
+					Misconfigurations: []types.DetectedMisconfiguration{
+						{AVDID: "AVD-KSV-0001", Severity: "MEDIUM", Status: types.MisconfStatusFailure},
+						{AVDID: "AVD-KSV-0012", Severity: "MEDIUM", Status: types.MisconfStatusFailure},
+					},
+				},
+				{
+					// Not a misconfiguration result
+					Target: "package-lock.json",
+				},
+			},
+			want: &types.Scorecard{
+				Successes:  36,
+				Failures:   4,
+				Exceptions: 3,
+				Severities: []types.SeverityScore{
+					{Severity: "HIGH", Failures: 1},
+					{Severity: "MEDIUM", Failures: 2},
+					{Severity: "LOW", Failures: 1},
+				},
+			},
+			wantScore: 90,
+			wantGrade: "A",
+		},
+		{
+			name: "with non-failures",
+			results: types.Results{
+				{
+					Target:         "main.tf",
+					MisconfSummary: &types.MisconfSummary{Successes: 2, Failures: 2, Exceptions: 1},
+					Misconfigurations: []types.DetectedMisconfiguration{
+						{AVDID: "AVD-AWS-0086", Severity: "CRITICAL", Status: types.MisconfStatusPassed},
+						{AVDID: "AVD-AWS-0087", Severity: "HIGH", Status: types.MisconfStatusFailure},
+						{AVDID: "AVD-AWS-0088", Severity: "HIGH", Status: types.MisconfStatusPassed},
+						{AVDID: "AVD-AWS-0089", Severity: "HIGH", Status: types.MisconfStatusException},
+						{AVDID: "AVD-AWS-0090", Severity: "LOW", Status: types.MisconfStatusFailure},
+					},
+				},
+			},
+			want: &types.Scorecard{
+				Successes:  2,
+				Failures:   2,
+				Exceptions: 1,
+				Severities: []types.SeverityScore{
+					{Severity: "CRITICAL", Successes: 1},
+					{Severity: "HIGH", Successes: 1, Failures: 1},
+					{Severity: "LOW", Failures: 1},
+				},
+			},
+			wantScore:             50,
+			wantGrade:             "F",
+			wantSeverityBreakdown: true,
+		},
+		{
+			name: "only exceptions",
+			results: types.Results{
+				{
+					Target:         "Dockerfile",
+					MisconfSummary: &types.MisconfSummary{Exceptions: 2},
+				},
+			},
+			want: &types.Scorecard{
+				Exceptions: 2,
+			},
+			wantScore:             100,
+			wantGrade:             "A",
+			wantSeverityBreakdown: true,
+		},
+		{
+			name: "no checks",
+			results: types.Results{
+				{
+					Target: "package-lock.json",
+				},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := types.NewScorecard(tt.results)
+			assert.Equal(t, tt.want, got)
+			if got == nil {
+				return
+			}
+			assert.InDelta(t, tt.wantScore, got.Score(), 0.001)
+			assert.Equal(t, tt.wantGrade, got.Grade())
+			assert.Equal(t, tt.wantSeverityBreakdown, got.SeverityBreakdown())
+		})
+	}
+}
+
+func TestGrade(t *testing.T) {
+	tests := []struct {
+		score float64
+		want  string
+	}{
+		{score: 100, want: "A"},
+		{score: 90, want: "A"},
+		{score: 89.9, want: "B"},
+		{score: 80, want: "B"},
+		{score: 75, want: "C"},
+		{score: 60, want: "D"},
+		{score: 59.9, want: "F"},
+		{score: 0, want: "F"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, types.Grade(tt.score), tt.score)
+	}
+}
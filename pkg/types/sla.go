@@ -0,0 +1,109 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+)
+
+// SLAStatus represents whether a vulnerability is fixed within the time allowed by the SLA
+type SLAStatus string
+
+const (
+	SLAStatusOnTrack  SLAStatus = "on-track"
+	SLAStatusBreached SLAStatus = "BREACHED"
+)
+
+// SLA holds the time allowed to fix vulnerabilities for each severity, e.g. 7 days for CRITICAL.
+// The age of a vulnerability is counted from its published date.
+type SLA map[string]time.Duration
+
+// ParseSLA parses the SLA in the form of "<severity>=<duration>", e.g. "critical=7d".
+// The duration is a number of days with the "d" suffix, or any duration accepted by time.ParseDuration.
+func ParseSLA(values []string) (SLA, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	sla := make(SLA)
+	for _, value := range values {
+		s, d, ok := strings.Cut(value, "=")
+		if !ok {
+			return nil, xerrors.Errorf("invalid SLA %q: must be in the form of '<severity>=<duration>', e.g. 'critical=7d'", value)
+		}
+		severity, err := dbTypes.NewSeverity(strings.ToUpper(strings.TrimSpace(s)))
+		if err != nil {
+			return nil, xerrors.Errorf("invalid SLA %q: %w", value, err)
+		}
+		duration, err := parseSLADuration(strings.TrimSpace(d))
+		if err != nil {
+			return nil, xerrors.Errorf("invalid SLA %q: %w", value, err)
+		}
+		sla[severity.String()] = duration
+	}
+	return sla, nil
+}
+
+func parseSLADuration(s string) (time.Duration, error) {
+	var duration time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, xerrors.Errorf("invalid number of days: %s", s)
+		}
+		duration = time.Duration(n) * day
+	} else {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, xerrors.Errorf("invalid duration: %w", err)
+		}
+		duration = d
+	}
+	if duration <= 0 {
+		return 0, xerrors.Errorf("duration must be positive: %s", s)
+	}
+	return duration, nil
+}
+
+// Status returns the SLA status of the vulnerability at "now".
+// It returns an empty status if the severity has no SLA or the published date is unknown.
+func (s SLA) Status(vuln DetectedVulnerability, now time.Time) SLAStatus {
+	allowed, ok := s[vuln.Severity]
+	if !ok || vuln.PublishedDate == nil || vuln.PublishedDate.IsZero() {
+		return ""
+	}
+	if now.Sub(*vuln.PublishedDate) > allowed {
+		return SLAStatusBreached
+	}
+	return SLAStatusOnTrack
+}
+
+// Apply sets the SLA status of the vulnerabilities in the results.
+func (s SLA) Apply(results Results, now time.Time) {
+	if len(s) == 0 {
+		return
+	}
+	for i := range results {
+		for j := range results[i].Vulnerabilities {
+			vuln := &results[i].Vulnerabilities[j]
+			vuln.SLAStatus = s.Status(*vuln, now)
+		}
+	}
+}
+
+// Breached returns the number of vulnerabilities in the results breaching the SLA.
+func (s SLA) Breached(results Results, now time.Time) int {
+	var count int
+	for _, result := range results {
+		for _, vuln := range result.Vulnerabilities {
+			if s.Status(vuln, now) == SLAStatusBreached {
+				count++
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,117 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestParseSLA(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    types.SLA
+		wantErr string
+	}{
+		{
+			name:   "days and durations",
+			values: []string{"critical=7d", "HIGH=30d", "medium=36h"},
+			want: types.SLA{
+				"CRITICAL": 7 * 24 * time.Hour,
+				"HIGH":     30 * 24 * time.Hour,
+				"MEDIUM":   36 * time.Hour,
+			},
+		},
+		{
+			name:   "empty",
+			values: nil,
+			want:   nil,
+		},
+		{
+			name:    "missing duration",
+			values:  []string{"critical"},
+			wantErr: `invalid SLA "critical": must be in the form of '<severity>=<duration>'`,
+		},
+		{
+			name:    "unknown severity",
+			values:  []string{"urgent=7d"},
+			wantErr: `invalid SLA "urgent=7d"`,
+		},
+		{
+			name:    "invalid days",
+			values:  []string{"critical=7.5d"},
+			wantErr: "invalid number of days: 7.5d",
+		},
+		{
+			name:    "zero",
+			values:  []string{"critical=0d"},
+			wantErr: "duration must be positive: 0d",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := types.ParseSLA(tt.values)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSLA_Apply(t *testing.T) {
+	now := time.Date(2024, 10, 31, 12, 0, 0, 0, time.UTC)
+	published := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+	vuln := func(severity string, published *time.Time) types.DetectedVulnerability {
+		return types.DetectedVulnerability{
+			VulnerabilityID: "CVE-2024-0001",
+			Vulnerability: dbTypes.Vulnerability{
+				Severity:      severity,
+				PublishedDate: published,
+			},
+		}
+	}
+
+	sla := types.SLA{
+		"CRITICAL": 7 * 24 * time.Hour,
+		"HIGH":     30 * 24 * time.Hour,
+	}
+	results := types.Results{
+		{
+			Target: "package-lock.json",
+			Vulnerabilities: []types.DetectedVulnerability{
+				vuln("CRITICAL", published(7*24*time.Hour-time.Second)), // 1 second before the deadline
+				vuln("CRITICAL", published(7*24*time.Hour)),             // exactly at the deadline
+				vuln("CRITICAL", published(7*24*time.Hour+time.Second)), // 1 second after the deadline
+				vuln("HIGH", published(31*24*time.Hour)),
+				vuln("HIGH", nil),
+				vuln("LOW", published(365*24*time.Hour)),
+			},
+		},
+	}
+
+	sla.Apply(results, now)
+	got := make([]types.SLAStatus, 0, len(results[0].Vulnerabilities))
+	for _, v := range results[0].Vulnerabilities {
+		got = append(got, v.SLAStatus)
+	}
+	assert.Equal(t, []types.SLAStatus{
+		types.SLAStatusOnTrack,
+		types.SLAStatusOnTrack,
+		types.SLAStatusBreached,
+		types.SLAStatusBreached,
+		"", // unknown published date
+		"", // no SLA for the severity
+	}, got)
+	assert.Equal(t, 2, sla.Breached(results, now))
+}
@@ -20,6 +20,31 @@ type DetectedVulnerability struct {
 	SeveritySource   types.SourceID       `json:",omitempty"`
 	PrimaryURL       string               `json:",omitempty"`
 
+	// Reachability holds whether the vulnerable code is reachable from the application, as stated by VEX documents.
+	// It is only filled with "--show-reachability" and empty when no VEX document states it.
+	Reachability Reachability `json:",omitempty"`
+
+	// EPSS holds the probability of exploitation, only filled with "--show-epss"
+	EPSS *EPSS `json:",omitempty"`
+
+	// KEV holds the entry in the CISA Known Exploited Vulnerabilities catalog, only filled with "--show-kev", "--show-kev-due" or "--kev-only"
+	KEV *KEV `json:",omitempty"`
+
+	// AffectedRange holds the vulnerable versions in the advisory, e.g. ">=1.0.0, <1.4.2", only filled with "--show-affected-range"
+	AffectedRange string `json:",omitempty"`
+
+	// Labels holds the labels attached by "--labels-file"
+	Labels map[string]string `json:",omitempty"`
+
+	// SLAStatus holds whether the vulnerability is fixed within the SLA given by "--sla"
+	SLAStatus SLAStatus `json:",omitempty"`
+
+	// BaselineStatus holds whether the vulnerability is new or known in the baseline given by "--baseline-file"
+	BaselineStatus BaselineStatus `json:",omitempty"`
+
+	// Platforms holds the platforms where the vulnerability is detected, only filled with "--merge-platforms"
+	Platforms []string `json:",omitempty"`
+
 	// DataSource holds where the advisory comes from
 	DataSource *types.DataSource `json:",omitempty"`
 
@@ -30,6 +55,39 @@ type DetectedVulnerability struct {
 	types.Vulnerability
 }
 
+// Reachability represents whether the vulnerable code is actually called
+type Reachability string
+
+const (
+	ReachabilityUnknown     Reachability = "unknown"
+	ReachabilityReachable   Reachability = "reachable"
+	ReachabilityUnreachable Reachability = "unreachable"
+)
+
+// BaselineStatus represents whether a finding exists in the baseline report
+type BaselineStatus string
+
+const (
+	BaselineStatusNew   BaselineStatus = "new"
+	BaselineStatusKnown BaselineStatus = "known"
+
+	// BaselineStatusResolved represents a misconfiguration failing in the baseline but not anymore
+	BaselineStatusResolved BaselineStatus = "resolved"
+)
+
+// EPSS represents the Exploit Prediction Scoring System data of a CVE
+type EPSS struct {
+	Score      float64 // Probability of exploitation in the next 30 days
+	Percentile float64 // Proportion of CVEs with the same or a lower score
+}
+
+// KEV represents the entry of a CVE in the CISA Known Exploited Vulnerabilities catalog
+type KEV struct {
+	DateAdded  string // Date when the CVE was added to the catalog, e.g. "2021-12-10"
+	DueDate    string // Date by which U.S. federal agencies must remediate the CVE
+	Ransomware bool   // Whether the CVE is known to be used in ransomware campaigns
+}
+
 func (DetectedVulnerability) findingType() FindingType { return FindingTypeVulnerability }
 
 // BySeverity implements sort.Interface based on the Severity field.
@@ -1,6 +1,8 @@
 package vex
 
 import (
+	"slices"
+
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/samber/lo"
 
@@ -48,11 +50,35 @@ func (v *CycloneDX) NotAffected(vuln types.DetectedVulnerability, product, _ *co
 		return types.ModifiedFinding{}, false
 	}
 
-	for _, affect := range stmt.Affects {
-		if stmt.Status != types.FindingStatusNotAffected && stmt.Status != types.FindingStatusFixed {
-			continue
-		}
+	if stmt.Status != types.FindingStatusNotAffected && stmt.Status != types.FindingStatusFixed {
+		return types.ModifiedFinding{}, false
+	}
+	if v.affects(stmt, product) {
+		return types.NewModifiedFinding(vuln, stmt.Status, stmt.Justification, "CycloneDX VEX"), true
+	}
+	return types.ModifiedFinding{}, false
+}
 
+// Reachability returns whether the vulnerable code is reachable according to the analysis of the vulnerability.
+func (v *CycloneDX) Reachability(vuln types.DetectedVulnerability, product *core.Component) types.Reachability {
+	stmt, ok := v.statements[vuln.VulnerabilityID]
+	if !ok || !v.affects(stmt, product) {
+		return ""
+	}
+
+	// "exploitable" doesn't mean the vulnerable code is called
+	switch {
+	case stmt.Status == types.FindingStatusAffected, stmt.Status == types.FindingStatusUnderInvestigation:
+		return types.ReachabilityUnknown
+	case stmt.Status == types.FindingStatusNotAffected && slices.Contains(unreachableJustifications, stmt.Justification):
+		return types.ReachabilityUnreachable
+	}
+	return ""
+}
+
+// affects returns true if the statement refers to the product
+func (v *CycloneDX) affects(stmt Statement, product *core.Component) bool {
+	for _, affect := range stmt.Affects {
 		// Affect must be BOM-Link at the moment
 		link, err := cdx.ParseBOMLink(affect)
 		if err != nil {
@@ -66,10 +92,10 @@ func (v *CycloneDX) NotAffected(vuln types.DetectedVulnerability, product, _ *co
 			continue
 		}
 		if product.PkgIdentifier.Match(link.Reference()) {
-			return types.NewModifiedFinding(vuln, stmt.Status, stmt.Justification, "CycloneDX VEX"), true
+			return true
 		}
 	}
-	return types.ModifiedFinding{}, false
+	return false
 }
 
 func cdxStatus(s cdx.ImpactAnalysisState) types.FindingStatus {
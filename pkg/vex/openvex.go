@@ -1,6 +1,8 @@
 package vex
 
 import (
+	"slices"
+
 	openvex "github.com/openvex/go-vex/pkg/vex"
 
 	"github.com/aquasecurity/trivy/pkg/sbom/core"
@@ -40,6 +42,25 @@ func (v *OpenVEX) NotAffected(vuln types.DetectedVulnerability, product, subComp
 	return types.ModifiedFinding{}, false
 }
 
+// Reachability returns whether the vulnerable code is reachable according to the latest statement.
+// Only "not_affected" with the justification that the code is not in the execute path states the reachability,
+// as "affected" and "under_investigation" don't mean the vulnerable code is called.
+func (v *OpenVEX) Reachability(vuln types.DetectedVulnerability, product *core.Component) types.Reachability {
+	stmts := v.Matches(vuln, product, nil)
+	if len(stmts) == 0 {
+		return ""
+	}
+
+	stmt := stmts[len(stmts)-1]
+	switch {
+	case stmt.Status == openvex.StatusAffected, stmt.Status == openvex.StatusUnderInvestigation:
+		return types.ReachabilityUnknown
+	case stmt.Status == openvex.StatusNotAffected && slices.Contains(unreachableJustifications, string(stmt.Justification)):
+		return types.ReachabilityUnreachable
+	}
+	return ""
+}
+
 func (v *OpenVEX) Matches(vuln types.DetectedVulnerability, product, subComponent *core.Component) []openvex.Statement {
 	if product == nil || product.PkgIdentifier.PURL == nil {
 		return nil
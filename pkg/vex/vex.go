@@ -3,6 +3,7 @@ package vex
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"github.com/samber/lo"
 	"golang.org/x/xerrors"
@@ -34,6 +35,9 @@ type Client struct {
 type Options struct {
 	CacheDir string
 	Sources  []Source
+
+	// Reachability records whether the vulnerable code is reachable, as stated by the VEX documents
+	Reachability bool
 }
 
 type SourceType string
@@ -59,6 +63,17 @@ func NewSource(src string) Source {
 
 type NotAffected func(vuln types.DetectedVulnerability, product, subComponent *core.Component) (types.ModifiedFinding, bool)
 
+// reachabilityVEX is implemented by VEX formats which can state whether the vulnerable code is reachable
+type reachabilityVEX interface {
+	Reachability(vuln types.DetectedVulnerability, product *core.Component) types.Reachability
+}
+
+// unreachableJustifications are the justifications of "not_affected" statements meaning the code is not reachable
+var unreachableJustifications = []string{
+	"vulnerable_code_not_in_execute_path", // OpenVEX and CSAF
+	"code_not_reachable",                  // CycloneDX
+}
+
 // Filter determines whether a detected vulnerability should be filtered out based on the provided VEX document.
 // If the VEX document is passed and the vulnerability is either not affected or fixed according to the VEX statement,
 // the vulnerability is filtered out.
@@ -82,6 +97,9 @@ func Filter(ctx context.Context, report *types.Report, opts Options) error {
 			continue
 		}
 		filterVulnerabilities(&report.Results[i], bom, client.NotAffected)
+		if opts.Reachability {
+			setReachability(&report.Results[i], bom, client.Reachability)
+		}
 	}
 	return nil
 }
@@ -134,10 +152,49 @@ func (c *Client) NotAffected(vuln types.DetectedVulnerability, product, subCompo
 	return types.ModifiedFinding{}, false
 }
 
-func filterVulnerabilities(result *types.Result, bom *core.BOM, fn NotAffected) {
-	components := lo.MapEntries(bom.Components(), func(id uuid.UUID, component *core.Component) (string, *core.Component) {
+// Reachability returns the reachability stated by the first VEX document having a statement for the vulnerability.
+// It is empty if no VEX document states it.
+func (c *Client) Reachability(vuln types.DetectedVulnerability, product *core.Component) types.Reachability {
+	for _, v := range c.VEXes {
+		r, ok := v.(reachabilityVEX)
+		if !ok {
+			continue
+		}
+		if reachability := r.Reachability(vuln, product); reachability != "" {
+			return reachability
+		}
+	}
+	return ""
+}
+
+func componentsByUID(bom *core.BOM) map[string]*core.Component {
+	return lo.MapEntries(bom.Components(), func(id uuid.UUID, component *core.Component) (string, *core.Component) {
 		return component.PkgIdentifier.UID, component
 	})
+}
+
+// setReachability fills the reachability of the vulnerabilities from the statements about their packages.
+// The vulnerabilities suppressed as the vulnerable code is not in the execute path are unreachable.
+func setReachability(result *types.Result, bom *core.BOM, fn func(types.DetectedVulnerability, *core.Component) types.Reachability) {
+	components := componentsByUID(bom)
+	for i, vuln := range result.Vulnerabilities {
+		if c, ok := components[vuln.PkgIdentifier.UID]; ok {
+			result.Vulnerabilities[i].Reachability = fn(vuln, c)
+		}
+	}
+
+	for i, m := range result.ModifiedFindings {
+		vuln, ok := m.Finding.(types.DetectedVulnerability)
+		if !ok || m.Status != types.FindingStatusNotAffected || !slices.Contains(unreachableJustifications, m.Statement) {
+			continue
+		}
+		vuln.Reachability = types.ReachabilityUnreachable
+		result.ModifiedFindings[i].Finding = vuln
+	}
+}
+
+func filterVulnerabilities(result *types.Result, bom *core.BOM, fn NotAffected) {
+	components := componentsByUID(bom)
 
 	result.Vulnerabilities = lo.Filter(result.Vulnerabilities, func(vuln types.DetectedVulnerability, _ int) bool {
 		c, ok := components[vuln.PkgIdentifier.UID]
@@ -129,6 +129,18 @@ var (
 		InstalledVersion: springPackage.Version,
 		PkgIdentifier:    springPackage.Identifier,
 	}
+	vuln6 = types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2021-0002",
+		PkgName:          springPackage.Name,
+		InstalledVersion: springPackage.Version,
+		PkgIdentifier:    springPackage.Identifier,
+	}
+	vuln7 = types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2021-0003",
+		PkgName:          springPackage.Name,
+		InstalledVersion: springPackage.Version,
+		PkgIdentifier:    springPackage.Identifier,
+	}
 	vuln3 = types.DetectedVulnerability{
 		VulnerabilityID:  "CVE-2022-3715",
 		PkgName:          bashPackage.Name,
@@ -224,6 +236,45 @@ func TestFilter(t *testing.T) {
 				}),
 			}),
 		},
+		{
+			name: "OpenVEX, reachability",
+			args: args{
+				// - oci:debian?tag=12
+				//     - pkg:maven/org.springframework.boot/spring-boot@2.6.0
+				report: imageReport([]types.Result{
+					springResult(types.Result{
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln1, // filtered by VEX
+							vuln2,
+							vuln6,
+							vuln7, // no statement
+						},
+					}),
+				}),
+				opts: vex.Options{
+					Sources: []vex.Source{
+						{
+							Type:     vex.TypeFile,
+							FilePath: "testdata/openvex-reachability.json",
+						},
+					},
+					Reachability: true,
+				},
+			},
+			want: imageReport([]types.Result{
+				springResult(types.Result{
+					Vulnerabilities: []types.DetectedVulnerability{
+						withReachability(vuln2, types.ReachabilityUnknown),
+						withReachability(vuln6, types.ReachabilityUnknown),
+						vuln7,
+					},
+					ModifiedFindings: []types.ModifiedFinding{
+						modifiedFinding(withReachability(vuln1, types.ReachabilityUnreachable),
+							vulnerableCodeNotInExecutePath, "testdata/openvex-reachability.json"),
+					},
+				}),
+			}),
+		},
 		{
 			name: "OpenVEX, subcomponents, oci image",
 			args: args{
@@ -364,6 +415,56 @@ func TestFilter(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "CycloneDX SBOM with CycloneDX VEX, reachability",
+			args: args{
+				report: &types.Report{
+					ArtifactType: artifact.TypeCycloneDX,
+					BOM: &core.BOM{
+						SerialNumber: "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+						Version:      1,
+					},
+					Results: []types.Result{
+						springResult(types.Result{
+							Vulnerabilities: []types.DetectedVulnerability{
+								vuln1, // filtered by VEX
+								vuln2,
+								vuln6,
+								vuln7, // no statement
+							},
+						}),
+					},
+				},
+				opts: vex.Options{
+					Sources: []vex.Source{
+						{
+							Type:     vex.TypeFile,
+							FilePath: "testdata/cyclonedx-reachability.json",
+						},
+					},
+					Reachability: true,
+				},
+			},
+			want: &types.Report{
+				ArtifactType: artifact.TypeCycloneDX,
+				BOM: &core.BOM{
+					SerialNumber: "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+					Version:      1,
+				},
+				Results: []types.Result{
+					springResult(types.Result{
+						Vulnerabilities: []types.DetectedVulnerability{
+							withReachability(vuln2, types.ReachabilityUnknown),
+							withReachability(vuln6, types.ReachabilityUnknown),
+							vuln7,
+						},
+						ModifiedFindings: []types.ModifiedFinding{
+							modifiedFinding(withReachability(vuln1, types.ReachabilityUnreachable), codeNotReachable, "CycloneDX VEX"),
+						},
+					}),
+				},
+			},
+		},
 		{
 			name: "CycloneDX VEX wrong URN",
 			args: args{
@@ -702,6 +803,11 @@ func modifiedFinding(vuln types.DetectedVulnerability, statement, source string)
 	}
 }
 
+func withReachability(vuln types.DetectedVulnerability, reachability types.Reachability) types.DetectedVulnerability {
+	vuln.Reachability = reachability
+	return vuln
+}
+
 func clonePackage(p ftypes.Package) ftypes.Package {
 	n := p
 	n.DependsOn = []string{}